@@ -2,6 +2,8 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"os"
 	"os/signal"
@@ -10,10 +12,21 @@ import (
 	"time"
 
 	"github.com/rs/zerolog/log"
+	"github.com/ryacub/telos-idea-matrix/internal/analytics"
 	"github.com/ryacub/telos-idea-matrix/internal/api"
 	"github.com/ryacub/telos-idea-matrix/internal/config"
 	"github.com/ryacub/telos-idea-matrix/internal/database"
+	"github.com/ryacub/telos-idea-matrix/internal/llm"
 	"github.com/ryacub/telos-idea-matrix/internal/logging"
+	"github.com/ryacub/telos-idea-matrix/internal/metrics"
+	"github.com/ryacub/telos-idea-matrix/internal/models"
+	"github.com/ryacub/telos-idea-matrix/internal/notify"
+	"github.com/ryacub/telos-idea-matrix/internal/profile"
+	"github.com/ryacub/telos-idea-matrix/internal/reanalyze"
+	"github.com/ryacub/telos-idea-matrix/internal/tasks"
+	"github.com/ryacub/telos-idea-matrix/internal/telos"
+	"github.com/ryacub/telos-idea-matrix/internal/tracing"
+	"go.opentelemetry.io/otel/trace"
 )
 
 func main() {
@@ -23,20 +36,28 @@ func main() {
 }
 
 func run() error {
-	// Initialize logging
-	homeDir, err := os.UserHomeDir()
+	paths := config.ResolvePaths()
+
+	// Load configuration first, since it sets up logging
+	cfg, err := config.Load()
 	if err != nil {
-		homeDir = "/tmp"
+		return fmt.Errorf("failed to load configuration: %w", err)
 	}
-	logDir := filepath.Join(homeDir, ".telos-idea-matrix", "logs")
-	if err := os.MkdirAll(logDir, 0755); err != nil {
-		log.Warn().Err(err).Str("log_dir", logDir).Msg("failed to create log directory")
+
+	// Initialize logging
+	logOutput := cfg.Log.Output
+	if logOutput == "" {
+		logDir := paths.LogDir
+		if err := os.MkdirAll(logDir, 0755); err != nil {
+			log.Warn().Err(err).Str("log_dir", logDir).Msg("failed to create log directory")
+		}
+		logOutput = filepath.Join(logDir, "telos-matrix.log")
 	}
 
 	logCfg := logging.Config{
-		Level:      "info",
-		Format:     "json",
-		OutputPath: filepath.Join(logDir, "telos-matrix.log"),
+		Level:      cfg.Log.Level,
+		Format:     cfg.Log.Format,
+		OutputPath: logOutput,
 		MaxSizeMB:  10,
 		MaxBackups: 7,
 		MaxAgeDays: 7,
@@ -44,20 +65,43 @@ func run() error {
 	logging.NewLogger(logCfg)
 
 	log.Info().Msg("Telos Idea Matrix starting...")
+	log.Info().
+		Str("config_dir", paths.ConfigDir).
+		Str("data_dir", paths.DataDir).
+		Str("log_dir", paths.LogDir).
+		Str("source", paths.Source).
+		Msg("Resolved directories")
 
-	// Load configuration
-	cfg, err := config.Load()
-	if err != nil {
+	// Validated by config.Load
+	if err := models.SetRecommendationThresholds(cfg.Recommendation); err != nil {
 		return fmt.Errorf("failed to load configuration: %w", err)
 	}
 
+	// Export traces over OTLP if enabled; otherwise spans are no-ops
+	var tracer trace.Tracer
+	if cfg.Tracing.Enabled {
+		tracerProvider, shutdown, err := tracing.Setup(context.Background(), "telos-matrix")
+		if err != nil {
+			return fmt.Errorf("failed to set up tracing: %w", err)
+		}
+		defer func() {
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			if err := shutdown(ctx); err != nil {
+				log.Error().Err(err).Msg("failed to flush traces")
+			}
+		}()
+		tracer = tracerProvider.Tracer(llm.TracerName)
+		log.Info().Msg("OpenTelemetry tracing enabled")
+	}
+
 	// Ensure data directory exists
 	if err := config.EnsureDataDir(cfg.Database.Path); err != nil {
 		return fmt.Errorf("failed to create data directory: %w", err)
 	}
 
 	// Initialize database
-	repo, err := database.NewRepository(cfg.Database.Path)
+	repo, err := database.NewRepositoryWithConfig(cfg.Database)
 	if err != nil {
 		return fmt.Errorf("failed to initialize database: %w", err)
 	}
@@ -89,6 +133,36 @@ func run() error {
 	if err != nil {
 		return fmt.Errorf("failed to create server: %w", err)
 	}
+
+	bounds, err := profile.LoadScoreBounds(config.ResolvePaths().ScoreBoundsFile())
+	if err != nil {
+		return fmt.Errorf("failed to load score bounds: %w", err)
+	}
+	server.SetScoreBounds(bounds)
+	server.SetIdempotencyTTL(cfg.Server.IdempotencyTTL)
+	server.SetAnalysisLimit(cfg.Server.MaxConcurrentAnalyses, cfg.Server.AnalysisQueueTimeout)
+
+	// Enable AI analysis for ideas created with "use_ai"
+	llmConfig := llm.DefaultManagerConfig()
+	llmConfig.DefaultProvider = cfg.LLM.DefaultProvider
+	llmConfig.HealthCheckTimeout = cfg.LLM.HealthCheckTimeout
+	llmConfig.ProviderConfig.SystemPrompts = cfg.LLM.SystemPrompts
+	llmConfig.Tracer = tracer
+	llmManager := llm.NewManager(llmConfig)
+	if err := llmManager.LoadPromptTemplates(config.ResolvePaths().PromptsDir()); err != nil {
+		log.Warn().Err(err).Msg("Invalid prompt templates ignored, using the built-in prompt")
+	}
+	if providers := llmManager.PromptTemplateProviders(); len(providers) > 0 {
+		log.Info().Strs("providers", providers).Msg("Loaded prompt templates")
+	}
+	server.SetLLMManager(llmManager)
+	exportMetrics(repo, llmManager)
+
+	// Send digests of high-scoring ideas to a webhook, if configured
+	if notifier := notify.FromConfig(cfg.Notify); notifier != nil {
+		server.SetNotifier(notifier)
+		log.Info().Dur("batch_window", cfg.Notify.BatchWindow).Msg("Webhook notifications enabled")
+	}
 	defer func() {
 		if err := server.Close(); err != nil {
 			log.Error().Err(err).Msg("failed to close server")
@@ -99,9 +173,18 @@ func run() error {
 	done := make(chan os.Signal, 1)
 	signal.Notify(done, os.Interrupt, syscall.SIGINT, syscall.SIGTERM)
 
-	// Spawn background tasks with simple tickers
+	// Spawn background tasks
 	stopTasks := make(chan struct{})
-	setupBackgroundTasks(repo, stopTasks)
+	taskManager, err := setupBackgroundTasks(repo)
+	if err != nil {
+		return fmt.Errorf("failed to start background tasks: %w", err)
+	}
+	server.SetTaskManager(taskManager)
+	if cfg.Reanalyze.OnTelosChange {
+		startReanalysisTask(cfg, repo, llmManager, stopTasks)
+	}
+	// Keep provider availability reported by /health current
+	go llmManager.StartPeriodicHealthCheck(stopTasks)
 
 	// Start server in goroutine
 	go func() {
@@ -124,74 +207,204 @@ func run() error {
 
 	// Stop background tasks
 	close(stopTasks)
+	taskManager.Shutdown()
 
 	return nil
 }
 
-// setupBackgroundTasks initializes and spawns background tasks using simple tickers
-func setupBackgroundTasks(repo *database.Repository, stop <-chan struct{}) {
-	// Database cleanup task - runs once per day
-	go func() {
-		ticker := time.NewTicker(24 * time.Hour)
-		defer ticker.Stop()
+// Cron specs for the background tasks, in the server's local time
+const (
+	databaseCleanupSpec   = "0 3 * * *"   // daily at 3am
+	metricsCollectionSpec = "*/5 * * * *" // every 5 minutes
+	analyticsRefreshSpec  = "* * * * *"   // every minute
+)
 
-		log.Info().Msg("Started database cleanup task (runs every 24 hours)")
+// setupBackgroundTasks spawns the server's maintenance tasks. Shut the
+// returned manager down to stop them.
+func setupBackgroundTasks(repo *database.Repository) (*tasks.TaskManager, error) {
+	cleanup, err := tasks.NewCronTask("database cleanup", databaseCleanupSpec, func(context.Context) error {
+		log.Info().Msg("Running database vacuum")
+		if _, err := repo.DB().Exec("VACUUM"); err != nil {
+			return fmt.Errorf("database vacuum failed: %w", err)
+		}
+		log.Info().Msg("Database vacuum completed")
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
 
-		for {
-			select {
-			case <-ticker.C:
-				log.Info().Msg("Running database vacuum")
-				if _, err := repo.DB().Exec("VACUUM"); err != nil {
-					log.Warn().Err(err).Msg("Database vacuum failed")
-				} else {
-					log.Info().Msg("Database vacuum completed")
-				}
-			case <-stop:
-				log.Info().Msg("Stopping database cleanup task")
-				return
-			}
+	collectMetrics := func(context.Context) error {
+		stats := repo.DB().Stats()
+		log.Debug().
+			Int("open_connections", stats.OpenConnections).
+			Int("in_use", stats.InUse).
+			Msg("Database connection stats")
+		return updateIdeaGauges(repo)
+	}
+	metricsCollection, err := tasks.NewCronTask("metrics collection", metricsCollectionSpec, collectMetrics)
+	if err != nil {
+		return nil, err
+	}
+	// Don't leave the gauges empty until the first scheduled run
+	if err := collectMetrics(context.Background()); err != nil {
+		log.Warn().Err(err).Msg("Initial metrics collection failed")
+	}
+
+	service := analytics.NewService(repo)
+	analyticsRefresh, err := tasks.NewCronTask("analytics summary refresh", analyticsRefreshSpec, func(context.Context) error {
+		refreshed, err := service.RefreshSummaryIfStale()
+		if err != nil {
+			return fmt.Errorf("analytics summary refresh failed: %w", err)
 		}
+		if refreshed {
+			log.Debug().Msg("Analytics summary refreshed")
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Finer-grained than a cron spec can express. A blip is retried; the task
+	// turns unhealthy after about two minutes of failures.
+	healthCheck := tasks.NewScheduledTask("health check", 30*time.Second, func(context.Context) error {
+		if err := repo.Ping(); err != nil {
+			return fmt.Errorf("database health check failed: %w", err)
+		}
+		return nil
+	}, tasks.WithRetry(2, time.Second), tasks.WithMaxConsecutiveFailures(3))
+
+	manager := tasks.NewTaskManager()
+	for _, task := range []*tasks.Task{cleanup, metricsCollection, analyticsRefresh, healthCheck} {
+		manager.Spawn(task)
+	}
+	return manager, nil
+}
+
+// startReanalysisTask re-analyzes ideas scored against an older telos in the
+// background, paced and budgeted by cfg.Reanalyze. Progress is kept in bulk
+// jobs, so a paused run picks up where it stopped, even after a restart.
+func startReanalysisTask(cfg *config.Config, repo *database.Repository, manager *llm.Manager, stop <-chan struct{}) {
+	runner := reanalyze.NewRunner(repo, reanalyze.Options{
+		MaxPerMinute: cfg.Reanalyze.MaxPerMinute,
+		BudgetUSD:    cfg.Reanalyze.BudgetUSD,
+		MaxAttempts:  cfg.Reanalyze.MaxAttempts,
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-stop
+		cancel()
 	}()
 
-	// Metrics collection task - runs every 5 minutes
 	go func() {
-		ticker := time.NewTicker(5 * time.Minute)
+		ticker := time.NewTicker(1 * time.Minute)
 		defer ticker.Stop()
 
-		log.Info().Msg("Started metrics collection task (runs every 5 minutes)")
+		log.Info().
+			Int("max_per_minute", cfg.Reanalyze.MaxPerMinute).
+			Float64("budget_usd", cfg.Reanalyze.BudgetUSD).
+			Msg("Started telos re-analysis task (checks every minute)")
 
 		for {
+			reanalyzeStaleIdeas(ctx, cfg, repo, manager, runner)
+
 			select {
 			case <-ticker.C:
-				stats := repo.DB().Stats()
-				log.Debug().
-					Int("open_connections", stats.OpenConnections).
-					Int("in_use", stats.InUse).
-					Msg("Database connection stats")
-			case <-stop:
-				log.Info().Msg("Stopping metrics collection task")
+			case <-ctx.Done():
+				log.Info().Msg("Stopping telos re-analysis task")
 				return
 			}
 		}
 	}()
+}
 
-	// Health check task - runs every 30 seconds
-	go func() {
-		ticker := time.NewTicker(30 * time.Second)
-		defer ticker.Stop()
+// reanalyzeStaleIdeas queues ideas scored against an older telos, unless a
+// re-analysis job is already under way, and works through pending jobs
+func reanalyzeStaleIdeas(ctx context.Context, cfg *config.Config, repo *database.Repository, manager *llm.Manager, runner *reanalyze.Runner) {
+	version, err := telos.Version(cfg.Telos.FilePath)
+	if err != nil {
+		log.Warn().Err(err).Msg("Telos re-analysis skipped")
+		return
+	}
 
-		log.Info().Msg("Started health check task (runs every 30 seconds)")
+	pending, err := runner.HasPending()
+	if err != nil {
+		log.Warn().Err(err).Msg("Telos re-analysis skipped")
+		return
+	}
+	if !pending {
+		stale, err := reanalyze.StaleIdeas(repo, cfg.Telos.Profile, version)
+		if err != nil {
+			log.Warn().Err(err).Msg("Telos re-analysis skipped")
+			return
+		}
+		job, err := runner.Enqueue(stale, version)
+		if err != nil {
+			log.Warn().Err(err).Msg("Telos re-analysis skipped")
+			return
+		}
+		if job == nil {
+			return
+		}
+		log.Info().Str("job_id", job.ID).Int("ideas", job.Total).Str("telos_version", version).
+			Msg("Queued ideas for re-analysis against the current telos")
+	}
 
-		for {
-			select {
-			case <-ticker.C:
-				if err := repo.Ping(); err != nil {
-					log.Error().Err(err).Msg("Database health check failed")
-				}
-			case <-stop:
-				log.Info().Msg("Stopping health check task")
-				return
-			}
+	telosData, err := telos.NewParser().ParseFile(cfg.Telos.FilePath)
+	if err != nil {
+		log.Warn().Err(err).Msg("Telos re-analysis skipped")
+		return
+	}
+
+	bounds, err := profile.LoadScoreBounds(config.ResolvePaths().ScoreBoundsFile())
+	if err != nil {
+		log.Warn().Err(err).Msg("Telos re-analysis skipped")
+		return
+	}
+
+	err = runner.RunPending(ctx, reanalyze.LLMAnalyzer(repo, manager, telosData, version, bounds))
+	switch {
+	case err == nil:
+		log.Info().Float64("spent_usd", runner.SpentUSD()).Msg("Telos re-analysis complete")
+	case errors.Is(err, reanalyze.ErrBudgetExhausted):
+		log.Debug().Float64("spent_usd", runner.SpentUSD()).Msg("Re-analysis budget spent; paused until tomorrow (UTC)")
+	case ctx.Err() != nil:
+		// Shutting down; the job resumes on the next start
+	default:
+		log.Warn().Err(err).Msg("Telos re-analysis incomplete; retrying in a minute")
+	}
+}
+
+// exportMetrics registers the database pool and the LLM providers' request
+// counts with the Prometheus exporter served at /metrics
+func exportMetrics(repo *database.Repository, manager *llm.Manager) {
+	metrics.ExportDatabaseStats(repo.DB())
+	metrics.ExportProviderStats(func() []metrics.ProviderRequestStats {
+		stats := manager.GetStats()
+		out := make([]metrics.ProviderRequestStats, 0, len(stats))
+		for _, stat := range stats {
+			out = append(out, metrics.ProviderRequestStats{
+				Provider:  stat.Name,
+				Successes: stat.SuccessCount,
+				Failures:  stat.FailureCount,
+			})
 		}
-	}()
+		return out
+	})
+}
+
+// updateIdeaGauges sets the ideas gauge to the current count per status
+func updateIdeaGauges(repo *database.Repository) error {
+	counts := make(map[string]int)
+	for _, status := range []models.IdeaStatus{models.StatusActive, models.StatusArchived, models.StatusDeleted} {
+		count, err := repo.Count(database.ListOptions{Status: string(status)})
+		if err != nil {
+			return fmt.Errorf("failed to count %s ideas: %w", status, err)
+		}
+		counts[string(status)] = count
+	}
+	metrics.SetIdeaCounts(counts)
+	return nil
 }
@@ -0,0 +1,92 @@
+package analytics
+
+import (
+	"sort"
+
+	"github.com/ryacub/telos-idea-matrix/internal/models"
+)
+
+// ScoreBuckets lists the score distribution buckets from lowest to highest
+var ScoreBuckets = []string{"0-2", "2-4", "4-6", "6-8", "8-10"}
+
+// ProfileOverview holds the overview metrics of one telos profile's ideas
+type ProfileOverview struct {
+	Profile string          `json:"profile"`
+	Metrics OverviewMetrics `json:"metrics"`
+	// Distribution is the percentage of the profile's ideas in each of
+	// ScoreBuckets; all zero when the profile has no ideas
+	Distribution map[string]float64 `json:"distribution"`
+}
+
+// OverviewDelta is the difference To minus From in each compared metric.
+// Distribution deltas are in percentage points.
+type OverviewDelta struct {
+	From         string             `json:"from"`
+	To           string             `json:"to"`
+	TotalIdeas   int                `json:"total_ideas"`
+	AverageScore float64            `json:"average_score"`
+	MedianScore  float64            `json:"median_score"`
+	HighestScore float64            `json:"highest_score"`
+	LowestScore  float64            `json:"lowest_score"`
+	Distribution map[string]float64 `json:"distribution"`
+}
+
+// ComparisonReport compares the overview metrics of several telos profiles
+type ComparisonReport struct {
+	Profiles []ProfileOverview `json:"profiles"` // Sorted by profile name
+	// Delta compares the last profile with the first; nil with fewer than
+	// two profiles
+	Delta *OverviewDelta `json:"delta,omitempty"`
+}
+
+// CompareProfiles computes overview metrics and the score distribution for
+// each profile's ideas, keyed by profile name, and the delta between the
+// last and first profile by name.
+func CompareProfiles(ideasByProfile map[string][]*models.Idea) ComparisonReport {
+	names := make([]string, 0, len(ideasByProfile))
+	for name := range ideasByProfile {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	service := NewService(nil)
+	report := ComparisonReport{Profiles: make([]ProfileOverview, 0, len(names))}
+	for _, name := range names {
+		ideas := ideasByProfile[name]
+		buckets := service.CalculateScoreDistribution(ideas).Buckets
+		distribution := make(map[string]float64, len(ScoreBuckets))
+		for _, bucket := range ScoreBuckets {
+			if len(ideas) > 0 {
+				distribution[bucket] = float64(buckets[bucket]) / float64(len(ideas)) * 100
+			} else {
+				distribution[bucket] = 0
+			}
+		}
+		report.Profiles = append(report.Profiles, ProfileOverview{
+			Profile:      name,
+			Metrics:      service.CalculateOverviewMetrics(ideas),
+			Distribution: distribution,
+		})
+	}
+
+	if len(report.Profiles) >= 2 {
+		from := report.Profiles[0]
+		to := report.Profiles[len(report.Profiles)-1]
+		delta := &OverviewDelta{
+			From:         from.Profile,
+			To:           to.Profile,
+			TotalIdeas:   to.Metrics.TotalIdeas - from.Metrics.TotalIdeas,
+			AverageScore: to.Metrics.AverageScore - from.Metrics.AverageScore,
+			MedianScore:  to.Metrics.MedianScore - from.Metrics.MedianScore,
+			HighestScore: to.Metrics.HighestScore - from.Metrics.HighestScore,
+			LowestScore:  to.Metrics.LowestScore - from.Metrics.LowestScore,
+			Distribution: make(map[string]float64, len(ScoreBuckets)),
+		}
+		for _, bucket := range ScoreBuckets {
+			delta.Distribution[bucket] = to.Distribution[bucket] - from.Distribution[bucket]
+		}
+		report.Delta = delta
+	}
+
+	return report
+}
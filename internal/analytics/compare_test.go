@@ -0,0 +1,59 @@
+package analytics
+
+import (
+	"testing"
+
+	"github.com/ryacub/telos-idea-matrix/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompareProfiles_SideBySideWithDelta(t *testing.T) {
+	report := CompareProfiles(map[string][]*models.Idea{
+		"work": {
+			{ID: "1", FinalScore: 8.0},
+			{ID: "2", FinalScore: 9.0},
+		},
+		"personal": {
+			{ID: "3", FinalScore: 3.0},
+			{ID: "4", FinalScore: 5.0},
+			{ID: "5", FinalScore: 7.0},
+			{ID: "6", FinalScore: 1.0},
+		},
+	})
+
+	require.Len(t, report.Profiles, 2)
+	assert.Equal(t, "personal", report.Profiles[0].Profile, "profiles are sorted by name")
+	assert.Equal(t, 4, report.Profiles[0].Metrics.TotalIdeas)
+	assert.InDelta(t, 4.0, report.Profiles[0].Metrics.AverageScore, 0.001)
+	assert.InDelta(t, 25.0, report.Profiles[0].Distribution["0-2"], 0.001)
+	assert.InDelta(t, 100.0, report.Profiles[1].Distribution["8-10"], 0.001)
+
+	require.NotNil(t, report.Delta)
+	assert.Equal(t, "personal", report.Delta.From)
+	assert.Equal(t, "work", report.Delta.To)
+	assert.Equal(t, -2, report.Delta.TotalIdeas)
+	assert.InDelta(t, 4.5, report.Delta.AverageScore, 0.001)
+	assert.InDelta(t, -25.0, report.Delta.Distribution["0-2"], 0.001)
+}
+
+func TestCompareProfiles_EmptyProfile(t *testing.T) {
+	report := CompareProfiles(map[string][]*models.Idea{
+		"side":    nil,
+		"default": {{ID: "1", FinalScore: 6.5}},
+	})
+
+	require.Len(t, report.Profiles, 2)
+	assert.Equal(t, OverviewMetrics{}, report.Profiles[1].Metrics)
+	for _, bucket := range ScoreBuckets {
+		assert.Contains(t, report.Profiles[1].Distribution, bucket)
+		assert.Zero(t, report.Profiles[1].Distribution[bucket])
+	}
+	assert.Equal(t, -1, report.Delta.TotalIdeas)
+}
+
+func TestCompareProfiles_SingleProfileHasNoDelta(t *testing.T) {
+	report := CompareProfiles(map[string][]*models.Idea{"default": {{ID: "1", FinalScore: 6.5}}})
+	assert.Len(t, report.Profiles, 1)
+	assert.Nil(t, report.Delta)
+}
@@ -0,0 +1,234 @@
+package analytics
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/ryacub/telos-idea-matrix/internal/models"
+	"github.com/ryacub/telos-idea-matrix/internal/telos"
+	"gopkg.in/yaml.v3"
+)
+
+// ConflictKind identifies why ideas were flagged
+type ConflictKind string
+
+const (
+	// ConflictTelos marks an idea that runs into a telos failure pattern or challenge
+	ConflictTelos ConflictKind = "telos"
+	// ConflictExclusive marks two ideas that a conflict rule says can't both be pursued
+	ConflictExclusive ConflictKind = "exclusive"
+)
+
+// ConflictRule declares two kinds of ideas that are mutually exclusive.
+// Two ideas conflict when one contains any of Keywords and the other contains
+// any of ConflictsWith. Matching is case-insensitive.
+type ConflictRule struct {
+	Name          string   `yaml:"name"`
+	Description   string   `yaml:"description,omitempty"`
+	Keywords      []string `yaml:"keywords"`
+	ConflictsWith []string `yaml:"conflicts_with"`
+}
+
+// conflictRulesFile is the on-disk layout of a conflict rules file
+type conflictRulesFile struct {
+	Conflicts []ConflictRule `yaml:"conflicts"`
+}
+
+// ConflictIdea identifies an idea involved in a conflict
+type ConflictIdea struct {
+	ID      string  `json:"id"`
+	Content string  `json:"content"`
+	Score   float64 `json:"score"`
+}
+
+// Conflict is an idea that conflicts with the telos, or a pair of ideas that
+// conflict with each other
+type Conflict struct {
+	Kind     ConflictKind   `json:"kind"`
+	Source   string         `json:"source"` // Failure pattern, challenge, or rule name
+	Reason   string         `json:"reason"`
+	Keywords []string       `json:"keywords"` // Keywords that matched
+	Ideas    []ConflictIdea `json:"ideas"`
+}
+
+// telosKeywordSet is a failure pattern or challenge reduced to what an idea can match
+type telosKeywordSet struct {
+	source   string
+	reason   string
+	phrase   string // Matches on its own when it appears verbatim
+	keywords []string
+}
+
+// LoadConflictRules reads and validates conflict rules from a YAML file.
+//
+// Example:
+//
+//	conflicts:
+//	  - name: Location
+//	    description: Can't both relocate and put down roots
+//	    keywords: [move abroad, relocate]
+//	    conflicts_with: [buy a house, mortgage]
+func LoadConflictRules(path string) ([]ConflictRule, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read conflicts file: %w", err)
+	}
+
+	var file conflictRulesFile
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse conflicts file: %w", err)
+	}
+
+	for i := range file.Conflicts {
+		rule := &file.Conflicts[i]
+		rule.Name = strings.TrimSpace(rule.Name)
+		if rule.Name == "" {
+			return nil, fmt.Errorf("conflicts[%d]: name is required", i)
+		}
+		if len(rule.Keywords) == 0 || len(rule.ConflictsWith) == 0 {
+			return nil, fmt.Errorf("conflicts[%d]: rule %q needs both keywords and conflicts_with", i, rule.Name)
+		}
+	}
+
+	return file.Conflicts, nil
+}
+
+// DetectConflicts flags ideas that match a telos failure pattern or challenge,
+// and pairs of ideas that a rule marks as mutually exclusive.
+// Telos conflicts come first, followed by rule conflicts; t may be nil.
+func DetectConflicts(ideas []*models.Idea, t *models.Telos, rules []ConflictRule) []Conflict {
+	normalized := make([]string, len(ideas))
+	for i, idea := range ideas {
+		normalized[i] = normalizeConflictText(idea.Content)
+	}
+
+	var conflicts []Conflict
+
+	sets := telosKeywordSets(t)
+	for i, idea := range ideas {
+		for _, set := range sets {
+			matched := set.match(normalized[i])
+			if matched == nil {
+				continue
+			}
+			conflicts = append(conflicts, Conflict{
+				Kind:     ConflictTelos,
+				Source:   set.source,
+				Reason:   set.reason,
+				Keywords: matched,
+				Ideas:    []ConflictIdea{conflictIdea(idea)},
+			})
+		}
+	}
+
+	for _, rule := range rules {
+		seen := make(map[string]bool)
+		for i, left := range ideas {
+			leftMatched := matchAny(normalized[i], rule.Keywords)
+			if len(leftMatched) == 0 {
+				continue
+			}
+			for j, right := range ideas {
+				if i == j {
+					continue
+				}
+				rightMatched := matchAny(normalized[j], rule.ConflictsWith)
+				if len(rightMatched) == 0 {
+					continue
+				}
+
+				// An idea pair matching both ways is reported once
+				key := left.ID + "|" + right.ID
+				if left.ID > right.ID {
+					key = right.ID + "|" + left.ID
+				}
+				if seen[key] {
+					continue
+				}
+				seen[key] = true
+
+				reason := rule.Description
+				if reason == "" {
+					reason = "Ideas are mutually exclusive"
+				}
+				conflicts = append(conflicts, Conflict{
+					Kind:     ConflictExclusive,
+					Source:   rule.Name,
+					Reason:   reason,
+					Keywords: append(append([]string{}, leftMatched...), rightMatched...),
+					Ideas:    []ConflictIdea{conflictIdea(left), conflictIdea(right)},
+				})
+			}
+		}
+	}
+
+	return conflicts
+}
+
+// telosKeywordSets collects the failure patterns and challenges of a telos
+func telosKeywordSets(t *models.Telos) []telosKeywordSet {
+	if t == nil {
+		return nil
+	}
+
+	sets := make([]telosKeywordSet, 0, len(t.FailurePatterns)+len(t.Challenges))
+	for _, pattern := range t.FailurePatterns {
+		sets = append(sets, telosKeywordSet{
+			source:   pattern.Name,
+			reason:   pattern.Description,
+			phrase:   normalizeConflictText(pattern.Name),
+			keywords: pattern.Keywords,
+		})
+	}
+	for _, challenge := range t.Challenges {
+		sets = append(sets, telosKeywordSet{
+			source:   challenge.ID,
+			reason:   challenge.Description,
+			keywords: telos.ExtractKeywords(normalizeConflictText(challenge.Description)),
+		})
+	}
+	return sets
+}
+
+// match returns the keywords found in text, or nil if too few matched.
+// Like failure pattern detection, sets of more than three keywords need
+// two matches so that a single common word doesn't flag an idea.
+func (s telosKeywordSet) match(text string) []string {
+	if s.phrase != "" && strings.Contains(text, s.phrase) {
+		return []string{s.phrase}
+	}
+
+	matched := matchAny(text, s.keywords)
+	threshold := 2
+	if len(s.keywords) <= 3 {
+		threshold = 1
+	}
+	if len(matched) < threshold {
+		return nil
+	}
+	return matched
+}
+
+// matchAny returns the keywords that appear in text
+func matchAny(text string, keywords []string) []string {
+	var matched []string
+	for _, keyword := range keywords {
+		keyword = normalizeConflictText(keyword)
+		if keyword != "" && strings.Contains(text, keyword) {
+			matched = append(matched, keyword)
+		}
+	}
+	return matched
+}
+
+// normalizeConflictText lowercases text and treats hyphens and underscores as
+// spaces, so "context-switching" matches "context switching"
+func normalizeConflictText(text string) string {
+	text = strings.NewReplacer("-", " ", "_", " ").Replace(strings.ToLower(text))
+	return strings.Join(strings.Fields(text), " ")
+}
+
+func conflictIdea(idea *models.Idea) ConflictIdea {
+	return ConflictIdea{ID: idea.ID, Content: idea.Content, Score: idea.FinalScore}
+}
@@ -0,0 +1,104 @@
+package analytics
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ryacub/telos-idea-matrix/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func conflictTelos() *models.Telos {
+	return &models.Telos{
+		Challenges: []models.Challenge{
+			{ID: "C1", Description: "Context switching between too many projects"},
+		},
+		FailurePatterns: []models.Pattern{
+			{Name: "Perfectionism", Description: "Over-engineering before validating", Keywords: []string{"over-engineering", "polish"}},
+		},
+	}
+}
+
+func TestDetectConflicts_FlagsChallengeKeywords(t *testing.T) {
+	ideas := []*models.Idea{
+		{ID: "1", Content: "Start three side projects that increase context-switching", FinalScore: 4.0},
+		{ID: "2", Content: "Ship the CLI release this week", FinalScore: 8.0},
+	}
+
+	conflicts := DetectConflicts(ideas, conflictTelos(), nil)
+
+	require.Len(t, conflicts, 1)
+	assert.Equal(t, ConflictTelos, conflicts[0].Kind)
+	assert.Equal(t, "C1", conflicts[0].Source)
+	assert.Equal(t, []string{"context", "switching", "projects"}, conflicts[0].Keywords)
+	assert.Equal(t, []ConflictIdea{{ID: "1", Content: ideas[0].Content, Score: 4.0}}, conflicts[0].Ideas)
+}
+
+func TestDetectConflicts_FlagsFailurePatterns(t *testing.T) {
+	ideas := []*models.Idea{
+		{ID: "1", Content: "Polish the settings page before anyone uses it"},
+		{ID: "2", Content: "Fight my PERFECTIONISM"},
+	}
+
+	conflicts := DetectConflicts(ideas, conflictTelos(), nil)
+
+	require.Len(t, conflicts, 2)
+	assert.Equal(t, "Perfectionism", conflicts[0].Source)
+	assert.Equal(t, []string{"polish"}, conflicts[0].Keywords)
+	assert.Equal(t, []string{"perfectionism"}, conflicts[1].Keywords)
+}
+
+func TestDetectConflicts_SingleCommonWordIsNotEnough(t *testing.T) {
+	ideas := []*models.Idea{{ID: "1", Content: "Finish one of my projects"}}
+
+	assert.Empty(t, DetectConflicts(ideas, conflictTelos(), nil))
+}
+
+func TestDetectConflicts_ExclusiveRules(t *testing.T) {
+	ideas := []*models.Idea{
+		{ID: "a", Content: "Relocate to Lisbon next year"},
+		{ID: "b", Content: "Buy a house near my parents"},
+		{ID: "c", Content: "Learn to sail"},
+		{ID: "d", Content: "Relocate, then buy a house there"},
+	}
+	rules := []ConflictRule{{
+		Name:          "Location",
+		Keywords:      []string{"relocate"},
+		ConflictsWith: []string{"buy a house"},
+	}}
+
+	conflicts := DetectConflicts(ideas, nil, rules)
+
+	var pairs [][2]string
+	for _, c := range conflicts {
+		assert.Equal(t, ConflictExclusive, c.Kind)
+		assert.Equal(t, "Ideas are mutually exclusive", c.Reason)
+		pairs = append(pairs, [2]string{c.Ideas[0].ID, c.Ideas[1].ID})
+	}
+	assert.Equal(t, [][2]string{{"a", "b"}, {"a", "d"}, {"d", "b"}}, pairs)
+	assert.Equal(t, []string{"relocate", "buy a house"}, conflicts[0].Keywords)
+}
+
+func TestLoadConflictRules(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "conflicts.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`
+conflicts:
+  - name: " Location "
+    description: Can't both relocate and put down roots
+    keywords: [relocate]
+    conflicts_with: [buy a house]
+`), 0644))
+
+	rules, err := LoadConflictRules(path)
+	require.NoError(t, err)
+	require.Len(t, rules, 1)
+	assert.Equal(t, "Location", rules[0].Name)
+	assert.Equal(t, []string{"buy a house"}, rules[0].ConflictsWith)
+
+	require.NoError(t, os.WriteFile(path, []byte("conflicts:\n  - name: Half\n    keywords: [relocate]\n"), 0644))
+	_, err = LoadConflictRules(path)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "needs both keywords and conflicts_with")
+}
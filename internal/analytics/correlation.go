@@ -0,0 +1,120 @@
+package analytics
+
+import (
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/ryacub/telos-idea-matrix/internal/models"
+)
+
+// PatternCorrelation describes how strongly a pattern is associated with idea scores
+type PatternCorrelation struct {
+	Pattern      string  `json:"pattern"`
+	WithCount    int     `json:"with_count"`    // Ideas that have the pattern
+	WithoutCount int     `json:"without_count"` // Ideas that do not have the pattern
+	MeanWith     float64 `json:"mean_with"`     // Mean final score of ideas with the pattern
+	MeanWithout  float64 `json:"mean_without"`  // Mean final score of ideas without the pattern
+	Correlation  float64 `json:"correlation"`   // Point-biserial correlation coefficient (-1 to 1)
+}
+
+// CalculatePatternScoreCorrelation computes, for every pattern seen across ideas,
+// the mean score of ideas with and without it and the point-biserial correlation
+// between having the pattern and the final score.
+//
+// Patterns are matched by name, so stored values such as "Perfectionism: description"
+// are grouped under "Perfectionism". Results are ordered from the most negative to the
+// most positive correlation. A pattern present in every idea (or a dataset where all
+// scores are identical) has a correlation of 0.
+func CalculatePatternScoreCorrelation(ideas []*models.Idea) []PatternCorrelation {
+	if len(ideas) == 0 {
+		return []PatternCorrelation{}
+	}
+
+	// Collect the distinct pattern names for each idea
+	ideaPatterns := make([]map[string]bool, len(ideas))
+	names := make(map[string]string) // lowercased key -> display name
+	for i, idea := range ideas {
+		ideaPatterns[i] = make(map[string]bool)
+		for _, raw := range idea.Patterns {
+			name := patternName(raw)
+			if name == "" {
+				continue
+			}
+			key := strings.ToLower(name)
+			if _, ok := names[key]; !ok {
+				names[key] = name
+			}
+			ideaPatterns[i][key] = true
+		}
+	}
+
+	// Population mean and standard deviation of all scores
+	n := float64(len(ideas))
+	mean := 0.0
+	for _, idea := range ideas {
+		mean += idea.FinalScore
+	}
+	mean /= n
+
+	variance := 0.0
+	for _, idea := range ideas {
+		diff := idea.FinalScore - mean
+		variance += diff * diff
+	}
+	stdDev := math.Sqrt(variance / n)
+
+	results := make([]PatternCorrelation, 0, len(names))
+	for key, name := range names {
+		var sumWith, sumWithout float64
+		var withCount, withoutCount int
+
+		for i, idea := range ideas {
+			if ideaPatterns[i][key] {
+				sumWith += idea.FinalScore
+				withCount++
+			} else {
+				sumWithout += idea.FinalScore
+				withoutCount++
+			}
+		}
+
+		pc := PatternCorrelation{
+			Pattern:      name,
+			WithCount:    withCount,
+			WithoutCount: withoutCount,
+		}
+		if withCount > 0 {
+			pc.MeanWith = sumWith / float64(withCount)
+		}
+		if withoutCount > 0 {
+			pc.MeanWithout = sumWithout / float64(withoutCount)
+		}
+
+		if withCount > 0 && withoutCount > 0 && stdDev > 0 {
+			p := float64(withCount) / n
+			q := float64(withoutCount) / n
+			pc.Correlation = (pc.MeanWith - pc.MeanWithout) / stdDev * math.Sqrt(p*q)
+		}
+
+		results = append(results, pc)
+	}
+
+	// Sort by correlation (ascending), then by name for consistency
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Correlation == results[j].Correlation {
+			return results[i].Pattern < results[j].Pattern
+		}
+		return results[i].Correlation < results[j].Correlation
+	})
+
+	return results
+}
+
+// patternName extracts the pattern name from a stored "Name: description" value
+func patternName(raw string) string {
+	if idx := strings.Index(raw, ":"); idx >= 0 {
+		raw = raw[:idx]
+	}
+	return strings.TrimSpace(raw)
+}
@@ -0,0 +1,99 @@
+package analytics
+
+import (
+	"testing"
+
+	"github.com/ryacub/telos-idea-matrix/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func ideaWithPatterns(score float64, patterns ...string) *models.Idea {
+	return &models.Idea{FinalScore: score, Patterns: patterns}
+}
+
+// TestCorrelation_KnownValues checks the coefficient against a hand-computed dataset
+func TestCorrelation_KnownValues(t *testing.T) {
+	// Scores 2,4,6,8: mean 5, population stddev sqrt(5)
+	// "Scope creep" on the two lowest: (3 - 7) / sqrt(5) * 0.5 = -0.894
+	ideas := []*models.Idea{
+		ideaWithPatterns(2, "Scope creep: too big"),
+		ideaWithPatterns(4, "Scope creep: too big"),
+		ideaWithPatterns(6, "Quick win"),
+		ideaWithPatterns(8, "Quick win"),
+	}
+
+	result := CalculatePatternScoreCorrelation(ideas)
+	require.Len(t, result, 2)
+
+	assert.Equal(t, "Scope creep", result[0].Pattern)
+	assert.Equal(t, 2, result[0].WithCount)
+	assert.Equal(t, 2, result[0].WithoutCount)
+	assert.InDelta(t, 3.0, result[0].MeanWith, 0.0001)
+	assert.InDelta(t, 7.0, result[0].MeanWithout, 0.0001)
+	assert.InDelta(t, -0.8944, result[0].Correlation, 0.0001)
+
+	assert.Equal(t, "Quick win", result[1].Pattern)
+	assert.InDelta(t, 0.8944, result[1].Correlation, 0.0001)
+}
+
+// TestCorrelation_PerfectSeparation verifies a pattern that fully splits scores gives r = 1
+func TestCorrelation_PerfectSeparation(t *testing.T) {
+	ideas := []*models.Idea{
+		ideaWithPatterns(2),
+		ideaWithPatterns(2),
+		ideaWithPatterns(8, "Focus"),
+		ideaWithPatterns(8, "Focus"),
+	}
+
+	result := CalculatePatternScoreCorrelation(ideas)
+	require.Len(t, result, 1)
+	assert.InDelta(t, 1.0, result[0].Correlation, 0.0001)
+}
+
+// TestCorrelation_Degenerate verifies zero correlation when it cannot be computed
+func TestCorrelation_Degenerate(t *testing.T) {
+	t.Run("pattern on every idea", func(t *testing.T) {
+		ideas := []*models.Idea{
+			ideaWithPatterns(3, "Everywhere"),
+			ideaWithPatterns(9, "Everywhere"),
+		}
+
+		result := CalculatePatternScoreCorrelation(ideas)
+		require.Len(t, result, 1)
+		assert.Equal(t, 0.0, result[0].Correlation)
+		assert.Equal(t, 0, result[0].WithoutCount)
+		assert.InDelta(t, 6.0, result[0].MeanWith, 0.0001)
+	})
+
+	t.Run("identical scores", func(t *testing.T) {
+		ideas := []*models.Idea{
+			ideaWithPatterns(5, "A"),
+			ideaWithPatterns(5),
+		}
+
+		result := CalculatePatternScoreCorrelation(ideas)
+		require.Len(t, result, 1)
+		assert.Equal(t, 0.0, result[0].Correlation)
+	})
+
+	t.Run("no ideas", func(t *testing.T) {
+		assert.Empty(t, CalculatePatternScoreCorrelation(nil))
+	})
+}
+
+// TestCorrelation_GroupsByName verifies patterns are matched by name, case-insensitively,
+// and counted once per idea
+func TestCorrelation_GroupsByName(t *testing.T) {
+	ideas := []*models.Idea{
+		ideaWithPatterns(4, "Perfectionism: polishing forever", "perfectionism: again"),
+		ideaWithPatterns(6, "PERFECTIONISM"),
+		ideaWithPatterns(8),
+	}
+
+	result := CalculatePatternScoreCorrelation(ideas)
+	require.Len(t, result, 1)
+	assert.Equal(t, "Perfectionism", result[0].Pattern)
+	assert.Equal(t, 2, result[0].WithCount)
+	assert.Less(t, result[0].Correlation, 0.0)
+}
@@ -0,0 +1,119 @@
+package analytics
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/ryacub/telos-idea-matrix/internal/models"
+)
+
+// shingleSize is the number of consecutive words compared by ContentSimilarity
+const shingleSize = 2
+
+// SimilarityFunc scores how alike two ideas are, from 0 (unrelated) to 1
+// (the same)
+type SimilarityFunc func(a, b *models.Idea) float64
+
+// FindDuplicateGroups groups ideas whose content similarity, by
+// ContentSimilarity, is at least threshold. See FindDuplicateGroupsBy.
+func FindDuplicateGroups(ideas []*models.Idea, threshold float64) [][]*models.Idea {
+	return FindDuplicateGroupsBy(ideas, threshold, ContentSimilarity)
+}
+
+// FindDuplicateGroupsBy groups ideas that are at least threshold similar by
+// similarity, directly or through other ideas in the group. Only groups of two
+// or more are returned, largest first. Each group is ordered by score, highest
+// first, so its first idea is the one worth keeping; ties go to the older idea.
+func FindDuplicateGroupsBy(ideas []*models.Idea, threshold float64, similarity SimilarityFunc) [][]*models.Idea {
+	parent := make([]int, len(ideas))
+	for i := range parent {
+		parent[i] = i
+	}
+	var find func(int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+
+	for i := range ideas {
+		for j := i + 1; j < len(ideas); j++ {
+			if find(i) == find(j) {
+				continue
+			}
+			if similarity(ideas[i], ideas[j]) >= threshold {
+				parent[find(j)] = find(i)
+			}
+		}
+	}
+
+	members := make(map[int][]*models.Idea)
+	for i, idea := range ideas {
+		root := find(i)
+		members[root] = append(members[root], idea)
+	}
+
+	var groups [][]*models.Idea
+	for _, group := range members {
+		if len(group) < 2 {
+			continue
+		}
+		sort.SliceStable(group, func(i, j int) bool {
+			if group[i].FinalScore != group[j].FinalScore {
+				return group[i].FinalScore > group[j].FinalScore
+			}
+			return group[i].CreatedAt.Before(group[j].CreatedAt)
+		})
+		groups = append(groups, group)
+	}
+
+	sort.Slice(groups, func(i, j int) bool {
+		if len(groups[i]) != len(groups[j]) {
+			return len(groups[i]) > len(groups[j])
+		}
+		return groups[i][0].CreatedAt.Before(groups[j][0].CreatedAt)
+	})
+	return groups
+}
+
+// ContentSimilarity is the Jaccard similarity of the two ideas' word pairs,
+// ignoring case and punctuation
+func ContentSimilarity(a, b *models.Idea) float64 {
+	return jaccard(shingles(a.Content), shingles(b.Content))
+}
+
+// shingles returns the set of consecutive word runs in content. Content
+// shorter than a shingle is one shingle of all its words.
+func shingles(content string) map[string]struct{} {
+	words := strings.FieldsFunc(strings.ToLower(content), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+
+	set := make(map[string]struct{})
+	if len(words) == 0 {
+		return set
+	}
+	if len(words) < shingleSize {
+		set[strings.Join(words, " ")] = struct{}{}
+		return set
+	}
+	for i := 0; i+shingleSize <= len(words); i++ {
+		set[strings.Join(words[i:i+shingleSize], " ")] = struct{}{}
+	}
+	return set
+}
+
+func jaccard(a, b map[string]struct{}) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+	shared := 0
+	for s := range a {
+		if _, ok := b[s]; ok {
+			shared++
+		}
+	}
+	return float64(shared) / float64(len(a)+len(b)-shared)
+}
@@ -0,0 +1,78 @@
+package analytics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ryacub/telos-idea-matrix/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func duplicateIdea(id, content string, score float64, created time.Time) *models.Idea {
+	return &models.Idea{ID: id, Content: content, FinalScore: score, CreatedAt: created}
+}
+
+func TestContentSimilarity(t *testing.T) {
+	a := &models.Idea{Content: "Build a CLI tool for tracking habits"}
+
+	assert.Equal(t, 1.0, ContentSimilarity(a, &models.Idea{Content: "build a cli tool, for tracking HABITS!"}))
+	assert.Equal(t, 0.0, ContentSimilarity(a, &models.Idea{Content: "Bake sourdough bread every weekend"}))
+
+	partial := ContentSimilarity(a, &models.Idea{Content: "Build a CLI tool for tracking workouts"})
+	assert.Greater(t, partial, 0.5)
+	assert.Less(t, partial, 1.0)
+
+	assert.Equal(t, 0.0, ContentSimilarity(a, &models.Idea{Content: "   "}))
+	assert.Equal(t, 1.0, ContentSimilarity(&models.Idea{Content: "Podcast"}, &models.Idea{Content: "podcast."}))
+}
+
+func TestFindDuplicateGroups(t *testing.T) {
+	day := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	ideas := []*models.Idea{
+		duplicateIdea("habits-1", "Build a CLI tool for tracking habits", 6, day),
+		duplicateIdea("bread", "Bake sourdough bread every weekend", 5, day),
+		duplicateIdea("habits-2", "build a cli tool for tracking habits", 8, day.Add(time.Hour)),
+		duplicateIdea("podcast-1", "Start a podcast about Go", 7, day),
+		duplicateIdea("habits-3", "Build a CLI tool for tracking habits daily", 8, day.Add(2*time.Hour)),
+		duplicateIdea("podcast-2", "Start a podcast about Go!", 7, day.Add(time.Hour)),
+	}
+
+	groups := FindDuplicateGroups(ideas, 0.8)
+	require.Len(t, groups, 2)
+
+	// Largest group first; highest score first, the older idea winning ties
+	assert.Equal(t, []string{"habits-2", "habits-3", "habits-1"}, ideaIDs(groups[0]))
+	assert.Equal(t, []string{"podcast-1", "podcast-2"}, ideaIDs(groups[1]))
+}
+
+func TestFindDuplicateGroups_ThresholdControlsMatching(t *testing.T) {
+	ideas := []*models.Idea{
+		duplicateIdea("a", "Build a CLI tool for tracking habits", 5, time.Now()),
+		duplicateIdea("b", "Build a CLI tool for tracking workouts", 5, time.Now()),
+	}
+
+	assert.Empty(t, FindDuplicateGroups(ideas, 0.95))
+	assert.Len(t, FindDuplicateGroups(ideas, 0.5), 1)
+	assert.Empty(t, FindDuplicateGroups(nil, 0.5))
+}
+
+func TestFindDuplicateGroupsBy_UsesGivenSimilarity(t *testing.T) {
+	ideas := []*models.Idea{
+		duplicateIdea("a", "Write a novel", 5, time.Now()),
+		duplicateIdea("b", "Author a book of fiction", 6, time.Now()),
+	}
+	same := func(a, b *models.Idea) float64 { return 0.97 }
+
+	groups := FindDuplicateGroupsBy(ideas, 0.9, same)
+	require.Len(t, groups, 1)
+	assert.Equal(t, []string{"b", "a"}, ideaIDs(groups[0]))
+}
+
+func ideaIDs(ideas []*models.Idea) []string {
+	ids := make([]string, len(ideas))
+	for i, idea := range ideas {
+		ids[i] = idea.ID
+	}
+	return ids
+}
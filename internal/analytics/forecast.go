@@ -0,0 +1,66 @@
+package analytics
+
+import "math"
+
+// ForecastPoint is a projected average score for a future period
+type ForecastPoint struct {
+	PeriodsAhead int     `json:"periods_ahead"` // 1 for the next period, 2 for the one after, ...
+	Score        float64 `json:"score"`         // Projected average score, clamped to 0-10
+	StdErr       float64 `json:"std_err"`       // Residual standard error of the fitted line
+}
+
+// ForecastScores fits a least-squares line to the per-period average scores and
+// projects it over the next periods.
+//
+// Periods are treated as evenly spaced in the order given. Fewer than two data
+// points (or a non-positive periods count) yields no forecast; identical averages
+// yield a flat projection with zero error. StdErr is the residual standard error
+// of the fit and is zero when there are too few points to estimate it.
+func ForecastScores(trends []TrendData, periods int) []ForecastPoint {
+	n := len(trends)
+	if n < 2 || periods <= 0 {
+		return []ForecastPoint{}
+	}
+
+	// x is the period index, y is the average score
+	var sumX, sumY float64
+	for i, trend := range trends {
+		sumX += float64(i)
+		sumY += trend.AvgScore
+	}
+	meanX := sumX / float64(n)
+	meanY := sumY / float64(n)
+
+	var sxx, sxy float64
+	for i, trend := range trends {
+		dx := float64(i) - meanX
+		sxx += dx * dx
+		sxy += dx * (trend.AvgScore - meanY)
+	}
+
+	slope := sxy / sxx
+	intercept := meanY - slope*meanX
+
+	// Residual standard error needs at least one degree of freedom
+	stdErr := 0.0
+	if n > 2 {
+		var sse float64
+		for i, trend := range trends {
+			residual := trend.AvgScore - (intercept + slope*float64(i))
+			sse += residual * residual
+		}
+		stdErr = math.Sqrt(sse / float64(n-2))
+	}
+
+	points := make([]ForecastPoint, periods)
+	for k := 1; k <= periods; k++ {
+		x := float64(n - 1 + k)
+		points[k-1] = ForecastPoint{
+			PeriodsAhead: k,
+			Score:        math.Max(0, math.Min(10, intercept+slope*x)),
+			StdErr:       stdErr,
+		}
+	}
+
+	return points
+}
@@ -0,0 +1,81 @@
+package analytics
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func trendsFromScores(scores ...float64) []TrendData {
+	trends := make([]TrendData, len(scores))
+	for i, score := range scores {
+		trends[i] = TrendData{AvgScore: score, IdeaCount: 1}
+	}
+	return trends
+}
+
+// TestForecastScores_PerfectLine tests projection of an exactly linear series
+func TestForecastScores_PerfectLine(t *testing.T) {
+	forecast := ForecastScores(trendsFromScores(4.0, 5.0, 6.0), 2)
+
+	require.Len(t, forecast, 2)
+	assert.Equal(t, 1, forecast[0].PeriodsAhead)
+	assert.InDelta(t, 7.0, forecast[0].Score, 0.0001)
+	assert.Equal(t, 2, forecast[1].PeriodsAhead)
+	assert.InDelta(t, 8.0, forecast[1].Score, 0.0001)
+	assert.InDelta(t, 0.0, forecast[0].StdErr, 0.0001)
+}
+
+// TestForecastScores_NoisySeries tests the fitted line and residual standard error
+func TestForecastScores_NoisySeries(t *testing.T) {
+	// Least squares on (0,5) (1,7) (2,6) (3,8): slope 0.8, intercept 5.3
+	// Residuals: -0.3, 0.9, -0.9, 0.3 -> SSE 1.8, RSE sqrt(1.8/2) = 0.9487
+	forecast := ForecastScores(trendsFromScores(5.0, 7.0, 6.0, 8.0), 1)
+
+	require.Len(t, forecast, 1)
+	assert.InDelta(t, 8.5, forecast[0].Score, 0.0001)
+	assert.InDelta(t, 0.9487, forecast[0].StdErr, 0.0001)
+}
+
+// TestForecastScores_Degenerate tests inputs that cannot produce a meaningful line
+func TestForecastScores_Degenerate(t *testing.T) {
+	t.Run("no data", func(t *testing.T) {
+		assert.Empty(t, ForecastScores(nil, 3))
+	})
+
+	t.Run("single point", func(t *testing.T) {
+		assert.Empty(t, ForecastScores(trendsFromScores(7.0), 3))
+	})
+
+	t.Run("non-positive periods", func(t *testing.T) {
+		assert.Empty(t, ForecastScores(trendsFromScores(5.0, 6.0), 0))
+	})
+
+	t.Run("identical values project flat", func(t *testing.T) {
+		forecast := ForecastScores(trendsFromScores(6.5, 6.5, 6.5, 6.5), 3)
+		require.Len(t, forecast, 3)
+		for _, point := range forecast {
+			assert.InDelta(t, 6.5, point.Score, 0.0001)
+			assert.InDelta(t, 0.0, point.StdErr, 0.0001)
+		}
+	})
+
+	t.Run("two points have no error estimate", func(t *testing.T) {
+		forecast := ForecastScores(trendsFromScores(5.0, 6.0), 1)
+		require.Len(t, forecast, 1)
+		assert.InDelta(t, 7.0, forecast[0].Score, 0.0001)
+		assert.Equal(t, 0.0, forecast[0].StdErr)
+	})
+}
+
+// TestForecastScores_ClampsToScoreRange tests projections stay within 0-10
+func TestForecastScores_ClampsToScoreRange(t *testing.T) {
+	up := ForecastScores(trendsFromScores(8.0, 9.0, 10.0), 2)
+	require.Len(t, up, 2)
+	assert.Equal(t, 10.0, up[1].Score)
+
+	down := ForecastScores(trendsFromScores(2.0, 1.0, 0.5), 3)
+	require.Len(t, down, 3)
+	assert.Equal(t, 0.0, down[2].Score)
+}
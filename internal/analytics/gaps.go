@@ -0,0 +1,66 @@
+package analytics
+
+import (
+	"sort"
+	"time"
+
+	"github.com/ryacub/telos-idea-matrix/internal/models"
+)
+
+// Gap is a stretch of time between two consecutive captures
+type Gap struct {
+	Start time.Time `json:"start"` // When the idea before the gap was captured
+	End   time.Time `json:"end"`   // When the idea after the gap was captured
+	Days  float64   `json:"days"`
+}
+
+// CaptureGapStats describes the rhythm of idea capture
+type CaptureGapStats struct {
+	Ideas int `json:"ideas"`
+
+	// Gaps holds every interval between consecutive captures, longest first
+	Gaps []Gap `json:"gaps"`
+
+	// Interval distribution in days; all zero with fewer than two ideas
+	MinDays    float64 `json:"min_days"`
+	MedianDays float64 `json:"median_days"`
+	MaxDays    float64 `json:"max_days"`
+}
+
+// CaptureGaps computes the intervals between consecutive ideas ordered by
+// CreatedAt. Ties in gap length are ordered by start time.
+func CaptureGaps(ideas []*models.Idea) CaptureGapStats {
+	stats := CaptureGapStats{Ideas: len(ideas)}
+	if len(ideas) < 2 {
+		return stats
+	}
+
+	times := make([]time.Time, len(ideas))
+	for i, idea := range ideas {
+		times[i] = idea.CreatedAt
+	}
+	sort.Slice(times, func(i, j int) bool { return times[i].Before(times[j]) })
+
+	stats.Gaps = make([]Gap, 0, len(times)-1)
+	days := make([]float64, 0, len(times)-1)
+	for i := 1; i < len(times); i++ {
+		d := times[i].Sub(times[i-1]).Hours() / 24
+		stats.Gaps = append(stats.Gaps, Gap{Start: times[i-1], End: times[i], Days: d})
+		days = append(days, d)
+	}
+
+	sort.SliceStable(stats.Gaps, func(i, j int) bool {
+		return stats.Gaps[i].Days > stats.Gaps[j].Days
+	})
+
+	sort.Float64s(days)
+	stats.MinDays = days[0]
+	stats.MaxDays = days[len(days)-1]
+	if mid := len(days) / 2; len(days)%2 == 1 {
+		stats.MedianDays = days[mid]
+	} else {
+		stats.MedianDays = (days[mid-1] + days[mid]) / 2
+	}
+
+	return stats
+}
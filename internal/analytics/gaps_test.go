@@ -0,0 +1,72 @@
+package analytics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ryacub/telos-idea-matrix/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestCaptureGaps_KnownIntervals tests gaps between ideas spaced 1, 3, 10 and 2 days apart
+func TestCaptureGaps_KnownIntervals(t *testing.T) {
+	base := time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+
+	// Deliberately out of order; gaps are computed over sorted capture times
+	ideas := []*models.Idea{
+		{ID: "e", CreatedAt: base.Add(16 * day)},
+		{ID: "a", CreatedAt: base},
+		{ID: "c", CreatedAt: base.Add(4 * day)},
+		{ID: "b", CreatedAt: base.Add(1 * day)},
+		{ID: "d", CreatedAt: base.Add(14 * day)},
+	}
+
+	stats := CaptureGaps(ideas)
+
+	assert.Equal(t, 5, stats.Ideas)
+	require.Len(t, stats.Gaps, 4)
+
+	longest := stats.Gaps[0]
+	assert.InDelta(t, 10.0, longest.Days, 0.001)
+	assert.Equal(t, base.Add(4*day), longest.Start)
+	assert.Equal(t, base.Add(14*day), longest.End)
+
+	// Intervals sorted: 1, 2, 3, 10
+	assert.InDelta(t, 3.0, stats.Gaps[1].Days, 0.001)
+	assert.InDelta(t, 2.0, stats.Gaps[2].Days, 0.001)
+	assert.InDelta(t, 1.0, stats.Gaps[3].Days, 0.001)
+
+	assert.InDelta(t, 1.0, stats.MinDays, 0.001)
+	assert.InDelta(t, 2.5, stats.MedianDays, 0.001)
+	assert.InDelta(t, 10.0, stats.MaxDays, 0.001)
+}
+
+// TestCaptureGaps_OddCountMedian tests the median of an odd number of intervals
+func TestCaptureGaps_OddCountMedian(t *testing.T) {
+	base := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	ideas := []*models.Idea{
+		{CreatedAt: base},
+		{CreatedAt: base.Add(12 * time.Hour)},
+		{CreatedAt: base.Add(72 * time.Hour)},
+		{CreatedAt: base.Add(240 * time.Hour)},
+	}
+
+	stats := CaptureGaps(ideas)
+
+	// Intervals: 0.5, 2.5, 7 days
+	assert.InDelta(t, 0.5, stats.MinDays, 0.001)
+	assert.InDelta(t, 2.5, stats.MedianDays, 0.001)
+	assert.InDelta(t, 7.0, stats.MaxDays, 0.001)
+}
+
+// TestCaptureGaps_TooFewIdeas tests that fewer than two ideas have no intervals
+func TestCaptureGaps_TooFewIdeas(t *testing.T) {
+	assert.Equal(t, CaptureGapStats{}, CaptureGaps(nil))
+
+	stats := CaptureGaps([]*models.Idea{{CreatedAt: time.Now()}})
+	assert.Equal(t, 1, stats.Ideas)
+	assert.Empty(t, stats.Gaps)
+	assert.Zero(t, stats.MedianDays)
+}
@@ -0,0 +1,211 @@
+package analytics
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/ryacub/telos-idea-matrix/internal/models"
+)
+
+// HeatmapMetric selects what shades an activity heatmap cell
+type HeatmapMetric string
+
+const (
+	// HeatmapCount shades each day by how many ideas were captured
+	HeatmapCount HeatmapMetric = "count"
+	// HeatmapAvgScore shades each day by the average final score of its ideas
+	HeatmapAvgScore HeatmapMetric = "avg-score"
+)
+
+// heatLevels is the number of shades for days with ideas; days without
+// ideas get their own
+const heatLevels = 4
+
+// ActivityDay is one day of an activity grid
+type ActivityDay struct {
+	Date     string  `json:"date"` // YYYY-MM-DD, local time
+	Count    int     `json:"count"`
+	AvgScore float64 `json:"avg_score"` // Average final score; 0 without ideas
+}
+
+// ActivityGrid counts ideas captured per day, laid out in weeks like a
+// contribution calendar
+type ActivityGrid struct {
+	Start time.Time     `json:"-"`    // First day, as a calendarDay
+	End   time.Time     `json:"-"`    // Last day, as a calendarDay
+	Days  []ActivityDay `json:"days"` // One per day from Start through End
+
+	Total      int `json:"total"`       // Ideas captured in the grid
+	ActiveDays int `json:"active_days"` // Days with at least one idea
+	MaxCount   int `json:"max_count"`   // Most ideas captured on one day
+}
+
+// BuildActivityGrid counts ideas per day over the last weeks weeks, in local
+// time. Weeks start on Sunday; the last one is the current week and ends today.
+func BuildActivityGrid(ideas []*models.Idea, weeks int) *ActivityGrid {
+	return buildRecentActivityGrid(ideas, weeks, time.Now().Local())
+}
+
+// BuildYearActivityGrid counts ideas per day from January 1st through
+// December 31st of year, in local time
+func BuildYearActivityGrid(ideas []*models.Idea, year int) *ActivityGrid {
+	start := time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(year, time.December, 31, 0, 0, 0, 0, time.UTC)
+	return buildActivityGrid(ideas, start, end, time.Local)
+}
+
+// buildRecentActivityGrid builds the grid for the weeks up to now, in now's location
+func buildRecentActivityGrid(ideas []*models.Idea, weeks int, now time.Time) *ActivityGrid {
+	end := calendarDay(now)
+	weeks = max(weeks, 1)
+	start := end.AddDate(0, 0, -int(end.Weekday())-7*(weeks-1))
+	return buildActivityGrid(ideas, start, end, now.Location())
+}
+
+// buildActivityGrid counts ideas per day from start through end, both
+// calendarDays, taking capture dates in loc
+func buildActivityGrid(ideas []*models.Idea, start, end time.Time, loc *time.Location) *ActivityGrid {
+	grid := &ActivityGrid{Start: start, End: end}
+
+	days := daysBetween(start, end) + 1
+	grid.Days = make([]ActivityDay, days)
+	scoreSums := make([]float64, days)
+	for i := range grid.Days {
+		grid.Days[i].Date = start.AddDate(0, 0, i).Format("2006-01-02")
+	}
+
+	for _, idea := range ideas {
+		i := daysBetween(start, calendarDay(idea.CreatedAt.In(loc)))
+		if i < 0 || i >= days {
+			continue
+		}
+		grid.Days[i].Count++
+		scoreSums[i] += idea.FinalScore
+		grid.Total++
+	}
+
+	for i := range grid.Days {
+		day := &grid.Days[i]
+		if day.Count == 0 {
+			continue
+		}
+		day.AvgScore = scoreSums[i] / float64(day.Count)
+		grid.ActiveDays++
+		grid.MaxCount = max(grid.MaxCount, day.Count)
+	}
+
+	return grid
+}
+
+// Weeks returns how many week columns the grid spans
+func (g *ActivityGrid) Weeks() int {
+	if len(g.Days) == 0 {
+		return 0
+	}
+	return (int(g.Start.Weekday())+len(g.Days)-1)/7 + 1
+}
+
+// Day returns the day in week column week and weekday row, and false for
+// cells before Start or after End
+func (g *ActivityGrid) Day(week int, weekday time.Weekday) (ActivityDay, bool) {
+	i := week*7 + int(weekday) - int(g.Start.Weekday())
+	if i < 0 || i >= len(g.Days) {
+		return ActivityDay{}, false
+	}
+	return g.Days[i], true
+}
+
+// Busiest returns the day with the most ideas, the earliest on ties, and
+// false if the grid has no ideas
+func (g *ActivityGrid) Busiest() (ActivityDay, bool) {
+	for _, day := range g.Days {
+		if day.Count > 0 && day.Count == g.MaxCount {
+			return day, true
+		}
+	}
+	return ActivityDay{}, false
+}
+
+// heatLevel shades day from 0 (no ideas) to heatLevels. Counts are relative
+// to the busiest day; average scores use the fixed 0-10 scale, so they read
+// the same in every grid.
+func (g *ActivityGrid) heatLevel(day ActivityDay, metric HeatmapMetric) int {
+	if day.Count == 0 {
+		return 0
+	}
+	fraction := float64(day.Count) / float64(g.MaxCount)
+	if metric == HeatmapAvgScore {
+		fraction = day.AvgScore / 10
+	}
+	return min(max(int(math.Ceil(fraction*heatLevels)), 1), heatLevels)
+}
+
+// RenderHeatmap draws the grid with UnicodeCharset
+func RenderHeatmap(grid *ActivityGrid, metric HeatmapMetric) string {
+	return UnicodeCharset.RenderHeatmap(grid, metric)
+}
+
+// RenderHeatmap draws the grid as a contribution calendar: one column per
+// week, one row per weekday from Sunday, month names above the week each
+// month starts in, and a legend for the shades. A month that starts before
+// the grid does isn't named.
+func (cs Charset) RenderHeatmap(grid *ActivityGrid, metric HeatmapMetric) string {
+	weeks := grid.Weeks()
+	if weeks == 0 {
+		return ""
+	}
+
+	const labelWidth = 4
+	var chart strings.Builder
+
+	// Month labels, skipped where the previous one hasn't ended yet
+	months := []rune(strings.Repeat(" ", weeks+3))
+	next := 0
+	for week := 0; week < weeks; week++ {
+		for weekday := time.Sunday; weekday <= time.Saturday; weekday++ {
+			day, ok := grid.Day(week, weekday)
+			if !ok {
+				continue
+			}
+			date, _ := time.Parse("2006-01-02", day.Date)
+			if date.Day() == 1 && week >= next {
+				copy(months[week:], []rune(date.Format("Jan")))
+				next = week + 4
+			}
+		}
+	}
+	chart.WriteString(strings.Repeat(" ", labelWidth) + strings.TrimRight(string(months), " ") + "\n")
+
+	for weekday := time.Sunday; weekday <= time.Saturday; weekday++ {
+		label := ""
+		if weekday == time.Monday || weekday == time.Wednesday || weekday == time.Friday {
+			label = weekday.String()[:3]
+		}
+		row := fmt.Sprintf("%-*s", labelWidth, label)
+		for week := 0; week < weeks; week++ {
+			day, ok := grid.Day(week, weekday)
+			if !ok {
+				row += " "
+				continue
+			}
+			row += string(cs.Heat[grid.heatLevel(day, metric)])
+		}
+		chart.WriteString(strings.TrimRight(row, " ") + "\n")
+	}
+
+	chart.WriteString("\n" + strings.Repeat(" ", labelWidth))
+	if metric == HeatmapAvgScore {
+		chart.WriteString(fmt.Sprintf("%c none", cs.Heat[0]))
+		for level := 1; level <= heatLevels; level++ {
+			chart.WriteString(fmt.Sprintf("  %c %.1f-%.1f", cs.Heat[level],
+				10*float64(level-1)/heatLevels, 10*float64(level)/heatLevels))
+		}
+		chart.WriteString("  average score\n")
+	} else {
+		chart.WriteString("Less " + string(cs.Heat) + " More\n")
+	}
+
+	return chart.String()
+}
@@ -0,0 +1,99 @@
+package analytics
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ryacub/telos-idea-matrix/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func heatmapIdea(month time.Month, day int, score float64) *models.Idea {
+	return &models.Idea{CreatedAt: time.Date(2025, month, day, 10, 0, 0, 0, time.UTC), FinalScore: score}
+}
+
+// TestBuildActivityGrid_LastWeeks tests a grid ending on Wednesday 12th March 2025
+func TestBuildActivityGrid_LastWeeks(t *testing.T) {
+	ideas := []*models.Idea{
+		heatmapIdea(3, 12, 8), heatmapIdea(3, 12, 6), heatmapIdea(3, 2, 5),
+		heatmapIdea(2, 22, 9), // Before the grid
+		heatmapIdea(3, 13, 9), // After today
+	}
+	now := time.Date(2025, 3, 12, 18, 0, 0, 0, time.UTC)
+
+	grid := buildRecentActivityGrid(ideas, 2, now)
+
+	assert.Equal(t, "2025-03-02", grid.Days[0].Date, "starts on the Sunday a week before this one")
+	assert.Equal(t, "2025-03-12", grid.Days[len(grid.Days)-1].Date)
+	assert.Len(t, grid.Days, 11)
+	assert.Equal(t, 2, grid.Weeks())
+
+	assert.Equal(t, 3, grid.Total)
+	assert.Equal(t, 2, grid.ActiveDays)
+	assert.Equal(t, 2, grid.MaxCount)
+	assert.Equal(t, 1, grid.Days[0].Count)
+
+	today, ok := grid.Day(1, time.Wednesday)
+	require.True(t, ok)
+	assert.Equal(t, "2025-03-12", today.Date)
+	assert.Equal(t, 2, today.Count)
+	assert.InDelta(t, 7.0, today.AvgScore, 0.001)
+
+	_, ok = grid.Day(1, time.Thursday)
+	assert.False(t, ok, "days after today are outside the grid")
+
+	busiest, ok := grid.Busiest()
+	require.True(t, ok)
+	assert.Equal(t, "2025-03-12", busiest.Date)
+}
+
+// TestBuildActivityGrid_Year tests a calendar-year grid, which starts mid-week
+func TestBuildActivityGrid_Year(t *testing.T) {
+	grid := buildActivityGrid([]*models.Idea{heatmapIdea(1, 1, 5), heatmapIdea(12, 31, 5)},
+		time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2025, 12, 31, 0, 0, 0, 0, time.UTC), time.UTC)
+
+	assert.Len(t, grid.Days, 365)
+	assert.Equal(t, 53, grid.Weeks(), "2025 starts on a Wednesday")
+	assert.Equal(t, 2, grid.Total)
+
+	_, ok := grid.Day(0, time.Tuesday)
+	assert.False(t, ok, "December 31st 2024 is outside the grid")
+	first, ok := grid.Day(0, time.Wednesday)
+	require.True(t, ok)
+	assert.Equal(t, "2025-01-01", first.Date)
+	assert.Equal(t, 1, first.Count)
+}
+
+// TestActivityGrid_HeatLevel tests shading by count and by average score
+func TestActivityGrid_HeatLevel(t *testing.T) {
+	grid := &ActivityGrid{MaxCount: 8}
+
+	assert.Equal(t, 0, grid.heatLevel(ActivityDay{}, HeatmapCount))
+	assert.Equal(t, 1, grid.heatLevel(ActivityDay{Count: 1}, HeatmapCount))
+	assert.Equal(t, 2, grid.heatLevel(ActivityDay{Count: 4}, HeatmapCount))
+	assert.Equal(t, 4, grid.heatLevel(ActivityDay{Count: 8}, HeatmapCount))
+
+	assert.Equal(t, 1, grid.heatLevel(ActivityDay{Count: 8, AvgScore: 0}, HeatmapAvgScore), "a day with ideas is never blank")
+	assert.Equal(t, 3, grid.heatLevel(ActivityDay{Count: 1, AvgScore: 7.0}, HeatmapAvgScore))
+	assert.Equal(t, 4, grid.heatLevel(ActivityDay{Count: 1, AvgScore: 9.5}, HeatmapAvgScore))
+}
+
+func TestRenderHeatmap(t *testing.T) {
+	ideas := []*models.Idea{heatmapIdea(3, 1, 8), heatmapIdea(3, 1, 8), heatmapIdea(3, 3, 2)}
+	grid := buildRecentActivityGrid(ideas, 3, time.Date(2025, 3, 4, 12, 0, 0, 0, time.UTC))
+
+	lines := strings.Split(ASCIICharset.RenderHeatmap(grid, HeatmapCount), "\n")
+
+	assert.Equal(t, "     Mar", lines[0], "labelled above the week March starts in")
+	assert.Equal(t, "    ...", lines[1], "Sundays")
+	assert.Equal(t, "Mon ..+", lines[2], "half as many ideas as the busiest day")
+	assert.Equal(t, "    ...", lines[3], "Tuesday 4th is today")
+	assert.Equal(t, "Wed ..", lines[4], "days after today are blank")
+	assert.Equal(t, "    .#", lines[7], "Saturday 1st is the busiest day")
+	assert.Equal(t, "    Less .:+*# More", lines[9])
+
+	legend := UnicodeCharset.RenderHeatmap(grid, HeatmapAvgScore)
+	assert.Contains(t, legend, "▓ 5.0-7.5  █ 7.5-10.0  average score")
+}
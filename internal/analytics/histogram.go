@@ -0,0 +1,47 @@
+package analytics
+
+import (
+	"math"
+	"strconv"
+)
+
+// HistogramBucket counts the scores from Min up to Max; the last bucket
+// also counts scores of exactly Max
+type HistogramBucket struct {
+	Min   float64 `json:"min"`
+	Max   float64 `json:"max"`
+	Count int     `json:"count"`
+}
+
+// Label names the bucket's range, such as "7.5-8"
+func (b HistogramBucket) Label() string {
+	return strconv.FormatFloat(b.Min, 'f', -1, 64) + "-" + strconv.FormatFloat(b.Max, 'f', -1, 64)
+}
+
+// Histogram counts scores in buckets width wide covering 0-10. When width
+// doesn't divide 10 evenly the last bucket is narrower. Scores outside 0-10
+// count in the first or last bucket. A width of zero or less returns nil.
+func Histogram(scores []float64, width float64) []HistogramBucket {
+	if width <= 0 {
+		return nil
+	}
+
+	// The epsilon keeps float error, as in 10/0.1, from adding a bucket
+	n := max(1, int(math.Ceil(10/width-1e-9)))
+	buckets := make([]HistogramBucket, n)
+	for i := range buckets {
+		buckets[i].Min = roundBucketEdge(float64(i) * width)
+		buckets[i].Max = min(10, roundBucketEdge(float64(i+1)*width))
+	}
+
+	for _, score := range scores {
+		i := int(math.Floor(score/width + 1e-9))
+		buckets[min(max(i, 0), n-1)].Count++
+	}
+	return buckets
+}
+
+// roundBucketEdge drops float error from a bucket edge, so 3*0.1 is 0.3
+func roundBucketEdge(edge float64) float64 {
+	return math.Round(edge*1e6) / 1e6
+}
@@ -0,0 +1,52 @@
+package analytics
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func bucketCounts(buckets []HistogramBucket) []int {
+	counts := make([]int, len(buckets))
+	for i, b := range buckets {
+		counts[i] = b.Count
+	}
+	return counts
+}
+
+func TestHistogram_WholeBuckets(t *testing.T) {
+	buckets := Histogram([]float64{0, 0.9, 1, 6.5, 6.99, 7, 9.99, 10}, 1)
+
+	require.Len(t, buckets, 10)
+	assert.Equal(t, HistogramBucket{Min: 0, Max: 1, Count: 2}, buckets[0])
+	assert.Equal(t, "6-7", buckets[6].Label())
+	assert.Equal(t, []int{2, 1, 0, 0, 0, 0, 2, 1, 0, 2}, bucketCounts(buckets), "10 counts in the last bucket")
+}
+
+func TestHistogram_FineAndUnevenWidths(t *testing.T) {
+	buckets := Histogram([]float64{0.3, 7.2, 7.3}, 0.1)
+	require.Len(t, buckets, 100)
+	assert.Equal(t, 1, buckets[3].Count, "0.3 starts its own bucket despite float error")
+	assert.Equal(t, "0.3-0.4", buckets[3].Label())
+	assert.Equal(t, 1, buckets[72].Count)
+	assert.Equal(t, 1, buckets[73].Count)
+
+	buckets = Histogram([]float64{2.5, 7.5, 9.5}, 2.5)
+	assert.Equal(t, []int{0, 1, 0, 2}, bucketCounts(buckets))
+
+	buckets = Histogram([]float64{9.5, 10}, 3)
+	require.Len(t, buckets, 4)
+	assert.Equal(t, HistogramBucket{Min: 9, Max: 10, Count: 2}, buckets[3], "the last bucket stops at 10")
+}
+
+func TestHistogram_Edges(t *testing.T) {
+	assert.Nil(t, Histogram([]float64{5}, 0))
+	assert.Nil(t, Histogram([]float64{5}, -1))
+
+	buckets := Histogram([]float64{-1, 11}, 5)
+	assert.Equal(t, []int{1, 1}, bucketCounts(buckets), "out-of-range scores are clamped")
+
+	buckets = Histogram(nil, 20)
+	assert.Equal(t, []HistogramBucket{{Min: 0, Max: 10}}, buckets)
+}
@@ -0,0 +1,188 @@
+package analytics
+
+import (
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/ryacub/telos-idea-matrix/internal/models"
+	"github.com/ryacub/telos-idea-matrix/internal/patterns"
+)
+
+// PatternShareThreshold is how far, as a fraction of a period's ideas, a
+// pattern's share has to move between the earlier and recent periods to count
+// as rising or declining
+const PatternShareThreshold = 0.1
+
+// PatternPeriod counts the patterns of the ideas captured in one time period
+type PatternPeriod struct {
+	Period           string         `json:"period"`
+	IdeaCount        int            `json:"idea_count"`
+	AntiPatterns     map[string]int `json:"anti_patterns"`     // Ideas with each anti-pattern, by name
+	PositivePatterns map[string]int `json:"positive_patterns"` // Ideas with each positive pattern, by name
+}
+
+// PatternTrend describes how often a pattern appeared in each period
+type PatternTrend struct {
+	Pattern      string    `json:"pattern"`
+	Positive     bool      `json:"positive"`
+	Total        int       `json:"total"`         // Ideas with the pattern across all periods
+	Counts       []int     `json:"counts"`        // Ideas with the pattern, per period
+	Shares       []float64 `json:"shares"`        // Fraction of each period's ideas with the pattern
+	EarlierShare float64   `json:"earlier_share"` // Mean share over the earlier half of the periods
+	RecentShare  float64   `json:"recent_share"`  // Mean share over the recent half of the periods
+	Direction    string    `json:"direction"`     // "up", "down" or "neutral"
+}
+
+// IsRisingAntiPattern reports whether the trend is an anti-pattern whose
+// share of ideas is increasing
+func (t PatternTrend) IsRisingAntiPattern() bool {
+	return !t.Positive && t.Direction == "up"
+}
+
+// PatternTrendsOverTime groups ideas by time period and counts, for each
+// period, how many ideas have each pattern. groupBy can be "day", "week", or
+// "month". Patterns are matched by name ignoring case, and an idea counts
+// once per pattern however often it was detected. Positive patterns are
+// counted apart from the anti-patterns they share a name with. Periods
+// without ideas are left out; the rest are in chronological order.
+func PatternTrendsOverTime(ideas []*models.Idea, groupBy string) []PatternPeriod {
+	names := make(map[string]string) // lowercased key -> display name
+	periods := make(map[string]*PatternPeriod)
+
+	for _, idea := range ideas {
+		key := periodKey(idea.CreatedAt, groupBy)
+		period, ok := periods[key]
+		if !ok {
+			period = &PatternPeriod{
+				Period:           key,
+				AntiPatterns:     make(map[string]int),
+				PositivePatterns: make(map[string]int),
+			}
+			periods[key] = period
+		}
+		period.IdeaCount++
+
+		seen := make(map[string]bool, len(idea.Patterns))
+		for _, raw := range idea.Patterns {
+			name := patternName(raw)
+			if name == "" {
+				continue
+			}
+			lower := strings.ToLower(name)
+			if _, ok := names[lower]; !ok {
+				names[lower] = name
+			}
+			name = names[lower]
+
+			counts := period.AntiPatterns
+			seenKey := lower
+			if patterns.IsPositive(raw) {
+				counts = period.PositivePatterns
+				seenKey += "+"
+			}
+			if seen[seenKey] {
+				continue
+			}
+			seen[seenKey] = true
+			counts[name]++
+		}
+	}
+
+	result := make([]PatternPeriod, 0, len(periods))
+	for _, period := range periods {
+		result = append(result, *period)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Period < result[j].Period
+	})
+	return result
+}
+
+// CalculatePatternTrends follows the n patterns seen in the most ideas across
+// periods, ordered by that count and then by name. A pattern is rising or
+// declining when its mean share of ideas over the recent half of the periods
+// differs from the earlier half by at least PatternShareThreshold; with
+// fewer than two periods every pattern is neutral.
+func CalculatePatternTrends(periods []PatternPeriod, n int) []PatternTrend {
+	type patternKey struct {
+		name     string
+		positive bool
+	}
+	totals := make(map[patternKey]int)
+	for _, period := range periods {
+		for name, count := range period.AntiPatterns {
+			totals[patternKey{name, false}] += count
+		}
+		for name, count := range period.PositivePatterns {
+			totals[patternKey{name, true}] += count
+		}
+	}
+
+	keys := make([]patternKey, 0, len(totals))
+	for key := range totals {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if totals[keys[i]] != totals[keys[j]] {
+			return totals[keys[i]] > totals[keys[j]]
+		}
+		if keys[i].name != keys[j].name {
+			return keys[i].name < keys[j].name
+		}
+		return !keys[i].positive
+	})
+	if n >= 0 && len(keys) > n {
+		keys = keys[:n]
+	}
+
+	trends := make([]PatternTrend, 0, len(keys))
+	for _, key := range keys {
+		trend := PatternTrend{
+			Pattern:   key.name,
+			Positive:  key.positive,
+			Total:     totals[key],
+			Counts:    make([]int, len(periods)),
+			Shares:    make([]float64, len(periods)),
+			Direction: "neutral",
+		}
+		for i, period := range periods {
+			counts := period.AntiPatterns
+			if key.positive {
+				counts = period.PositivePatterns
+			}
+			trend.Counts[i] = counts[key.name]
+			if period.IdeaCount > 0 {
+				trend.Shares[i] = float64(trend.Counts[i]) / float64(period.IdeaCount)
+			}
+		}
+
+		if len(periods) >= 2 {
+			mid := len(periods) / 2
+			trend.EarlierShare = meanShare(trend.Shares[:mid])
+			trend.RecentShare = meanShare(trend.Shares[mid:])
+			// Round so a move of exactly the threshold isn't lost to float error
+			diff := math.Round((trend.RecentShare-trend.EarlierShare)*1e6) / 1e6
+			if diff >= PatternShareThreshold {
+				trend.Direction = "up"
+			} else if diff <= -PatternShareThreshold {
+				trend.Direction = "down"
+			}
+		} else if len(periods) == 1 {
+			trend.EarlierShare = trend.Shares[0]
+			trend.RecentShare = trend.Shares[0]
+		}
+
+		trends = append(trends, trend)
+	}
+	return trends
+}
+
+// meanShare averages shares; callers pass at least one
+func meanShare(shares []float64) float64 {
+	sum := 0.0
+	for _, share := range shares {
+		sum += share
+	}
+	return sum / float64(len(shares))
+}
@@ -0,0 +1,98 @@
+package analytics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ryacub/telos-idea-matrix/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func ideaCreated(createdAt time.Time, patterns ...string) *models.Idea {
+	return &models.Idea{CreatedAt: createdAt, Patterns: patterns}
+}
+
+func TestPatternTrendsOverTime_CountsPerPeriod(t *testing.T) {
+	jan := time.Date(2024, 1, 10, 12, 0, 0, 0, time.UTC)
+	feb := time.Date(2024, 2, 10, 12, 0, 0, 0, time.UTC)
+	ideas := []*models.Idea{
+		ideaCreated(feb, "Perfectionism: scope creep"),
+		ideaCreated(jan, "Perfectionism: scope creep", "perfectionism: detected twice"),
+		ideaCreated(jan, "Context switching: Staying focused on current tech stack"),
+		ideaCreated(jan),
+	}
+
+	periods := PatternTrendsOverTime(ideas, "month")
+	require.Len(t, periods, 2)
+
+	assert.Equal(t, "2024-01", periods[0].Period, "periods are chronological")
+	assert.Equal(t, 3, periods[0].IdeaCount)
+	assert.Equal(t, map[string]int{"Perfectionism": 1}, periods[0].AntiPatterns, "an idea counts once per pattern")
+	assert.Equal(t, map[string]int{"Context switching": 1}, periods[0].PositivePatterns)
+
+	assert.Equal(t, "2024-02", periods[1].Period)
+	assert.Equal(t, 1, periods[1].IdeaCount)
+	assert.Equal(t, 1, periods[1].AntiPatterns["Perfectionism"])
+}
+
+func TestPatternTrendsOverTime_Empty(t *testing.T) {
+	assert.Empty(t, PatternTrendsOverTime(nil, "week"))
+}
+
+func TestCalculatePatternTrends_Directions(t *testing.T) {
+	periods := []PatternPeriod{
+		{Period: "2024-W01", IdeaCount: 4, AntiPatterns: map[string]int{"Procrastination": 1, "Perfectionism": 3}},
+		{Period: "2024-W02", IdeaCount: 2, AntiPatterns: map[string]int{"Perfectionism": 1}},
+		{Period: "2024-W03", IdeaCount: 5, AntiPatterns: map[string]int{"Procrastination": 4}},
+		{Period: "2024-W04", IdeaCount: 5, AntiPatterns: map[string]int{"Procrastination": 3},
+			PositivePatterns: map[string]int{"Accountability avoidance": 1}},
+	}
+
+	trends := CalculatePatternTrends(periods, 10)
+	require.Len(t, trends, 3)
+
+	procrastination := trends[0]
+	assert.Equal(t, "Procrastination", procrastination.Pattern, "ordered by ideas with the pattern")
+	assert.Equal(t, 8, procrastination.Total)
+	assert.Equal(t, []int{1, 0, 4, 3}, procrastination.Counts)
+	assert.InDelta(t, 0.25, procrastination.Shares[0], 1e-9)
+	assert.InDelta(t, 0.125, procrastination.EarlierShare, 1e-9)
+	assert.InDelta(t, 0.7, procrastination.RecentShare, 1e-9)
+	assert.Equal(t, "up", procrastination.Direction)
+	assert.True(t, procrastination.IsRisingAntiPattern())
+
+	perfectionism := trends[1]
+	assert.Equal(t, "Perfectionism", perfectionism.Pattern)
+	assert.Equal(t, "down", perfectionism.Direction)
+	assert.False(t, perfectionism.IsRisingAntiPattern())
+
+	accountability := trends[2]
+	assert.True(t, accountability.Positive)
+	assert.Equal(t, "up", accountability.Direction)
+	assert.False(t, accountability.IsRisingAntiPattern(), "positive patterns rising is good news")
+}
+
+func TestCalculatePatternTrends_TopNAndSinglePeriod(t *testing.T) {
+	periods := []PatternPeriod{
+		{Period: "2024-01", IdeaCount: 2, AntiPatterns: map[string]int{"B": 2, "A": 2, "C": 1}},
+	}
+
+	trends := CalculatePatternTrends(periods, 2)
+	require.Len(t, trends, 2)
+	assert.Equal(t, "A", trends[0].Pattern, "ties are broken by name")
+	assert.Equal(t, "B", trends[1].Pattern)
+	assert.Equal(t, "neutral", trends[0].Direction, "one period has no trend")
+	assert.Equal(t, 1.0, trends[0].RecentShare)
+}
+
+func TestCalculatePatternTrends_ThresholdIsInclusive(t *testing.T) {
+	periods := []PatternPeriod{
+		{Period: "2024-01", IdeaCount: 10, AntiPatterns: map[string]int{"Procrastination": 4}},
+		{Period: "2024-02", IdeaCount: 10, AntiPatterns: map[string]int{"Procrastination": 5}},
+	}
+
+	trends := CalculatePatternTrends(periods, 5)
+	require.Len(t, trends, 1)
+	assert.Equal(t, "up", trends[0].Direction)
+}
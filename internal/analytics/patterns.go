@@ -0,0 +1,28 @@
+package analytics
+
+import "strings"
+
+// CanonicalizePatternsForDisplay collapses patterns that differ only in case or
+// whitespace, keeping the first spelling seen with its whitespace tidied.
+// Order is preserved and the input slice is never modified, so stored patterns
+// are unaffected.
+func CanonicalizePatternsForDisplay(patterns []string) []string {
+	result := make([]string, 0, len(patterns))
+	seen := make(map[string]bool, len(patterns))
+
+	for _, raw := range patterns {
+		display := strings.Join(strings.Fields(raw), " ")
+		if display == "" {
+			continue
+		}
+
+		key := strings.ToLower(display)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		result = append(result, display)
+	}
+
+	return result
+}
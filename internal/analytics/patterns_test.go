@@ -0,0 +1,43 @@
+package analytics
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestCanonicalizePatternsForDisplay_CollapsesVariants tests case and whitespace variants are merged
+func TestCanonicalizePatternsForDisplay_CollapsesVariants(t *testing.T) {
+	patterns := []string{
+		"Perfectionism: polishing forever",
+		"perfectionism:  polishing forever ",
+		"Context Switching",
+		"  context   switching",
+		"Shiny Object",
+	}
+
+	display := CanonicalizePatternsForDisplay(patterns)
+
+	assert.Equal(t, []string{
+		"Perfectionism: polishing forever",
+		"Context Switching",
+		"Shiny Object",
+	}, display)
+}
+
+// TestCanonicalizePatternsForDisplay_DoesNotMutateInput tests the stored slice is left untouched
+func TestCanonicalizePatternsForDisplay_DoesNotMutateInput(t *testing.T) {
+	patterns := []string{"  Scope   Creep ", "scope creep", "Burnout"}
+	original := append([]string(nil), patterns...)
+
+	display := CanonicalizePatternsForDisplay(patterns)
+
+	assert.Equal(t, []string{"Scope Creep", "Burnout"}, display)
+	assert.Equal(t, original, patterns)
+}
+
+// TestCanonicalizePatternsForDisplay_Empty tests empty and blank input
+func TestCanonicalizePatternsForDisplay_Empty(t *testing.T) {
+	assert.Empty(t, CanonicalizePatternsForDisplay(nil))
+	assert.Empty(t, CanonicalizePatternsForDisplay([]string{"", "   "}))
+}
@@ -0,0 +1,399 @@
+package analytics
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// PDF page geometry, in points (1/72 inch). Pages are US Letter.
+const (
+	pdfPageWidth  = 612.0
+	pdfPageHeight = 792.0
+	pdfMargin     = 54.0
+	pdfFooter     = 24.0 // Space kept free above the bottom margin for the page footer
+)
+
+// Fonts registered on every page: the standard Helvetica faces, which PDF
+// viewers provide, so nothing needs embedding
+const (
+	pdfFontRegular = "F1"
+	pdfFontBold    = "F2"
+)
+
+// pdfColor is an RGB fill color with components from 0 to 1
+type pdfColor struct{ r, g, b float64 }
+
+var (
+	pdfBlack      = pdfColor{0, 0, 0}
+	pdfGray       = pdfColor{0.45, 0.45, 0.45}
+	pdfTrack      = pdfColor{0.92, 0.92, 0.92}
+	pdfHighColor  = pdfColor{0.20, 0.62, 0.35}
+	pdfMedColor   = pdfColor{0.95, 0.66, 0.15}
+	pdfLowColor   = pdfColor{0.84, 0.26, 0.23}
+	pdfTrendLimit = 24 // Most recent months drawn in the trend chart
+)
+
+// RenderReportPDF writes report as a paginated PDF document. The score
+// distribution and monthly trend are drawn as bar charts beside their
+// sections; every other section is set as wrapped text. Characters outside
+// the Latin-1 range, such as emoji, are left out.
+func RenderReportPDF(report Report, w io.Writer) error {
+	doc := newPDFDocument()
+
+	doc.paragraph(pdfFontBold, 20, report.Title)
+	doc.space(6)
+	doc.paragraph(pdfFontRegular, 11, report.Summary)
+	doc.space(8)
+	doc.rule()
+
+	for _, section := range report.Sections {
+		// Keep a heading on the same page as the start of its section
+		doc.reserve(60)
+		doc.space(10)
+		doc.paragraph(pdfFontBold, 14, section.Title)
+		doc.space(6)
+
+		switch section.Title {
+		case SectionScoreDistribution:
+			if report.Distribution.Total() > 0 {
+				doc.distributionChart(report.Distribution)
+			}
+		case SectionTrends:
+			if len(report.Trends) > 0 {
+				doc.trendChart(report.Trends)
+			}
+		}
+
+		doc.paragraph(pdfFontRegular, 10, section.Content)
+		doc.space(6)
+		doc.rule()
+	}
+
+	footer := "Generated: " + report.GeneratedAt.Format("2006-01-02 15:04:05")
+	return doc.write(w, footer)
+}
+
+// pdfDocument lays out content top to bottom, starting a new page when the
+// next element would run into the footer
+type pdfDocument struct {
+	pages []*bytes.Buffer // Content stream of each page
+	y     float64         // Top of the free space on the current page
+}
+
+func newPDFDocument() *pdfDocument {
+	d := &pdfDocument{}
+	d.addPage()
+	return d
+}
+
+func (d *pdfDocument) addPage() {
+	d.pages = append(d.pages, &bytes.Buffer{})
+	d.y = pdfPageHeight - pdfMargin
+}
+
+func (d *pdfDocument) page() *bytes.Buffer {
+	return d.pages[len(d.pages)-1]
+}
+
+// reserve starts a new page unless height points fit on the current one
+func (d *pdfDocument) reserve(height float64) {
+	if d.y-height < pdfMargin+pdfFooter {
+		d.addPage()
+	}
+}
+
+// space moves down by height points, unless at the top of a page
+func (d *pdfDocument) space(height float64) {
+	if d.y < pdfPageHeight-pdfMargin {
+		d.y -= height
+	}
+}
+
+func (d *pdfDocument) text(x, y float64, font string, size float64, c pdfColor, s string) {
+	fmt.Fprintf(d.page(), "%.3f %.3f %.3f rg BT /%s %.1f Tf %.2f %.2f Td (%s) Tj ET\n",
+		c.r, c.g, c.b, font, size, x, y, pdfEncode(s))
+}
+
+func (d *pdfDocument) rect(x, y, width, height float64, c pdfColor) {
+	fmt.Fprintf(d.page(), "%.3f %.3f %.3f rg %.2f %.2f %.2f %.2f re f\n",
+		c.r, c.g, c.b, x, y, width, height)
+}
+
+// rule draws a thin horizontal line across the text column
+func (d *pdfDocument) rule() {
+	d.reserve(8)
+	d.y -= 4
+	fmt.Fprintf(d.page(), "0.8 G 0.5 w %.2f %.2f m %.2f %.2f l S\n",
+		pdfMargin, d.y, pdfPageWidth-pdfMargin, d.y)
+	d.y -= 4
+}
+
+// paragraph sets s in the text column, wrapping long lines. A wrapped line
+// keeps the indentation of the line it came from.
+func (d *pdfDocument) paragraph(font string, size float64, s string) {
+	leading := size * 1.35
+	width := pdfPageWidth - 2*pdfMargin
+
+	for _, line := range strings.Split(strings.TrimRight(pdfText(s), "\n"), "\n") {
+		for _, wrapped := range wrapPDFLine(line, size, width) {
+			d.reserve(leading)
+			d.y -= leading
+			if strings.TrimSpace(wrapped) != "" {
+				d.text(pdfMargin, d.y+size*0.3, font, size, pdfBlack, wrapped)
+			}
+		}
+	}
+}
+
+// distributionChart draws one horizontal bar per score band
+func (d *pdfDocument) distributionChart(bands ScoreBands) {
+	const (
+		rowHeight = 18.0
+		barHeight = 10.0
+		labelW    = 110.0
+		barW      = 260.0
+	)
+
+	rows := []struct {
+		label string
+		count int
+		color pdfColor
+	}{
+		{"High (8.0+)", bands.High, pdfHighColor},
+		{"Medium (5-8)", bands.Medium, pdfMedColor},
+		{"Low (<5)", bands.Low, pdfLowColor},
+	}
+
+	d.reserve(rowHeight*float64(len(rows)) + 8)
+	total := bands.Total()
+	for _, row := range rows {
+		d.y -= rowHeight
+		barX := pdfMargin + labelW
+		d.text(pdfMargin, d.y+1, pdfFontRegular, 10, pdfBlack, row.label)
+		d.rect(barX, d.y, barW, barHeight, pdfTrack)
+		if row.count > 0 {
+			d.rect(barX, d.y, barW*float64(row.count)/float64(total), barHeight, row.color)
+		}
+		d.text(barX+barW+8, d.y+1, pdfFontRegular, 10, pdfBlack,
+			fmt.Sprintf("%d ideas (%d%%)", row.count, row.count*100/total))
+	}
+	d.y -= 8
+}
+
+// trendChart draws the average score of each month as a vertical bar, with
+// the first and last month labeled beneath
+func (d *pdfDocument) trendChart(trends []TrendData) {
+	const (
+		chartHeight = 70.0
+		labelSpace  = 14.0
+		gap         = 3.0
+	)
+
+	if len(trends) > pdfTrendLimit {
+		trends = trends[len(trends)-pdfTrendLimit:]
+	}
+
+	width := pdfPageWidth - 2*pdfMargin
+	barW := width/float64(len(trends)) - gap
+	if barW > 28 {
+		barW = 28
+	}
+
+	d.reserve(chartHeight + labelSpace + 12)
+	d.text(pdfMargin, d.y-10, pdfFontRegular, 9, pdfGray, "Average score by month (0-10)")
+	d.y -= 14
+	base := d.y - chartHeight
+
+	d.rect(pdfMargin, base, width, 0.5, pdfGray)
+	for i, trend := range trends {
+		x := pdfMargin + float64(i)*(barW+gap)
+		height := chartHeight * clampScore(trend.AvgScore) / 10
+		d.rect(x, base, barW, height, scoreBandColor(trend.AvgScore))
+		if barW >= 18 {
+			d.text(x+2, base+height+2, pdfFontRegular, 7, pdfGray, fmt.Sprintf("%.1f", trend.AvgScore))
+		}
+	}
+
+	d.text(pdfMargin, base-labelSpace+3, pdfFontRegular, 8, pdfGray, trends[0].Period)
+	if len(trends) > 1 {
+		last := trends[len(trends)-1].Period
+		x := pdfMargin + float64(len(trends)-1)*(barW+gap) + barW - pdfTextWidth(last, 8)
+		d.text(x, base-labelSpace+3, pdfFontRegular, 8, pdfGray, last)
+	}
+	d.y = base - labelSpace - 6
+}
+
+// write numbers the pages, stamps footer on each and writes the document
+func (d *pdfDocument) write(w io.Writer, footer string) error {
+	for i, page := range d.pages {
+		d.pages[i] = page
+		y := pdfMargin
+		number := fmt.Sprintf("Page %d of %d", i+1, len(d.pages))
+		fmt.Fprintf(page, "%.3f %.3f %.3f rg BT /%s 8.0 Tf %.2f %.2f Td (%s) Tj ET\n",
+			pdfGray.r, pdfGray.g, pdfGray.b, pdfFontRegular, pdfMargin, y, pdfEncode(pdfText(footer)))
+		fmt.Fprintf(page, "%.3f %.3f %.3f rg BT /%s 8.0 Tf %.2f %.2f Td (%s) Tj ET\n",
+			pdfGray.r, pdfGray.g, pdfGray.b, pdfFontRegular,
+			pdfPageWidth-pdfMargin-pdfTextWidth(number, 8), y, number)
+	}
+
+	var buf bytes.Buffer
+	var offsets []int
+	object := func(body string) {
+		offsets = append(offsets, buf.Len())
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	buf.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+
+	// Objects 1-4 are the catalog, page tree and fonts; each page is then
+	// followed by its content stream
+	kids := make([]string, len(d.pages))
+	for i := range d.pages {
+		kids[i] = fmt.Sprintf("%d 0 R", 5+2*i)
+	}
+	object("<< /Type /Catalog /Pages 2 0 R >>")
+	object(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(d.pages)))
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>")
+	for i, page := range d.pages {
+		object(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.0f %.0f] "+
+			"/Resources << /Font << /%s 3 0 R /%s 4 0 R >> >> /Contents %d 0 R >>",
+			pdfPageWidth, pdfPageHeight, pdfFontRegular, pdfFontBold, 6+2*i))
+		object(fmt.Sprintf("<< /Length %d >>\nstream\n%s\nendstream", page.Len(), page.Bytes()))
+	}
+
+	xref := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+// scoreBandColor returns the chart color of the band score falls in
+func scoreBandColor(score float64) pdfColor {
+	switch {
+	case score >= 8.0:
+		return pdfHighColor
+	case score >= 5.0:
+		return pdfMedColor
+	default:
+		return pdfLowColor
+	}
+}
+
+func clampScore(score float64) float64 {
+	if score < 0 {
+		return 0
+	}
+	if score > 10 {
+		return 10
+	}
+	return score
+}
+
+// pdfReplacements maps common typographic characters outside Latin-1 to
+// plain equivalents
+var pdfReplacements = map[rune]string{
+	'–': "-", '—': "-", '‘': "'", '’': "'", '“': "\"", '”': "\"",
+	'•': "-", '●': "-", '…': "...", '→': "->",
+}
+
+// pdfText reduces s to the Latin-1 characters the standard fonts can show.
+// Other characters are dropped along with the spaces after them, so
+// "📈 Improving" becomes "Improving".
+func pdfText(s string) string {
+	var sb strings.Builder
+	dropped := false
+	for _, r := range s {
+		if dropped && r == ' ' {
+			continue
+		}
+		dropped = false
+
+		switch {
+		case r == '\n' || (r >= 32 && r <= 126) || (r >= 160 && r <= 255):
+			sb.WriteRune(r)
+		case pdfReplacements[r] != "":
+			sb.WriteString(pdfReplacements[r])
+		case r == '\t':
+			sb.WriteString("    ")
+		default:
+			dropped = true
+		}
+	}
+	return sb.String()
+}
+
+// pdfEncode escapes s, already reduced by pdfText, as the bytes of a PDF
+// string literal in WinAnsiEncoding
+func pdfEncode(s string) string {
+	var sb strings.Builder
+	for _, r := range s {
+		switch r {
+		case '\\', '(', ')':
+			sb.WriteByte('\\')
+			sb.WriteRune(r)
+		default:
+			sb.WriteByte(byte(r))
+		}
+	}
+	return sb.String()
+}
+
+// wrapPDFLine breaks line into pieces no wider than width points at size,
+// repeating the line's indentation on every piece. A line that fits is kept
+// as is, so aligned columns stay aligned.
+func wrapPDFLine(line string, size, width float64) []string {
+	if pdfTextWidth(line, size) <= width {
+		return []string{line}
+	}
+
+	trimmed := strings.TrimLeft(line, " ")
+	indent := line[:len(line)-len(trimmed)]
+	words := strings.Fields(trimmed)
+	if len(words) == 0 {
+		return []string{""}
+	}
+
+	var lines []string
+	current := indent + words[0]
+	for _, word := range words[1:] {
+		if pdfTextWidth(current+" "+word, size) > width {
+			lines = append(lines, current)
+			current = indent + word
+			continue
+		}
+		current += " " + word
+	}
+	return append(lines, current)
+}
+
+// pdfTextWidth estimates the width of s set in Helvetica at size points
+func pdfTextWidth(s string, size float64) float64 {
+	units := 0
+	for _, r := range s {
+		if r >= 32 && r <= 126 {
+			units += helveticaWidths[r-32]
+		} else {
+			units += 556
+		}
+	}
+	return float64(units) * size / 1000
+}
+
+// helveticaWidths are the advance widths of ASCII 32-126 in Helvetica, in
+// thousandths of the font size
+var helveticaWidths = [95]int{
+	278, 278, 355, 556, 556, 889, 667, 191, 333, 333, 389, 584, 278, 333, 278, 278,
+	556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 278, 278, 584, 584, 584, 556,
+	1015, 667, 667, 722, 722, 667, 611, 778, 722, 278, 500, 667, 556, 833, 722, 778,
+	667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 278, 278, 278, 469, 556,
+	333, 556, 556, 500, 556, 556, 278, 556, 556, 222, 222, 500, 222, 833, 556, 556,
+	556, 556, 333, 500, 278, 556, 500, 722, 500, 500, 500, 334, 260, 334, 584,
+}
@@ -0,0 +1,134 @@
+package analytics
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ryacub/telos-idea-matrix/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// assertValidPDF checks the document's framing and that every xref entry
+// points at the object it names
+func assertValidPDF(t *testing.T, pdf []byte) {
+	t.Helper()
+
+	require.True(t, bytes.HasPrefix(pdf, []byte("%PDF-1.4\n")))
+	require.True(t, bytes.HasSuffix(pdf, []byte("%%EOF\n")))
+
+	startxref := regexp.MustCompile(`startxref\n(\d+)\n`).FindSubmatch(pdf)
+	require.NotNil(t, startxref)
+	xref, err := strconv.Atoi(string(startxref[1]))
+	require.NoError(t, err)
+	require.True(t, bytes.HasPrefix(pdf[xref:], []byte("xref\n")))
+
+	entries := regexp.MustCompile(`(\d{10}) 00000 n `).FindAllSubmatch(pdf[xref:], -1)
+	require.NotEmpty(t, entries)
+	for i, entry := range entries {
+		offset, err := strconv.Atoi(string(entry[1]))
+		require.NoError(t, err)
+		assert.True(t, bytes.HasPrefix(pdf[offset:], []byte(fmt.Sprintf("%d 0 obj\n", i+1))),
+			"xref entry %d points at the wrong object", i+1)
+	}
+}
+
+func pageCount(t *testing.T, pdf []byte) int {
+	t.Helper()
+	match := regexp.MustCompile(`/Count (\d+)`).FindSubmatch(pdf)
+	require.NotNil(t, match)
+	count, err := strconv.Atoi(string(match[1]))
+	require.NoError(t, err)
+	return count
+}
+
+func TestRenderReportPDF(t *testing.T) {
+	baseTime := time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC)
+	ideas := []*models.Idea{
+		{ID: "1", FinalScore: 9.0, CreatedAt: baseTime},
+		{ID: "2", FinalScore: 6.5, CreatedAt: baseTime.AddDate(0, 1, 0)},
+		{ID: "3", FinalScore: 3.0, CreatedAt: baseTime.AddDate(0, 2, 0)},
+	}
+	report := GenerateReport(ideas)
+
+	var buf bytes.Buffer
+	require.NoError(t, RenderReportPDF(report, &buf))
+	pdf := buf.Bytes()
+
+	assertValidPDF(t, pdf)
+	pages := pageCount(t, pdf)
+	assert.Contains(t, string(pdf), "(Telos Idea Matrix Analytics Report) Tj")
+	assert.Contains(t, string(pdf), "(Score Distribution) Tj")
+	assert.Contains(t, string(pdf), "(Recommendations) Tj")
+	assert.Contains(t, string(pdf), "(High \\(8.0+\\)) Tj", "distribution chart labels are escaped")
+	assert.Contains(t, string(pdf), "(Average score by month \\(0-10\\)) Tj")
+	assert.Contains(t, string(pdf), "(  High Score \\(8.0+\\):   1 ideas \\(33%\\)) Tj", "aligned text keeps its spacing")
+	assert.Contains(t, string(pdf), fmt.Sprintf("(Page 1 of %d) Tj", pages))
+	assert.NotContains(t, string(pdf), "() Tj", "blank lines are skipped")
+	assert.NotContains(t, string(pdf), "🔥", "emoji can't be shown by the standard fonts")
+}
+
+func TestRenderReportPDF_Paginates(t *testing.T) {
+	report := Report{
+		Title:       "Long Report",
+		Summary:     "Many sections",
+		GeneratedAt: time.Date(2024, 3, 15, 12, 0, 0, 0, time.UTC),
+	}
+	for i := 0; i < 20; i++ {
+		report.Sections = append(report.Sections, ReportSection{
+			Title:   fmt.Sprintf("Section %d", i+1),
+			Content: strings.Repeat("A fairly long line of report text that needs wrapping on the page. ", 6),
+		})
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, RenderReportPDF(report, &buf))
+	pdf := buf.Bytes()
+
+	assertValidPDF(t, pdf)
+	pages := pageCount(t, pdf)
+	assert.Greater(t, pages, 1)
+	assert.Contains(t, string(pdf), fmt.Sprintf("(Page %d of %d) Tj", pages, pages))
+	assert.Contains(t, string(pdf), "(Section 20) Tj")
+}
+
+func TestRenderReportPDF_EmptyReport(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, RenderReportPDF(GenerateReport(nil), &buf))
+
+	assertValidPDF(t, buf.Bytes())
+	assert.Contains(t, buf.String(), "(No ideas found. Start capturing ideas to see analytics!) Tj")
+}
+
+func TestPDFText(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"plain text", "plain text"},
+		{"Trend Direction: 📈 Improving", "Trend Direction: Improving"},
+		{"1. ⚠️  Review alignment", "1. Review alignment"},
+		{"café – “quoted”", "café - \"quoted\""},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, pdfText(tt.in))
+	}
+}
+
+func TestWrapPDFLine(t *testing.T) {
+	line := "  " + strings.Repeat("word ", 40)
+	lines := wrapPDFLine(line, 10, 200)
+
+	require.Greater(t, len(lines), 1)
+	for _, l := range lines {
+		assert.True(t, strings.HasPrefix(l, "  word"), "indentation kept: %q", l)
+		assert.LessOrEqual(t, pdfTextWidth(l, 10), 200.0)
+	}
+	assert.Equal(t, 40, strings.Count(strings.Join(lines, " "), "word"))
+}
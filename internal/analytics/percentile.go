@@ -0,0 +1,34 @@
+package analytics
+
+import (
+	"fmt"
+	"math"
+)
+
+// Percentile returns the percentile rank of score within all, from 0 to 100:
+// the share of scores below it, counting scores equal to it as half below.
+// A score in the middle of the distribution is at the 50th percentile.
+// It returns 0 when all is empty.
+func Percentile(score float64, all []float64) float64 {
+	if len(all) == 0 {
+		return 0
+	}
+
+	var below, equal int
+	for _, s := range all {
+		switch {
+		case s < score:
+			below++
+		case s == score:
+			equal++
+		}
+	}
+	return (float64(below) + float64(equal)/2) / float64(len(all)) * 100
+}
+
+// RelativeLabel describes a percentile as the share of ideas at or above it,
+// e.g. "top 15%"
+func RelativeLabel(percentile float64) string {
+	top := int(math.Ceil(100 - percentile))
+	return fmt.Sprintf("top %d%%", max(min(top, 100), 1))
+}
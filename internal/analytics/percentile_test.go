@@ -0,0 +1,37 @@
+package analytics
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestPercentile_KnownDistribution tests percentile ranks against scores 1 through 10
+func TestPercentile_KnownDistribution(t *testing.T) {
+	all := []float64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+
+	// Six scores are below 7.0 and one equals it: (6 + 0.5) / 10
+	assert.InDelta(t, 65.0, Percentile(7.0, all), 0.001)
+	assert.InDelta(t, 95.0, Percentile(10.0, all), 0.001)
+	assert.InDelta(t, 5.0, Percentile(1.0, all), 0.001)
+
+	// Scores outside the distribution sit at either end
+	assert.InDelta(t, 100.0, Percentile(10.5, all), 0.001)
+	assert.InDelta(t, 0.0, Percentile(0.5, all), 0.001)
+}
+
+// TestPercentile_TiesAndEmpty tests repeated scores and an empty distribution
+func TestPercentile_TiesAndEmpty(t *testing.T) {
+	assert.InDelta(t, 50.0, Percentile(7.0, []float64{7, 7, 7, 7}), 0.001)
+	assert.InDelta(t, 75.0, Percentile(8.0, []float64{5, 8}), 0.001)
+	assert.Zero(t, Percentile(7.0, nil))
+}
+
+// TestRelativeLabel tests that percentiles are described as the share of ideas at the top
+func TestRelativeLabel(t *testing.T) {
+	assert.Equal(t, "top 35%", RelativeLabel(65))
+	assert.Equal(t, "top 15%", RelativeLabel(85))
+	assert.Equal(t, "top 16%", RelativeLabel(84.5))
+	assert.Equal(t, "top 1%", RelativeLabel(100))
+	assert.Equal(t, "top 100%", RelativeLabel(0))
+}
@@ -0,0 +1,140 @@
+package analytics
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ryacub/telos-idea-matrix/internal/models"
+	"github.com/ryacub/telos-idea-matrix/internal/patterns"
+)
+
+// RankWeights sets how much each component counts towards an idea's rank.
+// Only the ratio between weights matters.
+type RankWeights struct {
+	Score   float64 `json:"score"`   // Final score
+	Recency float64 `json:"recency"` // How recently the idea was captured
+	Pattern float64 `json:"pattern"` // Absence of anti-patterns
+}
+
+// DefaultRankWeights favors the score, with enough weight on recency and
+// patterns to lift fresh ideas above stale ones scored about the same
+func DefaultRankWeights() RankWeights {
+	return RankWeights{Score: 0.6, Recency: 0.25, Pattern: 0.15}
+}
+
+// Validate checks that no weight is negative and at least one is positive
+func (w RankWeights) Validate() error {
+	if w.Score < 0 || w.Recency < 0 || w.Pattern < 0 {
+		return errors.New("rank weights must not be negative")
+	}
+	if w.Score+w.Recency+w.Pattern == 0 {
+		return errors.New("at least one rank weight must be positive")
+	}
+	return nil
+}
+
+// RankedIdea is an idea with its normalized component scores, each from 0
+// to 1, and the weighted composite of them
+type RankedIdea struct {
+	ID         string    `json:"id"`
+	Content    string    `json:"content"`
+	FinalScore float64   `json:"final_score"`
+	CreatedAt  time.Time `json:"created_at"`
+	Score      float64   `json:"score"`     // Final score out of 10
+	Recency    float64   `json:"recency"`   // 1 for the newest idea, 0 for the oldest
+	Pattern    float64   `json:"pattern"`   // 1 with no anti-patterns, lower with each one
+	Composite  float64   `json:"composite"` // Weighted average of the components
+}
+
+// RankIdeas ranks ideas by a weighted combination of final score, recency
+// and pattern desirability, best first.
+//
+// Recency is relative to the ideas given: the newest scores 1 and the oldest
+// 0, in proportion to when they were captured. Each distinct anti-pattern on
+// an idea lowers its pattern score and each positive pattern offsets one, so
+// an idea with one anti-pattern scores 0.5 and one with two scores 0.33.
+// Ties are broken by final score, then by the newer idea.
+func RankIdeas(ideas []*models.Idea, weights RankWeights) []RankedIdea {
+	ranked := make([]RankedIdea, 0, len(ideas))
+	if len(ideas) == 0 {
+		return ranked
+	}
+
+	oldest, newest := ideas[0].CreatedAt, ideas[0].CreatedAt
+	for _, idea := range ideas[1:] {
+		if idea.CreatedAt.Before(oldest) {
+			oldest = idea.CreatedAt
+		}
+		if idea.CreatedAt.After(newest) {
+			newest = idea.CreatedAt
+		}
+	}
+	span := newest.Sub(oldest)
+
+	total := weights.Score + weights.Recency + weights.Pattern
+	for _, idea := range ideas {
+		r := RankedIdea{
+			ID:         idea.ID,
+			Content:    idea.Content,
+			FinalScore: idea.FinalScore,
+			CreatedAt:  idea.CreatedAt,
+			Score:      min(max(idea.FinalScore/10, 0), 1),
+			Recency:    1,
+			Pattern:    patternDesirability(idea.Patterns),
+		}
+		if span > 0 {
+			r.Recency = float64(idea.CreatedAt.Sub(oldest)) / float64(span)
+		}
+		if total > 0 {
+			r.Composite = (weights.Score*r.Score + weights.Recency*r.Recency + weights.Pattern*r.Pattern) / total
+		}
+		ranked = append(ranked, r)
+	}
+
+	sort.SliceStable(ranked, func(i, j int) bool {
+		if ranked[i].Composite != ranked[j].Composite {
+			return ranked[i].Composite > ranked[j].Composite
+		}
+		if ranked[i].FinalScore != ranked[j].FinalScore {
+			return ranked[i].FinalScore > ranked[j].FinalScore
+		}
+		return ranked[i].CreatedAt.After(ranked[j].CreatedAt)
+	})
+
+	return ranked
+}
+
+// patternDesirability scores an idea's patterns from 0 to 1. Patterns are
+// counted once per name and kind, so re-detections don't compound.
+func patternDesirability(stored []string) float64 {
+	var anti, positive int
+	seen := make(map[string]bool, len(stored))
+	for _, raw := range stored {
+		name := strings.ToLower(patternName(raw))
+		if name == "" {
+			continue
+		}
+		isPositive := patterns.IsPositive(raw)
+		key := name
+		if isPositive {
+			key += "+"
+		}
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		if isPositive {
+			positive++
+		} else {
+			anti++
+		}
+	}
+
+	if anti == 0 {
+		return 1
+	}
+	return float64(positive+1) / float64(positive+anti+1)
+}
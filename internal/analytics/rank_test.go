@@ -0,0 +1,83 @@
+package analytics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ryacub/telos-idea-matrix/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRankIdeas_FreshIdeasBeatStaleHighScorers(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	ideas := []*models.Idea{
+		{ID: "stale", Content: "Old favorite", FinalScore: 8.0, CreatedAt: now.AddDate(0, 0, -90)},
+		{ID: "fresh", Content: "New idea", FinalScore: 7.5, CreatedAt: now},
+		{ID: "middle", Content: "Last month", FinalScore: 6.0, CreatedAt: now.AddDate(0, 0, -45),
+			Patterns: []string{"Perfectionism: Scope creep risk - over-engineering detected"}},
+	}
+
+	ranked := RankIdeas(ideas, DefaultRankWeights())
+
+	require.Len(t, ranked, 3)
+	assert.Equal(t, []string{"fresh", "stale", "middle"}, []string{ranked[0].ID, ranked[1].ID, ranked[2].ID})
+
+	fresh := ranked[0]
+	assert.InDelta(t, 0.75, fresh.Score, 1e-9)
+	assert.InDelta(t, 1.0, fresh.Recency, 1e-9)
+	assert.InDelta(t, 1.0, fresh.Pattern, 1e-9)
+	assert.InDelta(t, 0.6*0.75+0.25+0.15, fresh.Composite, 1e-9)
+
+	assert.InDelta(t, 0.0, ranked[1].Recency, 1e-9)
+
+	// The anti-pattern keeps the middle idea below the stale one
+	middle := ranked[2]
+	assert.InDelta(t, 0.5, middle.Recency, 1e-9)
+	assert.InDelta(t, 0.5, middle.Pattern, 1e-9)
+
+	// With only the score weighted, the order is the plain score order
+	ranked = RankIdeas(ideas, RankWeights{Score: 1})
+	assert.Equal(t, []string{"stale", "fresh", "middle"}, []string{ranked[0].ID, ranked[1].ID, ranked[2].ID})
+	assert.InDelta(t, 0.8, ranked[0].Composite, 1e-9)
+}
+
+func TestRankIdeas_SingleIdeaAndEmpty(t *testing.T) {
+	assert.Empty(t, RankIdeas(nil, DefaultRankWeights()))
+
+	ranked := RankIdeas([]*models.Idea{{ID: "only", FinalScore: 12, CreatedAt: time.Now()}}, DefaultRankWeights())
+	require.Len(t, ranked, 1)
+	assert.Equal(t, 1.0, ranked[0].Score, "scores are capped at 10")
+	assert.Equal(t, 1.0, ranked[0].Recency, "a lone idea is the newest")
+}
+
+func TestPatternDesirability(t *testing.T) {
+	tests := []struct {
+		name     string
+		patterns []string
+		want     float64
+	}{
+		{"none", nil, 1},
+		{"one anti-pattern", []string{"Perfectionism: Scope creep risk - over-engineering detected"}, 0.5},
+		{"two anti-patterns", []string{"Perfectionism: x", "Procrastination: y"}, 1.0 / 3},
+		{"repeated anti-pattern counts once", []string{"Perfectionism: x", "perfectionism: y"}, 0.5},
+		{"positive offsets an anti-pattern", []string{
+			"Perfectionism: x",
+			"Accountability avoidance: External accountability component detected - building in public or with customers",
+		}, 2.0 / 3},
+		{"positive only", []string{"Context switching: Staying focused on current tech stack"}, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.InDelta(t, tt.want, patternDesirability(tt.patterns), 1e-9)
+		})
+	}
+}
+
+func TestRankWeights_Validate(t *testing.T) {
+	assert.NoError(t, DefaultRankWeights().Validate())
+	assert.NoError(t, RankWeights{Recency: 1}.Validate())
+	assert.Error(t, RankWeights{}.Validate())
+	assert.Error(t, RankWeights{Score: 1, Pattern: -0.5}.Validate())
+}
@@ -9,12 +9,60 @@ import (
 	"github.com/ryacub/telos-idea-matrix/internal/models"
 )
 
+// Report section titles that renderers can draw charts for
+const (
+	SectionScoreDistribution = "Score Distribution"
+	SectionTrends            = "Trends"
+)
+
 // Report represents a comprehensive analytics report
 type Report struct {
 	Title       string
 	Summary     string
 	Sections    []ReportSection
 	GeneratedAt time.Time
+
+	// Chart data behind the sections, for renderers that draw rather than
+	// print them
+	Distribution ScoreBands
+	Trends       []TrendData // Monthly, oldest first
+}
+
+// ScoreBands counts ideas by recommendation band
+type ScoreBands struct {
+	High    int     // 8.0 and above
+	Medium  int     // 5.0 up to 8.0
+	Low     int     // Below 5.0
+	Average float64 // Mean score across all bands
+}
+
+// Total returns the number of ideas counted
+func (b ScoreBands) Total() int {
+	return b.High + b.Medium + b.Low
+}
+
+// CountScoreBands sorts ideas into high, medium and low score bands
+func CountScoreBands(ideas []*models.Idea) ScoreBands {
+	var bands ScoreBands
+	if len(ideas) == 0 {
+		return bands
+	}
+
+	totalScore := 0.0
+	for _, idea := range ideas {
+		totalScore += idea.FinalScore
+
+		if idea.FinalScore >= 8.0 {
+			bands.High++
+		} else if idea.FinalScore >= 5.0 {
+			bands.Medium++
+		} else {
+			bands.Low++
+		}
+	}
+	bands.Average = totalScore / float64(len(ideas))
+
+	return bands
 }
 
 // ReportSection represents a section in the report
@@ -43,11 +91,14 @@ func GenerateReport(ideas []*models.Idea) Report {
 		time.Now().Format("2006-01-02 15:04"),
 	)
 
+	report.Distribution = CountScoreBands(ideas)
+	report.Trends = CalculateScoreTrends(ideas, "month")
+
 	// Add score distribution section
-	report.Sections = append(report.Sections, generateScoreDistribution(ideas))
+	report.Sections = append(report.Sections, generateScoreDistribution(report.Distribution))
 
 	// Add trends section
-	report.Sections = append(report.Sections, generateTrendsSection(ideas))
+	report.Sections = append(report.Sections, generateTrendsSection(report.Trends))
 
 	// Add pattern analysis section
 	report.Sections = append(report.Sections, generatePatternSection(ideas))
@@ -62,25 +113,8 @@ func GenerateReport(ideas []*models.Idea) Report {
 }
 
 // generateScoreDistribution creates the score distribution section
-func generateScoreDistribution(ideas []*models.Idea) ReportSection {
-	high := 0   // >= 8.0
-	medium := 0 // 5.0 - 8.0
-	low := 0    // < 5.0
-
-	totalScore := 0.0
-	for _, idea := range ideas {
-		totalScore += idea.FinalScore
-
-		if idea.FinalScore >= 8.0 {
-			high++
-		} else if idea.FinalScore >= 5.0 {
-			medium++
-		} else {
-			low++
-		}
-	}
-
-	avgScore := totalScore / float64(len(ideas))
+func generateScoreDistribution(bands ScoreBands) ReportSection {
+	total := bands.Total()
 
 	content := fmt.Sprintf(`
 Average Score: %.1f/10.0
@@ -90,25 +124,23 @@ Distribution:
   ✅ Medium Score (5-8):  %d ideas (%d%%)
   🚫 Low Score (<5):      %d ideas (%d%%)
 `,
-		avgScore,
-		high, (high*100)/len(ideas),
-		medium, (medium*100)/len(ideas),
-		low, (low*100)/len(ideas),
+		bands.Average,
+		bands.High, (bands.High*100)/total,
+		bands.Medium, (bands.Medium*100)/total,
+		bands.Low, (bands.Low*100)/total,
 	)
 
 	return ReportSection{
-		Title:   "Score Distribution",
+		Title:   SectionScoreDistribution,
 		Content: strings.TrimSpace(content),
 	}
 }
 
-// generateTrendsSection creates the trends analysis section
-func generateTrendsSection(ideas []*models.Idea) ReportSection {
-	trends := CalculateScoreTrends(ideas, "month")
-
+// generateTrendsSection creates the trends analysis section from monthly trends
+func generateTrendsSection(trends []TrendData) ReportSection {
 	if len(trends) == 0 {
 		return ReportSection{
-			Title:   "Trends",
+			Title:   SectionTrends,
 			Content: "No trend data available yet.",
 		}
 	}
@@ -139,7 +171,7 @@ func generateTrendsSection(ideas []*models.Idea) ReportSection {
 	content.WriteString(fmt.Sprintf("\nTrend Direction: %s\n", directionMsg))
 
 	return ReportSection{
-		Title:   "Trends",
+		Title:   SectionTrends,
 		Content: content.String(),
 	}
 }
@@ -74,7 +74,7 @@ func TestGenerateScoreDistribution(t *testing.T) {
 		{ID: "10", FinalScore: 7.5}, // medium
 	}
 
-	section := generateScoreDistribution(ideas)
+	section := generateScoreDistribution(CountScoreBands(ideas))
 
 	assert.Equal(t, "Score Distribution", section.Title)
 	assert.Contains(t, section.Content, "High Score (8.0+):   3 ideas (30%)")
@@ -93,7 +93,7 @@ func TestGenerateTrendsSection(t *testing.T) {
 		{ID: "3", FinalScore: 9.0, CreatedAt: baseTime.AddDate(0, 2, 0)},
 	}
 
-	section := generateTrendsSection(ideas)
+	section := generateTrendsSection(CalculateScoreTrends(ideas, "month"))
 
 	assert.Equal(t, "Trends", section.Title)
 	assert.Contains(t, section.Content, "Score Trends by Month")
@@ -106,7 +106,7 @@ func TestGenerateTrendsSection(t *testing.T) {
 func TestGenerateTrendsSection_EmptyIdeas(t *testing.T) {
 	ideas := []*models.Idea{}
 
-	section := generateTrendsSection(ideas)
+	section := generateTrendsSection(CalculateScoreTrends(ideas, "month"))
 
 	assert.Equal(t, "Trends", section.Title)
 	assert.Contains(t, section.Content, "No trend data available")
@@ -135,6 +135,7 @@ type TimeMetrics struct {
 	NewestIdea      time.Time `json:"newest_idea"`
 	TotalDays       int       `json:"total_days"`
 	IdeasPerDay     float64   `json:"ideas_per_day"`
+	IdeasToday      int       `json:"ideas_today"`
 	IdeasLast7Days  int       `json:"ideas_last_7_days"`
 	IdeasLast30Days int       `json:"ideas_last_30_days"`
 }
@@ -310,13 +311,18 @@ func (s *Service) CalculateTimeMetrics(ideas []*models.Idea) TimeMetrics {
 	}
 
 	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
 	last7Days := now.AddDate(0, 0, -7)
 	last30Days := now.AddDate(0, 0, -30)
 
+	countToday := 0
 	count7Days := 0
 	count30Days := 0
 
 	for _, idea := range ideas {
+		if !idea.CreatedAt.Before(today) {
+			countToday++
+		}
 		if idea.CreatedAt.After(last7Days) {
 			count7Days++
 		}
@@ -330,6 +336,7 @@ func (s *Service) CalculateTimeMetrics(ideas []*models.Idea) TimeMetrics {
 		NewestIdea:      newest,
 		TotalDays:       totalDays,
 		IdeasPerDay:     float64(len(ideas)) / float64(totalDays),
+		IdeasToday:      countToday,
 		IdeasLast7Days:  count7Days,
 		IdeasLast30Days: count30Days,
 	}
@@ -0,0 +1,31 @@
+package analytics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ryacub/telos-idea-matrix/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCalculateTimeMetrics_CountsToday(t *testing.T) {
+	now := time.Now()
+	midnight := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	ideas := []*models.Idea{
+		{ID: "1", CreatedAt: midnight},
+		{ID: "2", CreatedAt: now},
+		{ID: "3", CreatedAt: midnight.Add(-time.Minute)},
+		{ID: "4", CreatedAt: now.AddDate(0, 0, -10)},
+	}
+
+	metrics := NewService(nil).CalculateTimeMetrics(ideas)
+
+	assert.Equal(t, 2, metrics.IdeasToday)
+	assert.Equal(t, 3, metrics.IdeasLast7Days)
+	assert.Equal(t, 4, metrics.IdeasLast30Days)
+}
+
+func TestCalculateTimeMetrics_Empty(t *testing.T) {
+	assert.Equal(t, TimeMetrics{}, NewService(nil).CalculateTimeMetrics(nil))
+}
@@ -0,0 +1,113 @@
+package analytics
+
+import (
+	"fmt"
+
+	"github.com/ryacub/telos-idea-matrix/internal/database"
+	"github.com/ryacub/telos-idea-matrix/internal/models"
+)
+
+// summaryTopPatterns is the number of patterns kept in the materialized summary
+const summaryTopPatterns = 10
+
+// BuildSummary computes the analytics summary for the given ideas
+func (s *Service) BuildSummary(ideas []*models.Idea) *database.AnalyticsSummary {
+	summary := &database.AnalyticsSummary{
+		TotalIdeas:   len(ideas),
+		ScoreBuckets: s.CalculateScoreDistribution(ideas).Buckets,
+		TopPatterns:  []database.PatternCount{},
+	}
+
+	if len(ideas) == 0 {
+		return summary
+	}
+
+	scores := make([]float64, len(ideas))
+	summary.HighScore = ideas[0].FinalScore
+	summary.LowScore = ideas[0].FinalScore
+	sum := 0.0
+
+	for i, idea := range ideas {
+		scores[i] = idea.FinalScore
+		sum += idea.FinalScore
+		if idea.Status == "active" {
+			summary.ActiveIdeas++
+		}
+		if idea.FinalScore > summary.HighScore {
+			summary.HighScore = idea.FinalScore
+		}
+		if idea.FinalScore < summary.LowScore {
+			summary.LowScore = idea.FinalScore
+		}
+	}
+
+	summary.AverageScore = sum / float64(len(ideas))
+	summary.MedianScore = CalculateMedian(scores)
+
+	for i, ps := range s.CalculatePatternStats(ideas) {
+		if i >= summaryTopPatterns {
+			break
+		}
+		summary.TopPatterns = append(summary.TopPatterns, database.PatternCount{
+			Pattern: ps.Pattern,
+			Count:   ps.Count,
+		})
+	}
+
+	return summary
+}
+
+// RefreshSummary recomputes the materialized analytics summary from all ideas and stores it
+func (s *Service) RefreshSummary() (*database.AnalyticsSummary, error) {
+	current, err := s.repo.GetAnalyticsSummary()
+	if err != nil {
+		return nil, err
+	}
+
+	ideas, err := s.repo.List(database.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list ideas: %w", err)
+	}
+
+	summary := s.BuildSummary(ideas)
+	if err := s.repo.SaveAnalyticsSummary(summary, current.ChangeCount); err != nil {
+		return nil, err
+	}
+
+	return summary, nil
+}
+
+// RefreshSummaryIfStale refreshes the materialized summary only when ideas have
+// changed since the last refresh. It reports whether a refresh happened.
+func (s *Service) RefreshSummaryIfStale() (bool, error) {
+	current, err := s.repo.GetAnalyticsSummary()
+	if err != nil {
+		return false, err
+	}
+	if !current.Stale && current.RefreshedAt != nil {
+		return false, nil
+	}
+
+	if _, err := s.RefreshSummary(); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// GetSummary returns the materialized analytics summary, refreshing it first when
+// it is stale. When fresh is true the summary is always recomputed live.
+func (s *Service) GetSummary(fresh bool) (*database.AnalyticsSummary, error) {
+	if fresh {
+		return s.RefreshSummary()
+	}
+
+	summary, err := s.repo.GetAnalyticsSummary()
+	if err != nil {
+		return nil, err
+	}
+	if summary.Stale || summary.RefreshedAt == nil {
+		return s.RefreshSummary()
+	}
+
+	return summary, nil
+}
@@ -27,20 +27,7 @@ func CalculateScoreTrends(ideas []*models.Idea, groupBy string) []TrendData {
 
 	// Group ideas by time period
 	for _, idea := range ideas {
-		var key string
-		switch groupBy {
-		case "week":
-			year, week := idea.CreatedAt.ISOWeek()
-			key = fmt.Sprintf("%d-W%02d", year, week)
-		case "month":
-			key = idea.CreatedAt.Format("2006-01")
-		case "day":
-			key = idea.CreatedAt.Format("2006-01-02")
-		default:
-			// Default to week if invalid groupBy
-			year, week := idea.CreatedAt.ISOWeek()
-			key = fmt.Sprintf("%d-W%02d", year, week)
-		}
+		key := periodKey(idea.CreatedAt, groupBy)
 		groups[key] = append(groups[key], idea)
 	}
 
@@ -69,6 +56,20 @@ func CalculateScoreTrends(ideas []*models.Idea, groupBy string) []TrendData {
 	return trends
 }
 
+// periodKey names the period t falls in; groupBy can be "day", "week", or "month"
+func periodKey(t time.Time, groupBy string) string {
+	switch groupBy {
+	case "month":
+		return t.Format("2006-01")
+	case "day":
+		return t.Format("2006-01-02")
+	default:
+		// Default to week if invalid groupBy
+		year, week := t.ISOWeek()
+		return fmt.Sprintf("%d-W%02d", year, week)
+	}
+}
+
 // CalculatePatternFrequency counts how often each pattern appears across all ideas
 func CalculatePatternFrequency(ideas []*models.Idea) map[string]int {
 	freq := make(map[string]int)
@@ -171,3 +172,24 @@ func CalculateTrendDirection(trends []TrendData) string {
 	}
 	return "neutral"
 }
+
+// MovingAverage smooths values with a trailing moving average over window
+// periods. The first periods, and every period when the window is longer
+// than the series, average only the values available so far, so the result
+// is as long as values. A window of 1 or less returns values unchanged.
+func MovingAverage(values []float64, window int) []float64 {
+	if window <= 1 {
+		return values
+	}
+
+	smoothed := make([]float64, len(values))
+	sum := 0.0
+	for i, v := range values {
+		sum += v
+		if i >= window {
+			sum -= values[i-window]
+		}
+		smoothed[i] = sum / float64(min(i+1, window))
+	}
+	return smoothed
+}
@@ -336,3 +336,18 @@ func TestTrends_TopPatterns(t *testing.T) {
 	assert.Contains(t, []string{"pattern-c", "pattern-d"}, topPatterns[2],
 		"third pattern should be pattern-c or pattern-d")
 }
+
+func TestMovingAverage(t *testing.T) {
+	values := []float64{2, 8, 5, 5, 9}
+
+	smoothed := MovingAverage(values, 3)
+	require.Len(t, smoothed, len(values))
+	assert.InDeltaSlice(t, []float64{2, 5, 5, 6, 19.0 / 3}, smoothed, 1e-9)
+
+	// A window longer than the series is a running average
+	assert.InDeltaSlice(t, []float64{2, 5, 5, 5, 5.8}, MovingAverage(values, 10), 1e-9)
+
+	assert.Equal(t, values, MovingAverage(values, 1))
+	assert.Equal(t, values, MovingAverage(values, 0))
+	assert.Empty(t, MovingAverage(nil, 3))
+}
@@ -0,0 +1,71 @@
+package analytics
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/ryacub/telos-idea-matrix/internal/models"
+)
+
+// NoTrigger is the bucket name for ideas captured without a trigger
+const NoTrigger = "(no trigger)"
+
+// TriggerStat summarizes the ideas that share a trigger
+type TriggerStat struct {
+	Trigger   string  `json:"trigger"`
+	IdeaCount int     `json:"idea_count"`
+	AvgScore  float64 `json:"avg_score"`
+	HighScore float64 `json:"high_score"`
+}
+
+// CalculateTriggerStats buckets ideas by trigger and computes the average score of each bucket.
+// Triggers are grouped case-insensitively, keeping the first spelling seen; ideas without a
+// trigger are grouped under NoTrigger. Results are sorted by average score (descending).
+func CalculateTriggerStats(ideas []*models.Idea) []TriggerStat {
+	type bucket struct {
+		name  string
+		count int
+		sum   float64
+		high  float64
+	}
+
+	buckets := make(map[string]*bucket)
+	for _, idea := range ideas {
+		name := strings.TrimSpace(idea.Trigger)
+		if name == "" {
+			name = NoTrigger
+		}
+		key := strings.ToLower(name)
+
+		b, ok := buckets[key]
+		if !ok {
+			b = &bucket{name: name, high: idea.FinalScore}
+			buckets[key] = b
+		}
+		b.count++
+		b.sum += idea.FinalScore
+		if idea.FinalScore > b.high {
+			b.high = idea.FinalScore
+		}
+	}
+
+	stats := make([]TriggerStat, 0, len(buckets))
+	for _, b := range buckets {
+		stats = append(stats, TriggerStat{
+			Trigger:   b.name,
+			IdeaCount: b.count,
+			AvgScore:  b.sum / float64(b.count),
+			HighScore: b.high,
+		})
+	}
+
+	// Sort by average score (descending), then by name for consistency
+	sort.Slice(stats, func(i, j int) bool {
+		if stats[i].AvgScore == stats[j].AvgScore {
+			return stats[i].Trigger < stats[j].Trigger
+		}
+		return stats[i].AvgScore > stats[j].AvgScore
+	})
+
+	return stats
+}
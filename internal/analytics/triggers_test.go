@@ -0,0 +1,45 @@
+package analytics
+
+import (
+	"testing"
+
+	"github.com/ryacub/telos-idea-matrix/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestCalculateTriggerStats_BucketsByTrigger tests grouping and average scores per trigger
+func TestCalculateTriggerStats_BucketsByTrigger(t *testing.T) {
+	ideas := []*models.Idea{
+		{FinalScore: 8.0, Trigger: "Competitor launch"},
+		{FinalScore: 6.0, Trigger: "competitor launch "},
+		{FinalScore: 9.0, Trigger: "Customer request"},
+		{FinalScore: 3.0, Trigger: "Shower thought"},
+		{FinalScore: 5.0, Trigger: "Shower thought"},
+		{FinalScore: 2.0},
+	}
+
+	stats := CalculateTriggerStats(ideas)
+	require.Len(t, stats, 4)
+
+	assert.Equal(t, "Customer request", stats[0].Trigger)
+	assert.Equal(t, 1, stats[0].IdeaCount)
+	assert.InDelta(t, 9.0, stats[0].AvgScore, 0.001)
+
+	assert.Equal(t, "Competitor launch", stats[1].Trigger)
+	assert.Equal(t, 2, stats[1].IdeaCount)
+	assert.InDelta(t, 7.0, stats[1].AvgScore, 0.001)
+	assert.InDelta(t, 8.0, stats[1].HighScore, 0.001)
+
+	assert.Equal(t, "Shower thought", stats[2].Trigger)
+	assert.InDelta(t, 4.0, stats[2].AvgScore, 0.001)
+
+	assert.Equal(t, NoTrigger, stats[3].Trigger)
+	assert.Equal(t, 1, stats[3].IdeaCount)
+	assert.InDelta(t, 2.0, stats[3].AvgScore, 0.001)
+}
+
+// TestCalculateTriggerStats_Empty tests that no ideas produce no buckets
+func TestCalculateTriggerStats_Empty(t *testing.T) {
+	assert.Empty(t, CalculateTriggerStats(nil))
+}
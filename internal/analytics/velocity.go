@@ -0,0 +1,121 @@
+package analytics
+
+import (
+	"sort"
+	"time"
+
+	"github.com/ryacub/telos-idea-matrix/internal/models"
+)
+
+// daysPerMonth is the average length of a calendar month
+const daysPerMonth = 365.25 / 12
+
+// VelocityReport describes how often ideas are captured
+type VelocityReport struct {
+	Ideas int `json:"ideas"`
+
+	// SpanDays counts calendar days from the first capture through the last,
+	// inclusive; ActiveDays counts the days with at least one capture
+	SpanDays   int `json:"span_days"`
+	ActiveDays int `json:"active_days"`
+
+	// Capture rates over SpanDays. A span shorter than a week or month
+	// counts as one, so a single day of ideas isn't scaled up.
+	PerDay   float64 `json:"per_day"`
+	PerWeek  float64 `json:"per_week"`
+	PerMonth float64 `json:"per_month"`
+
+	// Streaks are runs of consecutive active days. The current streak ends
+	// today, or yesterday if nothing has been captured yet today.
+	LongestStreak int `json:"longest_streak"`
+	CurrentStreak int `json:"current_streak"`
+
+	// ByWeekday counts captures per day of the week, indexed by
+	// time.Weekday (Sunday first); ByHour counts them per hour of the day
+	ByWeekday [7]int  `json:"by_weekday"`
+	ByHour    [24]int `json:"by_hour"`
+
+	// Most productive day of the week and hour of the day; ties go to the
+	// earlier one. Empty and zero with no ideas.
+	BusiestWeekday string `json:"busiest_weekday,omitempty"`
+	BusiestHour    int    `json:"busiest_hour"`
+}
+
+// CalculateVelocity computes capture rates, streaks and the busiest day of
+// the week and hour of the day from each idea's CreatedAt, in local time.
+func CalculateVelocity(ideas []*models.Idea) VelocityReport {
+	return calculateVelocity(ideas, time.Now().Local())
+}
+
+// calculateVelocity computes the velocity report in now's location, with the
+// current streak measured back from now
+func calculateVelocity(ideas []*models.Idea, now time.Time) VelocityReport {
+	report := VelocityReport{Ideas: len(ideas)}
+	if len(ideas) == 0 {
+		return report
+	}
+
+	loc := now.Location()
+	active := make(map[time.Time]bool)
+	for _, idea := range ideas {
+		created := idea.CreatedAt.In(loc)
+		active[calendarDay(created)] = true
+		report.ByWeekday[created.Weekday()]++
+		report.ByHour[created.Hour()]++
+	}
+
+	days := make([]time.Time, 0, len(active))
+	for day := range active {
+		days = append(days, day)
+	}
+	sort.Slice(days, func(i, j int) bool { return days[i].Before(days[j]) })
+
+	report.ActiveDays = len(days)
+	report.SpanDays = daysBetween(days[0], days[len(days)-1]) + 1
+
+	span := float64(report.SpanDays)
+	report.PerDay = float64(report.Ideas) / span
+	report.PerWeek = float64(report.Ideas) / max(span/7, 1)
+	report.PerMonth = float64(report.Ideas) / max(span/daysPerMonth, 1)
+
+	streak := 0
+	for i, day := range days {
+		if i > 0 && daysBetween(days[i-1], day) == 1 {
+			streak++
+		} else {
+			streak = 1
+		}
+		report.LongestStreak = max(report.LongestStreak, streak)
+	}
+
+	// streak now holds the run ending on the last active day
+	if sinceLast := daysBetween(days[len(days)-1], calendarDay(now)); sinceLast <= 1 {
+		report.CurrentStreak = streak
+	}
+
+	busiest := time.Sunday
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		if report.ByWeekday[day] > report.ByWeekday[busiest] {
+			busiest = day
+		}
+	}
+	report.BusiestWeekday = busiest.String()
+	for hour := range report.ByHour {
+		if report.ByHour[hour] > report.ByHour[report.BusiestHour] {
+			report.BusiestHour = hour
+		}
+	}
+
+	return report
+}
+
+// calendarDay returns t's date as midnight UTC, so days can be compared and
+// subtracted without daylight saving time getting in the way
+func calendarDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// daysBetween counts whole days from one calendarDay to another
+func daysBetween(from, to time.Time) int {
+	return int(to.Sub(from).Hours() / 24)
+}
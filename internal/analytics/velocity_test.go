@@ -0,0 +1,112 @@
+package analytics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ryacub/telos-idea-matrix/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestCalculateVelocity_StreaksAndBusiestTimes tests ideas captured on
+// Mon 3rd, Tue 4th, Wed 5th, Fri 7th and Sat 8th of March 2025
+func TestCalculateVelocity_StreaksAndBusiestTimes(t *testing.T) {
+	at := func(day, hour int) *models.Idea {
+		return &models.Idea{CreatedAt: time.Date(2025, 3, day, hour, 30, 0, 0, time.UTC)}
+	}
+	ideas := []*models.Idea{
+		at(7, 9), at(3, 9), at(4, 21), at(5, 9), at(3, 14), at(8, 9), at(3, 9),
+	}
+	now := time.Date(2025, 3, 9, 12, 0, 0, 0, time.UTC)
+
+	report := calculateVelocity(ideas, now)
+
+	assert.Equal(t, 7, report.Ideas)
+	assert.Equal(t, 6, report.SpanDays)
+	assert.Equal(t, 5, report.ActiveDays)
+	assert.InDelta(t, 7.0/6, report.PerDay, 0.001)
+	assert.InDelta(t, 7.0, report.PerWeek, 0.001)
+	assert.InDelta(t, 7.0, report.PerMonth, 0.001)
+
+	assert.Equal(t, 3, report.LongestStreak)
+	assert.Equal(t, 2, report.CurrentStreak, "Sunday without ideas yet continues Saturday's streak")
+
+	assert.Equal(t, 3, report.ByWeekday[time.Monday])
+	assert.Equal(t, "Monday", report.BusiestWeekday)
+	assert.Equal(t, 5, report.ByHour[9])
+	assert.Equal(t, 9, report.BusiestHour)
+}
+
+// TestCalculateVelocity_RatesOverLongSpans tests that rates spread ideas over the whole span
+func TestCalculateVelocity_RatesOverLongSpans(t *testing.T) {
+	base := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	var ideas []*models.Idea
+	for i := 0; i < 61; i++ {
+		ideas = append(ideas, &models.Idea{CreatedAt: base.AddDate(0, 0, i)})
+	}
+
+	report := calculateVelocity(ideas, base.AddDate(0, 6, 0))
+
+	assert.Equal(t, 61, report.SpanDays)
+	assert.InDelta(t, 1.0, report.PerDay, 0.001)
+	assert.InDelta(t, 7.0, report.PerWeek, 0.001)
+	assert.InDelta(t, daysPerMonth, report.PerMonth, 0.001)
+	assert.Equal(t, 61, report.LongestStreak)
+	assert.Equal(t, 0, report.CurrentStreak, "streak ended months ago")
+}
+
+// TestCalculateVelocity_SingleIdea tests a single captured idea
+func TestCalculateVelocity_SingleIdea(t *testing.T) {
+	created := time.Date(2025, 3, 6, 23, 45, 0, 0, time.UTC) // A Thursday
+	report := calculateVelocity([]*models.Idea{{CreatedAt: created}}, created.Add(time.Hour))
+
+	assert.Equal(t, 1, report.SpanDays)
+	assert.Equal(t, 1, report.ActiveDays)
+	assert.InDelta(t, 1.0, report.PerDay, 0.001)
+	assert.InDelta(t, 1.0, report.PerWeek, 0.001)
+	assert.InDelta(t, 1.0, report.PerMonth, 0.001)
+	assert.Equal(t, 1, report.LongestStreak)
+	assert.Equal(t, 1, report.CurrentStreak)
+	assert.Equal(t, "Thursday", report.BusiestWeekday)
+	assert.Equal(t, 23, report.BusiestHour)
+}
+
+// TestCalculateVelocity_AllSameDay tests several ideas captured on one day
+func TestCalculateVelocity_AllSameDay(t *testing.T) {
+	day := time.Date(2025, 3, 6, 0, 0, 0, 0, time.UTC)
+	ideas := []*models.Idea{
+		{CreatedAt: day.Add(8 * time.Hour)},
+		{CreatedAt: day.Add(13 * time.Hour)},
+		{CreatedAt: day.Add(13*time.Hour + 20*time.Minute)},
+	}
+
+	report := calculateVelocity(ideas, day.Add(20*time.Hour))
+
+	assert.Equal(t, 1, report.SpanDays)
+	assert.Equal(t, 1, report.ActiveDays)
+	assert.InDelta(t, 3.0, report.PerDay, 0.001)
+	assert.InDelta(t, 3.0, report.PerWeek, 0.001)
+	assert.Equal(t, 1, report.LongestStreak)
+	assert.Equal(t, 1, report.CurrentStreak)
+	assert.Equal(t, 13, report.BusiestHour)
+}
+
+// TestCalculateVelocity_LocalDays tests that days and hours follow now's time zone
+func TestCalculateVelocity_LocalDays(t *testing.T) {
+	tokyo := time.FixedZone("JST", 9*60*60)
+	// 20:00 UTC on Wednesday is 05:00 on Thursday in Tokyo
+	ideas := []*models.Idea{{CreatedAt: time.Date(2025, 3, 5, 20, 0, 0, 0, time.UTC)}}
+
+	report := calculateVelocity(ideas, time.Date(2025, 3, 6, 12, 0, 0, 0, tokyo))
+
+	assert.Equal(t, "Thursday", report.BusiestWeekday)
+	assert.Equal(t, 5, report.BusiestHour)
+	assert.Equal(t, 1, report.CurrentStreak)
+}
+
+// TestCalculateVelocity_NoIdeas tests the empty report
+func TestCalculateVelocity_NoIdeas(t *testing.T) {
+	report := CalculateVelocity(nil)
+
+	assert.Equal(t, VelocityReport{}, report)
+}
@@ -6,14 +6,92 @@ import (
 	"strings"
 )
 
-// RenderSparkline generates a simple ASCII sparkline chart
+// Charset holds the characters charts are drawn with
+type Charset struct {
+	Sparkline []rune // Sparkline levels, lowest to highest
+	Bar       string // Bar chart and filled progress
+	Empty     string // Unfilled progress
+	High      string // Distribution segment for high scores
+	Medium    string // Distribution segment for medium scores
+	Low       string // Distribution segment for low scores
+	Point     string // Trend chart data point
+	VLine     string // Vertical axis and separators
+	HLine     string // Horizontal axis
+	Corner    string // Where the axes meet
+	Heat      []rune // Heatmap shades, from no ideas to the most
+}
+
+// UnicodeCharset draws charts with block and box-drawing characters
+var UnicodeCharset = Charset{
+	Sparkline: []rune{'▁', '▂', '▃', '▄', '▅', '▆', '▇', '█'},
+	Bar:       "█",
+	Empty:     "░",
+	High:      "█",
+	Medium:    "▓",
+	Low:       "░",
+	Point:     "●",
+	VLine:     "│",
+	HLine:     "─",
+	Corner:    "└",
+	Heat:      []rune{'·', '░', '▒', '▓', '█'},
+}
+
+// ASCIICharset draws charts with plain ASCII for terminals and fonts
+// that lack block characters
+var ASCIICharset = Charset{
+	Sparkline: []rune{'_', '.', '-', ':', '=', '+', '*', '#'},
+	Bar:       "#",
+	Empty:     "-",
+	High:      "#",
+	Medium:    "=",
+	Low:       "-",
+	Point:     "*",
+	VLine:     "|",
+	HLine:     "-",
+	Corner:    "+",
+	Heat:      []rune{'.', ':', '+', '*', '#'},
+}
+
+// ChartCharset returns ASCIICharset when ascii is set, otherwise UnicodeCharset
+func ChartCharset(ascii bool) Charset {
+	if ascii {
+		return ASCIICharset
+	}
+	return UnicodeCharset
+}
+
+// RenderSparkline generates a sparkline chart with UnicodeCharset
 func RenderSparkline(values []float64) string {
+	return UnicodeCharset.RenderSparkline(values)
+}
+
+// RenderBarChart generates a bar chart with UnicodeCharset
+func RenderBarChart(labels []string, values []float64, maxWidth int) string {
+	return UnicodeCharset.RenderBarChart(labels, values, maxWidth)
+}
+
+// RenderTrendChart generates a line chart for trend data with UnicodeCharset
+func RenderTrendChart(trends []TrendData, height int) string {
+	return UnicodeCharset.RenderTrendChart(trends, height)
+}
+
+// RenderDistribution creates a distribution histogram with UnicodeCharset
+func RenderDistribution(high, medium, low int, width int) string {
+	return UnicodeCharset.RenderDistribution(high, medium, low, width)
+}
+
+// RenderProgressBar creates a progress/percentage bar with UnicodeCharset
+func RenderProgressBar(current, total int, width int) string {
+	return UnicodeCharset.RenderProgressBar(current, total, width)
+}
+
+// RenderSparkline generates a simple sparkline chart
+func (cs Charset) RenderSparkline(values []float64) string {
 	if len(values) == 0 {
 		return ""
 	}
 
-	// Sparkline characters from lowest to highest
-	chars := []rune{'▁', '▂', '▃', '▄', '▅', '▆', '▇', '█'}
+	chars := cs.Sparkline
 
 	// Find min and max
 	minVal, maxVal := values[0], values[0]
@@ -45,8 +123,8 @@ func RenderSparkline(values []float64) string {
 	return result.String()
 }
 
-// RenderBarChart generates a simple ASCII bar chart
-func RenderBarChart(labels []string, values []float64, maxWidth int) string {
+// RenderBarChart generates a simple bar chart
+func (cs Charset) RenderBarChart(labels []string, values []float64, maxWidth int) string {
 	if len(labels) == 0 || len(values) == 0 || len(labels) != len(values) {
 		return ""
 	}
@@ -83,20 +161,21 @@ func RenderBarChart(labels []string, values []float64, maxWidth int) string {
 		}
 
 		// Create bar
-		bar := strings.Repeat("█", barWidth)
+		bar := strings.Repeat(cs.Bar, barWidth)
 
-		// Format line
-		result.WriteString(fmt.Sprintf("%-*s │ %-*s %.1f\n",
+		// Format line, padding by bar width since multi-byte characters defeat %-*s
+		result.WriteString(fmt.Sprintf("%-*s %s %s%s %.1f\n",
 			maxLabelWidth, label,
-			maxWidth, bar,
+			cs.VLine,
+			bar, strings.Repeat(" ", maxWidth-barWidth),
 			value))
 	}
 
 	return result.String()
 }
 
-// RenderTrendChart generates an ASCII line chart for trend data
-func RenderTrendChart(trends []TrendData, height int) string {
+// RenderTrendChart generates a line chart for trend data
+func (cs Charset) RenderTrendChart(trends []TrendData, height int) string {
 	if len(trends) == 0 {
 		return ""
 	}
@@ -132,7 +211,7 @@ func RenderTrendChart(trends []TrendData, height int) string {
 		yValue := minVal + (float64(row)/float64(height-1))*(maxVal-minVal)
 
 		// Y-axis label
-		chart.WriteString(fmt.Sprintf("%4.1f │", yValue))
+		chart.WriteString(fmt.Sprintf("%4.1f %s", yValue, cs.VLine))
 
 		// Plot points
 		for col := 0; col < len(values); col++ {
@@ -140,7 +219,7 @@ func RenderTrendChart(trends []TrendData, height int) string {
 			pointRow := int(normalized * float64(height-1))
 
 			if pointRow == row {
-				chart.WriteString("●")
+				chart.WriteString(cs.Point)
 			} else {
 				chart.WriteString(" ")
 			}
@@ -149,14 +228,14 @@ func RenderTrendChart(trends []TrendData, height int) string {
 	}
 
 	// X-axis
-	chart.WriteString("     └")
-	chart.WriteString(strings.Repeat("─", len(values)))
+	chart.WriteString("     " + cs.Corner)
+	chart.WriteString(strings.Repeat(cs.HLine, len(values)))
 	chart.WriteString("\n      ")
 
 	// X-axis labels (show first, middle, last)
 	for i := range trends {
 		if i == 0 || i == len(trends)/2 || i == len(trends)-1 {
-			chart.WriteString("│")
+			chart.WriteString(cs.VLine)
 		} else {
 			chart.WriteString(" ")
 		}
@@ -167,7 +246,7 @@ func RenderTrendChart(trends []TrendData, height int) string {
 }
 
 // RenderDistribution creates a simple distribution histogram
-func RenderDistribution(high, medium, low int, width int) string {
+func (cs Charset) RenderDistribution(high, medium, low int, width int) string {
 	total := high + medium + low
 	if total == 0 {
 		return ""
@@ -192,16 +271,16 @@ func RenderDistribution(high, medium, low int, width int) string {
 	// Build distribution bar
 	var bar strings.Builder
 	bar.WriteString("[")
-	bar.WriteString(strings.Repeat("█", highWidth))   // High
-	bar.WriteString(strings.Repeat("▓", mediumWidth)) // Medium
-	bar.WriteString(strings.Repeat("░", lowWidth))    // Low
+	bar.WriteString(strings.Repeat(cs.High, highWidth))
+	bar.WriteString(strings.Repeat(cs.Medium, mediumWidth))
+	bar.WriteString(strings.Repeat(cs.Low, lowWidth))
 	bar.WriteString("]")
 
 	return bar.String()
 }
 
 // RenderProgressBar creates a simple progress/percentage bar
-func RenderProgressBar(current, total int, width int) string {
+func (cs Charset) RenderProgressBar(current, total int, width int) string {
 	if total == 0 {
 		return "[" + strings.Repeat(" ", width) + "] 0%"
 	}
@@ -213,6 +292,6 @@ func RenderProgressBar(current, total int, width int) string {
 		filled = width
 	}
 
-	bar := strings.Repeat("█", filled) + strings.Repeat("░", width-filled)
+	bar := strings.Repeat(cs.Bar, filled) + strings.Repeat(cs.Empty, width-filled)
 	return fmt.Sprintf("[%s] %d%%", bar, int(percentage*100))
 }
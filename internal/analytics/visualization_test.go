@@ -196,3 +196,33 @@ func TestRenderProgressBar(t *testing.T) {
 		assert.Equal(t, 20, strings.Count(result, "█"))
 	})
 }
+
+// TestASCIICharset_RendersOnlyASCII tests that every chart drawn with the
+// ASCII charset avoids block and box-drawing characters
+func TestASCIICharset_RendersOnlyASCII(t *testing.T) {
+	values := []float64{2.5, 7.0, 4.25, 9.5, 1.0, 6.0}
+	trends := []TrendData{{AvgScore: 5.0}, {AvgScore: 7.5}, {AvgScore: 6.0}}
+
+	charts := map[string]string{
+		"sparkline":    ASCIICharset.RenderSparkline(values),
+		"bar chart":    ASCIICharset.RenderBarChart([]string{"focus", "perfectionism"}, []float64{3, 7}, 20),
+		"trend chart":  ASCIICharset.RenderTrendChart(trends, 5),
+		"distribution": ASCIICharset.RenderDistribution(5, 3, 2, 30),
+		"progress bar": ASCIICharset.RenderProgressBar(40, 100, 20),
+	}
+
+	for name, chart := range charts {
+		assert.NotEmpty(t, chart, name)
+		for _, r := range chart {
+			assert.Less(t, r, rune(128), "%s contains non-ASCII %q:\n%s", name, r, chart)
+		}
+	}
+
+	assert.Equal(t, ".=-#_=", ASCIICharset.RenderSparkline(values))
+	assert.Equal(t, "[###############=========------]", ASCIICharset.RenderDistribution(5, 3, 2, 30))
+}
+
+func TestChartCharset(t *testing.T) {
+	assert.Equal(t, "#", ChartCharset(true).Bar)
+	assert.Equal(t, "█", ChartCharset(false).Bar)
+}
@@ -0,0 +1,102 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"time"
+)
+
+// DefaultMaxConcurrentAnalyses is how many AI analyses run at once unless
+// SetAnalysisLimit says otherwise
+const DefaultMaxConcurrentAnalyses = 4
+
+// DefaultAnalysisQueueTimeout is how long an AI analysis waits for a free
+// slot unless SetAnalysisLimit says otherwise
+const DefaultAnalysisQueueTimeout = 10 * time.Second
+
+// errAnalysisBusy means every analysis slot stayed taken for the whole
+// queue timeout
+var errAnalysisBusy = errors.New("too many analyses in progress")
+
+// analysisLimiter caps the AI analyses in flight across the server, so a
+// burst of requests can't exhaust the LLM providers' quotas. Requests over
+// the limit wait up to the queue timeout for a slot.
+type analysisLimiter struct {
+	slots    chan struct{} // Nil when analyses are unlimited
+	wait     time.Duration // Zero rejects at once when every slot is taken
+	inFlight atomic.Int64
+	queued   atomic.Int64
+}
+
+// newAnalysisLimiter allows limit analyses at once; zero or less means no limit
+func newAnalysisLimiter(limit int, wait time.Duration) *analysisLimiter {
+	l := &analysisLimiter{wait: wait}
+	if limit > 0 {
+		l.slots = make(chan struct{}, limit)
+	}
+	return l
+}
+
+// acquire claims a slot, waiting up to the queue timeout or until ctx is
+// done. It returns errAnalysisBusy if no slot came free in time. A
+// successful acquire must be paired with release.
+func (l *analysisLimiter) acquire(ctx context.Context) error {
+	if l.slots == nil {
+		l.inFlight.Add(1)
+		return nil
+	}
+
+	select {
+	case l.slots <- struct{}{}:
+		l.inFlight.Add(1)
+		return nil
+	default:
+	}
+	if l.wait <= 0 {
+		return errAnalysisBusy
+	}
+
+	l.queued.Add(1)
+	defer l.queued.Add(-1)
+	timer := time.NewTimer(l.wait)
+	defer timer.Stop()
+	select {
+	case l.slots <- struct{}{}:
+		l.inFlight.Add(1)
+		return nil
+	case <-timer.C:
+		return errAnalysisBusy
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (l *analysisLimiter) release() {
+	l.inFlight.Add(-1)
+	if l.slots != nil {
+		<-l.slots
+	}
+}
+
+// retryAfter is how many seconds a rejected client should wait, as sent
+// in the Retry-After header
+func (l *analysisLimiter) retryAfter() int {
+	return max(1, int(l.wait.Round(time.Second)/time.Second))
+}
+
+// load reports the analyses in flight and waiting, and the limit
+func (l *analysisLimiter) load() AnalysisLoad {
+	return AnalysisLoad{
+		InFlight: int(l.inFlight.Load()),
+		Queued:   int(l.queued.Load()),
+		Limit:    cap(l.slots),
+	}
+}
+
+// SetAnalysisLimit caps the AI analyses running at once at limit, zero
+// meaning no limit. Requests over the limit wait up to wait for a slot and
+// are then answered 429; with a zero wait they are answered 429 at once.
+func (s *Server) SetAnalysisLimit(limit int, wait time.Duration) {
+	s.analyses = newAnalysisLimiter(limit, wait)
+}
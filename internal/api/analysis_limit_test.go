@@ -0,0 +1,106 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ryacub/telos-idea-matrix/internal/llm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAnalysisLimiter_QueuesUntilASlotFrees(t *testing.T) {
+	limiter := newAnalysisLimiter(1, time.Second)
+	require.NoError(t, limiter.acquire(context.Background()))
+	assert.Equal(t, AnalysisLoad{InFlight: 1, Limit: 1}, limiter.load())
+
+	acquired := make(chan error)
+	go func() { acquired <- limiter.acquire(context.Background()) }()
+	require.Eventually(t, func() bool { return limiter.load().Queued == 1 }, time.Second, time.Millisecond)
+
+	limiter.release()
+	require.NoError(t, <-acquired)
+	assert.Equal(t, AnalysisLoad{InFlight: 1, Limit: 1}, limiter.load())
+	limiter.release()
+	assert.Equal(t, AnalysisLoad{Limit: 1}, limiter.load())
+}
+
+func TestAnalysisLimiter_RejectsWhenFull(t *testing.T) {
+	limiter := newAnalysisLimiter(1, 10*time.Millisecond)
+	require.NoError(t, limiter.acquire(context.Background()))
+	defer limiter.release()
+
+	assert.ErrorIs(t, limiter.acquire(context.Background()), errAnalysisBusy, "the queue timeout runs out")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	limiter.wait = time.Minute
+	assert.ErrorIs(t, limiter.acquire(ctx), context.Canceled)
+
+	limiter.wait = 0
+	assert.ErrorIs(t, limiter.acquire(context.Background()), errAnalysisBusy, "no wait rejects at once")
+	assert.Equal(t, 0, limiter.load().Queued)
+}
+
+func TestAnalysisLimiter_Unlimited(t *testing.T) {
+	limiter := newAnalysisLimiter(0, 0)
+	for i := 0; i < 10; i++ {
+		require.NoError(t, limiter.acquire(context.Background()))
+	}
+	assert.Equal(t, AnalysisLoad{InFlight: 10}, limiter.load())
+	for i := 0; i < 10; i++ {
+		limiter.release()
+	}
+	assert.Zero(t, limiter.load().InFlight)
+}
+
+func TestAnalysisLimiter_RetryAfter(t *testing.T) {
+	assert.Equal(t, 10, newAnalysisLimiter(1, 10*time.Second).retryAfter())
+	assert.Equal(t, 1, newAnalysisLimiter(1, 0).retryAfter())
+}
+
+func TestCreateIdeaHandler_AnalysisLimitAnswers429(t *testing.T) {
+	server, _, cleanup := setupTestServer(t)
+	defer cleanup()
+	server.SetLLMManager(llm.NewManager(llm.DefaultManagerConfig()))
+	server.SetAnalysisLimit(1, 0)
+
+	// Hold the only slot, as a slow analysis would
+	require.NoError(t, server.analyses.acquire(context.Background()))
+
+	_, health := getHealth(t, server)
+	assert.Equal(t, AnalysisLoad{InFlight: 1, Limit: 1}, health.Analyses)
+
+	post := func(path, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("POST", path, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		server.Router().ServeHTTP(w, req)
+		return w
+	}
+
+	w := post("/api/v1/ideas", `{"content":"Build AI-powered Go code reviewer","use_ai":true,"provider":"rule_based"}`)
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.Equal(t, "1", w.Header().Get("Retry-After"))
+
+	w = post("/api/v1/ideas/batch", `{"ideas":[{"content":"Launch a SaaS","use_ai":true,"provider":"rule_based"},{"content":"Write a Go book"}]}`)
+	require.Equal(t, http.StatusMultiStatus, w.Code)
+	assert.Equal(t, "1", w.Header().Get("Retry-After"))
+	var batch BatchCreateIdeasResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &batch))
+	assert.Equal(t, http.StatusTooManyRequests, batch.Results[0].Status)
+	assert.Equal(t, http.StatusCreated, batch.Results[1].Status, "rule-based scoring isn't limited")
+
+	server.analyses.release()
+	w = post("/api/v1/ideas", `{"content":"Build AI-powered Go code reviewer","use_ai":true,"provider":"rule_based"}`)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Empty(t, w.Header().Get("Retry-After"))
+
+	_, health = getHealth(t, server)
+	assert.Equal(t, AnalysisLoad{Limit: 1}, health.Analyses)
+}
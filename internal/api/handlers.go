@@ -1,16 +1,26 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
+	"net/url"
+	"os"
 	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid"
 	"github.com/rs/zerolog/log"
+	"github.com/ryacub/telos-idea-matrix/internal/analytics"
 	"github.com/ryacub/telos-idea-matrix/internal/database"
+	"github.com/ryacub/telos-idea-matrix/internal/llm"
+	"github.com/ryacub/telos-idea-matrix/internal/logging"
 	"github.com/ryacub/telos-idea-matrix/internal/metrics"
 	"github.com/ryacub/telos-idea-matrix/internal/models"
 	"github.com/ryacub/telos-idea-matrix/internal/patterns"
@@ -31,13 +41,45 @@ type AnalyzeResponse struct {
 
 // CreateIdeaRequest represents a request to create an idea
 type CreateIdeaRequest struct {
-	Content string `json:"content"`
+	Content  string `json:"content"`
+	Trigger  string `json:"trigger,omitempty"`
+	UseAI    bool   `json:"use_ai,omitempty"`
+	Provider string `json:"provider,omitempty"` // LLM provider for use_ai; empty uses the primary provider
+}
+
+// MaxBatchSize is the most ideas one batch create request may hold
+const MaxBatchSize = 100
+
+// batchWorkers is how many ideas of a batch are analyzed at once
+const batchWorkers = 4
+
+// BatchCreateIdeasRequest represents a request to create several ideas
+type BatchCreateIdeasRequest struct {
+	Ideas []CreateIdeaRequest `json:"ideas"`
+}
+
+// BatchItemResult is the outcome of one idea in a batch
+type BatchItemResult struct {
+	Index  int           `json:"index"`  // Position of the idea in the request
+	Status int           `json:"status"` // HTTP status the single create endpoint would return
+	Idea   *IdeaResponse `json:"idea,omitempty"`
+	Error  string        `json:"error,omitempty"`
+}
+
+// BatchCreateIdeasResponse reports every idea of a batch, in request order
+type BatchCreateIdeasResponse struct {
+	Results   []BatchItemResult `json:"results"`
+	Succeeded int               `json:"succeeded"`
+	Failed    int               `json:"failed"`
 }
 
 // UpdateIdeaRequest represents a request to update an idea
 type UpdateIdeaRequest struct {
 	Content *string `json:"content,omitempty"`
 	Status  *string `json:"status,omitempty"`
+	// Version is the idea version the client last read. When set, the update
+	// is rejected with 409 Conflict if the idea has changed since.
+	Version *int `json:"version,omitempty"`
 }
 
 // IdeaResponse represents an idea in API responses
@@ -48,27 +90,72 @@ type IdeaResponse struct {
 	FinalScore     float64          `json:"final_score"`
 	Patterns       []string         `json:"patterns"`
 	Recommendation string           `json:"recommendation"`
+	Trigger        string           `json:"trigger,omitempty"`
 	Analysis       *models.Analysis `json:"analysis,omitempty"`
 	CreatedAt      string           `json:"created_at"`
 	ReviewedAt     *string          `json:"reviewed_at,omitempty"`
 	Status         string           `json:"status"`
+	Version        int              `json:"version"`
 }
 
 // ListIdeasResponse represents a paginated list of ideas
 type ListIdeasResponse struct {
-	Ideas  []IdeaResponse `json:"ideas"`
-	Total  int            `json:"total"`
+	Items  []IdeaResponse `json:"items"`
+	Total  int            `json:"total"` // Ideas matching the filters, across all pages
 	Limit  int            `json:"limit"`
 	Offset int            `json:"offset"`
 }
 
 // StatsResponse represents analytics statistics
 type StatsResponse struct {
-	TotalIdeas   int     `json:"total_ideas"`
-	ActiveIdeas  int     `json:"active_ideas"`
-	AverageScore float64 `json:"average_score"`
-	HighScore    float64 `json:"high_score"`
-	LowScore     float64 `json:"low_score"`
+	TotalIdeas   int                     `json:"total_ideas"`
+	ActiveIdeas  int                     `json:"active_ideas"`
+	AverageScore float64                 `json:"average_score"`
+	MedianScore  float64                 `json:"median_score"`
+	HighScore    float64                 `json:"high_score"`
+	LowScore     float64                 `json:"low_score"`
+	ScoreBuckets map[string]int          `json:"score_buckets"`
+	TopPatterns  []database.PatternCount `json:"top_patterns"`
+	RefreshedAt  *time.Time              `json:"refreshed_at"` // When the statistics were last computed
+}
+
+// Overall statuses reported by the health endpoint
+const (
+	HealthStatusHealthy   = "healthy"
+	HealthStatusDegraded  = "degraded"
+	HealthStatusUnhealthy = "unhealthy"
+)
+
+// Statuses of a single health check
+const (
+	HealthCheckUp   = "up"
+	HealthCheckDown = "down"
+)
+
+// HealthCheck is the status of one dependency
+type HealthCheck struct {
+	Status    string     `json:"status"`
+	LastCheck *time.Time `json:"last_check,omitempty"` // When an LLM provider was last probed or a task last ran
+}
+
+// HealthResponse represents the health of the server and its dependencies
+type HealthResponse struct {
+	Status string `json:"status"`
+	Checks struct {
+		Database     HealthCheck            `json:"database"`
+		Telos        HealthCheck            `json:"telos"`
+		LLMProviders map[string]HealthCheck `json:"llm_providers,omitempty"` // Absent when AI analysis is off
+		Tasks        map[string]HealthCheck `json:"tasks,omitempty"`         // Absent when no background tasks run
+	} `json:"checks"`
+	Analyses AnalysisLoad `json:"analyses"`
+}
+
+// AnalysisLoad reports the AI analyses the server is running against its
+// concurrency limit
+type AnalysisLoad struct {
+	InFlight int `json:"in_flight"`
+	Queued   int `json:"queued"` // Waiting for a free slot
+	Limit    int `json:"limit"`  // Zero when unlimited
 }
 
 // ErrorResponse represents an error response
@@ -100,9 +187,11 @@ func ideaToResponse(idea *models.Idea) IdeaResponse {
 		FinalScore:     idea.FinalScore,
 		Patterns:       idea.Patterns,
 		Recommendation: idea.Recommendation,
+		Trigger:        idea.Trigger,
 		Analysis:       idea.Analysis,
 		CreatedAt:      idea.CreatedAt.Format(time.RFC3339),
 		Status:         idea.Status,
+		Version:        idea.Version,
 	}
 	if idea.ReviewedAt != nil {
 		reviewedAt := idea.ReviewedAt.Format(time.RFC3339)
@@ -113,20 +202,78 @@ func ideaToResponse(idea *models.Idea) IdeaResponse {
 
 // Handlers
 
-// HealthHandler handles health check requests
+// HealthHandler reports the status of the server and its dependencies.
+// The database is critical: when it is down the status is "unhealthy" with
+// 503. A missing telos file, an unavailable LLM provider or an unhealthy
+// background task only degrades the server, which still answers 200 with a
+// "degraded" status. Provider availability comes from the manager's last
+// health check, so this never waits on a provider.
 func (s *Server) HealthHandler(w http.ResponseWriter, r *http.Request) {
-	// Simple health check: verify database connectivity
-	status := "healthy"
-	httpStatus := http.StatusOK
+	resp := HealthResponse{Status: HealthStatusHealthy}
+	degrade := func() {
+		if resp.Status == HealthStatusHealthy {
+			resp.Status = HealthStatusDegraded
+		}
+	}
 
+	resp.Checks.Database = HealthCheck{Status: HealthCheckUp}
 	if err := s.repo.Ping(); err != nil {
-		status = "unhealthy"
-		httpStatus = http.StatusServiceUnavailable
+		logging.FromContext(r.Context()).Error().Err(err).Msg("health check: database ping failed")
+		resp.Checks.Database.Status = HealthCheckDown
+		resp.Status = HealthStatusUnhealthy
 	}
 
-	respondJSON(w, httpStatus, map[string]string{
-		"status": status,
-	})
+	resp.Checks.Telos = HealthCheck{Status: HealthCheckUp}
+	if s.telosPath != "" {
+		if _, err := os.Stat(s.telosPath); err != nil {
+			resp.Checks.Telos.Status = HealthCheckDown
+			degrade()
+		}
+	}
+
+	if s.llm != nil {
+		resp.Checks.LLMProviders = make(map[string]HealthCheck)
+		for _, name := range s.llm.ProviderNames() {
+			available, lastCheck, err := s.llm.GetHealthStatus(name)
+			if err != nil {
+				continue
+			}
+			check := HealthCheck{Status: HealthCheckUp}
+			if !lastCheck.IsZero() {
+				checked := lastCheck.UTC()
+				check.LastCheck = &checked
+			}
+			if !available {
+				check.Status = HealthCheckDown
+				degrade()
+			}
+			resp.Checks.LLMProviders[name] = check
+		}
+	}
+
+	if s.tasks != nil {
+		resp.Checks.Tasks = make(map[string]HealthCheck)
+		for name, status := range s.tasks.Status() {
+			check := HealthCheck{Status: HealthCheckUp}
+			if !status.LastRun.IsZero() {
+				lastRun := status.LastRun.UTC()
+				check.LastCheck = &lastRun
+			}
+			if !status.Healthy {
+				check.Status = HealthCheckDown
+				degrade()
+			}
+			resp.Checks.Tasks[name] = check
+		}
+	}
+
+	resp.Analyses = s.analyses.load()
+
+	httpStatus := http.StatusOK
+	if resp.Status == HealthStatusUnhealthy {
+		httpStatus = http.StatusServiceUnavailable
+	}
+	respondJSON(w, httpStatus, resp)
 }
 
 // AnalyzeHandler handles idea analysis requests
@@ -149,7 +296,7 @@ func (s *Server) AnalyzeHandler(w http.ResponseWriter, r *http.Request) {
 	analysis, err := scoringEngine.CalculateScore(req.Content)
 	if err != nil {
 		// Log internal error details but don't expose to client
-		log.Error().Err(err).Msg("Failed to analyze idea")
+		logging.FromContext(r.Context()).Error().Err(err).Msg("Failed to analyze idea")
 		respondError(w, http.StatusInternalServerError, "Failed to analyze idea")
 		return
 	}
@@ -159,6 +306,7 @@ func (s *Server) AnalyzeHandler(w http.ResponseWriter, r *http.Request) {
 
 	// Update analysis with detected patterns
 	analysis.DetectedPatterns = detectedPatterns
+	scoring.ApplyAnalysisBounds(analysis, nil, patternNames(detectedPatterns), s.scoreBounds)
 
 	// Record metrics
 	metrics.RecordScoringDuration(time.Since(start))
@@ -166,38 +314,105 @@ func (s *Server) AnalyzeHandler(w http.ResponseWriter, r *http.Request) {
 	respondJSON(w, http.StatusOK, AnalyzeResponse{Analysis: analysis})
 }
 
-// CreateIdeaHandler handles idea creation requests
+// CreateIdeaHandler handles idea creation requests. A request with an
+// Idempotency-Key header is answered once; repeats of it within the
+// idempotency TTL get the original response.
 func (s *Server) CreateIdeaHandler(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
 	var req CreateIdeaRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+	if err := json.Unmarshal(body, &req); err != nil {
 		respondError(w, http.StatusBadRequest, "Invalid request body")
 		return
 	}
 
-	if req.Content == "" {
-		respondError(w, http.StatusBadRequest, "content is required")
+	key := r.Header.Get(IdempotencyKeyHeader)
+	if s.idempotencyTTL <= 0 {
+		key = ""
+	}
+	hash := requestHash(body)
+	if key != "" {
+		if !validIdempotencyKey(key) {
+			respondError(w, http.StatusBadRequest, "Idempotency-Key must be 1-255 printable ASCII characters")
+			return
+		}
+		if !s.idempotency.acquire(key) {
+			respondError(w, http.StatusConflict, "A request with this Idempotency-Key is still being processed")
+			return
+		}
+		defer s.idempotency.release(key)
+
+		if s.replayIdempotent(w, r, key, hash) {
+			return
+		}
+	}
+
+	idea, createErr := s.createIdea(r.Context(), req)
+	if createErr != nil {
+		if createErr.retryAfter > 0 {
+			w.Header().Set("Retry-After", strconv.Itoa(createErr.retryAfter))
+		}
+		respondError(w, createErr.status, createErr.message)
 		return
 	}
 
+	resp := ideaToResponse(idea)
+	if key != "" {
+		// Stored exactly as respondJSON writes it
+		encoded, err := json.Marshal(resp)
+		if err == nil {
+			s.saveIdempotent(r, key, hash, idea.ID, http.StatusCreated, append(encoded, '\n'))
+		}
+	}
+
+	w.Header().Set("Location", "/api/v1/ideas/"+idea.ID)
+	respondJSON(w, http.StatusCreated, resp)
+}
+
+// createIdeaError is why an idea couldn't be created, as reported to the client
+type createIdeaError struct {
+	status     int
+	message    string
+	retryAfter int // Seconds for the Retry-After header; zero sends none
+}
+
+// createIdea validates, analyzes and saves one idea. It is shared by the
+// single and batch create endpoints.
+func (s *Server) createIdea(ctx context.Context, req CreateIdeaRequest) (*models.Idea, *createIdeaError) {
+	if strings.TrimSpace(req.Content) == "" {
+		return nil, &createIdeaError{status: http.StatusBadRequest, message: "content is required"}
+	}
+
+	if len(req.Trigger) > 200 {
+		return nil, &createIdeaError{status: http.StatusBadRequest, message: "trigger must be at most 200 characters"}
+	}
+
 	// Analyze the idea
-	scoringEngine := scoring.NewEngine(s.telos)
-	analysis, err := scoringEngine.CalculateScore(req.Content)
+	analysis, err := s.analyzeNewIdea(ctx, req)
+	if errors.Is(err, llm.ErrUnknownProvider) {
+		return nil, &createIdeaError{status: http.StatusBadRequest, message: err.Error()}
+	}
+	if errors.Is(err, errAnalysisBusy) {
+		return nil, &createIdeaError{
+			status:     http.StatusTooManyRequests,
+			message:    "Too many analyses in progress. Please try again later.",
+			retryAfter: s.analyses.retryAfter(),
+		}
+	}
 	if err != nil {
 		// Log internal error details but don't expose to client
-		log.Error().Err(err).Msg("Failed to analyze idea")
-		respondError(w, http.StatusInternalServerError, "Failed to analyze idea")
-		return
+		logging.FromContext(ctx).Error().Err(err).Msg("Failed to analyze idea")
+		return nil, &createIdeaError{status: http.StatusInternalServerError, message: "Failed to analyze idea"}
 	}
 
 	detector := patterns.NewDetector(s.telos)
 	detectedPatterns := detector.DetectPatterns(req.Content)
 	analysis.DetectedPatterns = detectedPatterns
-
-	// Extract pattern names for storage
-	patternNames := make([]string, len(detectedPatterns))
-	for i, p := range detectedPatterns {
-		patternNames[i] = p.Name
-	}
+	names := patternNames(detectedPatterns)
+	scoring.ApplyAnalysisBounds(analysis, nil, names, s.scoreBounds)
 
 	// Create idea
 	idea := &models.Idea{
@@ -205,8 +420,10 @@ func (s *Server) CreateIdeaHandler(w http.ResponseWriter, r *http.Request) {
 		Content:        req.Content,
 		RawScore:       analysis.RawScore,
 		FinalScore:     analysis.FinalScore,
-		Patterns:       patternNames,
+		Patterns:       names,
 		Recommendation: analysis.GetRecommendation(),
+		Trigger:        strings.TrimSpace(req.Trigger),
+		TelosVersion:   s.telosVersion,
 		Analysis:       analysis,
 		Status:         "active",
 		CreatedAt:      time.Now().UTC(),
@@ -214,15 +431,118 @@ func (s *Server) CreateIdeaHandler(w http.ResponseWriter, r *http.Request) {
 
 	if err := s.repo.Create(idea); err != nil {
 		// Log internal error details but don't expose to client
-		log.Error().Err(err).Str("idea_id", idea.ID).Msg("Failed to create idea")
-		respondError(w, http.StatusInternalServerError, "Failed to create idea")
-		return
+		logging.FromContext(ctx).Error().Err(err).Str("idea_id", idea.ID).Msg("Failed to create idea")
+		return nil, &createIdeaError{status: http.StatusInternalServerError, message: "Failed to create idea"}
 	}
 
 	// Record metrics
 	metrics.RecordIdeaCreated()
+	s.notifier.Notify(idea)
 
-	respondJSON(w, http.StatusCreated, ideaToResponse(idea))
+	return idea, nil
+}
+
+// BatchCreateIdeasHandler creates up to MaxBatchSize ideas, analyzing them
+// concurrently. Each idea succeeds or fails on its own; the response is
+// always 207 Multi-Status with a result per idea.
+func (s *Server) BatchCreateIdeasHandler(w http.ResponseWriter, r *http.Request) {
+	var req BatchCreateIdeasRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if len(req.Ideas) == 0 {
+		respondError(w, http.StatusBadRequest, "ideas is required")
+		return
+	}
+	if len(req.Ideas) > MaxBatchSize {
+		respondError(w, http.StatusBadRequest, fmt.Sprintf("a batch can hold at most %d ideas", MaxBatchSize))
+		return
+	}
+
+	results := make([]BatchItemResult, len(req.Ideas))
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for i := 0; i < min(batchWorkers, len(req.Ideas)); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for index := range indexes {
+				result := BatchItemResult{Index: index, Status: http.StatusCreated}
+				idea, err := s.createIdea(r.Context(), req.Ideas[index])
+				if err != nil {
+					result.Status, result.Error = err.status, err.message
+				} else {
+					response := ideaToResponse(idea)
+					result.Idea = &response
+				}
+				results[index] = result
+			}
+		}()
+	}
+	for i := range req.Ideas {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	response := BatchCreateIdeasResponse{Results: results}
+	for _, result := range results {
+		if result.Error == "" {
+			response.Succeeded++
+		} else {
+			response.Failed++
+		}
+		if result.Status == http.StatusTooManyRequests {
+			w.Header().Set("Retry-After", strconv.Itoa(s.analyses.retryAfter()))
+		}
+	}
+	respondJSON(w, http.StatusMultiStatus, response)
+}
+
+// analyzeNewIdea scores an idea the way 'tm add' does: with an LLM when
+// requested, falling back to rule-based scoring if the LLM fails.
+// An unknown provider is returned as an error instead of falling back, and
+// so is errAnalysisBusy when no analysis slot comes free in time.
+func (s *Server) analyzeNewIdea(ctx context.Context, req CreateIdeaRequest) (*models.Analysis, error) {
+	if req.UseAI && s.llm != nil {
+		limiter := s.analyses
+		if err := limiter.acquire(ctx); err != nil {
+			return nil, err
+		}
+		defer limiter.release()
+
+		var analysis *models.Analysis
+		var err error
+		if req.Provider != "" {
+			analysis, err = s.llm.AnalyzeWithNamedProviderContext(ctx, req.Content, req.Provider, s.telos)
+		} else {
+			var result *llm.AnalysisResult
+			if result, err = s.llm.AnalyzeWithTelosContext(ctx, req.Content, s.telos); err == nil {
+				analysis = llm.ConvertResultToAnalysis(result)
+			}
+		}
+
+		if err == nil {
+			return analysis, nil
+		}
+		if errors.Is(err, llm.ErrUnknownProvider) {
+			return nil, err
+		}
+		logging.FromContext(ctx).Warn().Err(err).Msg("AI analysis failed, using rule-based scoring")
+	}
+
+	return scoring.NewEngine(s.telos).CalculateScore(req.Content)
+}
+
+// patternNames returns the names of detected patterns, as stored on ideas
+func patternNames(detected []models.DetectedPattern) []string {
+	names := make([]string, len(detected))
+	for i, p := range detected {
+		names[i] = p.Name
+	}
+	return names
 }
 
 // GetIdeaHandler handles requests to get a single idea
@@ -251,32 +571,10 @@ func (s *Server) GetIdeaHandler(w http.ResponseWriter, r *http.Request) {
 
 // ListIdeasHandler handles requests to list ideas
 func (s *Server) ListIdeasHandler(w http.ResponseWriter, r *http.Request) {
-	// Parse query parameters
-	query := r.URL.Query()
-
-	status := query.Get("status")
-	limitStr := query.Get("limit")
-	offsetStr := query.Get("offset")
-
-	var limit, offset *int
-	if limitStr != "" {
-		l, err := strconv.Atoi(limitStr)
-		if err == nil {
-			limit = &l
-		}
-	}
-	if offsetStr != "" {
-		o, err := strconv.Atoi(offsetStr)
-		if err == nil {
-			offset = &o
-		}
-	}
-
-	// Build list options
-	options := database.ListOptions{
-		Status: status,
-		Limit:  limit,
-		Offset: offset,
+	options, err := parseListOptions(r.URL.Query())
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
 	}
 
 	ideas, err := s.repo.List(options)
@@ -287,31 +585,105 @@ func (s *Server) ListIdeasHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	total, err := s.repo.Count(options)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to count ideas")
+		respondError(w, http.StatusInternalServerError, "Failed to list ideas")
+		return
+	}
+
 	// Convert to response format
 	ideaResponses := make([]IdeaResponse, len(ideas))
 	for i := range ideas {
 		ideaResponses[i] = ideaToResponse(ideas[i])
 	}
 
-	// Calculate totals
-	total := len(ideas)
-	responseLimit := 100
-	responseOffset := 0
-	if limit != nil {
-		responseLimit = *limit
-	}
-	if offset != nil {
-		responseOffset = *offset
-	}
-
 	respondJSON(w, http.StatusOK, ListIdeasResponse{
-		Ideas:  ideaResponses,
+		Items:  ideaResponses,
 		Total:  total,
-		Limit:  responseLimit,
-		Offset: responseOffset,
+		Limit:  *options.Limit,
+		Offset: *options.Offset,
 	})
 }
 
+// Page sizes for GET /api/v1/ideas
+const (
+	defaultListLimit = 50
+	maxListLimit     = 200 // Larger requests are capped to keep responses cheap
+)
+
+// parseOrderBy converts an order_by value such as "-final_score" into an
+// order; a leading "-" sorts descending. Fields outside the repository's
+// whitelist are rejected.
+func parseOrderBy(value string) (database.Order, error) {
+	direction := "asc"
+	if field, ok := strings.CutPrefix(value, "-"); ok {
+		value, direction = field, "desc"
+	}
+	order, err := database.ParseOrder(value, direction)
+	if err != nil {
+		return database.Order{}, errors.New("order_by must be one of: created_at, final_score, raw_score (prefix with - for descending)")
+	}
+	return order, nil
+}
+
+// parseListOptions converts list query parameters into repository options.
+// Limit and Offset are always set in the result.
+func parseListOptions(query url.Values) (database.ListOptions, error) {
+	options := database.ListOptions{Status: query.Get("status")}
+
+	for _, bound := range []struct {
+		name string
+		dest **float64
+	}{
+		{"min_score", &options.MinScore},
+		{"max_score", &options.MaxScore},
+	} {
+		value := query.Get(bound.name)
+		if value == "" {
+			continue
+		}
+		score, err := strconv.ParseFloat(value, 64)
+		if err != nil || score < 0 || score > 10 {
+			return options, fmt.Errorf("%s must be a number between 0 and 10", bound.name)
+		}
+		*bound.dest = &score
+	}
+	if options.MinScore != nil && options.MaxScore != nil && *options.MinScore > *options.MaxScore {
+		return options, errors.New("min_score cannot be greater than max_score")
+	}
+
+	limit := defaultListLimit
+	if value := query.Get("limit"); value != "" {
+		l, err := strconv.Atoi(value)
+		if err != nil || l < 1 {
+			return options, errors.New("limit must be a positive integer")
+		}
+		limit = min(l, maxListLimit)
+	}
+	options.Limit = &limit
+
+	offset := 0
+	if value := query.Get("offset"); value != "" {
+		o, err := strconv.Atoi(value)
+		if err != nil || o < 0 {
+			return options, errors.New("offset must be a non-negative integer")
+		}
+		offset = o
+	}
+	options.Offset = &offset
+
+	if value := query.Get("order_by"); value != "" {
+		orderBy, err := parseOrderBy(value)
+		if err != nil {
+			return options, err
+		}
+		options.OrderBy = orderBy
+	}
+
+	return options, nil
+}
+
 // UpdateIdeaHandler handles requests to update an idea
 func (s *Server) UpdateIdeaHandler(w http.ResponseWriter, r *http.Request) {
 	idStr := chi.URLParam(r, "id")
@@ -357,15 +729,12 @@ func (s *Server) UpdateIdeaHandler(w http.ResponseWriter, r *http.Request) {
 		detector := patterns.NewDetector(s.telos)
 		detectedPatterns := detector.DetectPatterns(idea.Content)
 		analysis.DetectedPatterns = detectedPatterns
-
-		patternNames := make([]string, len(detectedPatterns))
-		for i, p := range detectedPatterns {
-			patternNames[i] = p.Name
-		}
+		names := patternNames(detectedPatterns)
+		scoring.ApplyAnalysisBounds(analysis, idea.Tags, names, s.scoreBounds)
 
 		idea.RawScore = analysis.RawScore
 		idea.FinalScore = analysis.FinalScore
-		idea.Patterns = patternNames
+		idea.Patterns = names
 		idea.Recommendation = analysis.GetRecommendation()
 		idea.Analysis = analysis
 	}
@@ -374,7 +743,15 @@ func (s *Server) UpdateIdeaHandler(w http.ResponseWriter, r *http.Request) {
 		idea.Status = *req.Status
 	}
 
+	if req.Version != nil {
+		idea.Version = *req.Version
+	}
+
 	if err := s.repo.Update(idea); err != nil {
+		if database.IsStaleVersion(err) {
+			respondError(w, http.StatusConflict, "Idea was modified since it was read; fetch it again and retry")
+			return
+		}
 		// Log internal error details but don't expose to client
 		log.Error().Err(err).Str("idea_id", idea.ID).Msg("Failed to update idea")
 		respondError(w, http.StatusInternalServerError, "Failed to update idea")
@@ -387,7 +764,9 @@ func (s *Server) UpdateIdeaHandler(w http.ResponseWriter, r *http.Request) {
 	respondJSON(w, http.StatusOK, ideaToResponse(idea))
 }
 
-// DeleteIdeaHandler handles requests to delete an idea
+// DeleteIdeaHandler handles requests to delete an idea. The idea is moved
+// to the trash, where 'tm trash restore' can bring it back; pass
+// ?permanent=true to remove it for good.
 func (s *Server) DeleteIdeaHandler(w http.ResponseWriter, r *http.Request) {
 	idStr := chi.URLParam(r, "id")
 
@@ -397,8 +776,18 @@ func (s *Server) DeleteIdeaHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	permanent := false
+	if permanentStr := r.URL.Query().Get("permanent"); permanentStr != "" {
+		parsed, err := strconv.ParseBool(permanentStr)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "Invalid permanent parameter")
+			return
+		}
+		permanent = parsed
+	}
+
 	// Check if idea exists
-	_, err := s.repo.GetByID(idStr)
+	idea, err := s.repo.GetByID(idStr)
 	if err != nil {
 		if database.IsNotFound(err) {
 			respondError(w, http.StatusNotFound, "Idea not found")
@@ -409,9 +798,22 @@ func (s *Server) DeleteIdeaHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := s.repo.Delete(idStr); err != nil {
+	if permanent {
+		err = s.repo.Delete(idStr)
+	} else {
+		err = s.trashIdea(idea)
+	}
+	if err != nil {
+		if database.IsNotFound(err) {
+			respondError(w, http.StatusNotFound, "Idea not found")
+			return
+		}
+		if database.IsStaleVersion(err) {
+			respondError(w, http.StatusConflict, "Idea was modified while it was being deleted; retry")
+			return
+		}
 		// Log internal error details but don't expose to client
-		log.Error().Err(err).Str("idea_id", idStr).Msg("Failed to delete idea")
+		log.Error().Err(err).Str("idea_id", idStr).Bool("permanent", permanent).Msg("Failed to delete idea")
 		respondError(w, http.StatusInternalServerError, "Failed to delete idea")
 		return
 	}
@@ -422,54 +824,57 @@ func (s *Server) DeleteIdeaHandler(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNoContent)
 }
 
-// AnalyticsStatsHandler handles requests for analytics statistics
-func (s *Server) AnalyticsStatsHandler(w http.ResponseWriter, _ *http.Request) {
-	// Note: For personal use with <10K ideas, loading all ideas is acceptable.
-	// For larger datasets, this should use SQL aggregation (COUNT, AVG, MIN, MAX).
-	allIdeas, err := s.repo.List(database.ListOptions{})
-	if err != nil {
-		// Log internal error details but don't expose to client
-		log.Error().Err(err).Msg("Failed to get statistics (all ideas)")
-		respondError(w, http.StatusInternalServerError, "Failed to get statistics")
-		return
+// trashIdea moves idea to the trash. A delete carries no version from the
+// client, so when another write lands after idea was read, the current idea
+// is read again and trashed once more rather than failing the delete.
+func (s *Server) trashIdea(idea *models.Idea) error {
+	idea.Trash()
+	err := s.repo.Update(idea)
+	if !database.IsStaleVersion(err) {
+		return err
 	}
 
-	// Get active ideas
-	activeIdeas, err := s.repo.List(database.ListOptions{Status: "active"})
+	current, err := s.repo.GetByID(idea.ID)
 	if err != nil {
-		// Log internal error details but don't expose to client
-		log.Error().Err(err).Msg("Failed to get statistics (active ideas)")
-		respondError(w, http.StatusInternalServerError, "Failed to get statistics")
-		return
-	}
-
-	// Calculate statistics
-	stats := StatsResponse{
-		TotalIdeas:  len(allIdeas),
-		ActiveIdeas: len(activeIdeas),
+		return err
 	}
+	current.Trash()
+	return s.repo.Update(current)
+}
 
-	if len(allIdeas) > 0 {
-		var sum, high, low float64
-		high = allIdeas[0].FinalScore
-		low = allIdeas[0].FinalScore
-
-		for _, idea := range allIdeas {
-			sum += idea.FinalScore
-			if idea.FinalScore > high {
-				high = idea.FinalScore
-			}
-			if idea.FinalScore < low {
-				low = idea.FinalScore
-			}
+// AnalyticsStatsHandler handles requests for analytics statistics.
+// Statistics are served from the materialized analytics summary, which is
+// refreshed when ideas change; pass ?fresh=true to force a live recompute.
+func (s *Server) AnalyticsStatsHandler(w http.ResponseWriter, r *http.Request) {
+	fresh := false
+	if freshStr := r.URL.Query().Get("fresh"); freshStr != "" {
+		parsed, err := strconv.ParseBool(freshStr)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "Invalid fresh parameter")
+			return
 		}
+		fresh = parsed
+	}
 
-		stats.AverageScore = sum / float64(len(allIdeas))
-		stats.HighScore = high
-		stats.LowScore = low
+	summary, err := analytics.NewService(s.repo).GetSummary(fresh)
+	if err != nil {
+		// Log internal error details but don't expose to client
+		log.Error().Err(err).Msg("Failed to get statistics")
+		respondError(w, http.StatusInternalServerError, "Failed to get statistics")
+		return
 	}
 
-	respondJSON(w, http.StatusOK, stats)
+	respondJSON(w, http.StatusOK, StatsResponse{
+		TotalIdeas:   summary.TotalIdeas,
+		ActiveIdeas:  summary.ActiveIdeas,
+		AverageScore: summary.AverageScore,
+		MedianScore:  summary.MedianScore,
+		HighScore:    summary.HighScore,
+		LowScore:     summary.LowScore,
+		ScoreBuckets: summary.ScoreBuckets,
+		TopPatterns:  summary.TopPatterns,
+		RefreshedAt:  summary.RefreshedAt,
+	})
 }
 
 // MetricsHandler handles requests for application metrics
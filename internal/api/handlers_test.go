@@ -1,18 +1,26 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"os"
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/ryacub/telos-idea-matrix/internal/config"
 	"github.com/ryacub/telos-idea-matrix/internal/database"
+	"github.com/ryacub/telos-idea-matrix/internal/llm"
+	"github.com/ryacub/telos-idea-matrix/internal/metrics"
 	"github.com/ryacub/telos-idea-matrix/internal/models"
+	"github.com/ryacub/telos-idea-matrix/internal/profile"
+	"github.com/ryacub/telos-idea-matrix/internal/tasks"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -88,6 +96,189 @@ func TestHealthHandler(t *testing.T) {
 	assert.Contains(t, []string{"healthy", "degraded", "unhealthy"}, response["status"])
 }
 
+// healthProvider is an LLM provider with a fixed availability
+type healthProvider struct {
+	name      string
+	available bool
+}
+
+func (p *healthProvider) Name() string      { return p.name }
+func (p *healthProvider) IsAvailable() bool { return p.available }
+func (p *healthProvider) Analyze(req llm.AnalysisRequest) (*llm.AnalysisResult, error) {
+	return p.AnalyzeContext(context.Background(), req)
+}
+func (p *healthProvider) AnalyzeContext(context.Context, llm.AnalysisRequest) (*llm.AnalysisResult, error) {
+	return nil, llm.ErrUnavailable
+}
+
+// newHealthManager returns a manager without Ollama, which would be
+// registered unreachable, so only available providers and the given one remain
+func newHealthManager(provider llm.Provider) *llm.Manager {
+	cfg := llm.DefaultManagerConfig()
+	cfg.ProviderConfig = llm.ProviderConfig{}
+	manager := llm.NewManager(cfg)
+	manager.RegisterProvider(provider)
+	return manager
+}
+
+func getHealth(t *testing.T, server *Server) (int, HealthResponse) {
+	t.Helper()
+	req := httptest.NewRequest("GET", "/health", nil)
+	w := httptest.NewRecorder()
+	server.Router().ServeHTTP(w, req)
+
+	var response HealthResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Empty(t, w.Header().Get("X-Cache"), "health checks must not be cached")
+	return w.Code, response
+}
+
+func TestHealthHandler_ReportsChecks(t *testing.T) {
+	server, _, cleanup := setupTestServer(t)
+	defer cleanup()
+
+	code, response := getHealth(t, server)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, HealthStatusHealthy, response.Status)
+	assert.Equal(t, HealthCheckUp, response.Checks.Database.Status)
+	assert.Equal(t, HealthCheckUp, response.Checks.Telos.Status)
+	assert.Nil(t, response.Checks.LLMProviders, "providers are left out without AI analysis")
+
+	server.SetLLMManager(newHealthManager(&healthProvider{name: "up", available: true}))
+
+	code, response = getHealth(t, server)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, HealthStatusHealthy, response.Status)
+	require.Contains(t, response.Checks.LLMProviders, "up")
+	assert.Equal(t, HealthCheckUp, response.Checks.LLMProviders["up"].Status)
+	assert.NotNil(t, response.Checks.LLMProviders["up"].LastCheck)
+}
+
+func TestHealthHandler_UnavailableProviderDegrades(t *testing.T) {
+	server, _, cleanup := setupTestServer(t)
+	defer cleanup()
+
+	server.SetLLMManager(newHealthManager(&healthProvider{name: "down", available: false}))
+
+	code, response := getHealth(t, server)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, HealthStatusDegraded, response.Status)
+	assert.Equal(t, HealthCheckDown, response.Checks.LLMProviders["down"].Status)
+}
+
+func TestHealthHandler_MissingTelosDegrades(t *testing.T) {
+	server, _, cleanup := setupTestServer(t)
+	defer cleanup()
+
+	require.NoError(t, os.Remove(server.telosPath))
+
+	code, response := getHealth(t, server)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, HealthStatusDegraded, response.Status)
+	assert.Equal(t, HealthCheckDown, response.Checks.Telos.Status)
+}
+
+func TestHealthHandler_UnhealthyTaskDegrades(t *testing.T) {
+	server, _, cleanup := setupTestServer(t)
+	defer cleanup()
+
+	manager := tasks.NewTaskManager()
+	defer manager.Shutdown()
+	server.SetTaskManager(manager)
+
+	manager.Spawn(tasks.NewScheduledTask("ok", time.Millisecond, func(context.Context) error { return nil }))
+	manager.Spawn(tasks.NewScheduledTask("failing", time.Millisecond, func(context.Context) error {
+		return errors.New("boom")
+	}, tasks.WithMaxConsecutiveFailures(1)))
+	require.Eventually(t, func() bool {
+		return !manager.Status()["failing"].Healthy && !manager.Status()["ok"].LastRun.IsZero()
+	}, 5*time.Second, 5*time.Millisecond)
+
+	code, response := getHealth(t, server)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, HealthStatusDegraded, response.Status)
+	require.Contains(t, response.Checks.Tasks, "ok")
+	assert.Equal(t, HealthCheckUp, response.Checks.Tasks["ok"].Status)
+	assert.NotNil(t, response.Checks.Tasks["ok"].LastCheck)
+	assert.Equal(t, HealthCheckDown, response.Checks.Tasks["failing"].Status)
+}
+
+func TestHealthHandler_DatabaseDownIsUnhealthy(t *testing.T) {
+	server, repo, cleanup := setupTestServer(t)
+	defer cleanup()
+
+	// Prime the response cache to check health isn't served from it
+	code, _ := getHealth(t, server)
+	require.Equal(t, http.StatusOK, code)
+
+	require.NoError(t, repo.Close())
+
+	code, response := getHealth(t, server)
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Equal(t, HealthStatusUnhealthy, response.Status)
+	assert.Equal(t, HealthCheckDown, response.Checks.Database.Status)
+}
+
+func TestPrometheusMetricsHandler(t *testing.T) {
+	server, repo, cleanup := setupTestServer(t)
+	defer cleanup()
+
+	metrics.ExportDatabaseStats(repo.DB())
+	metrics.ExportProviderStats(func() []metrics.ProviderRequestStats {
+		return []metrics.ProviderRequestStats{{Provider: "ollama", Successes: 3, Failures: 1}}
+	})
+	metrics.SetIdeaCounts(map[string]int{"active": 2, "archived": 1})
+	metrics.RecordIdeaCreated()
+	metrics.RecordLLMRequest("ollama", true, 250*time.Millisecond)
+
+	req := httptest.NewRequest("GET", "/metrics", nil)
+	w := httptest.NewRecorder()
+
+	server.Router().ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.True(t, strings.HasPrefix(w.Header().Get("Content-Type"), "text/plain; version=0.0.4"),
+		"unexpected content type %q", w.Header().Get("Content-Type"))
+
+	body := w.Body.String()
+	for _, want := range []string{
+		`telos_ideas{status="active"} 2`,
+		`telos_ideas{status="archived"} 1`,
+		"telos_ideas_created_total",
+		`telos_llm_analysis_duration_seconds_count{provider="ollama"}`,
+		`telos_llm_provider_requests_total{provider="ollama",result="success"} 3`,
+		`telos_llm_provider_requests_total{provider="ollama",result="failure"} 1`,
+		`go_sql_open_connections{db_name="ideas"}`,
+	} {
+		assert.Contains(t, body, want)
+	}
+
+	// Scrapes bypass the response cache
+	w = httptest.NewRecorder()
+	server.Router().ServeHTTP(w, httptest.NewRequest("GET", "/metrics", nil))
+	assert.Empty(t, w.Header().Get("X-Cache"))
+}
+
+func TestRequestIDHeader(t *testing.T) {
+	server, _, cleanup := setupTestServer(t)
+	defer cleanup()
+
+	// A client-supplied ID is echoed back
+	req := httptest.NewRequest("GET", "/api/v1/ideas", nil)
+	req.Header.Set("X-Request-ID", "client-trace-1")
+	w := httptest.NewRecorder()
+	server.Router().ServeHTTP(w, req)
+	assert.Equal(t, "client-trace-1", w.Header().Get("X-Request-ID"))
+
+	// A cached response still carries the current request's ID
+	w = httptest.NewRecorder()
+	server.Router().ServeHTTP(w, httptest.NewRequest("GET", "/api/v1/ideas", nil))
+	assert.Equal(t, "HIT", w.Header().Get("X-Cache"))
+	generated := w.Header().Get("X-Request-ID")
+	_, err := uuid.Parse(generated)
+	assert.NoError(t, err, "expected a generated request ID, got %q", generated)
+}
+
 // Test Analyze Endpoint
 func TestAnalyzeHandler(t *testing.T) {
 	server, _, cleanup := setupTestServer(t)
@@ -185,6 +376,11 @@ func TestCreateIdeaHandler(t *testing.T) {
 				require.NoError(t, err)
 			},
 		},
+		{
+			name:           "whitespace-only content",
+			body:           `{"content":"   "}`,
+			expectedStatus: http.StatusBadRequest,
+		},
 		{
 			name:           "invalid json",
 			body:           `{not valid json}`,
@@ -213,6 +409,173 @@ func TestCreateIdeaHandler(t *testing.T) {
 	}
 }
 
+func TestCreateIdeaHandler_AIAnalysisAndLocation(t *testing.T) {
+	server, repo, cleanup := setupTestServer(t)
+	defer cleanup()
+	server.SetLLMManager(llm.NewManager(llm.DefaultManagerConfig()))
+
+	post := func(body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("POST", "/api/v1/ideas", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		server.Router().ServeHTTP(w, req)
+		return w
+	}
+
+	w := post(`{"content":"Build AI-powered Go code reviewer","use_ai":true,"provider":"rule_based"}`)
+	require.Equal(t, http.StatusCreated, w.Code)
+
+	var response IdeaResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "/api/v1/ideas/"+response.ID, w.Header().Get("Location"))
+	require.NotNil(t, response.Analysis)
+
+	stored, err := repo.GetByID(response.ID)
+	require.NoError(t, err)
+	assert.Equal(t, response.FinalScore, stored.FinalScore)
+
+	w = post(`{"content":"Another idea","use_ai":true,"provider":"no-such-provider"}`)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "unknown provider")
+}
+
+func TestCreateIdeaHandler_EnforcesScoreBounds(t *testing.T) {
+	server, repo, cleanup := setupTestServer(t)
+	defer cleanup()
+	floor, ceiling := 9.0, 1.0
+	server.SetScoreBounds([]profile.ScoreBound{
+		{Pattern: "perfectionism", Floor: &floor},
+		{Pattern: "context switching", Ceiling: &ceiling},
+	})
+
+	create := func(content string) IdeaResponse {
+		t.Helper()
+		req := httptest.NewRequest("POST", "/api/v1/ideas", strings.NewReader(`{"content":"`+content+`"}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		server.Router().ServeHTTP(w, req)
+		require.Equal(t, http.StatusCreated, w.Code)
+
+		var response IdeaResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		return response
+	}
+
+	floored := create("Write a comprehensive guide to knitting")
+	assert.Equal(t, 9.0, floored.FinalScore)
+	require.NotNil(t, floored.Analysis)
+	require.NotEmpty(t, floored.Analysis.ScoringDetails)
+	last := floored.Analysis.ScoringDetails[len(floored.Analysis.ScoringDetails)-1]
+	assert.True(t, strings.HasPrefix(last, "Score floor 9.0 applied (pattern:perfectionism)"), last)
+
+	capped := create("Build an AI-powered Go SaaS in Rust for developers")
+	assert.Equal(t, 1.0, capped.FinalScore)
+
+	stored, err := repo.GetByID(capped.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 1.0, stored.FinalScore)
+	assert.Equal(t, models.CurrentRecommendationThresholds().Recommend(1.0).String(), stored.Recommendation)
+}
+
+func postBatch(t *testing.T, server *Server, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest("POST", "/api/v1/ideas/batch", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	server.Router().ServeHTTP(w, req)
+	return w
+}
+
+func TestBatchCreateIdeasHandler_ReportsEachIdea(t *testing.T) {
+	server, repo, cleanup := setupTestServer(t)
+	defer cleanup()
+	server.SetLLMManager(llm.NewManager(llm.DefaultManagerConfig()))
+
+	w := postBatch(t, server, `{"ideas":[
+		{"content":"Build AI-powered Go code reviewer"},
+		{"content":"   "},
+		{"content":"Launch a SaaS for Go developers","use_ai":true,"provider":"rule_based"},
+		{"content":"Ask an unknown model","use_ai":true,"provider":"nonexistent"},
+		{"content":"Write a blog post","trigger":"new release"}
+	]}`)
+	require.Equal(t, http.StatusMultiStatus, w.Code)
+
+	var response BatchCreateIdeasResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, 3, response.Succeeded)
+	assert.Equal(t, 2, response.Failed)
+	require.Len(t, response.Results, 5)
+
+	wantStatus := []int{http.StatusCreated, http.StatusBadRequest, http.StatusCreated, http.StatusBadRequest, http.StatusCreated}
+	for i, result := range response.Results {
+		assert.Equal(t, i, result.Index, "results keep request order")
+		assert.Equal(t, wantStatus[i], result.Status, "idea %d", i)
+		if result.Status == http.StatusCreated {
+			require.NotNil(t, result.Idea)
+			assert.Empty(t, result.Error)
+			saved, err := repo.GetByID(result.Idea.ID)
+			require.NoError(t, err)
+			assert.Equal(t, result.Idea.Content, saved.Content)
+		} else {
+			assert.Nil(t, result.Idea)
+			assert.NotEmpty(t, result.Error)
+		}
+	}
+	assert.Equal(t, "content is required", response.Results[1].Error)
+	assert.Equal(t, "new release", response.Results[4].Idea.Trigger)
+
+	count, err := repo.Count(database.ListOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, 3, count)
+}
+
+func TestBatchCreateIdeasHandler_RejectsBadBatches(t *testing.T) {
+	server, repo, cleanup := setupTestServer(t)
+	defer cleanup()
+
+	tooMany := BatchCreateIdeasRequest{Ideas: make([]CreateIdeaRequest, MaxBatchSize+1)}
+	for i := range tooMany.Ideas {
+		tooMany.Ideas[i].Content = "An idea"
+	}
+	tooManyBody, err := json.Marshal(tooMany)
+	require.NoError(t, err)
+
+	for name, body := range map[string]string{
+		"too many ideas": string(tooManyBody),
+		"no ideas":       `{"ideas":[]}`,
+		"invalid json":   `{"ideas":`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			w := postBatch(t, server, body)
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+		})
+	}
+
+	count, err := repo.Count(database.ListOptions{})
+	require.NoError(t, err)
+	assert.Zero(t, count, "a rejected batch creates nothing")
+}
+
+func TestBatchCreateIdeasHandler_RequiresAuth(t *testing.T) {
+	_, repo, cleanup := setupTestServer(t)
+	defer cleanup()
+
+	server := NewServer(repo, &models.Telos{}, config.AuthConfig{
+		Enabled: true,
+		Mode:    "api-key",
+		APIKeys: map[string]string{"test-key": "Test Client"},
+	})
+
+	w := postBatch(t, server, `{"ideas":[{"content":"An idea"}]}`)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+	req := httptest.NewRequest("POST", "/api/v1/ideas/batch", strings.NewReader(`{"ideas":[{"content":"An idea"}]}`))
+	req.Header.Set("Authorization", "Bearer test-key")
+	w = httptest.NewRecorder()
+	server.Router().ServeHTTP(w, req)
+	assert.Equal(t, http.StatusMultiStatus, w.Code)
+}
+
 // Test Get Idea Endpoint
 func TestGetIdeaHandler(t *testing.T) {
 	server, repo, cleanup := setupTestServer(t)
@@ -313,7 +676,9 @@ func TestListIdeasHandler(t *testing.T) {
 				err := json.Unmarshal(body, &response)
 				require.NoError(t, err)
 				assert.Equal(t, 3, response.Total)
-				assert.Len(t, response.Ideas, 3)
+				assert.Len(t, response.Items, 3)
+				assert.Equal(t, defaultListLimit, response.Limit)
+				assert.Equal(t, 0, response.Offset)
 			},
 		},
 		{
@@ -335,7 +700,48 @@ func TestListIdeasHandler(t *testing.T) {
 				var response ListIdeasResponse
 				err := json.Unmarshal(body, &response)
 				require.NoError(t, err)
-				assert.Len(t, response.Ideas, 2)
+				assert.Len(t, response.Items, 2)
+				assert.Equal(t, 3, response.Total, "total counts every match, not just this page")
+				assert.Equal(t, 2, response.Limit)
+				assert.Equal(t, 1, response.Offset)
+			},
+		},
+		{
+			name:           "filter by score range",
+			queryParams:    "?min_score=6.5&max_score=8",
+			expectedStatus: http.StatusOK,
+			checkResponse: func(t *testing.T, body []byte) {
+				var response ListIdeasResponse
+				err := json.Unmarshal(body, &response)
+				require.NoError(t, err)
+				require.Len(t, response.Items, 1)
+				assert.Equal(t, "Idea 3", response.Items[0].Content)
+				assert.Equal(t, 1, response.Total)
+			},
+		},
+		{
+			name:           "order by score descending",
+			queryParams:    "?order_by=-final_score",
+			expectedStatus: http.StatusOK,
+			checkResponse: func(t *testing.T, body []byte) {
+				var response ListIdeasResponse
+				err := json.Unmarshal(body, &response)
+				require.NoError(t, err)
+				require.Len(t, response.Items, 3)
+				assert.Equal(t, "Idea 1", response.Items[0].Content)
+				assert.Equal(t, "Idea 3", response.Items[1].Content)
+				assert.Equal(t, "Idea 2", response.Items[2].Content)
+			},
+		},
+		{
+			name:           "limit is capped",
+			queryParams:    "?limit=100000",
+			expectedStatus: http.StatusOK,
+			checkResponse: func(t *testing.T, body []byte) {
+				var response ListIdeasResponse
+				err := json.Unmarshal(body, &response)
+				require.NoError(t, err)
+				assert.Equal(t, maxListLimit, response.Limit)
 			},
 		},
 	}
@@ -355,6 +761,33 @@ func TestListIdeasHandler(t *testing.T) {
 	}
 }
 
+func TestListIdeasHandler_InvalidParams(t *testing.T) {
+	server, _, cleanup := setupTestServer(t)
+	defer cleanup()
+
+	tests := []struct {
+		name        string
+		queryParams string
+	}{
+		{name: "order_by outside whitelist", queryParams: "?order_by=" + url.QueryEscape("final_score; DROP TABLE ideas")},
+		{name: "invalid limit", queryParams: "?limit=abc"},
+		{name: "negative offset", queryParams: "?offset=-1"},
+		{name: "score out of range", queryParams: "?min_score=11"},
+		{name: "inverted score range", queryParams: "?min_score=8&max_score=6"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "/api/v1/ideas"+tt.queryParams, nil)
+			w := httptest.NewRecorder()
+
+			server.Router().ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+		})
+	}
+}
+
 // Test Update Idea Endpoint
 func TestUpdateIdeaHandler(t *testing.T) {
 	server, repo, cleanup := setupTestServer(t)
@@ -398,6 +831,34 @@ func TestUpdateIdeaHandler(t *testing.T) {
 				err := json.Unmarshal(body, &response)
 				require.NoError(t, err)
 				assert.Equal(t, "archived", response.Status)
+				assert.Equal(t, 3, response.Version)
+			},
+		},
+		{
+			name:           "stale version",
+			ideaID:         idea.ID,
+			body:           `{"content":"Edited from an old copy","version":1}`,
+			expectedStatus: http.StatusConflict,
+			checkResponse: func(t *testing.T, body []byte) {
+				var response ErrorResponse
+				err := json.Unmarshal(body, &response)
+				require.NoError(t, err)
+				stored, err := repo.GetByID(idea.ID)
+				require.NoError(t, err)
+				assert.Equal(t, "Updated content", stored.Content)
+			},
+		},
+		{
+			name:           "current version",
+			ideaID:         idea.ID,
+			body:           `{"status":"active","version":3}`,
+			expectedStatus: http.StatusOK,
+			checkResponse: func(t *testing.T, body []byte) {
+				var response IdeaResponse
+				err := json.Unmarshal(body, &response)
+				require.NoError(t, err)
+				assert.Equal(t, "active", response.Status)
+				assert.Equal(t, 4, response.Version)
 			},
 		},
 		{
@@ -497,6 +958,53 @@ func TestDeleteIdeaHandler(t *testing.T) {
 			assert.Equal(t, tt.expectedStatus, w.Code)
 		})
 	}
+
+	// The idea was moved to the trash, not removed
+	trashed, err := repo.GetByID(idea.ID)
+	require.NoError(t, err)
+	assert.Equal(t, string(models.StatusDeleted), trashed.Status)
+}
+
+func TestServer_TrashIdea_RetriesStaleVersion(t *testing.T) {
+	server, repo, cleanup := setupTestServer(t)
+	defer cleanup()
+
+	idea := models.NewIdea("Edited while being deleted")
+	require.NoError(t, repo.Create(idea))
+	read, err := repo.GetByID(idea.ID)
+	require.NoError(t, err)
+
+	// Another write lands between the delete's read and its update
+	idea.Notes = "edited elsewhere"
+	require.NoError(t, repo.Update(idea))
+
+	require.NoError(t, server.trashIdea(read))
+
+	trashed, err := repo.GetByID(idea.ID)
+	require.NoError(t, err)
+	assert.Equal(t, string(models.StatusDeleted), trashed.Status)
+	assert.Equal(t, "edited elsewhere", trashed.Notes, "the other write is kept")
+}
+
+func TestDeleteIdeaHandler_Permanent(t *testing.T) {
+	server, repo, cleanup := setupTestServer(t)
+	defer cleanup()
+
+	idea := models.NewIdea("To be removed for good")
+	require.NoError(t, repo.Create(idea))
+
+	del := func(query string) int {
+		req := httptest.NewRequest("DELETE", "/api/v1/ideas/"+idea.ID+query, nil)
+		w := httptest.NewRecorder()
+		server.Router().ServeHTTP(w, req)
+		return w.Code
+	}
+
+	assert.Equal(t, http.StatusBadRequest, del("?permanent=maybe"))
+	assert.Equal(t, http.StatusNoContent, del("?permanent=true"))
+
+	_, err := repo.GetByID(idea.ID)
+	assert.True(t, database.IsNotFound(err))
 }
 
 // Test Analytics Stats Endpoint
@@ -530,3 +1038,65 @@ func TestAnalyticsStatsHandler(t *testing.T) {
 	assert.Equal(t, 3, response.ActiveIdeas)
 	assert.Greater(t, response.AverageScore, 0.0)
 }
+
+// Test that analytics stats are served from the materialized summary and refreshed after changes
+func TestAnalyticsStatsHandler_MaterializedSummary(t *testing.T) {
+	server, repo, cleanup := setupTestServer(t)
+	defer cleanup()
+
+	getStats := func(query string) StatsResponse {
+		t.Helper()
+		// Bypass the HTTP response cache so each request reaches the handler
+		server.cache.Clear()
+
+		req := httptest.NewRequest("GET", "/api/v1/analytics/stats"+query, nil)
+		w := httptest.NewRecorder()
+		server.Router().ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var response StatsResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		return response
+	}
+
+	require.NoError(t, repo.Create(&models.Idea{ID: uuid.New().String(), Content: "Idea 1", Status: "active", FinalScore: 8.0}))
+	require.NoError(t, repo.Create(&models.Idea{ID: uuid.New().String(), Content: "Idea 2", Status: "active", FinalScore: 4.0}))
+
+	first := getStats("")
+	assert.Equal(t, 2, first.TotalIdeas)
+	assert.InDelta(t, 6.0, first.MedianScore, 0.001)
+	require.NotNil(t, first.RefreshedAt, "response should carry a freshness timestamp")
+
+	summary, err := repo.GetAnalyticsSummary()
+	require.NoError(t, err)
+	assert.False(t, summary.Stale, "summary should be fresh after being served")
+
+	// Changing ideas marks the materialized summary stale
+	require.NoError(t, repo.Create(&models.Idea{ID: uuid.New().String(), Content: "Idea 3", Status: "archived", FinalScore: 9.0}))
+	summary, err = repo.GetAnalyticsSummary()
+	require.NoError(t, err)
+	assert.True(t, summary.Stale, "summary should be stale after ideas change")
+
+	second := getStats("")
+	assert.Equal(t, 3, second.TotalIdeas)
+	assert.Equal(t, 2, second.ActiveIdeas)
+	assert.Equal(t, 2, second.ScoreBuckets["8-10"])
+	require.NotNil(t, second.RefreshedAt)
+	assert.False(t, second.RefreshedAt.Before(*first.RefreshedAt))
+
+	summary, err = repo.GetAnalyticsSummary()
+	require.NoError(t, err)
+	assert.False(t, summary.Stale, "summary should be refreshed after ideas change")
+
+	// Forcing a fresh recompute still returns a timestamped summary
+	fresh := getStats("?fresh=true")
+	assert.Equal(t, 3, fresh.TotalIdeas)
+	assert.NotNil(t, fresh.RefreshedAt)
+
+	// Invalid fresh parameter is rejected
+	server.cache.Clear()
+	req := httptest.NewRequest("GET", "/api/v1/analytics/stats?fresh=maybe", nil)
+	w := httptest.NewRecorder()
+	server.Router().ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
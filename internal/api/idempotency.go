@@ -0,0 +1,129 @@
+package api
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/ryacub/telos-idea-matrix/internal/database"
+	"github.com/ryacub/telos-idea-matrix/internal/logging"
+)
+
+// IdempotencyKeyHeader lets a client retry a create request safely: a request
+// repeating a key seen within the idempotency TTL gets the original response
+// instead of creating another idea.
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// IdempotentReplayedHeader is set to "true" on responses replayed for a
+// repeated idempotency key
+const IdempotentReplayedHeader = "Idempotent-Replayed"
+
+// DefaultIdempotencyTTL is how long idempotency keys are remembered unless
+// SetIdempotencyTTL says otherwise
+const DefaultIdempotencyTTL = 24 * time.Hour
+
+// maxIdempotencyKeyLength bounds keys, which are stored as sent
+const maxIdempotencyKeyLength = 255
+
+// idempotencyLocks tracks the keys whose requests are still being processed,
+// so a retry sent before the original finished can't create a second idea
+type idempotencyLocks struct {
+	mu       sync.Mutex
+	inFlight map[string]bool
+}
+
+// acquire claims key, reporting false if a request with it is in flight
+func (l *idempotencyLocks) acquire(key string) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.inFlight == nil {
+		l.inFlight = make(map[string]bool)
+	}
+	if l.inFlight[key] {
+		return false
+	}
+	l.inFlight[key] = true
+	return true
+}
+
+func (l *idempotencyLocks) release(key string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	delete(l.inFlight, key)
+}
+
+// SetIdempotencyTTL sets how long idempotency keys are remembered; zero
+// ignores the Idempotency-Key header
+func (s *Server) SetIdempotencyTTL(ttl time.Duration) {
+	s.idempotencyTTL = ttl
+}
+
+// requestHash identifies a request body, so a key reused for a different
+// request can be told apart from a retry
+func requestHash(body []byte) string {
+	sum := sha256.Sum256(body)
+	return hex.EncodeToString(sum[:])
+}
+
+// validIdempotencyKey accepts non-empty keys of printable ASCII, like the
+// request IDs accepted by the logging middleware
+func validIdempotencyKey(key string) bool {
+	if key == "" || len(key) > maxIdempotencyKeyLength {
+		return false
+	}
+	for i := 0; i < len(key); i++ {
+		if key[i] < 0x20 || key[i] > 0x7e {
+			return false
+		}
+	}
+	return true
+}
+
+// replayIdempotent writes the stored response for key if one exists and
+// reports whether the request has been answered: by the replay, or by an
+// error because the key was reused for a different body or the lookup
+// failed.
+func (s *Server) replayIdempotent(w http.ResponseWriter, r *http.Request, key, hash string) bool {
+	rec, err := s.repo.GetIdempotencyRecord(key, time.Now().Add(-s.idempotencyTTL))
+	if database.IsNotFound(err) {
+		return false
+	}
+	if err != nil {
+		logging.FromContext(r.Context()).Error().Err(err).Msg("Failed to look up idempotency key")
+		respondError(w, http.StatusInternalServerError, "Failed to create idea")
+		return true
+	}
+
+	if rec.RequestHash != hash {
+		respondError(w, http.StatusUnprocessableEntity, "Idempotency-Key was already used for a different request")
+		return true
+	}
+
+	logging.FromContext(r.Context()).Info().Str("idea_id", rec.IdeaID).Msg("Replayed idempotent create")
+	w.Header().Set("Location", "/api/v1/ideas/"+rec.IdeaID)
+	w.Header().Set(IdempotentReplayedHeader, "true")
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(rec.Status)
+	_, _ = w.Write(rec.Response)
+	return true
+}
+
+// saveIdempotent remembers the response to a create request with key. A
+// failure is logged rather than returned: the idea exists, so the client
+// should get its response, and only a retry would be affected.
+func (s *Server) saveIdempotent(r *http.Request, key, hash, ideaID string, status int, response []byte) {
+	now := time.Now().UTC()
+	rec := &database.IdempotencyRecord{
+		Key:         key,
+		RequestHash: hash,
+		IdeaID:      ideaID,
+		Status:      status,
+		Response:    response,
+		CreatedAt:   now,
+	}
+	if err := s.repo.SaveIdempotencyRecord(rec, now.Add(-s.idempotencyTTL)); err != nil {
+		logging.FromContext(r.Context()).Error().Err(err).Str("idea_id", ideaID).Msg("Failed to save idempotency key")
+	}
+}
@@ -0,0 +1,129 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ryacub/telos-idea-matrix/internal/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func postIdea(server *Server, body, key string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest("POST", "/api/v1/ideas", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	if key != "" {
+		req.Header.Set(IdempotencyKeyHeader, key)
+	}
+	w := httptest.NewRecorder()
+	server.Router().ServeHTTP(w, req)
+	return w
+}
+
+func countIdeas(t *testing.T, repo *database.Repository) int {
+	t.Helper()
+	ideas, err := repo.List(database.ListOptions{})
+	require.NoError(t, err)
+	return len(ideas)
+}
+
+func TestCreateIdeaHandler_IdempotencyKeyReplaysOriginalResponse(t *testing.T) {
+	server, repo, cleanup := setupTestServer(t)
+	defer cleanup()
+
+	body := `{"content":"Build AI-powered Go code reviewer"}`
+	first := postIdea(server, body, "retry-abc-123")
+	require.Equal(t, http.StatusCreated, first.Code)
+	assert.Empty(t, first.Header().Get(IdempotentReplayedHeader))
+
+	retry := postIdea(server, body, "retry-abc-123")
+	require.Equal(t, http.StatusCreated, retry.Code)
+	assert.Equal(t, "true", retry.Header().Get(IdempotentReplayedHeader))
+	assert.Equal(t, first.Header().Get("Location"), retry.Header().Get("Location"))
+	assert.Equal(t, first.Body.String(), retry.Body.String())
+
+	var response IdeaResponse
+	require.NoError(t, json.Unmarshal(retry.Body.Bytes(), &response))
+	assert.Equal(t, "/api/v1/ideas/"+response.ID, retry.Header().Get("Location"))
+	assert.Equal(t, 1, countIdeas(t, repo))
+
+	// A different key is a different request
+	other := postIdea(server, body, "retry-abc-456")
+	require.Equal(t, http.StatusCreated, other.Code)
+	assert.Equal(t, 2, countIdeas(t, repo))
+}
+
+func TestCreateIdeaHandler_IdempotencyKeyReusedForDifferentRequest(t *testing.T) {
+	server, repo, cleanup := setupTestServer(t)
+	defer cleanup()
+
+	require.Equal(t, http.StatusCreated, postIdea(server, `{"content":"First idea"}`, "key-1").Code)
+
+	w := postIdea(server, `{"content":"Second idea"}`, "key-1")
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	assert.Contains(t, w.Body.String(), "different request")
+	assert.Equal(t, 1, countIdeas(t, repo))
+}
+
+func TestCreateIdeaHandler_FailedRequestIsNotRemembered(t *testing.T) {
+	server, repo, cleanup := setupTestServer(t)
+	defer cleanup()
+
+	require.Equal(t, http.StatusBadRequest, postIdea(server, `{"content":""}`, "key-1").Code)
+
+	// The key is free for the corrected request
+	require.Equal(t, http.StatusCreated, postIdea(server, `{"content":"Fixed idea"}`, "key-1").Code)
+	assert.Equal(t, 1, countIdeas(t, repo))
+}
+
+func TestCreateIdeaHandler_ExpiredIdempotencyKey(t *testing.T) {
+	server, repo, cleanup := setupTestServer(t)
+	defer cleanup()
+
+	body := `{"content":"Build AI-powered Go code reviewer"}`
+	require.NoError(t, repo.SaveIdempotencyRecord(&database.IdempotencyRecord{
+		Key:         "old-key",
+		RequestHash: requestHash([]byte(body)),
+		IdeaID:      "long-gone",
+		Status:      http.StatusCreated,
+		Response:    []byte("{}"),
+		CreatedAt:   time.Now().Add(-DefaultIdempotencyTTL - time.Hour),
+	}, time.Now().Add(-30*24*time.Hour)))
+
+	w := postIdea(server, body, "old-key")
+	require.Equal(t, http.StatusCreated, w.Code)
+	assert.Empty(t, w.Header().Get(IdempotentReplayedHeader))
+	assert.Equal(t, 1, countIdeas(t, repo))
+}
+
+func TestCreateIdeaHandler_IdempotencyDisabled(t *testing.T) {
+	server, repo, cleanup := setupTestServer(t)
+	defer cleanup()
+	server.SetIdempotencyTTL(0)
+
+	body := `{"content":"Build AI-powered Go code reviewer"}`
+	require.Equal(t, http.StatusCreated, postIdea(server, body, "key-1").Code)
+	require.Equal(t, http.StatusCreated, postIdea(server, body, "key-1").Code)
+	assert.Equal(t, 2, countIdeas(t, repo))
+}
+
+func TestCreateIdeaHandler_IdempotencyKeyErrors(t *testing.T) {
+	server, _, cleanup := setupTestServer(t)
+	defer cleanup()
+
+	w := postIdea(server, `{"content":"An idea"}`, strings.Repeat("k", maxIdempotencyKeyLength+1))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	// A retry sent while the original is still being processed
+	require.True(t, server.idempotency.acquire("in-flight"))
+	w = postIdea(server, `{"content":"An idea"}`, "in-flight")
+	assert.Equal(t, http.StatusConflict, w.Code)
+	server.idempotency.release("in-flight")
+
+	w = postIdea(server, `{"content":"An idea"}`, "in-flight")
+	assert.Equal(t, http.StatusCreated, w.Code)
+}
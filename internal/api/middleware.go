@@ -188,8 +188,9 @@ func (rw *responseWriter) Write(b []byte) (int, error) {
 func CacheMiddleware(cache *Cache) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			// Only cache GET requests
-			if r.Method != http.MethodGet {
+			// Only cache GET requests; scrapes and health checks must always
+			// see the current state
+			if r.Method != http.MethodGet || r.URL.Path == "/metrics" || r.URL.Path == "/health" {
 				next.ServeHTTP(w, r)
 				return
 			}
@@ -197,8 +198,12 @@ func CacheMiddleware(cache *Cache) func(http.Handler) http.Handler {
 			// Check cache
 			key := cacheKey(r)
 			if entry, found := cache.Get(key); found {
-				// Serve from cache
+				// Serve from cache, keeping headers already set for this
+				// request such as its X-Request-ID
 				for k, v := range entry.Headers {
+					if _, set := w.Header()[k]; set {
+						continue
+					}
 					w.Header()[k] = v
 				}
 				w.Header().Set("X-Cache", "HIT")
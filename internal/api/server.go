@@ -13,21 +13,36 @@ import (
 	"github.com/go-chi/cors"
 	"github.com/ryacub/telos-idea-matrix/internal/config"
 	"github.com/ryacub/telos-idea-matrix/internal/database"
+	"github.com/ryacub/telos-idea-matrix/internal/llm"
 	"github.com/ryacub/telos-idea-matrix/internal/logging"
+	"github.com/ryacub/telos-idea-matrix/internal/metrics"
 	"github.com/ryacub/telos-idea-matrix/internal/models"
+	"github.com/ryacub/telos-idea-matrix/internal/notify"
+	"github.com/ryacub/telos-idea-matrix/internal/profile"
+	"github.com/ryacub/telos-idea-matrix/internal/tasks"
 	"github.com/ryacub/telos-idea-matrix/internal/telos"
+	"github.com/ryacub/telos-idea-matrix/internal/tracing"
 )
 
 // Server represents the API server
 type Server struct {
 	repo           *database.Repository
 	telos          *models.Telos
+	telosVersion   string // Version of telos recorded on created ideas; empty if unknown
+	telosPath      string // File the telos was loaded from; empty if it was given directly
 	router         *chi.Mux
 	cache          *Cache
 	rateLimiter    *RateLimiter
 	csrfProtection *CSRFProtection
 	sessionManager *SessionManager
 	authConfig     config.AuthConfig
+	notifier       *notify.Batcher    // Nil when webhook notifications are disabled
+	llm            *llm.Manager       // Nil when AI analysis is unavailable
+	tasks          *tasks.TaskManager // Nil when no background tasks run
+	idempotencyTTL time.Duration      // Zero ignores Idempotency-Key headers
+	idempotency    idempotencyLocks
+	analyses       *analysisLimiter // Caps AI analyses in flight
+	scoreBounds    []profile.ScoreBound
 }
 
 // NewServer creates a new API server from a telos configuration object
@@ -49,6 +64,8 @@ func NewServer(repo *database.Repository, telosConfig *models.Telos, authConfig
 		csrfProtection: NewCSRFProtection(1 * time.Hour), // 1-hour token TTL
 		sessionManager: sessionManager,
 		authConfig:     authConfig,
+		idempotencyTTL: DefaultIdempotencyTTL,
+		analyses:       newAnalysisLimiter(DefaultMaxConcurrentAnalyses, DefaultAnalysisQueueTimeout),
 	}
 
 	s.setupRouter()
@@ -64,7 +81,37 @@ func NewServerFromPath(repo *database.Repository, telosPath string, authConfig c
 		return nil, fmt.Errorf("failed to load telos: %w", err)
 	}
 
-	return NewServer(repo, telosData, authConfig), nil
+	version, err := telos.Version(telosPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load telos: %w", err)
+	}
+
+	s := NewServer(repo, telosData, authConfig)
+	s.telosVersion = version
+	s.telosPath = telosPath
+	return s, nil
+}
+
+// SetNotifier sends notifications about newly created ideas through n.
+// The server closes n, flushing pending notifications, when it is closed.
+func (s *Server) SetNotifier(n *notify.Batcher) {
+	s.notifier = n
+}
+
+// SetScoreBounds enforces score floors and ceilings on every idea the server
+// scores
+func (s *Server) SetScoreBounds(bounds []profile.ScoreBound) {
+	s.scoreBounds = bounds
+}
+
+// SetLLMManager enables AI analysis for ideas created with "use_ai"
+func (s *Server) SetLLMManager(m *llm.Manager) {
+	s.llm = m
+}
+
+// SetTaskManager reports the health of tm's background tasks in /health
+func (s *Server) SetTaskManager(tm *tasks.TaskManager) {
+	s.tasks = tm
 }
 
 // loadTelos loads and parses the telos configuration file
@@ -83,9 +130,9 @@ func (s *Server) setupRouter() {
 	r := chi.NewRouter()
 
 	// Middleware (order matters!)
-	r.Use(middleware.RequestID)
 	r.Use(middleware.RealIP)
-	r.Use(logging.Middleware) // Structured logging middleware
+	r.Use(tracing.Middleware) // No-op unless tracing is set up
+	r.Use(logging.Middleware) // Structured logging with per-request correlation IDs
 	r.Use(middleware.Recoverer)
 	r.Use(middleware.Timeout(60 * time.Second))
 
@@ -108,8 +155,8 @@ func (s *Server) setupRouter() {
 	r.Use(cors.Handler(cors.Options{
 		AllowedOrigins:   []string{"http://localhost:5173", "http://localhost:3000", "http://localhost:8080"},
 		AllowedMethods:   []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
-		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "X-CSRF-Token"},
-		ExposedHeaders:   []string{"Link", "X-Cache", "X-RateLimit-Limit"},
+		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "X-CSRF-Token", logging.RequestIDHeader, IdempotencyKeyHeader, "traceparent", "tracestate"},
+		ExposedHeaders:   []string{"Link", "X-Cache", "X-RateLimit-Limit", logging.RequestIDHeader, IdempotentReplayedHeader},
 		AllowCredentials: true,
 		MaxAge:           300,
 	}))
@@ -119,7 +166,7 @@ func (s *Server) setupRouter() {
 
 	// Routes
 	r.Get("/health", s.HealthHandler)
-	r.Get("/metrics", s.MetricsHandler)
+	r.Method(http.MethodGet, "/metrics", metrics.PrometheusHandler())
 
 	// OpenAPI documentation
 	r.Get("/api/openapi.yaml", s.OpenAPIHandler)
@@ -132,6 +179,7 @@ func (s *Server) setupRouter() {
 
 		// Ideas
 		r.Post("/ideas", s.CreateIdeaHandler)
+		r.Post("/ideas/batch", s.BatchCreateIdeasHandler)
 		r.Get("/ideas", s.ListIdeasHandler)
 		r.Get("/ideas/{id}", s.GetIdeaHandler)
 		r.Put("/ideas/{id}", s.UpdateIdeaHandler)
@@ -142,6 +190,9 @@ func (s *Server) setupRouter() {
 
 		// Analytics
 		r.Get("/analytics/stats", s.AnalyticsStatsHandler)
+
+		// In-process metrics snapshot as JSON
+		r.Get("/metrics", s.MetricsHandler)
 	})
 
 	s.router = r
@@ -164,6 +215,7 @@ func (s *Server) Close() error {
 	s.cache.Stop()
 	s.rateLimiter.Stop()
 	s.sessionManager.Stop()
+	s.notifier.Close()
 
 	// Close database connection
 	return s.repo.Close()
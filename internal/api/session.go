@@ -376,6 +376,13 @@ func (sm *SessionManager) ClearSessionCookie(w http.ResponseWriter) {
 func SessionMiddleware(sm *SessionManager) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// Skip sessions for monitoring endpoints: probes would create one
+			// per request, and /health must answer when the database is down
+			if r.URL.Path == "/health" || r.URL.Path == "/metrics" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
 			// Get or create session
 			session, isNew, err := sm.GetOrCreateSession(r)
 			if err != nil {
@@ -1,16 +1,25 @@
 package cli
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"os"
 	"strings"
+	"time"
+	"unicode"
 
 	"github.com/rs/zerolog/log"
 	"github.com/ryacub/telos-idea-matrix/internal/cliutil"
+	"github.com/ryacub/telos-idea-matrix/internal/config"
+	"github.com/ryacub/telos-idea-matrix/internal/llm"
 	"github.com/ryacub/telos-idea-matrix/internal/models"
 	"github.com/ryacub/telos-idea-matrix/internal/scoring"
 	"github.com/ryacub/telos-idea-matrix/internal/utils"
 	"github.com/spf13/cobra"
+	"golang.org/x/term"
 )
 
 func newAddCommand() *cobra.Command {
@@ -21,56 +30,100 @@ func newAddCommand() *cobra.Command {
 	var jsonOutput bool
 	var fromClipboard bool
 	var toClipboard bool
+	var file string
+	var split bool
+	var trigger string
+	var tags string
+	var timeout time.Duration
+	var verbose bool
 
 	cmd := &cobra.Command{
-		Use:   "add <idea>",
-		Short: "Add and score an idea",
+		Use:     "add [idea]",
+		Aliases: []string{"dump"},
+		Short:   "Add and score an idea",
 		Long: `Add an idea, score it against your goals, and save it.
 
+With no idea text and no --from-clipboard, the idea is read from stdin
+when it is piped or redirected.
+
+With --file the idea is read from a file, such as a draft written in your
+editor. Add --split to capture each idea in the file separately: ideas are
+separated by lines containing only "---" or, in files without them, by blank
+lines. A summary of the ideas captured is shown instead of each analysis.
+
 Examples:
   tm add "Build a mobile app"              # Add and save
   tm add "Start a podcast" --ai            # Add with AI analysis
+  tm add "Podcast" --ai --timeout 10s      # Give up on AI after 10s
+  tm add "Podcast" --ai --verbose          # Show which providers were tried
   tm add "Learn Rust" -n                   # Dry-run: score without saving
   tm add "Quick idea" -q                   # Quiet: minimal output
   tm add --from-clipboard                  # Read from clipboard
+  tm dump --file draft.md                  # Read the idea from a file
+  tm dump --file ideas.txt --split         # One idea per paragraph
+  echo "Newsletter for Go devs" | tm dump  # Read from stdin
   tm add "My idea" --json                  # Output as JSON
+  tm add "Price tracker" --trigger "competitor launch"  # Record why now
+  tm add "Quarterly OKRs" --tags work,urgent            # Tag while capturing
 
 Flags:
   -n, --dry-run       Score without saving (preview mode)
   -q, --quiet         Minimal output
       --ai            Use AI for deeper analysis
-      --json          Output as JSON (for scripting)`,
-		Args: func(cmd *cobra.Command, args []string) error {
-			fromClip, _ := cmd.Flags().GetBool("from-clipboard")
-			if !fromClip && len(args) < 1 {
-				return fmt.Errorf("provide an idea or use --from-clipboard")
-			}
-			return nil
-		},
+      --timeout       Deadline for AI analysis before falling back to
+                      rule-based scoring (default: llm.analysis_timeout)
+      --verbose       With --ai, list each provider tried and why it failed
+      --json          Output as JSON (for scripting)
+      --file          Read the idea from a file
+      --split         With --file, capture each idea in the file separately
+      --trigger       What prompted this idea ("why now")
+      --tags          Comma-separated tags for the idea`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			// Get idea text
+			if file != "" && (fromClipboard || len(args) > 0) {
+				return fmt.Errorf("--file cannot be used with --from-clipboard or idea text")
+			}
+			if split && file == "" {
+				return fmt.Errorf("--split requires --file")
+			}
+
 			var ideaText string
-			if fromClipboard {
-				text, err := utils.PasteFromClipboard()
-				if err != nil {
-					return fmt.Errorf("read clipboard: %w", err)
-				}
-				ideaText = strings.TrimSpace(text)
-				if ideaText == "" {
-					return fmt.Errorf("clipboard is empty")
-				}
+			var err error
+			if file != "" {
+				ideaText, err = readIdeaFile(file)
 			} else {
-				ideaText = strings.Join(args, " ")
+				ideaText, err = readIdeaText(cmd.InOrStdin(), args, fromClipboard)
+			}
+			if err != nil {
+				return err
+			}
+
+			if !cmd.Flags().Changed("timeout") {
+				timeout = config.LoadLLMConfig().AnalysisTimeout
+			}
+			if timeout < 0 {
+				return fmt.Errorf("--timeout must not be negative")
 			}
 
-			return runAdd(ideaText, addOptions{
+			opts := addOptions{
 				dryRun:      dryRun,
 				useAI:       useAI,
 				provider:    provider,
 				quiet:       quiet,
 				jsonOutput:  jsonOutput,
 				toClipboard: toClipboard,
-			})
+				trigger:     strings.TrimSpace(trigger),
+				tags:        parseTags(tags),
+				timeout:     timeout,
+				verbose:     verbose,
+			}
+			if split {
+				ideas := splitIdeas(ideaText)
+				if len(ideas) == 0 {
+					return fmt.Errorf("%s has no ideas", file)
+				}
+				return runAddSplit(ideas, opts)
+			}
+			return runAdd(ideaText, opts)
 		},
 	}
 
@@ -82,14 +135,109 @@ Flags:
 	// Feature flags
 	cmd.Flags().BoolVar(&useAI, "ai", false, "Use AI for deeper analysis")
 	cmd.Flags().StringVarP(&provider, "provider", "p", "", "AI provider (ollama|openai|claude)")
+	_ = cmd.RegisterFlagCompletionFunc("provider", completeProviders)
+	cmd.Flags().DurationVar(&timeout, "timeout", 0, "Deadline for AI analysis, e.g. 30s; 0 waits indefinitely (default from llm.analysis_timeout)")
+	cmd.Flags().BoolVar(&verbose, "verbose", false, "With --ai, list each provider tried and why it failed")
+	cmd.Flags().StringVar(&trigger, "trigger", "", "What prompted this idea (e.g. \"competitor launch\")")
+	cmd.Flags().StringVar(&tags, "tags", "", "Comma-separated tags (e.g. work,urgent)")
 
 	// Clipboard flags
 	cmd.Flags().BoolVar(&fromClipboard, "from-clipboard", false, "Read idea from clipboard")
 	cmd.Flags().BoolVar(&toClipboard, "to-clipboard", false, "Copy result to clipboard")
 
+	// File flags
+	cmd.Flags().StringVar(&file, "file", "", "Read the idea from a file")
+	cmd.Flags().BoolVar(&split, "split", false, "With --file, capture each idea in the file separately")
+
 	return cmd
 }
 
+// readIdeaText returns the idea text from the clipboard, the arguments, or
+// stdin when it isn't a terminal, in that order. Clipboard and stdin input
+// have trailing whitespace trimmed.
+func readIdeaText(stdin io.Reader, args []string, fromClipboard bool) (string, error) {
+	if fromClipboard {
+		text, err := utils.PasteFromClipboard()
+		if err != nil {
+			return "", fmt.Errorf("read clipboard: %w", err)
+		}
+		text = strings.TrimSpace(text)
+		if text == "" {
+			return "", fmt.Errorf("clipboard is empty")
+		}
+		return text, nil
+	}
+
+	if len(args) > 0 {
+		return strings.Join(args, " "), nil
+	}
+
+	if f, ok := stdin.(*os.File); ok && term.IsTerminal(int(f.Fd())) {
+		return "", fmt.Errorf("provide an idea, pipe one on stdin, or use --from-clipboard")
+	}
+	content, err := io.ReadAll(stdin)
+	if err != nil {
+		return "", fmt.Errorf("read stdin: %w", err)
+	}
+	text := strings.TrimRightFunc(string(content), unicode.IsSpace)
+	if strings.TrimSpace(text) == "" {
+		return "", fmt.Errorf("stdin is empty: pipe in the idea text, e.g. echo \"my idea\" | tm dump")
+	}
+	return text, nil
+}
+
+// readIdeaFile returns the content of an idea file with trailing whitespace
+// trimmed, failing if the file is missing or blank
+func readIdeaFile(path string) (string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return "", fmt.Errorf("idea file not found: %s", path)
+		}
+		return "", fmt.Errorf("read idea file: %w", err)
+	}
+	text := strings.TrimRightFunc(string(content), unicode.IsSpace)
+	if strings.TrimSpace(text) == "" {
+		return "", fmt.Errorf("idea file is empty: %s", path)
+	}
+	return text, nil
+}
+
+// splitIdeas splits text into separate ideas. Lines containing only "---"
+// separate ideas when there are any, so an idea may span paragraphs;
+// otherwise each block of lines between blank lines is an idea.
+func splitIdeas(text string) []string {
+	lines := strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+
+	delimited := false
+	for _, line := range lines {
+		if strings.TrimSpace(line) == "---" {
+			delimited = true
+			break
+		}
+	}
+
+	var ideas []string
+	var current []string
+	flush := func() {
+		if idea := strings.TrimSpace(strings.Join(current, "\n")); idea != "" {
+			ideas = append(ideas, idea)
+		}
+		current = current[:0]
+	}
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "---" || (!delimited && trimmed == "") {
+			flush()
+			continue
+		}
+		current = append(current, strings.TrimRightFunc(line, unicode.IsSpace))
+	}
+	flush()
+
+	return ideas
+}
+
 type addOptions struct {
 	dryRun      bool
 	useAI       bool
@@ -97,6 +245,10 @@ type addOptions struct {
 	quiet       bool
 	jsonOutput  bool
 	toClipboard bool
+	trigger     string
+	tags        []string
+	timeout     time.Duration // Deadline for AI analysis; zero means none
+	verbose     bool          // Show the providers tried by AI analysis
 }
 
 type addResult struct {
@@ -104,31 +256,70 @@ type addResult struct {
 	Content        string   `json:"content"`
 	Score          float64  `json:"score"`
 	Recommendation string   `json:"recommendation"`
+	Trigger        string   `json:"trigger,omitempty"`
+	Tags           []string `json:"tags,omitempty"`
 	Saved          bool     `json:"saved"`
 	Insights       []string `json:"insights,omitempty"`
 }
 
+// capturedIdea is an idea scored, and saved unless dry-running, by captureIdea.
+// Universal scoring sets universal and insights; telos scoring sets analysis,
+// and insights only for score bounds that applied.
+type capturedIdea struct {
+	idea          *models.Idea
+	universal     *scoring.UniversalScores
+	insights      []string
+	analysis      *models.Analysis
+	fallbackChain []string
+}
+
 func runAdd(ideaText string, opts addOptions) error {
-	var result addResult
-	result.Content = ideaText
-	result.Saved = !opts.dryRun
+	captured, err := captureIdea(ideaText, opts)
+	if err != nil {
+		return err
+	}
+
+	// Output
+	if opts.jsonOutput {
+		return outputAddJSON(captured.idea, captured.insights, opts.dryRun)
+	}
 
-	// Score the idea based on mode
+	if opts.quiet {
+		return outputAddQuiet(captured.idea, opts.dryRun)
+	}
+
+	if captured.universal != nil {
+		return outputAddFull(captured.idea, captured.universal, captured.insights, opts)
+	}
+	return outputAddFullLegacy(captured.idea, captured.analysis, captured.insights, captured.fallbackChain, opts)
+}
+
+// captureIdea scores an idea in the active scoring mode and saves it unless
+// dry-running
+func captureIdea(ideaText string, opts addOptions) (*capturedIdea, error) {
 	if ctx.ScoringMode == ScoringModeUniversal {
-		return runAddUniversal(ideaText, opts)
+		return captureIdeaUniversal(ideaText, opts)
 	}
-	return runAddLegacy(ideaText, opts)
+	return captureIdeaLegacy(ideaText, opts)
 }
 
-func runAddUniversal(ideaText string, opts addOptions) error {
+func captureIdeaUniversal(ideaText string, opts addOptions) (*capturedIdea, error) {
 	// Calculate score
 	analysis, err := ctx.UniversalEngine.Score(ideaText)
 	if err != nil {
-		return fmt.Errorf("failed to score: %w", err)
+		return nil, fmt.Errorf("failed to score: %w", err)
 	}
 
 	// Create idea
 	idea := models.NewIdea(ideaText)
+	idea.Trigger = opts.trigger
+	idea.Tags = opts.tags
+	idea.TelosVersion = ctx.TelosVersion
+	idea.Patterns = detectPatterns(ideaText)
+
+	// Enforce configured score floors and ceilings
+	ctx.UniversalEngine.ApplyBounds(analysis, idea.Tags, idea.Patterns)
+
 	idea.FinalScore = analysis.FinalScore
 	idea.Recommendation = analysis.Recommendation
 
@@ -139,8 +330,9 @@ func runAddUniversal(ideaText string, opts addOptions) error {
 	// Save unless dry-run
 	if !opts.dryRun {
 		if err := ctx.Repository.Create(idea); err != nil {
-			return fmt.Errorf("failed to save: %w", err)
+			return nil, fmt.Errorf("failed to save: %w", err)
 		}
+		ctx.Notifier.Notify(idea)
 	}
 
 	// Convert insights map to slice
@@ -148,29 +340,28 @@ func runAddUniversal(ideaText string, opts addOptions) error {
 	for _, v := range analysis.Insights {
 		insights = append(insights, v)
 	}
-
-	// Output
-	if opts.jsonOutput {
-		return outputAddJSON(idea, insights, opts.dryRun)
-	}
-
-	if opts.quiet {
-		return outputAddQuiet(idea, opts.dryRun)
+	for _, b := range analysis.Bounds {
+		insights = append(insights, b.String())
 	}
 
-	return outputAddFull(idea, &analysis.Universal, insights, opts)
+	return &capturedIdea{idea: idea, universal: &analysis.Universal, insights: insights}, nil
 }
 
-func runAddLegacy(ideaText string, opts addOptions) error {
+func captureIdeaLegacy(ideaText string, opts addOptions) (*capturedIdea, error) {
 	// Use AI if requested
 	var analysis *models.Analysis
+	var fallbackChain []string
 	var err error
 
 	if opts.useAI {
-		analysis, err = ctx.LLMManager.AnalyzeWithProviderOverride(ideaText, opts.provider, "", ctx.Telos)
+		analysis, fallbackChain, err = analyzeWithAI(ideaText, opts)
 		if err != nil {
 			if !opts.quiet {
-				_, _ = cliutil.WarningColor.Printf("AI unavailable, using rule-based: %v\n", err)
+				if errors.Is(err, context.DeadlineExceeded) {
+					_, _ = cliutil.WarningColor.Printf("AI analysis timed out after %s, using rule-based\n", opts.timeout)
+				} else {
+					_, _ = cliutil.WarningColor.Printf("AI unavailable, using rule-based: %v\n", err)
+				}
 			}
 			analysis, err = ctx.Engine.CalculateScore(ideaText)
 		}
@@ -179,21 +370,25 @@ func runAddLegacy(ideaText string, opts addOptions) error {
 	}
 
 	if err != nil {
-		return fmt.Errorf("failed to score: %w", err)
+		return nil, fmt.Errorf("failed to score: %w", err)
 	}
 
 	// Create idea
 	idea := models.NewIdea(ideaText)
-	idea.FinalScore = analysis.FinalScore
-	idea.Recommendation = analysis.GetRecommendation()
+	idea.Trigger = opts.trigger
+	idea.Tags = opts.tags
+	idea.Profile = ctx.TelosProfile
+	idea.TelosVersion = ctx.TelosVersion
 
-	// Detect patterns
-	detectedPatterns := ctx.Detector.DetectPatterns(ideaText)
-	patternStrings := make([]string, len(detectedPatterns))
-	for i, p := range detectedPatterns {
-		patternStrings[i] = fmt.Sprintf("%s: %s", p.Name, p.Description)
+	idea.Patterns = detectPatterns(ideaText)
+
+	// Enforce configured score floors and ceilings
+	var insights []string
+	for _, b := range scoring.ApplyAnalysisBounds(analysis, idea.Tags, idea.Patterns, ctx.ScoreBounds) {
+		insights = append(insights, b.String())
 	}
-	idea.Patterns = patternStrings
+	idea.FinalScore = analysis.FinalScore
+	idea.Recommendation = analysis.GetRecommendation()
 
 	// Serialize analysis
 	analysisJSON, _ := json.Marshal(analysis)
@@ -202,20 +397,115 @@ func runAddLegacy(ideaText string, opts addOptions) error {
 	// Save unless dry-run
 	if !opts.dryRun {
 		if err := ctx.Repository.Create(idea); err != nil {
-			return fmt.Errorf("failed to save: %w", err)
+			return nil, fmt.Errorf("failed to save: %w", err)
+		}
+		ctx.Notifier.Notify(idea)
+	}
+
+	return &capturedIdea{idea: idea, analysis: analysis, insights: insights, fallbackChain: fallbackChain}, nil
+}
+
+// detectPatterns returns the patterns detected in an idea, as stored on it
+func detectPatterns(ideaText string) []string {
+	detected := ctx.Detector.DetectPatterns(ideaText)
+	patternStrings := make([]string, len(detected))
+	for i, p := range detected {
+		patternStrings[i] = fmt.Sprintf("%s: %s", p.Name, p.Description)
+	}
+	return patternStrings
+}
+
+// runAddSplit captures each idea from a split file, carrying on past ideas
+// that fail, and reports a summary
+func runAddSplit(ideaTexts []string, opts addOptions) error {
+	// Per-idea AI warnings would interleave with the summary
+	quietOpts := opts
+	quietOpts.quiet = true
+
+	var results []addResult
+	var failed []string
+	var total float64
+	for i, ideaText := range ideaTexts {
+		captured, err := captureIdea(ideaText, quietOpts)
+		if err != nil {
+			failed = append(failed, fmt.Sprintf("idea %d (%s): %v", i+1, cliutil.TruncateText(ideaText, 40), err))
+			continue
+		}
+		idea := captured.idea
+		total += idea.FinalScore
+
+		result := addResult{
+			Content:        idea.Content,
+			Score:          idea.FinalScore,
+			Recommendation: idea.Recommendation,
+			Trigger:        idea.Trigger,
+			Tags:           idea.Tags,
+			Saved:          !opts.dryRun,
+			Insights:       captured.insights,
+		}
+		if !opts.dryRun {
+			result.ID = idea.ID
+		}
+		results = append(results, result)
+
+		if !opts.jsonOutput {
+			fmt.Printf("%3d. ", i+1)
+			_, _ = cliutil.GetScoreColor(idea.FinalScore).Printf("%4.1f", idea.FinalScore)
+			fmt.Printf("  %-28s %s\n", cliutil.TruncateText(idea.Recommendation, 28), cliutil.TruncateText(strings.Join(strings.Fields(idea.Content), " "), 50))
 		}
 	}
 
-	// Output
 	if opts.jsonOutput {
-		return outputAddJSON(idea, nil, opts.dryRun)
+		if results == nil {
+			results = []addResult{}
+		}
+		output, err := json.MarshalIndent(results, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(output))
+	} else {
+		fmt.Println(strings.Repeat("─", 60))
+		verb := "Saved"
+		if opts.dryRun {
+			verb = "Scored (not saved — dry run)"
+		}
+		if len(results) > 0 {
+			_, _ = cliutil.SuccessColor.Printf("%s %d of %d ideas, average score %.1f\n",
+				verb, len(results), len(ideaTexts), total/float64(len(results)))
+		}
+		for _, failure := range failed {
+			_, _ = cliutil.ErrorColor.Printf("  ✗ %s\n", failure)
+		}
 	}
 
-	if opts.quiet {
-		return outputAddQuiet(idea, opts.dryRun)
+	if len(failed) > 0 {
+		return fmt.Errorf("%d of %d ideas failed", len(failed), len(ideaTexts))
+	}
+	return nil
+}
+
+// analyzeWithAI runs LLM analysis, cancelling it once opts.timeout elapses.
+// It also returns the providers tried (see llm.AnalysisResult.FallbackChain).
+func analyzeWithAI(ideaText string, opts addOptions) (*models.Analysis, []string, error) {
+	analyzeCtx := context.Background()
+	if opts.timeout > 0 {
+		var cancel context.CancelFunc
+		analyzeCtx, cancel = context.WithTimeout(analyzeCtx, opts.timeout)
+		defer cancel()
 	}
 
-	return outputAddFullLegacy(idea, analysis, opts)
+	if opts.provider != "" {
+		if err := ctx.LLMManager.SetPrimaryProvider(opts.provider); err != nil {
+			return nil, nil, fmt.Errorf("failed to set provider: %w", err)
+		}
+	}
+
+	result, err := ctx.LLMManager.AnalyzeWithTelosContext(analyzeCtx, ideaText, ctx.Telos)
+	if err != nil {
+		return nil, nil, err
+	}
+	return llm.ConvertResultToAnalysis(result), result.FallbackChain, nil
 }
 
 func outputAddJSON(idea *models.Idea, insights []string, dryRun bool) error {
@@ -223,6 +513,8 @@ func outputAddJSON(idea *models.Idea, insights []string, dryRun bool) error {
 		Content:        idea.Content,
 		Score:          idea.FinalScore,
 		Recommendation: idea.Recommendation,
+		Trigger:        idea.Trigger,
+		Tags:           idea.Tags,
 		Saved:          !dryRun,
 		Insights:       insights,
 	}
@@ -242,7 +534,8 @@ func outputAddQuiet(idea *models.Idea, dryRun bool) error {
 	scoreColor := cliutil.GetScoreColor(idea.FinalScore)
 	if dryRun {
 		_, _ = scoreColor.Printf("%.1f", idea.FinalScore)
-		fmt.Printf(" %s\n", idea.Recommendation)
+		fmt.Printf(" %s ", idea.Recommendation)
+		_, _ = cliutil.InfoColor.Println("(not saved — dry run)")
 	} else {
 		_, _ = scoreColor.Printf("%.1f", idea.FinalScore)
 		fmt.Printf(" %s [%s]\n", idea.Recommendation, idea.ID[:8])
@@ -250,9 +543,38 @@ func outputAddQuiet(idea *models.Idea, dryRun bool) error {
 	return nil
 }
 
+// printAddHeader prints the idea content and, when given, what prompted it
+// and its tags
+func printAddHeader(idea *models.Idea) {
+	fmt.Println(idea.Content)
+	if idea.Trigger != "" {
+		_, _ = cliutil.InfoColor.Printf("Trigger: %s\n", idea.Trigger)
+	}
+	if len(idea.Tags) > 0 {
+		_, _ = cliutil.InfoColor.Printf("Tags: %s\n", strings.Join(idea.Tags, ", "))
+	}
+	fmt.Println()
+}
+
+// parseTags splits a comma-separated tag list, trimming whitespace and
+// dropping empty and repeated tags
+func parseTags(list string) []string {
+	var tags []string
+	seen := make(map[string]bool)
+	for _, tag := range strings.Split(list, ",") {
+		tag = strings.TrimSpace(tag)
+		if tag == "" || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		tags = append(tags, tag)
+	}
+	return tags
+}
+
 func outputAddFull(idea *models.Idea, scores *scoring.UniversalScores, insights []string, opts addOptions) error {
 	fmt.Println(strings.Repeat("─", 60))
-	fmt.Printf("%s\n\n", idea.Content)
+	printAddHeader(idea)
 
 	// Score with color
 	scoreColor := cliutil.GetScoreColor(idea.FinalScore)
@@ -275,7 +597,7 @@ func outputAddFull(idea *models.Idea, scores *scoring.UniversalScores, insights
 
 	// Status message
 	if opts.dryRun {
-		_, _ = cliutil.InfoColor.Println("Preview only — use 'tm add' without -n to save")
+		_, _ = cliutil.InfoColor.Println("(not saved — dry run) Run without -n to save")
 	} else {
 		_, _ = cliutil.SuccessColor.Printf("Saved [%s]\n", idea.ID[:8])
 	}
@@ -293,9 +615,9 @@ func outputAddFull(idea *models.Idea, scores *scoring.UniversalScores, insights
 	return nil
 }
 
-func outputAddFullLegacy(idea *models.Idea, analysis *models.Analysis, opts addOptions) error {
+func outputAddFullLegacy(idea *models.Idea, analysis *models.Analysis, insights, fallbackChain []string, opts addOptions) error {
 	fmt.Println(strings.Repeat("─", 60))
-	fmt.Printf("%s\n\n", idea.Content)
+	printAddHeader(idea)
 
 	// Score
 	scoreColor := cliutil.GetScoreColor(idea.FinalScore)
@@ -309,6 +631,18 @@ func outputAddFullLegacy(idea *models.Idea, analysis *models.Analysis, opts addO
 	fmt.Printf("Mission:       %.2f/4.00\n", analysis.Mission.Total)
 	fmt.Printf("Anti-Challenge: %.2f/3.50\n", analysis.AntiChallenge.Total)
 	fmt.Printf("Strategic:     %.2f/2.50\n", analysis.Strategic.Total)
+	for _, insight := range insights {
+		fmt.Printf("  • %s\n", insight)
+	}
+
+	// Providers tried, so a rule-based result can be traced to its cause
+	if opts.verbose && len(fallbackChain) > 0 {
+		fmt.Println()
+		_, _ = cliutil.InfoColor.Println("Providers tried:")
+		for _, step := range fallbackChain {
+			fmt.Printf("  • %s\n", step)
+		}
+	}
 
 	// Patterns
 	if len(idea.Patterns) > 0 {
@@ -324,7 +658,7 @@ func outputAddFullLegacy(idea *models.Idea, analysis *models.Analysis, opts addO
 
 	// Status
 	if opts.dryRun {
-		_, _ = cliutil.InfoColor.Println("Preview only — use 'tm add' without -n to save")
+		_, _ = cliutil.InfoColor.Println("(not saved — dry run) Run without -n to save")
 	} else {
 		_, _ = cliutil.SuccessColor.Printf("Saved [%s]\n", idea.ID[:8])
 	}
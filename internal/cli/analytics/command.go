@@ -2,11 +2,14 @@ package analytics
 
 import (
 	"fmt"
+	"io"
 
 	"github.com/rs/zerolog/log"
 	"github.com/ryacub/telos-idea-matrix/internal/analytics"
 	"github.com/ryacub/telos-idea-matrix/internal/cliutil"
+	"github.com/ryacub/telos-idea-matrix/internal/config"
 	"github.com/ryacub/telos-idea-matrix/internal/database"
+	"github.com/ryacub/telos-idea-matrix/internal/models"
 	"github.com/spf13/cobra"
 )
 
@@ -14,10 +17,14 @@ import (
 type CLIContext struct {
 	Repository *database.Repository
 	DBPath     string
+	Telos      *models.Telos // Nil when scoring with a profile instead of telos.md
+	Profile    string        // Restricts analytics to one telos profile; empty means all
 }
 
 // NewAnalyticsCommand creates the analytics command with all subcommands
 func NewAnalyticsCommand(getContext func() *CLIContext) *cobra.Command {
+	var format string
+
 	cmd := &cobra.Command{
 		Use:   "analytics",
 		Short: "View statistics about your ideas",
@@ -25,100 +32,190 @@ func NewAnalyticsCommand(getContext func() *CLIContext) *cobra.Command {
 
 Examples:
   tm analytics              # Show basic statistics
+  tm analytics --format json  # Basic statistics as JSON
   tm analytics trends       # Show score trends over time
   tm analytics report       # Generate comprehensive report
-  tm analytics patterns     # Show pattern frequency`,
+  tm analytics patterns     # Show pattern frequency
+  tm analytics pattern-trends  # Show how pattern frequency changes over time
+  tm analytics correlation  # Show how patterns correlate with scores
+  tm analytics triggers     # Show average score per trigger
+  tm analytics conflicts    # Find ideas that conflict with your telos
+  tm analytics gaps         # Find stretches with no ideas captured
+  tm analytics velocity     # Show how often and when you capture ideas
+  tm analytics score-histogram  # Show how scores cluster, in finer buckets
+  tm analytics heatmap      # Calendar heatmap of idea captures
+  tm analytics duplicates   # Find groups of likely duplicate ideas
+  tm analytics compare --profiles work,personal  # Compare telos profiles
+  tm analytics watch        # Live metrics that refresh in place`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runAnalytics(getContext)
+			return runAnalytics(getContext, format, chartCharset(cmd))
 		},
 	}
 
+	cmd.Flags().StringVar(&format, "format", "text", "Output format: text|json")
+
+	cmd.PersistentFlags().Bool("ascii", false, "Draw charts with ASCII characters (or set display.ascii_charts)")
+
 	// Add subcommands
 	cmd.AddCommand(NewTrendsCommand(getContext))
 	cmd.AddCommand(NewReportCommand(getContext))
 	cmd.AddCommand(NewPatternsCommand(getContext))
+	cmd.AddCommand(NewPatternTrendsCommand(getContext))
 	cmd.AddCommand(NewMetricsCommand(getContext))
+	cmd.AddCommand(NewCorrelationCommand(getContext))
+	cmd.AddCommand(NewTriggersCommand(getContext))
+	cmd.AddCommand(NewConflictsCommand(getContext))
+	cmd.AddCommand(NewGapsCommand(getContext))
+	cmd.AddCommand(NewVelocityCommand(getContext))
+	cmd.AddCommand(NewScoreHistogramCommand(getContext))
+	cmd.AddCommand(NewHeatmapCommand(getContext))
+	cmd.AddCommand(NewDuplicatesCommand(getContext))
+	cmd.AddCommand(NewWatchCommand(getContext))
+	cmd.AddCommand(NewCompareCommand(getContext))
 
 	return cmd
 }
 
-func runAnalytics(getContext func() *CLIContext) error {
+// chartCharset picks chart characters from --ascii or the display.ascii_charts setting
+func chartCharset(cmd *cobra.Command) analytics.Charset {
+	ascii, _ := cmd.Flags().GetBool("ascii")
+	return analytics.ChartCharset(ascii || config.LoadDisplayConfig().ASCIICharts)
+}
+
+// basicAnalytics is the basic statistics view
+type basicAnalytics struct {
+	TotalIdeas   int                `json:"total_ideas"`
+	AverageScore float64            `json:"average_score"`
+	HighScore    float64            `json:"high_score"`
+	LowScore     float64            `json:"low_score"`
+	Distribution scoreDistributions `json:"distribution"`
+
+	charset analytics.Charset // Draws the distribution bar in the text view
+}
+
+// scoreDistributions splits ideas into the same bands as the text view
+type scoreDistributions struct {
+	High   scoreBand `json:"high"`   // >= 7.0
+	Medium scoreBand `json:"medium"` // 5.0-7.0
+	Low    scoreBand `json:"low"`    // < 5.0
+}
+
+// scoreBand is how many ideas fall in a score band
+type scoreBand struct {
+	Count   int     `json:"count"`
+	Percent float64 `json:"percent"`
+}
+
+func runAnalytics(getContext func() *CLIContext, format string, charset analytics.Charset) error {
 	ctx := getContext()
 	if ctx == nil {
 		return fmt.Errorf("CLI context not initialized")
 	}
 
+	renderer, err := cliutil.NewRenderer(format)
+	if err != nil {
+		return err
+	}
+
 	// Fetch all active ideas
 	ideas, err := ctx.Repository.List(database.ListOptions{
-		Status: "active",
+		Status:  "active",
+		Profile: ctx.Profile,
 	})
 	if err != nil {
 		return fmt.Errorf("failed to list ideas: %w", err)
 	}
 
+	out := calculateBasicAnalytics(ctx.Repository, ideas)
+	out.charset = charset
+	return renderer.Render(out)
+}
+
+// calculateBasicAnalytics computes the basic statistics; with no ideas every value is zero
+func calculateBasicAnalytics(repo *database.Repository, ideas []*models.Idea) basicAnalytics {
 	if len(ideas) == 0 {
+		return basicAnalytics{}
+	}
+
+	service := analytics.NewService(repo)
+	stats := service.GetBasicStats(ideas)
+	highPct, mediumPct, lowPct := service.ScoreDistribution(stats)
+
+	return basicAnalytics{
+		TotalIdeas:   stats.TotalIdeas,
+		AverageScore: stats.AverageScore,
+		HighScore:    stats.HighScore,
+		LowScore:     stats.LowScore,
+		Distribution: scoreDistributions{
+			High:   scoreBand{Count: stats.HighCount, Percent: highPct},
+			Medium: scoreBand{Count: stats.MediumCount, Percent: mediumPct},
+			Low:    scoreBand{Count: stats.LowCount, Percent: lowPct},
+		},
+	}
+}
+
+// WriteText implements cliutil.Renderable
+func (b basicAnalytics) WriteText(w io.Writer) error {
+	if b.TotalIdeas == 0 {
 		warningColor := cliutil.GetScoreColor(5.0)
-		if _, err := warningColor.Println("No ideas found. Use 'tm dump' to capture your first idea!"); err != nil {
+		if _, err := warningColor.Fprintln(w, "No ideas found. Use 'tm dump' to capture your first idea!"); err != nil {
 			log.Warn().Err(err).Msg("failed to print warning message")
 		}
 		return nil
 	}
 
-	// Calculate statistics using service
-	service := analytics.NewService(ctx.Repository)
-	stats := service.GetBasicStats(ideas)
-	highPct, mediumPct, lowPct := service.ScoreDistribution(stats)
+	high, medium, low := b.Distribution.High, b.Distribution.Medium, b.Distribution.Low
 
 	// Display statistics
-	fmt.Println("📊 Idea Analytics")
-	fmt.Println("═════════════════════════════════════════════")
-	fmt.Println()
+	fmt.Fprintln(w, "📊 Idea Analytics")
+	fmt.Fprintln(w, "═════════════════════════════════════════════")
+	fmt.Fprintln(w)
 
 	successColor := cliutil.GetScoreColor(10.0)
-	if _, err := successColor.Printf("Total Ideas: %d\n", stats.TotalIdeas); err != nil {
+	if _, err := successColor.Fprintf(w, "Total Ideas: %d\n", b.TotalIdeas); err != nil {
 		log.Warn().Err(err).Msg("failed to print total ideas")
 	}
-	fmt.Printf("Average Score: %.1f/10.0\n", stats.AverageScore)
-	fmt.Printf("Highest Score: %.1f/10.0\n", stats.HighScore)
-	fmt.Printf("Lowest Score:  %.1f/10.0\n\n", stats.LowScore)
+	fmt.Fprintf(w, "Average Score: %.1f/10.0\n", b.AverageScore)
+	fmt.Fprintf(w, "Highest Score: %.1f/10.0\n", b.HighScore)
+	fmt.Fprintf(w, "Lowest Score:  %.1f/10.0\n\n", b.LowScore)
 
-	fmt.Println("Score Distribution:")
+	fmt.Fprintln(w, "Score Distribution:")
 
 	// Visual distribution bar
-	distBar := analytics.RenderDistribution(stats.HighCount, stats.MediumCount, stats.LowCount, 50)
-	fmt.Printf("%s\n\n", distBar)
+	distBar := b.charset.RenderDistribution(high.Count, medium.Count, low.Count, 50)
+	fmt.Fprintf(w, "%s\n\n", distBar)
 
-	if _, err := successColor.Printf("  🔥 High (>= 7.0):   %d ideas (%.0f%%)\n",
-		stats.HighCount, highPct); err != nil {
+	if _, err := successColor.Fprintf(w, "  🔥 High (>= 7.0):   %d ideas (%.0f%%)\n",
+		high.Count, high.Percent); err != nil {
 		log.Warn().Err(err).Msg("failed to print high count")
 	}
 
 	warningColor := cliutil.GetScoreColor(5.0)
-	if _, err := warningColor.Printf("  ⚠️  Medium (5-7):   %d ideas (%.0f%%)\n",
-		stats.MediumCount, mediumPct); err != nil {
+	if _, err := warningColor.Fprintf(w, "  ⚠️  Medium (5-7):   %d ideas (%.0f%%)\n",
+		medium.Count, medium.Percent); err != nil {
 		log.Warn().Err(err).Msg("failed to print medium count")
 	}
 
 	errorColor := cliutil.GetScoreColor(0.0)
-	if _, err := errorColor.Printf("  🚫 Low (< 5.0):     %d ideas (%.0f%%)\n",
-		stats.LowCount, lowPct); err != nil {
+	if _, err := errorColor.Fprintf(w, "  🚫 Low (< 5.0):     %d ideas (%.0f%%)\n",
+		low.Count, low.Percent); err != nil {
 		log.Warn().Err(err).Msg("failed to print low count")
 	}
-	fmt.Println()
+	fmt.Fprintln(w)
 
 	// Recommendations
-	if stats.HighCount > 0 {
-		if _, err := successColor.Printf("✨ You have %d high-scoring ideas to prioritize!\n", stats.HighCount); err != nil {
+	if high.Count > 0 {
+		if _, err := successColor.Fprintf(w, "✨ You have %d high-scoring ideas to prioritize!\n", high.Count); err != nil {
 			log.Warn().Err(err).Msg("failed to print recommendation")
 		}
 	}
-	if stats.LowCount > stats.TotalIdeas/2 {
-		if _, err := warningColor.Println("💡 Tip: Many ideas are low-scoring. Consider aligning more with your telos."); err != nil {
+	if low.Count > b.TotalIdeas/2 {
+		if _, err := warningColor.Fprintln(w, "💡 Tip: Many ideas are low-scoring. Consider aligning more with your telos."); err != nil {
 			log.Warn().Err(err).Msg("failed to print tip")
 		}
 	}
 
-	fmt.Println("═════════════════════════════════════════════")
+	fmt.Fprintln(w, "═════════════════════════════════════════════")
 
 	return nil
 }
@@ -0,0 +1,160 @@
+package analytics
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/ryacub/telos-idea-matrix/internal/analytics"
+	"github.com/ryacub/telos-idea-matrix/internal/cliutil"
+	"github.com/ryacub/telos-idea-matrix/internal/config"
+	"github.com/ryacub/telos-idea-matrix/internal/database"
+	"github.com/ryacub/telos-idea-matrix/internal/models"
+	"github.com/spf13/cobra"
+)
+
+// NewCompareCommand creates the analytics compare subcommand
+func NewCompareCommand(getContext func() *CLIContext) *cobra.Command {
+	var (
+		profiles string
+		format   string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "compare",
+		Short: "Compare score metrics across telos profiles",
+		Long: `Show the overview metrics of several telos profiles side by side:
+idea count, average, median, highest and lowest score, and the share of
+ideas in each score bucket. Profiles are listed by name, and the Δ column
+is the last profile minus the first, so you can see whether one profile's
+ideas score systematically higher.
+
+Only active ideas are counted.
+
+Examples:
+  tm analytics compare --profiles work,personal
+  tm analytics compare --profiles work,personal --format json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runCompare(getContext, profiles, format)
+		},
+	}
+
+	cmd.Flags().StringVar(&profiles, "profiles", "", "Comma-separated telos profiles to compare (at least two)")
+	cmd.Flags().StringVar(&format, "format", "text", "Output format: text|json")
+	_ = cmd.MarkFlagRequired("profiles")
+
+	return cmd
+}
+
+func runCompare(getContext func() *CLIContext, profileList, format string) error {
+	ctx := getContext()
+	if ctx == nil {
+		return fmt.Errorf("CLI context not initialized")
+	}
+
+	renderer, err := cliutil.NewRenderer(format)
+	if err != nil {
+		return err
+	}
+
+	ideasByProfile := make(map[string][]*models.Idea)
+	for _, name := range strings.Split(profileList, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		if err := config.ValidateProfileName(name); err != nil {
+			return err
+		}
+		ideas, err := ctx.Repository.List(database.ListOptions{
+			Status:  "active",
+			Profile: name,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to list ideas for profile %s: %w", name, err)
+		}
+		ideasByProfile[name] = ideas
+	}
+	if len(ideasByProfile) < 2 {
+		return fmt.Errorf("--profiles needs at least two profiles, e.g. --profiles work,personal")
+	}
+
+	report := analytics.CompareProfiles(ideasByProfile)
+
+	return renderer.Render(comparisonOutput(report))
+}
+
+// comparisonOutput renders one column per profile and a delta column
+type comparisonOutput analytics.ComparisonReport
+
+// WriteText implements cliutil.Renderable
+func (o comparisonOutput) WriteText(w io.Writer) error {
+	report := analytics.ComparisonReport(o)
+
+	const labelWidth = 18
+	const columnWidth = 12
+
+	fmt.Fprintln(w, "⚖️  Profile Comparison")
+	fmt.Fprintln(w, "═════════════════════════════════════════════")
+	fmt.Fprintln(w)
+
+	fmt.Fprintf(w, "%-*s", labelWidth, "")
+	for _, p := range report.Profiles {
+		fmt.Fprintf(w, "%*s", columnWidth, truncateColumn(p.Profile, columnWidth-1))
+	}
+	delta := report.Delta
+	fmt.Fprintf(w, "%*s\n", columnWidth, "Δ")
+	fmt.Fprintln(w, strings.Repeat("-", labelWidth+columnWidth*(len(report.Profiles)+1)))
+
+	row := func(label string, value func(analytics.ProfileOverview) string, change string) {
+		fmt.Fprintf(w, "%-*s", labelWidth, label)
+		for _, p := range report.Profiles {
+			fmt.Fprintf(w, "%*s", columnWidth, value(p))
+		}
+		fmt.Fprintf(w, "%*s\n", columnWidth, change)
+	}
+
+	row("Ideas", func(p analytics.ProfileOverview) string { return fmt.Sprintf("%d", p.Metrics.TotalIdeas) },
+		fmt.Sprintf("%+d", delta.TotalIdeas))
+	// Scores of a profile without ideas aren't zero, they don't exist
+	empty := func(p analytics.ProfileOverview) bool { return p.Metrics.TotalIdeas == 0 }
+	bothScored := !empty(report.Profiles[0]) && !empty(report.Profiles[len(report.Profiles)-1])
+	scoreRow := func(label string, score func(analytics.OverviewMetrics) float64, change float64) {
+		changeText := "-"
+		if bothScored {
+			changeText = fmt.Sprintf("%+.2f", change)
+		}
+		row(label, func(p analytics.ProfileOverview) string {
+			if empty(p) {
+				return "-"
+			}
+			return fmt.Sprintf("%.2f", score(p.Metrics))
+		}, changeText)
+	}
+	scoreRow("Average score", func(m analytics.OverviewMetrics) float64 { return m.AverageScore }, delta.AverageScore)
+	scoreRow("Median score", func(m analytics.OverviewMetrics) float64 { return m.MedianScore }, delta.MedianScore)
+	scoreRow("Highest score", func(m analytics.OverviewMetrics) float64 { return m.HighestScore }, delta.HighestScore)
+	scoreRow("Lowest score", func(m analytics.OverviewMetrics) float64 { return m.LowestScore }, delta.LowestScore)
+
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Score distribution (% of ideas):")
+	for _, bucket := range analytics.ScoreBuckets {
+		row("  "+bucket, func(p analytics.ProfileOverview) string { return fmt.Sprintf("%.0f%%", p.Distribution[bucket]) },
+			fmt.Sprintf("%+.0f pts", delta.Distribution[bucket]))
+	}
+
+	fmt.Fprintln(w)
+	fmt.Fprintf(w, "Δ = %s minus %s\n", delta.To, delta.From)
+	fmt.Fprintln(w, "═════════════════════════════════════════════")
+
+	return nil
+}
+
+// truncateColumn shortens a profile name to fit its column
+func truncateColumn(s string, width int) string {
+	if len(s) <= width {
+		return s
+	}
+	return s[:width-1] + "…"
+}
@@ -0,0 +1,137 @@
+package analytics
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/rs/zerolog/log"
+	"github.com/ryacub/telos-idea-matrix/internal/analytics"
+	"github.com/ryacub/telos-idea-matrix/internal/cliutil"
+	"github.com/ryacub/telos-idea-matrix/internal/config"
+	"github.com/ryacub/telos-idea-matrix/internal/database"
+	"github.com/spf13/cobra"
+)
+
+// NewConflictsCommand creates the analytics conflicts subcommand
+func NewConflictsCommand(getContext func() *CLIContext) *cobra.Command {
+	var (
+		format    string
+		rulesFile string
+	)
+
+	defaultRulesPath := filepath.Join(config.ResolvePaths().ConfigDir, "conflicts.yaml")
+
+	cmd := &cobra.Command{
+		Use:   "conflicts",
+		Short: "Find ideas that conflict with your telos or each other",
+		Long: `Flag ideas that run into a failure pattern or challenge from your
+telos.md, and pairs of ideas that a conflict rule marks as mutually exclusive.
+
+Conflict rules are read from ~/.telos/conflicts.yaml (or --rules):
+
+  conflicts:
+    - name: Location
+      description: Can't both relocate and put down roots
+      keywords: [move abroad, relocate]
+      conflicts_with: [buy a house, mortgage]
+
+Examples:
+  tm analytics conflicts                 # Show conflicts
+  tm analytics conflicts --format json   # Output as JSON`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runConflicts(getContext, format, rulesFile, cmd.Flags().Changed("rules"))
+		},
+	}
+
+	cmd.Flags().StringVar(&format, "format", "text", "Output format: text|json")
+	cmd.Flags().StringVar(&rulesFile, "rules", defaultRulesPath, "Path to conflict rules (YAML)")
+
+	return cmd
+}
+
+func runConflicts(getContext func() *CLIContext, format, rulesFile string, explicitRules bool) error {
+	ctx := getContext()
+	if ctx == nil {
+		return fmt.Errorf("CLI context not initialized")
+	}
+
+	renderer, err := cliutil.NewRenderer(format)
+	if err != nil {
+		return err
+	}
+
+	// A missing rules file is only an error if explicitly requested
+	var rules []analytics.ConflictRule
+	if _, err := os.Stat(rulesFile); err == nil || explicitRules {
+		loaded, err := analytics.LoadConflictRules(rulesFile)
+		if err != nil {
+			return err
+		}
+		rules = loaded
+	}
+
+	ideas, err := ctx.Repository.List(database.ListOptions{
+		Status:  "active",
+		Profile: ctx.Profile,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list ideas: %w", err)
+	}
+
+	conflicts := analytics.DetectConflicts(ideas, ctx.Telos, rules)
+
+	return renderer.Render(conflictsOutput{conflicts: conflicts, telosLoaded: ctx.Telos != nil})
+}
+
+// conflictsOutput renders detected conflicts; JSON is the list alone
+type conflictsOutput struct {
+	conflicts   []analytics.Conflict
+	telosLoaded bool
+}
+
+// MarshalJSON writes the conflicts as a list, empty rather than null
+func (o conflictsOutput) MarshalJSON() ([]byte, error) {
+	if o.conflicts == nil {
+		return json.Marshal([]analytics.Conflict{})
+	}
+	return json.Marshal(o.conflicts)
+}
+
+// WriteText implements cliutil.Renderable
+func (o conflictsOutput) WriteText(w io.Writer) error {
+	if !o.telosLoaded {
+		_, _ = cliutil.InfoColor.Fprintln(w, "No telos.md loaded; only conflict rules are checked.")
+	}
+
+	if len(o.conflicts) == 0 {
+		successColor := cliutil.GetScoreColor(10.0)
+		if _, err := successColor.Fprintln(w, "✓ No conflicting ideas found."); err != nil {
+			log.Warn().Err(err).Msg("failed to print success message")
+		}
+		return nil
+	}
+
+	fmt.Fprintln(w, "⚔️  Idea Conflicts")
+	fmt.Fprintln(w, "═════════════════════════════════════════════")
+
+	for _, c := range o.conflicts {
+		fmt.Fprintln(w)
+		warningColor := cliutil.GetScoreColor(5.0)
+		if _, err := warningColor.Fprintf(w, "%s: %s\n", c.Source, c.Reason); err != nil {
+			log.Warn().Err(err).Msg("failed to print conflict")
+		}
+		for _, idea := range c.Ideas {
+			fmt.Fprintf(w, "  %s  %s\n", idea.ID[:min(8, len(idea.ID))], cliutil.TruncateText(idea.Content, 60))
+		}
+		fmt.Fprintf(w, "  Matched: %v\n", c.Keywords)
+	}
+
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "═════════════════════════════════════════════")
+	fmt.Fprintf(w, "%d conflict(s) found\n", len(o.conflicts))
+
+	return nil
+}
@@ -0,0 +1,124 @@
+package analytics
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+	"github.com/ryacub/telos-idea-matrix/internal/analytics"
+	"github.com/ryacub/telos-idea-matrix/internal/cliutil"
+	"github.com/ryacub/telos-idea-matrix/internal/database"
+	"github.com/spf13/cobra"
+)
+
+// NewCorrelationCommand creates the analytics correlation subcommand
+func NewCorrelationCommand(getContext func() *CLIContext) *cobra.Command {
+	var format string
+
+	cmd := &cobra.Command{
+		Use:   "correlation",
+		Short: "Show how patterns correlate with scores",
+		Long: `Compare the scores of ideas with and without each detected pattern.
+
+For every pattern, shows the mean score of ideas that have it, the mean
+score of ideas that don't, and the point-biserial correlation between the
+pattern and the final score. Patterns are ranked from the most negative
+(drags scores down) to the most positive correlation.
+
+Examples:
+  tm analytics correlation                # Show ranked table
+  tm analytics correlation --format json  # Output as JSON
+  tm analytics correlation --format csv   # Output as CSV`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runCorrelation(getContext, format)
+		},
+	}
+
+	cmd.Flags().StringVar(&format, "format", "text", "Output format: text|json|csv")
+
+	return cmd
+}
+
+func runCorrelation(getContext func() *CLIContext, format string) error {
+	ctx := getContext()
+	if ctx == nil {
+		return fmt.Errorf("CLI context not initialized")
+	}
+
+	renderer, err := cliutil.NewRenderer(format)
+	if err != nil {
+		return err
+	}
+
+	ideas, err := ctx.Repository.List(database.ListOptions{
+		Status:  "active",
+		Profile: ctx.Profile,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list ideas: %w", err)
+	}
+
+	correlations := analytics.CalculatePatternScoreCorrelation(ideas)
+
+	return renderer.Render(correlationOutput(correlations))
+}
+
+// correlationOutput renders pattern correlations as a table
+type correlationOutput []analytics.PatternCorrelation
+
+// WriteText implements cliutil.Renderable
+func (o correlationOutput) WriteText(w io.Writer) error {
+	correlations := []analytics.PatternCorrelation(o)
+
+	if len(correlations) == 0 {
+		fmt.Fprintln(w, "No patterns detected in your ideas yet.")
+		return nil
+	}
+
+	fmt.Fprintln(w, "🔗 Pattern / Score Correlation")
+	fmt.Fprintln(w, "═════════════════════════════════════════════")
+	fmt.Fprintln(w)
+	fmt.Fprintf(w, "%-30s %6s %10s %10s %8s\n", "Pattern", "Ideas", "Mean with", "Without", "r")
+	fmt.Fprintln(w, strings.Repeat("-", 68))
+
+	for _, c := range correlations {
+		line := fmt.Sprintf("%-30s %6d %10.2f %10.2f %+8.2f",
+			cliutil.TruncateText(c.Pattern, 27), c.WithCount, c.MeanWith, c.MeanWithout, c.Correlation)
+
+		// Color by direction: strong negative red, strong positive green
+		color := cliutil.GetScoreColor(5.0)
+		switch {
+		case c.Correlation <= -0.3:
+			color = cliutil.GetScoreColor(0.0)
+		case c.Correlation >= 0.3:
+			color = cliutil.GetScoreColor(10.0)
+		}
+		if _, err := color.Fprintln(w, line); err != nil {
+			log.Warn().Err(err).Msg("failed to print correlation row")
+		}
+	}
+
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "r < 0: pattern is associated with lower scores; r > 0: higher scores")
+	fmt.Fprintln(w, "═════════════════════════════════════════════")
+
+	return nil
+}
+
+// CSVRows implements cliutil.CSVRenderable
+func (o correlationOutput) CSVRows() [][]string {
+	rows := [][]string{{"Pattern", "Ideas With", "Ideas Without", "Mean With", "Mean Without", "Correlation"}}
+	for _, c := range o {
+		rows = append(rows, []string{
+			c.Pattern,
+			strconv.Itoa(c.WithCount),
+			strconv.Itoa(c.WithoutCount),
+			fmt.Sprintf("%.2f", c.MeanWith),
+			fmt.Sprintf("%.2f", c.MeanWithout),
+			fmt.Sprintf("%.3f", c.Correlation),
+		})
+	}
+	return rows
+}
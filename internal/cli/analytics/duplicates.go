@@ -0,0 +1,171 @@
+package analytics
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/ryacub/telos-idea-matrix/internal/analytics"
+	"github.com/ryacub/telos-idea-matrix/internal/cliutil"
+	"github.com/ryacub/telos-idea-matrix/internal/config"
+	"github.com/ryacub/telos-idea-matrix/internal/database"
+	"github.com/ryacub/telos-idea-matrix/internal/llm"
+	"github.com/ryacub/telos-idea-matrix/internal/models"
+	"github.com/spf13/cobra"
+)
+
+// NewDuplicatesCommand creates the analytics duplicates subcommand
+func NewDuplicatesCommand(getContext func() *CLIContext) *cobra.Command {
+	var format string
+	var threshold float64
+
+	cmd := &cobra.Command{
+		Use:   "duplicates",
+		Short: "Find groups of likely duplicate ideas",
+		Long: `Find ideas that say nearly the same thing, grouped, with the
+highest-scoring idea in each group suggested as the one to keep.
+
+Ideas are compared by the overlap of their word pairs. When an embedding
+provider is configured, ideas embedded with 'tm bulk embed' are compared by
+the similarity of their embeddings instead. Nothing is changed; archive or
+delete duplicates yourself.
+
+Examples:
+  tm analytics duplicates                    # Groups at 0.9 similarity or more
+  tm analytics duplicates --threshold 0.6    # Looser matching
+  tm analytics duplicates --format json      # Output as JSON`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runDuplicates(getContext, format, threshold)
+		},
+	}
+
+	cmd.Flags().StringVar(&format, "format", "text", "Output format: text|json")
+	cmd.Flags().Float64Var(&threshold, "threshold", 0.9, "Similarity (0-1) at which ideas count as duplicates")
+
+	return cmd
+}
+
+// duplicateReport is the JSON form of the duplicates report
+type duplicateReport struct {
+	Threshold float64          `json:"threshold"`
+	Method    string           `json:"method"`
+	Groups    []duplicateGroup `json:"groups"`
+}
+
+type duplicateGroup struct {
+	Keep  string          `json:"keep"`
+	Ideas []duplicateIdea `json:"ideas"`
+}
+
+type duplicateIdea struct {
+	ID        string  `json:"id"`
+	Content   string  `json:"content"`
+	Score     float64 `json:"score"`
+	Status    string  `json:"status"`
+	CreatedAt string  `json:"created_at"`
+}
+
+func runDuplicates(getContext func() *CLIContext, format string, threshold float64) error {
+	ctx := getContext()
+	if ctx == nil {
+		return fmt.Errorf("CLI context not initialized")
+	}
+
+	renderer, err := cliutil.NewRenderer(format)
+	if err != nil {
+		return err
+	}
+	if threshold <= 0 || threshold > 1 {
+		return fmt.Errorf("invalid threshold %g: must be above 0 and at most 1", threshold)
+	}
+
+	ideas, err := ctx.Repository.List(database.ListOptions{Profile: ctx.Profile})
+	if err != nil {
+		return fmt.Errorf("failed to list ideas: %w", err)
+	}
+
+	similarity, method, err := duplicateSimilarity(ctx.Repository)
+	if err != nil {
+		return err
+	}
+	groups := analytics.FindDuplicateGroupsBy(ideas, threshold, similarity)
+
+	report := duplicateReport{Threshold: threshold, Method: method, Groups: make([]duplicateGroup, len(groups))}
+	for i, group := range groups {
+		report.Groups[i].Keep = group[0].ID
+		for _, idea := range group {
+			report.Groups[i].Ideas = append(report.Groups[i].Ideas, duplicateIdea{
+				ID:        idea.ID,
+				Content:   idea.Content,
+				Score:     idea.FinalScore,
+				Status:    idea.Status,
+				CreatedAt: idea.CreatedAt.Format("2006-01-02T15:04:05Z"),
+			})
+		}
+	}
+
+	return renderer.Render(report)
+}
+
+// WriteText implements cliutil.Renderable
+func (r duplicateReport) WriteText(w io.Writer) error {
+	fmt.Fprintf(w, "🔁 Likely Duplicates (similarity ≥ %.2f, by %s)\n", r.Threshold, r.Method)
+	fmt.Fprintln(w, "═════════════════════════════════════════════")
+	if len(r.Groups) == 0 {
+		fmt.Fprintln(w)
+		_, _ = cliutil.SuccessColor.Fprintln(w, "No likely duplicates found.")
+		return nil
+	}
+
+	for i, group := range r.Groups {
+		fmt.Fprintf(w, "\nGroup %d (%d ideas)\n", i+1, len(group.Ideas))
+		for j, idea := range group.Ideas {
+			marker := "        "
+			if j == 0 {
+				marker = "✓ keep  "
+			}
+			fmt.Fprintf(w, "  %s%s  ", marker, idea.ID[:8])
+			_, _ = cliutil.GetScoreColor(idea.Score).Fprintf(w, "%4.1f", idea.Score)
+			fmt.Fprintf(w, "  %s\n", cliutil.TruncateText(idea.Content, 50))
+		}
+	}
+
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "═════════════════════════════════════════════")
+	duplicates := 0
+	for _, group := range r.Groups {
+		duplicates += len(group.Ideas) - 1
+	}
+	_, _ = cliutil.InfoColor.Fprintf(w, "%d groups, %d ideas could be archived; nothing was changed\n", len(r.Groups), duplicates)
+	fmt.Fprintln(w, `Archive with 'tm archive <id> --reason "duplicate of <id>"'`)
+
+	return nil
+}
+
+// duplicateSimilarity compares ideas by embedding when an embedding provider
+// is configured and both ideas have a current embedding, and by word overlap
+// otherwise. It also describes the method for the report.
+func duplicateSimilarity(repo *database.Repository) (analytics.SimilarityFunc, string, error) {
+	cfg := config.LoadEmbeddingsConfig()
+	embedder, err := llm.NewEmbedder(cfg.Provider, cfg.Model)
+	if err != nil {
+		return analytics.ContentSimilarity, "word overlap", nil
+	}
+
+	embeddings, err := repo.Embeddings(embedder.Model())
+	if err != nil {
+		return nil, "", err
+	}
+	if len(embeddings) == 0 {
+		return analytics.ContentSimilarity, "word overlap", nil
+	}
+
+	similarity := func(a, b *models.Idea) float64 {
+		va, okA := embeddings[a.ID]
+		vb, okB := embeddings[b.ID]
+		if okA && okB {
+			return llm.CosineSimilarity(va, vb)
+		}
+		return analytics.ContentSimilarity(a, b)
+	}
+	return similarity, "embeddings (" + embedder.Model() + ") where available, word overlap otherwise", nil
+}
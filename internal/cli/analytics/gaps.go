@@ -0,0 +1,116 @@
+package analytics
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+	"github.com/ryacub/telos-idea-matrix/internal/analytics"
+	"github.com/ryacub/telos-idea-matrix/internal/cliutil"
+	"github.com/ryacub/telos-idea-matrix/internal/database"
+	"github.com/spf13/cobra"
+)
+
+// NewGapsCommand creates the analytics gaps subcommand
+func NewGapsCommand(getContext func() *CLIContext) *cobra.Command {
+	var format string
+	var limit int
+
+	cmd := &cobra.Command{
+		Use:   "gaps",
+		Short: "Show the longest stretches with no ideas captured",
+		Long: `Find the periods when you captured no ideas, and how often you usually capture.
+
+Gaps are measured between consecutive ideas by capture time, including
+archived ideas, since archiving doesn't change when an idea was captured.
+
+Examples:
+  tm analytics gaps                 # Show the 5 longest gaps
+  tm analytics gaps --limit 10      # Show the 10 longest gaps
+  tm analytics gaps --format json   # Output as JSON`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runGaps(getContext, format, limit)
+		},
+	}
+
+	cmd.Flags().StringVar(&format, "format", "text", "Output format: text|json")
+	cmd.Flags().IntVar(&limit, "limit", 5, "Number of longest gaps to show")
+
+	return cmd
+}
+
+func runGaps(getContext func() *CLIContext, format string, limit int) error {
+	ctx := getContext()
+	if ctx == nil {
+		return fmt.Errorf("CLI context not initialized")
+	}
+
+	renderer, err := cliutil.NewRenderer(format)
+	if err != nil {
+		return err
+	}
+	if limit < 1 {
+		return fmt.Errorf("invalid limit %d: must be at least 1", limit)
+	}
+
+	ideas, err := ctx.Repository.List(database.ListOptions{Profile: ctx.Profile})
+	if err != nil {
+		return fmt.Errorf("failed to list ideas: %w", err)
+	}
+
+	stats := analytics.CaptureGaps(ideas)
+	if len(stats.Gaps) > limit {
+		stats.Gaps = stats.Gaps[:limit]
+	}
+
+	return renderer.Render(gapsOutput(stats))
+}
+
+// gapsOutput renders capture gap stats
+type gapsOutput analytics.CaptureGapStats
+
+// WriteText implements cliutil.Renderable
+func (o gapsOutput) WriteText(w io.Writer) error {
+	stats := analytics.CaptureGapStats(o)
+
+	if stats.Ideas < 2 {
+		warningColor := cliutil.GetScoreColor(5.0)
+		if _, err := warningColor.Fprintln(w, "Capture at least two ideas to see gaps between them."); err != nil {
+			log.Warn().Err(err).Msg("failed to print warning message")
+		}
+		return nil
+	}
+
+	fmt.Fprintln(w, "⏳ Capture Gaps")
+	fmt.Fprintln(w, "═════════════════════════════════════════════")
+	fmt.Fprintln(w)
+	fmt.Fprintf(w, "Ideas:             %d\n", stats.Ideas)
+	fmt.Fprintf(w, "Shortest interval: %s\n", formatGapDays(stats.MinDays))
+	fmt.Fprintf(w, "Median interval:   %s\n", formatGapDays(stats.MedianDays))
+	fmt.Fprintf(w, "Longest interval:  %s\n", formatGapDays(stats.MaxDays))
+	fmt.Fprintln(w)
+
+	fmt.Fprintln(w, "Longest gaps:")
+	fmt.Fprintf(w, "  %-12s %-12s %10s\n", "From", "To", "Length")
+	fmt.Fprintln(w, "  "+strings.Repeat("-", 36))
+	for _, gap := range stats.Gaps {
+		fmt.Fprintf(w, "  %-12s %-12s %10s\n",
+			gap.Start.Local().Format("2006-01-02"), gap.End.Local().Format("2006-01-02"), formatGapDays(gap.Days))
+	}
+
+	fmt.Fprintln(w, "═════════════════════════════════════════════")
+
+	return nil
+}
+
+// formatGapDays shows short intervals in minutes or hours and longer ones in days
+func formatGapDays(days float64) string {
+	if days*24 < 1 {
+		return fmt.Sprintf("%.0f min", days*24*60)
+	}
+	if days < 1 {
+		return fmt.Sprintf("%.1f hours", days*24)
+	}
+	return fmt.Sprintf("%.1f days", days)
+}
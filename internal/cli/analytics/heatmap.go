@@ -0,0 +1,120 @@
+package analytics
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/ryacub/telos-idea-matrix/internal/analytics"
+	"github.com/ryacub/telos-idea-matrix/internal/cliutil"
+	"github.com/ryacub/telos-idea-matrix/internal/database"
+	"github.com/spf13/cobra"
+)
+
+// heatmapWeeks is how many weeks the heatmap shows without --year
+const heatmapWeeks = 53
+
+// NewHeatmapCommand creates the analytics heatmap subcommand
+func NewHeatmapCommand(getContext func() *CLIContext) *cobra.Command {
+	var format, metric string
+	var year int
+
+	cmd := &cobra.Command{
+		Use:   "heatmap",
+		Short: "Show a calendar heatmap of when you capture ideas",
+		Long: `Show a contribution-style calendar of idea captures: one column per week,
+one row per day of the week, each day shaded by how many ideas you captured.
+With --metric avg-score, days are shaded by the average score of their ideas
+instead, from 0 to 10.
+
+The calendar covers the last 53 weeks, or a whole calendar year with --year.
+Captures are counted in local time and include archived ideas.
+
+Examples:
+  tm analytics heatmap                        # The last 53 weeks
+  tm analytics heatmap --year 2024            # All of 2024
+  tm analytics heatmap --metric avg-score     # Shade by average score
+  tm analytics heatmap --format json          # Daily counts as JSON`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runHeatmap(getContext, format, analytics.HeatmapMetric(metric), year, chartCharset(cmd))
+		},
+	}
+
+	cmd.Flags().StringVar(&format, "format", "text", "Output format: text|json")
+	cmd.Flags().StringVar(&metric, "metric", string(analytics.HeatmapCount), "Shade days by: count|avg-score")
+	cmd.Flags().IntVar(&year, "year", 0, "Show this calendar year instead of the last 53 weeks")
+
+	return cmd
+}
+
+func runHeatmap(getContext func() *CLIContext, format string, metric analytics.HeatmapMetric, year int, charset analytics.Charset) error {
+	ctx := getContext()
+	if ctx == nil {
+		return fmt.Errorf("CLI context not initialized")
+	}
+
+	renderer, err := cliutil.NewRenderer(format)
+	if err != nil {
+		return err
+	}
+	if metric != analytics.HeatmapCount && metric != analytics.HeatmapAvgScore {
+		return fmt.Errorf("invalid metric %q: must be count or avg-score", metric)
+	}
+	if year < 0 || year > time.Now().Year() {
+		return fmt.Errorf("invalid year %d: must not be in the future", year)
+	}
+
+	ideas, err := ctx.Repository.List(database.ListOptions{Profile: ctx.Profile})
+	if err != nil {
+		return fmt.Errorf("failed to list ideas: %w", err)
+	}
+
+	var grid *analytics.ActivityGrid
+	period := "the last 53 weeks"
+	if year > 0 {
+		grid = analytics.BuildYearActivityGrid(ideas, year)
+		period = fmt.Sprintf("%d", year)
+	} else {
+		grid = analytics.BuildActivityGrid(ideas, heatmapWeeks)
+	}
+
+	return renderer.Render(heatmapOutput{ActivityGrid: grid, period: period, metric: metric, charset: charset})
+}
+
+// heatmapOutput renders an activity grid; JSON is the grid alone
+type heatmapOutput struct {
+	*analytics.ActivityGrid
+	period  string
+	metric  analytics.HeatmapMetric
+	charset analytics.Charset
+}
+
+// WriteText implements cliutil.Renderable
+func (o heatmapOutput) WriteText(w io.Writer) error {
+	grid, period := o.ActivityGrid, o.period
+	metric, charset := o.metric, o.charset
+
+	if grid.Total == 0 {
+		warningColor := cliutil.GetScoreColor(5.0)
+		if _, err := warningColor.Fprintf(w, "No ideas captured in %s. Use 'tm dump' to capture one!\n", period); err != nil {
+			log.Warn().Err(err).Msg("failed to print warning message")
+		}
+		return nil
+	}
+
+	fmt.Fprintf(w, "🗓️  Capture Activity: %s\n", period)
+	fmt.Fprintln(w, "═════════════════════════════════════════════")
+	fmt.Fprintln(w)
+	fmt.Fprint(w, charset.RenderHeatmap(grid, metric))
+	fmt.Fprintln(w)
+
+	busiest, _ := grid.Busiest()
+	fmt.Fprintf(w, "%d ideas on %s; busiest day %s with %d\n",
+		grid.Total, pluralDays(grid.ActiveDays), busiest.Date, busiest.Count)
+
+	fmt.Fprintln(w, "═════════════════════════════════════════════")
+
+	return nil
+}
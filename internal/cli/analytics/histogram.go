@@ -0,0 +1,127 @@
+package analytics
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/rs/zerolog/log"
+	"github.com/ryacub/telos-idea-matrix/internal/analytics"
+	"github.com/ryacub/telos-idea-matrix/internal/cliutil"
+	"github.com/ryacub/telos-idea-matrix/internal/database"
+	"github.com/spf13/cobra"
+)
+
+// NewScoreHistogramCommand creates the analytics score-histogram subcommand
+func NewScoreHistogramCommand(getContext func() *CLIContext) *cobra.Command {
+	var format string
+	var width float64
+
+	cmd := &cobra.Command{
+		Use:   "score-histogram",
+		Short: "Show how active ideas' scores cluster",
+		Long: `Count active ideas' final scores in buckets across 0-10 and draw them
+as a bar chart, with the mean and median score. Narrow buckets show where
+scores cluster; the last bucket includes scores of exactly 10, and is
+narrower when the width doesn't divide 10 evenly.
+
+Examples:
+  tm analytics score-histogram                     # One-point buckets
+  tm analytics score-histogram --bucket-width 0.5  # Finer buckets
+  tm analytics score-histogram --format json       # Output as JSON`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runScoreHistogram(getContext, format, width, chartCharset(cmd))
+		},
+	}
+
+	cmd.Flags().StringVar(&format, "format", "text", "Output format: text|json")
+	cmd.Flags().Float64Var(&width, "bucket-width", 1.0, "Width of each score bucket, from 0.1 to 10")
+
+	return cmd
+}
+
+// scoreHistogram is the score histogram view
+type scoreHistogram struct {
+	Ideas       int                         `json:"ideas"`
+	BucketWidth float64                     `json:"bucket_width"`
+	Mean        float64                     `json:"mean"`
+	Median      float64                     `json:"median"`
+	Buckets     []analytics.HistogramBucket `json:"buckets"`
+
+	charset analytics.Charset // Draws the bar chart in the text view
+}
+
+func runScoreHistogram(getContext func() *CLIContext, format string, width float64, charset analytics.Charset) error {
+	ctx := getContext()
+	if ctx == nil {
+		return fmt.Errorf("CLI context not initialized")
+	}
+
+	renderer, err := cliutil.NewRenderer(format)
+	if err != nil {
+		return err
+	}
+	// More than 100 buckets wouldn't fit on screen
+	if width < 0.1 || width > 10 {
+		return fmt.Errorf("invalid bucket width %g: must be from 0.1 to 10", width)
+	}
+
+	ideas, err := ctx.Repository.List(database.ListOptions{
+		Status:  "active",
+		Profile: ctx.Profile,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list ideas: %w", err)
+	}
+
+	scores := make([]float64, len(ideas))
+	sum := 0.0
+	for i, idea := range ideas {
+		scores[i] = idea.FinalScore
+		sum += idea.FinalScore
+	}
+
+	out := scoreHistogram{
+		Ideas:       len(ideas),
+		BucketWidth: width,
+		Median:      analytics.CalculateMedian(scores),
+		Buckets:     analytics.Histogram(scores, width),
+		charset:     charset,
+	}
+	if len(ideas) > 0 {
+		out.Mean = sum / float64(len(ideas))
+	}
+	return renderer.Render(out)
+}
+
+// WriteText implements cliutil.Renderable
+func (h scoreHistogram) WriteText(w io.Writer) error {
+	if h.Ideas == 0 {
+		warningColor := cliutil.GetScoreColor(5.0)
+		if _, err := warningColor.Fprintln(w, "No ideas found. Use 'tm dump' to capture your first idea!"); err != nil {
+			log.Warn().Err(err).Msg("failed to print warning message")
+		}
+		return nil
+	}
+
+	fmt.Fprintln(w, "📊 Score Histogram")
+	fmt.Fprintln(w, "═════════════════════════════════════════════")
+	fmt.Fprintln(w)
+	fmt.Fprintf(w, "Ideas:   %d\n", h.Ideas)
+	fmt.Fprintf(w, "Mean:    %.2f/10.0\n", h.Mean)
+	fmt.Fprintf(w, "Median:  %.2f/10.0\n", h.Median)
+	fmt.Fprintln(w)
+
+	labels := make([]string, len(h.Buckets))
+	values := make([]float64, len(h.Buckets))
+	for i, bucket := range h.Buckets {
+		labels[i] = bucket.Label()
+		values[i] = float64(bucket.Count)
+	}
+	fmt.Fprintf(w, "Ideas per %g-point bucket:\n", h.BucketWidth)
+	fmt.Fprint(w, h.charset.RenderBarChart(labels, values, 40))
+
+	fmt.Fprintln(w, "═════════════════════════════════════════════")
+
+	return nil
+}
@@ -1,14 +1,13 @@
 package analytics
 
 import (
-	"encoding/csv"
-	"encoding/json"
 	"fmt"
-	"os"
+	"io"
 	"strconv"
 	"strings"
 
 	"github.com/ryacub/telos-idea-matrix/internal/analytics"
+	"github.com/ryacub/telos-idea-matrix/internal/cliutil"
 	"github.com/ryacub/telos-idea-matrix/internal/database"
 	"github.com/spf13/cobra"
 )
@@ -16,6 +15,7 @@ import (
 type metricsOptions struct {
 	format  string
 	verbose bool
+	charset analytics.Charset
 }
 
 // NewMetricsCommand creates the analytics metrics subcommand
@@ -54,6 +54,7 @@ Examples:
 			return runSystemMetrics(getContext, metricsOptions{
 				format:  format,
 				verbose: verbose,
+				charset: chartCharset(cmd),
 			})
 		},
 	}
@@ -70,8 +71,13 @@ func runSystemMetrics(getContext func() *CLIContext, opts metricsOptions) error
 		return fmt.Errorf("CLI context not initialized")
 	}
 
+	renderer, err := cliutil.NewRenderer(opts.format)
+	if err != nil {
+		return err
+	}
+
 	// Fetch all ideas (not just active)
-	ideas, err := ctx.Repository.List(database.ListOptions{})
+	ideas, err := ctx.Repository.List(database.ListOptions{Profile: ctx.Profile})
 	if err != nil {
 		return fmt.Errorf("failed to fetch ideas: %w", err)
 	}
@@ -85,70 +91,69 @@ func runSystemMetrics(getContext func() *CLIContext, opts metricsOptions) error
 	service := analytics.NewServiceWithDB(ctx.Repository, ctx.DBPath)
 	metrics := service.CalculateSystemMetrics(ideas)
 
-	// Output based on format
-	switch opts.format {
-	case "json":
-		return outputMetricsJSON(metrics)
-	case "csv":
-		return outputMetricsCSV(metrics)
-	default:
-		return outputMetricsText(metrics, opts)
-	}
+	return renderer.Render(systemMetricsOutput{SystemMetrics: metrics, opts: opts})
+}
+
+// systemMetricsOutput renders system metrics; JSON is the metrics alone
+type systemMetricsOutput struct {
+	analytics.SystemMetrics
+	opts metricsOptions
 }
 
-// Output formatters
+// WriteText implements cliutil.Renderable
+func (o systemMetricsOutput) WriteText(w io.Writer) error {
+	metrics, opts := o.SystemMetrics, o.opts
 
-func outputMetricsText(metrics analytics.SystemMetrics, opts metricsOptions) error {
-	fmt.Println("System Metrics")
-	fmt.Println(strings.Repeat("=", 80))
-	fmt.Println()
+	fmt.Fprintln(w, "System Metrics")
+	fmt.Fprintln(w, strings.Repeat("=", 80))
+	fmt.Fprintln(w)
 
 	// Overview
-	fmt.Println("Overview:")
-	fmt.Println(strings.Repeat("-", 80))
-	fmt.Printf("  Total Ideas:      %d\n", metrics.Overview.TotalIdeas)
-	fmt.Printf("  Total Patterns:   %d\n", metrics.Overview.TotalPatterns)
-	fmt.Printf("  Average Score:    %.2f\n", metrics.Overview.AverageScore)
-	fmt.Printf("  Median Score:     %.2f\n", metrics.Overview.MedianScore)
-	fmt.Printf("  Highest Score:    %.2f\n", metrics.Overview.HighestScore)
-	fmt.Printf("  Lowest Score:     %.2f\n", metrics.Overview.LowestScore)
-	fmt.Println()
+	fmt.Fprintln(w, "Overview:")
+	fmt.Fprintln(w, strings.Repeat("-", 80))
+	fmt.Fprintf(w, "  Total Ideas:      %d\n", metrics.Overview.TotalIdeas)
+	fmt.Fprintf(w, "  Total Patterns:   %d\n", metrics.Overview.TotalPatterns)
+	fmt.Fprintf(w, "  Average Score:    %.2f\n", metrics.Overview.AverageScore)
+	fmt.Fprintf(w, "  Median Score:     %.2f\n", metrics.Overview.MedianScore)
+	fmt.Fprintf(w, "  Highest Score:    %.2f\n", metrics.Overview.HighestScore)
+	fmt.Fprintf(w, "  Lowest Score:     %.2f\n", metrics.Overview.LowestScore)
+	fmt.Fprintln(w)
 
 	// Status Breakdown
-	fmt.Println("Status Breakdown:")
-	fmt.Println(strings.Repeat("-", 80))
+	fmt.Fprintln(w, "Status Breakdown:")
+	fmt.Fprintln(w, strings.Repeat("-", 80))
 	total := metrics.Overview.TotalIdeas
 	for status, count := range metrics.StatusBreakdown {
 		pct := float64(count) / float64(total) * 100
-		fmt.Printf("  %-10s: %5d (%.1f%%)\n", status, count, pct)
+		fmt.Fprintf(w, "  %-10s: %5d (%.1f%%)\n", status, count, pct)
 	}
-	fmt.Println()
+	fmt.Fprintln(w)
 
 	// Score Distribution
-	fmt.Println("Score Distribution:")
-	fmt.Println(strings.Repeat("-", 80))
+	fmt.Fprintln(w, "Score Distribution:")
+	fmt.Fprintln(w, strings.Repeat("-", 80))
 	bucketOrder := []string{"0-2", "2-4", "4-6", "6-8", "8-10"}
 	for _, bucket := range bucketOrder {
 		count := metrics.ScoreDistribution.Buckets[bucket]
 		pct := float64(count) / float64(total) * 100
-		bar := strings.Repeat("█", int(pct/2))
-		fmt.Printf("  %5s: %5d (%.1f%%) %s\n", bucket, count, pct, bar)
+		bar := strings.Repeat(opts.charset.Bar, int(pct/2))
+		fmt.Fprintf(w, "  %5s: %5d (%.1f%%) %s\n", bucket, count, pct, bar)
 	}
-	fmt.Printf("  StdDev: %.2f\n", metrics.ScoreDistribution.StdDev)
+	fmt.Fprintf(w, "  StdDev: %.2f\n", metrics.ScoreDistribution.StdDev)
 	if opts.verbose {
-		fmt.Println("\n  Percentiles:")
-		fmt.Printf("    P50: %.2f\n", metrics.ScoreDistribution.Percentiles["P50"])
-		fmt.Printf("    P75: %.2f\n", metrics.ScoreDistribution.Percentiles["P75"])
-		fmt.Printf("    P90: %.2f\n", metrics.ScoreDistribution.Percentiles["P90"])
-		fmt.Printf("    P95: %.2f\n", metrics.ScoreDistribution.Percentiles["P95"])
-		fmt.Printf("    P99: %.2f\n", metrics.ScoreDistribution.Percentiles["P99"])
+		fmt.Fprintln(w, "\n  Percentiles:")
+		fmt.Fprintf(w, "    P50: %.2f\n", metrics.ScoreDistribution.Percentiles["P50"])
+		fmt.Fprintf(w, "    P75: %.2f\n", metrics.ScoreDistribution.Percentiles["P75"])
+		fmt.Fprintf(w, "    P90: %.2f\n", metrics.ScoreDistribution.Percentiles["P90"])
+		fmt.Fprintf(w, "    P95: %.2f\n", metrics.ScoreDistribution.Percentiles["P95"])
+		fmt.Fprintf(w, "    P99: %.2f\n", metrics.ScoreDistribution.Percentiles["P99"])
 	}
-	fmt.Println()
+	fmt.Fprintln(w)
 
 	// Top Patterns
 	if len(metrics.PatternStats) > 0 {
-		fmt.Println("Top Patterns:")
-		fmt.Println(strings.Repeat("-", 80))
+		fmt.Fprintln(w, "Top Patterns:")
+		fmt.Fprintln(w, strings.Repeat("-", 80))
 		topN := 10
 		if opts.verbose {
 			topN = 20
@@ -157,79 +162,56 @@ func outputMetricsText(metrics analytics.SystemMetrics, opts metricsOptions) err
 			if i >= topN {
 				break
 			}
-			fmt.Printf("  %2d. %-30s: %4d ideas (%.1f%%)\n",
+			fmt.Fprintf(w, "  %2d. %-30s: %4d ideas (%.1f%%)\n",
 				i+1, ps.Pattern, ps.Count, ps.Percentage)
 		}
 		if len(metrics.PatternStats) > topN {
-			fmt.Printf("  ... and %d more patterns\n", len(metrics.PatternStats)-topN)
+			fmt.Fprintf(w, "  ... and %d more patterns\n", len(metrics.PatternStats)-topN)
 		}
-		fmt.Println()
+		fmt.Fprintln(w)
 	}
 
 	// Time Metrics
-	fmt.Println("Activity Timeline:")
-	fmt.Println(strings.Repeat("-", 80))
-	fmt.Printf("  Oldest Idea:      %s\n", metrics.TimeMetrics.OldestIdea.Format("2006-01-02"))
-	fmt.Printf("  Newest Idea:      %s\n", metrics.TimeMetrics.NewestIdea.Format("2006-01-02"))
-	fmt.Printf("  Total Days:       %d\n", metrics.TimeMetrics.TotalDays)
-	fmt.Printf("  Ideas per Day:    %.2f\n", metrics.TimeMetrics.IdeasPerDay)
-	fmt.Printf("  Last 7 Days:      %d ideas\n", metrics.TimeMetrics.IdeasLast7Days)
-	fmt.Printf("  Last 30 Days:     %d ideas\n", metrics.TimeMetrics.IdeasLast30Days)
-	fmt.Println()
+	fmt.Fprintln(w, "Activity Timeline:")
+	fmt.Fprintln(w, strings.Repeat("-", 80))
+	fmt.Fprintf(w, "  Oldest Idea:      %s\n", metrics.TimeMetrics.OldestIdea.Format("2006-01-02"))
+	fmt.Fprintf(w, "  Newest Idea:      %s\n", metrics.TimeMetrics.NewestIdea.Format("2006-01-02"))
+	fmt.Fprintf(w, "  Total Days:       %d\n", metrics.TimeMetrics.TotalDays)
+	fmt.Fprintf(w, "  Ideas per Day:    %.2f\n", metrics.TimeMetrics.IdeasPerDay)
+	fmt.Fprintf(w, "  Today:            %d ideas\n", metrics.TimeMetrics.IdeasToday)
+	fmt.Fprintf(w, "  Last 7 Days:      %d ideas\n", metrics.TimeMetrics.IdeasLast7Days)
+	fmt.Fprintf(w, "  Last 30 Days:     %d ideas\n", metrics.TimeMetrics.IdeasLast30Days)
+	fmt.Fprintln(w)
 
 	// Database Stats
 	if opts.verbose && metrics.DatabaseStats.SizeFormatted != "Unknown" {
-		fmt.Println("Database:")
-		fmt.Println(strings.Repeat("-", 80))
-		fmt.Printf("  Size:             %s\n", metrics.DatabaseStats.SizeFormatted)
-		fmt.Printf("  Tables:           %d\n", metrics.DatabaseStats.TableCount)
-		fmt.Printf("  Indexes:          %d\n", metrics.DatabaseStats.IndexCount)
-		fmt.Println()
+		fmt.Fprintln(w, "Database:")
+		fmt.Fprintln(w, strings.Repeat("-", 80))
+		fmt.Fprintf(w, "  Size:             %s\n", metrics.DatabaseStats.SizeFormatted)
+		fmt.Fprintf(w, "  Tables:           %d\n", metrics.DatabaseStats.TableCount)
+		fmt.Fprintf(w, "  Indexes:          %d\n", metrics.DatabaseStats.IndexCount)
+		fmt.Fprintln(w)
 	}
 
 	return nil
 }
 
-func outputMetricsJSON(metrics analytics.SystemMetrics) error {
-	encoder := json.NewEncoder(os.Stdout)
-	encoder.SetIndent("", "  ")
-	return encoder.Encode(metrics)
-}
-
-func outputMetricsCSV(metrics analytics.SystemMetrics) error {
-	writer := csv.NewWriter(os.Stdout)
-	defer writer.Flush()
-
-	// Overview section
-	if err := writer.Write([]string{"Metric", "Value"}); err != nil {
-		return fmt.Errorf("failed to write CSV header: %w", err)
-	}
-	if err := writer.Write([]string{"Total Ideas", strconv.Itoa(metrics.Overview.TotalIdeas)}); err != nil {
-		return fmt.Errorf("failed to write CSV row: %w", err)
-	}
-	if err := writer.Write([]string{"Total Patterns", strconv.Itoa(metrics.Overview.TotalPatterns)}); err != nil {
-		return fmt.Errorf("failed to write CSV row: %w", err)
-	}
-	if err := writer.Write([]string{"Average Score", fmt.Sprintf("%.2f", metrics.Overview.AverageScore)}); err != nil {
-		return fmt.Errorf("failed to write CSV row: %w", err)
-	}
-	if err := writer.Write([]string{"Median Score", fmt.Sprintf("%.2f", metrics.Overview.MedianScore)}); err != nil {
-		return fmt.Errorf("failed to write CSV row: %w", err)
-	}
-	if err := writer.Write([]string{}); err != nil {
-		return fmt.Errorf("failed to write CSV row: %w", err)
-	}
-
-	// Status breakdown
-	if err := writer.Write([]string{"Status", "Count", "Percentage"}); err != nil {
-		return fmt.Errorf("failed to write CSV header: %w", err)
+// CSVRows implements cliutil.CSVRenderable: the overview, a blank row, then
+// the status breakdown
+func (o systemMetricsOutput) CSVRows() [][]string {
+	metrics := o.SystemMetrics
+	rows := [][]string{
+		{"Metric", "Value"},
+		{"Total Ideas", strconv.Itoa(metrics.Overview.TotalIdeas)},
+		{"Total Patterns", strconv.Itoa(metrics.Overview.TotalPatterns)},
+		{"Average Score", fmt.Sprintf("%.2f", metrics.Overview.AverageScore)},
+		{"Median Score", fmt.Sprintf("%.2f", metrics.Overview.MedianScore)},
+		{},
+		{"Status", "Count", "Percentage"},
 	}
 	for status, count := range metrics.StatusBreakdown {
 		pct := float64(count) / float64(metrics.Overview.TotalIdeas) * 100
-		if err := writer.Write([]string{status, strconv.Itoa(count), fmt.Sprintf("%.1f", pct)}); err != nil {
-			return fmt.Errorf("failed to write CSV row: %w", err)
-		}
+		rows = append(rows, []string{status, strconv.Itoa(count), fmt.Sprintf("%.1f", pct)})
 	}
-
-	return nil
+	return rows
 }
@@ -0,0 +1,196 @@
+package analytics
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+	"github.com/ryacub/telos-idea-matrix/internal/analytics"
+	"github.com/ryacub/telos-idea-matrix/internal/cliutil"
+	"github.com/ryacub/telos-idea-matrix/internal/database"
+	"github.com/spf13/cobra"
+)
+
+// NewPatternTrendsCommand creates the analytics pattern-trends subcommand
+func NewPatternTrendsCommand(getContext func() *CLIContext) *cobra.Command {
+	var groupBy string
+	var periods int
+	var topN int
+	var format string
+
+	cmd := &cobra.Command{
+		Use:   "pattern-trends",
+		Short: "Show how pattern frequency changes over time",
+		Long: `Show the share of ideas with each of your most common patterns, period by period.
+
+Ideas are grouped by day, week or month, and each pattern's share is the
+fraction of that period's ideas it was detected in. A pattern is rising or
+declining when its average share over the recent half of the periods shown
+moved at least 10 points from the earlier half. Anti-patterns that are
+rising are flagged so you can catch a habit creeping back in.
+
+Examples:
+  tm analytics pattern-trends                   # Top 5 patterns, last 6 weeks
+  tm analytics pattern-trends --group-by month  # Monthly shares
+  tm analytics pattern-trends --periods 12 --top 10
+  tm analytics pattern-trends --format json     # Output as JSON`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if periods < 1 {
+				return fmt.Errorf("--periods must be a positive number of periods")
+			}
+			if topN < 1 {
+				return fmt.Errorf("--top must be a positive number of patterns")
+			}
+			if groupBy != "day" && groupBy != "week" && groupBy != "month" {
+				return fmt.Errorf("invalid --group-by %q: must be day, week or month", groupBy)
+			}
+			return runPatternTrends(getContext, groupBy, periods, topN, format, chartCharset(cmd))
+		},
+	}
+
+	cmd.Flags().StringVar(&groupBy, "group-by", "week", "Group by: day, week, or month")
+	cmd.Flags().IntVar(&periods, "periods", 6, "Number of most recent periods to show")
+	cmd.Flags().IntVar(&topN, "top", 5, "Number of top patterns to follow")
+	cmd.Flags().StringVar(&format, "format", "text", "Output format: text|json")
+
+	return cmd
+}
+
+// patternTrendsOutput is the pattern-trends view
+type patternTrendsOutput struct {
+	GroupBy    string                   `json:"group_by"`
+	Periods    []string                 `json:"periods"`
+	IdeaCounts []int                    `json:"idea_counts"` // Ideas captured in each period
+	Patterns   []analytics.PatternTrend `json:"patterns"`
+
+	charset analytics.Charset // Draws the sparklines in the text view
+}
+
+func runPatternTrends(getContext func() *CLIContext, groupBy string, periods, topN int, format string, charset analytics.Charset) error {
+	ctx := getContext()
+	if ctx == nil {
+		return fmt.Errorf("CLI context not initialized")
+	}
+
+	renderer, err := cliutil.NewRenderer(format)
+	if err != nil {
+		return err
+	}
+
+	ideas, err := ctx.Repository.List(database.ListOptions{
+		Status:  "active",
+		Profile: ctx.Profile,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list ideas: %w", err)
+	}
+
+	byPeriod := analytics.PatternTrendsOverTime(ideas, groupBy)
+	if len(byPeriod) > periods {
+		byPeriod = byPeriod[len(byPeriod)-periods:]
+	}
+
+	out := patternTrendsOutput{
+		GroupBy:    groupBy,
+		Periods:    make([]string, len(byPeriod)),
+		IdeaCounts: make([]int, len(byPeriod)),
+		Patterns:   analytics.CalculatePatternTrends(byPeriod, topN),
+		charset:    charset,
+	}
+	for i, period := range byPeriod {
+		out.Periods[i] = period.Period
+		out.IdeaCounts[i] = period.IdeaCount
+	}
+	return renderer.Render(out)
+}
+
+// WriteText implements cliutil.Renderable
+func (o patternTrendsOutput) WriteText(w io.Writer) error {
+	if len(o.Patterns) == 0 {
+		fmt.Fprintln(w, "No patterns detected in your ideas yet.")
+		return nil
+	}
+
+	fmt.Fprintln(w, "📊 Pattern Trends")
+	fmt.Fprintln(w, "═════════════════════════════════════════════")
+	fmt.Fprintln(w)
+	fmt.Fprintf(w, "Grouping: %s (last %d periods)\n\n", o.GroupBy, len(o.Periods))
+
+	// Each period column is as wide as its label, and at least "100%"
+	widths := make([]int, len(o.Periods))
+	header := fmt.Sprintf("%-28s", "Pattern")
+	ideasRow := fmt.Sprintf("%-28s", "Ideas captured")
+	for i, period := range o.Periods {
+		widths[i] = max(len(period), 4)
+		header += fmt.Sprintf(" %*s", widths[i], period)
+		ideasRow += fmt.Sprintf(" %*d", widths[i], o.IdeaCounts[i])
+	}
+	header += "  Trend"
+	fmt.Fprintln(w, header)
+	fmt.Fprintln(w, strings.Repeat("-", len(header)))
+	fmt.Fprintln(w, ideasRow)
+
+	successColor := cliutil.GetScoreColor(10.0)
+	errorColor := cliutil.GetScoreColor(0.0)
+
+	var rising []analytics.PatternTrend
+	for _, trend := range o.Patterns {
+		label := trend.Pattern
+		if trend.Positive {
+			label += " (+)"
+		}
+		line := fmt.Sprintf("%-28s", cliutil.TruncateText(label, 27))
+		for i, share := range trend.Shares {
+			line += fmt.Sprintf(" %*s", widths[i], fmt.Sprintf("%.0f%%", share*100))
+		}
+		line += "  " + o.charset.RenderSparkline(trend.Shares)
+
+		switch trend.Direction {
+		case "up":
+			line += " rising"
+		case "down":
+			line += " declining"
+		default:
+			line += " steady"
+		}
+
+		// Red when things get worse: anti-patterns rising or positive patterns declining
+		switch {
+		case trend.IsRisingAntiPattern():
+			rising = append(rising, trend)
+			if _, err := errorColor.Fprintln(w, line+" ⚠️"); err != nil {
+				log.Warn().Err(err).Msg("failed to print pattern trend")
+			}
+		case trend.Positive && trend.Direction == "down":
+			if _, err := errorColor.Fprintln(w, line); err != nil {
+				log.Warn().Err(err).Msg("failed to print pattern trend")
+			}
+		case trend.Direction != "neutral":
+			if _, err := successColor.Fprintln(w, line); err != nil {
+				log.Warn().Err(err).Msg("failed to print pattern trend")
+			}
+		default:
+			fmt.Fprintln(w, line)
+		}
+	}
+
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Shares are the percent of each period's ideas with the pattern; (+) marks positive patterns.")
+
+	if len(rising) > 0 {
+		fmt.Fprintln(w)
+		warningColor := cliutil.GetScoreColor(5.0)
+		if _, err := warningColor.Fprintln(w, "⚠️  Rising anti-patterns:"); err != nil {
+			log.Warn().Err(err).Msg("failed to print warning message")
+		}
+		for _, trend := range rising {
+			fmt.Fprintf(w, "   %s: %.0f%% → %.0f%% of ideas\n",
+				trend.Pattern, trend.EarlierShare*100, trend.RecentShare*100)
+		}
+	}
+
+	fmt.Fprintln(w, "═════════════════════════════════════════════")
+
+	return nil
+}
@@ -33,7 +33,8 @@ Examples:
 
 			// Fetch all active ideas
 			ideas, err := ctx.Repository.List(database.ListOptions{
-				Status: "active",
+				Status:  "active",
+				Profile: ctx.Profile,
 			})
 			if err != nil {
 				return fmt.Errorf("failed to list ideas: %w", err)
@@ -72,7 +73,7 @@ Examples:
 			}
 
 			// Display bar chart
-			chart := analytics.RenderBarChart(labels, values, 40)
+			chart := chartCharset(cmd).RenderBarChart(labels, values, 40)
 			fmt.Println(chart)
 
 			// Display patterns with percentages
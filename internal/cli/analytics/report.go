@@ -15,6 +15,7 @@ import (
 func NewReportCommand(getContext func() *CLIContext) *cobra.Command {
 	var outputFile string
 	var format string
+	var pdf bool
 
 	cmd := &cobra.Command{
 		Use:   "report",
@@ -31,16 +32,25 @@ The report includes:
 Examples:
   tm analytics report                     # Display report in terminal
   tm analytics report --output report.md  # Save as markdown
-  tm analytics report --format plain      # Plain text format`,
+  tm analytics report --format plain      # Plain text format
+  tm analytics report --format pdf --output report.pdf  # PDF with charts`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx := getContext()
 			if ctx == nil {
 				return fmt.Errorf("CLI context not initialized")
 			}
 
+			if pdf {
+				format = "pdf"
+			}
+			if format == "pdf" && outputFile == "" {
+				return fmt.Errorf("--format pdf requires --output")
+			}
+
 			// Fetch all active ideas
 			ideas, err := ctx.Repository.List(database.ListOptions{
-				Status: "active",
+				Status:  "active",
+				Profile: ctx.Profile,
 			})
 			if err != nil {
 				return fmt.Errorf("failed to list ideas: %w", err)
@@ -49,6 +59,10 @@ Examples:
 			// Generate report
 			report := analytics.GenerateReport(ideas)
 
+			if format == "pdf" {
+				return writeReportPDF(report, outputFile)
+			}
+
 			// Render report based on format
 			var output string
 			switch format {
@@ -80,7 +94,30 @@ Examples:
 	}
 
 	cmd.Flags().StringVar(&outputFile, "output", "", "Output file path (default: stdout)")
-	cmd.Flags().StringVar(&format, "format", "plain", "Output format: plain or markdown")
+	cmd.Flags().StringVar(&format, "format", "plain", "Output format: plain, markdown, or pdf")
+	cmd.Flags().BoolVar(&pdf, "pdf", false, "Shorthand for --format pdf")
 
 	return cmd
 }
+
+// writeReportPDF renders report as a PDF at path
+func writeReportPDF(report analytics.Report, path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create report: %w", err)
+	}
+
+	if err := analytics.RenderReportPDF(report, f); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("failed to write report: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("failed to write report: %w", err)
+	}
+
+	successColor := cliutil.GetScoreColor(10.0)
+	if _, err := successColor.Printf("✅ Report saved to: %s\n", path); err != nil {
+		log.Warn().Err(err).Msg("failed to print success message")
+	}
+	return nil
+}
@@ -14,6 +14,8 @@ import (
 func NewTrendsCommand(getContext func() *CLIContext) *cobra.Command {
 	var days int
 	var groupBy string
+	var forecast int
+	var smooth int
 
 	cmd := &cobra.Command{
 		Use:   "trends",
@@ -27,16 +29,30 @@ Examples:
   tm analytics trends                    # Weekly trends for last 30 days
   tm analytics trends --days 90          # Weekly trends for last 90 days
   tm analytics trends --group-by month   # Monthly trends
-  tm analytics trends --group-by day     # Daily trends`,
+  tm analytics trends --group-by day     # Daily trends
+  tm analytics trends --forecast 4       # Project the next 4 periods
+  tm analytics trends --smooth 3         # Smooth the sparkline over 3 periods
+
+--smooth applies an N-period moving average to the per-period averages
+before drawing the sparkline and judging the trend direction. The averages
+listed for each period and the forecast use the unsmoothed data.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx := getContext()
 			if ctx == nil {
 				return fmt.Errorf("CLI context not initialized")
 			}
 
+			if forecast < 0 {
+				return fmt.Errorf("--forecast must be a positive number of periods")
+			}
+			if smooth < 0 {
+				return fmt.Errorf("--smooth must be a positive number of periods")
+			}
+
 			// Fetch all active ideas
 			ideas, err := ctx.Repository.List(database.ListOptions{
-				Status: "active",
+				Status:  "active",
+				Profile: ctx.Profile,
 			})
 			if err != nil {
 				return fmt.Errorf("failed to list ideas: %w", err)
@@ -64,14 +80,19 @@ Examples:
 			}
 
 			// Display trends
-			fmt.Printf("Grouping: %s\n\n", groupBy)
+			fmt.Printf("Grouping: %s\n", groupBy)
+			if smooth > 1 {
+				fmt.Printf("Smoothing: %d-period moving average\n", smooth)
+			}
+			fmt.Println()
 
 			// Generate sparkline
 			values := make([]float64, len(trends))
 			for i, trend := range trends {
 				values[i] = trend.AvgScore
 			}
-			sparkline := analytics.RenderSparkline(values)
+			values = analytics.MovingAverage(values, smooth)
+			sparkline := chartCharset(cmd).RenderSparkline(values)
 
 			fmt.Printf("Trend: %s\n\n", sparkline)
 
@@ -97,8 +118,13 @@ Examples:
 				)
 			}
 
-			// Show trend direction
-			direction := analytics.CalculateTrendDirection(trends)
+			// Show trend direction, judged on the smoothed averages
+			smoothed := make([]analytics.TrendData, len(trends))
+			for i, trend := range trends {
+				smoothed[i] = trend
+				smoothed[i].AvgScore = values[i]
+			}
+			direction := analytics.CalculateTrendDirection(smoothed)
 			fmt.Println()
 			switch direction {
 			case "up":
@@ -113,6 +139,10 @@ Examples:
 				fmt.Println("➡️  Trend: Your idea quality is stable.")
 			}
 
+			if forecast > 0 {
+				printForecast(trends, forecast)
+			}
+
 			fmt.Println("═════════════════════════════════════════════")
 
 			return nil
@@ -121,6 +151,36 @@ Examples:
 
 	cmd.Flags().IntVar(&days, "days", 30, "Number of days to analyze")
 	cmd.Flags().StringVar(&groupBy, "group-by", "week", "Group by: day, week, or month")
+	cmd.Flags().IntVar(&forecast, "forecast", 0, "Project average scores for the next N periods")
+	cmd.Flags().IntVar(&smooth, "smooth", 0, "Smooth the sparkline and trend direction with an N-period moving average")
 
 	return cmd
 }
+
+// printForecast prints a linear projection of average scores for the next periods
+func printForecast(trends []analytics.TrendData, periods int) {
+	fmt.Println()
+	fmt.Printf("🔮 Forecast (next %d periods)\n", periods)
+
+	points := analytics.ForecastScores(trends, periods)
+	if len(points) == 0 {
+		fmt.Println("  Not enough data to forecast (need at least 2 periods).")
+		return
+	}
+
+	for _, point := range points {
+		fmt.Printf("  +%d: %.1f avg (projected)\n", point.PeriodsAhead, point.Score)
+	}
+
+	stdErr := points[0].StdErr
+	switch {
+	case len(trends) < 3:
+		fmt.Println("  Confidence: low — only 2 periods of history, error can't be estimated.")
+	case stdErr < 0.5:
+		fmt.Printf("  Confidence: high — scores fit the trend closely (±%.1f).\n", stdErr)
+	case stdErr < 1.5:
+		fmt.Printf("  Confidence: moderate — scores vary around the trend (±%.1f).\n", stdErr)
+	default:
+		fmt.Printf("  Confidence: low — scores are noisy (±%.1f); treat as a rough guide.\n", stdErr)
+	}
+}
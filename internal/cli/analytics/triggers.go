@@ -0,0 +1,112 @@
+package analytics
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+	"github.com/ryacub/telos-idea-matrix/internal/analytics"
+	"github.com/ryacub/telos-idea-matrix/internal/cliutil"
+	"github.com/ryacub/telos-idea-matrix/internal/database"
+	"github.com/spf13/cobra"
+)
+
+// NewTriggersCommand creates the analytics triggers subcommand
+func NewTriggersCommand(getContext func() *CLIContext) *cobra.Command {
+	var format string
+
+	cmd := &cobra.Command{
+		Use:   "triggers",
+		Short: "Show average score per idea trigger",
+		Long: `Group ideas by what prompted them and compare their scores.
+
+Triggers are recorded with 'tm add --trigger'. This reveals which
+situations tend to produce your best ideas.
+
+Examples:
+  tm analytics triggers                 # Show ranked table
+  tm analytics triggers --format json   # Output as JSON
+  tm analytics triggers --format csv    # Output as CSV`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runTriggers(getContext, format)
+		},
+	}
+
+	cmd.Flags().StringVar(&format, "format", "text", "Output format: text|json|csv")
+
+	return cmd
+}
+
+func runTriggers(getContext func() *CLIContext, format string) error {
+	ctx := getContext()
+	if ctx == nil {
+		return fmt.Errorf("CLI context not initialized")
+	}
+
+	renderer, err := cliutil.NewRenderer(format)
+	if err != nil {
+		return err
+	}
+
+	ideas, err := ctx.Repository.List(database.ListOptions{
+		Status:  "active",
+		Profile: ctx.Profile,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list ideas: %w", err)
+	}
+
+	stats := analytics.CalculateTriggerStats(ideas)
+
+	return renderer.Render(triggerStatsOutput(stats))
+}
+
+// triggerStatsOutput renders trigger stats as a ranked table
+type triggerStatsOutput []analytics.TriggerStat
+
+// WriteText implements cliutil.Renderable
+func (o triggerStatsOutput) WriteText(w io.Writer) error {
+	stats := []analytics.TriggerStat(o)
+
+	if len(stats) == 0 {
+		warningColor := cliutil.GetScoreColor(5.0)
+		if _, err := warningColor.Fprintln(w, "No ideas found. Use 'tm add --trigger' to record what prompts your ideas."); err != nil {
+			log.Warn().Err(err).Msg("failed to print warning message")
+		}
+		return nil
+	}
+
+	fmt.Fprintln(w, "💡 Idea Triggers")
+	fmt.Fprintln(w, "═════════════════════════════════════════════")
+	fmt.Fprintln(w)
+	fmt.Fprintf(w, "%-30s %6s %8s %8s\n", "Trigger", "Ideas", "Avg", "Best")
+	fmt.Fprintln(w, strings.Repeat("-", 56))
+
+	for _, stat := range stats {
+		scoreColor := cliutil.GetScoreColor(stat.AvgScore)
+		if _, err := scoreColor.Fprintf(w, "%-30s %6d %8.1f %8.1f\n",
+			cliutil.TruncateText(stat.Trigger, 27), stat.IdeaCount, stat.AvgScore, stat.HighScore); err != nil {
+			log.Warn().Err(err).Msg("failed to print trigger row")
+		}
+	}
+
+	fmt.Fprintln(w, "═════════════════════════════════════════════")
+
+	return nil
+}
+
+// CSVRows implements cliutil.CSVRenderable
+func (o triggerStatsOutput) CSVRows() [][]string {
+	rows := [][]string{{"Trigger", "Ideas", "Avg Score", "High Score"}}
+	for _, stat := range o {
+		rows = append(rows, []string{
+			stat.Trigger,
+			strconv.Itoa(stat.IdeaCount),
+			fmt.Sprintf("%.2f", stat.AvgScore),
+			fmt.Sprintf("%.2f", stat.HighScore),
+		})
+	}
+	return rows
+}
@@ -0,0 +1,119 @@
+package analytics
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/ryacub/telos-idea-matrix/internal/analytics"
+	"github.com/ryacub/telos-idea-matrix/internal/cliutil"
+	"github.com/ryacub/telos-idea-matrix/internal/database"
+	"github.com/spf13/cobra"
+)
+
+// NewVelocityCommand creates the analytics velocity subcommand
+func NewVelocityCommand(getContext func() *CLIContext) *cobra.Command {
+	var format string
+
+	cmd := &cobra.Command{
+		Use:   "velocity",
+		Short: "Show how often and when you capture ideas",
+		Long: `Show your ideation rhythm: ideas captured per day, week and month,
+your longest and current streaks of consecutive days with a capture, and
+the day of the week and hour of the day you capture the most ideas.
+
+Captures are counted in local time and include archived ideas, since
+archiving doesn't change when an idea was captured.
+
+Examples:
+  tm analytics velocity                 # Show capture cadence
+  tm analytics velocity --format json   # Output as JSON`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runVelocity(getContext, format, chartCharset(cmd))
+		},
+	}
+
+	cmd.Flags().StringVar(&format, "format", "text", "Output format: text|json")
+
+	return cmd
+}
+
+func runVelocity(getContext func() *CLIContext, format string, charset analytics.Charset) error {
+	ctx := getContext()
+	if ctx == nil {
+		return fmt.Errorf("CLI context not initialized")
+	}
+
+	renderer, err := cliutil.NewRenderer(format)
+	if err != nil {
+		return err
+	}
+
+	ideas, err := ctx.Repository.List(database.ListOptions{Profile: ctx.Profile})
+	if err != nil {
+		return fmt.Errorf("failed to list ideas: %w", err)
+	}
+
+	report := analytics.CalculateVelocity(ideas)
+
+	return renderer.Render(velocityOutput{VelocityReport: report, charset: charset})
+}
+
+// velocityOutput renders a velocity report; JSON is the report alone
+type velocityOutput struct {
+	analytics.VelocityReport
+	charset analytics.Charset
+}
+
+// WriteText implements cliutil.Renderable
+func (o velocityOutput) WriteText(w io.Writer) error {
+	report, charset := o.VelocityReport, o.charset
+
+	if report.Ideas == 0 {
+		warningColor := cliutil.GetScoreColor(5.0)
+		if _, err := warningColor.Fprintln(w, "No ideas found. Use 'tm dump' to capture your first idea!"); err != nil {
+			log.Warn().Err(err).Msg("failed to print warning message")
+		}
+		return nil
+	}
+
+	fmt.Fprintln(w, "🚀 Capture Velocity")
+	fmt.Fprintln(w, "═════════════════════════════════════════════")
+	fmt.Fprintln(w)
+	fmt.Fprintf(w, "Ideas:          %d over %s (%d active)\n", report.Ideas, pluralDays(report.SpanDays), report.ActiveDays)
+	fmt.Fprintf(w, "Per day:        %.2f\n", report.PerDay)
+	fmt.Fprintf(w, "Per week:       %.1f\n", report.PerWeek)
+	fmt.Fprintf(w, "Per month:      %.1f\n", report.PerMonth)
+	fmt.Fprintln(w)
+	fmt.Fprintf(w, "Longest streak: %s\n", pluralDays(report.LongestStreak))
+	fmt.Fprintf(w, "Current streak: %s\n", pluralDays(report.CurrentStreak))
+	fmt.Fprintln(w)
+	fmt.Fprintf(w, "Busiest day:    %s\n", report.BusiestWeekday)
+	fmt.Fprintf(w, "Busiest hour:   %02d:00-%02d:00\n", report.BusiestHour, (report.BusiestHour+1)%24)
+	fmt.Fprintln(w)
+
+	// Monday first, the way most people think of a week
+	fmt.Fprintln(w, "Ideas by day of week:")
+	labels := make([]string, 0, 7)
+	values := make([]float64, 0, 7)
+	for i := 1; i <= 7; i++ {
+		day := time.Weekday(i % 7)
+		labels = append(labels, day.String()[:3])
+		values = append(values, float64(report.ByWeekday[day]))
+	}
+	fmt.Fprint(w, charset.RenderBarChart(labels, values, 30))
+
+	fmt.Fprintln(w, "═════════════════════════════════════════════")
+
+	return nil
+}
+
+// pluralDays formats a count of days
+func pluralDays(n int) string {
+	if n == 1 {
+		return "1 day"
+	}
+	return fmt.Sprintf("%d days", n)
+}
@@ -0,0 +1,167 @@
+package analytics
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/ryacub/telos-idea-matrix/internal/analytics"
+	"github.com/ryacub/telos-idea-matrix/internal/database"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+// ANSI sequences used to redraw the dashboard in place
+const (
+	clearScreen = "\033[H\033[2J"
+	hideCursor  = "\033[?25l"
+	showCursor  = "\033[?25h"
+)
+
+// resizePollInterval is how often the terminal size is checked between refreshes
+const resizePollInterval = 250 * time.Millisecond
+
+type watchOptions struct {
+	interval time.Duration
+	charset  analytics.Charset
+}
+
+// NewWatchCommand creates the analytics watch subcommand
+func NewWatchCommand(getContext func() *CLIContext) *cobra.Command {
+	var interval time.Duration
+
+	cmd := &cobra.Command{
+		Use:   "watch",
+		Short: "Show live idea metrics that refresh in place",
+		Long: `Display a continuously updating view of your idea metrics.
+
+Shows total ideas, ideas captured today, the average score, and the
+score distribution, using the same calculations as 'tm analytics metrics'.
+Press Ctrl+C to exit.
+
+Examples:
+  # Refresh every 5 seconds
+  tm analytics watch
+
+  # Refresh every 30 seconds
+  tm analytics watch --interval 30s`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if interval < time.Second {
+				return fmt.Errorf("--interval must be at least 1s, got %s", interval)
+			}
+
+			done, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+
+			return runWatch(done, getContext, watchOptions{
+				interval: interval,
+				charset:  chartCharset(cmd),
+			})
+		},
+	}
+
+	cmd.Flags().DurationVar(&interval, "interval", 5*time.Second, "Time between refreshes")
+
+	return cmd
+}
+
+func runWatch(done context.Context, getContext func() *CLIContext, opts watchOptions) error {
+	ctx := getContext()
+	if ctx == nil {
+		return fmt.Errorf("CLI context not initialized")
+	}
+
+	service := analytics.NewServiceWithDB(ctx.Repository, ctx.DBPath)
+	width := terminalWidth()
+
+	draw := func() error {
+		ideas, err := ctx.Repository.List(database.ListOptions{Profile: ctx.Profile})
+		if err != nil {
+			return fmt.Errorf("failed to fetch ideas: %w", err)
+		}
+		metrics := service.CalculateSystemMetrics(ideas)
+
+		// Render off-screen first so the redraw doesn't flicker
+		var frame bytes.Buffer
+		frame.WriteString(clearScreen)
+		renderWatchFrame(&frame, metrics, opts, width, time.Now())
+		_, err = os.Stdout.Write(frame.Bytes())
+		return err
+	}
+
+	fmt.Print(hideCursor)
+	defer fmt.Print(showCursor)
+
+	if err := draw(); err != nil {
+		return err
+	}
+
+	refresh := time.NewTicker(opts.interval)
+	defer refresh.Stop()
+	resize := time.NewTicker(resizePollInterval)
+	defer resize.Stop()
+
+	for {
+		select {
+		case <-done.Done():
+			fmt.Println()
+			return nil
+		case <-refresh.C:
+			if err := draw(); err != nil {
+				return err
+			}
+		case <-resize.C:
+			if w := terminalWidth(); w != width {
+				width = w
+				if err := draw(); err != nil {
+					return err
+				}
+			}
+		}
+	}
+}
+
+// renderWatchFrame writes one dashboard frame sized to fit width columns
+func renderWatchFrame(w io.Writer, metrics analytics.SystemMetrics, opts watchOptions, width int, now time.Time) {
+	ruleWidth := min(width, 80)
+	// Leave room for the bucket label and counts in front of the bar
+	barWidth := max(min(width-26, 50), 10)
+
+	fmt.Fprintln(w, "Idea Metrics (live)")
+	fmt.Fprintln(w, strings.Repeat("=", ruleWidth))
+	fmt.Fprintf(w, "  Total Ideas:      %d\n", metrics.Overview.TotalIdeas)
+	fmt.Fprintf(w, "  Today:            %d\n", metrics.TimeMetrics.IdeasToday)
+	fmt.Fprintf(w, "  Average Score:    %.2f\n", metrics.Overview.AverageScore)
+	fmt.Fprintln(w)
+
+	fmt.Fprintln(w, "Score Distribution:")
+	fmt.Fprintln(w, strings.Repeat("-", ruleWidth))
+	total := metrics.Overview.TotalIdeas
+	for _, bucket := range []string{"0-2", "2-4", "4-6", "6-8", "8-10"} {
+		count := metrics.ScoreDistribution.Buckets[bucket]
+		pct := 0.0
+		if total > 0 {
+			pct = float64(count) / float64(total) * 100
+		}
+		bar := strings.Repeat(opts.charset.Bar, int(pct/100*float64(barWidth)))
+		fmt.Fprintf(w, "  %5s: %5d (%5.1f%%) %s\n", bucket, count, pct, bar)
+	}
+	fmt.Fprintln(w)
+
+	fmt.Fprintf(w, "Updated %s, refreshing every %s. Press Ctrl+C to exit.\n", now.Format("15:04:05"), opts.interval)
+}
+
+// terminalWidth returns the width of stdout, or 80 when it isn't a terminal
+func terminalWidth() int {
+	width, _, err := term.GetSize(int(os.Stdout.Fd()))
+	if err != nil || width <= 0 {
+		return 80
+	}
+	return width
+}
@@ -0,0 +1,34 @@
+//go:build integration
+
+package cli
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPatternTrendsCommand_GroupBy(t *testing.T) {
+	cliCtx, cleanup := setupTestCLI(t)
+	defer cleanup()
+
+	cmd := GetRootCmd()
+	cmd.SetArgs([]string{
+		"--telos", cliCtx.TelosPath,
+		"--db", cliCtx.DBPath,
+		"analytics", "pattern-trends",
+		"--group-by", "month",
+	})
+	require.NoError(t, cmd.Execute())
+
+	cmd.SetArgs([]string{
+		"--telos", cliCtx.TelosPath,
+		"--db", cliCtx.DBPath,
+		"analytics", "pattern-trends",
+		"--group-by", "year",
+	})
+	err := cmd.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `invalid --group-by "year": must be day, week or month`)
+}
@@ -0,0 +1,196 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/ryacub/telos-idea-matrix/internal/cliutil"
+	"github.com/ryacub/telos-idea-matrix/internal/config"
+	"github.com/ryacub/telos-idea-matrix/internal/llm"
+	"github.com/spf13/cobra"
+)
+
+func newAnalyzeCommand() *cobra.Command {
+	var (
+		compare    bool
+		provider   string
+		timeout    time.Duration
+		jsonOutput bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "analyze <idea>",
+		Short: "Score an idea with AI without saving it",
+		Long: `Analyze an idea with the LLM providers and show the result. Nothing is saved;
+use 'tm add --ai' to keep the idea.
+
+With --compare-providers the idea is scored by every provider at once,
+including rule_based, and each one's score, recommendation and latency are
+shown side by side. Use it to see how far to trust each backend before
+choosing a default with 'tm llm set-default'.
+
+Examples:
+  tm analyze "Build a habit tracker"
+  tm analyze "Build a habit tracker" --provider ollama
+  tm analyze "Build a habit tracker" --compare-providers
+  tm analyze "Build a habit tracker" --compare-providers --json`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if !cmd.Flags().Changed("timeout") {
+				timeout = config.LoadLLMConfig().AnalysisTimeout
+			}
+			if timeout < 0 {
+				return fmt.Errorf("--timeout must not be negative")
+			}
+			if compare && provider != "" {
+				return fmt.Errorf("--provider and --compare-providers cannot be used together")
+			}
+
+			analyzeCtx := cmd.Context()
+			if analyzeCtx == nil {
+				analyzeCtx = context.Background()
+			}
+			if timeout > 0 {
+				var cancel context.CancelFunc
+				analyzeCtx, cancel = context.WithTimeout(analyzeCtx, timeout)
+				defer cancel()
+			}
+
+			ideaText := strings.Join(args, " ")
+			if compare {
+				return runAnalyzeCompare(analyzeCtx, cmd.OutOrStdout(), ideaText, jsonOutput)
+			}
+			return runAnalyze(analyzeCtx, ideaText, provider, jsonOutput)
+		},
+	}
+
+	cmd.Flags().BoolVar(&compare, "compare-providers", false, "Score the idea with every provider and compare the results")
+	cmd.Flags().StringVarP(&provider, "provider", "p", "", "LLM provider to use (default: the configured default)")
+	cmd.Flags().DurationVar(&timeout, "timeout", 0, "Deadline for the analysis, e.g. 30s; 0 waits indefinitely (default from llm.analysis_timeout)")
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output as JSON")
+	_ = cmd.RegisterFlagCompletionFunc("provider", completeProviders)
+
+	return cmd
+}
+
+// providerAnalysis is one provider's result in analyze output. A provider
+// that didn't answer has Error set instead of a score.
+type providerAnalysis struct {
+	Provider       string   `json:"provider"`
+	Score          *float64 `json:"score,omitempty"`
+	Recommendation string   `json:"recommendation,omitempty"`
+	LatencyMS      *int64   `json:"latency_ms,omitempty"`
+	Error          string   `json:"error,omitempty"`
+}
+
+func newProviderAnalysis(result *llm.AnalysisResult) providerAnalysis {
+	score := result.FinalScore
+	latency := result.Duration.Milliseconds()
+	return providerAnalysis{
+		Provider:       result.Provider,
+		Score:          &score,
+		Recommendation: result.Recommendation,
+		LatencyMS:      &latency,
+	}
+}
+
+func runAnalyze(analyzeCtx context.Context, ideaText, provider string, jsonOutput bool) error {
+	if provider != "" {
+		if err := ctx.LLMManager.SetPrimaryProvider(provider); err != nil {
+			return fmt.Errorf("failed to set provider: %w", err)
+		}
+	}
+
+	start := time.Now()
+	result, err := ctx.LLMManager.AnalyzeWithTelosContext(analyzeCtx, ideaText, ctx.Telos)
+	if err != nil {
+		return fmt.Errorf("analysis failed: %w", err)
+	}
+	// Time the whole analysis, including any providers tried before it
+	timed := *result
+	timed.Duration = time.Since(start)
+	analysis := newProviderAnalysis(&timed)
+
+	if jsonOutput {
+		output, err := json.MarshalIndent(analysis, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(output))
+		return nil
+	}
+
+	fmt.Println(ideaText)
+	_, _ = cliutil.GetScoreColor(*analysis.Score).Printf("%.1f/10", *analysis.Score)
+	fmt.Printf(" %s\n", analysis.Recommendation)
+	_, _ = cliutil.InfoColor.Printf("Analyzed by %s in %s (not saved)\n", analysis.Provider, formatLatency(*analysis.LatencyMS))
+	return nil
+}
+
+func runAnalyzeCompare(analyzeCtx context.Context, out io.Writer, ideaText string, jsonOutput bool) error {
+	results, errs := ctx.LLMManager.AnalyzeAllContext(analyzeCtx, llm.AnalysisRequest{
+		IdeaContent: ideaText,
+		Telos:       ctx.Telos,
+	})
+	if err := analyzeCtx.Err(); err != nil && len(results) == 0 {
+		return fmt.Errorf("analysis failed: %w", err)
+	}
+
+	// Providers are listed in priority order
+	var rows []providerAnalysis
+	for _, name := range ctx.LLMManager.ProviderNames() {
+		if result, ok := results[name]; ok {
+			rows = append(rows, newProviderAnalysis(&result))
+			continue
+		}
+		row := providerAnalysis{Provider: name, Error: "no result"}
+		if err, ok := errs[name]; ok {
+			row.Error = err.Error()
+		}
+		rows = append(rows, row)
+	}
+
+	if jsonOutput {
+		output, err := json.MarshalIndent(rows, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(out, string(output))
+		return nil
+	}
+
+	fmt.Fprintln(out, ideaText)
+	fmt.Fprintln(out, strings.Repeat("─", 72))
+	fmt.Fprintf(out, "%-12s %6s  %-34s %9s\n", "Provider", "Score", "Recommendation", "Latency")
+	for _, row := range rows {
+		if row.Error != "" {
+			if errors.Is(errs[row.Provider], llm.ErrUnavailable) {
+				_, _ = cliutil.InfoColor.Fprintf(out, "%-12s %6s  %s\n", row.Provider, "-", "unavailable")
+			} else {
+				_, _ = cliutil.ErrorColor.Fprintf(out, "%-12s %6s  %s\n", row.Provider, "-", cliutil.TruncateText("failed: "+row.Error, 44))
+			}
+			continue
+		}
+		fmt.Fprintf(out, "%-12s ", row.Provider)
+		_, _ = cliutil.GetScoreColor(*row.Score).Fprintf(out, "%6.1f", *row.Score)
+		fmt.Fprintf(out, "  %-34s %9s\n", cliutil.TruncateText(row.Recommendation, 34), formatLatency(*row.LatencyMS))
+	}
+
+	if len(results) == 0 {
+		return fmt.Errorf("no provider could analyze the idea")
+	}
+	return nil
+}
+
+// formatLatency shows a latency in milliseconds, or seconds once it's long
+func formatLatency(ms int64) string {
+	if ms < 1000 {
+		return fmt.Sprintf("%dms", ms)
+	}
+	return fmt.Sprintf("%.1fs", float64(ms)/1000)
+}
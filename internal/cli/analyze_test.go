@@ -0,0 +1,87 @@
+//go:build integration
+
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/ryacub/telos-idea-matrix/internal/database"
+	"github.com/ryacub/telos-idea-matrix/internal/llm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// failingProvider is available but fails every analysis
+type failingProvider struct{}
+
+func (failingProvider) Name() string      { return "failing" }
+func (failingProvider) IsAvailable() bool { return true }
+
+func (p failingProvider) Analyze(req llm.AnalysisRequest) (*llm.AnalysisResult, error) {
+	return p.AnalyzeContext(context.Background(), req)
+}
+
+func (failingProvider) AnalyzeContext(context.Context, llm.AnalysisRequest) (*llm.AnalysisResult, error) {
+	return nil, errors.New("invalid API key")
+}
+
+func TestAnalyzeCommand_DoesNotSave(t *testing.T) {
+	cliCtx, cleanup := setupTestCLI(t)
+	defer cleanup()
+
+	provider := &fixedProvider{}
+	manager := llm.NewManager(&llm.ManagerConfig{FallbackEnabled: true})
+	manager.RegisterProvider(provider)
+	cliCtx.LLMManager = manager
+	SetContext(cliCtx)
+
+	require.NoError(t, runAnalyze(context.Background(), "Build a SaaS product using Go", provider.Name(), false))
+	assert.Equal(t, 1, provider.calls)
+
+	ideas, err := cliCtx.Repository.List(database.ListOptions{})
+	require.NoError(t, err)
+	assert.Empty(t, ideas)
+}
+
+func TestAnalyzeCommand_CompareProviders(t *testing.T) {
+	// Only the test providers and rule_based take part
+	for _, env := range []string{"ANTHROPIC_API_KEY", "OPENAI_API_KEY", "GROQ_API_KEY", "CUSTOM_LLM_ENDPOINT"} {
+		t.Setenv(env, "")
+	}
+	cliCtx, cleanup := setupTestCLI(t)
+	defer cleanup()
+
+	provider := &fixedProvider{}
+	manager := llm.NewManager(&llm.ManagerConfig{FallbackEnabled: true})
+	manager.RegisterProvider(provider)
+	manager.RegisterProvider(failingProvider{})
+	cliCtx.LLMManager = manager
+	SetContext(cliCtx)
+
+	// One failure doesn't spoil the comparison
+	var out bytes.Buffer
+	require.NoError(t, runAnalyzeCompare(context.Background(), &out, "Build a SaaS product using Go", true))
+	assert.Equal(t, 1, provider.calls)
+
+	var rows []providerAnalysis
+	require.NoError(t, json.Unmarshal(out.Bytes(), &rows))
+	byProvider := make(map[string]providerAnalysis, len(rows))
+	for _, row := range rows {
+		byProvider[row.Provider] = row
+	}
+
+	require.Contains(t, byProvider, "failing")
+	assert.Equal(t, "invalid API key", byProvider["failing"].Error)
+	assert.Nil(t, byProvider["failing"].Score)
+
+	require.Contains(t, byProvider, "rule_based")
+	assert.Empty(t, byProvider["rule_based"].Error)
+	assert.NotNil(t, byProvider["rule_based"].Score)
+
+	require.Contains(t, byProvider, provider.Name())
+	assert.NotNil(t, byProvider[provider.Name()].Score)
+}
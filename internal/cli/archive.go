@@ -0,0 +1,60 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/ryacub/telos-idea-matrix/internal/cliutil"
+	"github.com/ryacub/telos-idea-matrix/internal/models"
+	"github.com/spf13/cobra"
+)
+
+func newArchiveCommand() *cobra.Command {
+	var reason string
+
+	cmd := &cobra.Command{
+		Use:   "archive <id>",
+		Short: "Archive an idea",
+		Long: `Archive an idea, optionally recording why.
+
+The reason is shown by 'tm show' and 'tm list --status archived'.
+
+Examples:
+  tm archive abc123
+  tm archive abc123 --reason "duplicate of def456"`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeIdeaIDs(1, ""),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runArchive(args[0], reason)
+		},
+	}
+
+	cmd.Flags().StringVar(&reason, "reason", "", "Why the idea is being archived")
+
+	return cmd
+}
+
+func runArchive(ideaID, reason string) error {
+	idea, err := ctx.Repository.GetByID(ideaID)
+	if err != nil {
+		idea, err = ctx.Repository.GetByPartialID(ideaID)
+		if err != nil {
+			return fmt.Errorf("idea not found: %s", ideaID)
+		}
+	}
+
+	if idea.Status == string(models.StatusArchived) && reason == "" {
+		_, _ = cliutil.InfoColor.Printf("Idea %s is already archived\n", idea.ID[:8])
+		return nil
+	}
+
+	idea.Archive(reason)
+	if err := ctx.Repository.Update(idea); err != nil {
+		return fmt.Errorf("failed to archive: %w", err)
+	}
+
+	_, _ = cliutil.SuccessColor.Printf("✓ Archived %s: %s\n", idea.ID[:8], cliutil.TruncateText(idea.Content, 50))
+	if idea.ArchiveReason != "" {
+		fmt.Printf("  Reason: %s\n", idea.ArchiveReason)
+	}
+	return nil
+}
@@ -0,0 +1,112 @@
+package cli
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ryacub/telos-idea-matrix/internal/cliutil"
+	"github.com/ryacub/telos-idea-matrix/internal/database"
+	"github.com/spf13/cobra"
+)
+
+func newBackupCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "backup <path>",
+		Short: "Back up the ideas database",
+		Long: `Write a consistent copy of the ideas database to a new file.
+
+The copy includes recent writes that haven't been checkpointed yet, so it is
+safe to run while the web server is using the database. The backup is a
+single standalone SQLite file.
+
+Examples:
+  tm backup ~/backups/ideas-2024-06-01.db`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runBackup(args[0])
+		},
+	}
+}
+
+func newRestoreCommand() *cobra.Command {
+	var yes bool
+
+	cmd := &cobra.Command{
+		Use:   "restore <path>",
+		Short: "Restore the ideas database from a backup",
+		Long: `Replace the ideas database with the contents of a backup made by 'tm backup'.
+
+The backup is checked before anything changes. The current database is
+saved next to it as a safety copy before the restore.
+
+Examples:
+  tm restore ~/backups/ideas-2024-06-01.db
+  tm restore ~/backups/ideas-2024-06-01.db --yes`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runRestore(args[0], yes)
+		},
+	}
+
+	cmd.Flags().BoolVar(&yes, "yes", false, "Auto-confirm (skip confirmation prompt)")
+
+	return cmd
+}
+
+func runBackup(path string) error {
+	if err := ctx.Repository.BackupTo(path); err != nil {
+		return err
+	}
+
+	info, err := database.ValidateBackup(path)
+	if err != nil {
+		return fmt.Errorf("backup written but failed verification: %w", err)
+	}
+
+	_, _ = cliutil.SuccessColor.Printf("✓ Backed up %d ideas to %s (%s)\n", info.IdeaCount, path, formatBytes(info.SizeBytes))
+	return nil
+}
+
+func runRestore(path string, yes bool) error {
+	info, err := database.ValidateBackup(path)
+	if err != nil {
+		return err
+	}
+
+	current, err := ctx.Repository.List(database.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to list ideas: %w", err)
+	}
+
+	fmt.Printf("Backup:   %s (%d ideas, %s)\n", path, info.IdeaCount, formatBytes(info.SizeBytes))
+	fmt.Printf("Database: %s (%d ideas)\n", ctx.DBPath, len(current))
+	fmt.Println()
+	_, _ = cliutil.WarningColor.Println("⚠️  Everything in the database will be replaced by the backup.")
+
+	if !yes && !cliutil.Confirm("Restore this backup?") {
+		_, _ = cliutil.WarningColor.Println("❌ Restore cancelled.")
+		return nil
+	}
+
+	safetyPath := fmt.Sprintf("%s.pre-restore-%s", ctx.DBPath, time.Now().Format("20060102-150405"))
+	if err := ctx.Repository.BackupTo(safetyPath); err != nil {
+		return fmt.Errorf("failed to save current database before restoring: %w", err)
+	}
+
+	if err := ctx.Repository.RestoreFrom(path); err != nil {
+		return err
+	}
+
+	_, _ = cliutil.SuccessColor.Printf("✓ Restored %d ideas from %s\n", info.IdeaCount, path)
+	fmt.Printf("  Previous database saved to %s\n", safetyPath)
+	return nil
+}
+
+// formatBytes renders a byte count in KB or MB
+func formatBytes(n int64) string {
+	const kb = 1024
+	if n < kb*kb {
+		return fmt.Sprintf("%.1f KB", float64(n)/kb)
+	}
+	return fmt.Sprintf("%.1f MB", float64(n)/(kb*kb))
+}
@@ -1,15 +1,24 @@
 package bulk
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"math"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
 	"time"
 
 	"github.com/fatih/color"
 	"github.com/rs/zerolog/log"
 	"github.com/ryacub/telos-idea-matrix/internal/cliutil"
 	"github.com/ryacub/telos-idea-matrix/internal/database"
+	"github.com/ryacub/telos-idea-matrix/internal/llm"
+	"github.com/ryacub/telos-idea-matrix/internal/models"
 	"github.com/ryacub/telos-idea-matrix/internal/patterns"
+	"github.com/ryacub/telos-idea-matrix/internal/scoring"
 	"github.com/spf13/cobra"
 )
 
@@ -23,6 +32,8 @@ func NewAnalyzeCommand(getContext func() *CLIContext) *cobra.Command {
 		dryRun    bool
 		provider  string
 		yes       bool
+		minDelta  float64
+		resume    string
 	)
 
 	cmd := &cobra.Command{
@@ -46,10 +57,25 @@ Examples:
   # Re-analyze with specific provider
   telos bulk analyze --provider ollama
 
+  # Only save re-analyses that move the score by at least 0.5
+  telos bulk analyze --min-delta 0.5
+
   # Dry-run to see what would be analyzed
-  telos bulk analyze --score-max 5.0 --dry-run`,
+  telos bulk analyze --score-max 5.0 --dry-run
+
+  # Continue an interrupted run, skipping ideas it already finished
+  telos bulk analyze --resume <job-id>
+
+Each run is recorded as a job, and every idea is marked done as soon as it
+has been saved. If a run is interrupted or some ideas fail, --resume picks up
+the remaining ideas with the job's original settings; --provider may be used
+to switch providers.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runBulkAnalyze(getContext, bulkAnalyzeOptions{
+			// Ctrl+C abandons the idea in flight so the job can be resumed
+			done, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+
+			return runBulkAnalyze(done, getContext, bulkAnalyzeOptions{
 				scoreMin:  scoreMin,
 				scoreMax:  scoreMax,
 				status:    status,
@@ -57,6 +83,8 @@ Examples:
 				dryRun:    dryRun,
 				provider:  provider,
 				yes:       yes,
+				minDelta:  minDelta,
+				resume:    resume,
 			})
 		},
 	}
@@ -67,7 +95,12 @@ Examples:
 	cmd.Flags().StringVar(&olderThan, "older-than", "", "Re-analyze ideas older than duration (e.g., 30d, 6h)")
 	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show what would be analyzed without making changes")
 	cmd.Flags().StringVar(&provider, "provider", "", "LLM provider to use (ollama|claude|openai|rule_based)")
+	_ = cmd.RegisterFlagCompletionFunc("provider", func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+		return createLLMManager().ProviderNames(), cobra.ShellCompDirectiveNoFileComp
+	})
 	cmd.Flags().BoolVar(&yes, "yes", false, "Auto-confirm (skip confirmation prompt)")
+	cmd.Flags().Float64Var(&minDelta, "min-delta", 0, "Only save re-analyses whose score changes by at least this amount")
+	cmd.Flags().StringVar(&resume, "resume", "", "Resume an interrupted job, skipping ideas it already finished")
 
 	return cmd
 }
@@ -81,25 +114,50 @@ type bulkAnalyzeOptions struct {
 	dryRun    bool
 	provider  string
 	yes       bool
+	minDelta  float64
+	resume    string // Job ID to resume; filters are ignored when set
+}
+
+// analyzeJobParams are stored with an analyze job so a resumed run uses the
+// same settings
+type analyzeJobParams struct {
+	Provider string  `json:"provider,omitempty"`
+	MinDelta float64 `json:"min_delta,omitempty"`
+}
+
+// exceedsMinDelta reports whether a re-analyzed score differs enough from the
+// stored score to be persisted. A minDelta of 0 persists every re-analysis.
+func exceedsMinDelta(oldScore, newScore, minDelta float64) bool {
+	if minDelta <= 0 {
+		return true
+	}
+	return math.Abs(newScore-oldScore) >= minDelta
 }
 
-// runBulkAnalyze performs bulk re-analysis of ideas
-func runBulkAnalyze(getContext func() *CLIContext, opts bulkAnalyzeOptions) error {
+// runBulkAnalyze performs bulk re-analysis of ideas, stopping as soon as done
+// is canceled
+func runBulkAnalyze(done context.Context, getContext func() *CLIContext, opts bulkAnalyzeOptions) error {
 	ctx := getContext()
 	if ctx == nil {
 		return fmt.Errorf("CLI context not initialized")
 	}
 
-	// Create service once
+	if opts.minDelta < 0 {
+		return fmt.Errorf("--min-delta must not be negative")
+	}
+
+	if opts.resume != "" {
+		return resumeBulkAnalyze(done, ctx, opts)
+	}
 
 	// Parse olderThan duration if specified
-	var cutoffTime time.Time
+	var createdBefore *time.Time
 	if opts.olderThan != "" {
 		duration, err := parseDuration(opts.olderThan)
 		if err != nil {
 			return fmt.Errorf("invalid duration: %w", err)
 		}
-		cutoffTime = time.Now().UTC().Add(-duration)
+		createdBefore = cutoffBefore(duration)
 	}
 
 	// Build filter criteria
@@ -108,21 +166,17 @@ func runBulkAnalyze(getContext func() *CLIContext, opts bulkAnalyzeOptions) erro
 	limit := 1000 // Safety limit
 
 	ideas, err := ctx.Repository.List(database.ListOptions{
-		Status:   opts.status,
-		MinScore: minScorePtr,
-		MaxScore: maxScorePtr,
-		Limit:    &limit,
-		OrderBy:  "created_at ASC",
+		Status:        opts.status,
+		MinScore:      minScorePtr,
+		MaxScore:      maxScorePtr,
+		CreatedBefore: createdBefore,
+		Limit:         &limit,
+		OrderBy:       database.OrderBy(database.SortByCreatedAt, database.Ascending),
 	})
 	if err != nil {
 		return fmt.Errorf("failed to find ideas: %w", err)
 	}
 
-	// Filter by age if specified
-	if !cutoffTime.IsZero() {
-		ideas = filterByAge(ideas, cutoffTime)
-	}
-
 	if len(ideas) == 0 {
 		fmt.Println("📭 No ideas match the criteria.")
 		return nil
@@ -138,6 +192,9 @@ func runBulkAnalyze(getContext func() *CLIContext, opts bulkAnalyzeOptions) erro
 	if opts.olderThan != "" {
 		fmt.Printf("  Older than: %s\n", opts.olderThan)
 	}
+	if opts.minDelta > 0 {
+		fmt.Printf("  Minimum score change: %.2f\n", opts.minDelta)
+	}
 	fmt.Println()
 
 	if opts.dryRun {
@@ -164,18 +221,103 @@ func runBulkAnalyze(getContext func() *CLIContext, opts bulkAnalyzeOptions) erro
 		return nil
 	}
 
-	// Create LLM manager
+	params := analyzeJobParams{Provider: opts.provider, MinDelta: opts.minDelta}
+	llmManager, err := analyzeLLMManager(ctx, params.Provider)
+	if err != nil {
+		return err
+	}
+
+	job, err := startAnalyzeJob(ctx.Repository, ideas, params)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("📋 Job %s\n\n", job.ID)
+
+	return runAnalyzeJob(done, ctx, llmManager, job.ID, ideas, params.MinDelta)
+}
+
+// resumeBulkAnalyze continues an analyze job with the ideas it hasn't finished
+func resumeBulkAnalyze(done context.Context, ctx *CLIContext, opts bulkAnalyzeOptions) error {
+	job, err := ctx.Repository.GetBulkJob(opts.resume)
+	if err != nil {
+		if database.IsNotFound(err) {
+			return fmt.Errorf("bulk job %s not found", opts.resume)
+		}
+		return err
+	}
+	if job.Kind != models.BulkJobAnalyze {
+		return fmt.Errorf("bulk job %s is a %s job, not analyze", job.ID, job.Kind)
+	}
+	if job.IsComplete() {
+		fmt.Printf("✅ Job %s already finished all %d ideas.\n", job.ID, job.Total)
+		return nil
+	}
+
+	var params analyzeJobParams
+	if err := json.Unmarshal([]byte(job.Params), &params); err != nil {
+		return fmt.Errorf("failed to read job settings: %w", err)
+	}
+	if opts.provider != "" {
+		params.Provider = opts.provider
+	}
+
+	pending, err := ctx.Repository.PendingBulkJobItems(job.ID)
+	if err != nil {
+		return err
+	}
+
+	ideas := make([]*models.Idea, 0, len(pending))
+	for _, id := range pending {
+		idea, err := ctx.Repository.GetByID(id)
+		if database.IsNotFound(err) {
+			// Deleted since the job started; nothing left to analyze
+			if err := ctx.Repository.MarkBulkJobItemDone(job.ID, id); err != nil {
+				return err
+			}
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to load idea %s: %w", shortID(id), err)
+		}
+		ideas = append(ideas, idea)
+	}
+
+	fmt.Printf("🔁 Resuming job %s: %s of %d ideas remaining\n\n",
+		job.ID, color.CyanString("%d", len(ideas)), job.Total)
+
+	if opts.dryRun {
+		if _, err := cliutil.InfoColor.Println("🔍 DRY RUN - No changes will be made"); err != nil {
+			log.Warn().Err(err).Msg("failed to print message")
+		}
+		return nil
+	}
+
+	if len(ideas) > 0 && !opts.yes && !cliutil.Confirm(fmt.Sprintf("Re-analyze %d remaining ideas?", len(ideas))) {
+		fmt.Println("❌ Cancelled")
+		return nil
+	}
+
+	llmManager, err := analyzeLLMManager(ctx, params.Provider)
+	if err != nil {
+		return err
+	}
+
+	return runAnalyzeJob(done, ctx, llmManager, job.ID, ideas, params.MinDelta)
+}
+
+// analyzeLLMManager returns the LLM manager to analyze with, switched to
+// provider when one is given
+func analyzeLLMManager(ctx *CLIContext, provider string) (*llm.Manager, error) {
 	llmManager := ctx.LLMManager
 	if llmManager == nil {
 		llmManager = createLLMManager()
 	}
 
-	// Set provider if specified
-	if opts.provider != "" {
-		if err := llmManager.SetPrimaryProvider(opts.provider); err != nil {
-			return fmt.Errorf("failed to set provider: %w", err)
+	if provider != "" {
+		if err := llmManager.SetPrimaryProvider(provider); err != nil {
+			return nil, fmt.Errorf("failed to set provider: %w", err)
 		}
-		if _, err := cliutil.InfoColor.Printf("🤖 Using provider: %s\n", opts.provider); err != nil {
+		if _, err := cliutil.InfoColor.Printf("🤖 Using provider: %s\n", provider); err != nil {
 			log.Warn().Err(err).Msg("failed to print message")
 		}
 	} else {
@@ -188,92 +330,192 @@ func runBulkAnalyze(getContext func() *CLIContext, opts bulkAnalyzeOptions) erro
 	}
 	fmt.Println()
 
-	// Create detector from telos
-	detector := patterns.NewDetector(ctx.Telos)
+	return llmManager, nil
+}
 
-	// Analyze ideas with progress tracking
-	successful := 0
-	failed := 0
-	errors := make([]string, 0)
+// startAnalyzeJob records a new analyze job over ideas
+func startAnalyzeJob(repo *database.Repository, ideas []*models.Idea, params analyzeJobParams) (*models.BulkJob, error) {
+	encoded, err := json.Marshal(params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode job settings: %w", err)
+	}
 
+	ids := make([]string, len(ideas))
 	for i, idea := range ideas {
-		// Show progress
-		progress := float64(i+1) / float64(len(ideas)) * 100
-		fmt.Printf("\r[%d/%d] 🔄 Analyzing ideas... %.1f%%",
-			i+1, len(ideas), progress)
+		ids[i] = idea.ID
+	}
 
-		// Re-analyze using LLM
-		result, err := llmManager.AnalyzeWithTelos(idea.Content, ctx.Telos)
-		if err != nil {
-			failed++
-			errors = append(errors, fmt.Sprintf("%s: %v", idea.ID[:8], err))
-			continue
+	job := models.NewBulkJob(models.BulkJobAnalyze, string(encoded))
+	if err := repo.CreateBulkJob(job, ids); err != nil {
+		return nil, err
+	}
+	return job, nil
+}
+
+// runAnalyzeJob analyzes ideas as part of job jobID and prints a summary.
+// The job is marked complete once every idea has been processed.
+func runAnalyzeJob(done context.Context, ctx *CLIContext, llmManager *llm.Manager, jobID string, ideas []*models.Idea, minDelta float64) error {
+	// Create detector from telos and any custom pattern rules
+	detector := patterns.NewDetectorWithRules(ctx.Telos, ctx.PatternRules)
+
+	// Analyze ideas with progress tracking
+	bar := cliutil.NewProgressBar(len(ideas), "🔄 Analyzing")
+	result, err := analyzeIdeas(done, ctx, llmManager, detector, jobID, ideas, minDelta, barProgress(bar))
+	bar.Finish()
+	fmt.Println()
+
+	if err != nil {
+		return err
+	}
+
+	interrupted := done.Err() != nil
+	if !interrupted && result.Failed == 0 {
+		if err := ctx.Repository.CompleteBulkJob(jobID); err != nil {
+			return err
 		}
+	}
 
-		// Detect patterns
-		detectedPatterns := detector.DetectPatterns(idea.Content)
-		patternStrings := make([]string, len(detectedPatterns))
-		for j, p := range detectedPatterns {
-			patternStrings[j] = fmt.Sprintf("%s: %s", p.Name, p.Description)
+	// Show summary
+	if interrupted {
+		if _, err := cliutil.WarningColor.Printf("⏸  Re-analysis interrupted:\n"); err != nil {
+			log.Warn().Err(err).Msg("failed to print interrupted message")
+		}
+	} else if _, err := cliutil.SuccessColor.Printf("✅ Re-analysis complete:\n"); err != nil {
+		log.Warn().Err(err).Msg("failed to print success message")
+	}
+	fmt.Printf("  ✓ Successful: %d\n", result.Succeeded)
+	if minDelta > 0 {
+		fmt.Printf("  = Unchanged: %d (score moved less than %.2f)\n", result.Unchanged, minDelta)
+	}
+	if result.Failed > 0 {
+		if _, err := cliutil.WarningColor.Printf("  ✗ Failed: %d\n", result.Failed); err != nil {
+			log.Warn().Err(err).Msg("failed to print failed count")
 		}
+	}
+	// Also lists ideas analyzed only after a provider failed
+	if len(result.Errors) > 0 && len(result.Errors) <= 10 {
+		fmt.Println("\nErrors:")
+		for _, errMsg := range result.Errors {
+			fmt.Printf("  - %s\n", errMsg)
+		}
+	} else if len(result.Errors) > 10 {
+		fmt.Printf("\n  (Showing first 10 of %d errors)\n", len(result.Errors))
+		for i := 0; i < 10; i++ {
+			fmt.Printf("  - %s\n", result.Errors[i])
+		}
+	}
 
-		// Format explanations as JSON for storage
-		analysisDetails := ""
-		if len(result.Explanations) > 0 {
-			detailsMap := map[string]interface{}{
-				"explanations": result.Explanations,
-				"provider":     result.Provider,
-				"scores": map[string]float64{
-					"mission_alignment": result.Scores.MissionAlignment,
-					"anti_challenge":    result.Scores.AntiChallenge,
-					"strategic_fit":     result.Scores.StrategicFit,
-				},
-			}
-			detailsBytes, _ := json.Marshal(detailsMap)
-			analysisDetails = string(detailsBytes)
-		} else {
-			analysisDetails = result.Recommendation
+	if interrupted || result.Failed > 0 {
+		fmt.Printf("\nResume with: tm bulk analyze --resume %s\n", jobID)
+	}
+
+	return nil
+}
+
+// analyzeIdeas re-analyzes each idea with the LLM manager and saves the ideas
+// whose score moved by at least minDelta. Every idea that doesn't fail is
+// marked done in job jobID before moving on, so failed ideas are retried on
+// resume. Canceling done abandons the idea in flight, which is left for the
+// resume rather than counted as failed; progress may be nil.
+// The error is non-nil only if progress could not be recorded.
+func analyzeIdeas(done context.Context, ctx *CLIContext, llmManager *llm.Manager, detector *patterns.Detector, jobID string, ideas []*models.Idea, minDelta float64, progress ProgressFunc) (BatchResult, error) {
+	var result BatchResult
+
+	for i, idea := range ideas {
+		if done.Err() != nil {
+			break
 		}
 
-		// Update idea
-		idea.FinalScore = result.FinalScore
-		idea.Patterns = patternStrings
-		idea.Recommendation = result.Recommendation
-		idea.AnalysisDetails = analysisDetails
+		if err := analyzeIdea(done, ctx, llmManager, detector, idea, minDelta, &result); err != nil {
+			if done.Err() != nil {
+				break
+			}
+			result.Failed++
+			result.Errors = append(result.Errors, fmt.Sprintf("%s: %v", shortID(idea.ID), err))
+		} else if err := ctx.Repository.MarkBulkJobItemDone(jobID, idea.ID); err != nil {
+			return result, fmt.Errorf("failed to record progress: %w", err)
+		}
 
-		if err := ctx.Repository.Update(idea); err != nil {
-			failed++
-			errors = append(errors, fmt.Sprintf("%s: failed to save: %v", idea.ID[:8], err))
-			continue
+		if progress != nil {
+			progress(i+1, len(ideas), idea)
 		}
+	}
+
+	return result, nil
+}
 
-		successful++
+// analyzeIdea re-analyzes one idea, counting it as succeeded or unchanged in result
+func analyzeIdea(done context.Context, ctx *CLIContext, llmManager *llm.Manager, detector *patterns.Detector, idea *models.Idea, minDelta float64, result *BatchResult) error {
+	// Re-analyze using LLM
+	analysis, err := llmManager.AnalyzeContext(done, llm.AnalysisRequest{
+		IdeaContent: idea.Content,
+		Telos:       ctx.Telos,
+	})
+	if err != nil {
+		return err
 	}
 
-	fmt.Println() // New line after progress
-	fmt.Println()
+	// Keep the providers that failed before one answered
+	if chain := analysis.FallbackChain; len(chain) > 1 {
+		result.Errors = append(result.Errors, fmt.Sprintf("%s: %s; analyzed by %s",
+			shortID(idea.ID), strings.Join(chain[:len(chain)-1], "; "), analysis.Provider))
+	}
 
-	// Show summary
-	if _, err := cliutil.SuccessColor.Printf("✅ Re-analysis complete:\n"); err != nil {
-		log.Warn().Err(err).Msg("failed to print success message")
+	// Detect patterns
+	detectedPatterns := detector.DetectPatterns(idea.Content)
+	patternStrings := make([]string, len(detectedPatterns))
+	for j, p := range detectedPatterns {
+		patternStrings[j] = fmt.Sprintf("%s: %s", p.Name, p.Description)
 	}
-	fmt.Printf("  ✓ Successful: %d\n", successful)
-	if failed > 0 {
-		if _, err := cliutil.WarningColor.Printf("  ✗ Failed: %d\n", failed); err != nil {
-			log.Warn().Err(err).Msg("failed to print failed count")
+
+	// Enforce configured score floors and ceilings
+	finalScore, bounds := scoring.ApplyScoreBounds(analysis.FinalScore, idea.Tags, patternStrings, ctx.ScoreBounds)
+	recommendation := analysis.Recommendation
+	if len(bounds) > 0 {
+		recommendation = models.CurrentRecommendationThresholds().Recommend(finalScore).String()
+	}
+
+	// Skip noise: leave the stored analysis alone when the score barely moved
+	if !exceedsMinDelta(idea.FinalScore, finalScore, minDelta) {
+		result.Unchanged++
+		return nil
+	}
+
+	// Format explanations as JSON for storage
+	analysisDetails := ""
+	if len(analysis.Explanations) > 0 {
+		detailsMap := map[string]interface{}{
+			"explanations": analysis.Explanations,
+			"provider":     analysis.Provider,
+			"scores": map[string]float64{
+				"mission_alignment": analysis.Scores.MissionAlignment,
+				"anti_challenge":    analysis.Scores.AntiChallenge,
+				"strategic_fit":     analysis.Scores.StrategicFit,
+			},
 		}
-		if len(errors) > 0 && len(errors) <= 10 {
-			fmt.Println("\nErrors:")
-			for _, errMsg := range errors {
-				fmt.Printf("  - %s\n", errMsg)
-			}
-		} else if len(errors) > 10 {
-			fmt.Printf("\n  (Showing first 10 of %d errors)\n", len(errors))
-			for i := 0; i < 10; i++ {
-				fmt.Printf("  - %s\n", errors[i])
-			}
+		if len(bounds) > 0 {
+			detailsMap["bounds"] = bounds
+		}
+		detailsBytes, _ := json.Marshal(detailsMap)
+		analysisDetails = string(detailsBytes)
+	} else {
+		text := analysis.Recommendation
+		for _, b := range bounds {
+			text += "\n" + b.String()
 		}
+		analysisDetails = models.WrapAnalysisText(text)
+	}
+
+	// Update idea
+	idea.FinalScore = finalScore
+	idea.Patterns = patternStrings
+	idea.Recommendation = recommendation
+	idea.AnalysisDetails = analysisDetails
+
+	if err := ctx.Repository.Update(idea); err != nil {
+		return fmt.Errorf("failed to save: %w", err)
 	}
 
+	result.Succeeded++
 	return nil
 }
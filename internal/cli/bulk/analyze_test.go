@@ -0,0 +1,378 @@
+//go:build integration
+
+package bulk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/ryacub/telos-idea-matrix/internal/llm"
+	"github.com/ryacub/telos-idea-matrix/internal/models"
+	"github.com/ryacub/telos-idea-matrix/internal/patterns"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fixedScoreProvider is an LLM provider that always returns the same score
+type fixedScoreProvider struct {
+	score float64
+	calls int
+}
+
+func (p *fixedScoreProvider) Name() string      { return "fixed" }
+func (p *fixedScoreProvider) IsAvailable() bool { return true }
+
+func (p *fixedScoreProvider) Analyze(req llm.AnalysisRequest) (*llm.AnalysisResult, error) {
+	p.calls++
+	return &llm.AnalysisResult{
+		FinalScore:     p.score,
+		Recommendation: "🔥 PRIORITIZE NOW",
+		Explanations:   map[string]string{"mission_alignment": "re-analyzed"},
+		Provider:       p.Name(),
+	}, nil
+}
+
+func (p *fixedScoreProvider) AnalyzeContext(_ context.Context, req llm.AnalysisRequest) (*llm.AnalysisResult, error) {
+	return p.Analyze(req)
+}
+
+func setupBulkAnalyzeTest(t *testing.T, provider llm.Provider) (*CLIContext, *models.Idea) {
+	t.Helper()
+
+	repo := newTestRepository(t)
+
+	idea := models.NewIdea("Build a Go CLI for tracking ideas")
+	idea.FinalScore = 6.0
+	idea.Recommendation = "✅ GOOD ALIGNMENT"
+	idea.AnalysisDetails = "original analysis"
+	idea.Patterns = []string{"original: pattern"}
+	require.NoError(t, repo.Create(idea))
+
+	manager := llm.NewManager(&llm.ManagerConfig{})
+	manager.RegisterProvider(provider)
+	require.NoError(t, manager.SetPrimaryProvider(provider.Name()))
+
+	cliCtx := &CLIContext{
+		Repository: repo,
+		Telos:      &models.Telos{},
+		LLMManager: manager,
+	}
+	return cliCtx, idea
+}
+
+func TestBulkAnalyze_BelowMinDelta_LeavesIdeaUntouched(t *testing.T) {
+	provider := &fixedScoreProvider{score: 6.05}
+	cliCtx, idea := setupBulkAnalyzeTest(t, provider)
+
+	before, err := cliCtx.Repository.GetAnalyticsSummary()
+	require.NoError(t, err)
+
+	err = runBulkAnalyze(context.Background(), func() *CLIContext { return cliCtx }, bulkAnalyzeOptions{
+		scoreMin: 0,
+		scoreMax: 10,
+		status:   "active",
+		yes:      true,
+		minDelta: 0.5,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 1, provider.calls)
+
+	stored, err := cliCtx.Repository.GetByID(idea.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 6.0, stored.FinalScore)
+	assert.Equal(t, "✅ GOOD ALIGNMENT", stored.Recommendation)
+	assert.Equal(t, "original analysis", stored.AnalysisDetails)
+	assert.Equal(t, []string{"original: pattern"}, stored.Patterns)
+
+	// No write reached the ideas table, so the change log did not advance
+	after, err := cliCtx.Repository.GetAnalyticsSummary()
+	require.NoError(t, err)
+	assert.Equal(t, before.ChangeCount, after.ChangeCount)
+}
+
+func TestBulkAnalyze_AboveMinDelta_PersistsReanalysis(t *testing.T) {
+	provider := &fixedScoreProvider{score: 8.2}
+	cliCtx, idea := setupBulkAnalyzeTest(t, provider)
+
+	err := runBulkAnalyze(context.Background(), func() *CLIContext { return cliCtx }, bulkAnalyzeOptions{
+		scoreMin: 0,
+		scoreMax: 10,
+		status:   "active",
+		yes:      true,
+		minDelta: 0.5,
+	})
+	require.NoError(t, err)
+
+	stored, err := cliCtx.Repository.GetByID(idea.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 8.2, stored.FinalScore)
+	assert.Equal(t, "🔥 PRIORITIZE NOW", stored.Recommendation)
+}
+
+func TestExceedsMinDelta(t *testing.T) {
+	tests := []struct {
+		name     string
+		old, new float64
+		minDelta float64
+		want     bool
+	}{
+		{"no gate persists tiny change", 6.0, 6.01, 0, true},
+		{"below threshold", 6.0, 6.01, 0.1, false},
+		{"exactly threshold", 6.0, 6.5, 0.5, true},
+		{"drop above threshold", 6.0, 5.0, 0.5, true},
+		{"drop below threshold", 6.0, 5.9, 0.5, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, exceedsMinDelta(tt.old, tt.new, tt.minDelta))
+		})
+	}
+}
+
+// countingProvider records how often each idea is analyzed. afterCall, if set,
+// runs after every analysis with the total number of calls so far; failFor
+// lists idea contents that fail once.
+type countingProvider struct {
+	calls     map[string]int
+	total     int
+	afterCall func(total int)
+	failFor   map[string]bool
+}
+
+func (p *countingProvider) Name() string      { return "counting" }
+func (p *countingProvider) IsAvailable() bool { return true }
+
+func (p *countingProvider) Analyze(req llm.AnalysisRequest) (*llm.AnalysisResult, error) {
+	p.calls[req.IdeaContent]++
+	p.total++
+	if p.afterCall != nil {
+		defer p.afterCall(p.total)
+	}
+	if p.failFor[req.IdeaContent] {
+		delete(p.failFor, req.IdeaContent)
+		return nil, errors.New("provider unavailable")
+	}
+	return &llm.AnalysisResult{FinalScore: 7.5, Recommendation: "✅ GOOD ALIGNMENT", Provider: p.Name()}, nil
+}
+
+func (p *countingProvider) AnalyzeContext(_ context.Context, req llm.AnalysisRequest) (*llm.AnalysisResult, error) {
+	return p.Analyze(req)
+}
+
+// hangingProvider succeeds for the first `succeed` ideas, then blocks until
+// the request's context is canceled, like a stalled Ollama request
+type hangingProvider struct {
+	succeed int
+	calls   int
+	hung    chan struct{}
+}
+
+func (p *hangingProvider) Name() string      { return "hanging" }
+func (p *hangingProvider) IsAvailable() bool { return true }
+
+func (p *hangingProvider) Analyze(req llm.AnalysisRequest) (*llm.AnalysisResult, error) {
+	return p.AnalyzeContext(context.Background(), req)
+}
+
+func (p *hangingProvider) AnalyzeContext(ctx context.Context, req llm.AnalysisRequest) (*llm.AnalysisResult, error) {
+	p.calls++
+	if p.calls <= p.succeed {
+		return &llm.AnalysisResult{FinalScore: 7.5, Recommendation: "✅ GOOD ALIGNMENT", Provider: p.Name()}, nil
+	}
+	close(p.hung)
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func setupResumeTest(t *testing.T, provider *countingProvider, n int) (*CLIContext, []*models.Idea) {
+	t.Helper()
+
+	repo := newTestRepository(t)
+	ideas := make([]*models.Idea, n)
+	for i := range ideas {
+		ideas[i] = models.NewIdea(fmt.Sprintf("Idea number %d for bulk analysis", i+1))
+		ideas[i].FinalScore = 5.0
+		require.NoError(t, repo.Create(ideas[i]))
+	}
+
+	manager := llm.NewManager(&llm.ManagerConfig{})
+	manager.RegisterProvider(provider)
+	require.NoError(t, manager.SetPrimaryProvider(provider.Name()))
+
+	return &CLIContext{Repository: repo, Telos: &models.Telos{}, LLMManager: manager}, ideas
+}
+
+func TestBulkAnalyze_ResumeAfterInterrupt_ProcessesRemainingOnce(t *testing.T) {
+	const crashAfter = 2
+	provider := &countingProvider{calls: map[string]int{}}
+	cliCtx, ideas := setupResumeTest(t, provider, 5)
+
+	job, err := startAnalyzeJob(cliCtx.Repository, ideas, analyzeJobParams{})
+	require.NoError(t, err)
+
+	// "Crash" once crashAfter ideas have been analyzed
+	done, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	provider.afterCall = func(total int) {
+		if total == crashAfter {
+			cancel()
+		}
+	}
+	require.NoError(t, runAnalyzeJob(done, cliCtx, cliCtx.LLMManager, job.ID, ideas, 0))
+
+	stored, err := cliCtx.Repository.GetBulkJob(job.ID)
+	require.NoError(t, err)
+	assert.Equal(t, crashAfter, stored.Done)
+	assert.Equal(t, len(ideas), stored.Total)
+	assert.False(t, stored.IsComplete())
+
+	provider.afterCall = nil
+	err = runBulkAnalyze(context.Background(), func() *CLIContext { return cliCtx }, bulkAnalyzeOptions{
+		resume: job.ID,
+		yes:    true,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, len(ideas), provider.total, "no idea should be analyzed twice")
+	for _, idea := range ideas {
+		assert.Equal(t, 1, provider.calls[idea.Content], "idea %q", idea.Content)
+
+		saved, err := cliCtx.Repository.GetByID(idea.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 7.5, saved.FinalScore)
+	}
+
+	stored, err = cliCtx.Repository.GetBulkJob(job.ID)
+	require.NoError(t, err)
+	assert.Equal(t, len(ideas), stored.Done)
+	assert.True(t, stored.IsComplete())
+}
+
+func TestBulkAnalyze_Resume_RetriesFailedIdeas(t *testing.T) {
+	provider := &countingProvider{calls: map[string]int{}}
+	cliCtx, ideas := setupResumeTest(t, provider, 3)
+	provider.failFor = map[string]bool{ideas[1].Content: true}
+
+	job, err := startAnalyzeJob(cliCtx.Repository, ideas, analyzeJobParams{})
+	require.NoError(t, err)
+	require.NoError(t, runAnalyzeJob(context.Background(), cliCtx, cliCtx.LLMManager, job.ID, ideas, 0))
+
+	stored, err := cliCtx.Repository.GetBulkJob(job.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 2, stored.Done)
+	assert.False(t, stored.IsComplete(), "a job with failures stays open for retry")
+
+	err = runBulkAnalyze(context.Background(), func() *CLIContext { return cliCtx }, bulkAnalyzeOptions{
+		resume: job.ID,
+		yes:    true,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, provider.calls[ideas[0].Content])
+	assert.Equal(t, 2, provider.calls[ideas[1].Content])
+	assert.Equal(t, 1, provider.calls[ideas[2].Content])
+
+	stored, err = cliCtx.Repository.GetBulkJob(job.ID)
+	require.NoError(t, err)
+	assert.True(t, stored.IsComplete())
+}
+
+func TestBulkAnalyze_ErrorsNameProviderAfterFallback(t *testing.T) {
+	provider := &countingProvider{calls: map[string]int{}}
+	cliCtx, ideas := setupResumeTest(t, provider, 3)
+	provider.failFor = map[string]bool{ideas[1].Content: true}
+
+	// Fall back to fixed before any provider configured in the environment
+	manager := llm.NewManager(&llm.ManagerConfig{})
+	manager.RegisterProvider(provider)
+	manager.RegisterProvider(&fixedScoreProvider{score: 6.5})
+	require.NoError(t, manager.LoadConfig(&llm.ManagerConfig{
+		DefaultProvider: provider.Name(),
+		FallbackEnabled: true,
+		Priority:        []string{provider.Name(), "fixed"},
+	}))
+
+	job, err := startAnalyzeJob(cliCtx.Repository, ideas, analyzeJobParams{})
+	require.NoError(t, err)
+	detector := patterns.NewDetectorWithRules(cliCtx.Telos, nil)
+	result, err := analyzeIdeas(context.Background(), cliCtx, manager, detector, job.ID, ideas, 0, nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, 3, result.Succeeded)
+	assert.Zero(t, result.Failed)
+	require.Len(t, result.Errors, 1)
+	assert.Equal(t, shortID(ideas[1].ID)+": counting: provider unavailable; analyzed by fixed", result.Errors[0])
+
+	saved, err := cliCtx.Repository.GetByID(ideas[1].ID)
+	require.NoError(t, err)
+	assert.Equal(t, 6.5, saved.FinalScore)
+}
+
+func TestBulkAnalyze_ResumeUnknownJob(t *testing.T) {
+	provider := &countingProvider{calls: map[string]int{}}
+	cliCtx, _ := setupResumeTest(t, provider, 1)
+
+	err := runBulkAnalyze(context.Background(), func() *CLIContext { return cliCtx }, bulkAnalyzeOptions{
+		resume: "no-such-job",
+		yes:    true,
+	})
+	assert.ErrorContains(t, err, "not found")
+	assert.Zero(t, provider.total)
+}
+
+func TestBulkAnalyze_InterruptCancelsHungRequest(t *testing.T) {
+	repo := newTestRepository(t)
+	ideas := make([]*models.Idea, 3)
+	for i := range ideas {
+		ideas[i] = models.NewIdea(fmt.Sprintf("Idea number %d for bulk analysis", i+1))
+		ideas[i].FinalScore = 5.0
+		require.NoError(t, repo.Create(ideas[i]))
+	}
+
+	provider := &hangingProvider{succeed: 1, hung: make(chan struct{})}
+	manager := llm.NewManager(&llm.ManagerConfig{FallbackEnabled: true})
+	manager.RegisterProvider(provider)
+	require.NoError(t, manager.SetPrimaryProvider(provider.Name()))
+	cliCtx := &CLIContext{Repository: repo, Telos: &models.Telos{}, LLMManager: manager}
+
+	job, err := startAnalyzeJob(repo, ideas, analyzeJobParams{})
+	require.NoError(t, err)
+
+	done, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		<-provider.hung
+		cancel()
+	}()
+
+	finished := make(chan error, 1)
+	go func() {
+		finished <- runAnalyzeJob(done, cliCtx, manager, job.ID, ideas, 0)
+	}()
+
+	select {
+	case err := <-finished:
+		require.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("bulk analyze did not stop after cancellation")
+	}
+
+	assert.Equal(t, 2, provider.calls, "no idea should be started after cancellation")
+
+	stored, err := repo.GetBulkJob(job.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 1, stored.Done, "the completed idea should be saved")
+	assert.False(t, stored.IsComplete())
+
+	saved, err := repo.GetByID(ideas[0].ID)
+	require.NoError(t, err)
+	assert.Equal(t, 7.5, saved.FinalScore)
+
+	untouched, err := repo.GetByID(ideas[1].ID)
+	require.NoError(t, err)
+	assert.Equal(t, 5.0, untouched.FinalScore)
+}
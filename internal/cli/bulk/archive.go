@@ -2,12 +2,14 @@ package bulk
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/fatih/color"
 	"github.com/rs/zerolog/log"
 	"github.com/ryacub/telos-idea-matrix/internal/cliutil"
 	"github.com/ryacub/telos-idea-matrix/internal/database"
+	"github.com/ryacub/telos-idea-matrix/internal/models"
 	"github.com/spf13/cobra"
 )
 
@@ -20,13 +22,15 @@ func NewArchiveCommand(getContext func() *CLIContext) *cobra.Command {
 	var limit int
 	var yes bool
 	var dryRun bool
+	var reason string
 
 	cmd := &cobra.Command{
 		Use:   "archive",
 		Short: "Archive multiple old/low-scoring ideas",
 		Long: `Archive multiple ideas based on age and score filters.
 Use --older-than to archive ideas older than N days.
-Use --max-score to archive ideas below a score threshold.`,
+Use --max-score to archive ideas below a score threshold.
+Each idea records --reason, or a description of the filters used.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx := getContext()
 			if ctx == nil {
@@ -46,23 +50,24 @@ Use --max-score to archive ideas below a score threshold.`,
 			}
 			limitPtr := &limit
 
+			// Filter by age in SQL so the limit applies to matching ideas
+			var createdBefore *time.Time
+			if olderThan > 0 {
+				createdBefore = cutoffBefore(time.Duration(olderThan) * 24 * time.Hour)
+			}
+
 			ideas, err := ctx.Repository.List(database.ListOptions{
-				Status:   "active",
-				MinScore: minScorePtr,
-				MaxScore: maxScorePtr,
-				Limit:    limitPtr,
-				OrderBy:  "created_at ASC", // Oldest first
+				Status:        "active",
+				MinScore:      minScorePtr,
+				MaxScore:      maxScorePtr,
+				CreatedBefore: createdBefore,
+				Limit:         limitPtr,
+				OrderBy:       database.OrderBy(database.SortByCreatedAt, database.Ascending), // Oldest first
 			})
 			if err != nil {
 				return fmt.Errorf("failed to list ideas: %w", err)
 			}
 
-			// Filter by age if specified
-			if olderThan > 0 {
-				cutoffDate := time.Now().UTC().Add(-time.Duration(olderThan) * 24 * time.Hour)
-				ideas = filterByAge(ideas, cutoffDate)
-			}
-
 			// Filter by search if provided
 			if search != "" {
 				ideas = filterBySearch(ideas, search)
@@ -101,33 +106,30 @@ Use --max-score to archive ideas below a score threshold.`,
 				return nil
 			}
 
-			// Archive ideas
-			successCount := 0
-			errorCount := 0
-			for i, idea := range ideas {
-				idea.Status = "archived"
-				if err := ctx.Repository.Update(idea); err != nil {
-					if _, printErr := cliutil.WarningColor.Printf("⚠  Failed to archive idea %s: %v\n", idea.ID, err); printErr != nil {
-						log.Warn().Err(printErr).Msg("failed to print error message")
-					}
-					errorCount++
-					continue
-				}
-				successCount++
+			if reason == "" {
+				reason = bulkArchiveReason(olderThan, minScore, maxScore, search)
+			}
 
-				// Show progress for large batches
-				if len(ideas) > 10 && (i+1)%10 == 0 {
-					fmt.Printf("  Progress: %d/%d archived\n", i+1, len(ideas))
+			// Archive ideas
+			bar := cliutil.NewProgressBar(len(ideas), "Archiving")
+			result := UpdateIdeas(ctx.Repository, ideas, UpdateOptions{
+				SetStatus:     string(models.StatusArchived),
+				ArchiveReason: reason,
+			}, barProgress(bar))
+			bar.Finish()
+			for _, errMsg := range result.Errors {
+				if _, err := cliutil.WarningColor.Printf("⚠  Failed to archive idea %s\n", errMsg); err != nil {
+					log.Warn().Err(err).Msg("failed to print error message")
 				}
 			}
 
-			if errorCount > 0 {
-				if _, err := cliutil.WarningColor.Printf("⚠  %d ideas failed to archive\n", errorCount); err != nil {
+			if result.Failed > 0 {
+				if _, err := cliutil.WarningColor.Printf("⚠  %d ideas failed to archive\n", result.Failed); err != nil {
 					log.Warn().Err(err).Msg("failed to print warning message")
 				}
 			}
 
-			if _, err := cliutil.SuccessColor.Printf("✅ Archived %d ideas\n", successCount); err != nil {
+			if _, err := cliutil.SuccessColor.Printf("✅ Archived %d ideas\n", result.Succeeded); err != nil {
 				log.Warn().Err(err).Msg("failed to print success message")
 			}
 			return nil
@@ -141,6 +143,29 @@ Use --max-score to archive ideas below a score threshold.`,
 	cmd.Flags().IntVar(&limit, "limit", 100, "Maximum ideas to process")
 	cmd.Flags().BoolVar(&yes, "yes", false, "Auto-confirm (skip confirmation prompt)")
 	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show what would be archived without making changes")
+	cmd.Flags().StringVar(&reason, "reason", "", "Archive reason (defaults to a description of the filters)")
 
 	return cmd
 }
+
+// bulkArchiveReason describes the filters used, recorded as each idea's archive reason
+func bulkArchiveReason(olderThan int, minScore, maxScore float64, search string) string {
+	var filters []string
+	if olderThan > 0 {
+		filters = append(filters, fmt.Sprintf("older than %d days", olderThan))
+	}
+	if minScore > 0 {
+		filters = append(filters, fmt.Sprintf("score at least %.1f", minScore))
+	}
+	if maxScore > 0 {
+		filters = append(filters, fmt.Sprintf("score at most %.1f", maxScore))
+	}
+	if search != "" {
+		filters = append(filters, fmt.Sprintf("matching %q", search))
+	}
+
+	if len(filters) == 0 {
+		return "bulk archive"
+	}
+	return "bulk archive: " + strings.Join(filters, ", ")
+}
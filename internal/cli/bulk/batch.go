@@ -0,0 +1,61 @@
+package bulk
+
+import (
+	"fmt"
+
+	"github.com/ryacub/telos-idea-matrix/internal/cliutil"
+	"github.com/ryacub/telos-idea-matrix/internal/database"
+	"github.com/ryacub/telos-idea-matrix/internal/models"
+)
+
+// ProgressFunc is called after each idea in a batch has been processed,
+// whether it succeeded, failed, or needed no change. done counts processed
+// ideas, so it increases by one per call and ends at total.
+type ProgressFunc func(done, total int, current *models.Idea)
+
+// BatchResult summarizes a batch operation
+type BatchResult struct {
+	Succeeded int
+	Unchanged int
+	Failed    int
+	Errors    []string // One "<short id>: <error>" entry per failed idea, and per idea analyzed after a provider failed
+}
+
+// UpdateIdeas applies opts to each idea and saves the ideas that changed.
+// Failures are recorded in the result rather than stopping the batch.
+// progress may be nil.
+func UpdateIdeas(repo *database.Repository, ideas []*models.Idea, opts UpdateOptions, progress ProgressFunc) BatchResult {
+	var result BatchResult
+
+	for i, idea := range ideas {
+		if !applyUpdates(idea, opts) {
+			result.Unchanged++
+		} else if err := repo.Update(idea); err != nil {
+			result.Failed++
+			result.Errors = append(result.Errors, fmt.Sprintf("%s: %v", shortID(idea.ID), err))
+		} else {
+			result.Succeeded++
+		}
+
+		if progress != nil {
+			progress(i+1, len(ideas), idea)
+		}
+	}
+
+	return result
+}
+
+// barProgress returns a ProgressFunc that advances bar once per idea
+func barProgress(bar *cliutil.ProgressBar) ProgressFunc {
+	return func(int, int, *models.Idea) {
+		bar.Increment()
+	}
+}
+
+// shortID returns the first 8 characters of an idea ID for display
+func shortID(id string) string {
+	if len(id) > 8 {
+		return id[:8]
+	}
+	return id
+}
@@ -0,0 +1,115 @@
+//go:build integration
+
+package bulk
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ryacub/telos-idea-matrix/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdateIdeas_ReportsProgressOncePerIdea(t *testing.T) {
+	repo := newTestRepository(t)
+
+	tagged := models.NewIdea("Already tagged idea")
+	tagged.Tags = []string{"reviewed"}
+	require.NoError(t, repo.Create(tagged))
+
+	ideas := []*models.Idea{tagged}
+	for _, content := range []string{"First untagged idea", "Second untagged idea"} {
+		idea := models.NewIdea(content)
+		require.NoError(t, repo.Create(idea))
+		ideas = append(ideas, idea)
+	}
+
+	// Not in the database, so saving it fails
+	ideas = append(ideas, models.NewIdea("Never saved"))
+
+	var done, totals []int
+	var seen []string
+	result := UpdateIdeas(repo, ideas, UpdateOptions{AddTags: []string{"reviewed"}}, func(d, total int, current *models.Idea) {
+		done = append(done, d)
+		totals = append(totals, total)
+		seen = append(seen, current.ID)
+	})
+
+	assert.Equal(t, []int{1, 2, 3, 4}, done)
+	assert.Equal(t, []int{4, 4, 4, 4}, totals)
+	assert.Equal(t, ideaIDs(ideas), seen)
+
+	assert.Equal(t, 2, result.Succeeded)
+	assert.Equal(t, 1, result.Unchanged)
+	assert.Equal(t, 1, result.Failed)
+	require.Len(t, result.Errors, 1)
+	assert.Contains(t, result.Errors[0], shortID(ideas[3].ID))
+}
+
+func TestUpdateIdeas_NilProgress(t *testing.T) {
+	repo := newTestRepository(t)
+
+	idea := models.NewIdea("Archive me")
+	require.NoError(t, repo.Create(idea))
+
+	result := UpdateIdeas(repo, []*models.Idea{idea}, UpdateOptions{
+		SetStatus:     "archived",
+		ArchiveReason: "bulk archive: older than 30 days",
+	}, nil)
+	assert.Equal(t, 1, result.Succeeded)
+
+	stored, err := repo.GetByID(idea.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "archived", stored.Status)
+	assert.Equal(t, "bulk archive: older than 30 days", stored.ArchiveReason)
+}
+
+func TestListArchivedIdeas_AppliesFilters(t *testing.T) {
+	repo := newTestRepository(t)
+	now := time.Now().UTC()
+
+	newIdea := func(content, status string, score float64, daysAgo int) *models.Idea {
+		idea := models.NewIdea(content)
+		idea.Status = status
+		idea.FinalScore = score
+		idea.CreatedAt = now.AddDate(0, 0, -daysAgo)
+		require.NoError(t, repo.Create(idea))
+		return idea
+	}
+	recentHigh := newIdea("Podcast about indie games", "archived", 8, 2)
+	recentLow := newIdea("Podcast about cooking", "archived", 3, 1)
+	oldHigh := newIdea("Newsletter about Go", "archived", 9, 60)
+	newIdea("Podcast that is still active", "active", 9, 1)
+
+	ideas, err := listArchivedIdeas(repo, 0, 0, "", 100)
+	require.NoError(t, err)
+	assert.Equal(t, []string{recentLow.ID, recentHigh.ID, oldHigh.ID}, ideaIDs(ideas), "archived ideas only, newest first")
+
+	ideas, err = listArchivedIdeas(repo, 30, 5, "", 100)
+	require.NoError(t, err)
+	assert.Equal(t, []string{recentHigh.ID}, ideaIDs(ideas))
+
+	ideas, err = listArchivedIdeas(repo, 0, 0, "podcast", 1)
+	require.NoError(t, err)
+	assert.Equal(t, []string{recentLow.ID}, ideaIDs(ideas))
+}
+
+func TestUpdateIdeas_RestoreClearsArchiveReason(t *testing.T) {
+	repo := newTestRepository(t)
+
+	idea := models.NewIdea("Archived too eagerly")
+	idea.Archive("bulk archive: older than 30 days")
+	require.NoError(t, repo.Create(idea))
+	active := models.NewIdea("Never archived")
+	require.NoError(t, repo.Create(active))
+
+	result := UpdateIdeas(repo, []*models.Idea{idea, active}, UpdateOptions{SetStatus: "active"}, nil)
+	assert.Equal(t, 1, result.Succeeded)
+	assert.Equal(t, 1, result.Unchanged)
+
+	stored, err := repo.GetByID(idea.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "active", stored.Status)
+	assert.Empty(t, stored.ArchiveReason)
+}
@@ -4,6 +4,9 @@ import (
 	"github.com/ryacub/telos-idea-matrix/internal/database"
 	"github.com/ryacub/telos-idea-matrix/internal/llm"
 	"github.com/ryacub/telos-idea-matrix/internal/models"
+	"github.com/ryacub/telos-idea-matrix/internal/notify"
+	"github.com/ryacub/telos-idea-matrix/internal/patterns"
+	"github.com/ryacub/telos-idea-matrix/internal/profile"
 	"github.com/spf13/cobra"
 )
 
@@ -12,13 +15,26 @@ const (
 	FormatJSON = "json"
 	// FormatCSV represents CSV format for export/import
 	FormatCSV = "csv"
+	// FormatXLSX represents Excel workbook format for export
+	FormatXLSX = "xlsx"
+	// FormatNDJSON represents newline-delimited JSON format for export
+	FormatNDJSON = "ndjson"
+	// FormatYAML represents YAML format for export/import
+	FormatYAML = "yaml"
+	// FormatNotion represents syncing to a Notion database on export
+	FormatNotion = "notion"
 )
 
 // CLIContext represents the shared CLI dependencies for bulk operations
 type CLIContext struct {
-	Repository *database.Repository
-	Telos      *models.Telos
-	LLMManager *llm.Manager
+	Repository   *database.Repository
+	DBPath       string // Export state is kept beside the database
+	Telos        *models.Telos
+	PatternRules []patterns.Rule
+	ScoreBounds  []profile.ScoreBound
+	LLMManager   *llm.Manager
+	Notifier     *notify.Batcher // Nil when webhook notifications are disabled
+	NewEmbedder  func() (llm.Embedder, error)
 }
 
 // NewBulkCommand creates the bulk operations command
@@ -31,9 +47,11 @@ func NewBulkCommand(getContext func() *CLIContext) *cobra.Command {
 - update: Update multiple ideas in batch
 - tag: Add tags to multiple ideas based on filters
 - archive: Archive old or low-scoring ideas
-- delete: Permanently delete ideas (requires confirmation)
-- import: Import ideas from CSV
-- export: Export ideas to CSV or JSON`,
+- restore: Make archived ideas active again
+- delete: Move ideas to the trash (requires confirmation)
+- import: Import ideas from CSV or YAML
+- export: Export ideas to CSV, JSON, NDJSON, XLSX or YAML, or sync them to Notion
+- embed: Compute embeddings for 'tm similar'`,
 	}
 
 	// Add subcommands with context getter
@@ -41,9 +59,11 @@ func NewBulkCommand(getContext func() *CLIContext) *cobra.Command {
 	cmd.AddCommand(NewUpdateCommand(getContext))
 	cmd.AddCommand(NewTagCommand(getContext))
 	cmd.AddCommand(NewArchiveCommand(getContext))
+	cmd.AddCommand(NewRestoreCommand(getContext))
 	cmd.AddCommand(NewDeleteCommand(getContext))
 	cmd.AddCommand(NewImportCommand(getContext))
 	cmd.AddCommand(NewExportCommand(getContext))
+	cmd.AddCommand(NewEmbedCommand(getContext))
 
 	return cmd
 }
@@ -17,12 +17,17 @@ func NewDeleteCommand(getContext func() *CLIContext) *cobra.Command {
 	var search string
 	var limit int
 	var yes bool
+	var permanent bool
 
 	cmd := &cobra.Command{
 		Use:   "delete",
-		Short: "Permanently delete multiple ideas",
-		Long: `Permanently delete multiple ideas based on filters.
-⚠️  WARNING: This operation cannot be undone!
+		Short: "Move multiple ideas to the trash",
+		Long: `Move multiple ideas to the trash based on filters.
+Trashed ideas can be brought back with 'tm trash restore' until the trash is
+emptied with 'tm trash empty'.
+
+With --permanent the ideas are deleted immediately instead.
+⚠️  WARNING: A permanent delete cannot be undone!
 Always requires confirmation for safety.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx := getContext()
@@ -30,8 +35,6 @@ Always requires confirmation for safety.`,
 				return fmt.Errorf("CLI context not initialized")
 			}
 
-			// Create service once
-
 			// Build filter options
 			maxScorePtr := &maxScore
 			if maxScore == 0 {
@@ -39,22 +42,23 @@ Always requires confirmation for safety.`,
 			}
 			limitPtr := &limit
 
+			// Filter by age in SQL so the limit applies to matching ideas
+			var createdBefore *time.Time
+			if olderThan > 0 {
+				createdBefore = cutoffBefore(time.Duration(olderThan) * 24 * time.Hour)
+			}
+
 			ideas, err := ctx.Repository.List(database.ListOptions{
-				Status:   "active",
-				MaxScore: maxScorePtr,
-				Limit:    limitPtr,
-				OrderBy:  "created_at ASC",
+				Status:        "active",
+				MaxScore:      maxScorePtr,
+				CreatedBefore: createdBefore,
+				Limit:         limitPtr,
+				OrderBy:       database.OrderBy(database.SortByCreatedAt, database.Ascending),
 			})
 			if err != nil {
 				return fmt.Errorf("failed to list ideas: %w", err)
 			}
 
-			// Filter by age if specified
-			if olderThan > 0 {
-				cutoffDate := time.Now().UTC().Add(-time.Duration(olderThan) * 24 * time.Hour)
-				ideas = filterByAge(ideas, cutoffDate)
-			}
-
 			// Filter by search if provided
 			if search != "" {
 				ideas = filterBySearch(ideas, search)
@@ -66,8 +70,12 @@ Always requires confirmation for safety.`,
 			}
 
 			// Show preview
-			if _, err := cliutil.ErrorColor.Printf("⚠️  WARNING: About to PERMANENTLY DELETE %d ideas:\n", len(ideas)); err != nil {
-				log.Warn().Err(err).Msg("failed to print warning message")
+			if permanent {
+				if _, err := cliutil.ErrorColor.Printf("⚠️  WARNING: About to PERMANENTLY DELETE %d ideas:\n", len(ideas)); err != nil {
+					log.Warn().Err(err).Msg("failed to print warning message")
+				}
+			} else {
+				fmt.Printf("🗑️  About to move %d ideas to the trash:\n", len(ideas))
 			}
 			for i, idea := range ideas {
 				if i < 5 {
@@ -83,7 +91,11 @@ Always requires confirmation for safety.`,
 			// Always require confirmation for delete
 			if !yes {
 				fmt.Println()
-				if !cliutil.Confirm("⚠️  PERMANENTLY DELETE these ideas? This CANNOT be undone!") {
+				prompt := "Move these ideas to the trash?"
+				if permanent {
+					prompt = "⚠️  PERMANENTLY DELETE these ideas? This CANNOT be undone!"
+				}
+				if !cliutil.Confirm(prompt) {
 					fmt.Println("❌ Cancelled")
 					return nil
 				}
@@ -92,21 +104,27 @@ Always requires confirmation for safety.`,
 			// Delete ideas
 			successCount := 0
 			errorCount := 0
-			for i, idea := range ideas {
-				if err := ctx.Repository.Delete(idea.ID); err != nil {
+			bar := cliutil.NewProgressBar(len(ideas), "Deleting")
+			for _, idea := range ideas {
+				var err error
+				if permanent {
+					err = ctx.Repository.Delete(idea.ID)
+				} else {
+					idea.Trash()
+					err = ctx.Repository.Update(idea)
+				}
+				if err != nil {
+					bar.Clear()
 					if _, printErr := cliutil.WarningColor.Printf("⚠  Failed to delete idea %s: %v\n", idea.ID, err); printErr != nil {
 						log.Warn().Err(printErr).Msg("failed to print error message")
 					}
 					errorCount++
-					continue
-				}
-				successCount++
-
-				// Show progress for large batches
-				if len(ideas) > 10 && (i+1)%10 == 0 {
-					fmt.Printf("  Progress: %d/%d deleted\n", i+1, len(ideas))
+				} else {
+					successCount++
 				}
+				bar.Increment()
 			}
+			bar.Finish()
 
 			if errorCount > 0 {
 				if _, err := cliutil.WarningColor.Printf("⚠  %d ideas failed to delete\n", errorCount); err != nil {
@@ -114,9 +132,17 @@ Always requires confirmation for safety.`,
 				}
 			}
 
-			if _, err := cliutil.ErrorColor.Printf("🗑️  Permanently deleted %d ideas\n", successCount); err != nil {
+			if permanent {
+				if _, err := cliutil.ErrorColor.Printf("🗑️  Permanently deleted %d ideas\n", successCount); err != nil {
+					log.Warn().Err(err).Msg("failed to print message")
+				}
+				return nil
+			}
+
+			if _, err := cliutil.SuccessColor.Printf("🗑️  Moved %d ideas to the trash\n", successCount); err != nil {
 				log.Warn().Err(err).Msg("failed to print message")
 			}
+			fmt.Println("   Restore with 'tm trash restore <id>'; remove for good with 'tm trash empty'")
 			return nil
 		},
 	}
@@ -126,6 +152,7 @@ Always requires confirmation for safety.`,
 	cmd.Flags().StringVar(&search, "search", "", "Search term to filter ideas")
 	cmd.Flags().IntVar(&limit, "limit", 100, "Maximum ideas to process")
 	cmd.Flags().BoolVar(&yes, "yes", false, "Auto-confirm (skip confirmation prompt)")
+	cmd.Flags().BoolVar(&permanent, "permanent", false, "Delete permanently instead of moving to the trash")
 
 	return cmd
 }
@@ -0,0 +1,119 @@
+package bulk
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/ryacub/telos-idea-matrix/internal/cliutil"
+	"github.com/ryacub/telos-idea-matrix/internal/database"
+	"github.com/ryacub/telos-idea-matrix/internal/models"
+	"github.com/spf13/cobra"
+)
+
+// NewEmbedCommand creates the bulk embed command
+func NewEmbedCommand(getContext func() *CLIContext) *cobra.Command {
+	var limit int
+	var force bool
+
+	cmd := &cobra.Command{
+		Use:   "embed",
+		Short: "Compute embeddings for similarity search",
+		Long: `Compute embeddings for ideas that don't have one, so 'tm similar' can
+compare them. Ideas whose content changed since they were embedded are
+embedded again.
+
+Uses the provider set by embeddings.provider in 'tm config'.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := getContext()
+			if ctx == nil {
+				return fmt.Errorf("CLI context not initialized")
+			}
+
+			done, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+
+			embedded, failed, err := runBulkEmbed(done, ctx, limit, force)
+			if err != nil {
+				return err
+			}
+
+			if failed > 0 {
+				_, _ = cliutil.WarningColor.Printf("⚠  %d ideas failed to embed\n", failed)
+			}
+			_, _ = cliutil.SuccessColor.Printf("✓ Embedded %d ideas\n", embedded)
+			return nil
+		},
+	}
+
+	cmd.Flags().IntVar(&limit, "limit", 0, "Maximum ideas to embed (0 for all)")
+	cmd.Flags().BoolVar(&force, "force", false, "Embed ideas again even if their embedding is current")
+
+	return cmd
+}
+
+// runBulkEmbed embeds ideas without a current embedding, or every idea with
+// force, and returns how many were embedded and how many failed
+func runBulkEmbed(done context.Context, ctx *CLIContext, limit int, force bool) (embedded, failed int, err error) {
+	if ctx.NewEmbedder == nil {
+		return 0, 0, fmt.Errorf("embeddings are not available")
+	}
+	embedder, err := ctx.NewEmbedder()
+	if err != nil {
+		return 0, 0, err
+	}
+
+	existing, err := ctx.Repository.Embeddings(embedder.Model())
+	if err != nil {
+		return 0, 0, err
+	}
+
+	ideas, err := ctx.Repository.List(database.ListOptions{
+		OrderBy: database.OrderBy(database.SortByCreatedAt, database.Descending),
+	})
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to list ideas: %w", err)
+	}
+
+	var pending []*models.Idea
+	for _, idea := range ideas {
+		if _, ok := existing[idea.ID]; ok && !force {
+			continue
+		}
+		pending = append(pending, idea)
+	}
+	if limit > 0 && len(pending) > limit {
+		pending = pending[:limit]
+	}
+	if len(pending) == 0 {
+		fmt.Println("📭 Every idea already has an embedding.")
+		return 0, 0, nil
+	}
+
+	fmt.Printf("Embedding %d ideas with %s...\n", len(pending), embedder.Model())
+	bar := cliutil.NewProgressBar(len(pending), "Embedding")
+	for _, idea := range pending {
+		if done.Err() != nil {
+			bar.Finish()
+			_, _ = cliutil.WarningColor.Println("Interrupted; run again to embed the rest")
+			break
+		}
+
+		vector, err := embedder.Embed(done, idea.Content)
+		if err == nil {
+			err = ctx.Repository.SaveEmbedding(idea, embedder.Model(), vector)
+		}
+		if err != nil {
+			bar.Clear()
+			_, _ = cliutil.WarningColor.Printf("⚠  Failed to embed idea %s: %v\n", idea.ID[:8], err)
+			failed++
+		} else {
+			embedded++
+		}
+		bar.Increment()
+	}
+	bar.Finish()
+	return embedded, failed, nil
+}
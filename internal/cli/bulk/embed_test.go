@@ -0,0 +1,77 @@
+//go:build integration
+
+package bulk
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ryacub/telos-idea-matrix/internal/llm"
+	"github.com/ryacub/telos-idea-matrix/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeEmbedder embeds text as its length, failing for texts in failFor
+type fakeEmbedder struct {
+	calls   int
+	failFor map[string]bool
+}
+
+func (e *fakeEmbedder) Model() string { return "fake/length" }
+
+func (e *fakeEmbedder) Embed(_ context.Context, text string) ([]float32, error) {
+	e.calls++
+	if e.failFor[text] {
+		return nil, errors.New("embedding failed")
+	}
+	return []float32{float32(len(text)), 1}, nil
+}
+
+func TestRunBulkEmbed_EmbedsOnlyMissingIdeas(t *testing.T) {
+	repo := newTestRepository(t)
+
+	done := models.NewIdea("Already embedded")
+	require.NoError(t, repo.Create(done))
+	require.NoError(t, repo.SaveEmbedding(done, "fake/length", []float32{1, 1}))
+
+	fresh := models.NewIdea("Needs an embedding")
+	require.NoError(t, repo.Create(fresh))
+	broken := models.NewIdea("Embedding fails")
+	require.NoError(t, repo.Create(broken))
+
+	embedder := &fakeEmbedder{failFor: map[string]bool{"Embedding fails": true}}
+	ctx := &CLIContext{
+		Repository:  repo,
+		NewEmbedder: func() (llm.Embedder, error) { return embedder, nil },
+	}
+
+	embedded, failed, err := runBulkEmbed(context.Background(), ctx, 0, false)
+	require.NoError(t, err)
+	assert.Equal(t, 1, embedded)
+	assert.Equal(t, 1, failed)
+	assert.Equal(t, 2, embedder.calls, "the embedded idea is skipped")
+
+	embeddings, err := repo.Embeddings("fake/length")
+	require.NoError(t, err)
+	assert.Len(t, embeddings, 2)
+	assert.Contains(t, embeddings, fresh.ID)
+	assert.NotContains(t, embeddings, broken.ID)
+
+	// --force embeds everything again
+	embedder.failFor = nil
+	embedded, _, err = runBulkEmbed(context.Background(), ctx, 0, true)
+	require.NoError(t, err)
+	assert.Equal(t, 3, embedded)
+}
+
+func TestRunBulkEmbed_RequiresProvider(t *testing.T) {
+	ctx := &CLIContext{
+		Repository:  newTestRepository(t),
+		NewEmbedder: func() (llm.Embedder, error) { return nil, llm.ErrNoEmbedder },
+	}
+
+	_, _, err := runBulkEmbed(context.Background(), ctx, 0, false)
+	assert.ErrorIs(t, err, llm.ErrNoEmbedder)
+}
@@ -1,18 +1,18 @@
 package bulk
 
 import (
-	"encoding/csv"
-	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
-	"strconv"
 	"strings"
 	"time"
 
 	"github.com/rs/zerolog/log"
 	"github.com/ryacub/telos-idea-matrix/internal/cliutil"
+	"github.com/ryacub/telos-idea-matrix/internal/config"
 	"github.com/ryacub/telos-idea-matrix/internal/database"
+	"github.com/ryacub/telos-idea-matrix/internal/export"
 	"github.com/ryacub/telos-idea-matrix/internal/models"
 	"github.com/spf13/cobra"
 )
@@ -24,162 +24,304 @@ func NewExportCommand(getContext func() *CLIContext) *cobra.Command {
 	var limit int
 	var format string
 	var pretty bool
+	var fieldList string
+	var sinceLast bool
 
 	cmd := &cobra.Command{
-		Use:   "export <file>",
-		Short: "Export ideas to CSV or JSON",
-		Long: `Export ideas to a file in CSV or JSON format.
-Use --format to specify the output format (csv or json).
-Use filters to control which ideas are exported.`,
-		Args: cobra.ExactArgs(1),
+		Use:   "export [file]",
+		Short: "Export ideas to CSV, JSON, NDJSON, XLSX, YAML, or Notion",
+		Long: `Export ideas to a file in CSV, JSON, NDJSON, Excel (XLSX), or YAML format,
+or sync them to a Notion database.
+Use --format to specify the output format (csv, json, ndjson, xlsx, yaml,
+or notion).
+XLSX workbooks include a summary sheet with score and pattern counts.
+NDJSON writes one idea per line as it is read from the database, so
+large collections are never held in memory; use --limit 0 to export
+every idea.
+Use filters to control which ideas are exported, and --fields to limit
+CSV and JSON exports to some of each idea's fields.
+
+--format notion takes no file. It creates a page for each idea in the
+database set by notion.database_id, using the integration token in
+NOTION_TOKEN; the database needs a Score number, a Recommendation select
+and a Patterns multi-select property. Page IDs are remembered, so exporting
+again updates the same pages.
+
+--since-last exports only the ideas created or updated since the last
+--since-last export to the same file or Notion database, for periodic
+syncs. The first run exports every match. Export times are remembered in
+export-state.json beside the database.
+
+Examples:
+  tm bulk export ideas.csv --min-score 7
+  tm bulk export ideas.json --fields id,content,final_score,recommendation
+  tm bulk export ideas.yaml
+  tm bulk export --format notion --min-score 7
+  tm bulk export changes.ndjson --since-last --limit 0`,
+		Args: cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx := getContext()
 			if ctx == nil {
 				return fmt.Errorf("CLI context not initialized")
 			}
 
-			filename := args[0]
+			var filename string
+			if len(args) > 0 {
+				filename = args[0]
+			}
+			switch {
+			case format == FormatNotion && filename != "":
+				return fmt.Errorf("--format notion exports to Notion, not a file")
+			case format != FormatNotion && filename == "":
+				return fmt.Errorf("requires a file to export to")
+			}
 
 			// Auto-detect format from extension if not specified
 			if format == "" {
 				ext := strings.ToLower(filepath.Ext(filename))
-				if ext == ".json" {
+				switch ext {
+				case ".json":
 					format = FormatJSON
-				} else {
+				case ".ndjson", ".jsonl":
+					format = FormatNDJSON
+				case ".xlsx":
+					format = FormatXLSX
+				case ".yaml", ".yml":
+					format = FormatYAML
+				default:
 					format = FormatCSV
 				}
 			}
 
-			// Fetch ideas to export
-			minScorePtr := &minScore
-			limitPtr := &limit
-			ideas, err := ctx.Repository.List(database.ListOptions{
-				Status:   "active",
-				MinScore: minScorePtr,
-				Limit:    limitPtr,
-				OrderBy:  "final_score DESC",
-			})
+			fields, err := export.ParseFields(fieldList)
 			if err != nil {
-				return fmt.Errorf("failed to list ideas: %w", err)
-			}
-
-			// Filter by search if provided
-			if search != "" {
-				ideas = filterBySearch(ideas, search)
+				return err
 			}
-
-			if len(ideas) == 0 {
-				fmt.Println("📭 No ideas match your criteria for export.")
-				return nil
+			if len(fields) > 0 && format != FormatCSV && format != FormatJSON {
+				return fmt.Errorf("--fields only applies to csv and json exports")
 			}
 
-			// Export based on format
-			switch format {
-			case FormatJSON:
-				err = exportJSON(ideas, filename, pretty)
-			case FormatCSV:
-				err = exportCSV(ideas, filename)
-			default:
-				return fmt.Errorf("unsupported format: %s (use 'csv' or 'json')", format)
+			options := database.ListOptions{
+				Status:   "active",
+				MinScore: &minScore,
+				OrderBy:  database.OrderBy(database.SortByFinalScore, database.Descending),
 			}
-
-			if err != nil {
-				return fmt.Errorf("failed to export: %w", err)
+			if limit > 0 {
+				options.Limit = &limit
 			}
 
-			if _, err := cliutil.SuccessColor.Printf("✅ Exported %d ideas to '%s' (%s format)\n",
-				len(ideas), filename, format); err != nil {
-				log.Warn().Err(err).Msg("failed to print success message")
+			if !sinceLast {
+				return exportIdeas(ctx, options, format, filename, search, pretty, fields)
 			}
-			return nil
+			return exportSinceLast(ctx, options, format, filename, search, pretty, fields)
 		},
 	}
 
 	cmd.Flags().Float64Var(&minScore, "min-score", 0, "Minimum score threshold")
 	cmd.Flags().StringVar(&search, "search", "", "Search term to filter ideas")
-	cmd.Flags().IntVar(&limit, "limit", 1000, "Maximum ideas to export")
-	cmd.Flags().StringVar(&format, "format", "", "Output format: csv or json (auto-detected from extension)")
+	cmd.Flags().IntVar(&limit, "limit", 1000, "Maximum ideas to export (0 for no limit)")
+	cmd.Flags().StringVar(&format, "format", "", "Output format: csv, json, ndjson, xlsx, yaml, or notion (auto-detected from extension)")
 	cmd.Flags().BoolVar(&pretty, "pretty", false, "Pretty-print JSON output (only for JSON format)")
+	cmd.Flags().BoolVar(&sinceLast, "since-last", false, "Export only ideas created or updated since the last export to the same file or Notion database")
+	cmd.Flags().StringVar(&fieldList, "fields", "", "Comma-separated fields to export, e.g. id,content,final_score (CSV and JSON only; default: all)")
 
 	return cmd
 }
 
-// exportCSV writes ideas to a CSV file.
-func exportCSV(ideas []*models.Idea, filename string) error {
-	file, err := os.Create(filename)
+// exportIdeas exports the ideas matching options and search to filename,
+// or to Notion
+func exportIdeas(ctx *CLIContext, options database.ListOptions, format, filename, search string, pretty bool, fields []string) error {
+	if format == FormatNDJSON {
+		return exportNDJSONStream(ctx.Repository, options, search, filename)
+	}
+
+	// Fetch ideas to export
+	ideas, err := ctx.Repository.List(options)
 	if err != nil {
-		return fmt.Errorf("create file: %w", err)
+		return fmt.Errorf("failed to list ideas: %w", err)
 	}
-	defer func() {
-		if err := file.Close(); err != nil {
-			log.Warn().Err(err).Msg("failed to close file")
-		}
-	}()
-
-	writer := csv.NewWriter(file)
-	defer writer.Flush()
-
-	// Write header
-	header := []string{
-		"ID",
-		"Content",
-		"RawScore",
-		"FinalScore",
-		"Patterns",
-		"Recommendation",
-		"AnalysisDetails",
-		"CreatedAt",
-		"Status",
-	}
-	if err := writer.Write(header); err != nil {
-		return fmt.Errorf("write header: %w", err)
-	}
-
-	// Write rows
-	for _, idea := range ideas {
-		// Join patterns with semicolon
-		patterns := strings.Join(idea.Patterns, ",")
-
-		row := []string{
-			idea.ID,
-			idea.Content,
-			strconv.FormatFloat(idea.RawScore, 'f', 2, 64),
-			strconv.FormatFloat(idea.FinalScore, 'f', 2, 64),
-			patterns,
-			idea.Recommendation,
-			idea.AnalysisDetails,
-			idea.CreatedAt.Format(time.RFC3339),
-			idea.Status,
+
+	// Filter by search if provided
+	if search != "" {
+		ideas = filterBySearch(ideas, search)
+	}
+
+	if len(ideas) == 0 {
+		fmt.Println("📭 No ideas match your criteria for export.")
+		return nil
+	}
+
+	// Export based on format
+	switch format {
+	case FormatJSON:
+		err = export.ExportJSON(ideas, filename, pretty, fields)
+	case FormatCSV:
+		err = export.ExportCSV(ideas, filename, fields)
+	case FormatXLSX:
+		err = export.ExportXLSX(ideas, filename)
+	case FormatYAML:
+		err = export.ExportYAML(ideas, filename)
+	case FormatNotion:
+		return exportNotion(ctx.Repository, ideas, config.LoadNotionConfig())
+	default:
+		return fmt.Errorf("unsupported format: %s (use 'csv', 'json', 'ndjson', 'xlsx', 'yaml', or 'notion')", format)
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to export: %w", err)
+	}
+
+	if _, err := cliutil.SuccessColor.Printf("✅ Exported %d ideas to '%s' (%s format)\n",
+		len(ideas), filename, format); err != nil {
+		log.Warn().Err(err).Msg("failed to print success message")
+	}
+	return nil
+}
+
+// exportSinceLast exports only the ideas created or updated since the last
+// export to the same destination, then records this export. The record is
+// only advanced once every changed idea was exported: not when the export
+// failed, even part way through, or when --limit left ideas out, so the next
+// run catches them.
+func exportSinceLast(ctx *CLIContext, options database.ListOptions, format, filename, search string, pretty bool, fields []string) error {
+	destination, err := exportDestination(format, filename)
+	if err != nil {
+		return err
+	}
+	state, err := export.LoadState(export.StatePath(ctx.DBPath))
+	if err != nil {
+		return err
+	}
+
+	started := time.Now()
+	if last, ok := state.LastExport(destination); ok {
+		options.UpdatedSince = &last
+		fmt.Printf("🔄 Exporting ideas changed since %s\n", last.Local().Format("2006-01-02 15:04:05"))
+	} else {
+		fmt.Println("🔄 No earlier export to this destination; exporting every matching idea")
+	}
+
+	truncated := false
+	if options.Limit != nil {
+		total, err := ctx.Repository.Count(options)
+		if err != nil {
+			return fmt.Errorf("failed to count ideas: %w", err)
 		}
+		truncated = total > *options.Limit
+	}
 
-		if err := writer.Write(row); err != nil {
-			return fmt.Errorf("write row: %w", err)
+	if err := exportIdeas(ctx, options, format, filename, search, pretty, fields); err != nil {
+		return err
+	}
+
+	if truncated {
+		if _, err := cliutil.WarningColor.Printf("⚠  More than %d ideas changed; run again with --limit 0 to export the rest\n", *options.Limit); err != nil {
+			log.Warn().Err(err).Msg("failed to print warning message")
 		}
+		return nil
 	}
+	return state.Advance(destination, started)
+}
 
-	return writer.Error()
+// exportDestination names where an export goes, so each destination's
+// last export is remembered separately: the file's absolute path, or the
+// Notion database
+func exportDestination(format, filename string) (string, error) {
+	if format == FormatNotion {
+		cfg := config.LoadNotionConfig()
+		if cfg.DatabaseID == "" {
+			return "", fmt.Errorf("no Notion database configured; set one with 'tm config set notion.database_id <id>'")
+		}
+		return "notion:" + cfg.DatabaseID, nil
+	}
+	path, err := filepath.Abs(filename)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve export path: %w", err)
+	}
+	return path, nil
 }
 
-// exportJSON writes ideas to a JSON file.
-func exportJSON(ideas []*models.Idea, filename string, pretty bool) error {
+// exportNDJSONStream streams the ideas matching options and search from the
+// database straight into an NDJSON file
+func exportNDJSONStream(repo *database.Repository, options database.ListOptions, search, filename string) error {
+	stream, streamErr, err := repo.Stream(options)
+	if err != nil {
+		return fmt.Errorf("failed to list ideas: %w", err)
+	}
+	if search != "" {
+		stream = filterStreamBySearch(stream, search)
+	}
+
 	file, err := os.Create(filename)
 	if err != nil {
-		return fmt.Errorf("create file: %w", err)
+		for range stream {
+		}
+		return fmt.Errorf("failed to export: create file: %w", err)
+	}
+
+	count, err := export.ExportNDJSONStream(stream, streamErr, file)
+	if closeErr := file.Close(); err == nil && closeErr != nil {
+		err = fmt.Errorf("close file: %w", closeErr)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to export: %w", err)
 	}
-	defer func() {
-		if err := file.Close(); err != nil {
-			log.Warn().Err(err).Msg("failed to close file")
+
+	if count == 0 {
+		if err := os.Remove(filename); err != nil {
+			log.Warn().Err(err).Msg("failed to remove empty export file")
 		}
-	}()
+		fmt.Println("📭 No ideas match your criteria for export.")
+		return nil
+	}
 
-	encoder := json.NewEncoder(file)
-	if pretty {
-		encoder.SetIndent("", "  ")
+	if _, err := cliutil.SuccessColor.Printf("✅ Exported %d ideas to '%s' (%s format)\n",
+		count, filename, FormatNDJSON); err != nil {
+		log.Warn().Err(err).Msg("failed to print success message")
 	}
+	return nil
+}
 
-	if err := encoder.Encode(ideas); err != nil {
-		return fmt.Errorf("encode json: %w", err)
+// exportNotion syncs ideas to the Notion database in cfg, updating the pages
+// of ideas exported there before, and records each idea's page
+func exportNotion(repo *database.Repository, ideas []*models.Idea, cfg config.NotionConfig) error {
+	if cfg.Token == "" {
+		return fmt.Errorf("NOTION_TOKEN is not set; create an integration at https://www.notion.so/my-integrations and share the database with it")
+	}
+	if cfg.DatabaseID == "" {
+		return fmt.Errorf("no Notion database configured; set one with 'tm config set notion.database_id <id>'")
 	}
 
+	pages, err := repo.NotionPages(cfg.DatabaseID)
+	if err != nil {
+		return fmt.Errorf("failed to load Notion pages: %w", err)
+	}
+
+	err = export.ExportNotion(ideas, export.NotionConfig{
+		Token:      cfg.Token,
+		DatabaseID: cfg.DatabaseID,
+		PageIDs:    pages,
+		OnSynced: func(idea *models.Idea, pageID string) error {
+			return repo.SaveNotionPage(idea.ID, cfg.DatabaseID, pageID)
+		},
+	})
+
+	var partial *export.NotionExportError
+	if errors.As(err, &partial) {
+		if _, err := cliutil.WarningColor.Printf("⚠  Synced %d of %d ideas to Notion\n", partial.Synced, len(ideas)); err != nil {
+			log.Warn().Err(err).Msg("failed to print warning message")
+		}
+		for _, failed := range partial.Failed {
+			fmt.Printf("  - %s: %v\n", shortID(failed.IdeaID), failed.Err)
+		}
+		return fmt.Errorf("%d ideas failed to sync to Notion", len(partial.Failed))
+	}
+	if err != nil {
+		return fmt.Errorf("failed to export: %w", err)
+	}
+
+	if _, err := cliutil.SuccessColor.Printf("✅ Synced %d ideas to Notion\n", len(ideas)); err != nil {
+		log.Warn().Err(err).Msg("failed to print success message")
+	}
 	return nil
 }
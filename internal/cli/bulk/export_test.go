@@ -0,0 +1,142 @@
+//go:build integration
+
+package bulk
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ryacub/telos-idea-matrix/internal/export"
+	"github.com/ryacub/telos-idea-matrix/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportCommand_SinceLastExportsOnlyChanges(t *testing.T) {
+	dir := t.TempDir()
+	repo := newTestRepository(t)
+	ctx := &CLIContext{Repository: repo, DBPath: filepath.Join(dir, "ideas.db")}
+	out := filepath.Join(dir, "changes.json")
+
+	exported := func(args ...string) []string {
+		t.Helper()
+		// Changes are compared to the millisecond, and ones in the same
+		// millisecond as the last export are sent again
+		time.Sleep(2 * time.Millisecond)
+		require.NoError(t, os.RemoveAll(out))
+		cmd := NewExportCommand(func() *CLIContext { return ctx })
+		cmd.SetArgs(append([]string{out, "--since-last"}, args...))
+		require.NoError(t, cmd.Execute())
+
+		data, err := os.ReadFile(out)
+		if os.IsNotExist(err) {
+			return nil
+		}
+		require.NoError(t, err)
+		var ideas []*models.Idea
+		require.NoError(t, json.Unmarshal(data, &ideas))
+		return ideaIDs(ideas)
+	}
+
+	first := models.NewIdea("Automate invoices")
+	require.NoError(t, repo.Create(first))
+	second := models.NewIdea("Start a podcast")
+	require.NoError(t, repo.Create(second))
+
+	assert.ElementsMatch(t, []string{first.ID, second.ID}, exported(), "the first export sends everything")
+	assert.Empty(t, exported(), "nothing changed since")
+
+	first.Notes = "talked to the accountant"
+	require.NoError(t, repo.Update(first))
+	third := models.NewIdea("Write a Go book")
+	require.NoError(t, repo.Create(third))
+
+	// A limit that leaves changes out doesn't advance the last export
+	assert.Len(t, exported("--limit", "1"), 1)
+	assert.ElementsMatch(t, []string{first.ID, third.ID}, exported())
+	assert.Empty(t, exported())
+
+	state, err := export.LoadState(export.StatePath(ctx.DBPath))
+	require.NoError(t, err)
+	_, ok := state.LastExport(out)
+	assert.True(t, ok, "exports are remembered by absolute path")
+}
+
+func TestExportCommand_SinceLastKeepsStateWhenExportFails(t *testing.T) {
+	dir := t.TempDir()
+	repo := newTestRepository(t)
+	ctx := &CLIContext{Repository: repo, DBPath: filepath.Join(dir, "ideas.db")}
+	out := filepath.Join(dir, "changes.ndjson")
+
+	runExport := func() error {
+		cmd := NewExportCommand(func() *CLIContext { return ctx })
+		cmd.SetArgs([]string{out, "--since-last"})
+		cmd.SilenceUsage = true
+		return cmd.Execute()
+	}
+
+	first := models.NewIdea("Automate invoices")
+	require.NoError(t, repo.Create(first))
+	second := models.NewIdea("Start a podcast")
+	require.NoError(t, repo.Create(second))
+	_, err := repo.DB().Exec("UPDATE ideas SET patterns = 'not json' WHERE id = ?", second.ID)
+	require.NoError(t, err)
+
+	// The stream stops at the bad row, so the export is incomplete
+	require.Error(t, runExport())
+	state, err := export.LoadState(export.StatePath(ctx.DBPath))
+	require.NoError(t, err)
+	_, ok := state.LastExport(out)
+	assert.False(t, ok, "a failed export must not be recorded")
+
+	_, err = repo.DB().Exec("UPDATE ideas SET patterns = '[]' WHERE id = ?", second.ID)
+	require.NoError(t, err)
+	require.NoError(t, runExport())
+
+	data, err := os.ReadFile(out)
+	require.NoError(t, err)
+	assert.Equal(t, 2, strings.Count(string(data), "\n"), "the next run exports both ideas")
+}
+
+func TestExportCommand_SinceLastIncludesImportedOldIdeas(t *testing.T) {
+	dir := t.TempDir()
+	repo := newTestRepository(t)
+	ctx := &CLIContext{Repository: repo, DBPath: filepath.Join(dir, "ideas.db")}
+	getContext := func() *CLIContext { return ctx }
+	out := filepath.Join(dir, "changes.json")
+
+	runExport := func() {
+		t.Helper()
+		time.Sleep(2 * time.Millisecond)
+		cmd := NewExportCommand(getContext)
+		cmd.SetArgs([]string{out, "--since-last"})
+		require.NoError(t, cmd.Execute())
+	}
+
+	require.NoError(t, repo.Create(models.NewIdea("Automate invoices")))
+	runExport()
+
+	// An idea from an older export, created long before the last export
+	path := filepath.Join(dir, "old.csv")
+	csv := "ID,Content,RawScore,FinalScore,Patterns,Recommendation,AnalysisDetails,CreatedAt,Status\n" +
+		"3f1c2d4e-0000-4000-8000-000000000001,Start a podcast,0,3.5,,,,2024-01-01T00:00:00Z,active\n"
+	require.NoError(t, os.WriteFile(path, []byte(csv), 0o600))
+	time.Sleep(2 * time.Millisecond)
+	cmd := NewImportCommand(getContext)
+	cmd.SetArgs([]string{path, "--yes"})
+	require.NoError(t, cmd.Execute())
+
+	require.NoError(t, os.RemoveAll(out))
+	runExport()
+	data, err := os.ReadFile(out)
+	require.NoError(t, err)
+	var ideas []*models.Idea
+	require.NoError(t, json.Unmarshal(data, &ideas))
+	require.Len(t, ideas, 1, "the imported idea counts as changed")
+	assert.Equal(t, "Start a podcast", ideas[0].Content)
+	assert.Equal(t, 2024, ideas[0].CreatedAt.Year(), "the import keeps its creation time")
+}
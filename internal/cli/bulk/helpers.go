@@ -53,13 +53,7 @@ func filterBySearch(ideas []*models.Idea, searchTerm string) []*models.Idea {
 	filtered := make([]*models.Idea, 0, len(ideas)/4)
 
 	for _, idea := range ideas {
-		contentLower := strings.ToLower(idea.Content)
-		recommendationLower := strings.ToLower(idea.Recommendation)
-		analysisLower := strings.ToLower(idea.AnalysisDetails)
-
-		if strings.Contains(contentLower, searchLower) ||
-			strings.Contains(recommendationLower, searchLower) ||
-			strings.Contains(analysisLower, searchLower) {
+		if matchesSearch(idea, searchLower) {
 			filtered = append(filtered, idea)
 		}
 	}
@@ -67,6 +61,37 @@ func filterBySearch(ideas []*models.Idea, searchTerm string) []*models.Idea {
 	return filtered
 }
 
+// filterStreamBySearch passes on the ideas from in that filterBySearch would keep
+func filterStreamBySearch(in <-chan *models.Idea, searchTerm string) <-chan *models.Idea {
+	searchLower := strings.ToLower(searchTerm)
+	out := make(chan *models.Idea)
+
+	go func() {
+		defer close(out)
+		for idea := range in {
+			if matchesSearch(idea, searchLower) {
+				out <- idea
+			}
+		}
+	}()
+
+	return out
+}
+
+// matchesSearch reports whether an idea's content, recommendation, or
+// analysis contains searchLower, which must already be lower case
+func matchesSearch(idea *models.Idea, searchLower string) bool {
+	return strings.Contains(strings.ToLower(idea.Content), searchLower) ||
+		strings.Contains(strings.ToLower(idea.Recommendation), searchLower) ||
+		strings.Contains(strings.ToLower(idea.AnalysisDetails), searchLower)
+}
+
+// cutoffBefore returns the creation time cutoff for ideas older than age
+func cutoffBefore(age time.Duration) *time.Time {
+	cutoff := time.Now().UTC().Add(-age)
+	return &cutoff
+}
+
 // filterByAge filters ideas created before the given cutoff date
 func filterByAge(ideas []*models.Idea, cutoffDate time.Time) []*models.Idea {
 	filtered := make([]*models.Idea, 0, len(ideas)/2)
@@ -80,9 +105,10 @@ func filterByAge(ideas []*models.Idea, cutoffDate time.Time) []*models.Idea {
 	return filtered
 }
 
-// updateOptions defines the options for bulk update operations
-type updateOptions struct {
+// UpdateOptions defines the options for bulk update operations
+type UpdateOptions struct {
 	SetStatus      string
+	ArchiveReason  string // Recorded when SetStatus is "archived"
 	AddPatterns    []string
 	RemovePatterns []string
 	AddTags        []string
@@ -90,12 +116,19 @@ type updateOptions struct {
 }
 
 // applyUpdates applies bulk updates to an idea and returns whether it was modified
-func applyUpdates(idea *models.Idea, opts updateOptions) bool {
+func applyUpdates(idea *models.Idea, opts UpdateOptions) bool {
 	modified := false
 
 	// Apply status change
 	if opts.SetStatus != "" && idea.Status != opts.SetStatus {
-		idea.Status = opts.SetStatus
+		switch opts.SetStatus {
+		case string(models.StatusArchived):
+			idea.Archive(opts.ArchiveReason)
+		case string(models.StatusActive):
+			idea.Restore()
+		default:
+			idea.Status = opts.SetStatus
+		}
 		modified = true
 	}
 
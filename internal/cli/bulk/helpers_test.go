@@ -0,0 +1,147 @@
+//go:build integration
+
+package bulk
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ryacub/telos-idea-matrix/internal/database"
+	"github.com/ryacub/telos-idea-matrix/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestRepository(t *testing.T) *database.Repository {
+	t.Helper()
+
+	repo, err := database.NewRepository(filepath.Join(t.TempDir(), "test.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = repo.Close() })
+	return repo
+}
+
+func ideaIDs(ideas []*models.Idea) []string {
+	ids := make([]string, len(ideas))
+	for i, idea := range ideas {
+		ids[i] = idea.ID
+	}
+	return ids
+}
+
+// TestCreatedBefore_MatchesFilterByAge proves filtering by age in SQL selects
+// the same ideas as fetching everything and filtering in memory
+func TestCreatedBefore_MatchesFilterByAge(t *testing.T) {
+	repo := newTestRepository(t)
+	now := time.Now().UTC()
+
+	for _, daysAgo := range []int{90, 45, 31, 29, 7, 0} {
+		idea := models.NewIdea("idea from days ago")
+		idea.FinalScore = float64(daysAgo%10) + 0.5
+		idea.CreatedAt = now.AddDate(0, 0, -daysAgo)
+		require.NoError(t, repo.Create(idea))
+	}
+
+	for _, olderThan := range []time.Duration{0, 24 * time.Hour, 30 * 24 * time.Hour, 60 * 24 * time.Hour, 365 * 24 * time.Hour} {
+		cutoff := cutoffBefore(olderThan)
+		maxScore := 8.0
+
+		all, err := repo.List(database.ListOptions{
+			Status:   "active",
+			MaxScore: &maxScore,
+			OrderBy:  database.OrderBy(database.SortByCreatedAt, database.Ascending),
+		})
+		require.NoError(t, err)
+		inMemory := filterByAge(all, *cutoff)
+
+		inSQL, err := repo.List(database.ListOptions{
+			Status:        "active",
+			MaxScore:      &maxScore,
+			CreatedBefore: cutoff,
+			OrderBy:       database.OrderBy(database.SortByCreatedAt, database.Ascending),
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, ideaIDs(inMemory), ideaIDs(inSQL), "older than %s", olderThan)
+	}
+}
+
+// TestCreatedBefore_LimitAppliesAfterAgeFilter tests that the safety limit no
+// longer hides old ideas behind newer ones
+func TestCreatedBefore_LimitAppliesAfterAgeFilter(t *testing.T) {
+	repo := newTestRepository(t)
+	now := time.Now().UTC()
+
+	old := models.NewIdea("old idea")
+	old.CreatedAt = now.AddDate(0, 0, -60)
+	require.NoError(t, repo.Create(old))
+
+	for i := 0; i < 3; i++ {
+		recent := models.NewIdea("recent idea")
+		recent.CreatedAt = now.Add(-time.Duration(i) * time.Hour)
+		require.NoError(t, repo.Create(recent))
+	}
+
+	limit := 2
+	ideas, err := repo.List(database.ListOptions{
+		Status:        "active",
+		CreatedBefore: cutoffBefore(30 * 24 * time.Hour),
+		Limit:         &limit,
+		OrderBy:       database.OrderBy(database.SortByCreatedAt, database.Descending),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{old.ID}, ideaIDs(ideas))
+}
+
+// TestExportNDJSONStream_AppliesSearchAndLimit tests that the streamed export
+// keeps the same filters as the other formats
+func TestExportNDJSONStream_AppliesSearchAndLimit(t *testing.T) {
+	repo := newTestRepository(t)
+
+	for i, content := range []string{"Write a book", "Build a bookshelf", "Learn piano", "Review book notes"} {
+		idea := models.NewIdea(content)
+		idea.FinalScore = float64(i + 1)
+		require.NoError(t, repo.Create(idea))
+	}
+
+	limit := 3
+	options := database.ListOptions{
+		Status:  "active",
+		Limit:   &limit,
+		OrderBy: database.OrderBy(database.SortByFinalScore, database.Descending),
+	}
+	path := filepath.Join(t.TempDir(), "ideas.ndjson")
+	require.NoError(t, exportNDJSONStream(repo, options, "BOOK", path))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	require.Len(t, lines, 2)
+	assert.Contains(t, lines[0], "Review book notes")
+	assert.Contains(t, lines[1], "Build a bookshelf")
+
+	empty := filepath.Join(t.TempDir(), "none.ndjson")
+	require.NoError(t, exportNDJSONStream(repo, options, "violin", empty))
+	assert.NoFileExists(t, empty, "an export with no matches should not leave an empty file")
+}
+
+// TestExportNDJSONStream_FailsOnBadRow tests that a stream cut short by a
+// row that fails to scan fails the export instead of reporting success
+func TestExportNDJSONStream_FailsOnBadRow(t *testing.T) {
+	repo := newTestRepository(t)
+
+	good := models.NewIdea("Write a book")
+	require.NoError(t, repo.Create(good))
+	bad := models.NewIdea("Build a bookshelf")
+	require.NoError(t, repo.Create(bad))
+	_, err := repo.DB().Exec("UPDATE ideas SET patterns = 'not json' WHERE id = ?", bad.ID)
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "ideas.ndjson")
+	err = exportNDJSONStream(repo, database.ListOptions{}, "", path)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to parse patterns")
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/csv"
 	"fmt"
 	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
 	"time"
@@ -11,6 +12,8 @@ import (
 	"github.com/fatih/color"
 	"github.com/rs/zerolog/log"
 	"github.com/ryacub/telos-idea-matrix/internal/cliutil"
+	"github.com/ryacub/telos-idea-matrix/internal/database"
+	"github.com/ryacub/telos-idea-matrix/internal/export"
 	"github.com/ryacub/telos-idea-matrix/internal/models"
 	"github.com/spf13/cobra"
 )
@@ -18,13 +21,27 @@ import (
 // NewImportCommand creates the bulk import command
 func NewImportCommand(getContext func() *CLIContext) *cobra.Command {
 	var yes bool
+	var skipDuplicates bool
+	var updateDuplicates bool
+	var format string
 
 	cmd := &cobra.Command{
 		Use:   "import <file>",
-		Short: "Import ideas from CSV",
-		Long: `Import ideas from a CSV file.
+		Short: "Import ideas from CSV or YAML",
+		Long: `Import ideas from a CSV or YAML file.
 The CSV file should have the following columns:
-ID,Content,RawScore,FinalScore,Patterns,Recommendation,AnalysisDetails,CreatedAt,Status`,
+ID,Content,RawScore,FinalScore,Patterns,Recommendation,AnalysisDetails,CreatedAt,Status
+and optionally Notes, as written by 'tm bulk export ideas.csv'.
+
+A YAML file holds a list of ideas with the keys of a JSON export, as
+written by 'tm bulk export ideas.yaml'. It may contain several documents
+separated by "---", each a list of ideas or a single idea. Only content
+is required; a missing id, created_at or status gets a new idea's default.
+
+Duplicate detection compares content after lowercasing and collapsing
+whitespace, ignoring ideas in the trash:
+  --skip-duplicates     Skip ideas whose content already exists
+  --update-duplicates   Overwrite the existing idea's analysis instead of skipping`,
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx := getContext()
@@ -32,16 +49,38 @@ ID,Content,RawScore,FinalScore,Patterns,Recommendation,AnalysisDetails,CreatedAt
 				return fmt.Errorf("CLI context not initialized")
 			}
 
+			if skipDuplicates && updateDuplicates {
+				return fmt.Errorf("--skip-duplicates and --update-duplicates cannot be used together")
+			}
+
 			filename := args[0]
 
-			// Import from CSV
-			ideas, err := importCSV(filename)
+			// Auto-detect format from extension if not specified
+			if format == "" {
+				switch strings.ToLower(filepath.Ext(filename)) {
+				case ".yaml", ".yml":
+					format = FormatYAML
+				default:
+					format = FormatCSV
+				}
+			}
+
+			var ideas []*models.Idea
+			var err error
+			switch format {
+			case FormatCSV:
+				ideas, err = importCSV(filename)
+			case FormatYAML:
+				ideas, err = export.ImportYAML(filename)
+			default:
+				return fmt.Errorf("unsupported format: %s (use 'csv' or 'yaml')", format)
+			}
 			if err != nil {
-				return fmt.Errorf("failed to import CSV: %w", err)
+				return fmt.Errorf("failed to import %s: %w", strings.ToUpper(format), err)
 			}
 
 			if len(ideas) == 0 {
-				fmt.Println("📭 No ideas found in CSV file.")
+				fmt.Printf("📭 No ideas found in %s file.\n", strings.ToUpper(format))
 				return nil
 			}
 
@@ -67,30 +106,67 @@ ID,Content,RawScore,FinalScore,Patterns,Recommendation,AnalysisDetails,CreatedAt
 			// Import ideas
 			successCount := 0
 			errorCount := 0
-			for i, idea := range ideas {
+			skippedCount := 0
+			updatedCount := 0
+			bar := cliutil.NewProgressBar(len(ideas), "Importing")
+			importIdea := func(idea *models.Idea) {
 				// Validate idea before import
 				if err := idea.Validate(); err != nil {
+					bar.Clear()
 					if _, printErr := cliutil.WarningColor.Printf("⚠  Skipping invalid idea: %v\n", err); printErr != nil {
 						log.Warn().Err(printErr).Msg("failed to print warning")
 					}
 					errorCount++
-					continue
+					return
+				}
+
+				if skipDuplicates || updateDuplicates {
+					existing, err := ctx.Repository.FindByContentHash(models.ContentHash(idea.Content))
+					if err != nil && !database.IsNotFound(err) {
+						bar.Clear()
+						if _, printErr := cliutil.WarningColor.Printf("⚠  Failed to check for duplicate: %v\n", err); printErr != nil {
+							log.Warn().Err(printErr).Msg("failed to print error message")
+						}
+						errorCount++
+						return
+					}
+
+					if existing != nil {
+						if skipDuplicates {
+							skippedCount++
+							return
+						}
+
+						copyAnalysis(existing, idea)
+						if err := ctx.Repository.Update(existing); err != nil {
+							bar.Clear()
+							if _, printErr := cliutil.WarningColor.Printf("⚠  Failed to update duplicate idea: %v\n", err); printErr != nil {
+								log.Warn().Err(printErr).Msg("failed to print error message")
+							}
+							errorCount++
+							return
+						}
+						updatedCount++
+						return
+					}
 				}
 
 				if err := ctx.Repository.Create(idea); err != nil {
+					bar.Clear()
 					if _, printErr := cliutil.WarningColor.Printf("⚠  Failed to import idea: %v\n", err); printErr != nil {
 						log.Warn().Err(printErr).Msg("failed to print error message")
 					}
 					errorCount++
-					continue
+					return
 				}
 				successCount++
-
-				// Show progress for large batches
-				if len(ideas) > 10 && (i+1)%10 == 0 {
-					fmt.Printf("  Progress: %d/%d imported\n", i+1, len(ideas))
-				}
+				ctx.Notifier.Notify(idea)
+			}
+			for _, idea := range ideas {
+				importIdea(idea)
+				bar.Increment()
 			}
+			bar.Finish()
 
 			if errorCount > 0 {
 				if _, err := cliutil.WarningColor.Printf("⚠  %d ideas failed to import\n", errorCount); err != nil {
@@ -101,15 +177,33 @@ ID,Content,RawScore,FinalScore,Patterns,Recommendation,AnalysisDetails,CreatedAt
 			if _, err := cliutil.SuccessColor.Printf("✅ Imported %d ideas from '%s'\n", successCount, filename); err != nil {
 				log.Warn().Err(err).Msg("failed to print success message")
 			}
+			if skipDuplicates {
+				fmt.Printf("   Skipped %d duplicate ideas\n", skippedCount)
+			}
+			if updateDuplicates {
+				fmt.Printf("   Updated %d duplicate ideas\n", updatedCount)
+			}
 			return nil
 		},
 	}
 
 	cmd.Flags().BoolVar(&yes, "yes", false, "Auto-confirm (skip confirmation prompt)")
+	cmd.Flags().BoolVar(&skipDuplicates, "skip-duplicates", false, "Skip ideas whose content already exists")
+	cmd.Flags().BoolVar(&updateDuplicates, "update-duplicates", false, "Overwrite analysis of existing duplicate ideas")
+	cmd.Flags().StringVar(&format, "format", "", "Input format: csv or yaml (auto-detected from extension)")
 
 	return cmd
 }
 
+// copyAnalysis overwrites the scoring and analysis fields of dst with those from src.
+func copyAnalysis(dst, src *models.Idea) {
+	dst.RawScore = src.RawScore
+	dst.FinalScore = src.FinalScore
+	dst.Patterns = src.Patterns
+	dst.Recommendation = src.Recommendation
+	dst.AnalysisDetails = src.AnalysisDetails
+}
+
 // importCSV reads ideas from a CSV file.
 func importCSV(filename string) ([]*models.Idea, error) {
 	file, err := os.Open(filename)
@@ -173,6 +267,9 @@ func importCSV(filename string) ([]*models.Idea, error) {
 			CreatedAt:       createdAt,
 			Status:          record[8],
 		}
+		if len(record) > 9 {
+			idea.Notes = record[9]
+		}
 
 		ideas = append(ideas, idea)
 	}
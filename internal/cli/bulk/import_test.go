@@ -0,0 +1,78 @@
+//go:build integration
+
+package bulk
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ryacub/telos-idea-matrix/internal/database"
+	"github.com/ryacub/telos-idea-matrix/internal/export"
+	"github.com/ryacub/telos-idea-matrix/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImportCSV_RoundTripsExportWithNotes(t *testing.T) {
+	idea := models.NewIdea("Automate invoices")
+	idea.FinalScore = 7.5
+	idea.Notes = "started prototyping 2025-06\nshowed it to the accountant"
+
+	path := filepath.Join(t.TempDir(), "ideas.csv")
+	require.NoError(t, export.ExportCSV([]*models.Idea{idea}, path, nil))
+
+	ideas, err := importCSV(path)
+	require.NoError(t, err)
+	require.Len(t, ideas, 1)
+	assert.Equal(t, idea.ID, ideas[0].ID)
+	assert.Equal(t, 7.5, ideas[0].FinalScore)
+	assert.Equal(t, idea.Notes, ideas[0].Notes)
+}
+
+func TestImportCSV_NotesColumnIsOptional(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ideas.csv")
+	csv := "ID,Content,RawScore,FinalScore,Patterns,Recommendation,AnalysisDetails,CreatedAt,Status\n" +
+		"abc,Start a podcast,0,3.5,,,,2024-01-01T00:00:00Z,active\n"
+	require.NoError(t, os.WriteFile(path, []byte(csv), 0o600))
+
+	ideas, err := importCSV(path)
+	require.NoError(t, err)
+	require.Len(t, ideas, 1)
+	assert.Equal(t, "Start a podcast", ideas[0].Content)
+	assert.Empty(t, ideas[0].Notes)
+}
+
+func TestImportCommand_TrashedDuplicateIsImported(t *testing.T) {
+	dir := t.TempDir()
+	repo := newTestRepository(t)
+	ctx := &CLIContext{Repository: repo, DBPath: filepath.Join(dir, "ideas.db")}
+
+	trashed := models.NewIdea("Start a podcast")
+	trashed.FinalScore = 2.0
+	require.NoError(t, repo.Create(trashed))
+	trashed.Trash()
+	require.NoError(t, repo.Update(trashed))
+
+	path := filepath.Join(dir, "ideas.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("- content: start a   PODCAST\n  final_score: 6.5\n"), 0o600))
+
+	for _, flag := range []string{"--skip-duplicates", "--update-duplicates"} {
+		cmd := NewImportCommand(func() *CLIContext { return ctx })
+		cmd.SetArgs([]string{path, "--yes", flag})
+		require.NoError(t, cmd.Execute())
+	}
+
+	// Skipping imported the idea, since its only match was in the trash, and
+	// updating then found that active copy rather than the trashed one
+	active, err := repo.List(database.ListOptions{})
+	require.NoError(t, err)
+	require.Len(t, active, 1)
+	assert.NotEqual(t, trashed.ID, active[0].ID)
+	assert.Equal(t, 6.5, active[0].FinalScore)
+
+	got, err := repo.GetByID(trashed.ID)
+	require.NoError(t, err)
+	assert.Equal(t, string(models.StatusDeleted), got.Status)
+	assert.Equal(t, 2.0, got.FinalScore, "the trashed idea is left alone")
+}
@@ -0,0 +1,140 @@
+package bulk
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/rs/zerolog/log"
+	"github.com/ryacub/telos-idea-matrix/internal/cliutil"
+	"github.com/ryacub/telos-idea-matrix/internal/database"
+	"github.com/ryacub/telos-idea-matrix/internal/models"
+	"github.com/spf13/cobra"
+)
+
+// NewRestoreCommand creates the bulk restore command
+func NewRestoreCommand(getContext func() *CLIContext) *cobra.Command {
+	var newerThan int
+	var minScore float64
+	var search string
+	var limit int
+	var yes bool
+	var dryRun bool
+
+	cmd := &cobra.Command{
+		Use:   "restore",
+		Short: "Make multiple archived ideas active again",
+		Long: `Restore archived ideas to active, undoing a bulk archive.
+Use --newer-than to restore ideas captured in the last N days.
+Use --min-score to restore only ideas scoring at least a threshold.
+The archive reason is cleared from each restored idea.
+
+Trashed ideas are restored with 'tm trash restore' instead.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := getContext()
+			if ctx == nil {
+				return fmt.Errorf("CLI context not initialized")
+			}
+			if newerThan < 0 {
+				return fmt.Errorf("--newer-than must not be negative")
+			}
+
+			ideas, err := listArchivedIdeas(ctx.Repository, newerThan, minScore, search, limit)
+			if err != nil {
+				return err
+			}
+
+			if len(ideas) == 0 {
+				fmt.Println("📭 No archived ideas match your criteria for restoring.")
+				return nil
+			}
+
+			// Show preview
+			fmt.Printf("♻️  Found %s archived ideas to restore:\n", color.CyanString("%d", len(ideas)))
+			for i, idea := range ideas {
+				if i < 5 {
+					age := time.Since(idea.CreatedAt).Hours() / 24
+					fmt.Printf("  - %s (score: %.1f, age: %.0f days)\n",
+						cliutil.TruncateText(idea.Content, 50),
+						idea.FinalScore,
+						age)
+				}
+			}
+			if len(ideas) > 5 {
+				fmt.Printf("  ... and %d more\n", len(ideas)-5)
+			}
+
+			if dryRun {
+				if _, err := cliutil.InfoColor.Println("\n🔍 DRY RUN - No changes will be made"); err != nil {
+					log.Warn().Err(err).Msg("failed to print message")
+				}
+				return nil
+			}
+
+			// Confirm
+			if !yes && !cliutil.Confirm("Proceed with restoring?") {
+				fmt.Println("❌ Cancelled")
+				return nil
+			}
+
+			// Restore ideas
+			bar := cliutil.NewProgressBar(len(ideas), "Restoring")
+			result := UpdateIdeas(ctx.Repository, ideas, UpdateOptions{
+				SetStatus: string(models.StatusActive),
+			}, barProgress(bar))
+			bar.Finish()
+			for _, errMsg := range result.Errors {
+				if _, err := cliutil.WarningColor.Printf("⚠  Failed to restore idea %s\n", errMsg); err != nil {
+					log.Warn().Err(err).Msg("failed to print error message")
+				}
+			}
+
+			if result.Failed > 0 {
+				if _, err := cliutil.WarningColor.Printf("⚠  %d ideas failed to restore\n", result.Failed); err != nil {
+					log.Warn().Err(err).Msg("failed to print warning message")
+				}
+			}
+
+			if _, err := cliutil.SuccessColor.Printf("✅ Restored %d ideas\n", result.Succeeded); err != nil {
+				log.Warn().Err(err).Msg("failed to print success message")
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().IntVar(&newerThan, "newer-than", 0, "Restore ideas created in the last N days")
+	cmd.Flags().Float64Var(&minScore, "min-score", 0, "Minimum score threshold")
+	cmd.Flags().StringVar(&search, "search", "", "Search term to filter ideas")
+	cmd.Flags().IntVar(&limit, "limit", 100, "Maximum ideas to process")
+	cmd.Flags().BoolVar(&yes, "yes", false, "Auto-confirm (skip confirmation prompt)")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show what would be restored without making changes")
+
+	return cmd
+}
+
+// listArchivedIdeas returns the archived ideas matching the restore filters,
+// newest first. Zero values leave a filter off.
+func listArchivedIdeas(repo *database.Repository, newerThan int, minScore float64, search string, limit int) ([]*models.Idea, error) {
+	opts := database.ListOptions{
+		Status:  string(models.StatusArchived),
+		Limit:   &limit,
+		OrderBy: database.OrderBy(database.SortByCreatedAt, database.Descending),
+	}
+	if minScore != 0 {
+		opts.MinScore = &minScore
+	}
+	// Filter by age in SQL so the limit applies to matching ideas
+	if newerThan > 0 {
+		opts.CreatedAfter = cutoffBefore(time.Duration(newerThan) * 24 * time.Hour)
+	}
+
+	ideas, err := repo.List(opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list ideas: %w", err)
+	}
+
+	if search != "" {
+		ideas = filterBySearch(ideas, search)
+	}
+	return ideas, nil
+}
@@ -41,7 +41,7 @@ Use --min-score, --search, and --limit to control which ideas are tagged.`,
 				Status:   "active",
 				MinScore: minScorePtr,
 				Limit:    limitPtr,
-				OrderBy:  "final_score DESC",
+				OrderBy:  database.OrderBy(database.SortByFinalScore, database.Descending),
 			})
 			if err != nil {
 				return fmt.Errorf("failed to list ideas: %w", err)
@@ -81,26 +81,27 @@ Use --min-score, --search, and --limit to control which ideas are tagged.`,
 			// Apply tags (placeholder - would need tags table)
 			successCount := 0
 			errorCount := 0
-			for i, idea := range ideas {
+			bar := cliutil.NewProgressBar(len(ideas), "Tagging")
+			for _, idea := range ideas {
 				// In a real implementation, we would add tags to a tags table
 				// For now, we'll append to analysis details as a workaround
+				var err error
 				if !strings.Contains(idea.AnalysisDetails, tagName) {
 					idea.AnalysisDetails = fmt.Sprintf("%s [tag:%s]", idea.AnalysisDetails, tagName)
-					if err := ctx.Repository.Update(idea); err != nil {
-						if _, printErr := cliutil.WarningColor.Printf("⚠  Failed to tag idea %s: %v\n", idea.ID, err); printErr != nil {
-							log.Warn().Err(printErr).Msg("failed to print error message")
-						}
-						errorCount++
-						continue
-					}
+					err = ctx.Repository.Update(idea)
 				}
-				successCount++
-
-				// Show progress for large batches
-				if len(ideas) > 10 && (i+1)%10 == 0 {
-					fmt.Printf("  Progress: %d/%d tagged\n", i+1, len(ideas))
+				if err != nil {
+					bar.Clear()
+					if _, printErr := cliutil.WarningColor.Printf("⚠  Failed to tag idea %s: %v\n", idea.ID, err); printErr != nil {
+						log.Warn().Err(printErr).Msg("failed to print error message")
+					}
+					errorCount++
+				} else {
+					successCount++
 				}
+				bar.Increment()
 			}
+			bar.Finish()
 
 			if errorCount > 0 {
 				if _, err := cliutil.WarningColor.Printf("⚠  %d ideas failed to tag\n", errorCount); err != nil {
@@ -134,7 +134,7 @@ func runBulkUpdate(getContext func() *CLIContext, opts bulkUpdateOptions) error
 		MinScore: minScorePtr,
 		MaxScore: maxScorePtr,
 		Limit:    limitPtr,
-		OrderBy:  "final_score DESC",
+		OrderBy:  database.OrderBy(database.SortByFinalScore, database.Descending),
 	})
 	if err != nil {
 		return fmt.Errorf("failed to find ideas: %w", err)
@@ -228,51 +228,26 @@ func runBulkUpdate(getContext func() *CLIContext, opts bulkUpdateOptions) error
 	}
 
 	// Apply updates
-	updated := 0
-	unchanged := 0
-	failed := 0
-	errors := make([]string, 0)
-
-	updateOpts := updateOptions{
+	bar := cliutil.NewProgressBar(len(ideas), "Updating")
+	result := UpdateIdeas(ctx.Repository, ideas, UpdateOptions{
 		SetStatus:      opts.setStatus,
 		AddPatterns:    opts.addPatterns,
 		RemovePatterns: opts.removePatterns,
 		AddTags:        opts.addTags,
 		RemoveTags:     opts.removeTags,
-	}
-
-	for i, idea := range ideas {
-		// Apply updates using service
-		modified := applyUpdates(idea, updateOpts)
-
-		// Only save if something actually changed
-		if modified {
-			if err := ctx.Repository.Update(idea); err != nil {
-				failed++
-				errors = append(errors, fmt.Sprintf("%s: %v", idea.ID[:8], err))
-				continue
-			}
-			updated++
-		} else {
-			unchanged++
-		}
-
-		// Show progress for large batches
-		if len(ideas) > 10 && (i+1)%10 == 0 {
-			fmt.Printf("  Progress: %d/%d processed\n", i+1, len(ideas))
-		}
-	}
+	}, barProgress(bar))
+	bar.Finish()
 
 	fmt.Printf("\n%s Update complete:\n", cliutil.SuccessColor.Sprint("✅"))
-	fmt.Printf("  ✓ Updated: %s\n", color.GreenString("%d", updated))
-	if unchanged > 0 {
-		fmt.Printf("  - Unchanged: %s (no modifications needed)\n", color.CyanString("%d", unchanged))
+	fmt.Printf("  ✓ Updated: %s\n", color.GreenString("%d", result.Succeeded))
+	if result.Unchanged > 0 {
+		fmt.Printf("  - Unchanged: %s (no modifications needed)\n", color.CyanString("%d", result.Unchanged))
 	}
-	if failed > 0 {
-		fmt.Printf("  ✗ Failed: %s\n", cliutil.ErrorColor.Sprint(failed))
-		if len(errors) > 0 && len(errors) <= 10 {
+	if result.Failed > 0 {
+		fmt.Printf("  ✗ Failed: %s\n", cliutil.ErrorColor.Sprint(result.Failed))
+		if len(result.Errors) > 0 && len(result.Errors) <= 10 {
 			fmt.Println("\nErrors:")
-			for _, errMsg := range errors {
+			for _, errMsg := range result.Errors {
 				fmt.Printf("  - %s\n", errMsg)
 			}
 		}
@@ -1,11 +1,23 @@
 package cli
 
 import (
+	"fmt"
 	"os"
+	"strings"
 
+	"github.com/ryacub/telos-idea-matrix/internal/cliutil"
+	"github.com/ryacub/telos-idea-matrix/internal/config"
+	"github.com/ryacub/telos-idea-matrix/internal/database"
+	"github.com/ryacub/telos-idea-matrix/internal/profile"
 	"github.com/spf13/cobra"
 )
 
+// completionIdeaLimit caps how many recent ideas are offered as ID completions
+const completionIdeaLimit = 50
+
+// shortIDLength is how much of an ID is completed; commands accept ID prefixes
+const shortIDLength = 8
+
 func newCompletionCommand() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "completion [bash|zsh|fish|powershell]",
@@ -32,6 +44,11 @@ Fish:
 
 PowerShell:
   PS> tm completion powershell | Out-String | Invoke-Expression
+
+Idea IDs (e.g. 'tm show <TAB>') complete from your most recent ideas,
+which needs read access to the ideas database. If it can't be opened,
+IDs simply aren't suggested. --provider values complete from the LLM
+providers that are currently configured.
 `,
 		DisableFlagsInUseLine: true,
 		ValidArgs:             []string{"bash", "zsh", "fish", "powershell"},
@@ -58,3 +75,92 @@ PowerShell:
 
 	return cmd
 }
+
+// completeIdeaIDs returns a ValidArgsFunction that completes the first n
+// arguments with the IDs of recent ideas in status, or outside the trash when
+// status is empty. Completion runs without initializeCLI, so the database is
+// opened directly; when that fails nothing is suggested.
+func completeIdeaIDs(n int, status string) func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) >= n {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+
+		repo, closeRepo, err := completionRepository()
+		if err != nil {
+			cobra.CompDebugln(err.Error(), true)
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		defer closeRepo()
+
+		limit := completionIdeaLimit
+		ideas, err := repo.List(database.ListOptions{
+			Status:  status,
+			OrderBy: database.OrderBy(database.SortByCreatedAt, database.Descending),
+			Limit:   &limit,
+		})
+		if err != nil {
+			cobra.CompDebugln(err.Error(), true)
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+
+		var completions []string
+		for _, idea := range ideas {
+			if !strings.HasPrefix(idea.ID, toComplete) {
+				continue
+			}
+			id := idea.ID
+			if len(toComplete) < shortIDLength && len(id) > shortIDLength {
+				id = id[:shortIDLength]
+			}
+			// Descriptions end at the first tab or newline in completion output
+			content := strings.Join(strings.Fields(idea.Content), " ")
+			completions = append(completions, id+"\t"+cliutil.TruncateText(content, 50))
+		}
+		return completions, cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// completeProviders completes --provider values with the registered LLM providers
+func completeProviders(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	manager := newLLMManager()
+	if ctx != nil {
+		manager = ctx.LLMManager
+	}
+	return manager.ProviderNames(), cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeProviderArg completes a single provider-name argument
+func completeProviderArg(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return completeProviders(cmd, args, toComplete)
+}
+
+// completionRepository opens the database initializeCLI would use, or reuses
+// the one in ctx when it is already set. The returned func releases it.
+func completionRepository() (*database.Repository, func(), error) {
+	if ctx != nil {
+		return ctx.Repository, func() {}, nil
+	}
+
+	path := dbPath
+	profileName := telosProfile
+	if profileName == "" {
+		profileName = config.ActiveProfile()
+	}
+	if profileName == config.DefaultProfile && profile.ExistsAtDefault() {
+		path = universalDBPath()
+	}
+
+	// Don't create a database just to complete an argument
+	if !config.FileExists(path) {
+		return nil, nil, fmt.Errorf("no ideas database at %s", path)
+	}
+	repo, err := openRepository(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	return repo, func() { _ = repo.Close() }, nil
+}
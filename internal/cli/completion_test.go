@@ -0,0 +1,70 @@
+//go:build integration
+
+package cli
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ryacub/telos-idea-matrix/internal/models"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompleteIdeaIDs_SuggestsRecentIdeaPrefixes(t *testing.T) {
+	cliCtx, cleanup := setupTestCLI(t)
+	defer cleanup()
+	SetContext(cliCtx)
+
+	active := models.NewIdea("Write a newsletter\nabout Go")
+	require.NoError(t, cliCtx.Repository.Create(active))
+	deleted := models.NewIdea("Deleted idea")
+	deleted.Trash()
+	require.NoError(t, cliCtx.Repository.Create(deleted))
+
+	complete := completeIdeaIDs(1, "")
+	completions, directive := complete(nil, nil, "")
+	assert.Equal(t, cobra.ShellCompDirectiveNoFileComp, directive)
+	assert.Equal(t, []string{active.ID[:shortIDLength] + "\tWrite a newsletter about Go"}, completions)
+
+	// Past the short prefix the full ID is completed
+	completions, _ = complete(nil, nil, active.ID[:10])
+	require.Len(t, completions, 1)
+	assert.True(t, strings.HasPrefix(completions[0], active.ID+"\t"))
+
+	completions, _ = complete(nil, nil, "zz")
+	assert.Empty(t, completions)
+
+	completions, _ = complete(nil, []string{active.ID}, "")
+	assert.Empty(t, completions, "only the first argument is an idea ID")
+
+	completions, _ = completeIdeaIDs(1, string(models.StatusDeleted))(nil, nil, "")
+	assert.Equal(t, []string{deleted.ID[:shortIDLength] + "\tDeleted idea"}, completions)
+}
+
+func TestCompleteIdeaIDs_WithoutDatabaseSuggestsNothing(t *testing.T) {
+	ClearContext()
+	t.Cleanup(ClearContext)
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("TELOS_HOME", "")
+	dbPath = filepath.Join(t.TempDir(), "missing.db")
+
+	completions, directive := completeIdeaIDs(1, "")(nil, nil, "")
+	assert.Empty(t, completions)
+	assert.Equal(t, cobra.ShellCompDirectiveNoFileComp, directive)
+	assert.NoFileExists(t, dbPath, "completion should not create a database")
+}
+
+func TestCompleteProviders_ListsRegisteredProviders(t *testing.T) {
+	ClearContext()
+	t.Cleanup(ClearContext)
+
+	completions, directive := completeProviders(nil, nil, "")
+	assert.Equal(t, cobra.ShellCompDirectiveNoFileComp, directive)
+	assert.Contains(t, completions, "rule_based")
+
+	completions, _ = completeProviderArg(nil, []string{"ollama"}, "")
+	assert.Empty(t, completions)
+}
@@ -0,0 +1,208 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/ryacub/telos-idea-matrix/internal/cliutil"
+	"github.com/ryacub/telos-idea-matrix/internal/config"
+	"github.com/spf13/cobra"
+)
+
+func newConfigCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "config",
+		Short: "View and change configuration",
+		Long: `View and change settings stored in the config file.
+
+The config file is config.yaml in the config directory, ~/.telos by
+default (override with $TELOS_CONFIG). Run 'tm config paths' to see the
+directories in use.
+Environment variables take precedence over the file, which takes
+precedence over built-in defaults.
+
+Examples:
+  tm config list                        # Settings stored in the file
+  tm config list --effective            # Every setting and where it comes from
+  tm config get auth.mode
+  tm config paths                       # Where config, data and logs live
+  tm config set llm.default_provider ollama`,
+		// Config commands don't need a database or scoring profile
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			return nil
+		},
+	}
+
+	cmd.AddCommand(newConfigGetCommand())
+	cmd.AddCommand(newConfigSetCommand())
+	cmd.AddCommand(newConfigListCommand())
+	cmd.AddCommand(newConfigPathsCommand())
+
+	return cmd
+}
+
+func newConfigGetCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "get <key>",
+		Short: "Print the effective value of a setting",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runConfigGet(args[0])
+		},
+	}
+}
+
+func newConfigSetCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "set <key> <value>",
+		Short: "Store a setting in the config file",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runConfigSet(args[0], args[1])
+		},
+	}
+}
+
+func newConfigListCommand() *cobra.Command {
+	var effective bool
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List settings",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runConfigList(effective)
+		},
+	}
+
+	cmd.Flags().BoolVar(&effective, "effective", false, "Show every setting merged from file, environment, and defaults")
+
+	return cmd
+}
+
+func newConfigPathsCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "paths",
+		Short: "Show the config, data and log directories",
+		Long: `Show the directories Brain Salad reads and writes.
+
+$TELOS_HOME puts everything in one directory. Otherwise an existing
+~/.telos is used; new installs follow $XDG_CONFIG_HOME and $XDG_DATA_HOME
+(as <dir>/telos), and fall back to ~/.telos when they are unset.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			runConfigPaths()
+			return nil
+		},
+	}
+}
+
+func runConfigPaths() {
+	paths := config.ResolvePaths()
+
+	fmt.Printf("%-12s %s\n", "Config:", paths.ConfigDir)
+	fmt.Printf("%-12s %s\n", "Data:", paths.DataDir)
+	fmt.Printf("%-12s %s\n", "Logs:", paths.LogDir)
+	fmt.Printf("%-12s %s\n", "Config file:", config.FilePath())
+
+	switch paths.Source {
+	case config.PathsFromTelosHome:
+		fmt.Println("\nChosen by $TELOS_HOME.")
+	case config.PathsFromLegacy:
+		fmt.Println("\nUsing the existing ~/.telos directory.")
+	case config.PathsFromXDG:
+		fmt.Println("\nChosen by $XDG_CONFIG_HOME / $XDG_DATA_HOME.")
+	default:
+		fmt.Println("\nUsing the default ~/.telos directory.")
+	}
+}
+
+func runConfigGet(key string) error {
+	if _, ok := config.LookupKey(key); !ok {
+		return fmt.Errorf("unknown config key %q (run 'tm config list --effective' to see all keys)", key)
+	}
+
+	settings, err := loadEffectiveSettings()
+	if err != nil {
+		return err
+	}
+
+	for _, s := range settings {
+		if s.Key == key {
+			fmt.Println(s.Value)
+			break
+		}
+	}
+	return nil
+}
+
+func runConfigSet(key, value string) error {
+	file, err := config.LoadFile(config.FilePath())
+	if err != nil {
+		return err
+	}
+
+	if err := file.Set(key, value); err != nil {
+		return err
+	}
+	if err := file.Save(); err != nil {
+		return err
+	}
+
+	stored, _ := file.Get(key)
+	_, _ = cliutil.SuccessColor.Printf("✓ Set %s = %s in %s\n", key, stored, file.Path())
+
+	if k, _ := config.LookupKey(key); k.Env != "" && os.Getenv(k.Env) != "" {
+		_, _ = cliutil.WarningColor.Printf("  Note: $%s is set and overrides this value\n", k.Env)
+	}
+	return nil
+}
+
+func runConfigList(effective bool) error {
+	if effective {
+		settings, err := loadEffectiveSettings()
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("%-28s %-24s %s\n", "KEY", "VALUE", "SOURCE")
+		for _, s := range settings {
+			value := s.Value
+			if value == "" {
+				value = "(unset)"
+			}
+			source := s.Source
+			if k, _ := config.LookupKey(s.Key); s.Source == config.SourceEnv {
+				source = fmt.Sprintf("env ($%s)", k.Env)
+			}
+			fmt.Printf("%-28s %-24s %s\n", s.Key, value, source)
+		}
+		return nil
+	}
+
+	file, err := config.LoadFile(config.FilePath())
+	if err != nil {
+		return err
+	}
+
+	found := false
+	for _, k := range config.Keys {
+		if value, ok := file.Get(k.Name); ok {
+			fmt.Printf("%s = %s\n", k.Name, value)
+			found = true
+		}
+	}
+	if !found {
+		fmt.Printf("No settings in %s.\n", file.Path())
+		fmt.Println("Run 'tm config list --effective' to see defaults and environment overrides.")
+	}
+	return nil
+}
+
+// loadEffectiveSettings merges the config file, environment, and defaults
+func loadEffectiveSettings() ([]config.Setting, error) {
+	file, err := config.LoadFile(config.FilePath())
+	if err != nil {
+		return nil, err
+	}
+	return config.Effective(file)
+}
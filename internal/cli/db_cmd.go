@@ -0,0 +1,187 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/ryacub/telos-idea-matrix/internal/cliutil"
+	"github.com/ryacub/telos-idea-matrix/internal/database"
+	"github.com/spf13/cobra"
+)
+
+func newDBCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "db",
+		Short: "Database maintenance",
+	}
+
+	cmd.AddCommand(newDBMigrateCommand())
+	cmd.AddCommand(newDBCheckpointCommand())
+	cmd.AddCommand(newDBRepairAnalysisCommand())
+
+	return cmd
+}
+
+func newDBMigrateCommand() *cobra.Command {
+	var status bool
+
+	cmd := &cobra.Command{
+		Use:   "migrate",
+		Short: "Apply pending schema migrations",
+		Long: `Apply any pending schema migrations to the ideas database.
+
+Migrations also run automatically whenever the database is opened, so this
+is mostly useful with --status to see which schema version you're on.
+
+Examples:
+  tm db migrate
+  tm db migrate --status`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if status {
+				return runDBMigrateStatus()
+			}
+			return runDBMigrate()
+		},
+	}
+
+	cmd.Flags().BoolVar(&status, "status", false, "Show applied and pending migrations")
+
+	return cmd
+}
+
+func runDBMigrate() error {
+	if err := ctx.Repository.Migrate(); err != nil {
+		return err
+	}
+
+	version, err := ctx.Repository.SchemaVersion()
+	if err != nil {
+		return err
+	}
+
+	_, _ = cliutil.SuccessColor.Printf("✓ Database is up to date (schema version %d)\n", version)
+	return nil
+}
+
+func runDBMigrateStatus() error {
+	statuses, err := ctx.Repository.MigrationStatus()
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Database: %s\n\n", ctx.DBPath)
+	fmt.Printf("%-8s %-24s %s\n", "VERSION", "NAME", "APPLIED")
+
+	pending := 0
+	for _, s := range statuses {
+		applied := "pending"
+		if s.Applied {
+			applied = s.AppliedAt.Local().Format("2006-01-02 15:04")
+		} else {
+			pending++
+		}
+		fmt.Printf("%-8d %-24s %s\n", s.Version, s.Name, applied)
+	}
+
+	fmt.Println()
+	if pending > 0 {
+		_, _ = cliutil.WarningColor.Printf("%d pending migration(s). Run 'tm db migrate' to apply.\n", pending)
+	} else {
+		_, _ = cliutil.SuccessColor.Println("✓ Up to date")
+	}
+	return nil
+}
+
+func newDBCheckpointCommand() *cobra.Command {
+	var truncate bool
+
+	cmd := &cobra.Command{
+		Use:   "checkpoint",
+		Short: "Copy the write-ahead log into the database",
+		Long: `Run a WAL checkpoint, copying changes from the write-ahead log (the
+database file with a -wal suffix) back into the database.
+
+SQLite checkpoints on its own every database.wal_autocheckpoint pages, but
+the WAL file keeps its size afterwards. Use --truncate to wait for other
+connections to finish and empty the WAL file, reclaiming its space.
+
+Examples:
+  tm db checkpoint
+  tm db checkpoint --truncate`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			mode := database.CheckpointPassive
+			if truncate {
+				mode = database.CheckpointTruncate
+			}
+			return runDBCheckpoint(mode)
+		},
+	}
+
+	cmd.Flags().BoolVar(&truncate, "truncate", false, "Wait for other connections and truncate the WAL file to zero bytes")
+
+	return cmd
+}
+
+func runDBCheckpoint(mode database.CheckpointMode) error {
+	result, err := ctx.Repository.Checkpoint(mode)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Database: %s\n", ctx.DBPath)
+	fmt.Printf("Mode:     %s\n\n", mode)
+	fmt.Printf("WAL frames:          %d\n", result.LogFrames)
+	fmt.Printf("Checkpointed frames: %d\n", result.CheckpointedFrames)
+	if info, err := os.Stat(ctx.DBPath + "-wal"); err == nil {
+		fmt.Printf("WAL file size:       %.1f KB\n", float64(info.Size())/1024)
+	}
+	fmt.Println()
+
+	if result.Busy {
+		_, _ = cliutil.WarningColor.Println("Checkpoint incomplete: the database is busy. Try again once other connections finish.")
+		return nil
+	}
+	_, _ = cliutil.SuccessColor.Println("✓ Checkpoint complete")
+	return nil
+}
+
+func newDBRepairAnalysisCommand() *cobra.Command {
+	var dryRun bool
+
+	cmd := &cobra.Command{
+		Use:   "repair-analysis",
+		Short: "Wrap plain-text analysis details in JSON",
+		Long: `Rewrite analysis details saved as plain text, as older versions did, into
+a minimal JSON object: {"text": "..."}. The text is kept and 'tm show'
+displays it as before, but every idea's analysis details now decode as JSON
+for exports and other tools.
+
+Examples:
+  tm db repair-analysis --dry-run
+  tm db repair-analysis`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runDBRepairAnalysis(dryRun)
+		},
+	}
+
+	cmd.Flags().BoolVarP(&dryRun, "dry-run", "n", false, "Count the ideas that need repair without changing them")
+
+	return cmd
+}
+
+func runDBRepairAnalysis(dryRun bool) error {
+	count, err := ctx.Repository.RepairAnalysisDetails(dryRun)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case count == 0:
+		_, _ = cliutil.SuccessColor.Println("✓ All analysis details are already JSON")
+	case dryRun:
+		_, _ = cliutil.InfoColor.Printf("🔍 DRY RUN - %d ideas have plain-text analysis details to repair\n", count)
+	default:
+		_, _ = cliutil.SuccessColor.Printf("✓ Repaired analysis details of %d ideas\n", count)
+	}
+	return nil
+}
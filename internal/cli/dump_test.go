@@ -3,12 +3,15 @@
 package cli
 
 import (
+	"context"
 	"os"
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/ryacub/telos-idea-matrix/internal/database"
+	"github.com/ryacub/telos-idea-matrix/internal/llm"
 	"github.com/ryacub/telos-idea-matrix/internal/patterns"
 	"github.com/ryacub/telos-idea-matrix/internal/scoring"
 	"github.com/ryacub/telos-idea-matrix/internal/telos"
@@ -206,3 +209,404 @@ func TestAddCommand_NoArgs_ReturnsError(t *testing.T) {
 	err := cmd.Execute()
 	assert.Error(t, err, "Expected error when no idea text provided")
 }
+
+func TestAddCommand_WithTrigger_StoresTrigger(t *testing.T) {
+	cliCtx, cleanup := setupTestCLI(t)
+	defer cleanup()
+
+	cmd := GetRootCmd()
+	cmd.SetArgs([]string{
+		"--telos", cliCtx.TelosPath,
+		"--db", cliCtx.DBPath,
+		"add", "Build a price tracker in Go",
+		"--trigger", "  competitor launch ",
+	})
+
+	err := cmd.Execute()
+	require.NoError(t, err)
+
+	ideas, err := cliCtx.Repository.List(database.ListOptions{})
+	require.NoError(t, err)
+	require.Len(t, ideas, 1)
+	assert.Equal(t, "competitor launch", ideas[0].Trigger)
+}
+
+func TestAddCommand_WithTags_StoresTags(t *testing.T) {
+	cliCtx, cleanup := setupTestCLI(t)
+	defer cleanup()
+
+	cmd := GetRootCmd()
+	cmd.SetArgs([]string{
+		"--telos", cliCtx.TelosPath,
+		"--db", cliCtx.DBPath,
+		"dump", "Plan the quarterly OKRs",
+		"--tags", " work, urgent,,work",
+	})
+
+	err := cmd.Execute()
+	require.NoError(t, err)
+
+	ideas, err := cliCtx.Repository.List(database.ListOptions{})
+	require.NoError(t, err)
+	require.Len(t, ideas, 1)
+	assert.Equal(t, []string{"work", "urgent"}, ideas[0].Tags)
+
+	tagged, err := cliCtx.Repository.List(database.ListOptions{Tag: "urgent"})
+	require.NoError(t, err)
+	assert.Len(t, tagged, 1)
+}
+
+func TestAddCommand_FromStdin_SavesTrimmedContent(t *testing.T) {
+	cliCtx, cleanup := setupTestCLI(t)
+	defer cleanup()
+
+	cmd := GetRootCmd()
+	cmd.SetIn(strings.NewReader("Build a CLI for piping ideas\n  \n"))
+	t.Cleanup(func() { cmd.SetIn(nil) })
+	cmd.SetArgs([]string{
+		"--telos", cliCtx.TelosPath,
+		"--db", cliCtx.DBPath,
+		"dump", "-q",
+	})
+
+	err := cmd.Execute()
+	require.NoError(t, err)
+
+	ideas, err := cliCtx.Repository.List(database.ListOptions{})
+	require.NoError(t, err)
+	require.Len(t, ideas, 1)
+	assert.Equal(t, "Build a CLI for piping ideas", ideas[0].Content)
+}
+
+func TestAddCommand_FromStdin_RejectsEmptyInput(t *testing.T) {
+	cliCtx, cleanup := setupTestCLI(t)
+	defer cleanup()
+
+	cmd := GetRootCmd()
+	cmd.SetIn(strings.NewReader(" \n\t\n"))
+	t.Cleanup(func() { cmd.SetIn(nil) })
+	cmd.SetArgs([]string{
+		"--telos", cliCtx.TelosPath,
+		"--db", cliCtx.DBPath,
+		"dump",
+	})
+
+	err := cmd.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "stdin is empty")
+
+	ideas, err := cliCtx.Repository.List(database.ListOptions{})
+	require.NoError(t, err)
+	assert.Empty(t, ideas)
+}
+
+func TestAddCommand_ArgsTakePrecedenceOverStdin(t *testing.T) {
+	cliCtx, cleanup := setupTestCLI(t)
+	defer cleanup()
+
+	cmd := GetRootCmd()
+	cmd.SetIn(strings.NewReader("Ignored stdin idea"))
+	t.Cleanup(func() { cmd.SetIn(nil) })
+	cmd.SetArgs([]string{
+		"--telos", cliCtx.TelosPath,
+		"--db", cliCtx.DBPath,
+		"dump", "Argument idea", "-q",
+	})
+
+	err := cmd.Execute()
+	require.NoError(t, err)
+
+	ideas, err := cliCtx.Repository.List(database.ListOptions{})
+	require.NoError(t, err)
+	require.Len(t, ideas, 1)
+	assert.Equal(t, "Argument idea", ideas[0].Content)
+}
+
+func TestAddCommand_FromFile_SavesContent(t *testing.T) {
+	cliCtx, cleanup := setupTestCLI(t)
+	defer cleanup()
+
+	path := filepath.Join(t.TempDir(), "draft.md")
+	require.NoError(t, os.WriteFile(path, []byte("Build a CLI for invoices\n\nwith Go\n\n"), 0o600))
+
+	cmd := GetRootCmd()
+	cmd.SetArgs([]string{
+		"--telos", cliCtx.TelosPath,
+		"--db", cliCtx.DBPath,
+		"dump", "--file", path, "-q",
+	})
+
+	err := cmd.Execute()
+	require.NoError(t, err)
+
+	ideas, err := cliCtx.Repository.List(database.ListOptions{})
+	require.NoError(t, err)
+	require.Len(t, ideas, 1)
+	assert.Equal(t, "Build a CLI for invoices\n\nwith Go", ideas[0].Content)
+}
+
+func TestAddCommand_FromFile_Split_SavesEachIdea(t *testing.T) {
+	cliCtx, cleanup := setupTestCLI(t)
+	defer cleanup()
+
+	path := filepath.Join(t.TempDir(), "ideas.txt")
+	require.NoError(t, os.WriteFile(path, []byte("Build a CLI for invoices\n\nStart a podcast\n\n\nWrite a newsletter\n"), 0o600))
+
+	cmd := GetRootCmd()
+	cmd.SetArgs([]string{
+		"--telos", cliCtx.TelosPath,
+		"--db", cliCtx.DBPath,
+		"dump", "--file", path, "--split", "--tags", "drafts",
+	})
+
+	err := cmd.Execute()
+	require.NoError(t, err)
+
+	ideas, err := cliCtx.Repository.List(database.ListOptions{})
+	require.NoError(t, err)
+	require.Len(t, ideas, 3)
+	contents := make([]string, len(ideas))
+	for i, idea := range ideas {
+		contents[i] = idea.Content
+		assert.Equal(t, []string{"drafts"}, idea.Tags)
+	}
+	assert.ElementsMatch(t, []string{"Build a CLI for invoices", "Start a podcast", "Write a newsletter"}, contents)
+}
+
+func TestAddCommand_FromFile_Errors(t *testing.T) {
+	dir := t.TempDir()
+	empty := filepath.Join(dir, "empty.txt")
+	require.NoError(t, os.WriteFile(empty, []byte(" \n\n"), 0o600))
+	draft := filepath.Join(dir, "draft.txt")
+	require.NoError(t, os.WriteFile(draft, []byte("An idea"), 0o600))
+
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{"missing file", []string{"--file", filepath.Join(dir, "missing.txt")}, "not found"},
+		{"empty file", []string{"--file", empty}, "empty"},
+		{"with idea text", []string{"Argument idea", "--file", draft}, "cannot be used"},
+		{"with clipboard", []string{"--file", draft, "--from-clipboard"}, "cannot be used"},
+		{"split without file", []string{"--split", "Argument idea"}, "--split requires --file"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cliCtx, cleanup := setupTestCLI(t)
+			defer cleanup()
+
+			cmd := GetRootCmd()
+			cmd.SetArgs(append([]string{"--telos", cliCtx.TelosPath, "--db", cliCtx.DBPath, "dump"}, tt.args...))
+
+			err := cmd.Execute()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.want)
+
+			ideas, err := cliCtx.Repository.List(database.ListOptions{})
+			require.NoError(t, err)
+			assert.Empty(t, ideas)
+		})
+	}
+}
+
+func TestSplitIdeas(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []string
+	}{
+		{"blank lines", "First idea\n\nSecond idea\ncontinued\n\n\nThird", []string{"First idea", "Second idea\ncontinued", "Third"}},
+		{"delimiters keep paragraphs together", "First\n\nstill first\n---\nSecond\n  ---  \n", []string{"First\n\nstill first", "Second"}},
+		{"windows line endings", "First\r\n\r\nSecond\r\n", []string{"First", "Second"}},
+		{"only delimiters", "---\n---\n", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, splitIdeas(tt.text))
+		})
+	}
+}
+
+// slowProvider blocks each analysis until the request's context is done,
+// like an Ollama model that takes minutes to answer
+type slowProvider struct {
+	canceled chan struct{}
+}
+
+func (p *slowProvider) Name() string      { return "slow" }
+func (p *slowProvider) IsAvailable() bool { return true }
+
+func (p *slowProvider) Analyze(req llm.AnalysisRequest) (*llm.AnalysisResult, error) {
+	return p.AnalyzeContext(context.Background(), req)
+}
+
+func (p *slowProvider) AnalyzeContext(ctx context.Context, _ llm.AnalysisRequest) (*llm.AnalysisResult, error) {
+	<-ctx.Done()
+	close(p.canceled)
+	return nil, ctx.Err()
+}
+
+func TestAddCommand_AITimeout_FallsBackToRuleBasedAndSaves(t *testing.T) {
+	cliCtx, cleanup := setupTestCLI(t)
+	defer cleanup()
+
+	provider := &slowProvider{canceled: make(chan struct{})}
+	manager := llm.NewManager(&llm.ManagerConfig{FallbackEnabled: true})
+	manager.RegisterProvider(provider)
+	require.NoError(t, manager.SetPrimaryProvider(provider.Name()))
+	cliCtx.LLMManager = manager
+	SetContext(cliCtx)
+
+	start := time.Now()
+	err := runAdd("Build a SaaS product using Go and AI agents", addOptions{
+		useAI:   true,
+		quiet:   true,
+		timeout: 50 * time.Millisecond,
+	})
+	require.NoError(t, err)
+	assert.Less(t, time.Since(start), 5*time.Second, "the deadline bounds the AI call")
+
+	select {
+	case <-provider.canceled:
+	default:
+		t.Fatal("the slow provider's request was not canceled")
+	}
+
+	ideas, err := cliCtx.Repository.List(database.ListOptions{})
+	require.NoError(t, err)
+	require.Len(t, ideas, 1, "the idea is saved with the rule-based score")
+
+	expected, err := cliCtx.Engine.CalculateScore(ideas[0].Content)
+	require.NoError(t, err)
+	assert.Equal(t, expected.FinalScore, ideas[0].FinalScore)
+}
+
+// fixedProvider answers every analysis with the same score
+type fixedProvider struct {
+	calls int
+}
+
+func (p *fixedProvider) Name() string      { return "fixed" }
+func (p *fixedProvider) IsAvailable() bool { return true }
+
+func (p *fixedProvider) Analyze(req llm.AnalysisRequest) (*llm.AnalysisResult, error) {
+	return p.AnalyzeContext(context.Background(), req)
+}
+
+func (p *fixedProvider) AnalyzeContext(context.Context, llm.AnalysisRequest) (*llm.AnalysisResult, error) {
+	p.calls++
+	return &llm.AnalysisResult{FinalScore: 8.5, Recommendation: "pursue", Provider: p.Name()}, nil
+}
+
+func TestAddCommand_DryRun_AnalyzesWithoutSaving(t *testing.T) {
+	tests := []struct {
+		name string
+		opts addOptions
+	}{
+		{"normal", addOptions{dryRun: true}},
+		{"quiet", addOptions{dryRun: true, quiet: true}},
+		{"ai", addOptions{dryRun: true, useAI: true}},
+		{"json", addOptions{dryRun: true, jsonOutput: true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cliCtx, cleanup := setupTestCLI(t)
+			defer cleanup()
+
+			provider := &fixedProvider{}
+			manager := llm.NewManager(&llm.ManagerConfig{FallbackEnabled: true})
+			manager.RegisterProvider(provider)
+			require.NoError(t, manager.SetPrimaryProvider(provider.Name()))
+			cliCtx.LLMManager = manager
+			SetContext(cliCtx)
+
+			err := runAdd("Build a SaaS product using Go and AI agents", tt.opts)
+			require.NoError(t, err)
+
+			if tt.opts.useAI {
+				assert.Equal(t, 1, provider.calls, "AI analysis still runs")
+			}
+
+			ideas, err := cliCtx.Repository.List(database.ListOptions{})
+			require.NoError(t, err)
+			assert.Empty(t, ideas)
+		})
+	}
+}
+
+func TestDumpCommand_DryRunFlag_DoesNotSave(t *testing.T) {
+	cliCtx, cleanup := setupTestCLI(t)
+	defer cleanup()
+
+	cmd := GetRootCmd()
+	cmd.SetArgs([]string{
+		"--telos", cliCtx.TelosPath,
+		"--db", cliCtx.DBPath,
+		"dump", "Build a SaaS product using Go and AI agents", "--dry-run",
+	})
+
+	err := cmd.Execute()
+	require.NoError(t, err)
+
+	ideas, err := cliCtx.Repository.List(database.ListOptions{})
+	require.NoError(t, err)
+	assert.Empty(t, ideas)
+}
+
+func TestAnalyzeWithAI_ReturnsProvidersTried(t *testing.T) {
+	cliCtx, cleanup := setupTestCLI(t)
+	defer cleanup()
+
+	provider := &fixedProvider{}
+	manager := llm.NewManager(&llm.ManagerConfig{FallbackEnabled: true})
+	manager.RegisterProvider(provider)
+	cliCtx.LLMManager = manager
+	SetContext(cliCtx)
+
+	analysis, chain, err := analyzeWithAI("Build a SaaS product using Go and AI agents", addOptions{provider: provider.Name()})
+	require.NoError(t, err)
+	assert.Equal(t, 8.5, analysis.FinalScore)
+	assert.Equal(t, []string{"fixed: ok"}, chain)
+}
+
+func TestAddCommand_ScoreBoundsFromFile(t *testing.T) {
+	cliCtx, cleanup := setupTestCLI(t)
+	defer cleanup()
+
+	home := t.TempDir()
+	t.Setenv("TELOS_HOME", home)
+	require.NoError(t, os.WriteFile(filepath.Join(home, "score-bounds.yaml"), []byte(`score_bounds:
+  - tag: urgent
+    floor: 9.5
+  - pattern: perfectionism
+    ceiling: 2
+`), 0o600))
+
+	add := func(args ...string) {
+		t.Helper()
+		cmd := GetRootCmd()
+		cmd.SetArgs(append([]string{"--telos", cliCtx.TelosPath, "--db", cliCtx.DBPath, "add"}, args...))
+		require.NoError(t, cmd.Execute())
+		ClearContext()
+	}
+	add("Start a knitting podcast", "--tags", "urgent")
+	add("Build a comprehensive AI agent platform in Go with LangChain")
+
+	ideas, err := cliCtx.Repository.List(database.ListOptions{})
+	require.NoError(t, err)
+	require.Len(t, ideas, 2)
+	scores := make(map[string]float64)
+	for _, idea := range ideas {
+		scores[idea.Content] = idea.FinalScore
+	}
+	assert.Equal(t, 9.5, scores["Start a knitting podcast"], "the urgent tag floors the score")
+	assert.Equal(t, 2.0, scores["Build a comprehensive AI agent platform in Go with LangChain"], "perfectionism caps the score")
+
+	for _, idea := range ideas {
+		assert.Contains(t, idea.AnalysisDetails, "applied (", "the bound is recorded in the analysis")
+	}
+}
@@ -119,6 +119,7 @@ func WrapError(err error, context string) error {
 				"Provide idea text: tm dump \"Your idea here\"",
 				"Use --interactive for step-by-step input",
 				"Or use --from-clipboard to paste from clipboard",
+				"Or pipe it in: echo \"Your idea\" | tm dump",
 			},
 		}
 	}
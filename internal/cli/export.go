@@ -0,0 +1,120 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+	"github.com/ryacub/telos-idea-matrix/internal/cliutil"
+	"github.com/ryacub/telos-idea-matrix/internal/database"
+	"github.com/ryacub/telos-idea-matrix/internal/export"
+	"github.com/spf13/cobra"
+)
+
+func newExportCommand() *cobra.Command {
+	var sqlFormat bool
+	var ndjsonFormat bool
+
+	cmd := &cobra.Command{
+		Use:   "export <file>",
+		Short: "Export all ideas as an SQL script or NDJSON",
+		Long: `Write an SQL script that recreates the ideas table and all of its rows
+in another SQLite database, e.g. for querying your ideas with other tools.
+
+Unlike 'tm backup', the output is plain text: only the ideas table is
+included, using the current schema. Use '-' to write to stdout.
+
+With a .ndjson file or --ndjson, every idea outside the trash is written
+as one JSON object per line, oldest first. Ideas are streamed from the
+database, so even very large collections are never held in memory.
+For CSV, JSON, or filtered exports, use 'tm bulk export'.
+
+Examples:
+  tm export dump.sql
+  tm export --sql - | sqlite3 ideas-copy.db
+  tm export ideas.ndjson
+  tm export --ndjson - | jq -r .content`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path := args[0]
+			ext := strings.ToLower(filepath.Ext(path))
+			switch {
+			case sqlFormat && ndjsonFormat:
+				return fmt.Errorf("--sql and --ndjson cannot be used together")
+			case sqlFormat || (!ndjsonFormat && ext == ".sql"):
+				return runExportSQL(path)
+			case ndjsonFormat || ext == ".ndjson" || ext == ".jsonl":
+				return runExportNDJSON(path)
+			default:
+				return fmt.Errorf("use a .sql or .ndjson file, or --sql or --ndjson (for CSV or JSON, use 'tm bulk export')")
+			}
+		},
+	}
+
+	cmd.Flags().BoolVar(&sqlFormat, "sql", false, "Write an SQL script (default for .sql files)")
+	cmd.Flags().BoolVar(&ndjsonFormat, "ndjson", false, "Write one JSON idea per line (default for .ndjson and .jsonl files)")
+
+	return cmd
+}
+
+func runExportSQL(path string) error {
+	if path == "-" {
+		_, err := ctx.Repository.ExportSQL(os.Stdout)
+		return err
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create export file: %w", err)
+	}
+
+	count, err := ctx.Repository.ExportSQL(file)
+	if closeErr := file.Close(); err == nil && closeErr != nil {
+		err = fmt.Errorf("failed to close export file: %w", closeErr)
+	}
+	if err != nil {
+		return err
+	}
+
+	if _, err := cliutil.SuccessColor.Printf("✅ Exported %d ideas to '%s'\n", count, path); err != nil {
+		log.Warn().Err(err).Msg("failed to print success message")
+	}
+	fmt.Printf("  Import with: sqlite3 new.db < %s\n", path)
+	return nil
+}
+
+func runExportNDJSON(path string) error {
+	stream, streamErr, err := ctx.Repository.Stream(database.ListOptions{
+		OrderBy: database.OrderBy(database.SortByCreatedAt, database.Ascending),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list ideas: %w", err)
+	}
+
+	if path == "-" {
+		_, err := export.ExportNDJSONStream(stream, streamErr, os.Stdout)
+		return err
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		for range stream {
+		}
+		return fmt.Errorf("failed to create export file: %w", err)
+	}
+
+	count, err := export.ExportNDJSONStream(stream, streamErr, file)
+	if closeErr := file.Close(); err == nil && closeErr != nil {
+		err = fmt.Errorf("failed to close export file: %w", closeErr)
+	}
+	if err != nil {
+		return err
+	}
+
+	if _, err := cliutil.SuccessColor.Printf("✅ Exported %d ideas to '%s'\n", count, path); err != nil {
+		log.Warn().Err(err).Msg("failed to print success message")
+	}
+	return nil
+}
@@ -129,7 +129,7 @@ func checkDatabase() healthStatus {
 	status.details["Size"] = fmt.Sprintf("%.1f MB", sizeMB)
 
 	// Connect to database
-	repo, err := database.NewRepository(dbPath)
+	repo, err := database.NewRepositoryWithConfig(cfg.Database)
 	if err != nil {
 		status.status = statusError
 		status.messages = append(status.messages, fmt.Sprintf("Failed to connect: %v", err))
@@ -260,6 +260,14 @@ func checkLLMProviders() healthStatus {
 		status.details["openai"] = "✗ OPENAI_API_KEY not set"
 	}
 
+	// Check Groq
+	if os.Getenv("GROQ_API_KEY") != "" {
+		status.details["groq"] = "✓ Configured"
+		status.status = statusHealthy
+	} else {
+		status.details["groq"] = "✗ GROQ_API_KEY not set"
+	}
+
 	// Check Claude
 	if os.Getenv("CLAUDE_API_KEY") != "" {
 		status.details["claude"] = "✓ Configured"
@@ -316,7 +324,7 @@ func checkSystem() healthStatus {
 	cfg, err := config.Load()
 	if err == nil {
 		// Try to get SQLite version
-		if repo, err := database.NewRepository(cfg.Database.Path); err == nil {
+		if repo, err := database.NewRepositoryWithConfig(cfg.Database); err == nil {
 			var sqliteVersion string
 			if err := repo.DB().QueryRow("SELECT sqlite_version()").Scan(&sqliteVersion); err == nil {
 				status.details["SQLite version"] = sqliteVersion
@@ -350,7 +358,7 @@ func checkRecentActivity() healthStatus {
 		return status
 	}
 
-	repo, err := database.NewRepository(cfg.Database.Path)
+	repo, err := database.NewRepositoryWithConfig(cfg.Database)
 	if err != nil {
 		status.status = statusWarning
 		status.messages = append(status.messages, "Could not connect to database")
@@ -444,7 +452,7 @@ func printStatus(status healthStatus) {
 	// Print details (sorted for consistency)
 	detailKeys := []string{
 		"Location", "Status", "Size", "Ideas", "Goals", "Strategies",
-		"Failure Patterns", "Last modified", "openai", "claude", "ollama",
+		"Failure Patterns", "Last modified", "openai", "groq", "claude", "ollama",
 		"rule-based", "Go version", "SQLite version", "Platform",
 		"Memory usage", "Ideas created today", "Ideas created this week",
 		"Average score (last 30 days)",
@@ -0,0 +1,150 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/ryacub/telos-idea-matrix/internal/cliutil"
+	"github.com/ryacub/telos-idea-matrix/internal/config"
+	"github.com/ryacub/telos-idea-matrix/internal/models"
+	"github.com/ryacub/telos-idea-matrix/internal/patterns"
+	"github.com/ryacub/telos-idea-matrix/internal/scoring"
+	"github.com/ryacub/telos-idea-matrix/internal/telos"
+	"github.com/spf13/cobra"
+)
+
+func newIdeaCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "idea",
+		Short: "Manage individual ideas",
+		Long:  `Manage individual ideas, such as moving them between telos profiles.`,
+	}
+
+	cmd.AddCommand(newIdeaMoveCommand())
+
+	return cmd
+}
+
+func newIdeaMoveCommand() *cobra.Command {
+	var (
+		to        string
+		reanalyze bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "move <id> --to <profile>",
+		Short: "Move an idea to another telos profile",
+		Long: `Move an idea that was filed under the wrong telos profile.
+
+The idea then appears in the target profile's listings and analytics instead
+of the current one's. With --reanalyze it is also re-scored against the target
+profile's telos; otherwise it keeps its score, which was computed against the
+old profile. Every move is recorded and shown by 'tm show'.
+
+Examples:
+  tm idea move abc123 --to side
+  tm idea move abc123 --to side --reanalyze`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeIdeaIDs(1, ""),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runIdeaMove(args[0], to, reanalyze)
+		},
+	}
+
+	cmd.Flags().StringVar(&to, "to", "", "Telos profile to move the idea to")
+	cmd.Flags().BoolVar(&reanalyze, "reanalyze", false, "Re-score the idea against the target profile's telos")
+	_ = cmd.MarkFlagRequired("to")
+
+	return cmd
+}
+
+func runIdeaMove(ideaID, to string, reanalyze bool) error {
+	if err := config.ValidateProfileName(to); err != nil {
+		return err
+	}
+	telosFile := profileTelosFile(to)
+	if to != config.DefaultProfile && !config.FileExists(telosFile) {
+		return fmt.Errorf("telos profile %q not found; create %s first", to, telosFile)
+	}
+
+	idea, err := ctx.Repository.GetByID(ideaID)
+	if err != nil {
+		idea, err = ctx.Repository.GetByPartialID(ideaID)
+		if err != nil {
+			return fmt.Errorf("idea not found: %s", ideaID)
+		}
+	}
+
+	from := idea.Profile
+	if from == "" {
+		from = models.DefaultProfile
+	}
+	if from == to {
+		_, _ = cliutil.InfoColor.Printf("Idea %s is already in profile %s\n", idea.ID[:8], to)
+		return nil
+	}
+
+	oldScore := idea.FinalScore
+	idea.Profile = to
+	if reanalyze {
+		if err := rescoreIdea(idea, telosFile); err != nil {
+			return err
+		}
+	}
+
+	if _, err := ctx.Repository.MoveIdea(idea, from, reanalyze); err != nil {
+		return fmt.Errorf("failed to move idea: %w", err)
+	}
+
+	_, _ = cliutil.SuccessColor.Printf("✓ Moved %s from %s to %s: %s\n",
+		idea.ID[:8], from, to, cliutil.TruncateText(idea.Content, 50))
+	if reanalyze {
+		fmt.Printf("  Score: %.1f → %.1f (%s)\n", oldScore, idea.FinalScore, idea.Recommendation)
+	}
+	return nil
+}
+
+// profileTelosFile returns the telos file of a named profile. The default
+// profile falls back to the --telos default rather than the current
+// profile's file.
+func profileTelosFile(name string) string {
+	fallback := telosPath
+	if flag := rootCmd.PersistentFlags().Lookup("telos"); flag != nil && !flag.Changed {
+		fallback = flag.DefValue
+	}
+	return config.ProfileTelosPath(name, fallback)
+}
+
+// rescoreIdea scores idea with the rule-based engine against the telos in
+// telosFile, as 'tm add' does, and records that telos version
+func rescoreIdea(idea *models.Idea, telosFile string) error {
+	telosData, err := telos.NewParser().ParseFile(telosFile)
+	if err != nil {
+		return fmt.Errorf("failed to parse telos for profile %s: %w", idea.Profile, err)
+	}
+	version, err := telos.Version(telosFile)
+	if err != nil {
+		return err
+	}
+
+	analysis, err := scoring.NewEngine(telosData).CalculateScore(idea.Content)
+	if err != nil {
+		return fmt.Errorf("failed to score: %w", err)
+	}
+
+	detected := patterns.NewDetectorWithRules(telosData, ctx.PatternRules).DetectPatterns(idea.Content)
+	patternStrings := make([]string, len(detected))
+	for i, p := range detected {
+		patternStrings[i] = fmt.Sprintf("%s: %s", p.Name, p.Description)
+	}
+
+	scoring.ApplyAnalysisBounds(analysis, idea.Tags, patternStrings, ctx.ScoreBounds)
+
+	analysisJSON, _ := json.Marshal(analysis)
+	idea.FinalScore = analysis.FinalScore
+	idea.Recommendation = analysis.GetRecommendation()
+	idea.Patterns = patternStrings
+	idea.AnalysisDetails = string(analysisJSON)
+	idea.TelosVersion = version
+	return nil
+}
@@ -0,0 +1,117 @@
+//go:build integration
+
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ryacub/telos-idea-matrix/internal/database"
+	"github.com/ryacub/telos-idea-matrix/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setupSideProfile points HOME at a temp directory with a "side" telos profile
+// whose goals differ from the test telos
+func setupSideProfile(t *testing.T) {
+	t.Helper()
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	dir := filepath.Join(home, ".telos", "profiles")
+	require.NoError(t, os.MkdirAll(dir, 0755))
+	side := `## Goals
+- G1: Write and sell a woodworking book (Deadline: 2025-12-31)
+
+## Stack
+- Primary: Woodworking, Writing
+
+## Failure Patterns
+- Perfectionism: Polishing chapters instead of publishing
+`
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "side.md"), []byte(side), 0644))
+}
+
+func TestIdeaMove_ChangesProfile(t *testing.T) {
+	cliCtx, cleanup := setupTestCLI(t)
+	defer cleanup()
+	setupSideProfile(t)
+	SetContext(cliCtx)
+
+	idea := models.NewIdea("Build an AI agent using Go and LangChain")
+	idea.FinalScore = 8.0
+	idea.TelosVersion = "v1"
+	require.NoError(t, cliCtx.Repository.Create(idea))
+
+	require.NoError(t, runIdeaMove(idea.ID[:8], "side", false))
+
+	got, err := cliCtx.Repository.GetByID(idea.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "side", got.Profile)
+	assert.Equal(t, 8.0, got.FinalScore, "score is kept without --reanalyze")
+	assert.Equal(t, "v1", got.TelosVersion)
+
+	// Profile-scoped listings follow the move
+	ideas, err := cliCtx.Repository.List(database.ListOptions{Profile: models.DefaultProfile})
+	require.NoError(t, err)
+	assert.Empty(t, ideas)
+	ideas, err = cliCtx.Repository.List(database.ListOptions{Profile: "side"})
+	require.NoError(t, err)
+	require.Len(t, ideas, 1)
+
+	moves, err := cliCtx.Repository.IdeaMoves(idea.ID)
+	require.NoError(t, err)
+	require.Len(t, moves, 1)
+	assert.Equal(t, models.DefaultProfile, moves[0].FromProfile)
+	assert.Equal(t, "side", moves[0].ToProfile)
+	assert.False(t, moves[0].Reanalyzed)
+}
+
+func TestIdeaMove_Reanalyze(t *testing.T) {
+	cliCtx, cleanup := setupTestCLI(t)
+	defer cleanup()
+	setupSideProfile(t)
+	SetContext(cliCtx)
+
+	content := "Build an AI agent using Go and LangChain with $2000/month SaaS subscription model, MVP in 30 days"
+	analysis, err := cliCtx.Engine.CalculateScore(content)
+	require.NoError(t, err)
+
+	idea := models.NewIdea(content)
+	idea.FinalScore = analysis.FinalScore
+	idea.Recommendation = analysis.GetRecommendation()
+	require.NoError(t, cliCtx.Repository.Create(idea))
+
+	require.NoError(t, runIdeaMove(idea.ID, "side", true))
+
+	got, err := cliCtx.Repository.GetByID(idea.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "side", got.Profile)
+	assert.Less(t, got.FinalScore, idea.FinalScore, "an AI SaaS idea should score lower against a woodworking telos")
+	assert.NotEmpty(t, got.TelosVersion)
+
+	moves, err := cliCtx.Repository.IdeaMoves(idea.ID)
+	require.NoError(t, err)
+	require.Len(t, moves, 1)
+	assert.True(t, moves[0].Reanalyzed)
+}
+
+func TestIdeaMove_RejectsUnknownProfile(t *testing.T) {
+	cliCtx, cleanup := setupTestCLI(t)
+	defer cleanup()
+	setupSideProfile(t)
+	SetContext(cliCtx)
+
+	idea := models.NewIdea("An idea filed correctly")
+	require.NoError(t, cliCtx.Repository.Create(idea))
+
+	err := runIdeaMove(idea.ID, "nonexistent", false)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not found")
+
+	got, err := cliCtx.Repository.GetByID(idea.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.DefaultProfile, got.Profile)
+}
@@ -0,0 +1,184 @@
+package cli
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+	"github.com/ryacub/telos-idea-matrix/internal/cliutil"
+	"github.com/ryacub/telos-idea-matrix/internal/database"
+	"github.com/ryacub/telos-idea-matrix/internal/export"
+	"github.com/ryacub/telos-idea-matrix/internal/models"
+	"github.com/spf13/cobra"
+)
+
+// importOptions are the flags of tm import
+type importOptions struct {
+	split          export.MarkdownSplit
+	analyze        bool
+	dryRun         bool
+	skipDuplicates bool
+	tags           []string
+}
+
+func newImportCommand() *cobra.Command {
+	var fromMarkdown bool
+	var split string
+	var tags string
+	var opts importOptions
+
+	cmd := &cobra.Command{
+		Use:   "import <file>",
+		Short: "Capture ideas from a Markdown notes file",
+		Long: `Capture every idea in a Markdown notes file at once.
+
+Each top-level list item becomes an idea, together with the nested items
+and indented lines under it. If the file has "##" headings, each heading
+and the text under it becomes an idea instead; use --split to choose.
+Front matter, code blocks and text outside the items are ignored.
+
+Ideas are saved unscored, ready for 'tm bulk analyze', unless --analyze
+scores each one as 'tm dump' would. Use --dry-run to preview what would
+be captured. For CSV or YAML exports, use 'tm bulk import'.
+
+Examples:
+  tm import notes.md
+  tm import brainstorm.txt --from-markdown
+  tm import notes.md --split heading --analyze
+  tm import notes.md --dry-run --analyze    # Preview with scores
+  tm import notes.md --skip-duplicates --tags brainstorm`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path := args[0]
+			ext := strings.ToLower(filepath.Ext(path))
+			if !fromMarkdown && ext != ".md" && ext != ".markdown" {
+				return fmt.Errorf("use a .md file or --from-markdown (for CSV or YAML, use 'tm bulk import')")
+			}
+
+			switch split {
+			case "auto":
+			case "list", "heading":
+				opts.split = export.MarkdownSplit(split)
+			default:
+				return fmt.Errorf("invalid split %q: must be auto, list, or heading", split)
+			}
+			opts.tags = parseTags(tags)
+			return runImportMarkdown(path, opts)
+		},
+	}
+
+	cmd.Flags().BoolVar(&fromMarkdown, "from-markdown", false, "Read the file as Markdown (default for .md and .markdown files)")
+	cmd.Flags().StringVar(&split, "split", "auto", "What becomes an idea: list items, headings, or auto (headings when the file has \"##\" headings)")
+	cmd.Flags().BoolVar(&opts.analyze, "analyze", false, "Score each idea while importing")
+	cmd.Flags().BoolVarP(&opts.dryRun, "dry-run", "n", false, "Show what would be captured without saving")
+	cmd.Flags().BoolVar(&opts.skipDuplicates, "skip-duplicates", false, "Skip ideas whose content already exists")
+	cmd.Flags().StringVar(&tags, "tags", "", "Comma-separated tags for every imported idea")
+
+	return cmd
+}
+
+func runImportMarkdown(path string, opts importOptions) error {
+	ideas, err := export.ImportMarkdown(path, export.MarkdownOptions{Split: opts.split})
+	if err != nil {
+		return fmt.Errorf("failed to import Markdown: %w", err)
+	}
+	if len(ideas) == 0 {
+		fmt.Printf("📭 No ideas found in '%s'.\n", path)
+		return nil
+	}
+
+	fmt.Printf("📥 Found %d ideas in '%s':\n", len(ideas), path)
+
+	imported, skipped := 0, 0
+	var failed []string
+	for i, parsed := range ideas {
+		summary := cliutil.TruncateText(strings.Join(strings.Fields(parsed.Content), " "), 50)
+
+		if opts.skipDuplicates {
+			_, err := ctx.Repository.FindByContentHash(models.ContentHash(parsed.Content))
+			if err == nil {
+				skipped++
+				fmt.Printf("%3d. %-34s %s\n", i+1, "(duplicate, skipped)", summary)
+				continue
+			}
+			if !database.IsNotFound(err) {
+				failed = append(failed, fmt.Sprintf("idea %d (%s): %v", i+1, cliutil.TruncateText(parsed.Content, 40), err))
+				continue
+			}
+		}
+
+		idea, err := importIdea(parsed, opts)
+		if err != nil {
+			failed = append(failed, fmt.Sprintf("idea %d (%s): %v", i+1, cliutil.TruncateText(parsed.Content, 40), err))
+			continue
+		}
+		imported++
+
+		if opts.analyze {
+			fmt.Printf("%3d. ", i+1)
+			_, _ = cliutil.GetScoreColor(idea.FinalScore).Printf("%4.1f", idea.FinalScore)
+			fmt.Printf("  %-28s %s\n", cliutil.TruncateText(idea.Recommendation, 28), summary)
+		} else {
+			fmt.Printf("%3d. %s\n", i+1, summary)
+		}
+	}
+
+	fmt.Println(strings.Repeat("─", 60))
+	if opts.dryRun {
+		if _, err := cliutil.InfoColor.Printf("🔍 DRY RUN - %d ideas would be imported\n", imported); err != nil {
+			log.Warn().Err(err).Msg("failed to print message")
+		}
+	} else {
+		if _, err := cliutil.SuccessColor.Printf("✅ Imported %d ideas from '%s'\n", imported, path); err != nil {
+			log.Warn().Err(err).Msg("failed to print success message")
+		}
+		if !opts.analyze && imported > 0 {
+			fmt.Println("   Score them with: tm bulk analyze")
+		}
+	}
+	if opts.skipDuplicates {
+		fmt.Printf("   Skipped %d duplicate ideas\n", skipped)
+	}
+
+	if len(failed) > 0 {
+		_, _ = cliutil.WarningColor.Printf("⚠  %d ideas failed:\n", len(failed))
+		for _, f := range failed {
+			fmt.Printf("   %s\n", f)
+		}
+		return fmt.Errorf("%d of %d ideas failed to import", len(failed), len(ideas))
+	}
+	return nil
+}
+
+// importIdea saves a parsed idea, scoring it first with --analyze. Nothing
+// is saved when dry-running.
+func importIdea(parsed *models.Idea, opts importOptions) (*models.Idea, error) {
+	if opts.analyze {
+		captured, err := captureIdea(parsed.Content, addOptions{
+			dryRun: opts.dryRun,
+			quiet:  true,
+			tags:   opts.tags,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return captured.idea, nil
+	}
+
+	parsed.Tags = opts.tags
+	if ctx.TelosProfile != "" {
+		parsed.Profile = ctx.TelosProfile
+	}
+	if err := parsed.Validate(); err != nil {
+		return nil, err
+	}
+	if opts.dryRun {
+		return parsed, nil
+	}
+	if err := ctx.Repository.Create(parsed); err != nil {
+		return nil, fmt.Errorf("failed to save: %w", err)
+	}
+	ctx.Notifier.Notify(parsed)
+	return parsed, nil
+}
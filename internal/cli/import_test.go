@@ -0,0 +1,95 @@
+//go:build integration
+
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ryacub/telos-idea-matrix/internal/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImportCommand_Markdown_SavesEachListItem(t *testing.T) {
+	cliCtx, cleanup := setupTestCLI(t)
+	defer cleanup()
+
+	path := filepath.Join(t.TempDir(), "notes.md")
+	require.NoError(t, os.WriteFile(path, []byte("# March\n\n- Build a Go CLI for invoices\n- Start a podcast\n"), 0o600))
+
+	cmd := GetRootCmd()
+	cmd.SetArgs([]string{"--telos", cliCtx.TelosPath, "--db", cliCtx.DBPath, "import", path, "--tags", "notes"})
+	require.NoError(t, cmd.Execute())
+
+	ideas, err := cliCtx.Repository.List(database.ListOptions{})
+	require.NoError(t, err)
+	require.Len(t, ideas, 2)
+	contents := make([]string, len(ideas))
+	for i, idea := range ideas {
+		contents[i] = idea.Content
+		assert.Equal(t, []string{"notes"}, idea.Tags)
+		assert.Zero(t, idea.FinalScore, "ideas are saved unscored without --analyze")
+	}
+	assert.ElementsMatch(t, []string{"Build a Go CLI for invoices", "Start a podcast"}, contents)
+
+	// Importing the same notes again skips what's already captured
+	cmd = GetRootCmd()
+	cmd.SetArgs([]string{"--telos", cliCtx.TelosPath, "--db", cliCtx.DBPath, "import", path, "--skip-duplicates"})
+	require.NoError(t, cmd.Execute())
+
+	ideas, err = cliCtx.Repository.List(database.ListOptions{})
+	require.NoError(t, err)
+	assert.Len(t, ideas, 2)
+}
+
+func TestImportCommand_Markdown_AnalyzeScoresEachIdea(t *testing.T) {
+	cliCtx, cleanup := setupTestCLI(t)
+	defer cleanup()
+
+	path := filepath.Join(t.TempDir(), "brainstorm.txt")
+	require.NoError(t, os.WriteFile(path, []byte("## Invoice CLI\nBuild a Go CLI with OpenAI to hit $2500/month revenue\n"), 0o600))
+
+	cmd := GetRootCmd()
+	cmd.SetArgs([]string{"--telos", cliCtx.TelosPath, "--db", cliCtx.DBPath, "import", path, "--from-markdown", "--analyze"})
+	require.NoError(t, cmd.Execute())
+
+	ideas, err := cliCtx.Repository.List(database.ListOptions{})
+	require.NoError(t, err)
+	require.Len(t, ideas, 1)
+	assert.Equal(t, "Invoice CLI\n\nBuild a Go CLI with OpenAI to hit $2500/month revenue", ideas[0].Content)
+	assert.Positive(t, ideas[0].FinalScore)
+	assert.NotEmpty(t, ideas[0].Recommendation)
+}
+
+func TestImportCommand_DryRunAndErrors(t *testing.T) {
+	cliCtx, cleanup := setupTestCLI(t)
+	defer cleanup()
+
+	dir := t.TempDir()
+	notes := filepath.Join(dir, "notes.md")
+	require.NoError(t, os.WriteFile(notes, []byte("- An idea\n"), 0o600))
+	text := filepath.Join(dir, "notes.txt")
+	require.NoError(t, os.WriteFile(text, []byte("- An idea\n"), 0o600))
+
+	run := func(args ...string) error {
+		cmd := GetRootCmd()
+		cmd.SetArgs(append([]string{"--telos", cliCtx.TelosPath, "--db", cliCtx.DBPath, "import"}, args...))
+		return cmd.Execute()
+	}
+
+	require.NoError(t, run(notes, "--dry-run", "--analyze"))
+
+	err := run(text)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--from-markdown")
+
+	err = run(notes, "--split", "paragraph")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid split")
+
+	ideas, err := cliCtx.Repository.List(database.ListOptions{})
+	require.NoError(t, err)
+	assert.Empty(t, ideas)
+}
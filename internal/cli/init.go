@@ -8,6 +8,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/ryacub/telos-idea-matrix/internal/cli/wizard"
+	"github.com/ryacub/telos-idea-matrix/internal/config"
 	"github.com/ryacub/telos-idea-matrix/internal/profile"
 )
 
@@ -48,11 +49,7 @@ func runInit(cmd *cobra.Command, args []string) error {
 	}
 
 	// Check for existing telos.md (legacy)
-	home, err := os.UserHomeDir()
-	if err != nil {
-		return fmt.Errorf("failed to get home directory: %w", err)
-	}
-	legacyTelosPath := filepath.Join(home, ".telos", "telos.md")
+	legacyTelosPath := config.ResolvePaths().TelosFile()
 
 	// Handle existing configurations
 	if profile.Exists(profilePath) {
@@ -146,24 +143,22 @@ func runAdvancedInit() error {
 	fmt.Println()
 
 	// 1. Create data directory
-	dataDir := os.Getenv("DATA_DIR")
-	if dataDir == "" {
-		home, err := os.UserHomeDir()
-		if err != nil {
-			return fmt.Errorf("failed to get home directory: %w", err)
-		}
-		dataDir = filepath.Join(home, ".telos")
+	paths := config.ResolvePaths()
+	if dataDir := os.Getenv("DATA_DIR"); dataDir != "" {
+		paths.ConfigDir, paths.DataDir = dataDir, dataDir
 	}
 
-	if err := os.MkdirAll(dataDir, 0755); err != nil {
-		return fmt.Errorf("failed to create data directory: %w", err)
+	for _, dir := range []string{paths.DataDir, paths.ConfigDir} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("failed to create data directory: %w", err)
+		}
 	}
-	fmt.Printf("✓ Created data directory: %s\n", dataDir)
+	fmt.Printf("✓ Created data directory: %s\n", paths.DataDir)
 
 	// 2. Create telos.md template
 	telosPath := os.Getenv("TELOS_PATH")
 	if telosPath == "" {
-		telosPath = filepath.Join(dataDir, "telos.md")
+		telosPath = paths.TelosFile()
 	}
 
 	if _, err := os.Stat(telosPath); os.IsNotExist(err) {
@@ -176,7 +171,7 @@ func runAdvancedInit() error {
 	}
 
 	// 3. Initialize database
-	dbPath := filepath.Join(dataDir, "ideas.db")
+	dbPath := paths.DatabaseFile()
 	if _, err := os.Stat(dbPath); os.IsNotExist(err) {
 		fmt.Printf("✓ Database will be created at: %s\n", dbPath)
 	} else {
@@ -184,7 +179,7 @@ func runAdvancedInit() error {
 	}
 
 	// 4. Create .env template
-	envPath := filepath.Join(dataDir, ".env.example")
+	envPath := filepath.Join(paths.ConfigDir, ".env.example")
 	if err := createEnvTemplate(envPath); err != nil {
 		return fmt.Errorf("failed to create .env template: %w", err)
 	}
@@ -255,6 +250,10 @@ TELOS_PATH=$HOME/.telos/telos.md
 # OPENAI_API_KEY=sk-...
 # OPENAI_MODEL=gpt-4
 
+# Groq
+# GROQ_API_KEY=gsk_...
+# GROQ_MODEL=llama-3.1-70b-versatile
+
 # Anthropic Claude
 # ANTHROPIC_API_KEY=sk-ant-...
 # ANTHROPIC_MODEL=claude-3-sonnet-20240229
@@ -61,7 +61,8 @@ Relationship types:
 Examples:
   tm link create abc123 def456 depends_on
   tm link create abc123 ghi789 related_to --no-confirm`,
-		Args: cobra.ExactArgs(3),
+		Args:              cobra.ExactArgs(3),
+		ValidArgsFunction: completeIdeaIDs(2, ""),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runLinkCreate(args[0], args[1], args[2], noConfirm)
 		},
@@ -83,7 +84,8 @@ incoming relationships (where this idea is the target).
 
 Examples:
   tm link list abc123`,
-		Args: cobra.ExactArgs(1),
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeIdeaIDs(1, ""),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runLinkList(args[0])
 		},
@@ -104,7 +106,8 @@ Optionally filter by relationship type.
 Examples:
   tm link show abc123
   tm link show abc123 --type depends_on`,
-		Args: cobra.ExactArgs(1),
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeIdeaIDs(1, ""),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runLinkShow(args[0], relType)
 		},
@@ -153,7 +156,8 @@ dependency chains.
 Examples:
   tm link path abc123 xyz789
   tm link path abc123 xyz789 --max-depth 5`,
-		Args: cobra.ExactArgs(2),
+		Args:              cobra.ExactArgs(2),
+		ValidArgsFunction: completeIdeaIDs(2, ""),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runLinkPath(args[0], args[1], maxDepth)
 		},
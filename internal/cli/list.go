@@ -1,12 +1,17 @@
 package cli
 
 import (
+	"encoding/csv"
 	"encoding/json"
 	"fmt"
+	"os"
+	"strconv"
 	"strings"
 
 	"github.com/rs/zerolog/log"
+	"github.com/ryacub/telos-idea-matrix/internal/analytics"
 	"github.com/ryacub/telos-idea-matrix/internal/cliutil"
+	"github.com/ryacub/telos-idea-matrix/internal/config"
 	"github.com/ryacub/telos-idea-matrix/internal/database"
 	"github.com/ryacub/telos-idea-matrix/internal/models"
 	"github.com/spf13/cobra"
@@ -16,9 +21,14 @@ func newListCommand() *cobra.Command {
 	var minScore float64
 	var maxScore float64
 	var status string
+	var recommendation string
 	var limit int
+	var sortBy string
+	var reverse bool
+	var format string
 	var jsonOutput bool
 	var quiet bool
+	var relative bool
 
 	cmd := &cobra.Command{
 		Use:   "list",
@@ -29,14 +39,43 @@ Examples:
   tm list                      # List recent ideas
   tm list --min-score 7.0      # High-scoring ideas only
   tm list --status archived    # Archived ideas
+  tm list --recommendation pursue  # Ideas recommended as GOOD ALIGNMENT
+  tm list --profile work       # Ideas scored against the work profile
   tm list --limit 20           # Show more ideas
-  tm list --json               # JSON output for scripting
+  tm list --sort date          # Newest first
+  tm list --sort date --reverse  # Oldest first
+  tm list --relative           # Show each score's rank among your active ideas
+  tm list --format table       # One row per idea
+  tm list --format csv > ideas.csv  # CSV for spreadsheets and scripts
+  tm list --json               # JSON output for scripting (same as --format json)
   tm list -q                   # Compact output`,
 		Aliases: []string{"ls"},
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if !isValidStatus(status) {
+				return fmt.Errorf("invalid status %q: must be active, archived, or deleted", status)
+			}
+			if jsonOutput {
+				format = "json"
+			}
+			switch format {
+			case "text", "table", "json", "csv":
+			default:
+				return fmt.Errorf("invalid format %q: must be text, table, json, or csv", format)
+			}
+			order, err := listOrder(sortBy, reverse)
+			if err != nil {
+				return err
+			}
+			category, err := recommendationFilter(recommendation)
+			if err != nil {
+				return err
+			}
+
 			opts := database.ListOptions{
-				Status:  status,
-				OrderBy: "final_score DESC",
+				Status:         status,
+				Profile:        profileFilter(),
+				Recommendation: category,
+				OrderBy:        order,
 			}
 
 			if cmd.Flags().Changed("min-score") {
@@ -55,49 +94,125 @@ Examples:
 			}
 
 			if len(ideas) == 0 {
-				if jsonOutput {
+				if format == "json" {
 					fmt.Println("[]")
+				} else if format == "csv" {
+					return outputListCSV(ideas, nil)
 				} else if !quiet {
 					_, _ = cliutil.InfoColor.Println("No ideas found.")
 				}
 				return nil
 			}
 
-			// JSON output
-			if jsonOutput {
-				return outputListJSON(ideas)
+			scores, err := relativeScores(relative, profileFilter())
+			if err != nil {
+				return err
+			}
+
+			switch format {
+			case "json":
+				return outputListJSON(ideas, scores)
+			case "csv":
+				return outputListCSV(ideas, scores)
+			case "table":
+				return outputIdeaTable(ideas)
 			}
 
 			// Quiet output
 			if quiet {
-				return outputListQuiet(ideas)
+				return outputListQuiet(ideas, scores)
 			}
 
 			// Full output
-			return outputListFull(ideas)
+			return outputListFull(ideas, scores)
 		},
 	}
 
 	cmd.Flags().Float64Var(&minScore, "min-score", 0, "Minimum score")
 	cmd.Flags().Float64Var(&maxScore, "max-score", 0, "Maximum score")
 	cmd.Flags().StringVar(&status, "status", "active", "Status (active|archived|deleted)")
+	cmd.Flags().StringVar(&recommendation, "recommendation", "", "Recommendation (prioritize|pursue|consider|avoid)")
 	cmd.Flags().IntVarP(&limit, "limit", "l", 10, "Max ideas to show")
-	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output as JSON")
+	cmd.Flags().StringVar(&sortBy, "sort", "score", "Sort by: score (highest first) or date (newest first)")
+	cmd.Flags().BoolVar(&reverse, "reverse", false, "Reverse the sort order")
+	cmd.Flags().StringVar(&format, "format", "text", "Output format: text|table|json|csv")
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output as JSON (same as --format json)")
 	cmd.Flags().BoolVarP(&quiet, "quiet", "q", false, "Compact output")
+	cmd.Flags().BoolVar(&relative, "relative", false, "Show each score's percentile among your active ideas")
 
 	return cmd
 }
 
+// recommendationFilter maps the --recommendation flag to a category; empty
+// means any. Wordings such as "good alignment" are accepted too.
+func recommendationFilter(value string) (models.RecommendationCategory, error) {
+	if value == "" {
+		return models.CategoryUnknown, nil
+	}
+	category := models.ParseRecommendation(value)
+	if category == models.CategoryUnknown {
+		return category, fmt.Errorf("invalid recommendation %q: must be prioritize, pursue, consider, or avoid", value)
+	}
+	return category, nil
+}
+
+// listOrder maps the --sort and --reverse flags to a repository order. Scores
+// sort highest first and dates newest first unless reversed.
+func listOrder(sortBy string, reverse bool) (database.Order, error) {
+	var field database.SortField
+	switch sortBy {
+	case "score":
+		field = database.SortByFinalScore
+	case "date":
+		field = database.SortByCreatedAt
+	default:
+		return database.Order{}, fmt.Errorf("invalid sort %q: must be score or date", sortBy)
+	}
+
+	direction := database.Descending
+	if reverse {
+		direction = database.Ascending
+	}
+	return database.OrderBy(field, direction), nil
+}
+
+// relativeScores returns the final scores of the active ideas in profile ("" for
+// all profiles), which relative scores are ranked against. It returns nil when
+// relative display is off via both the flag and display.relative_scores.
+func relativeScores(flag bool, profile string) ([]float64, error) {
+	if !flag && !config.LoadDisplayConfig().RelativeScores {
+		return nil, nil
+	}
+
+	ideas, err := ctx.Repository.List(database.ListOptions{
+		Status:  string(models.StatusActive),
+		Profile: profile,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to load scores for relative display: %w", err)
+	}
+
+	scores := make([]float64, len(ideas))
+	for i, idea := range ideas {
+		scores[i] = idea.FinalScore
+	}
+	return scores, nil
+}
+
 type listItem struct {
 	ID             string   `json:"id"`
 	Content        string   `json:"content"`
 	Score          float64  `json:"score"`
+	Percentile     *float64 `json:"percentile,omitempty"`
 	Recommendation string   `json:"recommendation"`
 	Patterns       []string `json:"patterns,omitempty"`
+	ArchiveReason  string   `json:"archive_reason,omitempty"`
+	Profile        string   `json:"profile"`
 	CreatedAt      string   `json:"created_at"`
+	UpdatedAt      string   `json:"updated_at"`
 }
 
-func outputListJSON(ideas []*models.Idea) error {
+func outputListJSON(ideas []*models.Idea, scores []float64) error {
 	items := make([]listItem, len(ideas))
 	for i, idea := range ideas {
 		items[i] = listItem{
@@ -106,7 +221,14 @@ func outputListJSON(ideas []*models.Idea) error {
 			Score:          idea.FinalScore,
 			Recommendation: idea.Recommendation,
 			Patterns:       idea.Patterns,
+			ArchiveReason:  idea.ArchiveReason,
+			Profile:        idea.Profile,
 			CreatedAt:      idea.CreatedAt.Format("2006-01-02T15:04:05Z"),
+			UpdatedAt:      idea.UpdatedAt.UTC().Format("2006-01-02T15:04:05Z"),
+		}
+		if scores != nil {
+			percentile := analytics.Percentile(idea.FinalScore, scores)
+			items[i].Percentile = &percentile
 		}
 	}
 
@@ -118,16 +240,55 @@ func outputListJSON(ideas []*models.Idea) error {
 	return nil
 }
 
-func outputListQuiet(ideas []*models.Idea) error {
+// outputListCSV writes one row per idea with the full content, for
+// spreadsheets and scripts. A percentile column is added when scores is set.
+func outputListCSV(ideas []*models.Idea, scores []float64) error {
+	w := csv.NewWriter(os.Stdout)
+
+	header := []string{"id", "score", "recommendation", "status", "profile", "created_at", "updated_at", "content"}
+	if scores != nil {
+		header = append(header, "percentile")
+	}
+	if err := w.Write(header); err != nil {
+		return err
+	}
+
+	for _, idea := range ideas {
+		row := []string{
+			idea.ID,
+			strconv.FormatFloat(idea.FinalScore, 'f', 1, 64),
+			idea.Recommendation,
+			idea.Status,
+			idea.Profile,
+			idea.CreatedAt.Format("2006-01-02T15:04:05Z"),
+			idea.UpdatedAt.UTC().Format("2006-01-02T15:04:05Z"),
+			idea.Content,
+		}
+		if scores != nil {
+			row = append(row, strconv.FormatFloat(analytics.Percentile(idea.FinalScore, scores), 'f', 0, 64))
+		}
+		if err := w.Write(row); err != nil {
+			return err
+		}
+	}
+
+	w.Flush()
+	return w.Error()
+}
+
+func outputListQuiet(ideas []*models.Idea, scores []float64) error {
 	for _, idea := range ideas {
 		scoreColor := cliutil.GetScoreColor(idea.FinalScore)
 		_, _ = scoreColor.Printf("%.1f", idea.FinalScore)
+		if scores != nil {
+			fmt.Printf(" %-8s", analytics.RelativeLabel(analytics.Percentile(idea.FinalScore, scores)))
+		}
 		fmt.Printf(" %s %s\n", idea.ID[:8], cliutil.TruncateText(idea.Content, 50))
 	}
 	return nil
 }
 
-func outputListFull(ideas []*models.Idea) error {
+func outputListFull(ideas []*models.Idea, scores []float64) error {
 	fmt.Println(strings.Repeat("─", 60))
 	_, _ = cliutil.SuccessColor.Printf("%d ideas\n", len(ideas))
 	fmt.Println(strings.Repeat("─", 60))
@@ -139,6 +300,9 @@ func outputListFull(ideas []*models.Idea) error {
 		// Header: "1. 8.5/10 - abc123"
 		fmt.Printf("%d. ", i+1)
 		_, _ = scoreColor.Printf("%.1f/10", idea.FinalScore)
+		if scores != nil {
+			fmt.Printf(" (%s)", analytics.RelativeLabel(analytics.Percentile(idea.FinalScore, scores)))
+		}
 		fmt.Printf(" - %s\n", idea.ID[:8])
 
 		// Content
@@ -152,6 +316,11 @@ func outputListFull(ideas []*models.Idea) error {
 			}
 		}
 
+		// Archive reason
+		if idea.ArchiveReason != "" {
+			_, _ = cliutil.WarningColor.Printf("   Archived: %s\n", idea.ArchiveReason)
+		}
+
 		// Date
 		fmt.Printf("   %s\n\n", idea.CreatedAt.Format("Jan 2, 2006"))
 	}
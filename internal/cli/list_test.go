@@ -0,0 +1,36 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/ryacub/telos-idea-matrix/internal/database"
+)
+
+func TestListOrder(t *testing.T) {
+	tests := []struct {
+		sort    string
+		reverse bool
+		want    database.Order
+	}{
+		{"score", false, database.OrderBy(database.SortByFinalScore, database.Descending)},
+		{"score", true, database.OrderBy(database.SortByFinalScore, database.Ascending)},
+		{"date", false, database.OrderBy(database.SortByCreatedAt, database.Descending)},
+		{"date", true, database.OrderBy(database.SortByCreatedAt, database.Ascending)},
+	}
+
+	for _, tt := range tests {
+		got, err := listOrder(tt.sort, tt.reverse)
+		if err != nil {
+			t.Fatalf("listOrder(%q, %v): unexpected error: %v", tt.sort, tt.reverse, err)
+		}
+		if got != tt.want {
+			t.Errorf("listOrder(%q, %v) = %+v, want %+v", tt.sort, tt.reverse, got, tt.want)
+		}
+	}
+}
+
+func TestListOrder_RejectsUnknownSort(t *testing.T) {
+	if _, err := listOrder("content", false); err == nil {
+		t.Error("expected error for unknown sort")
+	}
+}
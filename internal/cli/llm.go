@@ -108,6 +108,7 @@ func runLLMListSubcmd(manager *llm.Manager, showAll bool) error {
 		fmt.Println("\nNo providers configured. Set environment variables:")
 		fmt.Println("  - OPENAI_API_KEY for OpenAI")
 		fmt.Println("  - ANTHROPIC_API_KEY for Claude")
+		fmt.Println("  - GROQ_API_KEY for Groq")
 		fmt.Println("  - Ollama should be running on localhost:11434")
 		fmt.Println("  - CUSTOM_LLM_ENDPOINT for custom providers")
 	}
@@ -132,7 +133,8 @@ Examples:
   telos llm test openai
   telos llm test claude
   telos llm test ollama`,
-		Args: cobra.ExactArgs(1),
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeProviderArg,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			providerName := args[0]
 			return runLLMTestSubcmd(ctx.LLMManager, providerName)
@@ -220,13 +222,14 @@ func newLLMSetDefaultSubcommand() *cobra.Command {
 The default provider will be used for all analysis commands unless
 explicitly overridden with the --provider flag.
 
-The preference is saved to ~/.telos/llm-config.json and persists
-across sessions.
+This applies to the current session. To persist it, use
+'tm config set llm.default_provider <provider-name>'.
 
 Examples:
   telos llm set-default openai
   telos llm set-default claude`,
-		Args: cobra.ExactArgs(1),
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeProviderArg,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			providerName := args[0]
 			return runLLMSetDefaultSubcmd(ctx.LLMManager, providerName)
@@ -255,11 +258,9 @@ func runLLMSetDefaultSubcmd(manager *llm.Manager, providerName string) error {
 		return fmt.Errorf("failed to set primary provider: %w", err)
 	}
 
-	// Note: Persistence will be handled by Agent 2 (Phase 4 - Config Management)
-	// For now, we just set it for the current session
 	fmt.Printf("✓ Default provider set to: %s\n", provider.Name())
 	fmt.Println("  (active for current session)")
-	fmt.Println("\nNote: Persistent configuration will be available in a future update.")
+	fmt.Printf("\nTo make it permanent, run: tm config set llm.default_provider %s\n", provider.Name())
 
 	return nil
 }
@@ -345,6 +346,11 @@ func showProviderConfig(provider llm.Provider) {
 		fmt.Printf("API Key: %s\n", maskAPIKeyLLM(p.GetAPIKey()))
 		fmt.Printf("Endpoint: https://api.openai.com/v1/chat/completions\n")
 
+	case *llm.GroqProvider:
+		fmt.Printf("Model: %s\n", p.GetModel())
+		fmt.Printf("API Key: %s\n", maskAPIKeyLLM(p.GetAPIKey()))
+		fmt.Printf("Endpoint: https://api.groq.com/openai/v1/chat/completions\n")
+
 	case *llm.ClaudeProvider:
 		fmt.Printf("Model: %s\n", p.GetModel())
 		fmt.Printf("API Key: %s\n", maskAPIKeyLLM(p.GetAPIKey()))
@@ -399,6 +405,10 @@ func getProviderConfigHelp(providerName string) string {
   Optional: OPENAI_MODEL (default: gpt-5.1)
 
   Get API key: https://platform.openai.com/api-keys`,
+		"groq": `  Set environment variable: GROQ_API_KEY
+  Optional: GROQ_MODEL (default: llama-3.1-70b-versatile)
+
+  Get API key: https://console.groq.com/keys`,
 		"claude": `  Set environment variable: ANTHROPIC_API_KEY
   Optional: CLAUDE_MODEL (default: claude-3-5-sonnet-20241022)
 
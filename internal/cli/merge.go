@@ -0,0 +1,94 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/ryacub/telos-idea-matrix/internal/cliutil"
+	"github.com/ryacub/telos-idea-matrix/internal/database"
+	"github.com/spf13/cobra"
+)
+
+func newMergeCommand() *cobra.Command {
+	var (
+		keep string
+		yes  bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "merge <id1> <id2>",
+		Short: "Combine two ideas into one",
+		Long: `Combine two ideas captured twice with different wording.
+
+The first idea is kept and the second is moved to the trash. The kept idea
+takes the higher score of the two, with that idea's analysis, and the
+patterns and tags of both. Its content is both contents joined, or only one
+of them with --keep. Profile moves and links of the second idea are carried
+over to the first.
+
+Examples:
+  tm merge abc123 def456
+  tm merge abc123 def456 --keep second --yes`,
+		Args:              cobra.ExactArgs(2),
+		ValidArgsFunction: completeIdeaIDs(2, ""),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runMerge(args[0], args[1], keep, yes)
+		},
+	}
+
+	cmd.Flags().StringVar(&keep, "keep", "", "Keep only one idea's content: first or second (default: join both)")
+	cmd.Flags().BoolVar(&yes, "yes", false, "Auto-confirm (skip confirmation prompt)")
+
+	return cmd
+}
+
+func runMerge(firstID, secondID, keep string, yes bool) error {
+	var content database.MergeContent
+	switch keep {
+	case "":
+		content = database.MergeBothContents
+	case "first":
+		content = database.MergeKeptContent
+	case "second":
+		content = database.MergeAbsorbedContent
+	default:
+		return fmt.Errorf("invalid --keep %q: must be first or second", keep)
+	}
+
+	first, err := ctx.Repository.GetByID(firstID)
+	if err != nil {
+		first, err = ctx.Repository.GetByPartialID(firstID)
+		if err != nil {
+			return fmt.Errorf("idea not found: %s", firstID)
+		}
+	}
+	second, err := ctx.Repository.GetByID(secondID)
+	if err != nil {
+		second, err = ctx.Repository.GetByPartialID(secondID)
+		if err != nil {
+			return fmt.Errorf("idea not found: %s", secondID)
+		}
+	}
+
+	if first.ID == second.ID {
+		return fmt.Errorf("cannot merge idea %s into itself", first.ID[:8])
+	}
+
+	fmt.Printf("Keep:   %s  %s\n", first.ID[:8], cliutil.TruncateText(first.Content, 50))
+	fmt.Printf("Absorb: %s  %s\n", second.ID[:8], cliutil.TruncateText(second.Content, 50))
+	if !yes && !cliutil.Confirm(fmt.Sprintf("Merge %s into %s? %s will be moved to the trash.", second.ID[:8], first.ID[:8], second.ID[:8])) {
+		fmt.Println("❌ Cancelled")
+		return nil
+	}
+
+	if err := ctx.Repository.MergeWithContent(first.ID, second.ID, content); err != nil {
+		return fmt.Errorf("failed to merge: %w", err)
+	}
+
+	merged, err := ctx.Repository.GetByID(first.ID)
+	if err != nil {
+		return fmt.Errorf("failed to load merged idea: %w", err)
+	}
+	_, _ = cliutil.SuccessColor.Printf("✓ Merged %s into %s: %s\n", second.ID[:8], merged.ID[:8], cliutil.TruncateText(merged.Content, 50))
+	fmt.Printf("  Score: %.1f/10\n", merged.FinalScore)
+	return nil
+}
@@ -0,0 +1,44 @@
+//go:build integration
+
+package cli
+
+import (
+	"testing"
+
+	"github.com/ryacub/telos-idea-matrix/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMerge_KeepsFirstContentAndTrashesSecond(t *testing.T) {
+	cliCtx, cleanup := setupTestCLI(t)
+	defer cleanup()
+	SetContext(cliCtx)
+
+	first := models.NewIdea("Automate invoices")
+	require.NoError(t, cliCtx.Repository.Create(first))
+	second := models.NewIdea("Invoice automation tool")
+	second.FinalScore = 7.5
+	require.NoError(t, cliCtx.Repository.Create(second))
+
+	require.NoError(t, runMerge(first.ID[:8], second.ID[:8], "first", true))
+
+	got, err := cliCtx.Repository.GetByID(first.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Automate invoices", got.Content)
+	assert.Equal(t, 7.5, got.FinalScore)
+
+	absorbed, err := cliCtx.Repository.GetByID(second.ID)
+	require.NoError(t, err)
+	assert.Equal(t, string(models.StatusDeleted), absorbed.Status)
+}
+
+func TestMerge_RejectsInvalidKeep(t *testing.T) {
+	cliCtx, cleanup := setupTestCLI(t)
+	defer cleanup()
+	SetContext(cliCtx)
+
+	err := runMerge("a", "b", "both", true)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid --keep")
+}
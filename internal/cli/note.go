@@ -0,0 +1,93 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ryacub/telos-idea-matrix/internal/cliutil"
+	"github.com/spf13/cobra"
+)
+
+func newNoteCommand() *cobra.Command {
+	var clearNotes bool
+	var replace bool
+
+	cmd := &cobra.Command{
+		Use:   "note <id> [text]",
+		Short: "Add your own notes to an idea",
+		Long: `Attach your own commentary to an idea, such as follow-up decisions,
+kept apart from the analysis.
+
+The text is added on a new line below any existing notes; use --replace to
+overwrite them and --clear to remove them. Without text, the idea's notes
+are printed. Notes are shown by 'tm show' and included in exports.
+
+Examples:
+  tm note abc123 "started prototyping 2025-06"
+  tm note abc123 --replace "parked until Q3"
+  tm note abc123 --clear
+  tm note abc123`,
+		Args:              cobra.MinimumNArgs(1),
+		ValidArgsFunction: completeIdeaIDs(1, ""),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			text := strings.TrimSpace(strings.Join(args[1:], " "))
+			switch {
+			case clearNotes && (text != "" || replace):
+				return fmt.Errorf("--clear cannot be used with note text or --replace")
+			case replace && text == "":
+				return fmt.Errorf("--replace needs the new note text")
+			}
+			return runNote(args[0], text, clearNotes, replace)
+		},
+	}
+
+	cmd.Flags().BoolVar(&clearNotes, "clear", false, "Remove the idea's notes")
+	cmd.Flags().BoolVar(&replace, "replace", false, "Overwrite the idea's notes instead of adding to them")
+
+	return cmd
+}
+
+func runNote(ideaID, text string, clearNotes, replace bool) error {
+	idea, err := ctx.Repository.GetByID(ideaID)
+	if err != nil {
+		idea, err = ctx.Repository.GetByPartialID(ideaID)
+		if err != nil {
+			return fmt.Errorf("idea not found: %s", ideaID)
+		}
+	}
+
+	if !clearNotes && text == "" {
+		if idea.Notes == "" {
+			_, _ = cliutil.InfoColor.Printf("Idea %s has no notes\n", idea.ID[:8])
+			return nil
+		}
+		fmt.Println(idea.Notes)
+		return nil
+	}
+
+	switch {
+	case clearNotes:
+		if idea.Notes == "" {
+			_, _ = cliutil.InfoColor.Printf("Idea %s has no notes\n", idea.ID[:8])
+			return nil
+		}
+		idea.Notes = ""
+	case replace:
+		idea.Notes = ""
+		idea.AddNote(text)
+	default:
+		idea.AddNote(text)
+	}
+
+	if err := ctx.Repository.Update(idea); err != nil {
+		return fmt.Errorf("failed to save notes: %w", err)
+	}
+
+	if clearNotes {
+		_, _ = cliutil.SuccessColor.Printf("✓ Cleared notes of %s: %s\n", idea.ID[:8], cliutil.TruncateText(idea.Content, 50))
+		return nil
+	}
+	_, _ = cliutil.SuccessColor.Printf("✓ Noted on %s: %s\n", idea.ID[:8], cliutil.TruncateText(idea.Content, 50))
+	printWrapped(idea.Notes, "  ")
+	return nil
+}
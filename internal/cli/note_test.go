@@ -0,0 +1,59 @@
+//go:build integration
+
+package cli
+
+import (
+	"testing"
+
+	"github.com/ryacub/telos-idea-matrix/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNoteCommand_AddsReplacesAndClearsNotes(t *testing.T) {
+	cliCtx, cleanup := setupTestCLI(t)
+	defer cleanup()
+
+	idea := models.NewIdea("Weekly Go newsletter")
+	require.NoError(t, cliCtx.Repository.Create(idea))
+
+	note := func(args ...string) string {
+		t.Helper()
+		cmd := GetRootCmd()
+		cmd.SetArgs(append([]string{"--telos", cliCtx.TelosPath, "--db", cliCtx.DBPath, "note", idea.ID[:8]}, args...))
+		require.NoError(t, cmd.Execute())
+		got, err := cliCtx.Repository.GetByID(idea.ID)
+		require.NoError(t, err)
+		return got.Notes
+	}
+
+	assert.Equal(t, "started prototyping 2025-06", note("started", "prototyping", "2025-06"))
+	assert.Equal(t, "started prototyping 2025-06\ndemoed to two users", note("demoed to two users"))
+	assert.Equal(t, "started prototyping 2025-06\ndemoed to two users", note(), "without text the notes are only printed")
+	assert.Equal(t, "parked until Q3", note("--replace", "parked until Q3"))
+	assert.Empty(t, note("--clear"))
+}
+
+func TestNoteCommand_RejectsConflictingFlags(t *testing.T) {
+	cliCtx, cleanup := setupTestCLI(t)
+	defer cleanup()
+
+	idea := models.NewIdea("Weekly Go newsletter")
+	require.NoError(t, cliCtx.Repository.Create(idea))
+
+	for _, args := range [][]string{
+		{"--clear", "some text"},
+		{"--clear", "--replace"},
+		{"--replace"},
+	} {
+		cmd := GetRootCmd()
+		cmd.SetArgs(append([]string{"--telos", cliCtx.TelosPath, "--db", cliCtx.DBPath, "note", idea.ID}, args...))
+		assert.Error(t, cmd.Execute(), "note %v", args)
+	}
+
+	cmd := GetRootCmd()
+	cmd.SetArgs([]string{"--telos", cliCtx.TelosPath, "--db", cliCtx.DBPath, "note", "does-not-exist", "text"})
+	err := cmd.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "idea not found")
+}
@@ -2,10 +2,13 @@ package cli
 
 import (
 	"fmt"
+	"os"
 	"sort"
 	"strings"
 
 	"github.com/fatih/color"
+	"github.com/ryacub/telos-idea-matrix/internal/cliutil"
+	"github.com/ryacub/telos-idea-matrix/internal/config"
 	"github.com/ryacub/telos-idea-matrix/internal/profile"
 	"github.com/spf13/cobra"
 )
@@ -14,11 +17,22 @@ func newProfileCommand() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "profile",
 		Short: "View your scoring profile",
-		Long:  `Display your current scoring profile and priorities.`,
-		RunE:  runProfile,
+		Long: `Display your current scoring profile and priorities.
+
+Named telos profiles let you score different kinds of ideas against
+different goals. Each lives at ~/.telos/profiles/<name>.md and is
+selected with --profile or 'tm profile use'.
+
+Examples:
+  tm profile                 # Show the scoring profile
+  tm profile list            # List telos profiles
+  tm profile use work        # Score against ~/.telos/profiles/work.md`,
+		RunE: runProfile,
 	}
 
 	cmd.AddCommand(newProfileResetCommand())
+	cmd.AddCommand(newProfileListCommand())
+	cmd.AddCommand(newProfileUseCommand())
 
 	return cmd
 }
@@ -39,6 +53,89 @@ func newProfileResetCommand() *cobra.Command {
 	}
 }
 
+func newProfileListCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List telos profiles",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runProfileList()
+		},
+		// Listing profiles doesn't need a database or scoring profile
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			return nil
+		},
+	}
+}
+
+func newProfileUseCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "use <name>",
+		Short: "Set the active telos profile",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runProfileUse(args[0])
+		},
+		// Switching profiles doesn't need a database or scoring profile
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			return nil
+		},
+	}
+}
+
+func runProfileList() error {
+	names, err := config.ListProfiles()
+	if err != nil {
+		return err
+	}
+
+	active := config.ActiveProfile()
+	for _, name := range names {
+		path := config.ProfileTelosPath(name, telosPath)
+		marker := " "
+		if name == active {
+			marker = "*"
+		}
+		line := fmt.Sprintf("%s %-16s %s", marker, name, path)
+		if !config.FileExists(path) {
+			line += " (missing)"
+		}
+		if name == active {
+			_, _ = cliutil.SuccessColor.Println(line)
+		} else {
+			fmt.Println(line)
+		}
+	}
+	return nil
+}
+
+func runProfileUse(name string) error {
+	if err := config.ValidateProfileName(name); err != nil {
+		return err
+	}
+
+	path := config.ProfileTelosPath(name, telosPath)
+	if name != config.DefaultProfile && !config.FileExists(path) {
+		return fmt.Errorf("telos profile %q not found; create %s first", name, path)
+	}
+
+	file, err := config.LoadFile(config.FilePath())
+	if err != nil {
+		return err
+	}
+	if err := file.Set("telos.profile", name); err != nil {
+		return err
+	}
+	if err := file.Save(); err != nil {
+		return err
+	}
+
+	_, _ = cliutil.SuccessColor.Printf("✓ Now using profile %s (%s)\n", name, path)
+	if env := os.Getenv("TELOS_PROFILE"); env != "" && env != name {
+		_, _ = cliutil.WarningColor.Printf("  Note: $TELOS_PROFILE=%s overrides this until unset\n", env)
+	}
+	return nil
+}
+
 func runProfile(cmd *cobra.Command, args []string) error {
 	if ctx.ScoringMode != ScoringModeUniversal || ctx.Profile == nil {
 		fmt.Println("No profile found. Using advanced telos.md mode.")
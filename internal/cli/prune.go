@@ -92,9 +92,10 @@ func runPrune(cmd *cobra.Command, args []string) error {
 	}
 
 	// Archive ideas
+	reason := pruneReason()
 	archived := 0
 	for _, idea := range toPrune {
-		idea.Status = "archived"
+		idea.Archive(reason)
 		if err := ctx.Repository.Update(idea); err != nil {
 			if _, printErr := cliutil.WarningColor.Printf("Failed to archive idea %s: %v\n", idea.ID[:8], err); printErr != nil {
 				log.Warn().Err(printErr).Msg("failed to print message")
@@ -109,3 +110,15 @@ func runPrune(cmd *cobra.Command, args []string) error {
 	}
 	return nil
 }
+
+// pruneReason describes the prune policy, recorded as each idea's archive reason
+func pruneReason() string {
+	switch {
+	case pruneDays > 0 && pruneScore > 0:
+		return fmt.Sprintf("prune: older than %d days, score below %.1f", pruneDays, pruneScore)
+	case pruneDays > 0:
+		return fmt.Sprintf("prune: older than %d days", pruneDays)
+	default:
+		return fmt.Sprintf("prune: score below %.1f", pruneScore)
+	}
+}
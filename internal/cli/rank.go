@@ -0,0 +1,96 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/ryacub/telos-idea-matrix/internal/analytics"
+	"github.com/ryacub/telos-idea-matrix/internal/cliutil"
+	"github.com/ryacub/telos-idea-matrix/internal/database"
+	"github.com/spf13/cobra"
+)
+
+func newRankCommand() *cobra.Command {
+	var limit int
+	var jsonOutput bool
+	weights := analytics.DefaultRankWeights()
+
+	cmd := &cobra.Command{
+		Use:   "rank",
+		Short: "Rank active ideas by score, recency and patterns",
+		Long: `Rank active ideas by a weighted combination of their final score, how
+recently they were captured, and how free they are of anti-patterns.
+
+Each component is normalized from 0 to 1: the score out of 10, recency from
+the oldest idea (0) to the newest (1), and patterns from 1 with no anti-patterns
+down by each one detected. The composite is their weighted average, so fresh,
+high-potential ideas rise above stale high scorers. Only the ratio between the
+weights matters.
+
+Examples:
+  tm rank                           # Top 10 with the default weights
+  tm rank --limit 20                # Top 20
+  tm rank --w-recency 0.5           # Favor fresh ideas more
+  tm rank --w-score 1 --w-recency 0 --w-pattern 0  # Plain score order
+  tm rank --json                    # Components and composite as JSON`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if limit < 0 {
+				return fmt.Errorf("--limit must not be negative")
+			}
+			if err := weights.Validate(); err != nil {
+				return err
+			}
+			return runRank(weights, limit, jsonOutput)
+		},
+	}
+
+	cmd.Flags().IntVarP(&limit, "limit", "l", 10, "Number of ideas to show (0 for all)")
+	cmd.Flags().Float64Var(&weights.Score, "w-score", weights.Score, "Weight of the final score")
+	cmd.Flags().Float64Var(&weights.Recency, "w-recency", weights.Recency, "Weight of recency (newer ranks higher)")
+	cmd.Flags().Float64Var(&weights.Pattern, "w-pattern", weights.Pattern, "Weight of pattern desirability (anti-patterns rank lower)")
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output as JSON")
+
+	return cmd
+}
+
+func runRank(weights analytics.RankWeights, limit int, jsonOutput bool) error {
+	ideas, err := ctx.Repository.List(database.ListOptions{
+		Status:  "active",
+		Profile: profileFilter(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list ideas: %w", err)
+	}
+
+	ranked := analytics.RankIdeas(ideas, weights)
+	if limit > 0 && len(ranked) > limit {
+		ranked = ranked[:limit]
+	}
+
+	if jsonOutput {
+		output, err := json.MarshalIndent(ranked, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(output))
+		return nil
+	}
+
+	if len(ranked) == 0 {
+		_, _ = cliutil.InfoColor.Println("No active ideas to rank.")
+		return nil
+	}
+
+	fmt.Printf("🏆 Top %d ideas (weights: score %.2g, recency %.2g, pattern %.2g)\n",
+		len(ranked), weights.Score, weights.Recency, weights.Pattern)
+	fmt.Println(strings.Repeat("─", 78))
+	fmt.Printf("%-4s %-10s %9s %6s %7s %7s  %s\n", "#", "ID", "Composite", "Score", "Recency", "Pattern", "Idea")
+	for i, r := range ranked {
+		fmt.Printf("%-4d %-10s %9.2f ", i+1, r.ID[:8], r.Composite)
+		_, _ = cliutil.GetScoreColor(r.FinalScore).Printf("%6.1f", r.FinalScore)
+		fmt.Printf(" %7.2f %7.2f  %s\n", r.Recency, r.Pattern, cliutil.TruncateText(r.Content, 30))
+	}
+
+	return nil
+}
@@ -1,6 +1,7 @@
 package cli
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -9,9 +10,12 @@ import (
 	"github.com/ryacub/telos-idea-matrix/internal/cli/bulk"
 	clierrors "github.com/ryacub/telos-idea-matrix/internal/cli/errors"
 	"github.com/ryacub/telos-idea-matrix/internal/cliutil"
+	"github.com/ryacub/telos-idea-matrix/internal/config"
 	"github.com/ryacub/telos-idea-matrix/internal/database"
 	"github.com/ryacub/telos-idea-matrix/internal/llm"
+	"github.com/ryacub/telos-idea-matrix/internal/logging"
 	"github.com/ryacub/telos-idea-matrix/internal/models"
+	"github.com/ryacub/telos-idea-matrix/internal/notify"
 	"github.com/ryacub/telos-idea-matrix/internal/patterns"
 	"github.com/ryacub/telos-idea-matrix/internal/profile"
 	"github.com/ryacub/telos-idea-matrix/internal/scoring"
@@ -38,18 +42,26 @@ type CLIContext struct {
 	Detector        *patterns.Detector
 	Telos           *models.Telos
 	Profile         *profile.Profile
+	PatternRules    []patterns.Rule      // User-defined pattern rules from --patterns-file
+	ScoreBounds     []profile.ScoreBound // Score floors and ceilings from the profile or score-bounds.yaml
 	LLMManager      *llm.Manager
+	Notifier        *notify.Batcher // Nil when webhook notifications are disabled
 	DBPath          string
 	TelosPath       string
 	ProfilePath     string
+	TelosProfile    string // Named telos profile new ideas are scored against
+	TelosVersion    string // Version of the telos or profile new ideas are scored against
 	ScoringMode     ScoringMode
 }
 
 var (
-	ctx       *CLIContext
-	dbPath    string
-	telosPath string
-	rootCmd   *cobra.Command
+	ctx          *CLIContext
+	dbPath       string
+	telosPath    string
+	patternsFile string
+	telosProfile string
+	logLevel     logLevelFlag
+	rootCmd      *cobra.Command
 )
 
 func init() {
@@ -67,31 +79,62 @@ Quick Start:
 
 Run 'tm <command> --help' for details on any command.`,
 		PersistentPreRunE: initializeCLI,
+		// Deliver notifications still waiting in a batch window before
+		// exiting, but don't let a down webhook and its retries hold the
+		// command up for longer than one attempt
+		PersistentPostRun: func(cmd *cobra.Command, args []string) {
+			if ctx != nil {
+				closeCtx, cancel := context.WithTimeout(context.Background(), notify.DefaultWebhookTimeout)
+				defer cancel()
+				ctx.Notifier.CloseContext(closeCtx)
+			}
+		},
 	}
 
 	// Global flags
-	homeDir, _ := os.UserHomeDir()
-	defaultTelosPath := filepath.Join(homeDir, ".telos", "telos.md")
-	defaultDBPath := filepath.Join(homeDir, ".telos", "ideas.db")
+	paths := config.ResolvePaths()
+	rootCmd.PersistentFlags().StringVar(&dbPath, "db", paths.DatabaseFile(), "Path to ideas database")
+	rootCmd.PersistentFlags().StringVar(&telosPath, "telos", paths.TelosFile(), "Path to telos.md file")
+	rootCmd.PersistentFlags().StringVar(&patternsFile, "patterns-file", filepath.Join(paths.ConfigDir, "patterns.yaml"), "Path to custom pattern rules (YAML)")
+	rootCmd.PersistentFlags().StringVar(&telosProfile, "profile", "", "Telos profile to use and filter by (default: the active profile, see 'tm profile list')")
+	rootCmd.PersistentFlags().Var(&logLevel, "log-level", "Log level: debug, info, warn or error (default: log.level, see 'tm config list')")
 
-	rootCmd.PersistentFlags().StringVar(&dbPath, "db", defaultDBPath, "Path to ideas database")
-	rootCmd.PersistentFlags().StringVar(&telosPath, "telos", defaultTelosPath, "Path to telos.md file")
+	// Before any command runs, including those that skip initializeCLI
+	cobra.OnInitialize(initLogging)
 
 	// Primary commands (new simplified UX)
 	rootCmd.AddCommand(newAddCommand())
+	rootCmd.AddCommand(newAnalyzeCommand())
 	rootCmd.AddCommand(newListCommand())
+	rootCmd.AddCommand(newSearchCommand())
+	rootCmd.AddCommand(newSimilarCommand())
+	rootCmd.AddCommand(newRankCommand())
 	rootCmd.AddCommand(newShowCommand())
 	rootCmd.AddCommand(newStatusCommand())
 
 	// Setup and config
 	rootCmd.AddCommand(newInitCommand())
 	rootCmd.AddCommand(newProfileCommand())
+	rootCmd.AddCommand(newTelosCommand())
+	rootCmd.AddCommand(newSimulateCommand())
+	rootCmd.AddCommand(newConfigCommand())
+	rootCmd.AddCommand(newSchemaCommand())
 
 	// Management commands
+	rootCmd.AddCommand(newArchiveCommand())
+	rootCmd.AddCommand(newNoteCommand())
+	rootCmd.AddCommand(newIdeaCommand())
+	rootCmd.AddCommand(newMergeCommand())
+	rootCmd.AddCommand(newTrashCommand())
 	rootCmd.AddCommand(newPruneCommand())
 	rootCmd.AddCommand(newLinkCommand())
 	rootCmd.AddCommand(analytics.NewAnalyticsCommand(getAnalyticsContext))
 	rootCmd.AddCommand(bulk.NewBulkCommand(getBulkContext))
+	rootCmd.AddCommand(newBackupCommand())
+	rootCmd.AddCommand(newRestoreCommand())
+	rootCmd.AddCommand(newExportCommand())
+	rootCmd.AddCommand(newImportCommand())
+	rootCmd.AddCommand(newDBCommand())
 
 	// AI/LLM management
 	rootCmd.AddCommand(NewLLMCommand())
@@ -107,6 +150,23 @@ func initializeCLI(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
+	// Completion requests open only what they need, once flags are parsed
+	if cmd.Name() == cobra.ShellCompRequestCmd || cmd.Name() == cobra.ShellCompNoDescRequestCmd {
+		return nil
+	}
+
+	// Resolve the telos profile; --telos still wins when given explicitly
+	profileName := telosProfile
+	if profileName == "" {
+		profileName = config.ActiveProfile()
+	}
+	if err := config.ValidateProfileName(profileName); err != nil {
+		return clierrors.WrapError(err, "Invalid profile")
+	}
+	if !cmd.Flags().Changed("telos") {
+		telosPath = config.ProfileTelosPath(profileName, telosPath)
+	}
+
 	// Detect which scoring mode to use
 	profilePath, _ := profile.DefaultPath()
 	hasProfile := profile.Exists(profilePath)
@@ -115,11 +175,28 @@ func initializeCLI(cmd *cobra.Command, args []string) error {
 		hasTelosFile = true
 	}
 
-	// Determine scoring mode and initialize accordingly
+	if err := models.SetRecommendationThresholds(config.LoadRecommendationThresholds()); err != nil {
+		return clierrors.WrapError(fmt.Errorf("recommendation thresholds: %w", err), "Invalid configuration")
+	}
+
+	// Load custom pattern rules; a missing file is only an error if explicitly requested
+	rules, err := loadPatternRules(patternsFile, cmd.Flags().Changed("patterns-file"))
+	if err != nil {
+		return clierrors.WrapError(err, "Failed to load pattern rules")
+	}
+
+	// Determine scoring mode and initialize accordingly.
+	// Named telos profiles always score against their own telos file.
+	if profileName != config.DefaultProfile {
+		if !hasTelosFile {
+			return clierrors.WrapError(fmt.Errorf("telos profile %q not found at %s", profileName, telosPath), "Initialization failed")
+		}
+		return initializeLegacyMode(rules, profileName)
+	}
 	if hasProfile {
-		return initializeUniversalMode(profilePath)
+		return initializeUniversalMode(profilePath, rules)
 	} else if hasTelosFile {
-		return initializeLegacyMode()
+		return initializeLegacyMode(rules, profileName)
 	} else {
 		// No configuration found - prompt user to run init
 		_, _ = cliutil.WarningColor.Fprintf(os.Stderr, "⚠️  No configuration found.\n")
@@ -128,29 +205,93 @@ func initializeCLI(cmd *cobra.Command, args []string) error {
 	}
 }
 
+// logLevelFlag is the --log-level flag, validated as it's parsed
+type logLevelFlag string
+
+func (f *logLevelFlag) String() string { return string(*f) }
+
+func (f *logLevelFlag) Set(value string) error {
+	if _, err := logging.ParseLevel(value); err != nil {
+		return err
+	}
+	*f = logLevelFlag(value)
+	return nil
+}
+
+func (f *logLevelFlag) Type() string { return "string" }
+
+// initLogging applies the configured log settings, with --log-level taking
+// precedence. Logs go to stderr unless log.output names another destination,
+// so they never mix with command output.
+func initLogging() {
+	cfg := config.LoadLogConfig()
+	if logLevel != "" {
+		cfg.Level = string(logLevel)
+	}
+	output := cfg.Output
+	if output == "" {
+		output = "stderr"
+	}
+
+	logging.NewLogger(logging.Config{
+		Level:      cfg.Level,
+		Format:     cfg.Format,
+		OutputPath: output,
+		MaxSizeMB:  10,
+		MaxBackups: 7,
+		MaxAgeDays: 7,
+	})
+}
+
+// loadPatternRules loads user-defined pattern rules from path.
+// A missing file yields no rules unless the path was explicitly requested.
+func loadPatternRules(path string, explicit bool) ([]patterns.Rule, error) {
+	if path == "" {
+		return nil, nil
+	}
+	if _, err := os.Stat(path); os.IsNotExist(err) && !explicit {
+		return nil, nil
+	}
+	return patterns.LoadRules(path)
+}
+
+// newLLMManager creates the LLM manager, honoring a configured default provider
+func newLLMManager() *llm.Manager {
+	llmConfig := llm.DefaultManagerConfig()
+	settings := config.LoadLLMConfig()
+	llmConfig.DefaultProvider = settings.DefaultProvider
+	llmConfig.ProviderConfig.SystemPrompts = settings.SystemPrompts
+	if settings.HealthCheckTimeout > 0 {
+		llmConfig.HealthCheckTimeout = settings.HealthCheckTimeout
+	}
+	manager := llm.NewManager(llmConfig)
+	if err := manager.LoadPromptTemplates(config.ResolvePaths().PromptsDir()); err != nil {
+		_, _ = cliutil.WarningColor.Fprintf(os.Stderr, "⚠️  Using the built-in prompt instead of invalid templates: %v\n", err)
+	}
+	return manager
+}
+
 // initializeUniversalMode sets up the context with profile-based universal scoring
-func initializeUniversalMode(profilePath string) error {
+func initializeUniversalMode(profilePath string, rules []patterns.Rule) error {
 	// Load profile
 	p, err := profile.Load(profilePath)
 	if err != nil {
 		return clierrors.WrapError(err, "Failed to load profile")
 	}
-
-	// Determine database path
-	profileDir, _ := profile.DefaultDir()
-	actualDBPath := dbPath
-	if actualDBPath == "" || actualDBPath == filepath.Join(os.Getenv("HOME"), ".telos", "ideas.db") {
-		// Use brain-salad directory for new installs
-		actualDBPath = filepath.Join(profileDir, "ideas.db")
+	version, err := telos.Version(profilePath)
+	if err != nil {
+		return clierrors.WrapError(err, "Failed to read profile")
 	}
 
+	actualDBPath := universalDBPath()
+
 	// Create directory if needed
 	if err := os.MkdirAll(filepath.Dir(actualDBPath), 0755); err != nil {
 		return clierrors.WrapError(err, "Failed to create data directory")
 	}
 
 	// Initialize database
-	repo, err := database.NewRepository(actualDBPath)
+	repo, err := openRepository(actualDBPath)
 	if err != nil {
 		return clierrors.WrapError(err, "Failed to initialize database")
 	}
@@ -159,25 +300,55 @@ func initializeUniversalMode(profilePath string) error {
 	universalEngine := scoring.NewUniversalEngine(p)
 
 	// Initialize LLM Manager
-	llmConfig := llm.DefaultManagerConfig()
-	llmManager := llm.NewManager(llmConfig)
+	llmManager := newLLMManager()
 
 	// Store in shared context
 	ctx = &CLIContext{
 		Repository:      repo,
 		UniversalEngine: universalEngine,
+		Detector:        patterns.NewDetectorWithRules(nil, rules), // Built-in and custom patterns; there's no telos
 		Profile:         p,
+		PatternRules:    rules,
+		ScoreBounds:     p.ScoreBounds,
 		LLMManager:      llmManager,
+		Notifier:        notify.FromConfig(config.LoadNotifyConfig()),
 		DBPath:          actualDBPath,
 		ProfilePath:     profilePath,
+		TelosProfile:    config.DefaultProfile,
+		TelosVersion:    version,
 		ScoringMode:     ScoringModeUniversal,
 	}
 
 	return nil
 }
 
+// universalDBPath returns the database used in universal mode: --db when it
+// points somewhere other than the default, otherwise the default from
+// config.ResolvePaths. A database left beside the profile by earlier
+// versions is kept, so upgrades don't lose ideas.
+func universalDBPath() string {
+	defaultPath := config.ResolvePaths().DatabaseFile()
+	if dbPath != "" && dbPath != defaultPath {
+		return dbPath
+	}
+	if profileDir, err := profile.DefaultDir(); err == nil {
+		if legacy := filepath.Join(profileDir, "ideas.db"); config.FileExists(legacy) {
+			return legacy
+		}
+	}
+	return defaultPath
+}
+
+// openRepository opens the database at path with the connection settings
+// from the config file and environment
+func openRepository(path string) (*database.Repository, error) {
+	cfg := config.LoadDatabaseConfig()
+	cfg.Path = path
+	return database.NewRepositoryWithConfig(cfg)
+}
+
 // initializeLegacyMode sets up the context with traditional telos.md-based scoring
-func initializeLegacyMode() error {
+func initializeLegacyMode(rules []patterns.Rule, profileName string) error {
 	// Create .telos directory if it doesn't exist
 	telosDir := filepath.Dir(telosPath)
 	if err := os.MkdirAll(telosDir, 0755); err != nil {
@@ -190,31 +361,44 @@ func initializeLegacyMode() error {
 	if err != nil {
 		return clierrors.WrapError(err, "Failed to parse telos.md")
 	}
+	version, err := telos.Version(telosPath)
+	if err != nil {
+		return clierrors.WrapError(err, "Failed to read telos.md")
+	}
+
+	bounds, err := profile.LoadScoreBounds(config.ResolvePaths().ScoreBoundsFile())
+	if err != nil {
+		return clierrors.WrapError(err, "Failed to load score bounds")
+	}
 
 	// Initialize database
-	repo, err := database.NewRepository(dbPath)
+	repo, err := openRepository(dbPath)
 	if err != nil {
 		return clierrors.WrapError(err, "Failed to initialize database")
 	}
 
 	// Create scoring engine and pattern detector
 	engine := scoring.NewEngine(telosData)
-	detector := patterns.NewDetector(telosData)
+	detector := patterns.NewDetectorWithRules(telosData, rules)
 
 	// Initialize LLM Manager
-	llmConfig := llm.DefaultManagerConfig()
-	llmManager := llm.NewManager(llmConfig)
+	llmManager := newLLMManager()
 
 	// Store in shared context
 	ctx = &CLIContext{
-		Repository:  repo,
-		Engine:      engine,
-		Detector:    detector,
-		Telos:       telosData,
-		LLMManager:  llmManager,
-		DBPath:      dbPath,
-		TelosPath:   telosPath,
-		ScoringMode: ScoringModeLegacy,
+		Repository:   repo,
+		Engine:       engine,
+		Detector:     detector,
+		Telos:        telosData,
+		PatternRules: rules,
+		ScoreBounds:  bounds,
+		LLMManager:   llmManager,
+		Notifier:     notify.FromConfig(config.LoadNotifyConfig()),
+		DBPath:       dbPath,
+		TelosPath:    telosPath,
+		TelosProfile: profileName,
+		TelosVersion: version,
+		ScoringMode:  ScoringModeLegacy,
 	}
 
 	return nil
@@ -270,6 +454,17 @@ func ClearContext() {
 	// Also reset the global flag variables
 	dbPath = ""
 	telosPath = ""
+	patternsFile = ""
+	telosProfile = ""
+}
+
+// profileFilter returns the profile to restrict listings to: the active
+// profile when --profile was given explicitly, otherwise "" for all profiles
+func profileFilter() string {
+	if ctx == nil || !rootCmd.PersistentFlags().Changed("profile") {
+		return ""
+	}
+	return ctx.TelosProfile
 }
 
 // getAnalyticsContext converts CLIContext to analytics.CLIContext
@@ -280,6 +475,8 @@ func getAnalyticsContext() *analytics.CLIContext {
 	return &analytics.CLIContext{
 		Repository: ctx.Repository,
 		DBPath:     ctx.DBPath,
+		Telos:      ctx.Telos,
+		Profile:    profileFilter(),
 	}
 }
 
@@ -289,8 +486,13 @@ func getBulkContext() *bulk.CLIContext {
 		return nil
 	}
 	return &bulk.CLIContext{
-		Repository: ctx.Repository,
-		Telos:      ctx.Telos,
-		LLMManager: ctx.LLMManager,
+		Repository:   ctx.Repository,
+		DBPath:       ctx.DBPath,
+		Telos:        ctx.Telos,
+		PatternRules: ctx.PatternRules,
+		ScoreBounds:  ctx.ScoreBounds,
+		LLMManager:   ctx.LLMManager,
+		Notifier:     ctx.Notifier,
+		NewEmbedder:  newEmbedder,
 	}
 }
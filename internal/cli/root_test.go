@@ -0,0 +1,34 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUniversalDBPath(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("TELOS_HOME", "")
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_DATA_HOME", filepath.Join(home, "xdg-data"))
+
+	saved := dbPath
+	t.Cleanup(func() { dbPath = saved })
+
+	// New installs share the database the rest of the CLI and web server use
+	dbPath = ""
+	assert.Equal(t, filepath.Join(home, "xdg-data", "telos", "ideas.db"), universalDBPath())
+
+	dbPath = filepath.Join(home, "elsewhere.db")
+	assert.Equal(t, dbPath, universalDBPath())
+
+	// A database earlier versions kept beside the profile stays in use
+	legacy := filepath.Join(home, ".brain-salad", "ideas.db")
+	require.NoError(t, os.MkdirAll(filepath.Dir(legacy), 0755))
+	require.NoError(t, os.WriteFile(legacy, nil, 0600))
+	dbPath = ""
+	assert.Equal(t, legacy, universalDBPath())
+}
@@ -0,0 +1,56 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/ryacub/telos-idea-matrix/internal/jsonschema"
+	"github.com/spf13/cobra"
+)
+
+func newSchemaCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "schema <idea|analysis|telos>",
+		Short: "Print the JSON Schema of a model",
+		Long: `Print a JSON Schema (draft 2020-12) describing the JSON form of a model, so
+exports and API payloads can be checked with any JSON Schema validator.
+
+Fields that are always written are required. Fields limited to known values,
+such as an idea's status and recommendation, list them as an enum, and the
+rules the models enforce when saving, such as lengths, carry over.
+
+Models:
+  idea       An idea, as in JSON and NDJSON exports
+  analysis   The scoring breakdown of an idea
+  telos      A parsed telos file
+
+Examples:
+  tm schema idea > idea.schema.json
+  tm schema analysis | jq '.properties | keys'`,
+		Args:      cobra.ExactArgs(1),
+		ValidArgs: jsonschema.ModelNames,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runSchema(args[0])
+		},
+		// Schemas don't need a database or scoring profile
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+func runSchema(model string) error {
+	schema, err := jsonschema.ForModel(model)
+	if err != nil {
+		return err
+	}
+
+	output, err := json.MarshalIndent(schema, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode schema: %w", err)
+	}
+	fmt.Println(string(output))
+	return nil
+}
@@ -0,0 +1,178 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ryacub/telos-idea-matrix/internal/cliutil"
+	"github.com/ryacub/telos-idea-matrix/internal/database"
+	"github.com/ryacub/telos-idea-matrix/internal/models"
+	"github.com/spf13/cobra"
+)
+
+type searchOptions struct {
+	minScore      float64
+	maxScore      float64
+	status        string
+	pattern       string
+	tag           string
+	createdAfter  string
+	createdBefore string
+	contains      string
+	limit         int
+	format        string
+}
+
+func newSearchCommand() *cobra.Command {
+	var opts searchOptions
+
+	cmd := &cobra.Command{
+		Use:   "search [text]",
+		Short: "Find ideas by combining filters",
+		Long: `Search saved ideas by score, status, pattern, tag, date, and content.
+
+All filters are combined (AND). Dates accept YYYY-MM-DD or RFC3339;
+--created-before is exclusive.
+
+Examples:
+  tm search podcast                          # Content contains "podcast"
+  tm search --min-score 7 --tag work         # High-scoring work ideas
+  tm search --pattern perfectionism          # Ideas with a pattern
+  tm search --created-after 2025-01-01 --created-before 2025-02-01
+  tm search --status archived --format json  # JSON output for scripting`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 1 && opts.contains == "" {
+				opts.contains = args[0]
+			}
+
+			listOpts, err := buildSearchListOptions(opts, cmd.Flags().Changed("min-score"), cmd.Flags().Changed("max-score"))
+			if err != nil {
+				return err
+			}
+
+			ideas, err := ctx.Repository.List(listOpts)
+			if err != nil {
+				return fmt.Errorf("failed to search: %w", err)
+			}
+
+			switch opts.format {
+			case "json":
+				return outputListJSON(ideas, nil)
+			case "text":
+				return outputIdeaTable(ideas)
+			default:
+				return fmt.Errorf("invalid format %q: must be text or json", opts.format)
+			}
+		},
+	}
+
+	cmd.Flags().Float64Var(&opts.minScore, "min-score", 0, "Minimum score")
+	cmd.Flags().Float64Var(&opts.maxScore, "max-score", 0, "Maximum score")
+	cmd.Flags().StringVar(&opts.status, "status", "", "Status (active|archived|deleted), default all but deleted")
+	cmd.Flags().StringVar(&opts.pattern, "pattern", "", "Detected pattern name")
+	cmd.Flags().StringVar(&opts.tag, "tag", "", "Tag")
+	cmd.Flags().StringVar(&opts.createdAfter, "created-after", "", "Created on or after date (YYYY-MM-DD)")
+	cmd.Flags().StringVar(&opts.createdBefore, "created-before", "", "Created before date (YYYY-MM-DD)")
+	cmd.Flags().StringVar(&opts.contains, "contains", "", "Content contains text")
+	cmd.Flags().IntVarP(&opts.limit, "limit", "l", 20, "Max ideas to show (0 for all)")
+	cmd.Flags().StringVar(&opts.format, "format", "text", "Output format: text|json")
+
+	return cmd
+}
+
+// buildSearchListOptions translates search flags into a single repository query
+func buildSearchListOptions(opts searchOptions, hasMin, hasMax bool) (database.ListOptions, error) {
+	listOpts := database.ListOptions{
+		Status:   opts.status,
+		Pattern:  strings.TrimSpace(opts.pattern),
+		Tag:      strings.TrimSpace(opts.tag),
+		Contains: strings.TrimSpace(opts.contains),
+		OrderBy:  database.OrderBy(database.SortByFinalScore, database.Descending),
+	}
+
+	if opts.status != "" && !isValidStatus(opts.status) {
+		return listOpts, fmt.Errorf("invalid status %q: must be active, archived, or deleted", opts.status)
+	}
+
+	if hasMin {
+		listOpts.MinScore = &opts.minScore
+	}
+	if hasMax {
+		listOpts.MaxScore = &opts.maxScore
+	}
+	if hasMin && hasMax && opts.minScore > opts.maxScore {
+		return listOpts, fmt.Errorf("--min-score cannot be greater than --max-score")
+	}
+
+	if opts.createdAfter != "" {
+		t, err := parseSearchDate(opts.createdAfter)
+		if err != nil {
+			return listOpts, fmt.Errorf("invalid --created-after: %w", err)
+		}
+		listOpts.CreatedAfter = &t
+	}
+	if opts.createdBefore != "" {
+		t, err := parseSearchDate(opts.createdBefore)
+		if err != nil {
+			return listOpts, fmt.Errorf("invalid --created-before: %w", err)
+		}
+		listOpts.CreatedBefore = &t
+	}
+
+	if opts.limit < 0 {
+		return listOpts, fmt.Errorf("--limit cannot be negative")
+	}
+	if opts.limit > 0 {
+		listOpts.Limit = &opts.limit
+	}
+
+	return listOpts, nil
+}
+
+// parseSearchDate parses a date as YYYY-MM-DD (local midnight) or RFC3339
+func parseSearchDate(value string) (time.Time, error) {
+	if t, err := time.ParseInLocation("2006-01-02", value, time.Local); err == nil {
+		return t, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("expected YYYY-MM-DD or RFC3339, got %q", value)
+	}
+	return t, nil
+}
+
+func isValidStatus(status string) bool {
+	switch models.IdeaStatus(status) {
+	case models.StatusActive, models.StatusArchived, models.StatusDeleted:
+		return true
+	}
+	return false
+}
+
+// outputIdeaTable prints one row per idea: short ID, score, recommendation and
+// content
+func outputIdeaTable(ideas []*models.Idea) error {
+	if len(ideas) == 0 {
+		_, _ = cliutil.InfoColor.Println("No ideas match your search.")
+		return nil
+	}
+
+	fmt.Printf("%-8s  %5s  %-22s  %s\n", "ID", "SCORE", "RECOMMENDATION", "CONTENT")
+	fmt.Println(strings.Repeat("─", 80))
+
+	for _, idea := range ideas {
+		scoreColor := cliutil.GetScoreColor(idea.FinalScore)
+		fmt.Printf("%-8s  ", idea.ID[:8])
+		_, _ = scoreColor.Printf("%5.1f", idea.FinalScore)
+		fmt.Printf("  %-22s  %s\n",
+			cliutil.TruncateText(idea.Recommendation, 19),
+			cliutil.TruncateText(idea.Content, 37))
+	}
+
+	fmt.Println(strings.Repeat("─", 80))
+	_, _ = cliutil.InfoColor.Printf("%d ideas\n", len(ideas))
+
+	return nil
+}
@@ -0,0 +1,77 @@
+package cli
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBuildSearchListOptions(t *testing.T) {
+	opts := searchOptions{
+		minScore:      6.5,
+		maxScore:      9,
+		status:        "active",
+		pattern:       " perfectionism ",
+		tag:           "work",
+		createdAfter:  "2025-01-01",
+		createdBefore: "2025-02-01T00:00:00Z",
+		contains:      "podcast",
+		limit:         5,
+	}
+
+	listOpts, err := buildSearchListOptions(opts, true, true)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if listOpts.MinScore == nil || *listOpts.MinScore != 6.5 {
+		t.Errorf("expected min score 6.5, got %v", listOpts.MinScore)
+	}
+	if listOpts.MaxScore == nil || *listOpts.MaxScore != 9 {
+		t.Errorf("expected max score 9, got %v", listOpts.MaxScore)
+	}
+	if listOpts.Status != "active" || listOpts.Pattern != "perfectionism" || listOpts.Tag != "work" || listOpts.Contains != "podcast" {
+		t.Errorf("unexpected string filters: %+v", listOpts)
+	}
+	if listOpts.CreatedAfter == nil || listOpts.CreatedAfter.Format("2006-01-02") != "2025-01-01" {
+		t.Errorf("unexpected created after: %v", listOpts.CreatedAfter)
+	}
+	if listOpts.CreatedBefore == nil || !listOpts.CreatedBefore.Equal(time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected created before: %v", listOpts.CreatedBefore)
+	}
+	if listOpts.Limit == nil || *listOpts.Limit != 5 {
+		t.Errorf("expected limit 5, got %v", listOpts.Limit)
+	}
+}
+
+func TestBuildSearchListOptions_OmitsUnsetFilters(t *testing.T) {
+	listOpts, err := buildSearchListOptions(searchOptions{}, false, false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if listOpts.MinScore != nil || listOpts.MaxScore != nil || listOpts.CreatedAfter != nil ||
+		listOpts.CreatedBefore != nil || listOpts.Limit != nil {
+		t.Errorf("expected unset filters to be nil, got %+v", listOpts)
+	}
+}
+
+func TestBuildSearchListOptions_InvalidInput(t *testing.T) {
+	tests := []struct {
+		name   string
+		opts   searchOptions
+		hasMin bool
+		hasMax bool
+	}{
+		{"bad status", searchOptions{status: "done"}, false, false},
+		{"min above max", searchOptions{minScore: 8, maxScore: 2}, true, true},
+		{"bad date", searchOptions{createdAfter: "last week"}, false, false},
+		{"negative limit", searchOptions{limit: -1}, false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := buildSearchListOptions(tt.opts, tt.hasMin, tt.hasMax); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
@@ -3,10 +3,13 @@ package cli
 import (
 	"encoding/json"
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/rs/zerolog/log"
+	"github.com/ryacub/telos-idea-matrix/internal/analytics"
 	"github.com/ryacub/telos-idea-matrix/internal/cliutil"
+	"github.com/ryacub/telos-idea-matrix/internal/config"
 	"github.com/ryacub/telos-idea-matrix/internal/database"
 	"github.com/ryacub/telos-idea-matrix/internal/models"
 	"github.com/spf13/cobra"
@@ -18,6 +21,7 @@ var _ = log.Logger
 func newShowCommand() *cobra.Command {
 	var last bool
 	var jsonOutput bool
+	var relative bool
 
 	cmd := &cobra.Command{
 		Use:   "show <id>",
@@ -27,8 +31,10 @@ func newShowCommand() *cobra.Command {
 Examples:
   tm show abc123              # Show idea by ID
   tm show --last              # Show most recent idea
-  tm show abc123 --json       # JSON output`,
-		Aliases: []string{"view", "get"},
+  tm show abc123 --json       # JSON output
+  tm show abc123 --relative   # Include the score's rank among your active ideas`,
+		Aliases:           []string{"view", "get"},
+		ValidArgsFunction: completeIdeaIDs(1, ""),
 		Args: func(cmd *cobra.Command, args []string) error {
 			lastFlag, _ := cmd.Flags().GetBool("last")
 			if !lastFlag && len(args) < 1 {
@@ -45,7 +51,7 @@ Examples:
 				limit := 1
 				ideas, err := ctx.Repository.List(database.ListOptions{
 					Status:  "active",
-					OrderBy: "created_at DESC",
+					OrderBy: database.OrderBy(database.SortByCreatedAt, database.Descending),
 					Limit:   &limit,
 				})
 				if err != nil {
@@ -68,15 +74,27 @@ Examples:
 				}
 			}
 
+			// Rank against the ideas scored with the same telos profile
+			scores, err := relativeScores(relative, idea.Profile)
+			if err != nil {
+				return err
+			}
+
+			moves, err := ctx.Repository.IdeaMoves(idea.ID)
+			if err != nil {
+				return err
+			}
+
 			if jsonOutput {
-				return outputShowJSON(idea)
+				return outputShowJSON(idea, scores, moves)
 			}
-			return outputShowFull(idea)
+			return outputShowFull(idea, scores, moves)
 		},
 	}
 
 	cmd.Flags().BoolVar(&last, "last", false, "Show most recent idea")
 	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output as JSON")
+	cmd.Flags().BoolVar(&relative, "relative", false, "Show the score's percentile among your active ideas")
 
 	return cmd
 }
@@ -85,31 +103,46 @@ type showResult struct {
 	ID              string                 `json:"id"`
 	Content         string                 `json:"content"`
 	Score           float64                `json:"score"`
+	Percentile      *float64               `json:"percentile,omitempty"`
 	Recommendation  string                 `json:"recommendation"`
 	Patterns        []string               `json:"patterns,omitempty"`
+	Trigger         string                 `json:"trigger,omitempty"`
+	Status          string                 `json:"status"`
+	Profile         string                 `json:"profile"`
+	ArchiveReason   string                 `json:"archive_reason,omitempty"`
+	Moves           []*models.IdeaMove     `json:"moves,omitempty"`
 	AnalysisDetails map[string]interface{} `json:"analysis,omitempty"`
+	AnalysisText    string                 `json:"analysis_text,omitempty"`
+	Notes           string                 `json:"notes,omitempty"`
 	CreatedAt       string                 `json:"created_at"`
 	UpdatedAt       string                 `json:"updated_at"`
 }
 
-func outputShowJSON(idea *models.Idea) error {
-	updatedAt := idea.CreatedAt
-	if idea.ReviewedAt != nil {
-		updatedAt = *idea.ReviewedAt
-	}
-
+func outputShowJSON(idea *models.Idea, scores []float64, moves []*models.IdeaMove) error {
 	result := showResult{
 		ID:             idea.ID,
 		Content:        idea.Content,
 		Score:          idea.FinalScore,
 		Recommendation: idea.Recommendation,
 		Patterns:       idea.Patterns,
+		Trigger:        idea.Trigger,
+		Status:         idea.Status,
+		Profile:        idea.Profile,
+		ArchiveReason:  idea.ArchiveReason,
+		Moves:          moves,
+		Notes:          idea.Notes,
 		CreatedAt:      idea.CreatedAt.Format("2006-01-02T15:04:05Z"),
-		UpdatedAt:      updatedAt.Format("2006-01-02T15:04:05Z"),
+		UpdatedAt:      idea.UpdatedAt.UTC().Format("2006-01-02T15:04:05Z"),
+	}
+	if scores != nil {
+		percentile := analytics.Percentile(idea.FinalScore, scores)
+		result.Percentile = &percentile
 	}
 
-	// Parse analysis details if available
-	if idea.AnalysisDetails != "" {
+	// Parse analysis details if available; older ideas store plain text
+	if text, ok := idea.AnalysisText(); ok {
+		result.AnalysisText = text
+	} else if idea.AnalysisDetails != "" {
 		var analysis map[string]interface{}
 		if err := json.Unmarshal([]byte(idea.AnalysisDetails), &analysis); err == nil {
 			result.AnalysisDetails = analysis
@@ -124,7 +157,7 @@ func outputShowJSON(idea *models.Idea) error {
 	return nil
 }
 
-func outputShowFull(idea *models.Idea) error {
+func outputShowFull(idea *models.Idea, scores []float64, moves []*models.IdeaMove) error {
 	fmt.Println(strings.Repeat("═", 60))
 
 	// Header
@@ -135,9 +168,28 @@ func outputShowFull(idea *models.Idea) error {
 	// Content
 	fmt.Printf("%s\n\n", idea.Content)
 
+	// Trigger
+	if idea.Trigger != "" {
+		_, _ = cliutil.InfoColor.Printf("Trigger: %s\n\n", idea.Trigger)
+	}
+
+	// Archival
+	if idea.Status == string(models.StatusArchived) {
+		if idea.ArchiveReason != "" {
+			_, _ = cliutil.WarningColor.Printf("Archived: %s\n\n", idea.ArchiveReason)
+		} else {
+			_, _ = cliutil.WarningColor.Println("Archived")
+			fmt.Println()
+		}
+	}
+
 	// Score
 	scoreColor := cliutil.GetScoreColor(idea.FinalScore)
-	_, _ = scoreColor.Printf("Score: %.1f/10.0\n", idea.FinalScore)
+	_, _ = scoreColor.Printf("Score: %.1f/10.0", idea.FinalScore)
+	if scores != nil {
+		fmt.Printf(" — %s", analytics.RelativeLabel(analytics.Percentile(idea.FinalScore, scores)))
+	}
+	fmt.Println()
 
 	// Recommendation
 	if idea.Recommendation != "" {
@@ -148,23 +200,44 @@ func outputShowFull(idea *models.Idea) error {
 
 	// Analysis details
 	if idea.AnalysisDetails != "" {
-		displayStoredAnalysis(idea.AnalysisDetails)
+		displayStoredAnalysis(idea)
 	}
 
 	// Patterns
-	if len(idea.Patterns) > 0 {
+	patterns := idea.Patterns
+	if config.LoadDisplayConfig().CollapsePatterns {
+		patterns = analytics.CanonicalizePatternsForDisplay(patterns)
+	}
+	if len(patterns) > 0 {
 		_, _ = cliutil.WarningColor.Println("Patterns Detected:")
-		for _, p := range idea.Patterns {
+		for _, p := range patterns {
 			fmt.Printf("  • %s\n", p)
 		}
 		fmt.Println()
 	}
 
+	// Notes
+	if idea.Notes != "" {
+		_, _ = cliutil.InfoColor.Println("Notes:")
+		printWrapped(idea.Notes, "  ")
+		fmt.Println()
+	}
+
 	// Metadata
 	fmt.Println(strings.Repeat("─", 60))
 	fmt.Printf("Created: %s\n", idea.CreatedAt.Format("Jan 2, 2006 3:04 PM"))
+	if !idea.UpdatedAt.IsZero() {
+		fmt.Printf("Updated: %s (%s)\n", idea.UpdatedAt.Local().Format("Jan 2, 2006 3:04 PM"), cliutil.TimeAgo(idea.UpdatedAt))
+	}
 	if idea.ReviewedAt != nil {
-		fmt.Printf("Updated: %s\n", idea.ReviewedAt.Format("Jan 2, 2006 3:04 PM"))
+		fmt.Printf("Reviewed: %s\n", idea.ReviewedAt.Format("Jan 2, 2006 3:04 PM"))
+	}
+	for _, move := range moves {
+		line := fmt.Sprintf("Moved: %s → %s on %s", move.FromProfile, move.ToProfile, move.MovedAt.Local().Format("Jan 2, 2006 3:04 PM"))
+		if move.Reanalyzed {
+			line += " (re-analyzed)"
+		}
+		fmt.Println(line)
 	}
 	fmt.Printf("ID: %s\n", idea.ID)
 	fmt.Println(strings.Repeat("═", 60))
@@ -172,7 +245,7 @@ func outputShowFull(idea *models.Idea) error {
 	return nil
 }
 
-func displayStoredAnalysis(analysisJSON string) {
+func displayStoredAnalysis(idea *models.Idea) {
 	// Try to parse as universal analysis first
 	var universalAnalysis struct {
 		Universal struct {
@@ -185,7 +258,7 @@ func displayStoredAnalysis(analysisJSON string) {
 		} `json:"universal"`
 	}
 
-	if err := json.Unmarshal([]byte(analysisJSON), &universalAnalysis); err == nil {
+	if err := json.Unmarshal([]byte(idea.AnalysisDetails), &universalAnalysis); err == nil {
 		u := universalAnalysis.Universal
 		if u.CompletionLikelihood > 0 || u.SkillFit > 0 {
 			_, _ = cliutil.InfoColor.Println("Score Breakdown:")
@@ -196,18 +269,144 @@ func displayStoredAnalysis(analysisJSON string) {
 	}
 
 	// Try legacy mode
-	var analysis models.Analysis
-	if err := json.Unmarshal([]byte(analysisJSON), &analysis); err != nil {
+	stored, ok := parseStoredAnalysis(idea)
+	if !ok {
 		return
 	}
 
-	_, _ = cliutil.InfoColor.Println("Score Breakdown:")
-	fmt.Printf("  Mission Alignment:  %.2f/4.00\n", analysis.Mission.Total)
-	fmt.Printf("  Anti-Challenge:     %.2f/3.50\n", analysis.AntiChallenge.Total)
-	fmt.Printf("  Strategic Fit:      %.2f/2.50\n", analysis.Strategic.Total)
+	if stored.Text != "" {
+		// Bulk analyze saves the recommendation itself when there is no reasoning
+		if stored.Text == idea.Recommendation {
+			return
+		}
+		_, _ = cliutil.InfoColor.Println("Analysis:")
+		printWrapped(stored.Text, "  ")
+		fmt.Println()
+		return
+	}
+
+	if stored.Provider != "" {
+		_, _ = cliutil.InfoColor.Printf("Score Breakdown (%s):\n", stored.Provider)
+	} else {
+		_, _ = cliutil.InfoColor.Println("Score Breakdown:")
+	}
+	for _, category := range analysisCategories {
+		fmt.Printf("  %-20s%.2f/%.2f\n", category.label+":", stored.Scores[category.key], category.max)
+		if explanation := stored.Explanations[category.key]; explanation != "" {
+			printWrapped(explanation, "    ")
+		}
+	}
+
+	// Reasoning that isn't tied to a category, such as "overall"
+	var others []string
+	for key, explanation := range stored.Explanations {
+		if explanation != "" && !isAnalysisCategory(key) {
+			others = append(others, key)
+		}
+	}
+	sort.Strings(others)
+	for _, key := range others {
+		fmt.Printf("  %s:\n", explanationLabel(key))
+		printWrapped(stored.Explanations[key], "    ")
+	}
 	fmt.Println()
 }
 
+// analysisCategories are the legacy scoring categories, keyed the way LLM
+// providers name them in their explanations
+var analysisCategories = []struct {
+	key   string
+	label string
+	max   float64
+}{
+	{"mission_alignment", "Mission Alignment", 4.0},
+	{"anti_challenge", "Anti-Challenge", 3.5},
+	{"strategic_fit", "Strategic Fit", 2.5},
+}
+
+func isAnalysisCategory(key string) bool {
+	for _, category := range analysisCategories {
+		if category.key == key {
+			return true
+		}
+	}
+	return false
+}
+
+// storedAnalysis is a legacy AnalysisDetails value, normalized across the
+// formats it has been saved in
+type storedAnalysis struct {
+	Provider     string             // LLM provider, when bulk analyze recorded it
+	Scores       map[string]float64 // Category totals, keyed like analysisCategories
+	Explanations map[string]string  // Reasoning per category, plus e.g. "overall"
+	Text         string             // Plain-text details saved by older versions
+}
+
+// parseStoredAnalysis reads the map bulk analyze saves for LLM results, a
+// serialized models.Analysis, or plain text, bare or wrapped by
+// 'tm db repair-analysis'. It reports false for empty details and for JSON
+// in any other shape.
+func parseStoredAnalysis(idea *models.Idea) (storedAnalysis, bool) {
+	if text, ok := idea.AnalysisText(); ok {
+		return storedAnalysis{Text: text}, true
+	}
+	if analysis, ok := idea.ParsedAnalysis(); ok {
+		return storedAnalysis{
+			Scores: map[string]float64{
+				"mission_alignment": analysis.Mission.Total,
+				"anti_challenge":    analysis.AntiChallenge.Total,
+				"strategic_fit":     analysis.Strategic.Total,
+			},
+			Explanations: analysis.Explanations,
+		}, true
+	}
+
+	var llmDetails struct {
+		Provider     string             `json:"provider"`
+		Scores       map[string]float64 `json:"scores"`
+		Explanations map[string]string  `json:"explanations"`
+	}
+	if err := json.Unmarshal([]byte(idea.AnalysisDetails), &llmDetails); err != nil || llmDetails.Scores == nil {
+		return storedAnalysis{}, false
+	}
+	return storedAnalysis{
+		Provider:     llmDetails.Provider,
+		Scores:       llmDetails.Scores,
+		Explanations: llmDetails.Explanations,
+	}, true
+}
+
+// explanationLabel turns an explanation key like "overall" into "Overall"
+func explanationLabel(key string) string {
+	label := strings.ReplaceAll(key, "_", " ")
+	if label == "" {
+		return label
+	}
+	return strings.ToUpper(label[:1]) + label[1:]
+}
+
+// printWrapped prints text word-wrapped to the width of the show layout,
+// prefixing every line with indent
+func printWrapped(text, indent string) {
+	const width = 60
+	for _, paragraph := range strings.Split(text, "\n") {
+		line := indent
+		for _, word := range strings.Fields(paragraph) {
+			if line != indent && len(line)+1+len(word) > width {
+				fmt.Println(line)
+				line = indent
+			}
+			if line != indent {
+				line += " "
+			}
+			line += word
+		}
+		if line != indent {
+			fmt.Println(line)
+		}
+	}
+}
+
 func displayUniversalScoresFromStored(completion, skillFit, timeline, reward, sustainability, avoidance float64) {
 	dimensions := []struct {
 		name     string
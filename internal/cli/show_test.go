@@ -0,0 +1,79 @@
+package cli
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/ryacub/telos-idea-matrix/internal/llm"
+	"github.com/ryacub/telos-idea-matrix/internal/models"
+)
+
+func TestParseStoredAnalysis_BulkAnalyzeMap(t *testing.T) {
+	details := `{"explanations":{"mission_alignment":"Fits the AI goal","overall":"Worth doing"},` +
+		`"provider":"ollama","scores":{"mission_alignment":3.2,"anti_challenge":2.5,"strategic_fit":1.5}}`
+
+	stored, ok := parseStoredAnalysis(&models.Idea{AnalysisDetails: details})
+	if !ok {
+		t.Fatal("expected the bulk analyze format to parse")
+	}
+	if stored.Provider != "ollama" {
+		t.Errorf("Provider = %q, want ollama", stored.Provider)
+	}
+	if stored.Scores["mission_alignment"] != 3.2 || stored.Scores["strategic_fit"] != 1.5 {
+		t.Errorf("Scores = %v", stored.Scores)
+	}
+	if stored.Explanations["mission_alignment"] != "Fits the AI goal" || stored.Explanations["overall"] != "Worth doing" {
+		t.Errorf("Explanations = %v", stored.Explanations)
+	}
+}
+
+func TestParseStoredAnalysis_Analysis(t *testing.T) {
+	analysis := llm.ConvertResultToAnalysis(&llm.AnalysisResult{
+		FinalScore:   7.0,
+		Scores:       llm.ScoreBreakdown{MissionAlignment: 3.0, AntiChallenge: 2.5, StrategicFit: 1.5},
+		Explanations: map[string]string{"anti_challenge": "Ships in a week"},
+	})
+	details, err := json.Marshal(analysis)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	stored, ok := parseStoredAnalysis(&models.Idea{AnalysisDetails: string(details)})
+	if !ok {
+		t.Fatal("expected a serialized analysis to parse")
+	}
+	if stored.Scores["mission_alignment"] != 3.0 || stored.Scores["anti_challenge"] != 2.5 || stored.Scores["strategic_fit"] != 1.5 {
+		t.Errorf("Scores = %v", stored.Scores)
+	}
+	if stored.Explanations["anti_challenge"] != "Ships in a week" {
+		t.Errorf("Explanations = %v, want the LLM's reasoning kept", stored.Explanations)
+	}
+}
+
+func TestParseStoredAnalysis_PlainText(t *testing.T) {
+	stored, ok := parseStoredAnalysis(&models.Idea{AnalysisDetails: "  Good fit for the current stack\n"})
+	if !ok {
+		t.Fatal("expected plain text to parse")
+	}
+	if stored.Text != "Good fit for the current stack" {
+		t.Errorf("Text = %q", stored.Text)
+	}
+}
+
+func TestParseStoredAnalysis_RepairedText(t *testing.T) {
+	stored, ok := parseStoredAnalysis(&models.Idea{AnalysisDetails: models.WrapAnalysisText("Good fit for the current stack")})
+	if !ok {
+		t.Fatal("expected repaired text to parse")
+	}
+	if stored.Text != "Good fit for the current stack" {
+		t.Errorf("Text = %q", stored.Text)
+	}
+}
+
+func TestParseStoredAnalysis_Unrecognized(t *testing.T) {
+	for _, details := range []string{"", "   ", `{"universal":{}}`} {
+		if _, ok := parseStoredAnalysis(&models.Idea{AnalysisDetails: details}); ok {
+			t.Errorf("parseStoredAnalysis(%q): expected not ok", details)
+		}
+	}
+}
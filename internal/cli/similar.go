@@ -0,0 +1,169 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/ryacub/telos-idea-matrix/internal/cliutil"
+	"github.com/ryacub/telos-idea-matrix/internal/config"
+	"github.com/ryacub/telos-idea-matrix/internal/database"
+	"github.com/ryacub/telos-idea-matrix/internal/llm"
+	"github.com/ryacub/telos-idea-matrix/internal/models"
+	"github.com/spf13/cobra"
+)
+
+// newEmbedder returns the configured embedding provider. Tests replace it.
+var newEmbedder = func() (llm.Embedder, error) {
+	cfg := config.LoadEmbeddingsConfig()
+	embedder, err := llm.NewEmbedder(cfg.Provider, cfg.Model)
+	if errors.Is(err, llm.ErrNoEmbedder) {
+		return nil, fmt.Errorf("%w; enable one with 'tm config set embeddings.provider ollama' (or openai)", err)
+	}
+	return embedder, err
+}
+
+func newSimilarCommand() *cobra.Command {
+	var (
+		top        int
+		jsonOutput bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "similar <id>",
+		Short: "Find ideas similar to an idea",
+		Long: `Find the ideas closest in meaning to an idea, ranked by the cosine
+similarity of their embeddings.
+
+Needs an embedding provider (embeddings.provider in 'tm config'). The idea is
+embedded if it hasn't been yet; embed the rest with 'tm bulk embed'.
+
+Examples:
+  tm similar abc123
+  tm similar abc123 --top 10
+  tm similar abc123 --profile work --json`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeIdeaIDs(1, ""),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runSimilar(cmd.Context(), args[0], top, jsonOutput)
+		},
+	}
+
+	cmd.Flags().IntVarP(&top, "top", "n", 5, "Number of similar ideas to show")
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output as JSON")
+
+	return cmd
+}
+
+// similarIdea is an idea and its similarity to the idea searched from
+type similarIdea struct {
+	Idea       *models.Idea
+	Similarity float64
+}
+
+type similarItem struct {
+	ID         string  `json:"id"`
+	Content    string  `json:"content"`
+	Score      float64 `json:"score"`
+	Similarity float64 `json:"similarity"`
+}
+
+func runSimilar(cmdCtx context.Context, ideaID string, top int, jsonOutput bool) error {
+	if top < 1 {
+		return fmt.Errorf("--top must be at least 1")
+	}
+	if cmdCtx == nil {
+		cmdCtx = context.Background()
+	}
+
+	idea, err := ctx.Repository.GetByID(ideaID)
+	if err != nil {
+		idea, err = ctx.Repository.GetByPartialID(ideaID)
+		if err != nil {
+			return fmt.Errorf("idea not found: %s", ideaID)
+		}
+	}
+
+	embedder, err := newEmbedder()
+	if err != nil {
+		return err
+	}
+
+	embeddings, err := ctx.Repository.Embeddings(embedder.Model())
+	if err != nil {
+		return err
+	}
+
+	target, ok := embeddings[idea.ID]
+	if !ok {
+		if target, err = embedder.Embed(cmdCtx, idea.Content); err != nil {
+			return err
+		}
+		if err := ctx.Repository.SaveEmbedding(idea, embedder.Model(), target); err != nil {
+			return err
+		}
+	}
+
+	candidates, err := ctx.Repository.List(database.ListOptions{Profile: profileFilter()})
+	if err != nil {
+		return fmt.Errorf("failed to list ideas: %w", err)
+	}
+
+	matches, missing := rankSimilar(idea.ID, target, candidates, embeddings)
+	if len(matches) > top {
+		matches = matches[:top]
+	}
+
+	if jsonOutput {
+		items := make([]similarItem, len(matches))
+		for i, m := range matches {
+			items[i] = similarItem{ID: m.Idea.ID, Content: m.Idea.Content, Score: m.Idea.FinalScore, Similarity: m.Similarity}
+		}
+		output, err := json.MarshalIndent(items, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(output))
+		return nil
+	}
+
+	_, _ = cliutil.InfoColor.Printf("Ideas similar to %s: %s\n\n", idea.ID[:8], cliutil.TruncateText(idea.Content, 50))
+	if len(matches) == 0 {
+		fmt.Println("No other ideas have embeddings yet.")
+	}
+	for i, m := range matches {
+		fmt.Printf("%2d. %3.0f%%  %s  ", i+1, m.Similarity*100, m.Idea.ID[:8])
+		_, _ = cliutil.GetScoreColor(m.Idea.FinalScore).Printf("%4.1f", m.Idea.FinalScore)
+		fmt.Printf("  %s\n", cliutil.TruncateText(m.Idea.Content, 50))
+	}
+	if missing > 0 {
+		_, _ = cliutil.WarningColor.Printf("\n%d ideas have no embedding yet and were skipped; run 'tm bulk embed'\n", missing)
+	}
+	return nil
+}
+
+// rankSimilar orders candidates other than targetID by the similarity of
+// their embeddings to target, most similar first. It also returns how many
+// candidates had no embedding.
+func rankSimilar(targetID string, target []float32, candidates []*models.Idea, embeddings map[string][]float32) ([]similarIdea, int) {
+	var matches []similarIdea
+	missing := 0
+	for _, candidate := range candidates {
+		if candidate.ID == targetID {
+			continue
+		}
+		vector, ok := embeddings[candidate.ID]
+		if !ok {
+			missing++
+			continue
+		}
+		matches = append(matches, similarIdea{Idea: candidate, Similarity: llm.CosineSimilarity(target, vector)})
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].Similarity > matches[j].Similarity
+	})
+	return matches, missing
+}
@@ -0,0 +1,35 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/ryacub/telos-idea-matrix/internal/models"
+)
+
+func TestRankSimilar(t *testing.T) {
+	target := &models.Idea{ID: "target"}
+	near := &models.Idea{ID: "near"}
+	far := &models.Idea{ID: "far"}
+	unembedded := &models.Idea{ID: "unembedded"}
+
+	embeddings := map[string][]float32{
+		"target": {1, 0},
+		"near":   {0.9, 0.1},
+		"far":    {0, 1},
+	}
+
+	matches, missing := rankSimilar("target", embeddings["target"], []*models.Idea{far, target, unembedded, near}, embeddings)
+
+	if missing != 1 {
+		t.Errorf("expected 1 idea without an embedding, got %d", missing)
+	}
+	if len(matches) != 2 {
+		t.Fatalf("expected 2 matches, got %d", len(matches))
+	}
+	if matches[0].Idea.ID != "near" || matches[1].Idea.ID != "far" {
+		t.Errorf("expected near then far, got %s then %s", matches[0].Idea.ID, matches[1].Idea.ID)
+	}
+	if matches[0].Similarity <= matches[1].Similarity {
+		t.Errorf("expected descending similarity, got %v", matches)
+	}
+}
@@ -0,0 +1,159 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/ryacub/telos-idea-matrix/internal/cliutil"
+	"github.com/ryacub/telos-idea-matrix/internal/database"
+	"github.com/ryacub/telos-idea-matrix/internal/scoring"
+	"github.com/spf13/cobra"
+)
+
+// recommendationOrder lists recommendations from best to worst for display
+var recommendationOrder = []string{
+	"GREAT FIT - Start this now",
+	"GOOD FIT - Worth pursuing",
+	"MAYBE - Consider carefully",
+	"POOR FIT - Likely to struggle",
+	"AVOID - Not aligned with your goals",
+}
+
+func newSimulateCommand() *cobra.Command {
+	var (
+		weightsPath string
+		top         int
+		jsonOutput  bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "simulate",
+		Short: "Preview how new weights or thresholds would change scores",
+		Long: `Recompute rule-based scores for your active ideas under proposed
+weights and recommendation thresholds, and report what would change.
+Nothing is saved.
+
+The settings file only needs the values you want to change:
+
+  weights:
+    skill_fit: 0.25
+    sustainability: 0.05
+  thresholds:
+    good_fit: 6.5
+
+Examples:
+  tm simulate --weights new.yaml
+  tm simulate --weights new.yaml --top 20
+  tm simulate --weights new.yaml --json`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if top < 0 {
+				return fmt.Errorf("--top cannot be negative")
+			}
+			return runSimulate(weightsPath, top, jsonOutput)
+		},
+	}
+
+	cmd.Flags().StringVar(&weightsPath, "weights", "", "YAML file with proposed weights and thresholds (required)")
+	cmd.Flags().IntVar(&top, "top", 10, "Number of biggest movers to show")
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output as JSON")
+	_ = cmd.MarkFlagRequired("weights")
+
+	return cmd
+}
+
+func runSimulate(weightsPath string, top int, jsonOutput bool) error {
+	if ctx.ScoringMode != ScoringModeUniversal || ctx.Profile == nil || ctx.UniversalEngine == nil {
+		return fmt.Errorf("simulation requires a profile; telos.md weights are fixed (run 'tm init' to create one)")
+	}
+
+	proposed, err := scoring.LoadSettings(weightsPath, ctx.UniversalEngine.CurrentSettings())
+	if err != nil {
+		return err
+	}
+
+	ideas, err := ctx.Repository.List(database.ListOptions{Status: "active"})
+	if err != nil {
+		return fmt.Errorf("failed to list ideas: %w", err)
+	}
+
+	result, err := ctx.UniversalEngine.Simulate(ideas, proposed)
+	if err != nil {
+		return err
+	}
+	if top < len(result.Ideas) {
+		result.Ideas = result.Ideas[:top]
+	}
+
+	if jsonOutput {
+		output, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal JSON: %w", err)
+		}
+		fmt.Println(string(output))
+		return nil
+	}
+
+	printSimulation(result)
+	return nil
+}
+
+// printSimulation shows the averages, recommendation shift, and biggest movers
+func printSimulation(result scoring.SimulationResult) {
+	headerColor := color.New(color.FgCyan, color.Bold)
+
+	if result.Total == 0 {
+		fmt.Println("No active ideas to simulate.")
+		return
+	}
+
+	fmt.Println()
+	_, _ = headerColor.Println("Simulation (nothing saved)")
+	fmt.Println(strings.Repeat("─", 60))
+	fmt.Printf("Ideas:                  %d\n", result.Total)
+	fmt.Printf("Average score:          %.1f → %.1f\n", result.AverageBefore, result.AverageAfter)
+	fmt.Printf("Recommendation changes: %d\n", result.Flips)
+
+	fmt.Println()
+	_, _ = headerColor.Println("Distribution")
+	fmt.Println(strings.Repeat("─", 60))
+	fmt.Printf("%-18s %8s %8s\n", "Recommendation", "Before", "After")
+	for _, rec := range recommendationOrder {
+		before, after := result.DistributionBefore[rec], result.DistributionAfter[rec]
+		fmt.Printf("%-18s %8d %8d  %+d\n", recommendationLabel(rec), before, after, after-before)
+	}
+
+	moved := 0
+	for _, idea := range result.Ideas {
+		if idea.Delta() != 0 {
+			moved++
+		}
+	}
+	if moved == 0 {
+		fmt.Println()
+		fmt.Println("No scores would change.")
+		fmt.Println()
+		return
+	}
+
+	fmt.Println()
+	_, _ = headerColor.Println("Biggest Movers")
+	fmt.Println(strings.Repeat("─", 60))
+	for _, idea := range result.Ideas[:moved] {
+		flip := ""
+		if idea.RecommendationBefore != idea.RecommendationAfter {
+			flip = fmt.Sprintf("  [%s → %s]", recommendationLabel(idea.RecommendationBefore), recommendationLabel(idea.RecommendationAfter))
+		}
+		fmt.Printf("  %s  %4.1f → %s (%+.1f)  %s%s\n", idea.ID[:8], idea.ScoreBefore,
+			cliutil.GetScoreColor(idea.ScoreAfter).Sprintf("%4.1f", idea.ScoreAfter), idea.Delta(),
+			cliutil.TruncateText(idea.Content, 40), flip)
+	}
+	fmt.Println()
+}
+
+// recommendationLabel returns the short verdict, e.g. "GOOD FIT" for "GOOD FIT - Worth pursuing"
+func recommendationLabel(recommendation string) string {
+	label, _, _ := strings.Cut(recommendation, " - ")
+	return label
+}
@@ -198,7 +198,7 @@ func gatherDatabaseStatus() statusGroup {
 	group.Details["size"] = fmt.Sprintf("%.1f MB", sizeMB)
 
 	// Check connectivity
-	repo, err := database.NewRepository(dbPath)
+	repo, err := database.NewRepositoryWithConfig(cfg.Database)
 	if err != nil {
 		group.Status = statusError
 		group.Issues = append(group.Issues, "Connection failed")
@@ -212,6 +212,29 @@ func gatherDatabaseStatus() statusGroup {
 		return group
 	}
 
+	// Report the journal mode and lock handling in effect, with the pool settings
+	var journalMode string
+	if err := repo.DB().QueryRow("PRAGMA journal_mode").Scan(&journalMode); err == nil {
+		group.Details["journal"] = journalMode
+		if journalMode != "wal" {
+			group.Status = statusWarning
+			group.Issues = append(group.Issues, fmt.Sprintf("Journal mode is %s, expected wal", journalMode))
+		}
+	}
+	var busyTimeout int
+	if err := repo.DB().QueryRow("PRAGMA busy_timeout").Scan(&busyTimeout); err == nil {
+		group.Details["busy timeout"] = fmt.Sprintf("%d ms", busyTimeout)
+	}
+	if pages, err := repo.WALAutoCheckpoint(); err == nil {
+		if pages > 0 {
+			group.Details["wal autocheckpoint"] = fmt.Sprintf("every %d pages", pages)
+		} else {
+			group.Details["wal autocheckpoint"] = "off"
+		}
+	}
+	group.Details["pool"] = fmt.Sprintf("%d max open, %d max idle, %s max lifetime",
+		cfg.Database.MaxOpenConns, cfg.Database.MaxIdleConns, cfg.Database.ConnMaxLifetime)
+
 	// Count ideas
 	ideas, err := repo.List(database.ListOptions{})
 	if err == nil {
@@ -260,6 +283,14 @@ func gatherLLMStatus() statusGroup {
 		group.Details["openai"] = "not configured"
 	}
 
+	// Check Groq
+	if os.Getenv("GROQ_API_KEY") != "" {
+		group.Details["groq"] = "configured"
+		available++
+	} else {
+		group.Details["groq"] = "not configured"
+	}
+
 	// Check Claude
 	if os.Getenv("ANTHROPIC_API_KEY") != "" || os.Getenv("CLAUDE_API_KEY") != "" {
 		group.Details["claude"] = "configured"
@@ -293,7 +324,7 @@ func gatherActivityStatus() statusGroup {
 		return group
 	}
 
-	repo, err := database.NewRepository(cfg.Database.Path)
+	repo, err := database.NewRepositoryWithConfig(cfg.Database)
 	if err != nil {
 		group.Status = statusWarning
 		return group
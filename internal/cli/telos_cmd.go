@@ -0,0 +1,438 @@
+package cli
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/ryacub/telos-idea-matrix/internal/cliutil"
+	"github.com/ryacub/telos-idea-matrix/internal/config"
+	"github.com/ryacub/telos-idea-matrix/internal/database"
+	"github.com/ryacub/telos-idea-matrix/internal/models"
+	"github.com/ryacub/telos-idea-matrix/internal/profile"
+	"github.com/ryacub/telos-idea-matrix/internal/scoring"
+	"github.com/ryacub/telos-idea-matrix/internal/telos"
+	"github.com/spf13/cobra"
+)
+
+func newTelosCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "telos",
+		Short: "Calibrate how ideas are scored",
+		Long:  `Calibrate your scoring weights against your own judgement.`,
+	}
+
+	cmd.AddCommand(newTelosTuneCommand())
+	cmd.AddCommand(newTelosBackfillVersionCommand())
+	cmd.AddCommand(newTelosValidateCommand())
+
+	return cmd
+}
+
+func newTelosTuneCommand() *cobra.Command {
+	var sample int
+
+	cmd := &cobra.Command{
+		Use:   "tune",
+		Short: "Tune scoring weights by rating your own ideas",
+		Long: `Rate a handful of your ideas as good or bad, then get suggested
+priority weights that best separate the ones you like from the ones you don't.
+
+The suggestion is previewed against the ideas you rated before anything is saved.
+Weights are adjusted in 5% steps and kept between 5% and 40% per dimension.
+
+Examples:
+  tm telos tune              # Rate 8 ideas
+  tm telos tune --sample 12  # Rate 12 ideas`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if sample < 2 {
+				return fmt.Errorf("--sample must be at least 2")
+			}
+			return runTelosTune(bufio.NewReader(os.Stdin), sample)
+		},
+	}
+
+	cmd.Flags().IntVar(&sample, "sample", 8, "Number of ideas to rate")
+
+	return cmd
+}
+
+func newTelosBackfillVersionCommand() *cobra.Command {
+	var assumeCurrent bool
+
+	cmd := &cobra.Command{
+		Use:   "backfill-version",
+		Short: "Record a telos version on ideas captured before versions were tracked",
+		Long: `Stamp every idea that has no telos version recorded.
+
+By default these ideas are marked as "` + models.LegacyTelosVersion + `", so they count as
+stale and are picked up when ideas are re-scored. Use --assume-current if your
+telos hasn't changed since they were scored, to mark them with the current
+version instead.
+
+Ideas that already have a version are never changed.
+
+Examples:
+  tm telos backfill-version                  # Mark unversioned ideas as legacy
+  tm telos backfill-version --assume-current # Mark them as scored against today's telos`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runTelosBackfillVersion(assumeCurrent)
+		},
+	}
+
+	cmd.Flags().BoolVar(&assumeCurrent, "assume-current", false, "Mark unversioned ideas with the current telos version instead of legacy")
+
+	return cmd
+}
+
+func runTelosBackfillVersion(assumeCurrent bool) error {
+	version := models.LegacyTelosVersion
+	if assumeCurrent {
+		if ctx.TelosVersion == "" {
+			return fmt.Errorf("current telos version is unknown")
+		}
+		version = ctx.TelosVersion
+	}
+
+	updated, err := ctx.Repository.BackfillTelosVersion(version)
+	if err != nil {
+		return err
+	}
+
+	if updated == 0 {
+		fmt.Println("All ideas already have a telos version.")
+		return nil
+	}
+	_, _ = cliutil.SuccessColor.Printf("✓ Set telos version %s on %d idea(s)\n", version, updated)
+	return nil
+}
+
+func newTelosValidateCommand() *cobra.Command {
+	var jsonOutput bool
+
+	cmd := &cobra.Command{
+		Use:   "validate [path]",
+		Short: "Check a telos file for structural problems",
+		Long: `Parse a telos file and report structural problems.
+
+Errors are problems that make scoring unreliable: no goals, items without an
+ID or description, and IDs defined more than once. Warnings are worth fixing
+but don't stop the telos from being used: missing missions, challenges or
+strategies, and stack items that no goal, mission, problem, challenge or
+strategy mentions.
+
+Without a path the active profile's telos file is checked. The command exits
+non-zero when any errors are found, so it can gate CI.
+
+Examples:
+  tm telos validate                   # Check the active telos
+  tm telos validate ./telos.md        # Check a specific file
+  tm telos validate telos.md --json   # Machine-readable issues`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path := telosPath
+			if len(args) == 1 {
+				path = args[0]
+			} else if !cmd.Flags().Changed("telos") {
+				profileName := telosProfile
+				if profileName == "" {
+					profileName = config.ActiveProfile()
+				}
+				if err := config.ValidateProfileName(profileName); err != nil {
+					return err
+				}
+				path = config.ProfileTelosPath(profileName, telosPath)
+			}
+			return runTelosValidate(path, jsonOutput)
+		},
+		// A broken telos would fail initialization, and checking one is the point
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output issues as JSON")
+
+	return cmd
+}
+
+func runTelosValidate(path string, jsonOutput bool) error {
+	parsed, err := telos.NewParser().ParseFileUnvalidated(path)
+	if err != nil {
+		return err
+	}
+	issues := models.ValidateTelos(parsed)
+
+	if jsonOutput {
+		if issues == nil {
+			issues = []models.ValidationIssue{}
+		}
+		output, err := json.MarshalIndent(issues, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(output))
+	} else {
+		printValidationIssues(path, issues)
+	}
+
+	if models.HasValidationErrors(issues) {
+		return fmt.Errorf("%s has %d error(s)", path, countIssues(issues, models.SeverityError))
+	}
+	return nil
+}
+
+func printValidationIssues(path string, issues []models.ValidationIssue) {
+	if len(issues) == 0 {
+		_, _ = cliutil.SuccessColor.Printf("✓ %s is valid\n", path)
+		return
+	}
+
+	for _, severity := range []models.ValidationSeverity{models.SeverityError, models.SeverityWarning} {
+		count := countIssues(issues, severity)
+		if count == 0 {
+			continue
+		}
+
+		printer := cliutil.WarningColor
+		label := "Warnings"
+		if severity == models.SeverityError {
+			printer = cliutil.ErrorColor
+			label = "Errors"
+		}
+		_, _ = printer.Printf("%s (%d)\n", label, count)
+		for _, issue := range issues {
+			if issue.Severity == severity {
+				fmt.Printf("  %-18s %s\n", issue.Section, issue.Message)
+			}
+		}
+		fmt.Println()
+	}
+
+	errorCount := countIssues(issues, models.SeverityError)
+	warningCount := countIssues(issues, models.SeverityWarning)
+	fmt.Printf("%s: %d error(s), %d warning(s)\n", path, errorCount, warningCount)
+}
+
+func countIssues(issues []models.ValidationIssue, severity models.ValidationSeverity) int {
+	count := 0
+	for _, issue := range issues {
+		if issue.Severity == severity {
+			count++
+		}
+	}
+	return count
+}
+
+// ratedIdea is an idea the user labeled during tuning, with its dimension scores
+type ratedIdea struct {
+	idea    *models.Idea
+	labeled scoring.LabeledIdea
+}
+
+func runTelosTune(reader *bufio.Reader, sample int) error {
+	if ctx.ScoringMode != ScoringModeUniversal || ctx.Profile == nil || ctx.UniversalEngine == nil {
+		return fmt.Errorf("weight tuning requires a profile; telos.md weights are fixed (run 'tm init' to create one)")
+	}
+
+	ideas, err := ctx.Repository.List(database.ListOptions{
+		Status:  "active",
+		OrderBy: database.OrderBy(database.SortByFinalScore, database.Descending),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list ideas: %w", err)
+	}
+	if len(ideas) < 2 {
+		fmt.Println("Capture at least two ideas with 'tm add' before tuning.")
+		return nil
+	}
+
+	ideas = sampleAcrossScores(ideas, sample)
+
+	_, _ = cliutil.InfoColor.Println("🎯 Rate each idea: [g]ood, [b]ad, [s]kip, or [q]uit rating")
+	fmt.Println()
+
+	rated := make([]ratedIdea, 0, len(ideas))
+	for i, idea := range ideas {
+		fmt.Printf("%d/%d  %s (score: %.1f)\n", i+1, len(ideas), cliutil.TruncateText(idea.Content, 80), idea.FinalScore)
+
+		rating, err := promptRating(reader)
+		if err != nil {
+			return err
+		}
+		if rating == "q" {
+			break
+		}
+		if rating == "s" {
+			continue
+		}
+
+		analysis, err := ctx.UniversalEngine.Score(idea.Content)
+		if err != nil {
+			return fmt.Errorf("failed to score idea %s: %w", idea.ID[:8], err)
+		}
+		rated = append(rated, ratedIdea{
+			idea:    idea,
+			labeled: scoring.LabeledIdea{Scores: analysis.Universal, Good: rating == "g"},
+		})
+	}
+
+	labeled := make([]scoring.LabeledIdea, len(rated))
+	goodCount := 0
+	for i, r := range rated {
+		labeled[i] = r.labeled
+		if r.labeled.Good {
+			goodCount++
+		}
+	}
+	if goodCount == 0 || goodCount == len(labeled) {
+		fmt.Println()
+		fmt.Println("Rate at least one good and one bad idea to get a suggestion.")
+		return nil
+	}
+
+	base := scoring.Weights(ctx.Profile.Priorities)
+	suggested := scoring.SuggestWeights(labeled, base)
+
+	printTunePreview(rated, base, suggested)
+
+	if weightsEqual(base, suggested) {
+		fmt.Println("Your current weights already separate these ideas best. Nothing to change.")
+		return nil
+	}
+
+	answer, err := promptLine(reader, "Save these weights to your profile? [y/N]: ")
+	if err != nil {
+		return err
+	}
+	if answer != "y" && answer != "yes" {
+		fmt.Println("❌ Weights unchanged")
+		return nil
+	}
+
+	for dim, weight := range suggested {
+		ctx.Profile.SetPriority(dim, weight)
+	}
+	if err := profile.Save(ctx.Profile, ctx.ProfilePath); err != nil {
+		return fmt.Errorf("failed to save profile: %w", err)
+	}
+
+	_, _ = cliutil.SuccessColor.Printf("✅ Weights saved to %s\n", ctx.ProfilePath)
+	fmt.Println("New ideas will be scored with the updated weights.")
+
+	return nil
+}
+
+// printTunePreview shows current vs suggested weights and their effect on the rated ideas
+func printTunePreview(rated []ratedIdea, base, suggested scoring.Weights) {
+	headerColor := color.New(color.FgCyan, color.Bold)
+	labeled := make([]scoring.LabeledIdea, len(rated))
+	for i, r := range rated {
+		labeled[i] = r.labeled
+	}
+
+	fmt.Println()
+	_, _ = headerColor.Println("Suggested Weights")
+	fmt.Println(strings.Repeat("─", 60))
+	fmt.Printf("%-34s %8s %10s\n", "Dimension", "Current", "Suggested")
+	for _, dim := range profile.AllDimensions() {
+		change := ""
+		if delta := suggested[dim] - base[dim]; delta > 0.001 {
+			change = "  ↑"
+		} else if delta < -0.001 {
+			change = "  ↓"
+		}
+		fmt.Printf("%-34s %7.0f%% %9.0f%%%s\n",
+			profile.DimensionDescriptions[dim], base[dim]*100, suggested[dim]*100, change)
+	}
+
+	fmt.Println()
+	_, _ = headerColor.Println("Impact on Rated Ideas")
+	fmt.Println(strings.Repeat("─", 60))
+	for _, r := range rated {
+		label := "bad "
+		if r.labeled.Good {
+			label = "good"
+		}
+		before := scoring.WeightedScore(r.labeled.Scores, base)
+		after := scoring.WeightedScore(r.labeled.Scores, suggested)
+		fmt.Printf("  [%s] %4.1f → %s  %s\n", label, before,
+			cliutil.GetScoreColor(after).Sprintf("%4.1f", after), cliutil.TruncateText(r.idea.Content, 40))
+	}
+
+	fmt.Println()
+	fmt.Printf("Good vs bad gap: %.2f → %.2f points\n",
+		scoring.Separation(labeled, base), scoring.Separation(labeled, suggested))
+	fmt.Println()
+}
+
+// sampleAcrossScores picks up to n ideas evenly spaced through a score-sorted
+// list, so the user rates ideas from across the whole score range.
+func sampleAcrossScores(ideas []*models.Idea, n int) []*models.Idea {
+	if len(ideas) <= n {
+		return ideas
+	}
+
+	sampled := make([]*models.Idea, n)
+	for i := 0; i < n; i++ {
+		sampled[i] = ideas[i*(len(ideas)-1)/(n-1)]
+	}
+	return sampled
+}
+
+// promptRating asks for a rating until a valid one is given
+func promptRating(reader *bufio.Reader) (string, error) {
+	for {
+		input, err := promptLine(reader, "  Good or bad? [g/b/s/q]: ")
+		if err != nil {
+			return "", err
+		}
+		if rating := parseRating(input); rating != "" {
+			return rating, nil
+		}
+	}
+}
+
+// parseRating normalizes a rating answer to g, b, s, or q, or "" if invalid
+func parseRating(input string) string {
+	switch input {
+	case "g", "good", "y", "yes":
+		return "g"
+	case "b", "bad", "n", "no":
+		return "b"
+	case "s", "skip":
+		return "s"
+	case "q", "quit":
+		return "q"
+	default:
+		return ""
+	}
+}
+
+// promptLine prints a prompt and reads a trimmed, lowercased line of input.
+// End of input is treated as quitting.
+func promptLine(reader *bufio.Reader, prompt string) (string, error) {
+	fmt.Print(prompt)
+	input, err := reader.ReadString('\n')
+	if err == io.EOF && strings.TrimSpace(input) == "" {
+		return "q", nil
+	}
+	if err != nil && err != io.EOF {
+		return "", fmt.Errorf("failed to read input: %w", err)
+	}
+	return strings.ToLower(strings.TrimSpace(input)), nil
+}
+
+// weightsEqual reports whether two weight sets match to within rounding
+func weightsEqual(a, b scoring.Weights) bool {
+	for _, dim := range profile.AllDimensions() {
+		if diff := a[dim] - b[dim]; diff > 0.001 || diff < -0.001 {
+			return false
+		}
+	}
+	return true
+}
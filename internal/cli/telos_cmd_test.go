@@ -0,0 +1,74 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ryacub/telos-idea-matrix/internal/models"
+)
+
+func TestSampleAcrossScores(t *testing.T) {
+	ideas := make([]*models.Idea, 10)
+	for i := range ideas {
+		ideas[i] = &models.Idea{FinalScore: float64(10 - i)}
+	}
+
+	sampled := sampleAcrossScores(ideas, 4)
+	if len(sampled) != 4 {
+		t.Fatalf("expected 4 ideas, got %d", len(sampled))
+	}
+	if sampled[0] != ideas[0] || sampled[3] != ideas[9] {
+		t.Errorf("expected sample to span highest and lowest scores, got %.0f..%.0f",
+			sampled[0].FinalScore, sampled[3].FinalScore)
+	}
+
+	if all := sampleAcrossScores(ideas[:3], 8); len(all) != 3 {
+		t.Errorf("expected all 3 ideas when sample exceeds list, got %d", len(all))
+	}
+}
+
+func TestParseRating(t *testing.T) {
+	tests := map[string]string{
+		"g":     "g",
+		"good":  "g",
+		"b":     "b",
+		"no":    "b",
+		"s":     "s",
+		"quit":  "q",
+		"":      "",
+		"maybe": "",
+	}
+
+	for input, want := range tests {
+		if got := parseRating(input); got != want {
+			t.Errorf("parseRating(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
+
+func TestRunTelosValidate(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	// Warnings alone don't fail validation
+	warnings := write("warnings.md", "## Goals\n- G1: Launch a Go CLI\n")
+	if err := runTelosValidate(warnings, false); err != nil {
+		t.Errorf("expected warnings to pass, got %v", err)
+	}
+
+	duplicate := write("duplicate.md", "## Goals\n- G1: Launch a Go CLI\n- G1: Write a book\n")
+	if err := runTelosValidate(duplicate, true); err == nil {
+		t.Error("expected an error for a duplicate goal ID")
+	}
+
+	if err := runTelosValidate(filepath.Join(dir, "missing.md"), false); err == nil {
+		t.Error("expected an error for a missing file")
+	}
+}
@@ -0,0 +1,168 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/ryacub/telos-idea-matrix/internal/cliutil"
+	"github.com/ryacub/telos-idea-matrix/internal/database"
+	"github.com/ryacub/telos-idea-matrix/internal/models"
+	"github.com/spf13/cobra"
+)
+
+func newTrashCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "trash",
+		Short: "View, restore, or empty deleted ideas",
+		Long: `Deleted ideas are moved to the trash rather than removed.
+
+They stay out of listings, search, and analytics, but can be restored until
+the trash is emptied. Use --profile to limit a command to one telos profile.`,
+	}
+
+	cmd.AddCommand(newTrashListCommand())
+	cmd.AddCommand(newTrashRestoreCommand())
+	cmd.AddCommand(newTrashEmptyCommand())
+
+	return cmd
+}
+
+func newTrashListCommand() *cobra.Command {
+	var (
+		limit      int
+		jsonOutput bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List ideas in the trash",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ideas, err := trashedIdeas(limit)
+			if err != nil {
+				return err
+			}
+
+			if len(ideas) == 0 {
+				if jsonOutput {
+					fmt.Println("[]")
+				} else {
+					_, _ = cliutil.InfoColor.Println("Trash is empty.")
+				}
+				return nil
+			}
+
+			if jsonOutput {
+				return outputListJSON(ideas, nil)
+			}
+			return outputListFull(ideas, nil)
+		},
+	}
+
+	cmd.Flags().IntVarP(&limit, "limit", "l", 0, "Max ideas to show (0 for all)")
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output as JSON")
+
+	return cmd
+}
+
+// trashedIdeas returns up to limit ideas in the trash, newest first; zero
+// means no limit
+func trashedIdeas(limit int) ([]*models.Idea, error) {
+	opts := database.ListOptions{
+		Status:  string(models.StatusDeleted),
+		Profile: profileFilter(),
+		OrderBy: database.OrderBy(database.SortByCreatedAt, database.Descending),
+	}
+	if limit > 0 {
+		opts.Limit = &limit
+	}
+
+	ideas, err := ctx.Repository.List(opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list trash: %w", err)
+	}
+	return ideas, nil
+}
+
+func newTrashRestoreCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:               "restore <id>",
+		Short:             "Move an idea from the trash back to active",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeIdeaIDs(1, string(models.StatusDeleted)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runTrashRestore(args[0])
+		},
+	}
+}
+
+func runTrashRestore(ideaID string) error {
+	idea, err := ctx.Repository.GetByID(ideaID)
+	if err != nil {
+		idea, err = ctx.Repository.GetByPartialID(ideaID)
+		if err != nil {
+			return fmt.Errorf("idea not found: %s", ideaID)
+		}
+	}
+
+	if idea.Status != string(models.StatusDeleted) {
+		return fmt.Errorf("idea %s is not in the trash (status: %s)", idea.ID[:8], idea.Status)
+	}
+
+	idea.Restore()
+	if err := ctx.Repository.Update(idea); err != nil {
+		return fmt.Errorf("failed to restore: %w", err)
+	}
+
+	_, _ = cliutil.SuccessColor.Printf("✓ Restored %s: %s\n", idea.ID[:8], cliutil.TruncateText(idea.Content, 50))
+	return nil
+}
+
+func newTrashEmptyCommand() *cobra.Command {
+	var yes bool
+
+	cmd := &cobra.Command{
+		Use:   "empty",
+		Short: "Permanently delete every idea in the trash",
+		Long: `Permanently delete every idea in the trash.
+⚠️  WARNING: This operation cannot be undone!
+
+Examples:
+  tm trash empty
+  tm trash empty --profile side --yes`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runTrashEmpty(yes)
+		},
+	}
+
+	cmd.Flags().BoolVar(&yes, "yes", false, "Auto-confirm (skip confirmation prompt)")
+
+	return cmd
+}
+
+func runTrashEmpty(yes bool) error {
+	count, err := ctx.Repository.Count(database.ListOptions{
+		Status:  string(models.StatusDeleted),
+		Profile: profileFilter(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to count trash: %w", err)
+	}
+	if count == 0 {
+		_, _ = cliutil.InfoColor.Println("Trash is empty.")
+		return nil
+	}
+
+	if !yes && !cliutil.Confirm(fmt.Sprintf("⚠️  PERMANENTLY DELETE %d ideas in the trash? This CANNOT be undone!", count)) {
+		fmt.Println("❌ Cancelled")
+		return nil
+	}
+
+	removed, err := ctx.Repository.EmptyTrash(profileFilter())
+	if err != nil {
+		return err
+	}
+
+	_, _ = cliutil.SuccessColor.Printf("🗑️  Permanently deleted %d ideas\n", removed)
+	return nil
+}
@@ -0,0 +1,100 @@
+//go:build integration
+
+package cli
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ryacub/telos-idea-matrix/internal/api"
+	"github.com/ryacub/telos-idea-matrix/internal/config"
+	"github.com/ryacub/telos-idea-matrix/internal/database"
+	"github.com/ryacub/telos-idea-matrix/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTrashRestore_ReactivatesDeletedIdea(t *testing.T) {
+	cliCtx, cleanup := setupTestCLI(t)
+	defer cleanup()
+	SetContext(cliCtx)
+
+	idea := models.NewIdea("An idea deleted by mistake")
+	idea.Trash()
+	require.NoError(t, cliCtx.Repository.Create(idea))
+
+	require.NoError(t, runTrashRestore(idea.ID[:8]))
+
+	got, err := cliCtx.Repository.GetByID(idea.ID)
+	require.NoError(t, err)
+	assert.Equal(t, string(models.StatusActive), got.Status)
+}
+
+func TestTrashRestore_RejectsIdeaNotInTrash(t *testing.T) {
+	cliCtx, cleanup := setupTestCLI(t)
+	defer cleanup()
+	SetContext(cliCtx)
+
+	idea := models.NewIdea("An active idea")
+	require.NoError(t, cliCtx.Repository.Create(idea))
+
+	err := runTrashRestore(idea.ID)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not in the trash")
+}
+
+func TestTrashEmpty_RemovesDeletedIdeas(t *testing.T) {
+	cliCtx, cleanup := setupTestCLI(t)
+	defer cleanup()
+	SetContext(cliCtx)
+
+	active := models.NewIdea("An active idea")
+	require.NoError(t, cliCtx.Repository.Create(active))
+	trashed := models.NewIdea("A trashed idea")
+	trashed.Trash()
+	require.NoError(t, cliCtx.Repository.Create(trashed))
+
+	require.NoError(t, runTrashEmpty(true))
+
+	_, err := cliCtx.Repository.GetByID(trashed.ID)
+	assert.Error(t, err)
+	_, err = cliCtx.Repository.GetByID(active.ID)
+	assert.NoError(t, err)
+}
+
+func TestTrash_ListsIdeasDeletedThroughTheAPI(t *testing.T) {
+	cliCtx, cleanup := setupTestCLI(t)
+	defer cleanup()
+	SetContext(cliCtx)
+
+	server, err := api.NewServerFromPath(cliCtx.Repository, cliCtx.TelosPath, config.DefaultAuthConfig())
+	require.NoError(t, err)
+
+	trashed := models.NewIdea("Deleted from the web UI")
+	require.NoError(t, cliCtx.Repository.Create(trashed))
+	removed := models.NewIdea("Deleted for good")
+	require.NoError(t, cliCtx.Repository.Create(removed))
+
+	del := func(path string) {
+		t.Helper()
+		w := httptest.NewRecorder()
+		server.Router().ServeHTTP(w, httptest.NewRequest(http.MethodDelete, path, nil))
+		require.Equal(t, http.StatusNoContent, w.Code)
+	}
+	del("/api/v1/ideas/" + trashed.ID)
+	del("/api/v1/ideas/" + removed.ID + "?permanent=true")
+
+	ideas, err := trashedIdeas(0)
+	require.NoError(t, err)
+	require.Len(t, ideas, 1, "only the soft-deleted idea is in the trash")
+	assert.Equal(t, trashed.ID, ideas[0].ID)
+
+	_, err = cliCtx.Repository.GetByID(removed.ID)
+	assert.True(t, database.IsNotFound(err))
+
+	require.NoError(t, runTrashRestore(trashed.ID[:8]))
+	got, err := cliCtx.Repository.GetByID(trashed.ID)
+	require.NoError(t, err)
+	assert.Equal(t, string(models.StatusActive), got.Status)
+}
@@ -3,9 +3,11 @@ package cliutil
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/fatih/color"
 	"github.com/rs/zerolog/log"
+	"github.com/ryacub/telos-idea-matrix/internal/models"
 )
 
 // Shared color definitions for CLI commands
@@ -16,30 +18,33 @@ var (
 	WarningColor = color.New(color.FgYellow)
 )
 
-// GetScoreColor returns a color based on the score value
+// GetScoreColor returns a color based on the recommendation the score earns
 func GetScoreColor(score float64) *color.Color {
-	switch {
-	case score >= 8.5:
+	switch models.CurrentRecommendationThresholds().Recommend(score) {
+	case models.RecommendationPriority:
 		return color.New(color.FgGreen, color.Bold)
-	case score >= 7.0:
+	case models.RecommendationGood:
 		return color.New(color.FgGreen)
-	case score >= 5.0:
+	case models.RecommendationConsider:
 		return color.New(color.FgYellow)
 	default:
 		return color.New(color.FgRed)
 	}
 }
 
-// GetRecommendationColor returns a color based on the recommendation text
+// GetRecommendationColor returns a color based on the recommendation's
+// category, so LLM wordings without an emoji are colored like the built-in ones
 func GetRecommendationColor(recommendation string) *color.Color {
-	if strings.Contains(recommendation, "🔥") {
+	switch models.ParseRecommendation(recommendation) {
+	case models.CategoryPrioritize:
 		return color.New(color.FgGreen, color.Bold)
-	} else if strings.Contains(recommendation, "✅") {
+	case models.CategoryPursue:
 		return color.New(color.FgGreen)
-	} else if strings.Contains(recommendation, "⚠️") {
+	case models.CategoryConsider:
 		return color.New(color.FgYellow)
+	default:
+		return color.New(color.FgRed)
 	}
-	return color.New(color.FgRed)
 }
 
 // TruncateText truncates text to specified length with ellipsis
@@ -50,6 +55,36 @@ func TruncateText(text string, maxLen int) string {
 	return text[:maxLen] + "..."
 }
 
+// TimeAgo describes how long ago t was, such as "3 hours ago"
+func TimeAgo(t time.Time) string {
+	return timeAgo(t, time.Now())
+}
+
+func timeAgo(t, now time.Time) string {
+	elapsed := now.Sub(t)
+	plural := func(n int, unit string) string {
+		if n == 1 {
+			return "1 " + unit + " ago"
+		}
+		return fmt.Sprintf("%d %ss ago", n, unit)
+	}
+
+	switch days := int(elapsed.Hours() / 24); {
+	case elapsed < time.Minute:
+		return "just now"
+	case elapsed < time.Hour:
+		return plural(int(elapsed.Minutes()), "minute")
+	case elapsed < 24*time.Hour:
+		return plural(int(elapsed.Hours()), "hour")
+	case days < 30:
+		return plural(days, "day")
+	case days < 365:
+		return plural(days/30, "month")
+	default:
+		return plural(days/365, "year")
+	}
+}
+
 // Confirm prompts the user for yes/no confirmation
 func Confirm(prompt string) bool {
 	fmt.Printf("%s [y/N]: ", prompt)
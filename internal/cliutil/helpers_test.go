@@ -0,0 +1,29 @@
+package cliutil
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTimeAgo(t *testing.T) {
+	now := time.Date(2025, 6, 15, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		ago  time.Duration
+		want string
+	}{
+		{-time.Minute, "just now"},
+		{30 * time.Second, "just now"},
+		{time.Minute, "1 minute ago"},
+		{59 * time.Minute, "59 minutes ago"},
+		{3 * time.Hour, "3 hours ago"},
+		{25 * time.Hour, "1 day ago"},
+		{29 * 24 * time.Hour, "29 days ago"},
+		{65 * 24 * time.Hour, "2 months ago"},
+		{800 * 24 * time.Hour, "2 years ago"},
+	}
+	for _, tt := range tests {
+		if got := timeAgo(now.Add(-tt.ago), now); got != tt.want {
+			t.Errorf("timeAgo(%s ago) = %q, want %q", tt.ago, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,131 @@
+package cliutil
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"golang.org/x/term"
+)
+
+const (
+	progressBarWidth = 24
+
+	// progressRedrawInterval limits how often a terminal bar is redrawn, so
+	// fast batches don't spend their time writing to the terminal
+	progressRedrawInterval = 100 * time.Millisecond
+
+	// progressLogSteps is how many lines a non-terminal bar logs over a run,
+	// one per 10% of the total. Runs this short log nothing; the summary
+	// that follows them says enough.
+	progressLogSteps = 10
+)
+
+// ProgressBar reports the progress of a long batch operation. On a terminal
+// it redraws one line in place with a filled bar, percentage, throughput and
+// ETA; when output is piped it logs a plain line every 10% of runs over 10
+// items instead, so logs and scripts don't fill up with control characters.
+type ProgressBar struct {
+	out   io.Writer
+	label string
+	total int
+	done  int
+	tty   bool
+
+	now      func() time.Time
+	start    time.Time
+	drawn    time.Time // When the terminal line was last drawn
+	logged   int       // Last 10% step logged when not on a terminal
+	finished bool
+}
+
+// NewProgressBar creates a progress bar on stdout for total items, labeled
+// with what is being done, e.g. "Archiving"
+func NewProgressBar(total int, label string) *ProgressBar {
+	return newProgressBar(os.Stdout, total, label, term.IsTerminal(int(os.Stdout.Fd())))
+}
+
+func newProgressBar(out io.Writer, total int, label string, tty bool) *ProgressBar {
+	p := &ProgressBar{out: out, label: label, total: total, tty: tty, now: time.Now}
+	p.start = p.now()
+	return p
+}
+
+// Increment marks one more item as processed
+func (p *ProgressBar) Increment() {
+	if p.finished || p.done >= p.total {
+		return
+	}
+	p.done++
+
+	if p.tty {
+		if now := p.now(); p.done == p.total || now.Sub(p.drawn) >= progressRedrawInterval {
+			p.drawn = now
+			fmt.Fprintf(p.out, "\r%s\x1b[K", p.status(true))
+		}
+		return
+	}
+
+	if p.total <= progressLogSteps {
+		return
+	}
+	if step := p.done * progressLogSteps / p.total; step > p.logged {
+		p.logged = step
+		fmt.Fprintln(p.out, p.status(false))
+	}
+}
+
+// Clear erases the bar from the terminal so a message can be printed on a
+// clean line; the bar is redrawn by the next Increment. It does nothing when
+// output is not a terminal.
+func (p *ProgressBar) Clear() {
+	if p.tty && !p.finished && p.done > 0 {
+		fmt.Fprint(p.out, "\r\x1b[K")
+		p.drawn = time.Time{}
+	}
+}
+
+// Finish shows the final state, including a run stopped short of the total,
+// and ends the bar's line. Later calls do nothing.
+func (p *ProgressBar) Finish() {
+	if p.finished || p.total == 0 {
+		p.finished = true
+		return
+	}
+	p.finished = true
+
+	if p.tty {
+		fmt.Fprintf(p.out, "\r%s\x1b[K\n", p.status(true))
+		return
+	}
+	// A complete run has logged its last line already
+	if p.total > progressLogSteps && p.done < p.total {
+		fmt.Fprintln(p.out, p.status(false))
+	}
+}
+
+// status formats the progress line, with the filled bar on a terminal
+func (p *ProgressBar) status(bar bool) string {
+	fraction := float64(p.done) / float64(p.total)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "  %s ", p.label)
+	if bar {
+		filled := int(fraction * progressBarWidth)
+		fmt.Fprintf(&b, "[%s%s] ", strings.Repeat("█", filled), strings.Repeat("░", progressBarWidth-filled))
+	}
+	fmt.Fprintf(&b, "%3.0f%% %d/%d", fraction*100, p.done, p.total)
+
+	elapsed := p.now().Sub(p.start)
+	if p.done > 0 && elapsed > 0 {
+		rate := float64(p.done) / elapsed.Seconds()
+		fmt.Fprintf(&b, " · %.1f/s", rate)
+		if p.done < p.total {
+			eta := time.Duration(float64(p.total-p.done) / rate * float64(time.Second))
+			fmt.Fprintf(&b, " · ETA %s", eta.Round(time.Second))
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,123 @@
+package cliutil
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeClock advances by step on every reading
+func fakeClock(step time.Duration) func() time.Time {
+	now := time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)
+	return func() time.Time {
+		now = now.Add(step)
+		return now
+	}
+}
+
+func TestProgressBar_PipedLogsEveryTenPercent(t *testing.T) {
+	var buf bytes.Buffer
+	bar := newProgressBar(&buf, 50, "Archiving", false)
+	bar.now = fakeClock(time.Second)
+	bar.start = bar.now()
+
+	for i := 0; i < 50; i++ {
+		bar.Increment()
+	}
+	bar.Finish()
+
+	out := buf.String()
+	if strings.ContainsAny(out, "\r\x1b") {
+		t.Errorf("Expected no control characters in piped output, got %q", out)
+	}
+	lines := strings.Split(strings.TrimSuffix(out, "\n"), "\n")
+	if len(lines) != 10 {
+		t.Fatalf("Expected 10 lines, got %d: %q", len(lines), out)
+	}
+	if !strings.HasPrefix(lines[0], "  Archiving  10% 5/50") {
+		t.Errorf("Unexpected first line %q", lines[0])
+	}
+	if !strings.Contains(lines[0], "ETA") || !strings.Contains(lines[0], "/s") {
+		t.Errorf("Expected throughput and ETA in %q", lines[0])
+	}
+	if !strings.HasPrefix(lines[9], "  Archiving 100% 50/50") || strings.Contains(lines[9], "ETA") {
+		t.Errorf("Unexpected last line %q", lines[9])
+	}
+}
+
+func TestProgressBar_PipedShortRunLogsNothing(t *testing.T) {
+	var buf bytes.Buffer
+	bar := newProgressBar(&buf, 5, "Deleting", false)
+	for i := 0; i < 5; i++ {
+		bar.Increment()
+	}
+	bar.Finish()
+
+	if buf.Len() != 0 {
+		t.Errorf("Expected no output for a short run, got %q", buf.String())
+	}
+}
+
+func TestProgressBar_PipedFinishReportsStoppedRun(t *testing.T) {
+	var buf bytes.Buffer
+	bar := newProgressBar(&buf, 20, "Analyzing", false)
+	for i := 0; i < 7; i++ {
+		bar.Increment()
+	}
+	bar.Finish()
+	bar.Finish()
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	if len(lines) != 4 || !strings.HasPrefix(lines[3], "  Analyzing  35% 7/20") {
+		t.Errorf("Expected the stopped run's state last, got %q", buf.String())
+	}
+}
+
+func TestProgressBar_TerminalRedrawsInPlace(t *testing.T) {
+	var buf bytes.Buffer
+	bar := newProgressBar(&buf, 4, "Importing", true)
+	bar.now = fakeClock(time.Second)
+	bar.start = bar.now()
+
+	bar.Increment()
+	bar.Increment()
+	bar.Clear()
+	bar.Increment()
+	bar.Increment()
+	bar.Increment() // Past the total; ignored
+	bar.Finish()
+
+	out := buf.String()
+	if strings.Count(out, "\n") != 1 || !strings.HasSuffix(out, "\n") {
+		t.Errorf("Expected a single line ended by Finish, got %q", out)
+	}
+	if !strings.Contains(out, "\r  Importing [████████████░░░░░░░░░░░░]  50% 2/4") {
+		t.Errorf("Expected a half-filled bar, got %q", out)
+	}
+	if !strings.Contains(out, "\r\x1b[K\r") {
+		t.Errorf("Expected Clear to erase the line, got %q", out)
+	}
+	if !strings.Contains(out, "[████████████████████████] 100% 4/4") {
+		t.Errorf("Expected a full bar at the end, got %q", out)
+	}
+}
+
+func TestProgressBar_TerminalThrottlesRedraws(t *testing.T) {
+	var buf bytes.Buffer
+	bar := newProgressBar(&buf, 100, "Updating", true)
+	bar.now = fakeClock(time.Millisecond)
+	bar.start = bar.now()
+
+	for i := 0; i < 100; i++ {
+		bar.Increment()
+	}
+
+	// The first draw, then one per 100ms, and the final item
+	if draws := strings.Count(buf.String(), "\r"); draws > 5 {
+		t.Errorf("Expected redraws to be throttled, got %d", draws)
+	}
+	if !strings.Contains(buf.String(), "100/100") {
+		t.Errorf("Expected the final item to be drawn, got %q", buf.String())
+	}
+}
@@ -0,0 +1,97 @@
+package cliutil
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+)
+
+// Output formats accepted by --format
+const (
+	FormatText = "text"
+	FormatJSON = "json"
+	FormatCSV  = "csv"
+)
+
+// ErrFormatNotSupported is returned when output can't be written in the chosen format
+var ErrFormatNotSupported = errors.New("output format not supported")
+
+// Renderable is command output. The text form is written by WriteText and
+// the JSON form is the value itself, encoded with its json tags.
+type Renderable interface {
+	WriteText(w io.Writer) error
+}
+
+// CSVRenderable is output that can also be written as CSV
+type CSVRenderable interface {
+	Renderable
+	// CSVRows returns the rows to write, header first
+	CSVRows() [][]string
+}
+
+// Renderer writes command output in one format
+type Renderer interface {
+	Render(data Renderable) error
+}
+
+// NewRenderer returns a renderer writing to stdout in format: text, json or csv
+func NewRenderer(format string) (Renderer, error) {
+	return newRenderer(os.Stdout, format)
+}
+
+func newRenderer(out io.Writer, format string) (Renderer, error) {
+	switch format {
+	case FormatText:
+		return TextRenderer{Out: out}, nil
+	case FormatJSON:
+		return JSONRenderer{Out: out}, nil
+	case FormatCSV:
+		return CSVRenderer{Out: out}, nil
+	default:
+		return nil, fmt.Errorf("invalid format %q: must be text, json or csv", format)
+	}
+}
+
+// TextRenderer writes the human-readable form of output
+type TextRenderer struct {
+	Out io.Writer
+}
+
+// Render implements Renderer
+func (r TextRenderer) Render(data Renderable) error {
+	return data.WriteText(r.Out)
+}
+
+// JSONRenderer writes output as indented JSON
+type JSONRenderer struct {
+	Out io.Writer
+}
+
+// Render implements Renderer
+func (r JSONRenderer) Render(data Renderable) error {
+	encoder := json.NewEncoder(r.Out)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(data)
+}
+
+// CSVRenderer writes output as CSV; data must implement CSVRenderable
+type CSVRenderer struct {
+	Out io.Writer
+}
+
+// Render implements Renderer
+func (r CSVRenderer) Render(data Renderable) error {
+	table, ok := data.(CSVRenderable)
+	if !ok {
+		return fmt.Errorf("%w: this command can't write csv, use text or json", ErrFormatNotSupported)
+	}
+
+	writer := csv.NewWriter(r.Out)
+	if err := writer.WriteAll(table.CSVRows()); err != nil {
+		return fmt.Errorf("failed to write CSV: %w", err)
+	}
+	return nil
+}
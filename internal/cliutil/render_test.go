@@ -0,0 +1,84 @@
+package cliutil
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+)
+
+// scoreTable is output that can be written in every format
+type scoreTable struct {
+	Scores map[string]float64 `json:"scores"`
+}
+
+func (s scoreTable) WriteText(w io.Writer) error {
+	_, err := fmt.Fprintf(w, "alpha scored %.1f\n", s.Scores["alpha"])
+	return err
+}
+
+func (s scoreTable) CSVRows() [][]string {
+	return [][]string{{"Idea", "Score"}, {"alpha", fmt.Sprintf("%.1f", s.Scores["alpha"])}}
+}
+
+// summary has no CSV form
+type summary struct {
+	Total int `json:"total"`
+}
+
+func (s summary) WriteText(w io.Writer) error {
+	_, err := fmt.Fprintf(w, "%d ideas\n", s.Total)
+	return err
+}
+
+func TestRenderer_Formats(t *testing.T) {
+	data := scoreTable{Scores: map[string]float64{"alpha": 7.5}}
+
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{FormatText, "alpha scored 7.5\n"},
+		{FormatJSON, "{\n  \"scores\": {\n    \"alpha\": 7.5\n  }\n}\n"},
+		{FormatCSV, "Idea,Score\nalpha,7.5\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			var out bytes.Buffer
+			renderer, err := newRenderer(&out, tt.format)
+			if err != nil {
+				t.Fatalf("newRenderer(%q) error = %v", tt.format, err)
+			}
+			if err := renderer.Render(data); err != nil {
+				t.Fatalf("Render() error = %v", err)
+			}
+			if out.String() != tt.want {
+				t.Errorf("Render() wrote %q, want %q", out.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestRenderer_CSVNotSupported(t *testing.T) {
+	var out bytes.Buffer
+	renderer, err := newRenderer(&out, FormatCSV)
+	if err != nil {
+		t.Fatalf("newRenderer() error = %v", err)
+	}
+
+	err = renderer.Render(summary{Total: 3})
+	if !errors.Is(err, ErrFormatNotSupported) {
+		t.Errorf("Expected ErrFormatNotSupported, got %v", err)
+	}
+	if out.Len() != 0 {
+		t.Errorf("Expected nothing written, got %q", out.String())
+	}
+}
+
+func TestNewRenderer_InvalidFormat(t *testing.T) {
+	if _, err := NewRenderer("yaml"); err == nil {
+		t.Error("Expected an error for an unknown format")
+	}
+}
@@ -37,12 +37,18 @@ func DefaultAuthConfig() AuthConfig {
 
 // LoadAuthConfig loads authentication configuration from environment variables
 func LoadAuthConfig() AuthConfig {
+	return authConfigFrom(os.Getenv("AUTH_ENABLED") == "true", getEnvOrDefault("AUTH_MODE", "api-key"))
+}
+
+// authConfigFrom builds auth configuration for the given switch and mode.
+// Secrets are always read from environment variables.
+func authConfigFrom(enabled bool, mode string) AuthConfig {
 	cfg := DefaultAuthConfig()
 
 	// Check if authentication is enabled
-	if os.Getenv("AUTH_ENABLED") == "true" {
+	if enabled {
 		cfg.Enabled = true
-		cfg.Mode = getEnvOrDefault("AUTH_MODE", "api-key")
+		cfg.Mode = mode
 
 		// Load API keys from comma-separated env var
 		// Format: AUTH_API_KEYS="key1:desc1,key2:desc2"
@@ -5,14 +5,26 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"time"
+
+	"github.com/ryacub/telos-idea-matrix/internal/models"
 )
 
 // Config holds the application configuration
 type Config struct {
-	Server   ServerConfig
-	Database DatabaseConfig
-	Telos    TelosConfig
-	Auth     AuthConfig
+	Server    ServerConfig
+	Database  DatabaseConfig
+	Telos     TelosConfig
+	Auth      AuthConfig
+	Display   DisplayConfig
+	LLM       LLMConfig
+	Notify    NotifyConfig
+	Reanalyze ReanalyzeConfig
+	Tracing   TracingConfig
+	Log       LogConfig
+
+	// Recommendation holds the score cutoffs for each recommendation
+	Recommendation models.RecommendationThresholds
 }
 
 // ServerConfig holds server-specific configuration
@@ -20,33 +32,380 @@ type ServerConfig struct {
 	Port         int
 	Host         string
 	AllowOrigins []string
+
+	// IdempotencyTTL is how long a create request's Idempotency-Key is
+	// remembered; zero ignores the header
+	IdempotencyTTL time.Duration
+
+	// MaxConcurrentAnalyses caps the AI analyses the API runs at once;
+	// zero removes the limit
+	MaxConcurrentAnalyses int
+
+	// AnalysisQueueTimeout is how long an analysis over the limit waits
+	// for a slot before the request is answered 429
+	AnalysisQueueTimeout time.Duration
 }
 
 // DatabaseConfig holds database configuration
 type DatabaseConfig struct {
 	Path string
+
+	// MaxOpenConns caps open connections. With WAL, readers don't block
+	// the writer, but SQLite still allows only one write at a time.
+	MaxOpenConns int
+
+	// MaxIdleConns is how many connections are kept ready for reuse
+	MaxIdleConns int
+
+	// ConnMaxLifetime closes connections after this long; zero keeps them open
+	ConnMaxLifetime time.Duration
+
+	// BusyTimeout is how long a write waits for a locked database
+	BusyTimeout time.Duration
+
+	// WALAutoCheckpoint is how many pages the WAL grows by before SQLite
+	// copies them back into the database; zero leaves checkpoints to
+	// 'tm db checkpoint'
+	WALAutoCheckpoint int
+}
+
+// DefaultDatabaseConfig returns the default settings for the database at path
+func DefaultDatabaseConfig(path string) DatabaseConfig {
+	values := make(map[string]string, len(Keys))
+	for _, k := range Keys {
+		values[k.Name] = k.DefaultValue()
+	}
+	cfg := databaseConfigFrom(values)
+	cfg.Path = path
+	return cfg
+}
+
+// Validate checks the database settings
+func (d DatabaseConfig) Validate() error {
+	if d.Path == "" {
+		return fmt.Errorf("database path cannot be empty")
+	}
+
+	if d.MaxOpenConns < 1 {
+		return fmt.Errorf("invalid database max open conns: %d (must be at least 1)", d.MaxOpenConns)
+	}
+
+	if d.MaxIdleConns < 0 {
+		return fmt.Errorf("invalid database max idle conns: %d (must not be negative)", d.MaxIdleConns)
+	}
+
+	if d.ConnMaxLifetime < 0 {
+		return fmt.Errorf("invalid database conn max lifetime: %s (must not be negative)", d.ConnMaxLifetime)
+	}
+
+	if d.BusyTimeout < 0 {
+		return fmt.Errorf("invalid database busy timeout: %s (must not be negative)", d.BusyTimeout)
+	}
+
+	if d.WALAutoCheckpoint < 0 {
+		return fmt.Errorf("invalid database wal autocheckpoint: %d (must not be negative)", d.WALAutoCheckpoint)
+	}
+
+	return nil
 }
 
 // TelosConfig holds telos file configuration
 type TelosConfig struct {
+	// Profile is the active named profile; see ProfilesDir
+	Profile string
+
+	// FilePath is the telos file for Profile
 	FilePath string
 }
 
-// Load loads configuration from environment variables with sensible defaults
+// DisplayConfig holds presentation settings that never affect stored data
+type DisplayConfig struct {
+	// CollapsePatterns merges patterns that differ only in case or whitespace when shown
+	CollapsePatterns bool
+
+	// ASCIICharts draws analytics charts with plain ASCII characters
+	ASCIICharts bool
+
+	// RelativeScores shows each score's percentile among the active ideas
+	RelativeScores bool
+}
+
+// LLMConfig holds LLM preferences
+type LLMConfig struct {
+	// DefaultProvider is the provider used for analysis; empty selects automatically
+	DefaultProvider string
+
+	// HealthCheckTimeout bounds each provider's health probe
+	HealthCheckTimeout time.Duration
+
+	// AnalysisTimeout bounds AI analysis in 'tm add' before it falls back
+	// to rule-based scoring; zero means no deadline
+	AnalysisTimeout time.Duration
+
+	// SystemPrompts maps a provider (ollama, claude, openai, groq, custom) to the
+	// system prompt setting its tone; providers without one use the default
+	SystemPrompts map[string]string
+}
+
+// NotifyConfig holds webhook notification settings
+type NotifyConfig struct {
+	// WebhookURL receives digests of high-scoring ideas; empty disables notifications
+	WebhookURL string
+
+	// MinScore is the lowest final score that triggers a notification
+	MinScore float64
+
+	// BatchWindow is how long ideas are collected into one digest
+	BatchWindow time.Duration
+
+	// MaxBatch sends a digest early once this many ideas are waiting
+	MaxBatch int
+
+	// Secret signs each delivery in an X-Signature header, read from
+	// NOTIFY_WEBHOOK_SECRET only; empty sends no signature
+	Secret string
+
+	// Timeout bounds each delivery attempt
+	Timeout time.Duration
+
+	// Retries is how many more attempts a failed delivery gets
+	Retries int
+}
+
+// ReanalyzeConfig holds background re-analysis settings for the web server
+type ReanalyzeConfig struct {
+	// OnTelosChange re-analyzes ideas scored against an older telos version
+	OnTelosChange bool
+
+	// MaxPerMinute paces background re-analysis to respect provider limits
+	MaxPerMinute int
+
+	// BudgetUSD is the estimated LLM spend allowed per day; 0 means no limit
+	BudgetUSD float64
+
+	// MaxAttempts is how many times an idea may fail to re-analyze before
+	// it is skipped
+	MaxAttempts int
+}
+
+// TracingConfig holds OpenTelemetry tracing settings for the web server
+type TracingConfig struct {
+	// Enabled exports spans over OTLP; the exporter itself is configured by
+	// the standard OTEL_EXPORTER_OTLP_* environment variables
+	Enabled bool
+}
+
+// LogConfig holds where and how much is logged
+type LogConfig struct {
+	// Level is the least severe level written: debug, info, warn or error
+	Level string
+
+	// Format is "json" or "console"
+	Format string
+
+	// Output is a file path, "stdout" or "stderr"; empty leaves the choice to the program
+	Output string
+}
+
+// EmbeddingsConfig holds the embedding provider used for similarity search
+type EmbeddingsConfig struct {
+	// Provider is "ollama" or "openai"; empty disables similarity search
+	Provider string
+
+	// Model overrides the provider's default embedding model
+	Model string
+}
+
+// NotionConfig holds the Notion database ideas are exported to
+type NotionConfig struct {
+	// DatabaseID is the database pages are created in
+	DatabaseID string
+
+	// Token is the integration token, read from NOTION_TOKEN only
+	Token string
+}
+
+// LoadDisplayConfig loads display configuration from the config file and environment
+func LoadDisplayConfig() DisplayConfig {
+	return displayConfigFrom(loadValues())
+}
+
+// LoadLLMConfig loads LLM preferences from the config file and environment
+func LoadLLMConfig() LLMConfig {
+	return llmConfigFrom(loadValues())
+}
+
+// LoadRecommendationThresholds loads the recommendation cutoffs from the
+// config file and environment. They are not validated; see
+// models.SetRecommendationThresholds.
+func LoadRecommendationThresholds() models.RecommendationThresholds {
+	return recommendationThresholdsFrom(loadValues())
+}
+
+// LoadLogConfig loads logging settings from the config file and environment
+func LoadLogConfig() LogConfig {
+	return logConfigFrom(loadValues())
+}
+
+func logConfigFrom(values map[string]string) LogConfig {
+	return LogConfig{
+		Level:  values["log.level"],
+		Format: values["log.format"],
+		Output: values["log.output"],
+	}
+}
+
+// LoadDatabaseConfig loads database settings from the config file and
+// environment. The CLI keeps its own database path, so callers usually
+// replace Path.
+func LoadDatabaseConfig() DatabaseConfig {
+	return databaseConfigFrom(loadValues())
+}
+
+func databaseConfigFrom(values map[string]string) DatabaseConfig {
+	maxOpen, _ := strconv.Atoi(values["database.max_open_conns"])
+	maxIdle, _ := strconv.Atoi(values["database.max_idle_conns"])
+	lifetime, _ := strconv.Atoi(values["database.conn_max_lifetime"])
+	busyTimeout, _ := strconv.Atoi(values["database.busy_timeout"])
+	autoCheckpoint, _ := strconv.Atoi(values["database.wal_autocheckpoint"])
+	return DatabaseConfig{
+		Path:              values["database.path"],
+		MaxOpenConns:      maxOpen,
+		MaxIdleConns:      maxIdle,
+		ConnMaxLifetime:   time.Duration(lifetime) * time.Second,
+		BusyTimeout:       time.Duration(busyTimeout) * time.Millisecond,
+		WALAutoCheckpoint: autoCheckpoint,
+	}
+}
+
+// LoadNotifyConfig loads notification settings from the config file and environment
+func LoadNotifyConfig() NotifyConfig {
+	return notifyConfigFrom(loadValues())
+}
+
+// LoadNotionConfig loads the Notion export settings from the config file and environment
+func LoadNotionConfig() NotionConfig {
+	return NotionConfig{
+		DatabaseID: loadValues()["notion.database_id"],
+		Token:      os.Getenv("NOTION_TOKEN"),
+	}
+}
+
+// LoadEmbeddingsConfig loads the embedding provider from the config file and environment
+func LoadEmbeddingsConfig() EmbeddingsConfig {
+	return embeddingsConfigFrom(loadValues())
+}
+
+func embeddingsConfigFrom(values map[string]string) EmbeddingsConfig {
+	return EmbeddingsConfig{
+		Provider: values["embeddings.provider"],
+		Model:    values["embeddings.model"],
+	}
+}
+
+func notifyConfigFrom(values map[string]string) NotifyConfig {
+	minScore, _ := strconv.ParseFloat(values["notify.min_score"], 64)
+	window, _ := strconv.Atoi(values["notify.batch_window"])
+	maxBatch, _ := strconv.Atoi(values["notify.max_batch"])
+	timeout, _ := strconv.Atoi(values["notify.webhook_timeout"])
+	retries, _ := strconv.Atoi(values["notify.webhook_retries"])
+	return NotifyConfig{
+		WebhookURL:  values["notify.webhook_url"],
+		MinScore:    minScore,
+		BatchWindow: time.Duration(window) * time.Second,
+		MaxBatch:    maxBatch,
+		Secret:      os.Getenv("NOTIFY_WEBHOOK_SECRET"),
+		Timeout:     time.Duration(timeout) * time.Second,
+		Retries:     retries,
+	}
+}
+
+func reanalyzeConfigFrom(values map[string]string) ReanalyzeConfig {
+	perMinute, _ := strconv.Atoi(values["reanalyze.max_per_minute"])
+	budget, _ := strconv.ParseFloat(values["reanalyze.budget_usd"], 64)
+	attempts, _ := strconv.Atoi(values["reanalyze.max_attempts"])
+	return ReanalyzeConfig{
+		OnTelosChange: values["reanalyze.on_telos_change"] == "true",
+		MaxPerMinute:  perMinute,
+		BudgetUSD:     budget,
+		MaxAttempts:   attempts,
+	}
+}
+
+func llmConfigFrom(values map[string]string) LLMConfig {
+	seconds, _ := strconv.Atoi(values["llm.health_check_timeout"])
+	analysisSeconds, _ := strconv.Atoi(values["llm.analysis_timeout"])
+	prompts := make(map[string]string)
+	for _, provider := range []string{"ollama", "claude", "openai", "groq", "custom"} {
+		if prompt := values["llm."+provider+".system_prompt"]; prompt != "" {
+			prompts[provider] = prompt
+		}
+	}
+	return LLMConfig{
+		DefaultProvider:    values["llm.default_provider"],
+		HealthCheckTimeout: time.Duration(seconds) * time.Second,
+		AnalysisTimeout:    time.Duration(analysisSeconds) * time.Second,
+		SystemPrompts:      prompts,
+	}
+}
+
+func recommendationThresholdsFrom(values map[string]string) models.RecommendationThresholds {
+	prioritize, _ := strconv.ParseFloat(values["recommendation.prioritize"], 64)
+	good, _ := strconv.ParseFloat(values["recommendation.good"], 64)
+	consider, _ := strconv.ParseFloat(values["recommendation.consider"], 64)
+	return models.RecommendationThresholds{Prioritize: prioritize, Good: good, Consider: consider}
+}
+
+func displayConfigFrom(values map[string]string) DisplayConfig {
+	return DisplayConfig{
+		CollapsePatterns: values["display.collapse_patterns"] == "true",
+		ASCIICharts:      values["display.ascii_charts"] == "true",
+		RelativeScores:   values["display.relative_scores"] == "true",
+	}
+}
+
+// Load loads configuration by merging defaults, the config file (see FilePath),
+// and environment variables, in increasing order of precedence
 func Load() (*Config, error) {
+	settings, err := loadSettings()
+	if err != nil {
+		return nil, fmt.Errorf("invalid configuration: %w", err)
+	}
+	values := make(map[string]string, len(settings))
+	for _, s := range settings {
+		values[s.Key] = s.Value
+	}
+
+	// Values are validated by the key registry, so conversion cannot fail
+	port, _ := strconv.Atoi(values["server.port"])
+	idempotencyTTL, _ := strconv.Atoi(values["server.idempotency_ttl"])
+	maxAnalyses, _ := strconv.Atoi(values["api.max_concurrent_analyses"])
+	queueTimeout, _ := strconv.Atoi(values["api.analysis_queue_timeout"])
+
 	cfg := &Config{
 		Server: ServerConfig{
-			Port:         getEnvAsInt("PORT", 8080),
-			Host:         getEnv("HOST", "0.0.0.0"),
+			Port:         port,
+			Host:         values["server.host"],
 			AllowOrigins: getEnvAsSlice("ALLOW_ORIGINS", []string{"http://localhost:5173", "http://localhost:3000"}),
+
+			IdempotencyTTL:        time.Duration(idempotencyTTL) * time.Second,
+			MaxConcurrentAnalyses: maxAnalyses,
+			AnalysisQueueTimeout:  time.Duration(queueTimeout) * time.Second,
 		},
-		Database: DatabaseConfig{
-			Path: getEnv("DB_PATH", "data/telos.db"),
-		},
+		Database: databaseConfigFrom(values),
 		Telos: TelosConfig{
-			FilePath: getEnv("TELOS_PATH", "telos.md"),
+			Profile:  values["telos.profile"],
+			FilePath: ProfileTelosPath(values["telos.profile"], values["telos.file_path"]),
 		},
-		Auth: LoadAuthConfig(),
+		Auth:      authConfigFrom(values["auth.enabled"] == "true", values["auth.mode"]),
+		Display:   displayConfigFrom(values),
+		LLM:       llmConfigFrom(values),
+		Notify:    notifyConfigFrom(values),
+		Reanalyze: reanalyzeConfigFrom(values),
+		Tracing:   TracingConfig{Enabled: values["tracing.enabled"] == "true"},
+		Log:       logConfigFrom(values),
+
+		Recommendation: recommendationThresholdsFrom(values),
 	}
 
 	// Validate configuration
@@ -67,45 +426,80 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("host cannot be empty")
 	}
 
-	if c.Database.Path == "" {
-		return fmt.Errorf("database path cannot be empty")
+	if err := c.Database.Validate(); err != nil {
+		return err
+	}
+
+	if err := ValidateProfileName(c.Telos.Profile); err != nil {
+		return err
 	}
 
 	if c.Telos.FilePath == "" {
 		return fmt.Errorf("telos file path cannot be empty")
 	}
 
-	return nil
-}
+	if c.Server.IdempotencyTTL < 0 {
+		return fmt.Errorf("invalid idempotency TTL: %s (must not be negative)", c.Server.IdempotencyTTL)
+	}
 
-// Address returns the server address as "host:port"
-func (c *Config) Address() string {
-	return fmt.Sprintf("%s:%d", c.Server.Host, c.Server.Port)
-}
+	if c.Server.MaxConcurrentAnalyses < 0 {
+		return fmt.Errorf("invalid API max concurrent analyses: %d (must not be negative)", c.Server.MaxConcurrentAnalyses)
+	}
 
-// Helper functions for environment variables
+	if c.Server.AnalysisQueueTimeout < 0 {
+		return fmt.Errorf("invalid API analysis queue timeout: %s (must not be negative)", c.Server.AnalysisQueueTimeout)
+	}
 
-func getEnv(key, defaultValue string) string {
-	if value := os.Getenv(key); value != "" {
-		return value
+	if c.LLM.HealthCheckTimeout <= 0 {
+		return fmt.Errorf("invalid LLM health check timeout: %s (must be at least 1 second)", c.LLM.HealthCheckTimeout)
 	}
-	return defaultValue
-}
 
-func getEnvAsInt(key string, defaultValue int) int {
-	valueStr := os.Getenv(key)
-	if valueStr == "" {
-		return defaultValue
+	if c.LLM.AnalysisTimeout < 0 {
+		return fmt.Errorf("invalid LLM analysis timeout: %s (must not be negative)", c.LLM.AnalysisTimeout)
 	}
 
-	value, err := strconv.Atoi(valueStr)
-	if err != nil {
-		return defaultValue
+	if c.Notify.BatchWindow < 0 {
+		return fmt.Errorf("invalid notify batch window: %s (must not be negative)", c.Notify.BatchWindow)
+	}
+
+	if c.Notify.MaxBatch < 0 {
+		return fmt.Errorf("invalid notify max batch: %d (must not be negative)", c.Notify.MaxBatch)
+	}
+
+	if c.Notify.Timeout <= 0 {
+		return fmt.Errorf("invalid notify webhook timeout: %s (must be at least 1 second)", c.Notify.Timeout)
+	}
+
+	if c.Notify.Retries < 0 {
+		return fmt.Errorf("invalid notify webhook retries: %d (must not be negative)", c.Notify.Retries)
+	}
+
+	if c.Reanalyze.MaxPerMinute < 1 {
+		return fmt.Errorf("invalid reanalyze max per minute: %d (must be at least 1)", c.Reanalyze.MaxPerMinute)
 	}
 
-	return value
+	if c.Reanalyze.BudgetUSD < 0 {
+		return fmt.Errorf("invalid reanalyze budget: %.2f (must not be negative)", c.Reanalyze.BudgetUSD)
+	}
+
+	if c.Reanalyze.MaxAttempts < 1 {
+		return fmt.Errorf("invalid reanalyze max attempts: %d (must be at least 1)", c.Reanalyze.MaxAttempts)
+	}
+
+	if err := c.Recommendation.Validate(); err != nil {
+		return fmt.Errorf("invalid recommendation thresholds: %w", err)
+	}
+
+	return nil
 }
 
+// Address returns the server address as "host:port"
+func (c *Config) Address() string {
+	return fmt.Sprintf("%s:%d", c.Server.Host, c.Server.Port)
+}
+
+// Helper functions for environment variables
+
 func getEnvAsSlice(key string, defaultValue []string) []string {
 	valueStr := os.Getenv(key)
 	if valueStr == "" {
@@ -0,0 +1,165 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// File is a YAML config file edited in place.
+// Values are read and written through the YAML node tree, so comments and
+// the order of existing keys survive a Set and Save.
+type File struct {
+	path string
+	doc  yaml.Node
+}
+
+// FilePath returns the config file location: $TELOS_CONFIG, or config.yaml
+// in the config directory (see ResolvePaths)
+func FilePath() string {
+	if path := os.Getenv("TELOS_CONFIG"); path != "" {
+		return path
+	}
+	return ResolvePaths().ConfigFile()
+}
+
+// LoadFile reads a config file. A missing or empty file yields an empty config.
+func LoadFile(path string) (*File, error) {
+	f := &File{path: path}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return f, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+	if len(bytes.TrimSpace(data)) == 0 {
+		return f, nil
+	}
+
+	if err := yaml.Unmarshal(data, &f.doc); err != nil {
+		return nil, fmt.Errorf("failed to parse config file: %w", err)
+	}
+	if root := f.root(); root != nil && root.Kind != yaml.MappingNode {
+		return nil, errors.New("failed to parse config file: top level must be a mapping")
+	}
+
+	return f, nil
+}
+
+// Path returns where the file is read from and saved to
+func (f *File) Path() string {
+	return f.path
+}
+
+// Get returns the raw value stored for a dotted key such as "auth.mode"
+func (f *File) Get(key string) (string, bool) {
+	node := f.root()
+	for _, part := range strings.Split(key, ".") {
+		if node == nil || node.Kind != yaml.MappingNode {
+			return "", false
+		}
+		node = mappingValue(node, part)
+	}
+	if node == nil || node.Kind != yaml.ScalarNode {
+		return "", false
+	}
+	return node.Value, true
+}
+
+// Set validates a value for a known key and stores it, creating sections as needed
+func (f *File) Set(key, value string) error {
+	k, ok := LookupKey(key)
+	if !ok {
+		return unknownKeyError(key)
+	}
+	normalized, err := k.Normalize(value)
+	if err != nil {
+		return err
+	}
+
+	if f.root() == nil {
+		f.doc = yaml.Node{
+			Kind:    yaml.DocumentNode,
+			Content: []*yaml.Node{{Kind: yaml.MappingNode, Tag: "!!map"}},
+		}
+	}
+
+	parts := strings.Split(key, ".")
+	node := f.root()
+	for _, part := range parts[:len(parts)-1] {
+		child := mappingValue(node, part)
+		if child == nil {
+			child = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+			node.Content = append(node.Content, scalarNode(part, "!!str"), child)
+		} else if child.Kind != yaml.MappingNode {
+			return fmt.Errorf("cannot set %s: %s is not a section", key, part)
+		}
+		node = child
+	}
+
+	leafName := parts[len(parts)-1]
+	if leaf := mappingValue(node, leafName); leaf != nil {
+		// Replace in place so comments attached to the value are kept
+		leaf.Kind = yaml.ScalarNode
+		leaf.Tag = k.Type.yamlTag()
+		leaf.Value = normalized
+		leaf.Style = 0
+		leaf.Content = nil
+		return nil
+	}
+	node.Content = append(node.Content, scalarNode(leafName, "!!str"), scalarNode(normalized, k.Type.yamlTag()))
+	return nil
+}
+
+// Save writes the file with user-only permissions, creating its directory if needed
+func (f *File) Save() error {
+	if err := os.MkdirAll(filepath.Dir(f.path), 0755); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if f.root() != nil {
+		encoder := yaml.NewEncoder(&buf)
+		encoder.SetIndent(2)
+		if err := encoder.Encode(&f.doc); err != nil {
+			return fmt.Errorf("failed to serialize config: %w", err)
+		}
+		if err := encoder.Close(); err != nil {
+			return fmt.Errorf("failed to serialize config: %w", err)
+		}
+	}
+
+	if err := os.WriteFile(f.path, buf.Bytes(), 0600); err != nil {
+		return fmt.Errorf("failed to write config file: %w", err)
+	}
+	return nil
+}
+
+// root returns the top-level mapping node, or nil for an empty document
+func (f *File) root() *yaml.Node {
+	if f.doc.Kind != yaml.DocumentNode || len(f.doc.Content) == 0 {
+		return nil
+	}
+	return f.doc.Content[0]
+}
+
+// mappingValue returns the value node for key in a mapping node, or nil
+func mappingValue(mapping *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			return mapping.Content[i+1]
+		}
+	}
+	return nil
+}
+
+func scalarNode(value, tag string) *yaml.Node {
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: tag, Value: value}
+}
@@ -0,0 +1,323 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ryacub/telos-idea-matrix/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// clearConfigEnv isolates a test from config environment variables on the host
+func clearConfigEnv(t *testing.T) {
+	t.Helper()
+	for _, k := range Keys {
+		t.Setenv(k.Env, "")
+	}
+}
+
+func TestFile_SetPreservesComments(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	original := "# My settings\nserver:\n  # web port\n  port: 9000 # custom\n"
+	require.NoError(t, os.WriteFile(path, []byte(original), 0600))
+
+	file, err := LoadFile(path)
+	require.NoError(t, err)
+	require.NoError(t, file.Set("server.port", "9100"))
+	require.NoError(t, file.Set("auth.mode", "jwt"))
+	require.NoError(t, file.Save())
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "# My settings\nserver:\n  # web port\n  port: 9100 # custom\nauth:\n  mode: jwt\n", string(data))
+
+	reloaded, err := LoadFile(path)
+	require.NoError(t, err)
+	value, ok := reloaded.Get("auth.mode")
+	assert.True(t, ok)
+	assert.Equal(t, "jwt", value)
+}
+
+func TestFile_SetValidatesKeysAndTypes(t *testing.T) {
+	file, err := LoadFile(filepath.Join(t.TempDir(), "missing.yaml"))
+	require.NoError(t, err)
+
+	testCases := []struct {
+		key, value, errMsg string
+	}{
+		{"server.port", "abc", "must be an integer"},
+		{"auth.enabled", "maybe", "must be true or false"},
+		{"auth.mode", "oauth", "must be one of api-key, jwt"},
+		{"nope.key", "1", "unknown config key"},
+		{"llm.openai.system_prompt", "  ", "must not be empty"},
+		{"reanalyze.budget_usd", "cheap", "must be a number"},
+	}
+	for _, tc := range testCases {
+		err := file.Set(tc.key, tc.value)
+		require.Error(t, err, tc.key)
+		assert.Contains(t, err.Error(), tc.errMsg)
+	}
+
+	// Values are stored in canonical form
+	require.NoError(t, file.Set("auth.enabled", "1"))
+	value, _ := file.Get("auth.enabled")
+	assert.Equal(t, "true", value)
+}
+
+func TestEffective_Precedence(t *testing.T) {
+	clearConfigEnv(t)
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("server:\n  port: 9000\n  host: 127.0.0.1\n"), 0600))
+	t.Setenv("HOST", "localhost")
+	t.Setenv("AUTH_MODE", "not-a-mode") // invalid env values are ignored
+
+	file, err := LoadFile(path)
+	require.NoError(t, err)
+	settings, err := Effective(file)
+	require.NoError(t, err)
+
+	bySource := make(map[string]Setting)
+	for _, s := range settings {
+		bySource[s.Key] = s
+	}
+	assert.Equal(t, Setting{Key: "server.port", Value: "9000", Source: SourceFile}, bySource["server.port"])
+	assert.Equal(t, Setting{Key: "server.host", Value: "localhost", Source: SourceEnv}, bySource["server.host"])
+	assert.Equal(t, Setting{Key: "auth.mode", Value: "api-key", Source: SourceDefault}, bySource["auth.mode"])
+}
+
+func TestLoad_MergesConfigFile(t *testing.T) {
+	clearConfigEnv(t)
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("server:\n  port: 9000\nllm:\n  default_provider: ollama\n  health_check_timeout: 12\ndisplay:\n  collapse_patterns: false\n  ascii_charts: true\n"), 0600))
+	t.Setenv("TELOS_CONFIG", path)
+
+	cfg, err := Load()
+	require.NoError(t, err)
+	assert.Equal(t, 9000, cfg.Server.Port)
+	assert.Equal(t, ResolvePaths().DatabaseFile(), cfg.Database.Path)
+	assert.Equal(t, "ollama", cfg.LLM.DefaultProvider)
+	assert.Equal(t, 12*time.Second, cfg.LLM.HealthCheckTimeout)
+	assert.False(t, cfg.Display.CollapsePatterns)
+	assert.False(t, LoadDisplayConfig().CollapsePatterns)
+	assert.True(t, cfg.Display.ASCIICharts)
+}
+
+func TestLoad_SystemPrompts(t *testing.T) {
+	clearConfigEnv(t)
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("llm:\n  openai:\n    system_prompt: Be terse.\n"), 0600))
+	t.Setenv("TELOS_CONFIG", path)
+	t.Setenv("OLLAMA_SYSTEM_PROMPT", "Explain your reasoning in detail.")
+
+	cfg, err := Load()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"openai": "Be terse.",
+		"ollama": "Explain your reasoning in detail.",
+	}, cfg.LLM.SystemPrompts)
+
+	// A prompt that is set must not be empty
+	require.NoError(t, os.WriteFile(path, []byte("llm:\n  claude:\n    system_prompt: \"\"\n"), 0600))
+	_, err = Load()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "llm.claude.system_prompt must not be empty")
+}
+
+func TestLoad_RecommendationThresholds(t *testing.T) {
+	clearConfigEnv(t)
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	t.Setenv("TELOS_CONFIG", path)
+
+	cfg, err := Load()
+	require.NoError(t, err)
+	assert.Equal(t, models.DefaultRecommendationThresholds(), cfg.Recommendation)
+
+	require.NoError(t, os.WriteFile(path, []byte("recommendation:\n  prioritize: 9\n  good: 6.5\n"), 0600))
+	t.Setenv("RECOMMENDATION_CONSIDER", "4")
+	cfg, err = Load()
+	require.NoError(t, err)
+	assert.Equal(t, models.RecommendationThresholds{Prioritize: 9, Good: 6.5, Consider: 4}, cfg.Recommendation)
+	assert.Equal(t, cfg.Recommendation, LoadRecommendationThresholds())
+
+	// Each value is valid on its own, but together they don't ascend
+	require.NoError(t, os.WriteFile(path, []byte("recommendation:\n  good: 3\n"), 0600))
+	_, err = Load()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid recommendation thresholds")
+}
+
+func TestLoad_InvalidConfigFile(t *testing.T) {
+	clearConfigEnv(t)
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("server:\n  port: lots\n"), 0600))
+	t.Setenv("TELOS_CONFIG", path)
+
+	_, err := Load()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "server.port must be an integer")
+
+	// Display settings fall back to defaults rather than failing
+	assert.True(t, LoadDisplayConfig().CollapsePatterns)
+}
+
+func TestLoad_ResolvesProfileTelosPath(t *testing.T) {
+	clearConfigEnv(t)
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("TELOS_CONFIG", filepath.Join(home, "config.yaml"))
+
+	// The default profile keeps the configured telos file until it has its own
+	cfg, err := Load()
+	require.NoError(t, err)
+	assert.Equal(t, DefaultProfile, cfg.Telos.Profile)
+	assert.Equal(t, filepath.Join(home, ".telos", "telos.md"), cfg.Telos.FilePath)
+
+	require.NoError(t, os.MkdirAll(ProfilesDir(), 0755))
+	for _, name := range []string{"work.md", "default.md", "notes.txt"} {
+		require.NoError(t, os.WriteFile(filepath.Join(ProfilesDir(), name), nil, 0600))
+	}
+
+	cfg, err = Load()
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(ProfilesDir(), "default.md"), cfg.Telos.FilePath)
+
+	t.Setenv("TELOS_PROFILE", "work")
+	cfg, err = Load()
+	require.NoError(t, err)
+	assert.Equal(t, "work", cfg.Telos.Profile)
+	assert.Equal(t, filepath.Join(ProfilesDir(), "work.md"), cfg.Telos.FilePath)
+
+	names, err := ListProfiles()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"default", "work"}, names)
+
+	t.Setenv("TELOS_PROFILE", "../escape")
+	_, err = Load()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid profile name")
+}
+
+func TestLoad_WebPathsDefaultToResolvedPaths(t *testing.T) {
+	clearConfigEnv(t)
+	dir := t.TempDir()
+	t.Setenv("TELOS_HOME", dir)
+	t.Setenv("TELOS_CONFIG", filepath.Join(dir, "config.yaml"))
+
+	cfg, err := Load()
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "ideas.db"), cfg.Database.Path)
+	assert.Equal(t, filepath.Join(dir, "telos.md"), cfg.Telos.FilePath)
+
+	t.Setenv("DB_PATH", "/srv/telos/ideas.db")
+	cfg, err = Load()
+	require.NoError(t, err)
+	assert.Equal(t, "/srv/telos/ideas.db", cfg.Database.Path)
+}
+
+func TestLoad_DatabasePool(t *testing.T) {
+	clearConfigEnv(t)
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	t.Setenv("TELOS_CONFIG", path)
+
+	cfg, err := Load()
+	require.NoError(t, err)
+	assert.Equal(t, DefaultDatabaseConfig(ResolvePaths().DatabaseFile()), cfg.Database)
+	assert.Equal(t, 5, cfg.Database.MaxOpenConns)
+	assert.Equal(t, 5*time.Second, cfg.Database.BusyTimeout)
+	assert.Equal(t, 1000, cfg.Database.WALAutoCheckpoint)
+
+	require.NoError(t, os.WriteFile(path, []byte("database:\n  max_open_conns: 1\n  max_idle_conns: 1\n  conn_max_lifetime: 0\n"), 0600))
+	t.Setenv("DB_BUSY_TIMEOUT", "10000")
+	t.Setenv("DB_WAL_AUTOCHECKPOINT", "0")
+	cfg, err = Load()
+	require.NoError(t, err)
+	assert.Equal(t, 1, cfg.Database.MaxOpenConns)
+	assert.Equal(t, 1, cfg.Database.MaxIdleConns)
+	assert.Zero(t, cfg.Database.ConnMaxLifetime)
+	assert.Equal(t, 10*time.Second, cfg.Database.BusyTimeout)
+	assert.Zero(t, cfg.Database.WALAutoCheckpoint)
+	assert.Equal(t, cfg.Database, LoadDatabaseConfig())
+
+	// The pool needs at least one connection
+	require.NoError(t, os.WriteFile(path, []byte("database:\n  max_open_conns: 0\n"), 0600))
+	_, err = Load()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "max open conns: 0 (must be at least 1)")
+}
+
+func TestLoad_AnalysisLimit(t *testing.T) {
+	clearConfigEnv(t)
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	t.Setenv("TELOS_CONFIG", path)
+
+	cfg, err := Load()
+	require.NoError(t, err)
+	assert.Equal(t, 4, cfg.Server.MaxConcurrentAnalyses)
+	assert.Equal(t, 10*time.Second, cfg.Server.AnalysisQueueTimeout)
+
+	require.NoError(t, os.WriteFile(path, []byte("api:\n  max_concurrent_analyses: 0\n"), 0600))
+	t.Setenv("API_ANALYSIS_QUEUE_TIMEOUT", "0")
+	cfg, err = Load()
+	require.NoError(t, err)
+	assert.Zero(t, cfg.Server.MaxConcurrentAnalyses, "zero removes the limit")
+	assert.Zero(t, cfg.Server.AnalysisQueueTimeout)
+
+	t.Setenv("API_MAX_CONCURRENT_ANALYSES", "-1")
+	_, err = Load()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "max concurrent analyses: -1 (must not be negative)")
+}
+
+func TestLoad_NotifyWebhook(t *testing.T) {
+	clearConfigEnv(t)
+	t.Setenv("NOTIFY_WEBHOOK_SECRET", "")
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	t.Setenv("TELOS_CONFIG", path)
+
+	cfg, err := Load()
+	require.NoError(t, err)
+	assert.Equal(t, 5*time.Second, cfg.Notify.Timeout)
+	assert.Equal(t, 3, cfg.Notify.Retries)
+	assert.Equal(t, 7.0, cfg.Notify.MinScore)
+	assert.Empty(t, cfg.Notify.Secret)
+
+	require.NoError(t, os.WriteFile(path, []byte("notify:\n  min_score: 7.5\n  webhook_retries: 0\n  webhook_timeout: 2\n"), 0600))
+	t.Setenv("NOTIFY_WEBHOOK_SECRET", "s3cret")
+	cfg, err = Load()
+	require.NoError(t, err)
+	assert.Equal(t, 2*time.Second, cfg.Notify.Timeout)
+	assert.Zero(t, cfg.Notify.Retries)
+	assert.Equal(t, 7.5, cfg.Notify.MinScore)
+	assert.Equal(t, "s3cret", cfg.Notify.Secret, "the secret comes from the environment")
+
+	t.Setenv("NOTIFY_WEBHOOK_TIMEOUT", "0")
+	_, err = Load()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "webhook timeout: 0s (must be at least 1 second)")
+}
+
+func TestLoad_Logging(t *testing.T) {
+	clearConfigEnv(t)
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	t.Setenv("TELOS_CONFIG", path)
+
+	cfg, err := Load()
+	require.NoError(t, err)
+	assert.Equal(t, LogConfig{Level: "info", Format: "json"}, cfg.Log)
+
+	require.NoError(t, os.WriteFile(path, []byte("log:\n  level: debug\n  output: /var/log/telos.log\n"), 0600))
+	t.Setenv("LOG_FORMAT", "console")
+	t.Setenv("LOG_OUTPUT", "stdout")
+	cfg, err = Load()
+	require.NoError(t, err)
+	assert.Equal(t, LogConfig{Level: "debug", Format: "console", Output: "stdout"}, cfg.Log)
+	assert.Equal(t, cfg.Log, LoadLogConfig())
+
+	require.NoError(t, os.WriteFile(path, []byte("log:\n  level: verbose\n"), 0600))
+	_, err = Load()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "log.level")
+}
@@ -0,0 +1,232 @@
+package config
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// KeyType is the value type accepted by a config key
+type KeyType string
+
+// Supported config key types
+const (
+	KeyTypeString KeyType = "string"
+	KeyTypeInt    KeyType = "int"
+	KeyTypeFloat  KeyType = "float"
+	KeyTypeBool   KeyType = "bool"
+)
+
+// yamlTag returns the YAML tag used when writing values of this type
+func (t KeyType) yamlTag() string {
+	switch t {
+	case KeyTypeInt:
+		return "!!int"
+	case KeyTypeFloat:
+		return "!!float"
+	case KeyTypeBool:
+		return "!!bool"
+	default:
+		return "!!str"
+	}
+}
+
+// Sources of an effective config value, from lowest to highest precedence
+const (
+	SourceDefault = "default"
+	SourceFile    = "file"
+	SourceEnv     = "env"
+)
+
+// Key describes a setting that can be stored in the config file
+type Key struct {
+	Name        string        // Dotted name, e.g. "auth.mode"
+	Type        KeyType       // Value type
+	Env         string        // Environment variable that overrides the file
+	Default     string        // Value used when neither file nor env set it
+	DefaultFunc func() string // Computes Default when it depends on the environment, e.g. ResolvePaths
+	Allowed     []string      // Permitted values, if restricted
+	NonEmpty    bool          // Optional, but may not be set to an empty value
+	Description string
+}
+
+// DefaultValue returns the value used when neither file nor env set the key
+func (k Key) DefaultValue() string {
+	if k.DefaultFunc != nil {
+		return k.DefaultFunc()
+	}
+	return k.Default
+}
+
+// Keys lists every setting the config file understands.
+// Secrets such as API keys are deliberately environment-only.
+var Keys = []Key{
+	{Name: "server.port", Type: KeyTypeInt, Env: "PORT", Default: "8080", Description: "Web server port"},
+	{Name: "server.host", Type: KeyTypeString, Env: "HOST", Default: "0.0.0.0", Description: "Web server bind address"},
+	{Name: "server.idempotency_ttl", Type: KeyTypeInt, Env: "IDEMPOTENCY_TTL", Default: "86400", Description: "Seconds the API remembers an Idempotency-Key and replays its response; 0 ignores the header"},
+	{Name: "api.max_concurrent_analyses", Type: KeyTypeInt, Env: "API_MAX_CONCURRENT_ANALYSES", Default: "4", Description: "Most AI analyses the web API runs at once; 0 removes the limit"},
+	{Name: "api.analysis_queue_timeout", Type: KeyTypeInt, Env: "API_ANALYSIS_QUEUE_TIMEOUT", Default: "10", Description: "Seconds an AI analysis over the limit waits for a slot before the API answers 429; 0 answers 429 at once"},
+	{Name: "database.path", Type: KeyTypeString, Env: "DB_PATH", DefaultFunc: func() string { return ResolvePaths().DatabaseFile() }, Description: "Web server database location; defaults to the CLI's"},
+	{Name: "database.max_open_conns", Type: KeyTypeInt, Env: "DB_MAX_OPEN_CONNS", Default: "5", Description: "Most open SQLite connections; SQLite allows one writer at a time however many are open"},
+	{Name: "database.max_idle_conns", Type: KeyTypeInt, Env: "DB_MAX_IDLE_CONNS", Default: "2", Description: "Idle connections kept ready for reuse"},
+	{Name: "database.conn_max_lifetime", Type: KeyTypeInt, Env: "DB_CONN_MAX_LIFETIME", Default: "300", Description: "Seconds before a connection is closed and reopened; 0 keeps connections open"},
+	{Name: "database.busy_timeout", Type: KeyTypeInt, Env: "DB_BUSY_TIMEOUT", Default: "5000", Description: "Milliseconds a write waits for a locked database before failing"},
+	{Name: "database.wal_autocheckpoint", Type: KeyTypeInt, Env: "DB_WAL_AUTOCHECKPOINT", Default: "1000", Description: "WAL pages written before SQLite checkpoints them into the database; 0 turns automatic checkpoints off"},
+	{Name: "telos.file_path", Type: KeyTypeString, Env: "TELOS_PATH", DefaultFunc: DefaultTelosPath, Description: "Web server telos.md location; defaults to the CLI's"},
+	{Name: "telos.profile", Type: KeyTypeString, Env: "TELOS_PROFILE", Default: DefaultProfile, Description: "Active telos profile in ~/.telos/profiles"},
+	{Name: "auth.enabled", Type: KeyTypeBool, Env: "AUTH_ENABLED", Default: "false", Description: "Require API authentication"},
+	{Name: "auth.mode", Type: KeyTypeString, Env: "AUTH_MODE", Default: "api-key", Allowed: []string{"api-key", "jwt"}, Description: "Authentication mechanism"},
+	{Name: "display.collapse_patterns", Type: KeyTypeBool, Env: "COLLAPSE_DUPLICATE_PATTERNS", Default: "true", Description: "Merge case/whitespace pattern variants when shown"},
+	{Name: "display.ascii_charts", Type: KeyTypeBool, Env: "ASCII_CHARTS", Default: "false", Description: "Draw analytics charts with ASCII instead of block characters"},
+	{Name: "display.relative_scores", Type: KeyTypeBool, Env: "RELATIVE_SCORES", Default: "false", Description: "Show each score's rank among your active ideas in show and list"},
+	{Name: "recommendation.prioritize", Type: KeyTypeFloat, Env: "RECOMMENDATION_PRIORITIZE", Default: "8.5", Description: "Lowest final score recommended as PRIORITIZE NOW"},
+	{Name: "recommendation.good", Type: KeyTypeFloat, Env: "RECOMMENDATION_GOOD", Default: "7", Description: "Lowest final score recommended as GOOD ALIGNMENT"},
+	{Name: "recommendation.consider", Type: KeyTypeFloat, Env: "RECOMMENDATION_CONSIDER", Default: "5", Description: "Lowest final score recommended as CONSIDER LATER; anything lower is AVOID FOR NOW"},
+	{Name: "llm.default_provider", Type: KeyTypeString, Env: "LLM_DEFAULT_PROVIDER", Default: "", Description: "LLM provider used for analysis"},
+	{Name: "notify.webhook_url", Type: KeyTypeString, Env: "NOTIFY_WEBHOOK_URL", Default: "", Description: "Webhook that receives high-scoring ideas; empty disables notifications"},
+	{Name: "notify.min_score", Type: KeyTypeFloat, Env: "NOTIFY_MIN_SCORE", Default: "7", Description: "Lowest final score that triggers a notification"},
+	{Name: "notify.batch_window", Type: KeyTypeInt, Env: "NOTIFY_BATCH_WINDOW", Default: "30", Description: "Seconds to collect ideas into one digest; 0 sends each idea immediately"},
+	{Name: "notify.max_batch", Type: KeyTypeInt, Env: "NOTIFY_MAX_BATCH", Default: "20", Description: "Send a digest early once this many ideas are waiting"},
+	{Name: "notify.webhook_timeout", Type: KeyTypeInt, Env: "NOTIFY_WEBHOOK_TIMEOUT", Default: "5", Description: "Seconds each webhook delivery attempt may take"},
+	{Name: "notify.webhook_retries", Type: KeyTypeInt, Env: "NOTIFY_WEBHOOK_RETRIES", Default: "3", Description: "Further attempts after a webhook delivery fails with a network error, 429 or 5xx; the secret is read from NOTIFY_WEBHOOK_SECRET"},
+	{Name: "llm.health_check_timeout", Type: KeyTypeInt, Env: "LLM_HEALTH_CHECK_TIMEOUT", Default: "5", Description: "Seconds to wait for each provider health check"},
+	{Name: "llm.analysis_timeout", Type: KeyTypeInt, Env: "LLM_ANALYSIS_TIMEOUT", Default: "60", Description: "Seconds 'tm add --ai' waits for the LLM before falling back to rule-based scoring; 0 waits indefinitely"},
+	{Name: "llm.ollama.system_prompt", Type: KeyTypeString, Env: "OLLAMA_SYSTEM_PROMPT", NonEmpty: true, Description: "Tone for Ollama, prepended to its prompt; unset uses the built-in prompt"},
+	{Name: "llm.claude.system_prompt", Type: KeyTypeString, Env: "CLAUDE_SYSTEM_PROMPT", NonEmpty: true, Description: "Tone for Claude, sent as its system message; unset uses the built-in prompt"},
+	{Name: "llm.openai.system_prompt", Type: KeyTypeString, Env: "OPENAI_SYSTEM_PROMPT", NonEmpty: true, Description: "Tone for OpenAI, sent as its system message; unset uses the built-in prompt"},
+	{Name: "llm.groq.system_prompt", Type: KeyTypeString, Env: "GROQ_SYSTEM_PROMPT", NonEmpty: true, Description: "Tone for Groq, sent as its system message; unset uses the built-in prompt"},
+	{Name: "llm.custom.system_prompt", Type: KeyTypeString, Env: "CUSTOM_LLM_SYSTEM_PROMPT", NonEmpty: true, Description: "Tone for the custom provider, sent as \"system\"; unset uses the built-in prompt"},
+	{Name: "reanalyze.on_telos_change", Type: KeyTypeBool, Env: "REANALYZE_ON_TELOS_CHANGE", Default: "false", Description: "Web server re-analyzes ideas scored against an older telos in the background"},
+	{Name: "reanalyze.max_per_minute", Type: KeyTypeInt, Env: "REANALYZE_MAX_PER_MINUTE", Default: "10", Description: "Most background re-analyses started per minute"},
+	{Name: "reanalyze.budget_usd", Type: KeyTypeFloat, Env: "REANALYZE_BUDGET_USD", Default: "1", Description: "Estimated LLM spend allowed for background re-analysis per day; 0 means no limit"},
+	{Name: "reanalyze.max_attempts", Type: KeyTypeInt, Env: "REANALYZE_MAX_ATTEMPTS", Default: "3", Description: "Failed background re-analyses of an idea before it is skipped"},
+	{Name: "log.level", Type: KeyTypeString, Env: "LOG_LEVEL", Default: "info", Allowed: []string{"debug", "info", "warn", "error"}, Description: "Least severe log messages written; 'tm --log-level' overrides it for one command"},
+	{Name: "log.format", Type: KeyTypeString, Env: "LOG_FORMAT", Default: "json", Allowed: []string{"json", "console"}, Description: "Log format: json, or console for human-readable lines"},
+	{Name: "log.output", Type: KeyTypeString, Env: "LOG_OUTPUT", Default: "", Description: "Log destination: a file path, stdout or stderr; empty uses telos-matrix.log in the log directory for the web server and stderr for the CLI"},
+	{Name: "tracing.enabled", Type: KeyTypeBool, Env: "TRACING_ENABLED", Default: "false", Description: "Web server exports OpenTelemetry traces over OTLP, to OTEL_EXPORTER_OTLP_ENDPOINT"},
+	{Name: "embeddings.provider", Type: KeyTypeString, Env: "EMBEDDINGS_PROVIDER", Default: "", Allowed: []string{"", "ollama", "openai"}, Description: "Provider that embeds ideas for 'tm similar'; empty disables similarity search"},
+	{Name: "embeddings.model", Type: KeyTypeString, Env: "EMBEDDINGS_MODEL", NonEmpty: true, Description: "Embedding model; unset uses nomic-embed-text (Ollama) or text-embedding-3-small (OpenAI)"},
+	{Name: "notion.database_id", Type: KeyTypeString, Env: "NOTION_DATABASE_ID", Default: "", Description: "Notion database 'tm bulk export --format notion' syncs ideas to; the token is read from NOTION_TOKEN"},
+}
+
+// LookupKey finds a known config key by its dotted name
+func LookupKey(name string) (Key, bool) {
+	for _, k := range Keys {
+		if k.Name == name {
+			return k, true
+		}
+	}
+	return Key{}, false
+}
+
+// Normalize validates a value for this key and returns its canonical form
+func (k Key) Normalize(value string) (string, error) {
+	value = strings.TrimSpace(value)
+	if k.NonEmpty && value == "" {
+		return "", fmt.Errorf("%s must not be empty; remove it from the config file to use the default", k.Name)
+	}
+
+	switch k.Type {
+	case KeyTypeInt:
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return "", fmt.Errorf("%s must be an integer, got %q", k.Name, value)
+		}
+		value = strconv.Itoa(n)
+	case KeyTypeFloat:
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil || math.IsNaN(f) || math.IsInf(f, 0) {
+			return "", fmt.Errorf("%s must be a number, got %q", k.Name, value)
+		}
+		value = strconv.FormatFloat(f, 'f', -1, 64)
+	case KeyTypeBool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return "", fmt.Errorf("%s must be true or false, got %q", k.Name, value)
+		}
+		value = strconv.FormatBool(b)
+	}
+
+	if len(k.Allowed) > 0 {
+		for _, allowed := range k.Allowed {
+			if value == allowed {
+				return value, nil
+			}
+		}
+		return "", fmt.Errorf("%s must be one of %s, got %q", k.Name, strings.Join(k.Allowed, ", "), value)
+	}
+
+	return value, nil
+}
+
+// Setting is the effective value of a key and where it came from
+type Setting struct {
+	Key    string `json:"key"`
+	Value  string `json:"value"`
+	Source string `json:"source"`
+}
+
+// Effective merges defaults, the config file, and environment variables for every key.
+// An invalid value in the file is an error; an invalid environment variable is ignored.
+func Effective(file *File) ([]Setting, error) {
+	settings := make([]Setting, 0, len(Keys))
+
+	for _, k := range Keys {
+		setting := Setting{Key: k.Name, Value: k.DefaultValue(), Source: SourceDefault}
+
+		if raw, ok := file.Get(k.Name); ok {
+			value, err := k.Normalize(raw)
+			if err != nil {
+				return nil, fmt.Errorf("config file %s: %w", file.Path(), err)
+			}
+			setting.Value, setting.Source = value, SourceFile
+		}
+
+		if raw := os.Getenv(k.Env); k.Env != "" && raw != "" {
+			if value, err := k.Normalize(raw); err == nil {
+				setting.Value, setting.Source = value, SourceEnv
+			}
+		}
+
+		settings = append(settings, setting)
+	}
+
+	return settings, nil
+}
+
+// loadValues returns effective values keyed by name. An unreadable or invalid
+// config file is skipped so callers that only need a setting or two still work.
+func loadValues() map[string]string {
+	settings, err := loadSettings()
+	if err != nil {
+		settings, _ = Effective(&File{})
+	}
+
+	values := make(map[string]string, len(settings))
+	for _, s := range settings {
+		values[s.Key] = s.Value
+	}
+	return values
+}
+
+// loadSettings reads the config file and returns the effective settings
+func loadSettings() ([]Setting, error) {
+	file, err := LoadFile(FilePath())
+	if err != nil {
+		return nil, err
+	}
+	return Effective(file)
+}
+
+// unknownKeyError lists the valid keys for a mistyped key
+func unknownKeyError(name string) error {
+	names := make([]string, len(Keys))
+	for i, k := range Keys {
+		names[i] = k.Name
+	}
+	sort.Strings(names)
+	return fmt.Errorf("unknown config key %q (valid keys: %s)", name, strings.Join(names, ", "))
+}
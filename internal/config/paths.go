@@ -5,6 +5,86 @@ import (
 	"path/filepath"
 )
 
+// Where the directories in Paths came from
+const (
+	PathsFromTelosHome = "TELOS_HOME" // $TELOS_HOME holds the whole tree
+	PathsFromLegacy    = "legacy"     // an existing ~/.telos
+	PathsFromXDG       = "xdg"        // $XDG_CONFIG_HOME and/or $XDG_DATA_HOME
+	PathsFromDefault   = "default"    // a new ~/.telos
+)
+
+// Paths locates the directories the CLI and web server keep their files in
+type Paths struct {
+	ConfigDir string // config.yaml, telos.md, profiles and rule files
+	DataDir   string // ideas.db
+	LogDir    string // web server logs
+	Source    string // one of the PathsFrom constants
+}
+
+// ResolvePaths picks the directories to use. $TELOS_HOME holds everything
+// when set. Otherwise an existing ~/.telos is kept, so upgrades don't lose
+// data; new installs follow $XDG_CONFIG_HOME and $XDG_DATA_HOME, falling
+// back to ~/.telos when they are unset.
+func ResolvePaths() Paths {
+	if dir := os.Getenv("TELOS_HOME"); dir != "" {
+		return Paths{ConfigDir: dir, DataDir: dir, LogDir: filepath.Join(dir, "logs"), Source: PathsFromTelosHome}
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		home = ""
+	}
+	legacy := filepath.Join(home, ".telos")
+
+	paths := Paths{ConfigDir: legacy, DataDir: legacy, Source: PathsFromDefault}
+	if isDir(legacy) {
+		paths.Source = PathsFromLegacy
+	} else {
+		if xdg := os.Getenv("XDG_CONFIG_HOME"); xdg != "" {
+			paths.ConfigDir = filepath.Join(xdg, "telos")
+			paths.Source = PathsFromXDG
+		}
+		if xdg := os.Getenv("XDG_DATA_HOME"); xdg != "" {
+			paths.DataDir = filepath.Join(xdg, "telos")
+			paths.Source = PathsFromXDG
+		}
+	}
+
+	// Older web servers logged to ~/.telos-idea-matrix/logs
+	paths.LogDir = filepath.Join(paths.DataDir, "logs")
+	if legacyLogs := filepath.Join(home, ".telos-idea-matrix", "logs"); isDir(legacyLogs) {
+		paths.LogDir = legacyLogs
+	}
+
+	return paths
+}
+
+// ConfigFile returns the config file location, ignoring $TELOS_CONFIG
+func (p Paths) ConfigFile() string {
+	return filepath.Join(p.ConfigDir, "config.yaml")
+}
+
+// TelosFile returns the default telos.md location
+func (p Paths) TelosFile() string {
+	return filepath.Join(p.ConfigDir, "telos.md")
+}
+
+// PromptsDir returns the directory of per-provider LLM prompt templates
+func (p Paths) PromptsDir() string {
+	return filepath.Join(p.ConfigDir, "prompts")
+}
+
+// ScoreBoundsFile returns the score floors and ceilings applied to telos.md
+// scoring
+func (p Paths) ScoreBoundsFile() string {
+	return filepath.Join(p.ConfigDir, "score-bounds.yaml")
+}
+
+// DatabaseFile returns the default ideas database location
+func (p Paths) DatabaseFile() string {
+	return filepath.Join(p.DataDir, "ideas.db")
+}
+
 // EnsureDataDir ensures the data directory exists
 func EnsureDataDir(dbPath string) error {
 	dir := filepath.Dir(dbPath)
@@ -13,11 +93,7 @@ func EnsureDataDir(dbPath string) error {
 
 // DefaultTelosPath returns the default path to telos.md
 func DefaultTelosPath() string {
-	home, err := os.UserHomeDir()
-	if err != nil {
-		return "telos.md"
-	}
-	return filepath.Join(home, "telos.md")
+	return ResolvePaths().TelosFile()
 }
 
 // FileExists checks if a file exists
@@ -25,3 +101,8 @@ func FileExists(path string) bool {
 	_, err := os.Stat(path)
 	return err == nil
 }
+
+func isDir(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.IsDir()
+}
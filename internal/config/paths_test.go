@@ -0,0 +1,73 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// isolatePaths points HOME at an empty directory and clears the variables
+// ResolvePaths reads
+func isolatePaths(t *testing.T) string {
+	t.Helper()
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	for _, env := range []string{"TELOS_HOME", "XDG_CONFIG_HOME", "XDG_DATA_HOME", "TELOS_CONFIG"} {
+		t.Setenv(env, "")
+	}
+	return home
+}
+
+func TestResolvePaths_DefaultsToTelosDir(t *testing.T) {
+	home := isolatePaths(t)
+
+	paths := ResolvePaths()
+	assert.Equal(t, PathsFromDefault, paths.Source)
+	assert.Equal(t, filepath.Join(home, ".telos"), paths.ConfigDir)
+	assert.Equal(t, filepath.Join(home, ".telos", "ideas.db"), paths.DatabaseFile())
+	assert.Equal(t, filepath.Join(home, ".telos", "logs"), paths.LogDir)
+	assert.Equal(t, filepath.Join(home, ".telos", "config.yaml"), FilePath())
+}
+
+func TestResolvePaths_FollowsXDG(t *testing.T) {
+	home := isolatePaths(t)
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(home, "xdg-config"))
+	t.Setenv("XDG_DATA_HOME", filepath.Join(home, "xdg-data"))
+
+	paths := ResolvePaths()
+	assert.Equal(t, PathsFromXDG, paths.Source)
+	assert.Equal(t, filepath.Join(home, "xdg-config", "telos", "telos.md"), paths.TelosFile())
+	assert.Equal(t, filepath.Join(home, "xdg-data", "telos", "ideas.db"), paths.DatabaseFile())
+	assert.Equal(t, filepath.Join(home, "xdg-data", "telos", "logs"), paths.LogDir)
+	assert.Equal(t, filepath.Join(home, "xdg-config", "telos", "profiles"), ProfilesDir())
+}
+
+func TestResolvePaths_KeepsExistingLegacyDirs(t *testing.T) {
+	home := isolatePaths(t)
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(home, "xdg-config"))
+	t.Setenv("XDG_DATA_HOME", filepath.Join(home, "xdg-data"))
+	require.NoError(t, os.MkdirAll(filepath.Join(home, ".telos"), 0755))
+	require.NoError(t, os.MkdirAll(filepath.Join(home, ".telos-idea-matrix", "logs"), 0755))
+
+	paths := ResolvePaths()
+	assert.Equal(t, PathsFromLegacy, paths.Source)
+	assert.Equal(t, filepath.Join(home, ".telos"), paths.ConfigDir)
+	assert.Equal(t, filepath.Join(home, ".telos"), paths.DataDir)
+	assert.Equal(t, filepath.Join(home, ".telos-idea-matrix", "logs"), paths.LogDir)
+}
+
+func TestResolvePaths_TelosHomeOverridesEverything(t *testing.T) {
+	home := isolatePaths(t)
+	require.NoError(t, os.MkdirAll(filepath.Join(home, ".telos"), 0755))
+	require.NoError(t, os.MkdirAll(filepath.Join(home, ".telos-idea-matrix", "logs"), 0755))
+	t.Setenv("XDG_DATA_HOME", filepath.Join(home, "xdg-data"))
+	tree := filepath.Join(home, "tree")
+	t.Setenv("TELOS_HOME", tree)
+
+	paths := ResolvePaths()
+	assert.Equal(t, Paths{ConfigDir: tree, DataDir: tree, LogDir: filepath.Join(tree, "logs"), Source: PathsFromTelosHome}, paths)
+	assert.Equal(t, filepath.Join(tree, "config.yaml"), FilePath())
+}
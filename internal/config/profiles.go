@@ -0,0 +1,65 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// DefaultProfile is the telos profile used when none is selected
+const DefaultProfile = "default"
+
+var profileNamePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_-]*$`)
+
+// ValidateProfileName checks that a profile name is safe to use as a file name
+func ValidateProfileName(name string) error {
+	if !profileNamePattern.MatchString(name) {
+		return fmt.Errorf("invalid profile name %q (use letters, digits, '-' and '_')", name)
+	}
+	return nil
+}
+
+// ProfilesDir returns the directory holding named telos profiles
+func ProfilesDir() string {
+	return filepath.Join(ResolvePaths().ConfigDir, "profiles")
+}
+
+// ProfileTelosPath returns the telos file for a named profile.
+// The default profile keeps using fallback until profiles/default.md exists,
+// so single-profile setups need no changes.
+func ProfileTelosPath(name, fallback string) string {
+	path := filepath.Join(ProfilesDir(), name+".md")
+	if name == DefaultProfile && !FileExists(path) {
+		return fallback
+	}
+	return path
+}
+
+// ListProfiles returns the names of all profiles in ProfilesDir, sorted.
+// The default profile is always included.
+func ListProfiles() ([]string, error) {
+	names := []string{DefaultProfile}
+
+	entries, err := os.ReadDir(ProfilesDir())
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to read profiles directory: %w", err)
+	}
+	for _, entry := range entries {
+		name, ok := strings.CutSuffix(entry.Name(), ".md")
+		if entry.IsDir() || !ok || name == DefaultProfile || ValidateProfileName(name) != nil {
+			continue
+		}
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+	return names, nil
+}
+
+// ActiveProfile returns the profile selected by the config file or TELOS_PROFILE
+func ActiveProfile() string {
+	return loadValues()["telos.profile"]
+}
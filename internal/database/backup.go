@@ -0,0 +1,193 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/mattn/go-sqlite3"
+)
+
+// requiredBackupColumns lists the columns a backup's ideas table must have.
+// These come from the initial schema; later columns are added by migrations
+// when an older backup is restored.
+var requiredBackupColumns = []string{
+	"id", "content", "raw_score", "final_score", "patterns", "recommendation",
+	"analysis_details", "created_at", "reviewed_at", "status",
+}
+
+// BackupInfo describes a validated backup file.
+type BackupInfo struct {
+	Path      string
+	SizeBytes int64
+	IdeaCount int
+}
+
+// BackupTo writes a consistent copy of the database to path using VACUUM INTO.
+// The copy is taken from a single read transaction, so it includes writes still
+// in the WAL and is safe to run while other processes are using the database.
+// The result is a standalone file with no -wal or -shm companions.
+func (r *Repository) BackupTo(path string) error {
+	if path == "" {
+		return fmt.Errorf("%w: backup path cannot be empty", ErrInvalidInput)
+	}
+	if _, err := os.Stat(path); err == nil {
+		return fmt.Errorf("%w: %s (choose a new backup path)", ErrAlreadyExists, path)
+	}
+
+	if dir := filepath.Dir(path); dir != "" && dir != "." {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("failed to create backup directory: %w", err)
+		}
+	}
+
+	if _, err := r.db.Exec("VACUUM INTO ?", path); err != nil {
+		return fmt.Errorf("failed to back up database: %w", err)
+	}
+
+	return nil
+}
+
+// RestoreFrom validates a backup and replaces the database contents with it.
+// The copy goes through SQLite's online backup API on a live connection, so
+// it is written through the WAL like any other change and other connections
+// see the restored data on their next read. Pending migrations are applied
+// afterwards so backups taken before newer schema changes are brought up to date.
+func (r *Repository) RestoreFrom(path string) error {
+	if _, err := ValidateBackup(path); err != nil {
+		return err
+	}
+
+	src, err := sql.Open("sqlite3", "file:"+path+"?mode=ro")
+	if err != nil {
+		return fmt.Errorf("failed to open backup: %w", err)
+	}
+	defer func() { _ = src.Close() }()
+
+	ctx := context.Background()
+	srcConn, err := src.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to open backup: %w", err)
+	}
+	defer func() { _ = srcConn.Close() }()
+
+	destConn, err := r.db.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer func() { _ = destConn.Close() }()
+
+	err = destConn.Raw(func(destDriverConn interface{}) error {
+		return srcConn.Raw(func(srcDriverConn interface{}) error {
+			dest, ok := destDriverConn.(*sqlite3.SQLiteConn)
+			if !ok {
+				return errors.New("unexpected database driver connection")
+			}
+			source, ok := srcDriverConn.(*sqlite3.SQLiteConn)
+			if !ok {
+				return errors.New("unexpected backup driver connection")
+			}
+			return copyDatabase(dest, source)
+		})
+	})
+	if err != nil {
+		return fmt.Errorf("failed to restore database: %w", err)
+	}
+
+	if err := r.Migrate(); err != nil {
+		return fmt.Errorf("failed to migrate restored database: %w", err)
+	}
+	if err := r.backfillContentHashes(); err != nil {
+		return fmt.Errorf("failed to backfill content hashes: %w", err)
+	}
+
+	return nil
+}
+
+// copyDatabase copies every page of the source's main database into dest
+func copyDatabase(dest, source *sqlite3.SQLiteConn) error {
+	backup, err := dest.Backup("main", source, "main")
+	if err != nil {
+		return err
+	}
+
+	done, err := backup.Step(-1)
+	if err != nil {
+		_ = backup.Finish()
+		return err
+	}
+	if !done {
+		_ = backup.Finish()
+		return errors.New("backup did not complete")
+	}
+
+	return backup.Finish()
+}
+
+// ValidateBackup checks that path is an intact SQLite database with an ideas
+// table this version can restore, and reports what it contains.
+func ValidateBackup(path string) (*BackupInfo, error) {
+	stat, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read backup: %w", err)
+	}
+	if stat.IsDir() {
+		return nil, fmt.Errorf("%w: backup %s is a directory", ErrInvalidInput, path)
+	}
+
+	db, err := sql.Open("sqlite3", "file:"+path+"?mode=ro")
+	if err != nil {
+		return nil, fmt.Errorf("failed to open backup: %w", err)
+	}
+	defer func() { _ = db.Close() }()
+
+	var integrity string
+	if err := db.QueryRow("PRAGMA integrity_check").Scan(&integrity); err != nil {
+		return nil, fmt.Errorf("%w: %s is not a readable SQLite database: %v", ErrInvalidInput, path, err)
+	}
+	if integrity != "ok" {
+		return nil, fmt.Errorf("%w: backup failed integrity check: %s", ErrInvalidInput, integrity)
+	}
+
+	columns, err := tableColumns(db, "ideas")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read backup schema: %w", err)
+	}
+	if len(columns) == 0 {
+		return nil, fmt.Errorf("%w: backup has no ideas table", ErrInvalidInput)
+	}
+	for _, column := range requiredBackupColumns {
+		if !columns[column] {
+			return nil, fmt.Errorf("%w: backup ideas table is missing column %q", ErrInvalidInput, column)
+		}
+	}
+
+	info := &BackupInfo{Path: path, SizeBytes: stat.Size()}
+	if err := db.QueryRow("SELECT COUNT(*) FROM ideas").Scan(&info.IdeaCount); err != nil {
+		return nil, fmt.Errorf("failed to count ideas in backup: %w", err)
+	}
+
+	return info, nil
+}
+
+// tableColumns returns the set of column names in a table, empty if it doesn't exist
+func tableColumns(db *sql.DB, table string) (map[string]bool, error) {
+	rows, err := db.Query("SELECT name FROM pragma_table_info(?)", table)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	columns := make(map[string]bool)
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		columns[name] = true
+	}
+	return columns, rows.Err()
+}
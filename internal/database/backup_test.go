@@ -0,0 +1,157 @@
+//go:build integration
+
+package database_test
+
+import (
+	"database/sql"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ryacub/telos-idea-matrix/internal/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_BackupTo_IncludesUncheckpointedWrites(t *testing.T) {
+	dir := t.TempDir()
+	dbPath := filepath.Join(dir, "ideas.db")
+
+	repo, err := database.NewRepository(dbPath)
+	require.NoError(t, err)
+	defer repo.Close()
+
+	// A second handle, like a running server, holds the WAL open
+	server, err := database.NewRepository(dbPath)
+	require.NoError(t, err)
+	defer server.Close()
+
+	content := "Written through the server moments before the backup"
+	id := createTestIdea(t, server, content)
+
+	walInfo, err := os.Stat(dbPath + "-wal")
+	require.NoError(t, err)
+	require.Greater(t, walInfo.Size(), int64(0), "write should still be in the WAL")
+
+	backupPath := filepath.Join(dir, "backups", "ideas-backup.db")
+	require.NoError(t, repo.BackupTo(backupPath))
+
+	info, err := database.ValidateBackup(backupPath)
+	require.NoError(t, err)
+	assert.Equal(t, 1, info.IdeaCount)
+
+	_, err = os.Stat(backupPath + "-wal")
+	assert.True(t, os.IsNotExist(err), "backup should be a standalone file")
+
+	restored, err := database.NewRepository(backupPath)
+	require.NoError(t, err)
+	defer restored.Close()
+
+	got, err := restored.GetByID(id)
+	require.NoError(t, err)
+	assert.Equal(t, content, got.Content)
+}
+
+func TestRepository_BackupTo_RefusesToOverwrite(t *testing.T) {
+	repo, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	backupPath := filepath.Join(t.TempDir(), "existing.db")
+	require.NoError(t, os.WriteFile(backupPath, []byte("keep me"), 0600))
+
+	err := repo.BackupTo(backupPath)
+	require.Error(t, err)
+	assert.True(t, database.IsAlreadyExists(err))
+
+	data, err := os.ReadFile(backupPath)
+	require.NoError(t, err)
+	assert.Equal(t, "keep me", string(data))
+}
+
+func TestRepository_RestoreFrom_ReplacesContents(t *testing.T) {
+	dir := t.TempDir()
+	dbPath := filepath.Join(dir, "ideas.db")
+
+	repo, err := database.NewRepository(dbPath)
+	require.NoError(t, err)
+	defer repo.Close()
+
+	keptContent := "Idea that exists in the backup"
+	keptID := createTestIdea(t, repo, keptContent)
+	backupPath := filepath.Join(dir, "backup.db")
+	require.NoError(t, repo.BackupTo(backupPath))
+
+	// Changes after the backup should be rolled back by the restore
+	addedID := createTestIdea(t, repo, "Idea added after the backup")
+	require.NoError(t, repo.Delete(keptID))
+
+	other, err := database.NewRepository(dbPath)
+	require.NoError(t, err)
+	defer other.Close()
+
+	require.NoError(t, repo.RestoreFrom(backupPath))
+
+	for _, r := range []*database.Repository{repo, other} {
+		got, err := r.GetByID(keptID)
+		require.NoError(t, err)
+		assert.Equal(t, keptContent, got.Content)
+
+		_, err = r.GetByID(addedID)
+		assert.True(t, database.IsNotFound(err), "idea created after the backup should be gone")
+	}
+}
+
+func TestValidateBackup_RejectsInvalidFiles(t *testing.T) {
+	dir := t.TempDir()
+
+	notSQLite := filepath.Join(dir, "notes.txt")
+	require.NoError(t, os.WriteFile(notSQLite, []byte("definitely not a database"), 0600))
+
+	noIdeas := filepath.Join(dir, "other.db")
+	db, err := sql.Open("sqlite3", noIdeas)
+	require.NoError(t, err)
+	_, err = db.Exec("CREATE TABLE notes (id TEXT PRIMARY KEY)")
+	require.NoError(t, err)
+	require.NoError(t, db.Close())
+
+	oldSchema := filepath.Join(dir, "old.db")
+	db, err = sql.Open("sqlite3", oldSchema)
+	require.NoError(t, err)
+	_, err = db.Exec("CREATE TABLE ideas (id TEXT PRIMARY KEY, content TEXT)")
+	require.NoError(t, err)
+	require.NoError(t, db.Close())
+
+	tests := []struct {
+		name    string
+		path    string
+		wantErr string
+	}{
+		{"missing file", filepath.Join(dir, "missing.db"), "failed to read backup"},
+		{"not a database", notSQLite, "not a readable SQLite database"},
+		{"no ideas table", noIdeas, "no ideas table"},
+		{"incompatible schema", oldSchema, `missing column "raw_score"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := database.ValidateBackup(tt.path)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestRepository_RestoreFrom_InvalidBackupLeavesDatabaseUntouched(t *testing.T) {
+	repo, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	id := createTestIdea(t, repo, "Idea that must survive a failed restore")
+
+	badBackup := filepath.Join(t.TempDir(), "bad.db")
+	require.NoError(t, os.WriteFile(badBackup, []byte("garbage"), 0600))
+
+	require.Error(t, repo.RestoreFrom(badBackup))
+
+	_, err := repo.GetByID(id)
+	assert.NoError(t, err)
+}
@@ -0,0 +1,86 @@
+package database
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/ryacub/telos-idea-matrix/internal/models"
+)
+
+// SaveEmbedding stores the embedding of idea's current content, computed by
+// model, replacing any earlier one
+func (r *Repository) SaveEmbedding(idea *models.Idea, model string, vector []float32) error {
+	if idea == nil {
+		return errors.New("idea cannot be nil")
+	}
+	if len(vector) == 0 {
+		return fmt.Errorf("%w: embedding is empty", ErrInvalidInput)
+	}
+
+	_, err := r.db.Exec(
+		`INSERT INTO idea_embeddings (idea_id, model, content_hash, embedding, embedded_at) VALUES (?, ?, ?, ?, ?)
+		ON CONFLICT(idea_id) DO UPDATE SET model = excluded.model, content_hash = excluded.content_hash,
+			embedding = excluded.embedding, embedded_at = excluded.embedded_at`,
+		idea.ID, model, contentHash(idea.Content), encodeVector(vector), time.Now().UTC().Format(time.RFC3339),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save embedding: %w", err)
+	}
+	return nil
+}
+
+// Embeddings returns the stored embeddings computed by model, keyed by idea
+// ID. Embeddings of content that has since changed are left out, as are
+// those of deleted ideas.
+func (r *Repository) Embeddings(model string) (map[string][]float32, error) {
+	rows, err := r.db.Query(
+		`SELECT e.idea_id, e.content_hash, e.embedding, i.content
+		FROM idea_embeddings e JOIN ideas i ON i.id = e.idea_id
+		WHERE e.model = ? AND i.status != ?`,
+		model, string(models.StatusDeleted),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query embeddings: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	embeddings := make(map[string][]float32)
+	for rows.Next() {
+		var id, hash, content string
+		var blob []byte
+		if err := rows.Scan(&id, &hash, &blob, &content); err != nil {
+			return nil, fmt.Errorf("failed to scan embedding: %w", err)
+		}
+		if hash != contentHash(content) {
+			continue
+		}
+		embeddings[id] = decodeVector(blob)
+	}
+	return embeddings, rows.Err()
+}
+
+func contentHash(content string) string {
+	sum := sha256.Sum256([]byte(content))
+	return hex.EncodeToString(sum[:])
+}
+
+func encodeVector(vector []float32) []byte {
+	buf := make([]byte, 4*len(vector))
+	for i, v := range vector {
+		binary.LittleEndian.PutUint32(buf[4*i:], math.Float32bits(v))
+	}
+	return buf
+}
+
+func decodeVector(buf []byte) []float32 {
+	vector := make([]float32, len(buf)/4)
+	for i := range vector {
+		vector[i] = math.Float32frombits(binary.LittleEndian.Uint32(buf[4*i:]))
+	}
+	return vector
+}
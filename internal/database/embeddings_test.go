@@ -0,0 +1,64 @@
+//go:build integration
+
+package database_test
+
+import (
+	"testing"
+
+	"github.com/ryacub/telos-idea-matrix/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_Embeddings_RoundTrip(t *testing.T) {
+	repo, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	idea := models.NewIdea("Write a book about sourdough")
+	require.NoError(t, repo.Create(idea))
+
+	vector := []float32{0.25, -1.5, 3}
+	require.NoError(t, repo.SaveEmbedding(idea, "ollama/nomic-embed-text", vector))
+
+	embeddings, err := repo.Embeddings("ollama/nomic-embed-text")
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]float32{idea.ID: vector}, embeddings)
+
+	// Other models' vectors are not comparable
+	embeddings, err = repo.Embeddings("openai/text-embedding-3-small")
+	require.NoError(t, err)
+	assert.Empty(t, embeddings)
+
+	// Saving again replaces the embedding
+	require.NoError(t, repo.SaveEmbedding(idea, "openai/text-embedding-3-small", []float32{1}))
+	embeddings, err = repo.Embeddings("ollama/nomic-embed-text")
+	require.NoError(t, err)
+	assert.Empty(t, embeddings)
+}
+
+func TestRepository_Embeddings_SkipsChangedAndDeletedIdeas(t *testing.T) {
+	repo, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	changed := models.NewIdea("Original content")
+	require.NoError(t, repo.Create(changed))
+	require.NoError(t, repo.SaveEmbedding(changed, "m", []float32{1, 0}))
+	changed.Content = "Rewritten content"
+	require.NoError(t, repo.Update(changed))
+
+	trashed := models.NewIdea("Trashed idea")
+	require.NoError(t, repo.Create(trashed))
+	require.NoError(t, repo.SaveEmbedding(trashed, "m", []float32{0, 1}))
+	trashed.Trash()
+	require.NoError(t, repo.Update(trashed))
+
+	embeddings, err := repo.Embeddings("m")
+	require.NoError(t, err)
+	assert.Empty(t, embeddings)
+
+	// Deleting an idea removes its embedding
+	require.NoError(t, repo.Delete(trashed.ID))
+	var count int
+	require.NoError(t, repo.DB().QueryRow("SELECT COUNT(*) FROM idea_embeddings").Scan(&count))
+	assert.Equal(t, 1, count)
+}
@@ -16,6 +16,9 @@ var (
 
 	// ErrConstraintViolation indicates a database constraint was violated
 	ErrConstraintViolation = errors.New("constraint violation")
+
+	// ErrStaleVersion indicates a resource was changed since it was read
+	ErrStaleVersion = errors.New("resource was modified since it was read")
 )
 
 // IsNotFound checks if an error is a "not found" error
@@ -37,3 +40,8 @@ func IsInvalidInput(err error) bool {
 func IsConstraintViolation(err error) bool {
 	return errors.Is(err, ErrConstraintViolation)
 }
+
+// IsStaleVersion checks if an error is a "stale version" error
+func IsStaleVersion(err error) bool {
+	return errors.Is(err, ErrStaleVersion)
+}
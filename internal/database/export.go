@@ -0,0 +1,174 @@
+package database
+
+import (
+	"bufio"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ExportSQL writes an SQL script that recreates the ideas table, its indexes,
+// and every row in a fresh SQLite database. The CREATE statements are copied
+// from the live schema, so columns added by migrations are included. Values
+// are written as SQL literals with quotes escaped. Returns the number of ideas
+// written.
+func (r *Repository) ExportSQL(w io.Writer) (int, error) {
+	schema, err := r.tableSchema("ideas")
+	if err != nil {
+		return 0, err
+	}
+
+	columns, err := r.orderedColumns("ideas")
+	if err != nil {
+		return 0, err
+	}
+
+	// Read every row inside one transaction so the dump is consistent
+	tx, err := r.db.Begin()
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin export: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	quoted := make([]string, len(columns))
+	for i, column := range columns {
+		quoted[i] = quoteIdentifier(column)
+	}
+	columnList := strings.Join(quoted, ", ")
+
+	rows, err := tx.Query("SELECT " + columnList + " FROM ideas ORDER BY created_at, id")
+	if err != nil {
+		return 0, fmt.Errorf("failed to query ideas: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	// Write errors are sticky on the bufio.Writer and reported by Flush
+	out := bufio.NewWriter(w)
+	_, _ = fmt.Fprintf(out, "-- Telos Idea Matrix ideas export\n")
+	_, _ = fmt.Fprintf(out, "-- Generated %s\n\n", time.Now().UTC().Format(time.RFC3339))
+	_, _ = fmt.Fprintf(out, "BEGIN TRANSACTION;\n\n")
+	for _, stmt := range schema {
+		_, _ = fmt.Fprintf(out, "%s;\n", stmt)
+	}
+	_, _ = fmt.Fprintln(out)
+
+	values := make([]interface{}, len(columns))
+	pointers := make([]interface{}, len(columns))
+	for i := range values {
+		pointers[i] = &values[i]
+	}
+
+	count := 0
+	literals := make([]string, len(columns))
+	for rows.Next() {
+		if err := rows.Scan(pointers...); err != nil {
+			return count, fmt.Errorf("failed to scan idea: %w", err)
+		}
+		for i, v := range values {
+			literals[i] = sqlLiteral(v)
+		}
+		_, _ = fmt.Fprintf(out, "INSERT INTO ideas (%s) VALUES (%s);\n", columnList, strings.Join(literals, ", "))
+		count++
+	}
+	if err := rows.Err(); err != nil {
+		return count, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	_, _ = fmt.Fprintf(out, "\nCOMMIT;\n")
+
+	if err := out.Flush(); err != nil {
+		return count, fmt.Errorf("failed to write export: %w", err)
+	}
+	return count, nil
+}
+
+// tableSchema returns the CREATE statements for a table followed by its indexes.
+// Triggers are left out because they refer to tables outside the export.
+func (r *Repository) tableSchema(table string) ([]string, error) {
+	rows, err := r.db.Query(`
+		SELECT sql FROM sqlite_master
+		WHERE tbl_name = ? AND type IN ('table', 'index') AND sql IS NOT NULL
+		ORDER BY CASE type WHEN 'table' THEN 0 ELSE 1 END, name
+	`, table)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s schema: %w", table, err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var statements []string
+	for rows.Next() {
+		var stmt string
+		if err := rows.Scan(&stmt); err != nil {
+			return nil, fmt.Errorf("failed to read %s schema: %w", table, err)
+		}
+		statements = append(statements, stmt)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read %s schema: %w", table, err)
+	}
+	if len(statements) == 0 {
+		return nil, fmt.Errorf("%w: table %s", ErrNotFound, table)
+	}
+	return statements, nil
+}
+
+// orderedColumns returns a table's column names in declaration order
+func (r *Repository) orderedColumns(table string) ([]string, error) {
+	rows, err := r.db.Query("SELECT name FROM pragma_table_info(?) ORDER BY cid", table)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s columns: %w", table, err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var columns []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, fmt.Errorf("failed to read %s columns: %w", table, err)
+		}
+		columns = append(columns, name)
+	}
+	return columns, rows.Err()
+}
+
+// quoteIdentifier quotes a column or table name for use in SQL
+func quoteIdentifier(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
+
+// sqlLiteral renders a scanned value as an SQLite literal
+func sqlLiteral(v interface{}) string {
+	switch value := v.(type) {
+	case nil:
+		return "NULL"
+	case int64:
+		return strconv.FormatInt(value, 10)
+	case float64:
+		switch {
+		case math.IsInf(value, 1):
+			return "9e999"
+		case math.IsInf(value, -1):
+			return "-9e999"
+		case math.IsNaN(value):
+			return "NULL"
+		}
+		return strconv.FormatFloat(value, 'g', -1, 64)
+	case bool:
+		if value {
+			return "1"
+		}
+		return "0"
+	case []byte:
+		return "X'" + hex.EncodeToString(value) + "'"
+	case string:
+		return "'" + strings.ReplaceAll(value, "'", "''") + "'"
+	case time.Time:
+		return "'" + value.Format(time.RFC3339Nano) + "'"
+	default:
+		return "'" + strings.ReplaceAll(fmt.Sprint(value), "'", "''") + "'"
+	}
+}
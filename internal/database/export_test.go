@@ -0,0 +1,90 @@
+//go:build integration
+
+package database_test
+
+import (
+	"bytes"
+	"database/sql"
+	"testing"
+
+	"github.com/ryacub/telos-idea-matrix/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_ExportSQL_RecreatesIdeasInFreshDatabase(t *testing.T) {
+	repo, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	tricky := models.NewIdea(`It's a "quoted" idea'); DROP TABLE ideas; --`)
+	tricky.RawScore = 6.25
+	tricky.FinalScore = 7.125
+	tricky.Patterns = []string{"perfectionism"}
+	tricky.Tags = []string{"o'clock", "work"}
+	tricky.Trigger = "Line one\nLine two"
+	require.NoError(t, repo.Create(tricky))
+
+	plain := models.NewIdea("Build a reading tracker")
+	plain.Status = "archived"
+	require.NoError(t, repo.Create(plain))
+
+	var script bytes.Buffer
+	count, err := repo.ExportSQL(&script)
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+
+	target, err := sql.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+	defer target.Close()
+	target.SetMaxOpenConns(1) // each :memory: connection is its own database
+
+	_, err = target.Exec(script.String())
+	require.NoError(t, err, "generated SQL should run cleanly:\n%s", script.String())
+
+	var rowCount int
+	require.NoError(t, target.QueryRow("SELECT COUNT(*) FROM ideas").Scan(&rowCount))
+	assert.Equal(t, 2, rowCount)
+
+	var content, tags, trigger, status string
+	var finalScore float64
+	err = target.QueryRow(
+		"SELECT content, tags, trigger_context, status, final_score FROM ideas WHERE id = ?", tricky.ID,
+	).Scan(&content, &tags, &trigger, &status, &finalScore)
+	require.NoError(t, err)
+	assert.Equal(t, tricky.Content, content)
+	assert.Equal(t, `["o'clock","work"]`, tags)
+	assert.Equal(t, tricky.Trigger, trigger)
+	assert.Equal(t, "active", status)
+	assert.Equal(t, 7.125, finalScore)
+
+	require.NoError(t, target.QueryRow("SELECT status FROM ideas WHERE id = ?", plain.ID).Scan(&status))
+	assert.Equal(t, "archived", status)
+
+	var indexCount int
+	require.NoError(t, target.QueryRow(
+		"SELECT COUNT(*) FROM sqlite_master WHERE type = 'index' AND tbl_name = 'ideas' AND sql IS NOT NULL",
+	).Scan(&indexCount))
+	assert.Greater(t, indexCount, 0, "indexes should be recreated")
+}
+
+func TestRepository_ExportSQL_EmptyDatabase(t *testing.T) {
+	repo, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	var script bytes.Buffer
+	count, err := repo.ExportSQL(&script)
+	require.NoError(t, err)
+	assert.Equal(t, 0, count)
+
+	target, err := sql.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+	defer target.Close()
+	target.SetMaxOpenConns(1)
+
+	_, err = target.Exec(script.String())
+	require.NoError(t, err)
+
+	var rowCount int
+	require.NoError(t, target.QueryRow("SELECT COUNT(*) FROM ideas").Scan(&rowCount))
+	assert.Equal(t, 0, rowCount)
+}
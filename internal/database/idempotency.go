@@ -0,0 +1,89 @@
+package database
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// IdempotencyRecord is the stored outcome of a request that carried an
+// idempotency key
+type IdempotencyRecord struct {
+	Key         string
+	RequestHash string // Identifies the request body, so a reused key with a different body is caught
+	IdeaID      string
+	Status      int
+	Response    []byte
+	CreatedAt   time.Time
+}
+
+// GetIdempotencyRecord retrieves the record for key, if it was saved after
+// since; older records have expired and are reported as not found
+func (r *Repository) GetIdempotencyRecord(key string, since time.Time) (*IdempotencyRecord, error) {
+	if key == "" {
+		return nil, errors.New("key cannot be empty")
+	}
+
+	var rec IdempotencyRecord
+	var response, createdAt string
+	err := r.db.QueryRow(
+		`SELECT key, request_hash, idea_id, status, response, created_at
+		FROM idempotency_keys WHERE key = ? AND created_at > ?`,
+		key, since.UTC().Format(time.RFC3339),
+	).Scan(&rec.Key, &rec.RequestHash, &rec.IdeaID, &rec.Status, &response, &createdAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get idempotency key: %w", err)
+	}
+
+	rec.Response = []byte(response)
+	rec.CreatedAt, err = time.Parse(time.RFC3339, createdAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse idempotency key created_at: %w", err)
+	}
+	return &rec, nil
+}
+
+// SaveIdempotencyRecord stores rec, replacing an expired record with the same
+// key, and removes records saved before expireBefore
+func (r *Repository) SaveIdempotencyRecord(rec *IdempotencyRecord, expireBefore time.Time) error {
+	if rec == nil {
+		return errors.New("record cannot be nil")
+	}
+	if rec.Key == "" || rec.IdeaID == "" {
+		return fmt.Errorf("%w: idempotency record needs a key and idea ID", ErrInvalidInput)
+	}
+	if rec.CreatedAt.IsZero() {
+		rec.CreatedAt = time.Now().UTC()
+	}
+
+	if _, err := r.PurgeIdempotencyRecords(expireBefore); err != nil {
+		return err
+	}
+
+	_, err := r.db.Exec(
+		`INSERT OR REPLACE INTO idempotency_keys (key, request_hash, idea_id, status, response, created_at)
+		VALUES (?, ?, ?, ?, ?, ?)`,
+		rec.Key, rec.RequestHash, rec.IdeaID, rec.Status, string(rec.Response), rec.CreatedAt.UTC().Format(time.RFC3339),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save idempotency key: %w", err)
+	}
+	return nil
+}
+
+// PurgeIdempotencyRecords removes records saved before before and returns
+// how many were removed
+func (r *Repository) PurgeIdempotencyRecords(before time.Time) (int64, error) {
+	result, err := r.db.Exec(
+		"DELETE FROM idempotency_keys WHERE created_at <= ?",
+		before.UTC().Format(time.RFC3339),
+	)
+	if err != nil {
+		return 0, fmt.Errorf("failed to purge idempotency keys: %w", err)
+	}
+	return result.RowsAffected()
+}
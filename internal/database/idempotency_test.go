@@ -0,0 +1,60 @@
+//go:build integration
+
+package database_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ryacub/telos-idea-matrix/internal/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_IdempotencyRecord_RoundTrip(t *testing.T) {
+	repo, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	now := time.Now().UTC().Truncate(time.Second)
+	rec := &database.IdempotencyRecord{
+		Key:         "retry-123",
+		RequestHash: "abc",
+		IdeaID:      "idea-1",
+		Status:      201,
+		Response:    []byte(`{"id":"idea-1"}`),
+		CreatedAt:   now,
+	}
+	require.NoError(t, repo.SaveIdempotencyRecord(rec, now.Add(-24*time.Hour)))
+
+	got, err := repo.GetIdempotencyRecord("retry-123", now.Add(-time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, rec, got)
+
+	_, err = repo.GetIdempotencyRecord("other-key", now.Add(-time.Hour))
+	assert.True(t, database.IsNotFound(err))
+}
+
+func TestRepository_IdempotencyRecord_Expires(t *testing.T) {
+	repo, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	now := time.Now().UTC()
+	old := &database.IdempotencyRecord{Key: "old", IdeaID: "idea-1", Status: 201, Response: []byte("{}"), CreatedAt: now.Add(-48 * time.Hour)}
+	require.NoError(t, repo.SaveIdempotencyRecord(old, now.Add(-72*time.Hour)))
+
+	// Expired records aren't returned
+	_, err := repo.GetIdempotencyRecord("old", now.Add(-24*time.Hour))
+	assert.True(t, database.IsNotFound(err))
+
+	// Saving another record purges them, and an expired key can be reused
+	fresh := &database.IdempotencyRecord{Key: "old", IdeaID: "idea-2", Status: 201, Response: []byte("{}")}
+	require.NoError(t, repo.SaveIdempotencyRecord(fresh, now.Add(-24*time.Hour)))
+
+	got, err := repo.GetIdempotencyRecord("old", now.Add(-24*time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, "idea-2", got.IdeaID)
+
+	purged, err := repo.PurgeIdempotencyRecords(now.Add(time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), purged)
+}
@@ -0,0 +1,215 @@
+package database
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ryacub/telos-idea-matrix/internal/models"
+)
+
+// CreateBulkJob records a new job over ideaIDs, which are processed in the
+// given order. The job and its items are written in one transaction.
+func (r *Repository) CreateBulkJob(job *models.BulkJob, ideaIDs []string) error {
+	if job == nil {
+		return errors.New("job cannot be nil")
+	}
+	if job.ID == "" || job.Kind == "" {
+		return fmt.Errorf("%w: job needs an ID and kind", ErrInvalidInput)
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	params := job.Params
+	if params == "" {
+		params = "{}"
+	}
+	if _, err := tx.Exec(
+		"INSERT INTO bulk_jobs (id, kind, params, created_at) VALUES (?, ?, ?, ?)",
+		job.ID, job.Kind, params, job.CreatedAt.UTC().Format(time.RFC3339),
+	); err != nil {
+		return fmt.Errorf("failed to create bulk job: %w", err)
+	}
+
+	stmt, err := tx.Prepare("INSERT OR IGNORE INTO bulk_job_items (job_id, idea_id, position) VALUES (?, ?, ?)")
+	if err != nil {
+		return fmt.Errorf("failed to prepare bulk job items: %w", err)
+	}
+	defer func() { _ = stmt.Close() }()
+
+	for i, id := range ideaIDs {
+		if _, err := stmt.Exec(job.ID, id, i); err != nil {
+			return fmt.Errorf("failed to add idea %s to bulk job: %w", id, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit bulk job: %w", err)
+	}
+
+	job.Params = params
+	job.Total = len(ideaIDs)
+	job.Done = 0
+	return nil
+}
+
+// GetBulkJob retrieves a job and its progress by ID
+func (r *Repository) GetBulkJob(id string) (*models.BulkJob, error) {
+	if id == "" {
+		return nil, errors.New("id cannot be empty")
+	}
+
+	query := `
+		SELECT j.id, j.kind, j.params, j.created_at, j.completed_at,
+		       COUNT(i.idea_id), COUNT(i.done_at),
+		       COUNT(CASE WHEN i.done_at IS NOT NULL AND i.last_error IS NOT NULL THEN 1 END)
+		FROM bulk_jobs j
+		LEFT JOIN bulk_job_items i ON i.job_id = j.id
+		WHERE j.id = ?
+		GROUP BY j.id
+	`
+
+	var job models.BulkJob
+	var createdAt string
+	var completedAt sql.NullString
+	err := r.db.QueryRow(query, id).Scan(
+		&job.ID, &job.Kind, &job.Params, &createdAt, &completedAt, &job.Total, &job.Done, &job.Skipped,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%w: bulk job %s", ErrNotFound, id)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get bulk job: %w", err)
+	}
+
+	job.CreatedAt, err = time.Parse(time.RFC3339, createdAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse created_at: %w", err)
+	}
+	if completedAt.Valid {
+		t, err := time.Parse(time.RFC3339, completedAt.String)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse completed_at: %w", err)
+		}
+		job.CompletedAt = &t
+	}
+
+	return &job, nil
+}
+
+// IncompleteBulkJobIDs returns the IDs of jobs of the given kind that are not
+// complete, oldest first
+func (r *Repository) IncompleteBulkJobIDs(kind string) ([]string, error) {
+	rows, err := r.db.Query(
+		"SELECT id FROM bulk_jobs WHERE kind = ? AND completed_at IS NULL ORDER BY created_at, rowid",
+		kind,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query incomplete bulk jobs: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan bulk job: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// PendingBulkJobItems returns the IDs of ideas the job has not finished, in
+// processing order
+func (r *Repository) PendingBulkJobItems(jobID string) ([]string, error) {
+	rows, err := r.db.Query(
+		"SELECT idea_id FROM bulk_job_items WHERE job_id = ? AND done_at IS NULL ORDER BY position",
+		jobID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query pending bulk job items: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan bulk job item: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// MarkBulkJobItemDone records that the job finished processing an idea,
+// clearing any error from an earlier attempt. The write is committed before
+// returning, so the idea is skipped on resume even if the process dies
+// immediately afterwards.
+func (r *Repository) MarkBulkJobItemDone(jobID, ideaID string) error {
+	result, err := r.db.Exec(
+		"UPDATE bulk_job_items SET done_at = ?, last_error = NULL WHERE job_id = ? AND idea_id = ?",
+		time.Now().UTC().Format(time.RFC3339), jobID, ideaID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to mark bulk job item done: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("%w: idea %s in bulk job %s", ErrNotFound, ideaID, jobID)
+	}
+	return nil
+}
+
+// RecordBulkJobItemFailure records a failed attempt at an idea. Once the
+// idea has failed maxAttempts times it is marked done, keeping the error, and
+// counted in the job's Skipped; until then it stays pending for a retry.
+// Reports whether the idea was skipped.
+func (r *Repository) RecordBulkJobItemFailure(jobID, ideaID string, cause error, maxAttempts int) (bool, error) {
+	var skipped bool
+	err := r.db.QueryRow(`
+		UPDATE bulk_job_items
+		SET attempts = attempts + 1, last_error = ?,
+		    done_at = CASE WHEN attempts + 1 >= ? THEN ? END
+		WHERE job_id = ? AND idea_id = ?
+		RETURNING done_at IS NOT NULL`,
+		cause.Error(), maxAttempts, time.Now().UTC().Format(time.RFC3339), jobID, ideaID,
+	).Scan(&skipped)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, fmt.Errorf("%w: idea %s in bulk job %s", ErrNotFound, ideaID, jobID)
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to record bulk job item failure: %w", err)
+	}
+	return skipped, nil
+}
+
+// CompleteBulkJob marks a job as finished
+func (r *Repository) CompleteBulkJob(jobID string) error {
+	result, err := r.db.Exec(
+		"UPDATE bulk_jobs SET completed_at = ? WHERE id = ?",
+		time.Now().UTC().Format(time.RFC3339), jobID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to complete bulk job: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("%w: bulk job %s", ErrNotFound, jobID)
+	}
+	return nil
+}
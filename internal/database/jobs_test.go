@@ -0,0 +1,110 @@
+//go:build integration
+
+package database_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ryacub/telos-idea-matrix/internal/database"
+	"github.com/ryacub/telos-idea-matrix/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_BulkJob_TracksProgress(t *testing.T) {
+	repo, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	job := models.NewBulkJob(models.BulkJobAnalyze, `{"min_delta":0.5}`)
+	require.NoError(t, repo.CreateBulkJob(job, []string{"idea-c", "idea-a", "idea-b"}))
+
+	pending, err := repo.PendingBulkJobItems(job.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"idea-c", "idea-a", "idea-b"}, pending, "items keep their original order")
+
+	require.NoError(t, repo.MarkBulkJobItemDone(job.ID, "idea-a"))
+
+	pending, err = repo.PendingBulkJobItems(job.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"idea-c", "idea-b"}, pending)
+
+	got, err := repo.GetBulkJob(job.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.BulkJobAnalyze, got.Kind)
+	assert.Equal(t, `{"min_delta":0.5}`, got.Params)
+	assert.Equal(t, 3, got.Total)
+	assert.Equal(t, 1, got.Done)
+	assert.False(t, got.IsComplete())
+
+	require.NoError(t, repo.CompleteBulkJob(job.ID))
+	got, err = repo.GetBulkJob(job.ID)
+	require.NoError(t, err)
+	assert.True(t, got.IsComplete())
+}
+
+func TestRepository_RecordBulkJobItemFailure(t *testing.T) {
+	repo, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	job := models.NewBulkJob(models.BulkJobReanalyze, "")
+	require.NoError(t, repo.CreateBulkJob(job, []string{"idea-a", "idea-b", "idea-c"}))
+
+	// A failure that later succeeds is not counted as skipped
+	skipped, err := repo.RecordBulkJobItemFailure(job.ID, "idea-a", errors.New("timeout"), 2)
+	require.NoError(t, err)
+	assert.False(t, skipped)
+	require.NoError(t, repo.MarkBulkJobItemDone(job.ID, "idea-a"))
+
+	skipped, err = repo.RecordBulkJobItemFailure(job.ID, "idea-b", errors.New("timeout"), 2)
+	require.NoError(t, err)
+	assert.False(t, skipped)
+	skipped, err = repo.RecordBulkJobItemFailure(job.ID, "idea-b", errors.New("timeout"), 2)
+	require.NoError(t, err)
+	assert.True(t, skipped, "the second failure uses up the attempts")
+
+	pending, err := repo.PendingBulkJobItems(job.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"idea-c"}, pending)
+
+	got, err := repo.GetBulkJob(job.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 2, got.Done)
+	assert.Equal(t, 1, got.Skipped)
+
+	_, err = repo.RecordBulkJobItemFailure(job.ID, "idea-z", errors.New("timeout"), 2)
+	assert.True(t, database.IsNotFound(err))
+}
+
+func TestRepository_BulkJob_NotFound(t *testing.T) {
+	repo, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	_, err := repo.GetBulkJob("missing")
+	assert.True(t, database.IsNotFound(err))
+
+	job := models.NewBulkJob(models.BulkJobAnalyze, "")
+	require.NoError(t, repo.CreateBulkJob(job, []string{"idea-a"}))
+
+	assert.True(t, database.IsNotFound(repo.MarkBulkJobItemDone(job.ID, "idea-z")))
+	assert.True(t, database.IsNotFound(repo.CompleteBulkJob("missing")))
+}
+
+func TestRepository_IncompleteBulkJobIDs(t *testing.T) {
+	repo, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	first := models.NewBulkJob(models.BulkJobReanalyze, "")
+	require.NoError(t, repo.CreateBulkJob(first, []string{"idea-a"}))
+	finished := models.NewBulkJob(models.BulkJobReanalyze, "")
+	require.NoError(t, repo.CreateBulkJob(finished, []string{"idea-b"}))
+	require.NoError(t, repo.CompleteBulkJob(finished.ID))
+	other := models.NewBulkJob(models.BulkJobAnalyze, "")
+	require.NoError(t, repo.CreateBulkJob(other, []string{"idea-c"}))
+	second := models.NewBulkJob(models.BulkJobReanalyze, "")
+	require.NoError(t, repo.CreateBulkJob(second, []string{"idea-d"}))
+
+	ids, err := repo.IncompleteBulkJobIDs(models.BulkJobReanalyze)
+	require.NoError(t, err)
+	assert.Equal(t, []string{first.ID, second.ID}, ids)
+}
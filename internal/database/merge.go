@@ -0,0 +1,148 @@
+package database
+
+import (
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/ryacub/telos-idea-matrix/internal/models"
+)
+
+// MergeContent selects the content an idea keeps after a merge
+type MergeContent int
+
+const (
+	// MergeBothContents joins the two contents, kept idea first
+	MergeBothContents MergeContent = iota
+	// MergeKeptContent keeps only the kept idea's content
+	MergeKeptContent
+	// MergeAbsorbedContent keeps only the absorbed idea's content
+	MergeAbsorbedContent
+)
+
+// Merge combines the idea absorbID into keepID, joining their contents. See
+// MergeWithContent.
+func (r *Repository) Merge(keepID, absorbID string) error {
+	return r.MergeWithContent(keepID, absorbID, MergeBothContents)
+}
+
+// MergeWithContent combines the idea absorbID into keepID. The kept idea
+// takes the analysis of whichever idea scored higher, the union of both
+// ideas' patterns and tags, and the content chosen by content. The absorbed
+// idea's profile moves and relationships are carried over to the kept idea,
+// and the absorbed idea is moved to the trash. Everything is written in one
+// transaction, so a failed merge changes nothing. Merging fails with
+// ErrInvalidInput when the combined notes would be longer than
+// models.MaxNotesLength.
+func (r *Repository) MergeWithContent(keepID, absorbID string, content MergeContent) error {
+	if keepID == "" || absorbID == "" {
+		return fmt.Errorf("%w: both idea IDs are required", ErrInvalidInput)
+	}
+	if keepID == absorbID {
+		return fmt.Errorf("%w: cannot merge an idea into itself", ErrInvalidInput)
+	}
+
+	keep, err := r.GetByID(keepID)
+	if err != nil {
+		return err
+	}
+	absorb, err := r.GetByID(absorbID)
+	if err != nil {
+		return err
+	}
+	for _, idea := range []*models.Idea{keep, absorb} {
+		if idea.Status == string(models.StatusDeleted) {
+			return fmt.Errorf("%w: idea %s is in the trash", ErrInvalidInput, idea.ID)
+		}
+	}
+
+	mergeInto(keep, absorb, content)
+	if n := utf8.RuneCountInString(keep.Notes); n > models.MaxNotesLength {
+		return fmt.Errorf("%w: the merged notes would be %d characters, over the limit of %d; shorten the notes of either idea first",
+			ErrInvalidInput, n, models.MaxNotesLength)
+	}
+	absorb.Trash()
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	keepUpdatedAt, err := updateIdea(tx, keep)
+	if err != nil {
+		return err
+	}
+	absorbUpdatedAt, err := updateIdea(tx, absorb)
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec("UPDATE idea_moves SET idea_id = ? WHERE idea_id = ?", keep.ID, absorb.ID); err != nil {
+		return fmt.Errorf("failed to carry over moves: %w", err)
+	}
+
+	// Links that would duplicate one the kept idea already has are ignored
+	// by the updates and removed with the rest of the absorbed idea's links
+	statements := []string{
+		"UPDATE OR IGNORE idea_relationships SET source_idea_id = ? WHERE source_idea_id = ? AND target_idea_id != ?",
+		"UPDATE OR IGNORE idea_relationships SET target_idea_id = ? WHERE target_idea_id = ? AND source_idea_id != ?",
+	}
+	for _, stmt := range statements {
+		if _, err := tx.Exec(stmt, keep.ID, absorb.ID, keep.ID); err != nil {
+			return fmt.Errorf("failed to carry over relationships: %w", err)
+		}
+	}
+	if _, err := tx.Exec(
+		"DELETE FROM idea_relationships WHERE source_idea_id = ? OR target_idea_id = ?",
+		absorb.ID, absorb.ID,
+	); err != nil {
+		return fmt.Errorf("failed to remove merged relationships: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit merge: %w", err)
+	}
+	markUpdated(keep, keepUpdatedAt)
+	markUpdated(absorb, absorbUpdatedAt)
+	return nil
+}
+
+// mergeInto folds absorb into keep
+func mergeInto(keep, absorb *models.Idea, content MergeContent) {
+	switch content {
+	case MergeBothContents:
+		keep.Content = keep.Content + "\n\n" + absorb.Content
+	case MergeAbsorbedContent:
+		keep.Content = absorb.Content
+	}
+
+	if absorb.FinalScore > keep.FinalScore {
+		keep.RawScore = absorb.RawScore
+		keep.FinalScore = absorb.FinalScore
+		keep.Recommendation = absorb.Recommendation
+		keep.AnalysisDetails = absorb.AnalysisDetails
+		keep.TelosVersion = absorb.TelosVersion
+	}
+
+	keep.Patterns = unionStrings(keep.Patterns, absorb.Patterns)
+	keep.Tags = unionStrings(keep.Tags, absorb.Tags)
+	if keep.Trigger == "" {
+		keep.Trigger = absorb.Trigger
+	}
+	keep.AddNote(absorb.Notes)
+}
+
+// unionStrings returns the values of a followed by those of b that a lacks
+func unionStrings(a, b []string) []string {
+	seen := make(map[string]bool, len(a)+len(b))
+	var out []string
+	for _, values := range [][]string{a, b} {
+		for _, v := range values {
+			if !seen[v] {
+				seen[v] = true
+				out = append(out, v)
+			}
+		}
+	}
+	return out
+}
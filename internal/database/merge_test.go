@@ -0,0 +1,181 @@
+//go:build integration
+
+package database_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/ryacub/telos-idea-matrix/internal/database"
+	"github.com/ryacub/telos-idea-matrix/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func link(t *testing.T, repo *database.Repository, source, target string, relType models.RelationshipType) {
+	t.Helper()
+	rel, err := models.NewIdeaRelationship(source, target, relType)
+	require.NoError(t, err)
+	require.NoError(t, repo.CreateRelationship(rel))
+}
+
+func TestRepository_Merge_CombinesIdeas(t *testing.T) {
+	repo, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	keep := models.NewIdea("Weekly Go newsletter")
+	keep.FinalScore = 6.0
+	keep.Patterns = []string{"context-switching"}
+	keep.Tags = []string{"writing"}
+	keep.Notes = "Asked two friends to subscribe"
+	require.NoError(t, repo.Create(keep))
+
+	absorb := models.NewIdea("Newsletter about Go, every week")
+	absorb.FinalScore = 8.0
+	absorb.Recommendation = "✅ GOOD ALIGNMENT"
+	absorb.AnalysisDetails = `{"mission":3}`
+	absorb.Patterns = []string{"perfectionism", "context-switching"}
+	absorb.Tags = []string{"go"}
+	absorb.Notes = "Drafted the first issue"
+	require.NoError(t, repo.Create(absorb))
+
+	other := models.NewIdea("Start a blog")
+	require.NoError(t, repo.Create(other))
+	link(t, repo, absorb.ID, other.ID, models.RelatedTo)
+	link(t, repo, other.ID, absorb.ID, models.DependsOn)
+	link(t, repo, keep.ID, absorb.ID, models.Duplicate)
+	// Already linked from the kept idea, so the absorbed copy is dropped
+	link(t, repo, keep.ID, other.ID, models.RelatedTo)
+
+	absorb.Profile = "side"
+	_, err := repo.MoveIdea(absorb, models.DefaultProfile, false)
+	require.NoError(t, err)
+
+	require.NoError(t, repo.Merge(keep.ID, absorb.ID))
+
+	got, err := repo.GetByID(keep.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Weekly Go newsletter\n\nNewsletter about Go, every week", got.Content)
+	assert.Equal(t, 8.0, got.FinalScore)
+	assert.Equal(t, "✅ GOOD ALIGNMENT", got.Recommendation)
+	assert.Equal(t, `{"mission":3}`, got.AnalysisDetails)
+	assert.Equal(t, []string{"context-switching", "perfectionism"}, got.Patterns)
+	assert.Equal(t, []string{"writing", "go"}, got.Tags)
+	assert.Equal(t, "Asked two friends to subscribe\nDrafted the first issue", got.Notes)
+
+	absorbed, err := repo.GetByID(absorb.ID)
+	require.NoError(t, err)
+	assert.Equal(t, string(models.StatusDeleted), absorbed.Status)
+
+	moves, err := repo.IdeaMoves(keep.ID)
+	require.NoError(t, err)
+	assert.Len(t, moves, 1)
+
+	rels, err := repo.GetRelationshipsForIdea(keep.ID)
+	require.NoError(t, err)
+	assert.Len(t, rels, 2)
+	for _, rel := range rels {
+		assert.NotEqual(t, absorb.ID, rel.SourceIdeaID)
+		assert.NotEqual(t, absorb.ID, rel.TargetIdeaID)
+	}
+	rels, err = repo.GetRelationshipsForIdea(absorb.ID)
+	require.NoError(t, err)
+	assert.Empty(t, rels)
+}
+
+func TestRepository_MergeWithContent_KeepsChosenContent(t *testing.T) {
+	repo, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	keep := models.NewIdea("First wording")
+	keep.FinalScore = 7.0
+	require.NoError(t, repo.Create(keep))
+	absorb := models.NewIdea("Second wording")
+	absorb.FinalScore = 5.0
+	require.NoError(t, repo.Create(absorb))
+
+	require.NoError(t, repo.MergeWithContent(keep.ID, absorb.ID, database.MergeAbsorbedContent))
+
+	got, err := repo.GetByID(keep.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Second wording", got.Content)
+	assert.Equal(t, 7.0, got.FinalScore)
+}
+
+func TestRepository_Merge_RejectsInvalidPairs(t *testing.T) {
+	repo, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	keep := models.NewIdea("Kept idea")
+	require.NoError(t, repo.Create(keep))
+	trashed := models.NewIdea("Trashed idea")
+	trashed.Trash()
+	require.NoError(t, repo.Create(trashed))
+
+	err := repo.Merge(keep.ID, keep.ID)
+	assert.True(t, errors.Is(err, database.ErrInvalidInput), "expected ErrInvalidInput, got %v", err)
+
+	err = repo.Merge(keep.ID, trashed.ID)
+	assert.True(t, errors.Is(err, database.ErrInvalidInput), "expected ErrInvalidInput, got %v", err)
+
+	err = repo.Merge(keep.ID, "missing")
+	assert.True(t, errors.Is(err, database.ErrNotFound), "expected ErrNotFound, got %v", err)
+
+	got, err := repo.GetByID(keep.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Kept idea", got.Content)
+}
+
+func TestRepository_Merge_RejectsNotesOverLimit(t *testing.T) {
+	repo, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	keep := models.NewIdea("Weekly Go newsletter")
+	keep.Notes = strings.Repeat("x", models.MaxNotesLength/2+1)
+	require.NoError(t, repo.Create(keep))
+	absorb := models.NewIdea("Newsletter about Go, every week")
+	absorb.Notes = strings.Repeat("y", models.MaxNotesLength/2)
+	require.NoError(t, repo.Create(absorb))
+
+	err := repo.Merge(keep.ID, absorb.ID)
+	assert.True(t, errors.Is(err, database.ErrInvalidInput), "expected ErrInvalidInput, got %v", err)
+	assert.Contains(t, err.Error(), "the merged notes would be 10002 characters, over the limit of 10000")
+
+	// Nothing changed
+	got, err := repo.GetByID(keep.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Weekly Go newsletter", got.Content)
+	assert.Equal(t, keep.Notes, got.Notes)
+	got, err = repo.GetByID(absorb.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "active", got.Status)
+}
+
+func TestRepository_Merge_FailureLeavesIdeasUnchanged(t *testing.T) {
+	repo, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	keep := models.NewIdea("Weekly Go newsletter")
+	require.NoError(t, repo.Create(keep))
+	absorb := models.NewIdea("Newsletter about Go, every week")
+	require.NoError(t, repo.Create(absorb))
+
+	// The kept idea is written, then trashing the absorbed one fails
+	_, err := repo.DB().Exec(`CREATE TRIGGER fail_trash BEFORE UPDATE OF status ON ideas
+		WHEN NEW.status = 'deleted' BEGIN SELECT RAISE(ABORT, 'trash unavailable'); END`)
+	require.NoError(t, err)
+
+	err = repo.Merge(keep.ID, absorb.ID)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "trash unavailable")
+
+	got, err := repo.GetByID(keep.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Weekly Go newsletter", got.Content)
+	assert.Equal(t, keep.Version, got.Version)
+
+	// Copies read before the merge are still current
+	keep.Notes = "after the failed merge"
+	require.NoError(t, repo.Update(keep))
+}
@@ -0,0 +1,620 @@
+package database
+
+import (
+	"database/sql"
+	"embed"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/ryacub/telos-idea-matrix/internal/models"
+)
+
+// migrationsFS holds the SQL files that make up the version-1 schema
+//
+//go:embed migrations/*.sql
+var migrationsFS embed.FS
+
+// Migration is a versioned schema change. Up and Down each run inside a
+// transaction, so a failing migration leaves the schema untouched.
+type Migration struct {
+	Version int
+	Name    string
+	Up      func(tx *sql.Tx) error
+	Down    func(tx *sql.Tx) error
+}
+
+// migrations lists every schema change in version order.
+// Add new changes to the end; never renumber or edit an applied migration.
+var migrations = []Migration{
+	{Version: 1, Name: "initial_schema", Up: initialSchemaUp, Down: initialSchemaDown},
+	{Version: 2, Name: "archive_reason", Up: archiveReasonUp, Down: archiveReasonDown},
+	{Version: 3, Name: "idea_profile", Up: ideaProfileUp, Down: ideaProfileDown},
+	{Version: 4, Name: "telos_version", Up: telosVersionUp, Down: telosVersionDown},
+	{Version: 5, Name: "bulk_jobs", Up: bulkJobsUp, Down: bulkJobsDown},
+	{Version: 6, Name: "idea_moves", Up: ideaMovesUp, Down: ideaMovesDown},
+	{Version: 7, Name: "idea_embeddings", Up: ideaEmbeddingsUp, Down: ideaEmbeddingsDown},
+	{Version: 8, Name: "idea_version", Up: ideaVersionUp, Down: ideaVersionDown},
+	{Version: 9, Name: "idempotency_keys", Up: idempotencyKeysUp, Down: idempotencyKeysDown},
+	{Version: 10, Name: "notion_pages", Up: notionPagesUp, Down: notionPagesDown},
+	{Version: 11, Name: "recommendation_category", Up: recommendationCategoryUp, Down: recommendationCategoryDown},
+	{Version: 12, Name: "idea_notes", Up: ideaNotesUp, Down: ideaNotesDown},
+	{Version: 13, Name: "idea_updated_at", Up: ideaUpdatedAtUp, Down: ideaUpdatedAtDown},
+	{Version: 14, Name: "bulk_job_item_attempts", Up: bulkJobItemAttemptsUp, Down: bulkJobItemAttemptsDown},
+}
+
+// MigrationStatus reports whether a known migration has been applied.
+type MigrationStatus struct {
+	Version   int        `json:"version"`
+	Name      string     `json:"name"`
+	Applied   bool       `json:"applied"`
+	AppliedAt *time.Time `json:"applied_at,omitempty"`
+}
+
+const createSchemaMigrations = `
+	CREATE TABLE IF NOT EXISTS schema_migrations (
+		version INTEGER PRIMARY KEY,
+		name TEXT NOT NULL,
+		applied_at TEXT NOT NULL        -- RFC3339 format (UTC)
+	)
+`
+
+// Migrate applies all pending migrations in version order. It is called when
+// the repository is opened, so callers rarely need it directly.
+func (r *Repository) Migrate() error {
+	return r.migrateUp(migrations)
+}
+
+// MigrateDown reverts applied migrations, newest first, until the schema is at
+// target. A target of 0 reverts everything, dropping all tables and data.
+func (r *Repository) MigrateDown(target int) error {
+	return r.migrateDown(migrations, target)
+}
+
+// SchemaVersion returns the highest applied migration version, or 0 if none.
+func (r *Repository) SchemaVersion() (int, error) {
+	if _, err := r.db.Exec(createSchemaMigrations); err != nil {
+		return 0, fmt.Errorf("failed to create schema_migrations table: %w", err)
+	}
+
+	var version int
+	if err := r.db.QueryRow("SELECT COALESCE(MAX(version), 0) FROM schema_migrations").Scan(&version); err != nil {
+		return 0, fmt.Errorf("failed to read schema version: %w", err)
+	}
+	return version, nil
+}
+
+// MigrationStatus lists every known migration and whether it has been applied.
+func (r *Repository) MigrationStatus() ([]MigrationStatus, error) {
+	applied, err := r.appliedMigrations()
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]MigrationStatus, 0, len(migrations))
+	for _, m := range migrations {
+		status := MigrationStatus{Version: m.Version, Name: m.Name}
+		if appliedAt, ok := applied[m.Version]; ok {
+			status.Applied = true
+			status.AppliedAt = &appliedAt
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+func (r *Repository) migrateUp(list []Migration) error {
+	applied, err := r.appliedMigrations()
+	if err != nil {
+		return err
+	}
+
+	latest := 0
+	if len(list) > 0 {
+		latest = list[len(list)-1].Version
+	}
+	for version := range applied {
+		if version > latest {
+			return fmt.Errorf("database schema version %d is newer than this build supports (%d); upgrade tm", version, latest)
+		}
+	}
+
+	for _, m := range list {
+		if _, ok := applied[m.Version]; ok {
+			continue
+		}
+
+		ran, err := r.applyMigration(m)
+		if err != nil {
+			return fmt.Errorf("migration %d (%s) failed: %w", m.Version, m.Name, err)
+		}
+		if ran {
+			log.Info().Int("version", m.Version).Str("name", m.Name).Msg("applied database migration")
+		}
+	}
+
+	return nil
+}
+
+// applyMigration runs one migration and records it in the same transaction.
+// The version is recorded first so that if another process is applying the
+// same migration concurrently, this one waits for its write lock and then
+// skips. Returns false if the migration was already applied.
+func (r *Repository) applyMigration(m Migration) (bool, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return false, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	result, err := tx.Exec(
+		"INSERT OR IGNORE INTO schema_migrations (version, name, applied_at) VALUES (?, ?, ?)",
+		m.Version, m.Name, time.Now().UTC().Format(time.RFC3339),
+	)
+	if err != nil {
+		return false, fmt.Errorf("failed to record migration: %w", err)
+	}
+	if inserted, err := result.RowsAffected(); err != nil {
+		return false, fmt.Errorf("failed to record migration: %w", err)
+	} else if inserted == 0 {
+		return false, nil
+	}
+
+	if err := m.Up(tx); err != nil {
+		return false, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, fmt.Errorf("failed to commit migration: %w", err)
+	}
+	return true, nil
+}
+
+func (r *Repository) migrateDown(list []Migration, target int) error {
+	if target < 0 {
+		return fmt.Errorf("%w: target version cannot be negative", ErrInvalidInput)
+	}
+
+	applied, err := r.appliedMigrations()
+	if err != nil {
+		return err
+	}
+
+	for i := len(list) - 1; i >= 0; i-- {
+		m := list[i]
+		if m.Version <= target {
+			break
+		}
+		if _, ok := applied[m.Version]; !ok {
+			continue
+		}
+
+		if err := r.revertMigration(m); err != nil {
+			return fmt.Errorf("reverting migration %d (%s) failed: %w", m.Version, m.Name, err)
+		}
+		log.Info().Int("version", m.Version).Str("name", m.Name).Msg("reverted database migration")
+	}
+
+	return nil
+}
+
+// revertMigration runs one migration's Down and removes its record in the same transaction
+func (r *Repository) revertMigration(m Migration) error {
+	if m.Down == nil {
+		return fmt.Errorf("migration cannot be reverted")
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if err := m.Down(tx); err != nil {
+		return err
+	}
+	if _, err := tx.Exec("DELETE FROM schema_migrations WHERE version = ?", m.Version); err != nil {
+		return fmt.Errorf("failed to remove migration record: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit migration: %w", err)
+	}
+	return nil
+}
+
+// appliedMigrations returns applied versions and when they were applied
+func (r *Repository) appliedMigrations() (map[int]time.Time, error) {
+	if _, err := r.db.Exec(createSchemaMigrations); err != nil {
+		return nil, fmt.Errorf("failed to create schema_migrations table: %w", err)
+	}
+
+	rows, err := r.db.Query("SELECT version, applied_at FROM schema_migrations")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read schema_migrations: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	applied := make(map[int]time.Time)
+	for rows.Next() {
+		var version int
+		var appliedAt string
+		if err := rows.Scan(&version, &appliedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan migration: %w", err)
+		}
+		t, _ := time.Parse(time.RFC3339, appliedAt)
+		applied[version] = t
+	}
+	return applied, rows.Err()
+}
+
+// initialSchemaUp creates the schema from the embedded migrations/*.sql files.
+// These predate versioned migrations and are written to be re-runnable, so
+// databases created before schema_migrations existed converge on version 1
+// without losing data.
+func initialSchemaUp(tx *sql.Tx) error {
+	entries, err := migrationsFS.ReadDir("migrations")
+	if err != nil {
+		return fmt.Errorf("failed to read migrations directory: %w", err)
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".sql") {
+			continue
+		}
+
+		content, err := migrationsFS.ReadFile("migrations/" + entry.Name())
+		if err != nil {
+			return fmt.Errorf("failed to read migration %s: %w", entry.Name(), err)
+		}
+
+		if _, err := tx.Exec(string(content)); err != nil {
+			// ALTER TABLE ADD COLUMN has no IF NOT EXISTS; on an existing
+			// database the column is already there
+			if strings.Contains(err.Error(), "duplicate column name") {
+				continue
+			}
+			return fmt.Errorf("failed to execute migration %s: %w", entry.Name(), err)
+		}
+	}
+
+	return nil
+}
+
+// initialSchemaDown drops every table created by the initial schema.
+// Indexes and triggers are dropped along with their tables.
+func initialSchemaDown(tx *sql.Tx) error {
+	for _, table := range []string{"analytics_summary", "sessions", "idea_relationships", "ideas"} {
+		if _, err := tx.Exec("DROP TABLE IF EXISTS " + table); err != nil {
+			return fmt.Errorf("failed to drop %s: %w", table, err)
+		}
+	}
+	return nil
+}
+
+// archiveReasonUp adds archive_reason, recording why an idea was archived.
+// Existing ideas get an empty reason.
+func archiveReasonUp(tx *sql.Tx) error {
+	if _, err := tx.Exec("ALTER TABLE ideas ADD COLUMN archive_reason TEXT NOT NULL DEFAULT ''"); err != nil {
+		return fmt.Errorf("failed to add archive_reason: %w", err)
+	}
+	return nil
+}
+
+func archiveReasonDown(tx *sql.Tx) error {
+	if _, err := tx.Exec("ALTER TABLE ideas DROP COLUMN archive_reason"); err != nil {
+		return fmt.Errorf("failed to drop archive_reason: %w", err)
+	}
+	return nil
+}
+
+// ideaProfileUp adds profile, the telos profile an idea was scored against.
+// Existing ideas were scored against the default profile.
+func ideaProfileUp(tx *sql.Tx) error {
+	if _, err := tx.Exec("ALTER TABLE ideas ADD COLUMN profile TEXT NOT NULL DEFAULT 'default'"); err != nil {
+		return fmt.Errorf("failed to add profile: %w", err)
+	}
+	if _, err := tx.Exec("CREATE INDEX IF NOT EXISTS idx_ideas_profile ON ideas(profile)"); err != nil {
+		return fmt.Errorf("failed to index profile: %w", err)
+	}
+	return nil
+}
+
+func ideaProfileDown(tx *sql.Tx) error {
+	if _, err := tx.Exec("DROP INDEX IF EXISTS idx_ideas_profile"); err != nil {
+		return fmt.Errorf("failed to drop profile index: %w", err)
+	}
+	if _, err := tx.Exec("ALTER TABLE ideas DROP COLUMN profile"); err != nil {
+		return fmt.Errorf("failed to drop profile: %w", err)
+	}
+	return nil
+}
+
+// telosVersionUp adds telos_version, the version of the telos an idea was
+// scored against. Existing ideas are left empty until backfilled.
+func telosVersionUp(tx *sql.Tx) error {
+	if _, err := tx.Exec("ALTER TABLE ideas ADD COLUMN telos_version TEXT NOT NULL DEFAULT ''"); err != nil {
+		return fmt.Errorf("failed to add telos_version: %w", err)
+	}
+	return nil
+}
+
+func telosVersionDown(tx *sql.Tx) error {
+	if _, err := tx.Exec("ALTER TABLE ideas DROP COLUMN telos_version"); err != nil {
+		return fmt.Errorf("failed to drop telos_version: %w", err)
+	}
+	return nil
+}
+
+// bulkJobsUp adds bulk_jobs and bulk_job_items, which record which ideas a
+// resumable bulk operation has finished. Items don't reference ideas, so
+// deleting an idea mid-job leaves the job intact.
+func bulkJobsUp(tx *sql.Tx) error {
+	statements := []string{
+		`CREATE TABLE IF NOT EXISTS bulk_jobs (
+			id TEXT PRIMARY KEY,
+			kind TEXT NOT NULL,
+			params TEXT NOT NULL DEFAULT '{}',
+			created_at TEXT NOT NULL,       -- RFC3339 format (UTC)
+			completed_at TEXT               -- RFC3339 format (UTC), NULL while running
+		)`,
+		`CREATE TABLE IF NOT EXISTS bulk_job_items (
+			job_id TEXT NOT NULL REFERENCES bulk_jobs(id) ON DELETE CASCADE,
+			idea_id TEXT NOT NULL,
+			position INTEGER NOT NULL,      -- Processing order within the job
+			done_at TEXT,                   -- RFC3339 format (UTC), NULL until processed
+			PRIMARY KEY (job_id, idea_id)
+		)`,
+	}
+	for _, stmt := range statements {
+		if _, err := tx.Exec(stmt); err != nil {
+			return fmt.Errorf("failed to create bulk job tables: %w", err)
+		}
+	}
+	return nil
+}
+
+func bulkJobsDown(tx *sql.Tx) error {
+	for _, table := range []string{"bulk_job_items", "bulk_jobs"} {
+		if _, err := tx.Exec("DROP TABLE IF EXISTS " + table); err != nil {
+			return fmt.Errorf("failed to drop %s: %w", table, err)
+		}
+	}
+	return nil
+}
+
+// ideaMovesUp adds idea_moves, a history of ideas transferred between telos
+// profiles. A move is deleted with its idea.
+func ideaMovesUp(tx *sql.Tx) error {
+	statements := []string{
+		`CREATE TABLE IF NOT EXISTS idea_moves (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			idea_id TEXT NOT NULL REFERENCES ideas(id) ON DELETE CASCADE,
+			from_profile TEXT NOT NULL,
+			to_profile TEXT NOT NULL,
+			reanalyzed INTEGER NOT NULL DEFAULT 0,
+			moved_at TEXT NOT NULL          -- RFC3339 format (UTC)
+		)`,
+		"CREATE INDEX IF NOT EXISTS idx_idea_moves_idea_id ON idea_moves(idea_id)",
+	}
+	for _, stmt := range statements {
+		if _, err := tx.Exec(stmt); err != nil {
+			return fmt.Errorf("failed to create idea_moves: %w", err)
+		}
+	}
+	return nil
+}
+
+func ideaMovesDown(tx *sql.Tx) error {
+	if _, err := tx.Exec("DROP TABLE IF EXISTS idea_moves"); err != nil {
+		return fmt.Errorf("failed to drop idea_moves: %w", err)
+	}
+	return nil
+}
+
+// ideaEmbeddingsUp adds idea_embeddings, one embedding vector per idea for
+// similarity search. The content hash tells when the idea changed since.
+func ideaEmbeddingsUp(tx *sql.Tx) error {
+	_, err := tx.Exec(`CREATE TABLE IF NOT EXISTS idea_embeddings (
+		idea_id TEXT PRIMARY KEY REFERENCES ideas(id) ON DELETE CASCADE,
+		model TEXT NOT NULL,
+		content_hash TEXT NOT NULL,
+		embedding BLOB NOT NULL,     -- little-endian float32s
+		embedded_at TEXT NOT NULL    -- RFC3339 format (UTC)
+	)`)
+	if err != nil {
+		return fmt.Errorf("failed to create idea_embeddings: %w", err)
+	}
+	return nil
+}
+
+func ideaEmbeddingsDown(tx *sql.Tx) error {
+	if _, err := tx.Exec("DROP TABLE IF EXISTS idea_embeddings"); err != nil {
+		return fmt.Errorf("failed to drop idea_embeddings: %w", err)
+	}
+	return nil
+}
+
+// ideaVersionUp adds version, incremented on every update so concurrent
+// edits of the same idea are detected instead of overwriting each other.
+func ideaVersionUp(tx *sql.Tx) error {
+	if _, err := tx.Exec("ALTER TABLE ideas ADD COLUMN version INTEGER NOT NULL DEFAULT 1"); err != nil {
+		return fmt.Errorf("failed to add version: %w", err)
+	}
+	return nil
+}
+
+func ideaVersionDown(tx *sql.Tx) error {
+	if _, err := tx.Exec("ALTER TABLE ideas DROP COLUMN version"); err != nil {
+		return fmt.Errorf("failed to drop version: %w", err)
+	}
+	return nil
+}
+
+// idempotencyKeysUp adds idempotency_keys, the responses to create requests
+// that carried an Idempotency-Key, so a retried request gets the original
+// response instead of creating the idea twice
+func idempotencyKeysUp(tx *sql.Tx) error {
+	_, err := tx.Exec(`CREATE TABLE IF NOT EXISTS idempotency_keys (
+		key TEXT PRIMARY KEY,
+		request_hash TEXT NOT NULL,  -- SHA-256 of the request body
+		idea_id TEXT NOT NULL,
+		status INTEGER NOT NULL,
+		response TEXT NOT NULL,
+		created_at TEXT NOT NULL     -- RFC3339 format (UTC)
+	)`)
+	if err != nil {
+		return fmt.Errorf("failed to create idempotency_keys: %w", err)
+	}
+	return nil
+}
+
+func idempotencyKeysDown(tx *sql.Tx) error {
+	if _, err := tx.Exec("DROP TABLE IF EXISTS idempotency_keys"); err != nil {
+		return fmt.Errorf("failed to drop idempotency_keys: %w", err)
+	}
+	return nil
+}
+
+// notionPagesUp records the Notion page each idea was exported to, so
+// exporting again updates the page instead of adding a duplicate.
+func notionPagesUp(tx *sql.Tx) error {
+	_, err := tx.Exec(`CREATE TABLE IF NOT EXISTS notion_pages (
+		idea_id TEXT NOT NULL REFERENCES ideas(id) ON DELETE CASCADE,
+		database_id TEXT NOT NULL,
+		page_id TEXT NOT NULL,
+		synced_at TEXT NOT NULL,     -- RFC3339 format (UTC)
+		PRIMARY KEY (idea_id, database_id)
+	)`)
+	if err != nil {
+		return fmt.Errorf("failed to create notion_pages: %w", err)
+	}
+	return nil
+}
+
+func notionPagesDown(tx *sql.Tx) error {
+	if _, err := tx.Exec("DROP TABLE IF EXISTS notion_pages"); err != nil {
+		return fmt.Errorf("failed to drop notion_pages: %w", err)
+	}
+	return nil
+}
+
+// recommendationCategoryUp adds recommendation_category, the canonical
+// category of each idea's free-form recommendation, and fills it in for
+// existing ideas
+func recommendationCategoryUp(tx *sql.Tx) error {
+	if _, err := tx.Exec("ALTER TABLE ideas ADD COLUMN recommendation_category TEXT NOT NULL DEFAULT ''"); err != nil {
+		return fmt.Errorf("failed to add recommendation_category: %w", err)
+	}
+	if _, err := tx.Exec("CREATE INDEX IF NOT EXISTS idx_ideas_recommendation_category ON ideas(recommendation_category)"); err != nil {
+		return fmt.Errorf("failed to index recommendation_category: %w", err)
+	}
+
+	rows, err := tx.Query("SELECT DISTINCT recommendation FROM ideas WHERE recommendation IS NOT NULL")
+	if err != nil {
+		return fmt.Errorf("failed to query recommendations: %w", err)
+	}
+	var recommendations []string
+	for rows.Next() {
+		var recommendation string
+		if err := rows.Scan(&recommendation); err != nil {
+			_ = rows.Close()
+			return fmt.Errorf("failed to scan recommendation: %w", err)
+		}
+		recommendations = append(recommendations, recommendation)
+	}
+	if err := rows.Err(); err != nil {
+		_ = rows.Close()
+		return fmt.Errorf("error iterating recommendations: %w", err)
+	}
+	_ = rows.Close()
+
+	for _, recommendation := range recommendations {
+		category := models.ParseRecommendation(recommendation)
+		if category == models.CategoryUnknown {
+			continue
+		}
+		if _, err := tx.Exec("UPDATE ideas SET recommendation_category = ? WHERE recommendation = ?",
+			string(category), recommendation); err != nil {
+			return fmt.Errorf("failed to backfill recommendation_category: %w", err)
+		}
+	}
+	return nil
+}
+
+func recommendationCategoryDown(tx *sql.Tx) error {
+	if _, err := tx.Exec("DROP INDEX IF EXISTS idx_ideas_recommendation_category"); err != nil {
+		return fmt.Errorf("failed to drop recommendation_category index: %w", err)
+	}
+	if _, err := tx.Exec("ALTER TABLE ideas DROP COLUMN recommendation_category"); err != nil {
+		return fmt.Errorf("failed to drop recommendation_category: %w", err)
+	}
+	return nil
+}
+
+// ideaNotesUp adds notes, the user's own commentary on an idea. Existing
+// ideas have no notes.
+func ideaNotesUp(tx *sql.Tx) error {
+	if _, err := tx.Exec("ALTER TABLE ideas ADD COLUMN notes TEXT NOT NULL DEFAULT ''"); err != nil {
+		return fmt.Errorf("failed to add notes: %w", err)
+	}
+	return nil
+}
+
+func ideaNotesDown(tx *sql.Tx) error {
+	if _, err := tx.Exec("ALTER TABLE ideas DROP COLUMN notes"); err != nil {
+		return fmt.Errorf("failed to drop notes: %w", err)
+	}
+	return nil
+}
+
+// ideaUpdatedAtUp adds updated_at, when each idea was last written, so
+// exports can pick up only what changed. Existing ideas take their review
+// time, or their creation time if never reviewed.
+func ideaUpdatedAtUp(tx *sql.Tx) error {
+	if _, err := tx.Exec("ALTER TABLE ideas ADD COLUMN updated_at TEXT NOT NULL DEFAULT ''"); err != nil {
+		return fmt.Errorf("failed to add updated_at: %w", err)
+	}
+	if _, err := tx.Exec("UPDATE ideas SET updated_at = COALESCE(reviewed_at, created_at)"); err != nil {
+		return fmt.Errorf("failed to backfill updated_at: %w", err)
+	}
+	if _, err := tx.Exec("CREATE INDEX IF NOT EXISTS idx_ideas_updated_at ON ideas(updated_at)"); err != nil {
+		return fmt.Errorf("failed to index updated_at: %w", err)
+	}
+	return nil
+}
+
+func ideaUpdatedAtDown(tx *sql.Tx) error {
+	if _, err := tx.Exec("DROP INDEX IF EXISTS idx_ideas_updated_at"); err != nil {
+		return fmt.Errorf("failed to drop updated_at index: %w", err)
+	}
+	if _, err := tx.Exec("ALTER TABLE ideas DROP COLUMN updated_at"); err != nil {
+		return fmt.Errorf("failed to drop updated_at: %w", err)
+	}
+	return nil
+}
+
+// bulkJobItemAttemptsUp records failed attempts at a bulk job item, so an
+// idea that keeps failing can be skipped instead of stalling its job
+func bulkJobItemAttemptsUp(tx *sql.Tx) error {
+	statements := []string{
+		"ALTER TABLE bulk_job_items ADD COLUMN attempts INTEGER NOT NULL DEFAULT 0",
+		"ALTER TABLE bulk_job_items ADD COLUMN last_error TEXT",
+	}
+	for _, stmt := range statements {
+		if _, err := tx.Exec(stmt); err != nil {
+			return fmt.Errorf("failed to add bulk job item attempts: %w", err)
+		}
+	}
+	return nil
+}
+
+func bulkJobItemAttemptsDown(tx *sql.Tx) error {
+	for _, column := range []string{"last_error", "attempts"} {
+		if _, err := tx.Exec("ALTER TABLE bulk_job_items DROP COLUMN " + column); err != nil {
+			return fmt.Errorf("failed to drop %s: %w", column, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,108 @@
+//go:build integration
+
+package database
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMigrateUp_FailedMigrationRollsBack(t *testing.T) {
+	repo, err := NewRepository(filepath.Join(t.TempDir(), "ideas.db"))
+	require.NoError(t, err)
+	defer repo.Close()
+
+	latest := migrations[len(migrations)-1].Version
+	broken := Migration{
+		Version: latest + 1,
+		Name:    "broken",
+		Up: func(tx *sql.Tx) error {
+			if _, err := tx.Exec("CREATE TABLE half_done (id TEXT)"); err != nil {
+				return err
+			}
+			return errors.New("boom")
+		},
+	}
+
+	err = repo.migrateUp(append(append([]Migration{}, migrations...), broken))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), fmt.Sprintf("migration %d (broken) failed", latest+1))
+
+	var tables int
+	require.NoError(t, repo.db.QueryRow(
+		"SELECT COUNT(*) FROM sqlite_master WHERE name = 'half_done'",
+	).Scan(&tables))
+	assert.Equal(t, 0, tables, "changes from a failed migration should be rolled back")
+
+	version, err := repo.SchemaVersion()
+	require.NoError(t, err)
+	assert.Equal(t, latest, version, "failed migration should not be recorded")
+}
+
+func TestMigrateUp_RejectsNewerSchema(t *testing.T) {
+	repo, err := NewRepository(filepath.Join(t.TempDir(), "ideas.db"))
+	require.NoError(t, err)
+	defer repo.Close()
+
+	_, err = repo.db.Exec("INSERT INTO schema_migrations (version, name, applied_at) VALUES (99, 'future', '2030-01-01T00:00:00Z')")
+	require.NoError(t, err)
+
+	err = repo.Migrate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "newer than this build supports")
+}
+
+func TestApplyMigration_SkipsAlreadyRecordedVersion(t *testing.T) {
+	repo, err := NewRepository(filepath.Join(t.TempDir(), "ideas.db"))
+	require.NoError(t, err)
+	defer repo.Close()
+
+	ran := false
+	again := Migration{Version: 1, Name: "initial_schema", Up: func(tx *sql.Tx) error {
+		ran = true
+		return nil
+	}}
+
+	applied, err := repo.applyMigration(again)
+	require.NoError(t, err)
+	assert.False(t, applied)
+	assert.False(t, ran, "Up should not run for a version another process already recorded")
+}
+
+func TestRecommendationCategoryUp_BackfillsExistingIdeas(t *testing.T) {
+	repo, err := NewRepository(filepath.Join(t.TempDir(), "ideas.db"))
+	require.NoError(t, err)
+	defer repo.Close()
+
+	// Go back to before the column existed and store ideas the old way
+	require.NoError(t, repo.MigrateDown(10))
+	for id, recommendation := range map[string]string{
+		"a": "\U0001F525 PRIORITIZE NOW",
+		"b": "CONSIDER LATER",
+		"c": "something else",
+	} {
+		_, err := repo.db.Exec(`INSERT INTO ideas (id, content, created_at, status, recommendation)
+			VALUES (?, ?, '2024-01-01T00:00:00Z', 'active', ?)`, id, "Idea "+id, recommendation)
+		require.NoError(t, err)
+	}
+	require.NoError(t, repo.Migrate())
+
+	categories := make(map[string]string)
+	rows, err := repo.db.Query("SELECT id, recommendation_category FROM ideas")
+	require.NoError(t, err)
+	defer rows.Close()
+	for rows.Next() {
+		var id, category string
+		require.NoError(t, rows.Scan(&id, &category))
+		categories[id] = category
+	}
+	require.NoError(t, rows.Err())
+
+	assert.Equal(t, map[string]string{"a": "prioritize", "b": "consider", "c": ""}, categories)
+}
@@ -0,0 +1,107 @@
+//go:build integration
+
+package database_test
+
+import (
+	"database/sql"
+	"path/filepath"
+	"testing"
+
+	"github.com/ryacub/telos-idea-matrix/internal/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_Migrate_FreshDatabaseIsCurrent(t *testing.T) {
+	repo, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	statuses, err := repo.MigrationStatus()
+	require.NoError(t, err)
+	require.NotEmpty(t, statuses)
+	for _, s := range statuses {
+		assert.True(t, s.Applied, "migration %d should be applied", s.Version)
+		assert.NotNil(t, s.AppliedAt)
+	}
+
+	version, err := repo.SchemaVersion()
+	require.NoError(t, err)
+	assert.Equal(t, statuses[len(statuses)-1].Version, version)
+
+	// Running again is a no-op
+	require.NoError(t, repo.Migrate())
+}
+
+func TestRepository_Migrate_ExistingUnversionedDatabaseConverges(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "ideas.db")
+
+	repo, err := database.NewRepository(dbPath)
+	require.NoError(t, err)
+	id := createTestIdea(t, repo, "Idea from before versioned migrations")
+	require.NoError(t, repo.Close())
+
+	// Simulate a database created before schema_migrations existed
+	db, err := sql.Open("sqlite3", dbPath)
+	require.NoError(t, err)
+	_, err = db.Exec("DROP TABLE schema_migrations")
+	require.NoError(t, err)
+	for _, stmt := range []string{
+		"ALTER TABLE ideas DROP COLUMN archive_reason",
+		"DROP INDEX idx_ideas_profile",
+		"ALTER TABLE ideas DROP COLUMN profile",
+		"ALTER TABLE ideas DROP COLUMN telos_version",
+		"ALTER TABLE ideas DROP COLUMN version",
+		"DROP INDEX idx_ideas_recommendation_category",
+		"ALTER TABLE ideas DROP COLUMN recommendation_category",
+		"ALTER TABLE ideas DROP COLUMN notes",
+		"DROP INDEX idx_ideas_updated_at",
+		"ALTER TABLE ideas DROP COLUMN updated_at",
+		"ALTER TABLE bulk_job_items DROP COLUMN last_error",
+		"ALTER TABLE bulk_job_items DROP COLUMN attempts",
+	} {
+		_, err = db.Exec(stmt)
+		require.NoError(t, err)
+	}
+	require.NoError(t, db.Close())
+
+	repo, err = database.NewRepository(dbPath)
+	require.NoError(t, err)
+	defer repo.Close()
+
+	statuses, err := repo.MigrationStatus()
+	require.NoError(t, err)
+	version, err := repo.SchemaVersion()
+	require.NoError(t, err)
+	assert.Equal(t, statuses[len(statuses)-1].Version, version)
+
+	got, err := repo.GetByID(id)
+	require.NoError(t, err)
+	assert.Equal(t, "Idea from before versioned migrations", got.Content)
+	assert.Empty(t, got.ArchiveReason)
+	assert.Equal(t, "default", got.Profile)
+	assert.Empty(t, got.TelosVersion)
+	assert.Equal(t, 1, got.Version)
+	assert.Equal(t, got.CreatedAt, got.UpdatedAt, "backfilled from the creation time")
+}
+
+func TestRepository_MigrateDown_RevertsAndReapplies(t *testing.T) {
+	repo, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	require.NoError(t, repo.MigrateDown(0))
+
+	version, err := repo.SchemaVersion()
+	require.NoError(t, err)
+	assert.Equal(t, 0, version)
+
+	var tables int
+	require.NoError(t, repo.DB().QueryRow(
+		"SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = 'ideas'",
+	).Scan(&tables))
+	assert.Equal(t, 0, tables, "ideas table should be dropped")
+
+	require.NoError(t, repo.Migrate())
+	createTestIdea(t, repo, "Idea after re-applying migrations")
+
+	assert.Error(t, repo.MigrateDown(-1))
+}
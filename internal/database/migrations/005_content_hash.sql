@@ -0,0 +1,8 @@
+-- 005_content_hash.sql
+-- Add normalized content hash to ideas for duplicate detection
+
+-- content_hash is the SHA-256 of the lowercased, whitespace-collapsed content.
+-- Existing rows are backfilled by the repository after migrations run.
+ALTER TABLE ideas ADD COLUMN content_hash TEXT;
+
+CREATE INDEX IF NOT EXISTS idx_ideas_content_hash ON ideas(content_hash);
@@ -0,0 +1,40 @@
+-- 006_analytics_summary.sql
+-- Materialized analytics summary for dashboards
+
+-- Single-row table holding precomputed statistics. Triggers on ideas mark it
+-- stale (and bump change_count) so readers know to refresh before serving it,
+-- including after changes made by the CLI.
+CREATE TABLE IF NOT EXISTS analytics_summary (
+    id INTEGER PRIMARY KEY CHECK (id = 1),
+    total_ideas INTEGER NOT NULL DEFAULT 0,
+    active_ideas INTEGER NOT NULL DEFAULT 0,
+    average_score REAL NOT NULL DEFAULT 0,
+    median_score REAL NOT NULL DEFAULT 0,
+    high_score REAL NOT NULL DEFAULT 0,
+    low_score REAL NOT NULL DEFAULT 0,
+    score_buckets TEXT NOT NULL DEFAULT '{}',   -- JSON object of bucket -> count
+    top_patterns TEXT NOT NULL DEFAULT '[]',    -- JSON array of {pattern, count}
+    stale INTEGER NOT NULL DEFAULT 1,
+    change_count INTEGER NOT NULL DEFAULT 0,    -- bumped on every idea change
+    refreshed_at TEXT                           -- RFC3339 format (UTC)
+);
+
+INSERT OR IGNORE INTO analytics_summary (id, stale) VALUES (1, 1);
+
+CREATE TRIGGER IF NOT EXISTS trg_ideas_insert_summary_stale
+AFTER INSERT ON ideas
+BEGIN
+    UPDATE analytics_summary SET stale = 1, change_count = change_count + 1;
+END;
+
+CREATE TRIGGER IF NOT EXISTS trg_ideas_update_summary_stale
+AFTER UPDATE ON ideas
+BEGIN
+    UPDATE analytics_summary SET stale = 1, change_count = change_count + 1;
+END;
+
+CREATE TRIGGER IF NOT EXISTS trg_ideas_delete_summary_stale
+AFTER DELETE ON ideas
+BEGIN
+    UPDATE analytics_summary SET stale = 1, change_count = change_count + 1;
+END;
@@ -0,0 +1,6 @@
+-- 007_trigger.sql
+-- Add trigger_context column recording what prompted an idea ("why now")
+
+-- Named trigger_context because TRIGGER is a reserved word in SQLite.
+-- Existing ideas get an empty trigger.
+ALTER TABLE ideas ADD COLUMN trigger_context TEXT NOT NULL DEFAULT '';
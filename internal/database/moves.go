@@ -0,0 +1,82 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ryacub/telos-idea-matrix/internal/models"
+)
+
+// MoveIdea saves idea, whose Profile has been changed to the destination,
+// and records a move from fromProfile. The update and the move record are
+// written in one transaction.
+func (r *Repository) MoveIdea(idea *models.Idea, fromProfile string, reanalyzed bool) (*models.IdeaMove, error) {
+	if idea == nil {
+		return nil, errors.New("idea cannot be nil")
+	}
+
+	move := &models.IdeaMove{
+		IdeaID:      idea.ID,
+		FromProfile: fromProfile,
+		ToProfile:   profileName(idea),
+		Reanalyzed:  reanalyzed,
+		MovedAt:     time.Now().UTC(),
+	}
+	if move.FromProfile == "" {
+		move.FromProfile = models.DefaultProfile
+	}
+	if move.FromProfile == move.ToProfile {
+		return nil, fmt.Errorf("%w: idea is already in profile %s", ErrInvalidInput, move.ToProfile)
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	updatedAt, err := updateIdea(tx, idea)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := tx.Exec(
+		"INSERT INTO idea_moves (idea_id, from_profile, to_profile, reanalyzed, moved_at) VALUES (?, ?, ?, ?, ?)",
+		move.IdeaID, move.FromProfile, move.ToProfile, move.Reanalyzed, move.MovedAt.Format(time.RFC3339),
+	); err != nil {
+		return nil, fmt.Errorf("failed to record move: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit move: %w", err)
+	}
+	markUpdated(idea, updatedAt)
+	return move, nil
+}
+
+// IdeaMoves returns the profile moves recorded for an idea, oldest first
+func (r *Repository) IdeaMoves(ideaID string) ([]*models.IdeaMove, error) {
+	rows, err := r.db.Query(
+		"SELECT idea_id, from_profile, to_profile, reanalyzed, moved_at FROM idea_moves WHERE idea_id = ? ORDER BY id",
+		ideaID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query idea moves: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var moves []*models.IdeaMove
+	for rows.Next() {
+		var move models.IdeaMove
+		var movedAt string
+		if err := rows.Scan(&move.IdeaID, &move.FromProfile, &move.ToProfile, &move.Reanalyzed, &movedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan idea move: %w", err)
+		}
+		if move.MovedAt, err = time.Parse(time.RFC3339, movedAt); err != nil {
+			return nil, fmt.Errorf("failed to parse moved_at: %w", err)
+		}
+		moves = append(moves, &move)
+	}
+	return moves, rows.Err()
+}
@@ -0,0 +1,81 @@
+//go:build integration
+
+package database_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ryacub/telos-idea-matrix/internal/database"
+	"github.com/ryacub/telos-idea-matrix/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_MoveIdea_RecordsMove(t *testing.T) {
+	repo, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	idea := models.NewIdea("Idea filed under the wrong profile")
+	require.NoError(t, repo.Create(idea))
+
+	idea.Profile = "side"
+	move, err := repo.MoveIdea(idea, models.DefaultProfile, false)
+	require.NoError(t, err)
+	assert.Equal(t, "side", move.ToProfile)
+
+	got, err := repo.GetByID(idea.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "side", got.Profile)
+
+	moves, err := repo.IdeaMoves(idea.ID)
+	require.NoError(t, err)
+	require.Len(t, moves, 1)
+	assert.Equal(t, models.DefaultProfile, moves[0].FromProfile)
+	assert.Equal(t, "side", moves[0].ToProfile)
+
+	// Moves are deleted with their idea
+	require.NoError(t, repo.Delete(idea.ID))
+	moves, err = repo.IdeaMoves(idea.ID)
+	require.NoError(t, err)
+	assert.Empty(t, moves)
+}
+
+func TestRepository_MoveIdea_SameProfile(t *testing.T) {
+	repo, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	idea := models.NewIdea("Idea that stays put")
+	require.NoError(t, repo.Create(idea))
+
+	_, err := repo.MoveIdea(idea, models.DefaultProfile, false)
+	assert.True(t, errors.Is(err, database.ErrInvalidInput), "expected ErrInvalidInput, got %v", err)
+
+	moves, err := repo.IdeaMoves(idea.ID)
+	require.NoError(t, err)
+	assert.Empty(t, moves)
+}
+
+func TestRepository_MoveIdea_FailureKeepsVersion(t *testing.T) {
+	repo, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	idea := models.NewIdea("Idea whose move fails")
+	require.NoError(t, repo.Create(idea))
+	version := idea.Version
+
+	// The idea is written, then recording the move fails
+	_, err := repo.DB().Exec(`CREATE TRIGGER fail_move BEFORE INSERT ON idea_moves
+		BEGIN SELECT RAISE(ABORT, 'moves unavailable'); END`)
+	require.NoError(t, err)
+
+	idea.Profile = "side"
+	_, err = repo.MoveIdea(idea, models.DefaultProfile, false)
+	require.Error(t, err)
+	assert.Equal(t, version, idea.Version, "a rolled back move must not advance the version")
+
+	// The idea can still be updated without a spurious ErrStaleVersion
+	idea.Profile = models.DefaultProfile
+	idea.Notes = "after the failed move"
+	require.NoError(t, repo.Update(idea))
+}
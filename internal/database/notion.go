@@ -0,0 +1,44 @@
+package database
+
+import (
+	"fmt"
+	"time"
+)
+
+// SaveNotionPage records that an idea was exported to pageID in the Notion
+// database databaseID, replacing any page recorded for it there before
+func (r *Repository) SaveNotionPage(ideaID, databaseID, pageID string) error {
+	if ideaID == "" || databaseID == "" || pageID == "" {
+		return fmt.Errorf("%w: idea, database and page IDs are required", ErrInvalidInput)
+	}
+
+	_, err := r.db.Exec(
+		`INSERT INTO notion_pages (idea_id, database_id, page_id, synced_at) VALUES (?, ?, ?, ?)
+		ON CONFLICT(idea_id, database_id) DO UPDATE SET page_id = excluded.page_id, synced_at = excluded.synced_at`,
+		ideaID, databaseID, pageID, time.Now().UTC().Format(time.RFC3339),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save notion page: %w", err)
+	}
+	return nil
+}
+
+// NotionPages returns the pages ideas were exported to in the Notion
+// database databaseID, keyed by idea ID
+func (r *Repository) NotionPages(databaseID string) (map[string]string, error) {
+	rows, err := r.db.Query("SELECT idea_id, page_id FROM notion_pages WHERE database_id = ?", databaseID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query notion pages: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	pages := make(map[string]string)
+	for rows.Next() {
+		var ideaID, pageID string
+		if err := rows.Scan(&ideaID, &pageID); err != nil {
+			return nil, fmt.Errorf("failed to scan notion page: %w", err)
+		}
+		pages[ideaID] = pageID
+	}
+	return pages, rows.Err()
+}
@@ -0,0 +1,38 @@
+//go:build integration
+
+package database_test
+
+import (
+	"testing"
+
+	"github.com/ryacub/telos-idea-matrix/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_NotionPages_RoundTrip(t *testing.T) {
+	repo, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	idea := models.NewIdea("Write a book about sourdough")
+	require.NoError(t, repo.Create(idea))
+
+	require.NoError(t, repo.SaveNotionPage(idea.ID, "db-1", "page-1"))
+
+	pages, err := repo.NotionPages("db-1")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{idea.ID: "page-1"}, pages)
+
+	// Pages are tracked per Notion database
+	pages, err = repo.NotionPages("db-2")
+	require.NoError(t, err)
+	assert.Empty(t, pages)
+
+	// Saving again replaces the page
+	require.NoError(t, repo.SaveNotionPage(idea.ID, "db-1", "page-2"))
+	pages, err = repo.NotionPages("db-1")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{idea.ID: "page-2"}, pages)
+
+	assert.Error(t, repo.SaveNotionPage(idea.ID, "db-1", ""))
+}
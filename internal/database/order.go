@@ -0,0 +1,87 @@
+package database
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SortField is a column ideas can be listed in order of
+type SortField string
+
+// Sort fields accepted by ListOptions.OrderBy
+const (
+	SortByFinalScore SortField = "final_score"
+	SortByCreatedAt  SortField = "created_at"
+	SortByRawScore   SortField = "raw_score"
+)
+
+// SortDirection is the direction of a sort
+type SortDirection string
+
+// Sort directions accepted by ListOptions.OrderBy
+const (
+	Ascending  SortDirection = "ASC"
+	Descending SortDirection = "DESC"
+)
+
+// sortFields is the whitelist of columns that may appear in ORDER BY
+var sortFields = map[SortField]bool{
+	SortByFinalScore: true,
+	SortByCreatedAt:  true,
+	SortByRawScore:   true,
+}
+
+// Order is how List sorts ideas. ORDER BY can't use bound parameters, so only
+// whitelisted fields and directions are ever written into the query; anything
+// else makes List fail. The zero value sorts newest first.
+type Order struct {
+	Field     SortField
+	Direction SortDirection
+}
+
+// OrderBy returns an order on field in direction
+func OrderBy(field SortField, direction SortDirection) Order {
+	return Order{Field: field, Direction: direction}
+}
+
+// ParseOrder builds an Order from untrusted input such as query parameters.
+// Field must be one of final_score, created_at or raw_score; direction is
+// "asc" or "desc" in any case, or empty for ascending.
+func ParseOrder(field, direction string) (Order, error) {
+	order := Order{Field: SortField(strings.ToLower(strings.TrimSpace(field)))}
+	if !sortFields[order.Field] {
+		return Order{}, fmt.Errorf("invalid sort field %q (use final_score, created_at or raw_score)", field)
+	}
+
+	switch SortDirection(strings.ToUpper(strings.TrimSpace(direction))) {
+	case "", Ascending:
+		order.Direction = Ascending
+	case Descending:
+		order.Direction = Descending
+	default:
+		return Order{}, fmt.Errorf("invalid sort direction %q (use asc or desc)", direction)
+	}
+	return order, nil
+}
+
+// String returns the order as it appears in SQL, e.g. "final_score DESC"
+func (o Order) String() string {
+	if o == (Order{}) {
+		return "created_at DESC"
+	}
+	return string(o.Field) + " " + string(o.Direction)
+}
+
+// clause validates the order and returns it for use after ORDER BY
+func (o Order) clause() (string, error) {
+	if o == (Order{}) {
+		return o.String(), nil
+	}
+	if !sortFields[o.Field] {
+		return "", fmt.Errorf("invalid sort field %q", o.Field)
+	}
+	if o.Direction != Ascending && o.Direction != Descending {
+		return "", fmt.Errorf("invalid sort direction %q", o.Direction)
+	}
+	return o.String(), nil
+}
@@ -2,8 +2,9 @@
 package database
 
 import (
+	"context"
 	"database/sql"
-	"embed"
+	"database/sql/driver"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -12,14 +13,12 @@ import (
 	"strings"
 	"time"
 
-	_ "github.com/mattn/go-sqlite3"
+	"github.com/mattn/go-sqlite3"
 	"github.com/rs/zerolog/log"
+	"github.com/ryacub/telos-idea-matrix/internal/config"
 	"github.com/ryacub/telos-idea-matrix/internal/models"
 )
 
-//go:embed migrations/*.sql
-var migrationsFS embed.FS
-
 // nullJSON represents a JSON null value from the database
 const nullJSON = "null"
 
@@ -30,47 +29,36 @@ type Repository struct {
 
 // ListOptions defines options for listing ideas.
 type ListOptions struct {
-	Status   string   // Filter by status (e.g., "active", "archived")
-	MinScore *float64 // Filter by minimum score
-	MaxScore *float64 // Filter by maximum score
-	OrderBy  string   // Order by clause (e.g., "final_score DESC")
-	Limit    *int     // Limit number of results
-	Offset   *int     // Offset for pagination
+	Status         string                        // Filter by status (e.g., "active", "archived"); empty lists every status except "deleted"
+	Profile        string                        // Filter by telos profile the idea was scored against
+	MinScore       *float64                      // Filter by minimum score
+	MaxScore       *float64                      // Filter by maximum score
+	CreatedAfter   *time.Time                    // Filter by creation time (inclusive)
+	CreatedBefore  *time.Time                    // Filter by creation time (exclusive)
+	UpdatedSince   *time.Time                    // Filter by when the idea was created or last updated (inclusive)
+	Pattern        string                        // Filter by detected pattern name (case-insensitive)
+	Recommendation models.RecommendationCategory // Filter by recommendation category
+	Tag            string                        // Filter by tag (case-insensitive)
+	Contains       string                        // Filter by substring of content (case-insensitive)
+	OrderBy        Order                         // Sort order; the zero value lists newest first
+	Limit          *int                          // Limit number of results
+	Offset         *int                          // Offset for pagination
 }
 
-// validOrderByColumns defines the whitelist of allowed ORDER BY columns
-var validOrderByColumns = map[string]bool{
-	"id":               true,
-	"content":          true,
-	"raw_score":        true,
-	"final_score":      true,
-	"created_at":       true,
-	"reviewed_at":      true,
-	"status":           true,
-	"final_score DESC": true,
-	"final_score ASC":  true,
-	"created_at DESC":  true,
-	"created_at ASC":   true,
-	"raw_score DESC":   true,
-	"raw_score ASC":    true,
+// NewRepository creates a new database repository with the default
+// connection settings and runs migrations.
+func NewRepository(dbPath string) (*Repository, error) {
+	return NewRepositoryWithConfig(config.DefaultDatabaseConfig(dbPath))
 }
 
-// validateOrderBy validates and sanitizes the ORDER BY clause against a whitelist
-func validateOrderBy(orderBy string) (string, error) {
-	if orderBy == "" {
-		return "", nil
-	}
-
-	// Check if the exact string is in the whitelist
-	if validOrderByColumns[orderBy] {
-		return orderBy, nil
+// NewRepositoryWithConfig creates a new database repository using cfg's
+// connection pool, busy timeout and WAL autocheckpoint, and runs migrations.
+func NewRepositoryWithConfig(cfg config.DatabaseConfig) (*Repository, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
 	}
+	dbPath := cfg.Path
 
-	return "", fmt.Errorf("invalid ORDER BY clause: %s", orderBy)
-}
-
-// NewRepository creates a new database repository and runs migrations.
-func NewRepository(dbPath string) (*Repository, error) {
 	// Create directory if it doesn't exist
 	dir := filepath.Dir(dbPath)
 	if dir != "" && dir != "." {
@@ -80,19 +68,26 @@ func NewRepository(dbPath string) (*Repository, error) {
 	}
 
 	// Enable WAL mode and other optimizations via connection string
-	dsn := dbPath + "?_journal_mode=WAL&_synchronous=NORMAL&_busy_timeout=5000"
-
-	// Open database connection
-	db, err := sql.Open("sqlite3", dsn)
-	if err != nil {
-		return nil, fmt.Errorf("failed to open database: %w", err)
-	}
+	busyTimeout := cfg.BusyTimeout.Milliseconds()
+	dsn := fmt.Sprintf("%s?_journal_mode=WAL&_synchronous=NORMAL&_busy_timeout=%d", dbPath, busyTimeout)
+
+	// Open database connection. Pragmas without a DSN parameter only last
+	// for the connection they run on, so every pooled connection sets them.
+	db := sql.OpenDB(&connector{dsn: dsn, driver: &sqlite3.SQLiteDriver{
+		ConnectHook: func(conn *sqlite3.SQLiteConn) error {
+			return execPragmas(conn, []string{
+				fmt.Sprintf("PRAGMA wal_autocheckpoint = %d", cfg.WALAutoCheckpoint),
+				"PRAGMA cache_size = -64000", // 64MB cache
+				"PRAGMA temp_store = MEMORY", // Keep temp tables in memory
+				"PRAGMA foreign_keys = ON",   // Enable foreign keys
+			})
+		},
+	}})
 
 	// Configure connection pooling
-	// For single-user local usage, conservative settings are appropriate
-	db.SetMaxOpenConns(5)                  // Max 5 concurrent connections
-	db.SetMaxIdleConns(2)                  // Keep 2 connections ready for reuse
-	db.SetConnMaxLifetime(5 * time.Minute) // Refresh connections every 5 minutes
+	db.SetMaxOpenConns(cfg.MaxOpenConns)
+	db.SetMaxIdleConns(cfg.MaxIdleConns)
+	db.SetConnMaxLifetime(cfg.ConnMaxLifetime)
 
 	// Test connection
 	if err := db.Ping(); err != nil {
@@ -105,10 +100,7 @@ func NewRepository(dbPath string) (*Repository, error) {
 	pragmas := []string{
 		"PRAGMA journal_mode = WAL",
 		"PRAGMA synchronous = NORMAL",
-		"PRAGMA busy_timeout = 5000",
-		"PRAGMA cache_size = -64000", // 64MB cache
-		"PRAGMA temp_store = MEMORY", // Keep temp tables in memory
-		"PRAGMA foreign_keys = ON",   // Enable foreign keys
+		fmt.Sprintf("PRAGMA busy_timeout = %d", busyTimeout),
 	}
 
 	for _, pragma := range pragmas {
@@ -120,43 +112,75 @@ func NewRepository(dbPath string) (*Repository, error) {
 
 	repo := &Repository{db: db}
 
-	// Run migrations
-	if err := repo.runMigrations(); err != nil {
+	// Apply pending schema migrations
+	if err := repo.Migrate(); err != nil {
 		_ = db.Close()
 		return nil, fmt.Errorf("failed to run migrations: %w", err)
 	}
 
+	// Populate content hashes for ideas created before the column existed
+	if err := repo.backfillContentHashes(); err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("failed to backfill content hashes: %w", err)
+	}
+
 	return repo, nil
 }
 
-// runMigrations applies all migration files.
-func (r *Repository) runMigrations() error {
-	// Read all migration files
-	entries, err := migrationsFS.ReadDir("migrations")
-	if err != nil {
-		return fmt.Errorf("failed to read migrations directory: %w", err)
-	}
+// connector opens connections to dsn through driver, whose ConnectHook
+// configures each one
+type connector struct {
+	dsn    string
+	driver *sqlite3.SQLiteDriver
+}
 
-	for _, entry := range entries {
-		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".sql") {
-			continue
+// Connect opens a new connection
+func (c *connector) Connect(context.Context) (driver.Conn, error) {
+	return c.driver.Open(c.dsn)
+}
+
+// Driver returns the underlying SQLite driver
+func (c *connector) Driver() driver.Driver {
+	return c.driver
+}
+
+// execPragmas runs pragmas on a single connection
+func execPragmas(conn *sqlite3.SQLiteConn, pragmas []string) error {
+	for _, pragma := range pragmas {
+		if _, err := conn.Exec(pragma, nil); err != nil {
+			return fmt.Errorf("failed to execute %s: %w", pragma, err)
 		}
+	}
+	return nil
+}
 
-		// Read migration file
-		content, err := migrationsFS.ReadFile("migrations/" + entry.Name())
-		if err != nil {
-			return fmt.Errorf("failed to read migration %s: %w", entry.Name(), err)
+// backfillContentHashes computes content_hash for rows where it is missing.
+func (r *Repository) backfillContentHashes() error {
+	rows, err := r.db.Query("SELECT id, content FROM ideas WHERE content_hash IS NULL")
+	if err != nil {
+		return fmt.Errorf("failed to query ideas without hash: %w", err)
+	}
+
+	hashes := make(map[string]string)
+	for rows.Next() {
+		var id, content string
+		if err := rows.Scan(&id, &content); err != nil {
+			_ = rows.Close()
+			return fmt.Errorf("failed to scan idea: %w", err)
 		}
+		hashes[id] = models.ContentHash(content)
+	}
+	if err := rows.Err(); err != nil {
+		_ = rows.Close()
+		return fmt.Errorf("error iterating rows: %w", err)
+	}
+	if err := rows.Close(); err != nil {
+		return fmt.Errorf("failed to close rows: %w", err)
+	}
 
-		// Execute migration
-		if _, err := r.db.Exec(string(content)); err != nil {
-			// For idempotency: ignore "duplicate column" errors from ALTER TABLE ADD COLUMN
-			// This allows migrations to be run multiple times safely (e.g., in tests)
-			if strings.Contains(err.Error(), "duplicate column name") {
-				// Migration already applied, skip silently
-				continue
-			}
-			return fmt.Errorf("failed to execute migration %s: %w", entry.Name(), err)
+	for id, hash := range hashes {
+		if _, err := r.db.Exec("UPDATE ideas SET content_hash = ? WHERE id = ?", hash, id); err != nil {
+			return fmt.Errorf("failed to update content hash for %s: %w", id, err)
 		}
 	}
 
@@ -197,10 +221,16 @@ func (r *Repository) Create(idea *models.Idea) error {
 	query := `
 		INSERT INTO ideas (
 			id, content, raw_score, final_score, patterns, tags,
-			recommendation, analysis_details, created_at, reviewed_at, status
-		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+			recommendation, analysis_details, created_at, reviewed_at, status,
+			content_hash, trigger_context, archive_reason, profile, telos_version,
+			recommendation_category, notes, version, updated_at
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, 1, ?)
 	`
 
+	// Stamped with the insert time, not CreatedAt, so ideas imported with an
+	// older creation date still count as changed since the last export
+	updatedAt := time.Now().UTC()
+
 	_, err = r.db.Exec(
 		query,
 		idea.ID,
@@ -214,11 +244,21 @@ func (r *Repository) Create(idea *models.Idea) error {
 		createdAt,
 		reviewedAt,
 		idea.Status,
+		models.ContentHash(idea.Content),
+		idea.Trigger,
+		archiveReason(idea),
+		profileName(idea),
+		idea.TelosVersion,
+		string(models.ParseRecommendation(idea.Recommendation)),
+		idea.Notes,
+		updatedAt.Format(time.RFC3339Nano),
 	)
 
 	if err != nil {
 		return fmt.Errorf("failed to insert idea: %w", err)
 	}
+	idea.Version = 1
+	idea.UpdatedAt = updatedAt
 
 	return nil
 }
@@ -231,7 +271,9 @@ func (r *Repository) GetByID(id string) (*models.Idea, error) {
 
 	query := `
 		SELECT id, content, raw_score, final_score, patterns, tags,
-		       recommendation, analysis_details, created_at, reviewed_at, status
+		       recommendation, analysis_details, created_at, reviewed_at, status,
+		       trigger_context, archive_reason, profile, telos_version, notes, version,
+		       updated_at
 		FROM ideas
 		WHERE id = ?
 	`
@@ -241,6 +283,7 @@ func (r *Repository) GetByID(id string) (*models.Idea, error) {
 	var tagsJSON string
 	var createdAt string
 	var reviewedAt sql.NullString
+	var updatedAt string
 
 	err := r.db.QueryRow(query, id).Scan(
 		&idea.ID,
@@ -254,6 +297,13 @@ func (r *Repository) GetByID(id string) (*models.Idea, error) {
 		&createdAt,
 		&reviewedAt,
 		&idea.Status,
+		&idea.Trigger,
+		&idea.ArchiveReason,
+		&idea.Profile,
+		&idea.TelosVersion,
+		&idea.Notes,
+		&idea.Version,
+		&updatedAt,
 	)
 
 	if err == sql.ErrNoRows {
@@ -294,6 +344,14 @@ func (r *Repository) GetByID(id string) (*models.Idea, error) {
 		idea.ReviewedAt = &parsedTime
 	}
 
+	if updatedAt != "" {
+		parsedTime, err := time.Parse(time.RFC3339Nano, updatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("corrupted updated_at timestamp in database: %w", err)
+		}
+		idea.UpdatedAt = parsedTime
+	}
+
 	return &idea, nil
 }
 
@@ -305,7 +363,9 @@ func (r *Repository) GetByPartialID(partialID string) (*models.Idea, error) {
 
 	query := `
 		SELECT id, content, raw_score, final_score, patterns, tags,
-		       recommendation, analysis_details, created_at, reviewed_at, status
+		       recommendation, analysis_details, created_at, reviewed_at, status,
+		       trigger_context, archive_reason, profile, telos_version, notes, version,
+		       updated_at
 		FROM ideas
 		WHERE id LIKE ?
 		LIMIT 1
@@ -316,6 +376,7 @@ func (r *Repository) GetByPartialID(partialID string) (*models.Idea, error) {
 	var tagsJSON string
 	var createdAt string
 	var reviewedAt sql.NullString
+	var updatedAt string
 
 	err := r.db.QueryRow(query, partialID+"%").Scan(
 		&idea.ID,
@@ -329,6 +390,13 @@ func (r *Repository) GetByPartialID(partialID string) (*models.Idea, error) {
 		&createdAt,
 		&reviewedAt,
 		&idea.Status,
+		&idea.Trigger,
+		&idea.ArchiveReason,
+		&idea.Profile,
+		&idea.TelosVersion,
+		&idea.Notes,
+		&idea.Version,
+		&updatedAt,
 	)
 
 	if err == sql.ErrNoRows {
@@ -365,30 +433,97 @@ func (r *Repository) GetByPartialID(partialID string) (*models.Idea, error) {
 		}
 	}
 
+	if updatedAt != "" {
+		if parsedTime, err := time.Parse(time.RFC3339Nano, updatedAt); err == nil {
+			idea.UpdatedAt = parsedTime
+		}
+	}
+
 	return &idea, nil
 }
 
-// Update updates an existing idea in the database.
+// FindByContentHash retrieves the oldest idea whose normalized content hash matches.
+// Use models.ContentHash to compute the hash for a given content string. Ideas
+// in the trash are ignored, so they don't hide a duplicate being imported.
+func (r *Repository) FindByContentHash(hash string) (*models.Idea, error) {
+	if hash == "" {
+		return nil, errors.New("hash cannot be empty")
+	}
+
+	query := `
+		SELECT id, content, raw_score, final_score, patterns, tags,
+		       recommendation, analysis_details, created_at, reviewed_at, status,
+		       trigger_context, archive_reason, profile, telos_version, notes, version,
+		       updated_at
+		FROM ideas
+		WHERE content_hash = ? AND status != 'deleted'
+		ORDER BY created_at ASC
+		LIMIT 1
+	`
+
+	rows, err := r.db.Query(query, hash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query idea by hash: %w", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			log.Warn().Err(err).Msg("failed to close rows")
+		}
+	}()
+
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return nil, fmt.Errorf("error iterating rows: %w", err)
+		}
+		return nil, fmt.Errorf("%w: content hash %s", ErrNotFound, hash)
+	}
+
+	return scanIdeaRow(rows)
+}
+
+// Update updates an existing idea in the database. The update only applies
+// if the stored version still matches idea.Version, i.e. nobody else updated
+// the idea since it was read; otherwise it returns ErrStaleVersion. On
+// success idea.Version is advanced to the stored version.
 func (r *Repository) Update(idea *models.Idea) error {
+	updatedAt, err := updateIdea(r.db, idea)
+	if err != nil {
+		return err
+	}
+	markUpdated(idea, updatedAt)
+	return nil
+}
+
+// execer runs statements on a database or within a transaction
+type execer interface {
+	Exec(query string, args ...interface{}) (sql.Result, error)
+	QueryRow(query string, args ...interface{}) *sql.Row
+}
+
+// updateIdea writes every stored field of idea through db, checking and
+// advancing the stored version, and returns the update time. idea itself is
+// left alone: once the write is committed, pass it to markUpdated, so a
+// rolled back transaction doesn't leave idea ahead of the stored version.
+func updateIdea(db execer, idea *models.Idea) (time.Time, error) {
 	if idea == nil {
-		return errors.New("idea cannot be nil")
+		return time.Time{}, errors.New("idea cannot be nil")
 	}
 
 	// Validate idea
 	if err := idea.Validate(); err != nil {
-		return fmt.Errorf("invalid idea: %w", err)
+		return time.Time{}, fmt.Errorf("invalid idea: %w", err)
 	}
 
 	// Serialize patterns to JSON
 	patternsJSON, err := json.Marshal(idea.Patterns)
 	if err != nil {
-		return fmt.Errorf("failed to serialize patterns: %w", err)
+		return time.Time{}, fmt.Errorf("failed to serialize patterns: %w", err)
 	}
 
 	// Serialize tags to JSON
 	tagsJSON, err := json.Marshal(idea.Tags)
 	if err != nil {
-		return fmt.Errorf("failed to serialize tags: %w", err)
+		return time.Time{}, fmt.Errorf("failed to serialize tags: %w", err)
 	}
 
 	// Format timestamps
@@ -401,11 +536,16 @@ func (r *Repository) Update(idea *models.Idea) error {
 	query := `
 		UPDATE ideas
 		SET content = ?, raw_score = ?, final_score = ?, patterns = ?, tags = ?,
-		    recommendation = ?, analysis_details = ?, reviewed_at = ?, status = ?
-		WHERE id = ?
+		    recommendation = ?, analysis_details = ?, reviewed_at = ?, status = ?,
+		    content_hash = ?, trigger_context = ?, archive_reason = ?, profile = ?,
+		    telos_version = ?, recommendation_category = ?, notes = ?, version = version + 1,
+		    updated_at = ?
+		WHERE id = ? AND version = ?
 	`
 
-	result, err := r.db.Exec(
+	updatedAt := time.Now().UTC()
+
+	result, err := db.Exec(
 		query,
 		idea.Content,
 		idea.RawScore,
@@ -416,24 +556,142 @@ func (r *Repository) Update(idea *models.Idea) error {
 		idea.AnalysisDetails,
 		reviewedAt,
 		idea.Status,
+		models.ContentHash(idea.Content),
+		idea.Trigger,
+		archiveReason(idea),
+		profileName(idea),
+		idea.TelosVersion,
+		string(models.ParseRecommendation(idea.Recommendation)),
+		idea.Notes,
+		updatedAt.Format(time.RFC3339Nano),
 		idea.ID,
+		idea.Version,
 	)
 
 	if err != nil {
-		return fmt.Errorf("failed to update idea: %w", err)
+		return time.Time{}, fmt.Errorf("failed to update idea: %w", err)
 	}
 
 	// Check if any rows were affected
 	rowsAffected, err := result.RowsAffected()
 	if err != nil {
-		return fmt.Errorf("failed to get rows affected: %w", err)
+		return time.Time{}, fmt.Errorf("failed to get rows affected: %w", err)
 	}
 
 	if rowsAffected == 0 {
-		return fmt.Errorf("%w: %s", ErrNotFound, idea.ID)
+		// Tell a missing idea from one updated since it was read
+		var stored int
+		err := db.QueryRow("SELECT version FROM ideas WHERE id = ?", idea.ID).Scan(&stored)
+		if err == sql.ErrNoRows {
+			return time.Time{}, fmt.Errorf("%w: %s", ErrNotFound, idea.ID)
+		}
+		if err != nil {
+			return time.Time{}, fmt.Errorf("failed to check idea version: %w", err)
+		}
+		return time.Time{}, fmt.Errorf("%w: idea %s is at version %d, not %d", ErrStaleVersion, idea.ID, stored, idea.Version)
 	}
 
-	return nil
+	return updatedAt, nil
+}
+
+// markUpdated brings idea in line with a committed updateIdea that returned
+// updatedAt
+func markUpdated(idea *models.Idea, updatedAt time.Time) {
+	idea.Version++
+	idea.UpdatedAt = updatedAt
+}
+
+// archiveReason returns the reason to store for an idea. A reason only
+// describes the current archival, so it is dropped once an idea leaves
+// the archived status.
+func archiveReason(idea *models.Idea) string {
+	if idea.Status != string(models.StatusArchived) {
+		return ""
+	}
+	return idea.ArchiveReason
+}
+
+// profileName returns the telos profile to store for an idea
+func profileName(idea *models.Idea) string {
+	if idea.Profile == "" {
+		return models.DefaultProfile
+	}
+	return idea.Profile
+}
+
+// BackfillTelosVersion sets version on every idea that has no telos version
+// recorded and returns how many ideas were updated. Ideas that already have a
+// version are left alone; updated ones are stamped as any write is.
+func (r *Repository) BackfillTelosVersion(version string) (int64, error) {
+	if version == "" {
+		return 0, errors.New("version cannot be empty")
+	}
+
+	result, err := r.db.Exec(
+		"UPDATE ideas SET telos_version = ?, updated_at = ?, version = version + 1 WHERE telos_version = ''",
+		version, time.Now().UTC().Format(time.RFC3339Nano),
+	)
+	if err != nil {
+		return 0, fmt.Errorf("failed to backfill telos versions: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	return rowsAffected, nil
+}
+
+// RepairAnalysisDetails wraps analysis details that are plain text rather
+// than JSON, as older versions saved them, into the minimal JSON object of
+// models.WrapAnalysisText. It returns how many ideas were repaired, or with
+// dryRun how many would be, leaving the database untouched. The text itself
+// is kept, and repaired ideas are stamped as any write is.
+func (r *Repository) RepairAnalysisDetails(dryRun bool) (int64, error) {
+	rows, err := r.db.Query("SELECT id, analysis_details FROM ideas WHERE analysis_details IS NOT NULL AND analysis_details != ''")
+	if err != nil {
+		return 0, fmt.Errorf("failed to query analysis details: %w", err)
+	}
+
+	repaired := make(map[string]string)
+	for rows.Next() {
+		var idea models.Idea
+		if err := rows.Scan(&idea.ID, &idea.AnalysisDetails); err != nil {
+			_ = rows.Close()
+			return 0, fmt.Errorf("failed to scan idea: %w", err)
+		}
+		if idea.HasPlainTextAnalysis() {
+			repaired[idea.ID] = models.WrapAnalysisText(idea.AnalysisDetails)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		_ = rows.Close()
+		return 0, fmt.Errorf("error iterating rows: %w", err)
+	}
+	if err := rows.Close(); err != nil {
+		return 0, fmt.Errorf("failed to close rows: %w", err)
+	}
+
+	if dryRun || len(repaired) == 0 {
+		return int64(len(repaired)), nil
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	updatedAt := time.Now().UTC().Format(time.RFC3339Nano)
+	for id, details := range repaired {
+		if _, err := tx.Exec("UPDATE ideas SET analysis_details = ?, updated_at = ?, version = version + 1 WHERE id = ?", details, updatedAt, id); err != nil {
+			return 0, fmt.Errorf("failed to repair analysis details for %s: %w", id, err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit repair: %w", err)
+	}
+	return int64(len(repaired)), nil
 }
 
 // Delete deletes an idea from the database.
@@ -462,6 +720,28 @@ func (r *Repository) Delete(id string) error {
 	return nil
 }
 
+// EmptyTrash permanently deletes every idea with status "deleted" in profile,
+// or in every profile when profile is empty, and returns how many were removed
+func (r *Repository) EmptyTrash(profile string) (int64, error) {
+	query := "DELETE FROM ideas WHERE status = ?"
+	args := []interface{}{string(models.StatusDeleted)}
+	if profile != "" {
+		query += " AND profile = ?"
+		args = append(args, profile)
+	}
+
+	result, err := r.db.Exec(query, args...)
+	if err != nil {
+		return 0, fmt.Errorf("failed to empty trash: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	return rowsAffected, nil
+}
+
 // scanIdeaRow scans a single database row into an Idea struct
 func scanIdeaRow(rows *sql.Rows) (*models.Idea, error) {
 	var idea models.Idea
@@ -469,6 +749,7 @@ func scanIdeaRow(rows *sql.Rows) (*models.Idea, error) {
 	var tagsJSON string
 	var createdAt string
 	var reviewedAt sql.NullString
+	var updatedAt string
 
 	err := rows.Scan(
 		&idea.ID,
@@ -482,6 +763,13 @@ func scanIdeaRow(rows *sql.Rows) (*models.Idea, error) {
 		&createdAt,
 		&reviewedAt,
 		&idea.Status,
+		&idea.Trigger,
+		&idea.ArchiveReason,
+		&idea.Profile,
+		&idea.TelosVersion,
+		&idea.Notes,
+		&idea.Version,
+		&updatedAt,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to scan row: %w", err)
@@ -518,23 +806,160 @@ func scanIdeaRow(rows *sql.Rows) (*models.Idea, error) {
 		idea.ReviewedAt = &parsedTime
 	}
 
+	if updatedAt != "" {
+		parsedTime, err := time.Parse(time.RFC3339Nano, updatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("corrupted updated_at timestamp in database: %w", err)
+		}
+		idea.UpdatedAt = parsedTime
+	}
+
 	return &idea, nil
 }
 
 // List retrieves ideas based on the provided options.
 func (r *Repository) List(options ListOptions) ([]*models.Idea, error) {
+	query, args, err := listQuery(options)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query ideas: %w", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			log.Warn().Err(err).Msg("failed to close rows")
+		}
+	}()
+
+	var ideas []*models.Idea
+
+	for rows.Next() {
+		idea, err := scanIdeaRow(rows)
+		if err != nil {
+			return nil, err
+		}
+		ideas = append(ideas, idea)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return ideas, nil
+}
+
+// Stream runs the same query as List but sends each idea on the returned
+// channel as its row is read, so large result sets are never held in memory
+// at once. The channel is closed after the last row. The query keeps a
+// database connection open until then, so callers must read the channel to
+// the end. A row that fails to scan, or a failed read, ends the stream early;
+// once the channel is closed, the returned func reports that error, so a
+// truncated stream can be told from a complete one.
+func (r *Repository) Stream(options ListOptions) (<-chan *models.Idea, func() error, error) {
+	query, args, err := listQuery(options)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to query ideas: %w", err)
+	}
+
+	ideas := make(chan *models.Idea)
+	var streamErr error
+	go func() {
+		defer close(ideas)
+		defer func() {
+			if err := rows.Close(); err != nil {
+				log.Warn().Err(err).Msg("failed to close rows")
+			}
+		}()
+
+		for rows.Next() {
+			idea, err := scanIdeaRow(rows)
+			if err != nil {
+				streamErr = err
+				return
+			}
+			ideas <- idea
+		}
+
+		if err := rows.Err(); err != nil {
+			streamErr = fmt.Errorf("error iterating rows: %w", err)
+		}
+	}()
+
+	return ideas, func() error { return streamErr }, nil
+}
+
+// listQuery builds the SELECT statement shared by List and Stream
+func listQuery(options ListOptions) (string, []interface{}, error) {
+	where, args := listFilters(options)
 	query := `
 		SELECT id, content, raw_score, final_score, patterns, tags,
-		       recommendation, analysis_details, created_at, reviewed_at, status
+		       recommendation, analysis_details, created_at, reviewed_at, status,
+		       trigger_context, archive_reason, profile, telos_version, notes, version,
+		       updated_at
 		FROM ideas
 		WHERE 1=1
-	`
+	` + where
+
+	// The order is checked against a whitelist since it can't be a bound parameter
+	orderBy, err := options.OrderBy.clause()
+	if err != nil {
+		return "", nil, err
+	}
+	query += " ORDER BY " + orderBy
+
+	// Add limit and offset. SQLite only accepts OFFSET after LIMIT, where -1 means no limit.
+	if options.Limit != nil {
+		query += " LIMIT ?"
+		args = append(args, *options.Limit)
+	} else if options.Offset != nil {
+		query += " LIMIT -1"
+	}
+
+	if options.Offset != nil {
+		query += " OFFSET ?"
+		args = append(args, *options.Offset)
+	}
+
+	return query, args, nil
+}
+
+// Count returns how many ideas match the filters in options.
+// OrderBy, Limit and Offset are ignored.
+func (r *Repository) Count(options ListOptions) (int, error) {
+	where, args := listFilters(options)
+
+	var count int
+	if err := r.db.QueryRow("SELECT COUNT(*) FROM ideas WHERE 1=1"+where, args...).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count ideas: %w", err)
+	}
+	return count, nil
+}
+
+// listFilters builds the WHERE conditions shared by List, Stream and Count
+func listFilters(options ListOptions) (string, []interface{}) {
+	query := ""
 	args := []interface{}{}
 
-	// Add filters
+	// Ideas in the trash are only listed when asked for by status
 	if options.Status != "" {
 		query += " AND status = ?"
 		args = append(args, options.Status)
+	} else {
+		query += " AND status != ?"
+		args = append(args, string(models.StatusDeleted))
+	}
+
+	if options.Profile != "" {
+		query += " AND profile = ?"
+		args = append(args, options.Profile)
 	}
 
 	if options.MinScore != nil {
@@ -547,53 +972,56 @@ func (r *Repository) List(options ListOptions) ([]*models.Idea, error) {
 		args = append(args, *options.MaxScore)
 	}
 
-	// Add ordering with validation to prevent SQL injection
-	if options.OrderBy != "" {
-		validatedOrderBy, err := validateOrderBy(options.OrderBy)
-		if err != nil {
-			return nil, fmt.Errorf("invalid order by clause: %w", err)
-		}
-		query += " ORDER BY " + validatedOrderBy
-	} else {
-		query += " ORDER BY created_at DESC"
+	// julianday keeps fractional seconds, so bounds match time.Time comparisons
+	if options.CreatedAfter != nil {
+		query += " AND julianday(created_at) >= julianday(?)"
+		args = append(args, options.CreatedAfter.UTC().Format(time.RFC3339Nano))
 	}
 
-	// Add limit and offset
-	if options.Limit != nil {
-		query += " LIMIT ?"
-		args = append(args, *options.Limit)
+	if options.CreatedBefore != nil {
+		query += " AND julianday(created_at) < julianday(?)"
+		args = append(args, options.CreatedBefore.UTC().Format(time.RFC3339Nano))
 	}
 
-	if options.Offset != nil {
-		query += " OFFSET ?"
-		args = append(args, *options.Offset)
+	if options.UpdatedSince != nil {
+		query += " AND julianday(updated_at) >= julianday(?)"
+		args = append(args, options.UpdatedSince.UTC().Format(time.RFC3339Nano))
 	}
 
-	rows, err := r.db.Query(query, args...)
-	if err != nil {
-		return nil, fmt.Errorf("failed to query ideas: %w", err)
+	// Patterns are stored as "Name" or "Name: description" in a JSON array
+	if options.Pattern != "" {
+		query += ` AND EXISTS (
+			SELECT 1 FROM json_each(CASE WHEN json_valid(ideas.patterns) THEN ideas.patterns ELSE '[]' END)
+			WHERE LOWER(json_each.value) = LOWER(?)
+			   OR LOWER(json_each.value) LIKE LOWER(?) ESCAPE '\'
+		)`
+		args = append(args, options.Pattern, escapeLike(options.Pattern)+":%")
 	}
-	defer func() {
-		if err := rows.Close(); err != nil {
-			log.Warn().Err(err).Msg("failed to close rows")
-		}
-	}()
 
-	var ideas []*models.Idea
+	if options.Recommendation != models.CategoryUnknown {
+		query += " AND recommendation_category = ?"
+		args = append(args, string(options.Recommendation))
+	}
 
-	for rows.Next() {
-		idea, err := scanIdeaRow(rows)
-		if err != nil {
-			return nil, err
-		}
-		ideas = append(ideas, idea)
+	if options.Tag != "" {
+		query += ` AND EXISTS (
+			SELECT 1 FROM json_each(CASE WHEN json_valid(ideas.tags) THEN ideas.tags ELSE '[]' END)
+			WHERE LOWER(json_each.value) = LOWER(?)
+		)`
+		args = append(args, options.Tag)
 	}
 
-	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("error iterating rows: %w", err)
+	if options.Contains != "" {
+		query += ` AND content LIKE ? ESCAPE '\'`
+		args = append(args, "%"+escapeLike(options.Contains)+"%")
 	}
 
-	return ideas, nil
+	return query, args
+}
+
+// escapeLike escapes LIKE wildcards so user input matches literally (use with ESCAPE '\').
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s)
 }
 
 // DB returns the underlying database connection for health checks and other purposes.
@@ -800,7 +1228,9 @@ func (r *Repository) GetRelatedIdeas(ideaID string, relType *models.Relationship
 
 	baseQuery := `
 		SELECT DISTINCT i.id, i.content, i.raw_score, i.final_score, i.patterns, i.tags,
-		       i.recommendation, i.analysis_details, i.created_at, i.reviewed_at, i.status
+		       i.recommendation, i.analysis_details, i.created_at, i.reviewed_at, i.status,
+		       i.trigger_context, i.archive_reason, i.profile, i.telos_version, i.notes, i.version,
+		       i.updated_at
 		FROM ideas i
 		INNER JOIN idea_relationships r ON (i.id = r.target_idea_id OR i.id = r.source_idea_id)
 		WHERE (r.source_idea_id = ? OR r.target_idea_id = ?)
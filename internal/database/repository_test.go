@@ -3,12 +3,17 @@
 package database_test
 
 import (
+	"context"
+	"database/sql"
 	"encoding/json"
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/ryacub/telos-idea-matrix/internal/config"
 	"github.com/ryacub/telos-idea-matrix/internal/database"
 	"github.com/ryacub/telos-idea-matrix/internal/models"
 	"github.com/stretchr/testify/assert"
@@ -50,6 +55,71 @@ func TestNewRepository_InvalidPath_ReturnsError(t *testing.T) {
 	t.Skip("SQLite is too permissive with file paths - skipping this test")
 }
 
+// TestNewRepositoryWithConfig_AppliesPoolSettings tests the configured pool and busy timeout
+func TestNewRepositoryWithConfig_AppliesPoolSettings(t *testing.T) {
+	cfg := config.DefaultDatabaseConfig(filepath.Join(t.TempDir(), "ideas.db"))
+	cfg.MaxOpenConns = 1
+	cfg.BusyTimeout = 2 * time.Second
+	cfg.WALAutoCheckpoint = 250
+
+	repo, err := database.NewRepositoryWithConfig(cfg)
+	require.NoError(t, err)
+	defer repo.Close()
+
+	assert.Equal(t, 1, repo.DB().Stats().MaxOpenConnections)
+
+	var busyTimeout int
+	require.NoError(t, repo.DB().QueryRow("PRAGMA busy_timeout").Scan(&busyTimeout))
+	assert.Equal(t, 2000, busyTimeout)
+
+	pages, err := repo.WALAutoCheckpoint()
+	require.NoError(t, err)
+	assert.Equal(t, 250, pages)
+
+	var journalMode string
+	require.NoError(t, repo.DB().QueryRow("PRAGMA journal_mode").Scan(&journalMode))
+	assert.Equal(t, "wal", journalMode)
+}
+
+// TestNewRepositoryWithConfig_SetsPragmasOnEveryConnection tests that
+// connection-scoped pragmas hold on each connection of a larger pool
+func TestNewRepositoryWithConfig_SetsPragmasOnEveryConnection(t *testing.T) {
+	cfg := config.DefaultDatabaseConfig(filepath.Join(t.TempDir(), "ideas.db"))
+	cfg.MaxOpenConns = 4
+	cfg.WALAutoCheckpoint = 250
+
+	repo, err := database.NewRepositoryWithConfig(cfg)
+	require.NoError(t, err)
+	defer repo.Close()
+
+	// Hold every connection at once so the pool has to open all of them
+	ctx := context.Background()
+	conns := make([]*sql.Conn, cfg.MaxOpenConns)
+	for i := range conns {
+		conns[i], err = repo.DB().Conn(ctx)
+		require.NoError(t, err)
+		defer conns[i].Close()
+	}
+
+	for i, conn := range conns {
+		var pages, foreignKeys int
+		require.NoError(t, conn.QueryRowContext(ctx, "PRAGMA wal_autocheckpoint").Scan(&pages))
+		require.NoError(t, conn.QueryRowContext(ctx, "PRAGMA foreign_keys").Scan(&foreignKeys))
+		assert.Equal(t, 250, pages, "connection %d", i)
+		assert.Equal(t, 1, foreignKeys, "connection %d", i)
+	}
+}
+
+// TestNewRepositoryWithConfig_RejectsEmptyPool tests that the pool needs a connection
+func TestNewRepositoryWithConfig_RejectsEmptyPool(t *testing.T) {
+	cfg := config.DefaultDatabaseConfig(filepath.Join(t.TempDir(), "ideas.db"))
+	cfg.MaxOpenConns = 0
+
+	_, err := database.NewRepositoryWithConfig(cfg)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "must be at least 1")
+}
+
 // TestRepository_Create_ValidIdea_SavesSuccessfully tests creating an idea
 func TestRepository_Create_ValidIdea_SavesSuccessfully(t *testing.T) {
 	repo, cleanup := setupTestDB(t)
@@ -189,6 +259,66 @@ func TestRepository_Update_NonexistentIdea_ReturnsError(t *testing.T) {
 
 	err := repo.Update(idea)
 	assert.Error(t, err)
+	assert.True(t, database.IsNotFound(err))
+}
+
+// TestRepository_Update_AdvancesVersion tests that each update bumps the version
+func TestRepository_Update_AdvancesVersion(t *testing.T) {
+	repo, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	idea := models.NewIdea("Versioned idea")
+	require.NoError(t, repo.Create(idea))
+	assert.Equal(t, 1, idea.Version)
+
+	idea.Content = "Versioned idea, edited"
+	require.NoError(t, repo.Update(idea))
+	assert.Equal(t, 2, idea.Version)
+
+	// The same copy can keep being updated
+	idea.Content = "Versioned idea, edited twice"
+	require.NoError(t, repo.Update(idea))
+
+	retrieved, err := repo.GetByID(idea.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 3, retrieved.Version)
+	assert.Equal(t, "Versioned idea, edited twice", retrieved.Content)
+}
+
+// TestRepository_Update_ConcurrentEdit_ReturnsStaleVersion tests that of two
+// readers updating the same idea, the second is rejected
+func TestRepository_Update_ConcurrentEdit_ReturnsStaleVersion(t *testing.T) {
+	repo, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	idea := models.NewIdea("Shared idea")
+	require.NoError(t, repo.Create(idea))
+
+	first, err := repo.GetByID(idea.ID)
+	require.NoError(t, err)
+	second, err := repo.GetByID(idea.ID)
+	require.NoError(t, err)
+
+	first.Content = "Edited from the web"
+	require.NoError(t, repo.Update(first))
+
+	second.Content = "Edited from the CLI"
+	err = repo.Update(second)
+	require.Error(t, err)
+	assert.True(t, database.IsStaleVersion(err), "expected ErrStaleVersion, got %v", err)
+	assert.False(t, database.IsNotFound(err))
+	assert.Equal(t, 1, second.Version, "a rejected update must not advance the version")
+
+	retrieved, err := repo.GetByID(idea.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Edited from the web", retrieved.Content)
+	assert.Equal(t, 2, retrieved.Version)
+
+	// Re-reading and retrying succeeds
+	second, err = repo.GetByID(idea.ID)
+	require.NoError(t, err)
+	second.Content = "Edited from the CLI"
+	require.NoError(t, repo.Update(second))
 }
 
 // TestRepository_Delete_ExistingIdea_DeletesSuccessfully tests deleting an idea
@@ -273,6 +403,68 @@ func TestRepository_List_FilterByStatus_ReturnsFiltered(t *testing.T) {
 	}
 }
 
+// TestRepository_List_ExcludesTrashUnlessRequested tests that deleted ideas
+// only appear when listed by status
+func TestRepository_List_ExcludesTrashUnlessRequested(t *testing.T) {
+	repo, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	kept := models.NewIdea("Kept idea")
+	require.NoError(t, repo.Create(kept))
+
+	trashed := models.NewIdea("Trashed idea")
+	trashed.Trash()
+	require.NoError(t, repo.Create(trashed))
+
+	ideas, err := repo.List(database.ListOptions{})
+	require.NoError(t, err)
+	require.Len(t, ideas, 1)
+	assert.Equal(t, kept.ID, ideas[0].ID)
+
+	count, err := repo.Count(database.ListOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+
+	ideas, err = repo.List(database.ListOptions{Status: string(models.StatusDeleted)})
+	require.NoError(t, err)
+	require.Len(t, ideas, 1)
+	assert.Equal(t, trashed.ID, ideas[0].ID)
+}
+
+// TestRepository_EmptyTrash_RemovesOnlyDeleted tests that emptying the trash
+// leaves other statuses and profiles alone
+func TestRepository_EmptyTrash_RemovesOnlyDeleted(t *testing.T) {
+	repo, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	active := models.NewIdea("Active idea")
+	require.NoError(t, repo.Create(active))
+
+	trashed := models.NewIdea("Trashed idea")
+	trashed.Trash()
+	require.NoError(t, repo.Create(trashed))
+
+	otherProfile := models.NewIdea("Trashed in another profile")
+	otherProfile.Profile = "side"
+	otherProfile.Trash()
+	require.NoError(t, repo.Create(otherProfile))
+
+	removed, err := repo.EmptyTrash(models.DefaultProfile)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), removed)
+
+	_, err = repo.GetByID(trashed.ID)
+	assert.True(t, database.IsNotFound(err))
+	_, err = repo.GetByID(active.ID)
+	assert.NoError(t, err)
+
+	removed, err = repo.EmptyTrash("")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), removed)
+	_, err = repo.GetByID(otherProfile.ID)
+	assert.True(t, database.IsNotFound(err))
+}
+
 // TestRepository_List_FilterByScoreRange_ReturnsFiltered tests score filtering
 func TestRepository_List_FilterByScoreRange_ReturnsFiltered(t *testing.T) {
 	repo, cleanup := setupTestDB(t)
@@ -302,6 +494,32 @@ func TestRepository_List_FilterByScoreRange_ReturnsFiltered(t *testing.T) {
 	assert.GreaterOrEqual(t, ideas[0].FinalScore, 7.0)
 }
 
+func TestRepository_List_FilterByRecommendation_MatchesAnyWording(t *testing.T) {
+	repo, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	for _, recommendation := range []string{models.RecommendationGood.String(), "GOOD ALIGNMENT", "pursue", "review", ""} {
+		idea := models.NewIdea("Idea recommended as " + recommendation)
+		idea.Recommendation = recommendation
+		require.NoError(t, repo.Create(idea))
+	}
+
+	ideas, err := repo.List(database.ListOptions{Recommendation: models.CategoryPursue})
+	require.NoError(t, err)
+	assert.Len(t, ideas, 3)
+
+	// Rescoring moves an idea to its new category
+	ideas[0].Recommendation = models.RecommendationAvoid.String()
+	require.NoError(t, repo.Update(ideas[0]))
+
+	ideas, err = repo.List(database.ListOptions{Recommendation: models.CategoryPursue})
+	require.NoError(t, err)
+	assert.Len(t, ideas, 2)
+	ideas, err = repo.List(database.ListOptions{Recommendation: models.CategoryAvoid})
+	require.NoError(t, err)
+	assert.Len(t, ideas, 1)
+}
+
 // TestRepository_List_OrderByScore_ReturnsOrdered tests sorting
 func TestRepository_List_OrderByScore_ReturnsOrdered(t *testing.T) {
 	repo, cleanup := setupTestDB(t)
@@ -324,7 +542,7 @@ func TestRepository_List_OrderByScore_ReturnsOrdered(t *testing.T) {
 	repo.Create(idea3)
 
 	// List ordered by score DESC
-	ideas, err := repo.List(database.ListOptions{OrderBy: "final_score DESC"})
+	ideas, err := repo.List(database.ListOptions{OrderBy: database.OrderBy(database.SortByFinalScore, database.Descending)})
 	require.NoError(t, err)
 	assert.Len(t, ideas, 3)
 	// Should be in descending order
@@ -332,6 +550,136 @@ func TestRepository_List_OrderByScore_ReturnsOrdered(t *testing.T) {
 	assert.GreaterOrEqual(t, ideas[1].FinalScore, ideas[2].FinalScore)
 }
 
+// TestRepository_Stream_MatchesList tests that streaming yields the same ideas as List
+func TestRepository_Stream_MatchesList(t *testing.T) {
+	repo, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	for i, score := range []float64{4.0, 9.0, 6.5, 2.0} {
+		idea := models.NewIdea("Streamed idea")
+		idea.FinalScore = score
+		idea.Tags = []string{"batch"}
+		if i == 3 {
+			idea.Status = string(models.StatusArchived)
+		}
+		require.NoError(t, repo.Create(idea))
+	}
+
+	options := database.ListOptions{
+		Status:  "active",
+		OrderBy: database.OrderBy(database.SortByFinalScore, database.Descending),
+	}
+	listed, err := repo.List(options)
+	require.NoError(t, err)
+
+	stream, streamErr, err := repo.Stream(options)
+	require.NoError(t, err)
+	var streamed []*models.Idea
+	for idea := range stream {
+		streamed = append(streamed, idea)
+	}
+	require.NoError(t, streamErr())
+
+	require.Len(t, streamed, 3)
+	assert.Equal(t, listed, streamed)
+	assert.Equal(t, []string{"batch"}, streamed[0].Tags)
+
+	_, _, err = repo.Stream(database.ListOptions{OrderBy: database.Order{Field: "content"}})
+	assert.Error(t, err, "an unsafe order should be rejected before querying")
+}
+
+// TestRepository_Stream_ReportsBadRow tests that a row that fails to scan
+// ends the stream with an error rather than looking like the end of the data
+func TestRepository_Stream_ReportsBadRow(t *testing.T) {
+	repo, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	for i, content := range []string{"First idea", "Corrupted idea", "Third idea"} {
+		idea := models.NewIdea(content)
+		idea.CreatedAt = time.Date(2025, 1, i+1, 0, 0, 0, 0, time.UTC)
+		require.NoError(t, repo.Create(idea))
+		if content == "Corrupted idea" {
+			_, err := repo.DB().Exec("UPDATE ideas SET patterns = 'not json' WHERE id = ?", idea.ID)
+			require.NoError(t, err)
+		}
+	}
+
+	stream, streamErr, err := repo.Stream(database.ListOptions{
+		OrderBy: database.OrderBy(database.SortByCreatedAt, database.Ascending),
+	})
+	require.NoError(t, err)
+	var streamed []string
+	for idea := range stream {
+		streamed = append(streamed, idea.Content)
+	}
+
+	assert.Equal(t, []string{"First idea"}, streamed)
+	err = streamErr()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to parse patterns")
+}
+
+// TestParseOrder_AcceptsWhitelist tests parsing sort fields and directions
+func TestParseOrder_AcceptsWhitelist(t *testing.T) {
+	order, err := database.ParseOrder("final_score", "desc")
+	require.NoError(t, err)
+	assert.Equal(t, database.OrderBy(database.SortByFinalScore, database.Descending), order)
+
+	order, err = database.ParseOrder("Created_At", "")
+	require.NoError(t, err)
+	assert.Equal(t, "created_at ASC", order.String())
+
+	order, err = database.ParseOrder("raw_score", "ASC")
+	require.NoError(t, err)
+	assert.Equal(t, database.OrderBy(database.SortByRawScore, database.Ascending), order)
+}
+
+// TestParseOrder_RejectsInjection tests that anything outside the whitelist is rejected
+func TestParseOrder_RejectsInjection(t *testing.T) {
+	tests := []struct {
+		name      string
+		field     string
+		direction string
+	}{
+		{"statement in field", "; DROP TABLE ideas", "asc"},
+		{"statement after field", "final_score; DROP TABLE ideas", "asc"},
+		{"clause in field", "final_score DESC", ""},
+		{"unknown column", "content", "asc"},
+		{"subquery in field", "(SELECT 1)", "asc"},
+		{"statement in direction", "final_score", "DESC; DROP TABLE ideas"},
+		{"unknown direction", "final_score", "sideways"},
+		{"empty field", "", "desc"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := database.ParseOrder(tt.field, tt.direction)
+			assert.Error(t, err)
+		})
+	}
+}
+
+// TestRepository_List_RejectsUnsafeOrder tests that List validates orders built without ParseOrder
+func TestRepository_List_RejectsUnsafeOrder(t *testing.T) {
+	repo, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	createTestIdea(t, repo, "Idea that must survive")
+
+	for _, order := range []database.Order{
+		{Field: "final_score; DROP TABLE ideas", Direction: database.Descending},
+		{Field: database.SortByFinalScore, Direction: "DESC; DROP TABLE ideas"},
+		{Field: database.SortByFinalScore},
+	} {
+		_, err := repo.List(database.ListOptions{OrderBy: order})
+		assert.Error(t, err, "order %q should be rejected", order.String())
+	}
+
+	ideas, err := repo.List(database.ListOptions{})
+	require.NoError(t, err)
+	assert.Len(t, ideas, 1, "ideas table should be intact")
+}
+
 // TestRepository_List_WithLimit_ReturnsLimited tests pagination
 func TestRepository_List_WithLimit_ReturnsLimited(t *testing.T) {
 	repo, cleanup := setupTestDB(t)
@@ -513,3 +861,415 @@ func TestRepository_List_MaxScoreFilter_ReturnsFiltered(t *testing.T) {
 	assert.Len(t, ideas, 1)
 	assert.LessOrEqual(t, ideas[0].FinalScore, 5.0)
 }
+
+// TestRepository_FindByContentHash_MatchesNormalizedContent tests duplicate lookup
+func TestRepository_FindByContentHash_MatchesNormalizedContent(t *testing.T) {
+	repo, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	idea := models.NewIdea("Build a Go CLI tool")
+	require.NoError(t, repo.Create(idea))
+
+	found, err := repo.FindByContentHash(models.ContentHash("  build a go   CLI tool "))
+	require.NoError(t, err)
+	assert.Equal(t, idea.ID, found.ID)
+
+	_, err = repo.FindByContentHash(models.ContentHash("Something else entirely"))
+	assert.True(t, database.IsNotFound(err))
+}
+
+// TestRepository_FindByContentHash_TracksUpdatedContent tests hash refresh on update
+func TestRepository_FindByContentHash_TracksUpdatedContent(t *testing.T) {
+	repo, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	idea := models.NewIdea("Original content")
+	require.NoError(t, repo.Create(idea))
+
+	idea.Content = "Rewritten content"
+	require.NoError(t, repo.Update(idea))
+
+	_, err := repo.FindByContentHash(models.ContentHash("Original content"))
+	assert.True(t, database.IsNotFound(err))
+
+	found, err := repo.FindByContentHash(models.ContentHash("Rewritten content"))
+	require.NoError(t, err)
+	assert.Equal(t, idea.ID, found.ID)
+}
+
+// TestRepository_AnalyticsSummary_MarkedStaleOnChange tests that idea changes invalidate the summary
+func TestRepository_AnalyticsSummary_MarkedStaleOnChange(t *testing.T) {
+	repo, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	summary, err := repo.GetAnalyticsSummary()
+	require.NoError(t, err)
+	assert.True(t, summary.Stale)
+	assert.Nil(t, summary.RefreshedAt)
+
+	require.NoError(t, repo.SaveAnalyticsSummary(&database.AnalyticsSummary{
+		TotalIdeas:   0,
+		ScoreBuckets: map[string]int{},
+		TopPatterns:  []database.PatternCount{},
+	}, summary.ChangeCount))
+
+	summary, err = repo.GetAnalyticsSummary()
+	require.NoError(t, err)
+	assert.False(t, summary.Stale)
+	require.NotNil(t, summary.RefreshedAt)
+
+	idea := models.NewIdea("Summary invalidation")
+	require.NoError(t, repo.Create(idea))
+
+	summary, err = repo.GetAnalyticsSummary()
+	require.NoError(t, err)
+	assert.True(t, summary.Stale)
+
+	// Saving a summary computed before the latest change keeps it stale
+	require.NoError(t, repo.SaveAnalyticsSummary(&database.AnalyticsSummary{
+		ScoreBuckets: map[string]int{},
+		TopPatterns:  []database.PatternCount{},
+	}, summary.ChangeCount-1))
+
+	summary, err = repo.GetAnalyticsSummary()
+	require.NoError(t, err)
+	assert.True(t, summary.Stale)
+}
+
+// TestRepository_Trigger_RoundTrips tests that an idea's trigger is stored and updated
+func TestRepository_Trigger_RoundTrips(t *testing.T) {
+	repo, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	idea := models.NewIdea("Price tracking for indie shops")
+	idea.Trigger = "competitor launch"
+	require.NoError(t, repo.Create(idea))
+
+	retrieved, err := repo.GetByID(idea.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "competitor launch", retrieved.Trigger)
+
+	ideas, err := repo.List(database.ListOptions{})
+	require.NoError(t, err)
+	require.Len(t, ideas, 1)
+	assert.Equal(t, "competitor launch", ideas[0].Trigger)
+
+	retrieved.Trigger = "customer request"
+	require.NoError(t, repo.Update(retrieved))
+
+	updated, err := repo.GetByPartialID(idea.ID[:8])
+	require.NoError(t, err)
+	assert.Equal(t, "customer request", updated.Trigger)
+}
+
+// TestRepository_Notes_RoundTrip tests that an idea's notes are stored and updated
+func TestRepository_Notes_RoundTrip(t *testing.T) {
+	repo, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	idea := models.NewIdea("Price tracking for indie shops")
+	idea.Notes = "started prototyping 2025-06"
+	require.NoError(t, repo.Create(idea))
+
+	ideas, err := repo.List(database.ListOptions{})
+	require.NoError(t, err)
+	require.Len(t, ideas, 1)
+	assert.Equal(t, "started prototyping 2025-06", ideas[0].Notes)
+
+	ideas[0].AddNote("paused for the holidays")
+	require.NoError(t, repo.Update(ideas[0]))
+
+	updated, err := repo.GetByPartialID(idea.ID[:8])
+	require.NoError(t, err)
+	assert.Equal(t, "started prototyping 2025-06\npaused for the holidays", updated.Notes)
+
+	updated.Notes = strings.Repeat("x", models.MaxNotesLength+1)
+	assert.Error(t, repo.Update(updated), "notes over the limit are rejected")
+}
+
+func TestRepository_UpdatedAt_MaintainedOnWrite(t *testing.T) {
+	repo, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	inserting := time.Now()
+	idea := models.NewIdea("Price tracking for indie shops")
+	idea.CreatedAt = time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	require.NoError(t, repo.Create(idea))
+	assert.False(t, idea.UpdatedAt.Before(inserting), "the insert is stamped, even for an older idea")
+
+	stored, err := repo.GetByID(idea.ID)
+	require.NoError(t, err)
+	assert.True(t, idea.UpdatedAt.Equal(stored.UpdatedAt))
+	assert.True(t, idea.CreatedAt.Equal(stored.CreatedAt))
+
+	untouched := models.NewIdea("Never edited")
+	require.NoError(t, repo.Create(untouched))
+
+	time.Sleep(2 * time.Millisecond)
+	since := time.Now()
+	time.Sleep(2 * time.Millisecond)
+
+	stored.Notes = "still worth doing"
+	require.NoError(t, repo.Update(stored))
+	assert.True(t, stored.UpdatedAt.After(since))
+	assert.True(t, stored.CreatedAt.Equal(idea.CreatedAt), "updates keep the creation time")
+
+	reread, err := repo.GetByPartialID(idea.ID[:8])
+	require.NoError(t, err)
+	assert.True(t, stored.UpdatedAt.Equal(reread.UpdatedAt))
+
+	ideas, err := repo.List(database.ListOptions{UpdatedSince: &since})
+	require.NoError(t, err)
+	require.Len(t, ideas, 1)
+	assert.Equal(t, idea.ID, ideas[0].ID)
+
+	count, err := repo.Count(database.ListOptions{UpdatedSince: &since})
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+}
+
+func TestRepository_ArchiveReason_PersistedAndClearedOnRestore(t *testing.T) {
+	repo, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	idea := models.NewIdea("Rewrite the blog engine")
+	require.NoError(t, repo.Create(idea))
+
+	idea.Archive("  duplicate of abc123 ")
+	require.NoError(t, repo.Update(idea))
+
+	retrieved, err := repo.GetByID(idea.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "archived", retrieved.Status)
+	assert.Equal(t, "duplicate of abc123", retrieved.ArchiveReason)
+
+	archived, err := repo.List(database.ListOptions{Status: "archived"})
+	require.NoError(t, err)
+	require.Len(t, archived, 1)
+	assert.Equal(t, "duplicate of abc123", archived[0].ArchiveReason)
+
+	// Reactivating drops the reason so it can't describe a later archival
+	retrieved.Status = "active"
+	require.NoError(t, repo.Update(retrieved))
+
+	restored, err := repo.GetByPartialID(idea.ID[:8])
+	require.NoError(t, err)
+	assert.Empty(t, restored.ArchiveReason)
+}
+
+// TestRepository_List_SearchFilters_ReturnsFiltered tests pattern, tag, content, and date filters
+func TestRepository_List_SearchFilters_ReturnsFiltered(t *testing.T) {
+	repo, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	now := time.Now().UTC()
+
+	podcast := models.NewIdea("Start a 100% remote podcast")
+	podcast.Patterns = []string{"Perfectionism: polishing forever"}
+	podcast.Tags = []string{"Media"}
+	podcast.CreatedAt = now.AddDate(0, 0, -10)
+	require.NoError(t, repo.Create(podcast))
+
+	tool := models.NewIdea("Build a CLI tool")
+	tool.Patterns = []string{"Context switching"}
+	tool.Tags = []string{"work", "go"}
+	tool.CreatedAt = now.AddDate(0, 0, -2)
+	require.NoError(t, repo.Create(tool))
+
+	blank := models.NewIdea("Learn pottery")
+	blank.CreatedAt = now
+	require.NoError(t, repo.Create(blank))
+
+	ids := func(opts database.ListOptions) []string {
+		t.Helper()
+		ideas, err := repo.List(opts)
+		require.NoError(t, err)
+		result := make([]string, len(ideas))
+		for i, idea := range ideas {
+			result[i] = idea.ID
+		}
+		return result
+	}
+
+	// Pattern matches by name, case-insensitively, with or without a description
+	assert.Equal(t, []string{podcast.ID}, ids(database.ListOptions{Pattern: "perfectionism"}))
+	assert.Equal(t, []string{tool.ID}, ids(database.ListOptions{Pattern: "Context Switching"}))
+	assert.Empty(t, ids(database.ListOptions{Pattern: "Perfection"}))
+
+	// Tag matches exactly, case-insensitively
+	assert.Equal(t, []string{podcast.ID}, ids(database.ListOptions{Tag: "media"}))
+	assert.Empty(t, ids(database.ListOptions{Tag: "wor"}))
+
+	// Contains treats LIKE wildcards literally
+	assert.Equal(t, []string{podcast.ID}, ids(database.ListOptions{Contains: "100%"}))
+	assert.Equal(t, []string{tool.ID}, ids(database.ListOptions{Contains: "cli"}))
+	assert.Empty(t, ids(database.ListOptions{Contains: "_"}))
+
+	// Date range: after is inclusive, before is exclusive
+	after := now.AddDate(0, 0, -5)
+	before := now.Add(-time.Hour)
+	assert.Equal(t, []string{tool.ID}, ids(database.ListOptions{CreatedAfter: &after, CreatedBefore: &before}))
+
+	// Filters combine
+	assert.Equal(t, []string{tool.ID}, ids(database.ListOptions{Tag: "go", CreatedAfter: &after}))
+	assert.Empty(t, ids(database.ListOptions{Tag: "go", Pattern: "perfectionism"}))
+}
+
+func TestRepository_List_FilterByProfile(t *testing.T) {
+	repo, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	personal := models.NewIdea("Learn to bake sourdough")
+	require.NoError(t, repo.Create(personal))
+
+	work := models.NewIdea("Automate the release checklist")
+	work.Profile = "work"
+	require.NoError(t, repo.Create(work))
+
+	// An empty profile is stored as the default
+	unset := models.NewIdea("Plan a weekend trip")
+	unset.Profile = ""
+	require.NoError(t, repo.Create(unset))
+
+	ideas, err := repo.List(database.ListOptions{Profile: "work"})
+	require.NoError(t, err)
+	require.Len(t, ideas, 1)
+	assert.Equal(t, work.ID, ideas[0].ID)
+	assert.Equal(t, "work", ideas[0].Profile)
+
+	ideas, err = repo.List(database.ListOptions{Profile: models.DefaultProfile})
+	require.NoError(t, err)
+	assert.Len(t, ideas, 2)
+
+	ideas, err = repo.List(database.ListOptions{})
+	require.NoError(t, err)
+	assert.Len(t, ideas, 3)
+}
+
+func TestRepository_BackfillTelosVersion(t *testing.T) {
+	tests := []struct {
+		name    string
+		version string
+	}{
+		{name: "legacy by default", version: models.LegacyTelosVersion},
+		{name: "assume current", version: "3f2a9c1b7d4e"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, cleanup := setupTestDB(t)
+			defer cleanup()
+
+			unversioned := models.NewIdea("Idea scored before versions were tracked")
+			require.NoError(t, repo.Create(unversioned))
+
+			versioned := models.NewIdea("Idea scored against an older telos")
+			versioned.TelosVersion = "0a1b2c3d4e5f"
+			require.NoError(t, repo.Create(versioned))
+
+			time.Sleep(2 * time.Millisecond)
+			updated, err := repo.BackfillTelosVersion(tt.version)
+			require.NoError(t, err)
+			assert.Equal(t, int64(1), updated)
+
+			got, err := repo.GetByID(unversioned.ID)
+			require.NoError(t, err)
+			assert.Equal(t, tt.version, got.TelosVersion)
+			assert.True(t, got.UpdatedAt.After(unversioned.UpdatedAt), "backfilled ideas are stamped")
+			assert.Equal(t, 2, got.Version)
+
+			got, err = repo.GetByID(versioned.ID)
+			require.NoError(t, err)
+			assert.Equal(t, "0a1b2c3d4e5f", got.TelosVersion, "existing versions must not be overwritten")
+			assert.True(t, got.UpdatedAt.Equal(versioned.UpdatedAt))
+			assert.Equal(t, 1, got.Version)
+
+			// Running again finds nothing left to backfill
+			updated, err = repo.BackfillTelosVersion(tt.version)
+			require.NoError(t, err)
+			assert.Zero(t, updated)
+		})
+	}
+
+	t.Run("rejects empty version", func(t *testing.T) {
+		repo, cleanup := setupTestDB(t)
+		defer cleanup()
+
+		_, err := repo.BackfillTelosVersion("")
+		assert.Error(t, err)
+	})
+}
+
+func TestRepository_RepairAnalysisDetails(t *testing.T) {
+	repo, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	plain := models.NewIdea("Idea analyzed by an older version")
+	plain.AnalysisDetails = "GOOD ALIGNMENT"
+	require.NoError(t, repo.Create(plain))
+
+	structured := models.NewIdea("Idea with JSON details")
+	structured.AnalysisDetails = `{"provider":"ollama","scores":{"mission_alignment":3.2}}`
+	require.NoError(t, repo.Create(structured))
+
+	require.NoError(t, repo.Create(models.NewIdea("Idea without details")))
+
+	count, err := repo.RepairAnalysisDetails(true)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+	got, err := repo.GetByID(plain.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "GOOD ALIGNMENT", got.AnalysisDetails, "a dry run changes nothing")
+
+	time.Sleep(2 * time.Millisecond)
+	count, err = repo.RepairAnalysisDetails(false)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+
+	got, err = repo.GetByID(plain.ID)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"text":"GOOD ALIGNMENT"}`, got.AnalysisDetails)
+	assert.True(t, got.UpdatedAt.After(plain.UpdatedAt), "repaired ideas are stamped")
+	assert.Equal(t, 2, got.Version)
+	text, ok := got.AnalysisText()
+	assert.True(t, ok)
+	assert.Equal(t, "GOOD ALIGNMENT", text)
+
+	got, err = repo.GetByID(structured.ID)
+	require.NoError(t, err)
+	assert.Equal(t, structured.AnalysisDetails, got.AnalysisDetails, "JSON details are left alone")
+	assert.Equal(t, 1, got.Version)
+
+	// Running again finds nothing left to repair
+	count, err = repo.RepairAnalysisDetails(false)
+	require.NoError(t, err)
+	assert.Zero(t, count)
+}
+
+func TestRepository_Count_IgnoresPagination(t *testing.T) {
+	repo, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	for content, score := range map[string]float64{"Low": 3, "Middling": 6, "High": 9} {
+		idea := models.NewIdea(content + " scoring idea")
+		idea.FinalScore = score
+		require.NoError(t, repo.Create(idea))
+	}
+
+	minScore := 5.0
+	limit, offset := 1, 1
+	options := database.ListOptions{MinScore: &minScore, Limit: &limit, Offset: &offset}
+
+	ideas, err := repo.List(options)
+	require.NoError(t, err)
+	assert.Len(t, ideas, 1)
+
+	count, err := repo.Count(options)
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+
+	// An offset without a limit skips ideas instead of failing
+	ideas, err = repo.List(database.ListOptions{Offset: &offset})
+	require.NoError(t, err)
+	assert.Len(t, ideas, 2)
+}
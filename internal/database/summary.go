@@ -0,0 +1,137 @@
+package database
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// AnalyticsSummary is the materialized analytics read-model stored in analytics_summary.
+type AnalyticsSummary struct {
+	TotalIdeas   int            `json:"total_ideas"`
+	ActiveIdeas  int            `json:"active_ideas"`
+	AverageScore float64        `json:"average_score"`
+	MedianScore  float64        `json:"median_score"`
+	HighScore    float64        `json:"high_score"`
+	LowScore     float64        `json:"low_score"`
+	ScoreBuckets map[string]int `json:"score_buckets"`
+	TopPatterns  []PatternCount `json:"top_patterns"`
+	Stale        bool           `json:"stale"`
+	ChangeCount  int64          `json:"-"`
+	RefreshedAt  *time.Time     `json:"refreshed_at,omitempty"`
+}
+
+// PatternCount is a pattern and the number of ideas it appears in.
+type PatternCount struct {
+	Pattern string `json:"pattern"`
+	Count   int    `json:"count"`
+}
+
+// GetAnalyticsSummary returns the stored analytics summary.
+// A summary that has never been refreshed has a nil RefreshedAt and is stale.
+func (r *Repository) GetAnalyticsSummary() (*AnalyticsSummary, error) {
+	var (
+		summary      AnalyticsSummary
+		bucketsJSON  string
+		patternsJSON string
+		stale        int
+		refreshedAt  sql.NullString
+	)
+
+	err := r.db.QueryRow(`
+		SELECT total_ideas, active_ideas, average_score, median_score, high_score, low_score,
+		       score_buckets, top_patterns, stale, change_count, refreshed_at
+		FROM analytics_summary
+		WHERE id = 1
+	`).Scan(
+		&summary.TotalIdeas,
+		&summary.ActiveIdeas,
+		&summary.AverageScore,
+		&summary.MedianScore,
+		&summary.HighScore,
+		&summary.LowScore,
+		&bucketsJSON,
+		&patternsJSON,
+		&stale,
+		&summary.ChangeCount,
+		&refreshedAt,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("analytics summary: %w", ErrNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get analytics summary: %w", err)
+	}
+
+	if err := json.Unmarshal([]byte(bucketsJSON), &summary.ScoreBuckets); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal score buckets: %w", err)
+	}
+	if err := json.Unmarshal([]byte(patternsJSON), &summary.TopPatterns); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal top patterns: %w", err)
+	}
+
+	summary.Stale = stale != 0
+	if refreshedAt.Valid {
+		parsedTime, err := time.Parse(time.RFC3339, refreshedAt.String)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse refreshed_at: %w", err)
+		}
+		summary.RefreshedAt = &parsedTime
+	}
+
+	return &summary, nil
+}
+
+// SaveAnalyticsSummary stores a freshly computed summary.
+// basedOn is the ChangeCount read before the ideas were loaded; if ideas changed
+// while the summary was being computed, the saved summary stays marked stale.
+func (r *Repository) SaveAnalyticsSummary(summary *AnalyticsSummary, basedOn int64) error {
+	bucketsJSON, err := json.Marshal(summary.ScoreBuckets)
+	if err != nil {
+		return fmt.Errorf("failed to marshal score buckets: %w", err)
+	}
+	patternsJSON, err := json.Marshal(summary.TopPatterns)
+	if err != nil {
+		return fmt.Errorf("failed to marshal top patterns: %w", err)
+	}
+
+	refreshedAt := time.Now().UTC().Truncate(time.Second)
+
+	_, err = r.db.Exec(`
+		INSERT INTO analytics_summary (
+			id, total_ideas, active_ideas, average_score, median_score, high_score, low_score,
+			score_buckets, top_patterns, stale, change_count, refreshed_at
+		) VALUES (1, ?, ?, ?, ?, ?, ?, ?, ?, 0, ?, ?)
+		ON CONFLICT(id) DO UPDATE SET
+			total_ideas = excluded.total_ideas,
+			active_ideas = excluded.active_ideas,
+			average_score = excluded.average_score,
+			median_score = excluded.median_score,
+			high_score = excluded.high_score,
+			low_score = excluded.low_score,
+			score_buckets = excluded.score_buckets,
+			top_patterns = excluded.top_patterns,
+			stale = CASE WHEN analytics_summary.change_count = ? THEN 0 ELSE 1 END,
+			refreshed_at = excluded.refreshed_at
+	`,
+		summary.TotalIdeas,
+		summary.ActiveIdeas,
+		summary.AverageScore,
+		summary.MedianScore,
+		summary.HighScore,
+		summary.LowScore,
+		string(bucketsJSON),
+		string(patternsJSON),
+		basedOn,
+		refreshedAt.Format(time.RFC3339),
+		basedOn,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save analytics summary: %w", err)
+	}
+
+	summary.RefreshedAt = &refreshedAt
+	return nil
+}
@@ -0,0 +1,52 @@
+package database
+
+import (
+	"fmt"
+)
+
+// CheckpointMode selects how a WAL checkpoint treats readers and writers
+type CheckpointMode string
+
+const (
+	// CheckpointPassive copies as many frames as it can without waiting
+	// for readers or writers
+	CheckpointPassive CheckpointMode = "PASSIVE"
+
+	// CheckpointTruncate waits for readers and writers, copies every frame
+	// and then truncates the WAL file to zero bytes
+	CheckpointTruncate CheckpointMode = "TRUNCATE"
+)
+
+// CheckpointResult is what SQLite reports after a WAL checkpoint
+type CheckpointResult struct {
+	Busy               bool // The checkpoint couldn't finish because of other connections
+	LogFrames          int  // Frames in the WAL file
+	CheckpointedFrames int  // Frames copied back into the database
+}
+
+// Checkpoint copies the write-ahead log back into the database file. With
+// CheckpointTruncate the WAL file is also emptied, reclaiming its space.
+func (r *Repository) Checkpoint(mode CheckpointMode) (CheckpointResult, error) {
+	if mode != CheckpointPassive && mode != CheckpointTruncate {
+		return CheckpointResult{}, fmt.Errorf("unknown checkpoint mode %q", mode)
+	}
+
+	var result CheckpointResult
+	var busy int
+	query := fmt.Sprintf("PRAGMA wal_checkpoint(%s)", mode)
+	if err := r.db.QueryRow(query).Scan(&busy, &result.LogFrames, &result.CheckpointedFrames); err != nil {
+		return CheckpointResult{}, fmt.Errorf("failed to checkpoint WAL: %w", err)
+	}
+	result.Busy = busy != 0
+	return result, nil
+}
+
+// WALAutoCheckpoint returns how many pages the WAL grows by before SQLite
+// checkpoints automatically; zero means automatic checkpoints are off
+func (r *Repository) WALAutoCheckpoint() (int, error) {
+	var pages int
+	if err := r.db.QueryRow("PRAGMA wal_autocheckpoint").Scan(&pages); err != nil {
+		return 0, fmt.Errorf("failed to read WAL autocheckpoint: %w", err)
+	}
+	return pages, nil
+}
@@ -0,0 +1,59 @@
+//go:build integration
+
+package database_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ryacub/telos-idea-matrix/internal/config"
+	"github.com/ryacub/telos-idea-matrix/internal/database"
+	"github.com/ryacub/telos-idea-matrix/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckpoint_TruncateEmptiesWAL(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ideas.db")
+	cfg := config.DefaultDatabaseConfig(path)
+	cfg.WALAutoCheckpoint = 0 // Leave every frame in the WAL until we checkpoint
+
+	repo, err := database.NewRepositoryWithConfig(cfg)
+	require.NoError(t, err)
+	defer repo.Close()
+
+	for i := 0; i < 5; i++ {
+		require.NoError(t, repo.Create(models.NewIdea("Write a checkpoint test")))
+	}
+	info, err := os.Stat(path + "-wal")
+	require.NoError(t, err)
+	require.Positive(t, info.Size())
+
+	result, err := repo.Checkpoint(database.CheckpointPassive)
+	require.NoError(t, err)
+	assert.False(t, result.Busy)
+	assert.Positive(t, result.LogFrames)
+	assert.Equal(t, result.LogFrames, result.CheckpointedFrames)
+
+	result, err = repo.Checkpoint(database.CheckpointTruncate)
+	require.NoError(t, err)
+	assert.False(t, result.Busy)
+	assert.Zero(t, result.LogFrames)
+
+	info, err = os.Stat(path + "-wal")
+	require.NoError(t, err)
+	assert.Zero(t, info.Size())
+
+	ideas, err := repo.List(database.ListOptions{})
+	require.NoError(t, err)
+	assert.Len(t, ideas, 5)
+}
+
+func TestCheckpoint_UnknownMode(t *testing.T) {
+	repo, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	_, err := repo.Checkpoint("RESTART")
+	require.Error(t, err)
+}
@@ -0,0 +1,56 @@
+package export
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+
+	"github.com/rs/zerolog/log"
+	"github.com/ryacub/telos-idea-matrix/internal/models"
+)
+
+// ExportCSV writes ideas to a CSV file with one column per field in fields.
+// Without fields it writes the standard columns: ID, content, scores,
+// patterns, recommendation, analysis details, creation time, status and notes.
+func ExportCSV(ideas []*models.Idea, filename string, fields []string) error {
+	if len(fields) == 0 {
+		fields = defaultCSVFields
+	}
+	columns, err := selectFields(fields)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.Create(filename)
+	if err != nil {
+		return fmt.Errorf("create file: %w", err)
+	}
+	defer func() {
+		if err := file.Close(); err != nil {
+			log.Warn().Err(err).Msg("failed to close file")
+		}
+	}()
+
+	writer := csv.NewWriter(file)
+
+	header := make([]string, len(columns))
+	for i, c := range columns {
+		header[i] = c.header
+	}
+	if err := writer.Write(header); err != nil {
+		return fmt.Errorf("write header: %w", err)
+	}
+
+	row := make([]string, len(columns))
+	for _, idea := range ideas {
+		for i, c := range columns {
+			row[i] = c.csv(idea)
+		}
+		if err := writer.Write(row); err != nil {
+			return fmt.Errorf("write row: %w", err)
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
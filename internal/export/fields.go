@@ -0,0 +1,145 @@
+package export
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ryacub/telos-idea-matrix/internal/models"
+)
+
+// field is an idea field that CSV and JSON exports can be limited to
+type field struct {
+	name   string // Name accepted by --fields and used as the JSON key
+	header string // CSV column header
+	csv    func(*models.Idea) string
+	json   func(*models.Idea) interface{}
+}
+
+// fields lists every exportable idea field, in export order
+var fields = []field{
+	{"id", "ID", func(i *models.Idea) string { return i.ID }, func(i *models.Idea) interface{} { return i.ID }},
+	{"content", "Content", func(i *models.Idea) string { return i.Content }, func(i *models.Idea) interface{} { return i.Content }},
+	{"raw_score", "RawScore", func(i *models.Idea) string { return formatScore(i.RawScore) }, func(i *models.Idea) interface{} { return i.RawScore }},
+	{"final_score", "FinalScore", func(i *models.Idea) string { return formatScore(i.FinalScore) }, func(i *models.Idea) interface{} { return i.FinalScore }},
+	{"patterns", "Patterns", func(i *models.Idea) string { return strings.Join(i.Patterns, ",") }, func(i *models.Idea) interface{} { return nonNil(i.Patterns) }},
+	{"tags", "Tags", func(i *models.Idea) string { return strings.Join(i.Tags, ",") }, func(i *models.Idea) interface{} { return nonNil(i.Tags) }},
+	{"recommendation", "Recommendation", func(i *models.Idea) string { return i.Recommendation }, func(i *models.Idea) interface{} { return i.Recommendation }},
+	{"analysis_details", "AnalysisDetails", func(i *models.Idea) string { return i.AnalysisDetails }, func(i *models.Idea) interface{} { return i.AnalysisDetails }},
+	{"created_at", "CreatedAt", func(i *models.Idea) string { return i.CreatedAt.Format(time.RFC3339) }, func(i *models.Idea) interface{} { return i.CreatedAt }},
+	{"reviewed_at", "ReviewedAt", formatReviewedAt, func(i *models.Idea) interface{} { return i.ReviewedAt }},
+	{"updated_at", "UpdatedAt", func(i *models.Idea) string { return i.UpdatedAt.Format(time.RFC3339) }, func(i *models.Idea) interface{} { return i.UpdatedAt }},
+	{"status", "Status", func(i *models.Idea) string { return i.Status }, func(i *models.Idea) interface{} { return i.Status }},
+	{"trigger", "Trigger", func(i *models.Idea) string { return i.Trigger }, func(i *models.Idea) interface{} { return i.Trigger }},
+	{"archive_reason", "ArchiveReason", func(i *models.Idea) string { return i.ArchiveReason }, func(i *models.Idea) interface{} { return i.ArchiveReason }},
+	{"profile", "Profile", func(i *models.Idea) string { return i.Profile }, func(i *models.Idea) interface{} { return i.Profile }},
+	{"telos_version", "TelosVersion", func(i *models.Idea) string { return i.TelosVersion }, func(i *models.Idea) interface{} { return i.TelosVersion }},
+	{"notes", "Notes", func(i *models.Idea) string { return i.Notes }, func(i *models.Idea) interface{} { return i.Notes }},
+}
+
+// defaultCSVFields are the columns of a CSV export without a field list
+var defaultCSVFields = []string{
+	"id", "content", "raw_score", "final_score", "patterns",
+	"recommendation", "analysis_details", "created_at", "status", "notes",
+}
+
+// FieldNames returns the names of every exportable idea field
+func FieldNames() []string {
+	names := make([]string, len(fields))
+	for i, f := range fields {
+		names[i] = f.name
+	}
+	return names
+}
+
+// ParseFields parses a comma-separated field list such as
+// "id,content,final_score". An empty list returns nil, meaning every field.
+// Unknown names are an error that lists the valid ones.
+func ParseFields(list string) ([]string, error) {
+	var names []string
+	seen := make(map[string]bool)
+	for _, name := range strings.Split(list, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" || seen[name] {
+			continue
+		}
+		if _, ok := lookupField(name); !ok {
+			return nil, fmt.Errorf("unknown field %q (valid fields: %s)", name, strings.Join(FieldNames(), ", "))
+		}
+		seen[name] = true
+		names = append(names, name)
+	}
+	return names, nil
+}
+
+// selectFields returns the fields named by names, in that order
+func selectFields(names []string) ([]field, error) {
+	selected := make([]field, 0, len(names))
+	for _, name := range names {
+		f, ok := lookupField(name)
+		if !ok {
+			return nil, fmt.Errorf("unknown field %q (valid fields: %s)", name, strings.Join(FieldNames(), ", "))
+		}
+		selected = append(selected, f)
+	}
+	return selected, nil
+}
+
+func lookupField(name string) (field, bool) {
+	for _, f := range fields {
+		if f.name == name {
+			return f, true
+		}
+	}
+	return field{}, false
+}
+
+// projectedIdea is an idea reduced to some of its fields. It marshals to a
+// JSON object with the fields in the order they were selected.
+type projectedIdea struct {
+	idea   *models.Idea
+	fields []field
+}
+
+// MarshalJSON writes the selected fields as one JSON object
+func (p projectedIdea) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, f := range p.fields {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		key, _ := json.Marshal(f.name)
+		value, err := json.Marshal(f.json(p.idea))
+		if err != nil {
+			return nil, fmt.Errorf("encode %s: %w", f.name, err)
+		}
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+func formatScore(score float64) string {
+	return strconv.FormatFloat(score, 'f', 2, 64)
+}
+
+func formatReviewedAt(idea *models.Idea) string {
+	if idea.ReviewedAt == nil {
+		return ""
+	}
+	return idea.ReviewedAt.Format(time.RFC3339)
+}
+
+// nonNil keeps an empty list as [] rather than null in JSON
+func nonNil(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
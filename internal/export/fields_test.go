@@ -0,0 +1,96 @@
+package export
+
+import (
+	"encoding/csv"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ryacub/telos-idea-matrix/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFields(t *testing.T) {
+	fields, err := ParseFields(" id, Content ,final_score,id,")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"id", "content", "final_score"}, fields)
+
+	fields, err = ParseFields("")
+	require.NoError(t, err)
+	assert.Nil(t, fields)
+
+	_, err = ParseFields("id,score")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `unknown field "score"`)
+	assert.Contains(t, err.Error(), "final_score")
+}
+
+func TestExportCSV_DefaultColumns(t *testing.T) {
+	idea := models.NewIdea("Automate invoices")
+	idea.FinalScore = 7.5
+	idea.Patterns = []string{"perfectionism", "scope-creep"}
+
+	path := filepath.Join(t.TempDir(), "ideas.csv")
+	require.NoError(t, ExportCSV([]*models.Idea{idea}, path, nil))
+
+	rows := readCSV(t, path)
+	require.Len(t, rows, 2)
+	assert.Equal(t, []string{"ID", "Content", "RawScore", "FinalScore", "Patterns", "Recommendation", "AnalysisDetails", "CreatedAt", "Status", "Notes"}, rows[0])
+	assert.Equal(t, "7.50", rows[1][3])
+	assert.Equal(t, "perfectionism,scope-creep", rows[1][4])
+}
+
+func TestExportCSV_SelectedFields(t *testing.T) {
+	idea := models.NewIdea("Automate invoices")
+	idea.FinalScore = 7.5
+	idea.Tags = []string{"finance"}
+
+	path := filepath.Join(t.TempDir(), "ideas.csv")
+	require.NoError(t, ExportCSV([]*models.Idea{idea}, path, []string{"final_score", "content", "tags"}))
+
+	rows := readCSV(t, path)
+	assert.Equal(t, [][]string{
+		{"FinalScore", "Content", "Tags"},
+		{"7.50", "Automate invoices", "finance"},
+	}, rows)
+}
+
+func TestExportJSON_SelectedFields(t *testing.T) {
+	idea := models.NewIdea("Automate invoices")
+	idea.FinalScore = 7.5
+
+	path := filepath.Join(t.TempDir(), "ideas.json")
+	require.NoError(t, ExportJSON([]*models.Idea{idea}, path, false, []string{"id", "final_score", "patterns"}))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, `[{"id":"`+idea.ID+`","final_score":7.5,"patterns":[]}]`+"\n", string(data))
+
+	err = ExportJSON([]*models.Idea{idea}, path, false, []string{"nope"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "valid fields")
+}
+
+func TestExportJSON_AllFieldsByDefault(t *testing.T) {
+	idea := models.NewIdea("Automate invoices")
+	idea.Trigger = "month-end close"
+
+	path := filepath.Join(t.TempDir(), "ideas.json")
+	require.NoError(t, ExportJSON([]*models.Idea{idea}, path, true, nil))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"trigger": "month-end close"`)
+	assert.Contains(t, string(data), `"created_at"`)
+}
+
+func readCSV(t *testing.T, path string) [][]string {
+	t.Helper()
+	file, err := os.Open(path)
+	require.NoError(t, err)
+	defer func() { _ = file.Close() }()
+	rows, err := csv.NewReader(file).ReadAll()
+	require.NoError(t, err)
+	return rows
+}
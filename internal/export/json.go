@@ -0,0 +1,49 @@
+package export
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/rs/zerolog/log"
+	"github.com/ryacub/telos-idea-matrix/internal/models"
+)
+
+// ExportJSON writes ideas to a file as a JSON array. With fields, each idea
+// is an object of only those fields, in that order; without, every field is
+// written.
+func ExportJSON(ideas []*models.Idea, filename string, pretty bool, fields []string) error {
+	var value interface{} = ideas
+	if len(fields) > 0 {
+		selected, err := selectFields(fields)
+		if err != nil {
+			return err
+		}
+		projected := make([]projectedIdea, len(ideas))
+		for i, idea := range ideas {
+			projected[i] = projectedIdea{idea: idea, fields: selected}
+		}
+		value = projected
+	}
+
+	file, err := os.Create(filename)
+	if err != nil {
+		return fmt.Errorf("create file: %w", err)
+	}
+	defer func() {
+		if err := file.Close(); err != nil {
+			log.Warn().Err(err).Msg("failed to close file")
+		}
+	}()
+
+	encoder := json.NewEncoder(file)
+	if pretty {
+		encoder.SetIndent("", "  ")
+	}
+
+	if err := encoder.Encode(value); err != nil {
+		return fmt.Errorf("encode json: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,255 @@
+package export
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/ryacub/telos-idea-matrix/internal/models"
+)
+
+// MarkdownSplit selects what in a Markdown file becomes an idea
+type MarkdownSplit string
+
+const (
+	// MarkdownSplitAuto splits on "##" headings when the file has any, and
+	// on top-level list items otherwise
+	MarkdownSplitAuto MarkdownSplit = ""
+	// MarkdownSplitList makes each top-level list item an idea
+	MarkdownSplitList MarkdownSplit = "list"
+	// MarkdownSplitHeading makes each "##" heading and the text under it an idea
+	MarkdownSplitHeading MarkdownSplit = "heading"
+)
+
+// MarkdownOptions controls how ImportMarkdown reads a notes file
+type MarkdownOptions struct {
+	Split MarkdownSplit
+}
+
+var (
+	// A list item: "-", "*" or "+" bullets, or "1." and "1)" numbers
+	markdownListItem = regexp.MustCompile(`^([-*+]|\d{1,9}[.)])(\s+(.*))?$`)
+	// A task list checkbox at the start of a list item
+	markdownCheckbox = regexp.MustCompile(`^\[[ xX]\]\s+`)
+	// Three or more "-", "*" or "_", optionally spaced: a horizontal rule
+	markdownRule = regexp.MustCompile(`^([-*_])( *[-*_]){2,}$`)
+	// An ATX heading, capturing its level and text
+	markdownHeading = regexp.MustCompile(`^(#{1,6})(\s+(.*?))?\s*#*\s*$`)
+)
+
+// ImportMarkdown reads ideas from a Markdown notes file. Each top-level list
+// item, with any nested items and indented lines under it, or each "##"
+// heading with the text up to the next heading of the same or higher level,
+// becomes one new active idea. Front matter and fenced code blocks never
+// start an idea, and task list checkboxes are dropped.
+func ImportMarkdown(path string, opts MarkdownOptions) ([]*models.Idea, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read file: %w", err)
+	}
+
+	split := opts.Split
+	switch split {
+	case MarkdownSplitAuto, MarkdownSplitList, MarkdownSplitHeading:
+	default:
+		return nil, fmt.Errorf("unknown split %q (must be list or heading)", split)
+	}
+
+	lines := markdownLines(string(data))
+	if split == MarkdownSplitAuto {
+		split = MarkdownSplitList
+		if hasSectionHeadings(lines) {
+			split = MarkdownSplitHeading
+		}
+	}
+
+	var texts []string
+	if split == MarkdownSplitHeading {
+		texts = markdownSections(lines)
+	} else {
+		texts = markdownListItems(lines)
+	}
+
+	ideas := make([]*models.Idea, len(texts))
+	for i, text := range texts {
+		ideas[i] = models.NewIdea(text)
+	}
+	return ideas, nil
+}
+
+// markdownLine is a line of a Markdown file outside its front matter
+type markdownLine struct {
+	text    string // The line without trailing whitespace
+	indent  int    // Leading whitespace, counting a tab as four spaces
+	inFence bool   // Inside a fenced code block, or one of its fences
+}
+
+// markdownLines splits text into lines, dropping YAML front matter and
+// marking fenced code blocks
+func markdownLines(text string) []markdownLine {
+	raw := strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+
+	// Front matter is a "---" block at the very top
+	if len(raw) > 0 && strings.TrimSpace(raw[0]) == "---" {
+		for i := 1; i < len(raw); i++ {
+			if t := strings.TrimSpace(raw[i]); t == "---" || t == "..." {
+				raw = raw[i+1:]
+				break
+			}
+		}
+	}
+
+	lines := make([]markdownLine, 0, len(raw))
+	fence := ""
+	for _, line := range raw {
+		line = strings.TrimRight(line, " \t")
+		trimmed := strings.TrimLeft(line, " \t")
+		indent := 0
+		for _, r := range line[:len(line)-len(trimmed)] {
+			if r == '\t' {
+				indent += 4
+			} else {
+				indent++
+			}
+		}
+
+		inFence := fence != ""
+		switch {
+		case fence == "" && (strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~")):
+			fence = trimmed[:3]
+			inFence = true
+		case fence != "" && strings.HasPrefix(trimmed, fence):
+			fence = ""
+		}
+		lines = append(lines, markdownLine{text: line, indent: indent, inFence: inFence})
+	}
+	return lines
+}
+
+// heading returns the level and text of an ATX heading line, or 0
+func (l markdownLine) heading() (int, string) {
+	if l.inFence || l.indent > 3 {
+		return 0, ""
+	}
+	m := markdownHeading.FindStringSubmatch(strings.TrimSpace(l.text))
+	if m == nil {
+		return 0, ""
+	}
+	return len(m[1]), m[3]
+}
+
+func hasSectionHeadings(lines []markdownLine) bool {
+	for _, line := range lines {
+		if level, _ := line.heading(); level == 2 {
+			return true
+		}
+	}
+	return false
+}
+
+// markdownSections returns each "##" heading and the text under it, up to
+// the next heading of level two or higher. Sections with no heading text
+// and no body are skipped.
+func markdownSections(lines []markdownLine) []string {
+	var sections []string
+	var current []string
+	inSection := false
+	flush := func() {
+		if text := joinMarkdown(current); inSection && text != "" {
+			sections = append(sections, text)
+		}
+		current = current[:0]
+	}
+
+	for _, line := range lines {
+		level, title := line.heading()
+		if level == 1 || level == 2 {
+			flush()
+			inSection = level == 2
+			if inSection && title != "" {
+				current = append(current, title, "")
+			}
+			continue
+		}
+		if inSection {
+			current = append(current, line.text)
+		}
+	}
+	flush()
+	return sections
+}
+
+// markdownListItems returns each top-level list item with the nested items
+// and indented lines that follow it
+func markdownListItems(lines []markdownLine) []string {
+	var items []string
+	var current []string
+	inItem := false
+	afterBlank := false
+	flush := func() {
+		if text := joinMarkdown(current); inItem && text != "" {
+			items = append(items, text)
+		}
+		current = current[:0]
+		inItem = false
+	}
+
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line.text)
+		switch {
+		case line.inFence:
+			// Code belongs to the item it's under, and never starts one
+			if inItem {
+				current = append(current, line.text)
+			}
+		case trimmed == "":
+			afterBlank = true
+			if inItem {
+				current = append(current, "")
+			}
+			continue
+		case line.indent < 2 && markdownRule.MatchString(trimmed):
+			flush()
+		case line.indent < 2 && markdownListItem.MatchString(trimmed):
+			flush()
+			text := markdownListItem.FindStringSubmatch(trimmed)[3]
+			current = append(current, markdownCheckbox.ReplaceAllString(text, ""))
+			inItem = true
+		case inItem && (line.indent >= 2 || !afterBlank):
+			// Indented lines, and text running straight on from the item, continue it
+			if level, _ := line.heading(); level > 0 && line.indent < 2 {
+				flush()
+				break
+			}
+			current = append(current, trimmed)
+		default:
+			flush()
+		}
+		afterBlank = false
+	}
+	flush()
+	return items
+}
+
+// joinMarkdown joins lines into an idea's content, trimming blank lines
+// at either end and collapsing runs of them
+func joinMarkdown(lines []string) string {
+	var b strings.Builder
+	blank := false
+	for _, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			blank = true
+			continue
+		}
+		if b.Len() > 0 {
+			b.WriteString("\n")
+			if blank {
+				b.WriteString("\n")
+			}
+		}
+		b.WriteString(line)
+		blank = false
+	}
+	return b.String()
+}
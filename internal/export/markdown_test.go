@@ -0,0 +1,113 @@
+package export
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ryacub/telos-idea-matrix/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeMarkdown(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "notes.md")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+	return path
+}
+
+func ideaContents(ideas []*models.Idea) []string {
+	contents := make([]string, len(ideas))
+	for i, idea := range ideas {
+		contents[i] = idea.Content
+	}
+	return contents
+}
+
+func TestImportMarkdown_ListItems(t *testing.T) {
+	path := writeMarkdown(t, `---
+title: Brainstorm
+---
+# Ideas for March
+
+Some intro text that isn't an idea.
+
+- Automate invoices with a Go CLI
+  - parse PDFs
+  - email receipts
+- [ ] Start a newsletter
+about productivity
+* Build a habit tracker
+
+  with streaks and reminders
+1. Write a course on SQLite
+
+Closing thoughts.
+`)
+
+	ideas, err := ImportMarkdown(path, MarkdownOptions{})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{
+		"Automate invoices with a Go CLI\n- parse PDFs\n- email receipts",
+		"Start a newsletter\nabout productivity",
+		"Build a habit tracker\n\nwith streaks and reminders",
+		"Write a course on SQLite",
+	}, ideaContents(ideas))
+	for _, idea := range ideas {
+		assert.NotEmpty(t, idea.ID)
+		assert.Equal(t, string(models.StatusActive), idea.Status)
+	}
+}
+
+func TestImportMarkdown_Headings(t *testing.T) {
+	path := writeMarkdown(t, `# Notes
+
+Preamble.
+
+## Invoice automation
+A Go CLI that parses PDFs.
+
+- needs OCR
+### Open questions
+Pricing?
+
+##
+
+## Habit tracker ##
+`)
+
+	ideas, err := ImportMarkdown(path, MarkdownOptions{})
+	require.NoError(t, err, "headings are picked automatically")
+
+	assert.Equal(t, []string{
+		"Invoice automation\n\nA Go CLI that parses PDFs.\n\n- needs OCR\n### Open questions\nPricing?",
+		"Habit tracker",
+	}, ideaContents(ideas))
+
+	ideas, err = ImportMarkdown(path, MarkdownOptions{Split: MarkdownSplitList})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"needs OCR"}, ideaContents(ideas))
+}
+
+func TestImportMarkdown_IgnoresCodeAndRules(t *testing.T) {
+	path := writeMarkdown(t, "```\n- not an idea\n## not a heading\n```\n\n- Real idea\n  ```\n  code\n  ```\n\n---\n\nTrailing text\n")
+
+	ideas, err := ImportMarkdown(path, MarkdownOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Real idea\n  ```\n  code\n  ```"}, ideaContents(ideas))
+}
+
+func TestImportMarkdown_Errors(t *testing.T) {
+	_, err := ImportMarkdown(filepath.Join(t.TempDir(), "missing.md"), MarkdownOptions{})
+	require.Error(t, err)
+
+	_, err = ImportMarkdown(writeMarkdown(t, "- idea"), MarkdownOptions{Split: "paragraph"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "must be list or heading")
+
+	ideas, err := ImportMarkdown(writeMarkdown(t, "Just prose.\n"), MarkdownOptions{})
+	require.NoError(t, err)
+	assert.Empty(t, ideas)
+}
@@ -0,0 +1,68 @@
+package export
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/rs/zerolog/log"
+	"github.com/ryacub/telos-idea-matrix/internal/models"
+)
+
+// ExportNDJSON writes ideas as newline-delimited JSON: one object per line,
+// with the same fields as the JSON export.
+func ExportNDJSON(ideas []*models.Idea, filename string) error {
+	file, err := os.Create(filename)
+	if err != nil {
+		return fmt.Errorf("create file: %w", err)
+	}
+	defer func() {
+		if err := file.Close(); err != nil {
+			log.Warn().Err(err).Msg("failed to close file")
+		}
+	}()
+
+	out := bufio.NewWriter(file)
+	encoder := json.NewEncoder(out)
+	for _, idea := range ideas {
+		if err := encoder.Encode(idea); err != nil {
+			return fmt.Errorf("encode idea %s: %w", idea.ID, err)
+		}
+	}
+
+	if err := out.Flush(); err != nil {
+		return fmt.Errorf("write file: %w", err)
+	}
+	return nil
+}
+
+// ExportNDJSONStream writes each idea received on ch to w as one JSON line,
+// until ch is closed, and returns the number of ideas written. Only the
+// current idea is held in memory, so ch can be fed straight from
+// Repository.Stream, with streamErr the func it returns: an error it reports
+// once ch is closed fails the export, since the stream ended early. On a
+// write error the rest of ch is drained, so the sender isn't left blocked.
+func ExportNDJSONStream(ch <-chan *models.Idea, streamErr func() error, w io.Writer) (int, error) {
+	out := bufio.NewWriter(w)
+	encoder := json.NewEncoder(out)
+
+	count := 0
+	for idea := range ch {
+		if err := encoder.Encode(idea); err != nil {
+			for range ch {
+			}
+			return count, fmt.Errorf("encode idea %s: %w", idea.ID, err)
+		}
+		count++
+	}
+
+	if err := out.Flush(); err != nil {
+		return count, fmt.Errorf("write ideas: %w", err)
+	}
+	if err := streamErr(); err != nil {
+		return count, fmt.Errorf("read ideas: stopped after %d: %w", count, err)
+	}
+	return count, nil
+}
@@ -0,0 +1,112 @@
+package export
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ryacub/telos-idea-matrix/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func decodeLines(t *testing.T, data []byte) []models.Idea {
+	t.Helper()
+
+	var ideas []models.Idea
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		var idea models.Idea
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &idea), "line %q is not a JSON object", scanner.Text())
+		ideas = append(ideas, idea)
+	}
+	require.NoError(t, scanner.Err())
+	return ideas
+}
+
+func TestExportNDJSON_WritesOneIdeaPerLine(t *testing.T) {
+	first := models.NewIdea("Automate invoices\nand receipts")
+	first.FinalScore = 8.25
+	first.Patterns = []string{"perfectionism"}
+	second := models.NewIdea("Start a podcast")
+
+	path := filepath.Join(t.TempDir(), "ideas.ndjson")
+	require.NoError(t, ExportNDJSON([]*models.Idea{first, second}, path))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, 2, strings.Count(string(data), "\n"), "newlines in content must stay escaped")
+
+	ideas := decodeLines(t, data)
+	require.Len(t, ideas, 2)
+	assert.Equal(t, first.ID, ideas[0].ID)
+	assert.Equal(t, first.Content, ideas[0].Content)
+	assert.Equal(t, 8.25, ideas[0].FinalScore)
+	assert.Equal(t, []string{"perfectionism"}, ideas[0].Patterns)
+	assert.Equal(t, second.ID, ideas[1].ID)
+}
+
+func noStreamErr() error { return nil }
+
+func TestExportNDJSONStream_WritesUntilClosed(t *testing.T) {
+	ch := make(chan *models.Idea)
+	go func() {
+		defer close(ch)
+		for _, content := range []string{"one", "two", "three"} {
+			ch <- models.NewIdea(content)
+		}
+	}()
+
+	var buf bytes.Buffer
+	count, err := ExportNDJSONStream(ch, noStreamErr, &buf)
+	require.NoError(t, err)
+	assert.Equal(t, 3, count)
+
+	ideas := decodeLines(t, buf.Bytes())
+	require.Len(t, ideas, 3)
+	assert.Equal(t, "three", ideas[2].Content)
+}
+
+func TestExportNDJSONStream_FailsOnStreamError(t *testing.T) {
+	ch := make(chan *models.Idea, 1)
+	ch <- models.NewIdea("one")
+	close(ch)
+
+	var buf bytes.Buffer
+	count, err := ExportNDJSONStream(ch, func() error { return errors.New("database is locked") }, &buf)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "stopped after 1: database is locked")
+	assert.Equal(t, 1, count)
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, errors.New("disk full")
+}
+
+func TestExportNDJSONStream_DrainsOnWriteError(t *testing.T) {
+	// Each idea is larger than the write buffer, so the first one fails
+	big := strings.Repeat("x", 8192)
+	ch := make(chan *models.Idea)
+	sent := make(chan int)
+	go func() {
+		n := 0
+		for i := 0; i < 5; i++ {
+			ch <- models.NewIdea(big)
+			n++
+		}
+		close(ch)
+		sent <- n
+	}()
+
+	_, err := ExportNDJSONStream(ch, noStreamErr, failingWriter{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "disk full")
+	assert.Equal(t, 5, <-sent, "the sender should not be left blocked")
+}
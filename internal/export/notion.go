@@ -0,0 +1,322 @@
+package export
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ryacub/telos-idea-matrix/internal/models"
+	"golang.org/x/time/rate"
+)
+
+const (
+	// NotionAPIURL is the base URL of the Notion API
+	NotionAPIURL = "https://api.notion.com/v1"
+
+	// notionVersion is the Notion API version the requests are written for
+	notionVersion = "2022-06-28"
+
+	// notionTextLimit is the most characters Notion accepts in one text value
+	notionTextLimit = 2000
+
+	// notionOptionLimit is the most characters Notion accepts in a select option
+	notionOptionLimit = 100
+)
+
+// Properties of the Notion database that ideas are exported to. Name is the
+// title property every Notion database has; create the others with these
+// names and types.
+const (
+	NotionPropertyName           = "Name"           // title: the idea's content
+	NotionPropertyScore          = "Score"          // number: the final score
+	NotionPropertyRecommendation = "Recommendation" // select
+	NotionPropertyPatterns       = "Patterns"       // multi-select
+)
+
+// NotionConfig configures an export to a Notion database
+type NotionConfig struct {
+	// Token is the integration token; the database must be shared with the integration
+	Token string
+
+	// DatabaseID is the database pages are created in
+	DatabaseID string
+
+	// PageIDs maps idea IDs to the pages they were exported to before. Those
+	// pages are updated instead of duplicated.
+	PageIDs map[string]string
+
+	// OnSynced, when set, is called after each page is created or updated so
+	// its ID can be stored for the next export
+	OnSynced func(idea *models.Idea, pageID string) error
+
+	// BaseURL overrides NotionAPIURL
+	BaseURL string
+
+	// Client sends the requests; nil uses a client with a 30 second timeout
+	Client *http.Client
+
+	// RequestsPerSecond paces requests; zero uses Notion's limit of 3
+	RequestsPerSecond float64
+
+	// MaxRetries is how often a rate-limited or failed request is retried; zero uses 3
+	MaxRetries int
+}
+
+// NotionPageError is an idea that could not be synced
+type NotionPageError struct {
+	IdeaID string
+	Err    error
+}
+
+// NotionExportError reports an export in which some pages failed. The
+// others were synced.
+type NotionExportError struct {
+	Synced int
+	Failed []NotionPageError
+}
+
+func (e *NotionExportError) Error() string {
+	return fmt.Sprintf("%d of %d ideas failed to sync to Notion: idea %s: %v",
+		len(e.Failed), e.Synced+len(e.Failed), e.Failed[0].IdeaID, e.Failed[0].Err)
+}
+
+// errNotionPageNotFound means a page recorded earlier no longer exists
+var errNotionPageNotFound = errors.New("notion page not found")
+
+// ExportNotion creates a page in the Notion database for each idea, or
+// updates the page it was exported to before. The content becomes the page
+// title, with the score, recommendation and patterns as properties.
+//
+// A page that fails doesn't stop the export: the rest are still synced and
+// a *NotionExportError reports how many were synced and which failed.
+func ExportNotion(ideas []*models.Idea, cfg NotionConfig) error {
+	if cfg.Token == "" {
+		return errors.New("notion token is required")
+	}
+	if cfg.DatabaseID == "" {
+		return errors.New("notion database ID is required")
+	}
+
+	client := newNotionClient(cfg)
+	result := &NotionExportError{}
+	for _, idea := range ideas {
+		pageID, err := client.syncPage(context.Background(), idea, cfg.PageIDs[idea.ID])
+		if err == nil && cfg.OnSynced != nil {
+			if err = cfg.OnSynced(idea, pageID); err != nil {
+				err = fmt.Errorf("page %s synced but not recorded: %w", pageID, err)
+			}
+		}
+		if err != nil {
+			result.Failed = append(result.Failed, NotionPageError{IdeaID: idea.ID, Err: err})
+			continue
+		}
+		result.Synced++
+	}
+
+	if len(result.Failed) > 0 {
+		return result
+	}
+	return nil
+}
+
+// notionClient sends paced, retried requests to the Notion API
+type notionClient struct {
+	baseURL    string
+	token      string
+	databaseID string
+	client     *http.Client
+	limiter    *rate.Limiter
+	maxRetries int
+}
+
+func newNotionClient(cfg NotionConfig) *notionClient {
+	c := &notionClient{
+		baseURL:    strings.TrimSuffix(cfg.BaseURL, "/"),
+		token:      cfg.Token,
+		databaseID: cfg.DatabaseID,
+		client:     cfg.Client,
+		maxRetries: cfg.MaxRetries,
+	}
+	if c.baseURL == "" {
+		c.baseURL = NotionAPIURL
+	}
+	if c.client == nil {
+		c.client = &http.Client{Timeout: 30 * time.Second}
+	}
+	perSecond := cfg.RequestsPerSecond
+	if perSecond <= 0 {
+		perSecond = 3
+	}
+	c.limiter = rate.NewLimiter(rate.Limit(perSecond), 1)
+	if c.maxRetries <= 0 {
+		c.maxRetries = 3
+	}
+	return c
+}
+
+// syncPage updates the idea's page, or creates one if it has none or the
+// page has since been deleted, and returns the page ID
+func (c *notionClient) syncPage(ctx context.Context, idea *models.Idea, pageID string) (string, error) {
+	properties := notionProperties(idea)
+
+	if pageID != "" {
+		err := c.do(ctx, http.MethodPatch, "/pages/"+pageID, map[string]any{"properties": properties}, nil)
+		if !errors.Is(err, errNotionPageNotFound) {
+			return pageID, err
+		}
+	}
+
+	var page struct {
+		ID string `json:"id"`
+	}
+	body := map[string]any{
+		"parent":     map[string]string{"database_id": c.databaseID},
+		"properties": properties,
+	}
+	if err := c.do(ctx, http.MethodPost, "/pages", body, &page); err != nil {
+		return "", err
+	}
+	if page.ID == "" {
+		return "", errors.New("notion returned a page without an ID")
+	}
+	return page.ID, nil
+}
+
+// do sends a request, waiting for the rate limiter, and retries when Notion
+// is rate limiting or unavailable. The response is decoded into out if set.
+func (c *notionClient) do(ctx context.Context, method, path string, body, out any) error {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("failed to encode request: %w", err)
+	}
+
+	for attempt := 0; ; attempt++ {
+		if err := c.limiter.Wait(ctx); err != nil {
+			return err
+		}
+
+		req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, bytes.NewReader(payload))
+		if err != nil {
+			return fmt.Errorf("failed to create request: %w", err)
+		}
+		req.Header.Set("Authorization", "Bearer "+c.token)
+		req.Header.Set("Notion-Version", notionVersion)
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := c.client.Do(req)
+		if err != nil {
+			return fmt.Errorf("notion request failed: %w", err)
+		}
+		data, err := io.ReadAll(resp.Body)
+		_ = resp.Body.Close()
+		if err != nil {
+			return fmt.Errorf("failed to read notion response: %w", err)
+		}
+
+		switch {
+		case resp.StatusCode >= 200 && resp.StatusCode < 300:
+			if out != nil {
+				if err := json.Unmarshal(data, out); err != nil {
+					return fmt.Errorf("failed to decode notion response: %w", err)
+				}
+			}
+			return nil
+		case resp.StatusCode == http.StatusNotFound && method == http.MethodPatch:
+			return errNotionPageNotFound
+		case (resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500) && attempt < c.maxRetries:
+			if err := sleepContext(ctx, retryDelay(resp, attempt)); err != nil {
+				return err
+			}
+		default:
+			return notionError(resp.StatusCode, data)
+		}
+	}
+}
+
+// retryDelay honors Notion's Retry-After header, backing off exponentially
+// when there is none
+func retryDelay(resp *http.Response, attempt int) time.Duration {
+	if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	return time.Duration(1<<attempt) * time.Second
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// notionError describes a failed response using Notion's error message
+func notionError(status int, data []byte) error {
+	var apiErr struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+	}
+	if err := json.Unmarshal(data, &apiErr); err == nil && apiErr.Message != "" {
+		return fmt.Errorf("notion returned status %d (%s): %s", status, apiErr.Code, apiErr.Message)
+	}
+	return fmt.Errorf("notion returned status %d", status)
+}
+
+// notionProperties maps an idea to page properties
+func notionProperties(idea *models.Idea) map[string]any {
+	properties := map[string]any{
+		NotionPropertyName: map[string]any{
+			"title": []map[string]any{
+				{"text": map[string]string{"content": truncateRunes(idea.Content, notionTextLimit)}},
+			},
+		},
+		NotionPropertyScore: map[string]any{"number": idea.FinalScore},
+	}
+
+	if idea.Recommendation != "" {
+		properties[NotionPropertyRecommendation] = map[string]any{
+			"select": map[string]string{"name": notionOption(idea.Recommendation)},
+		}
+	} else {
+		properties[NotionPropertyRecommendation] = map[string]any{"select": nil}
+	}
+
+	patterns := make([]map[string]string, 0, len(idea.Patterns))
+	seen := make(map[string]bool, len(idea.Patterns))
+	for _, pattern := range idea.Patterns {
+		name := notionOption(pattern)
+		if name == "" || seen[name] {
+			continue
+		}
+		seen[name] = true
+		patterns = append(patterns, map[string]string{"name": name})
+	}
+	properties[NotionPropertyPatterns] = map[string]any{"multi_select": patterns}
+
+	return properties
+}
+
+// notionOption makes a valid select option name: Notion rejects commas
+// and names over 100 characters
+func notionOption(name string) string {
+	name = strings.TrimSpace(strings.ReplaceAll(name, ",", " "))
+	return truncateRunes(name, notionOptionLimit)
+}
+
+func truncateRunes(s string, limit int) string {
+	runes := []rune(s)
+	if len(runes) <= limit {
+		return s
+	}
+	return string(runes[:limit])
+}
@@ -0,0 +1,200 @@
+package export
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/ryacub/telos-idea-matrix/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeNotion serves the pages endpoints of the Notion API
+type fakeNotion struct {
+	mu      sync.Mutex
+	pages   map[string]map[string]any // page ID to properties
+	created int
+	updated int
+
+	// rateLimited answers this many requests with 429 before serving them
+	rateLimited int
+	// reject fails pages whose title contains it
+	reject string
+}
+
+func newFakeNotion(t *testing.T) (*fakeNotion, *httptest.Server) {
+	t.Helper()
+	notion := &fakeNotion{pages: make(map[string]map[string]any)}
+	server := httptest.NewServer(notion)
+	t.Cleanup(server.Close)
+	return notion, server
+}
+
+func (n *fakeNotion) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if r.Header.Get("Authorization") != "Bearer secret" || r.Header.Get("Notion-Version") == "" {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	if n.rateLimited > 0 {
+		n.rateLimited--
+		w.Header().Set("Retry-After", "0")
+		w.WriteHeader(http.StatusTooManyRequests)
+		return
+	}
+
+	var body struct {
+		Parent     map[string]string `json:"parent"`
+		Properties map[string]any    `json:"properties"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	if n.reject != "" && strings.Contains(fmt.Sprint(body.Properties[NotionPropertyName]), n.reject) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"code": "validation_error", "message": "Recommendation is not a property that exists."}`))
+		return
+	}
+
+	switch {
+	case r.Method == http.MethodPost && r.URL.Path == "/pages":
+		if body.Parent["database_id"] != "db-1" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		n.created++
+		id := fmt.Sprintf("page-%d", n.created)
+		n.pages[id] = body.Properties
+		_, _ = fmt.Fprintf(w, `{"object": "page", "id": %q}`, id)
+	case r.Method == http.MethodPatch && strings.HasPrefix(r.URL.Path, "/pages/"):
+		id := strings.TrimPrefix(r.URL.Path, "/pages/")
+		if _, ok := n.pages[id]; !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		n.updated++
+		n.pages[id] = body.Properties
+		_, _ = fmt.Fprintf(w, `{"object": "page", "id": %q}`, id)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func notionTestConfig(server *httptest.Server) NotionConfig {
+	return NotionConfig{
+		Token:             "secret",
+		DatabaseID:        "db-1",
+		BaseURL:           server.URL,
+		RequestsPerSecond: 1000,
+	}
+}
+
+func TestExportNotion_CreatesThenUpdatesPages(t *testing.T) {
+	notion, server := newFakeNotion(t)
+
+	idea := models.NewIdea("Automate invoices")
+	idea.FinalScore = 8.25
+	idea.Recommendation = "🔥 PRIORITIZE NOW"
+	idea.Patterns = []string{"perfectionism", "context switching, again", "perfectionism"}
+
+	stored := make(map[string]string)
+	cfg := notionTestConfig(server)
+	cfg.OnSynced = func(idea *models.Idea, pageID string) error {
+		stored[idea.ID] = pageID
+		return nil
+	}
+
+	require.NoError(t, ExportNotion([]*models.Idea{idea}, cfg))
+	assert.Equal(t, map[string]string{idea.ID: "page-1"}, stored)
+	assert.Equal(t, 1, notion.created)
+
+	properties, err := json.Marshal(notion.pages["page-1"])
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"Name": {"title": [{"text": {"content": "Automate invoices"}}]},
+		"Score": {"number": 8.25},
+		"Recommendation": {"select": {"name": "🔥 PRIORITIZE NOW"}},
+		"Patterns": {"multi_select": [{"name": "perfectionism"}, {"name": "context switching  again"}]}
+	}`, string(properties))
+
+	// Exporting again with the stored page IDs updates instead of duplicating
+	idea.FinalScore = 6
+	cfg.PageIDs = stored
+	require.NoError(t, ExportNotion([]*models.Idea{idea}, cfg))
+	assert.Equal(t, 1, notion.created)
+	assert.Equal(t, 1, notion.updated)
+	assert.Equal(t, map[string]any{"number": 6.0}, notion.pages["page-1"][NotionPropertyScore])
+
+	// A page deleted in Notion is created again
+	delete(notion.pages, "page-1")
+	require.NoError(t, ExportNotion([]*models.Idea{idea}, cfg))
+	assert.Equal(t, "page-2", stored[idea.ID])
+}
+
+func TestExportNotion_RetriesRateLimitedRequests(t *testing.T) {
+	notion, server := newFakeNotion(t)
+	notion.rateLimited = 2
+
+	require.NoError(t, ExportNotion([]*models.Idea{models.NewIdea("Start a podcast")}, notionTestConfig(server)))
+	assert.Equal(t, 1, notion.created)
+
+	// Giving up once the retries run out
+	notion.rateLimited = 10
+	cfg := notionTestConfig(server)
+	cfg.MaxRetries = 1
+	err := ExportNotion([]*models.Idea{models.NewIdea("Write a newsletter")}, cfg)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "status 429")
+}
+
+func TestExportNotion_ReportsPartialFailures(t *testing.T) {
+	notion, server := newFakeNotion(t)
+	notion.reject = "podcast"
+
+	ideas := []*models.Idea{
+		models.NewIdea("Automate invoices"),
+		models.NewIdea("Start a podcast"),
+		models.NewIdea("Write a newsletter"),
+	}
+	cfg := notionTestConfig(server)
+	cfg.OnSynced = func(idea *models.Idea, pageID string) error {
+		if idea.Content == "Write a newsletter" {
+			return errors.New("database is locked")
+		}
+		return nil
+	}
+
+	err := ExportNotion(ideas, cfg)
+
+	var exportErr *NotionExportError
+	require.ErrorAs(t, err, &exportErr)
+	assert.Equal(t, 1, exportErr.Synced)
+	require.Len(t, exportErr.Failed, 2)
+	assert.Equal(t, ideas[1].ID, exportErr.Failed[0].IdeaID)
+	assert.Contains(t, exportErr.Failed[0].Err.Error(), "Recommendation is not a property that exists")
+	assert.Equal(t, ideas[2].ID, exportErr.Failed[1].IdeaID)
+	assert.Contains(t, exportErr.Failed[1].Err.Error(), "synced but not recorded")
+	assert.Equal(t, 2, notion.created, "the failure in the middle should not stop the export")
+}
+
+func TestExportNotion_RequiresTokenAndDatabase(t *testing.T) {
+	_, server := newFakeNotion(t)
+	ideas := []*models.Idea{models.NewIdea("Start a podcast")}
+
+	cfg := notionTestConfig(server)
+	cfg.Token = ""
+	assert.Error(t, ExportNotion(ideas, cfg))
+
+	cfg = notionTestConfig(server)
+	cfg.DatabaseID = ""
+	assert.Error(t, ExportNotion(ideas, cfg))
+}
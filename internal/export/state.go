@@ -0,0 +1,86 @@
+package export
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// StateFile is the name of the file, kept beside the database, that
+// remembers when each destination was last exported to
+const StateFile = "export-state.json"
+
+// State remembers when each export destination, such as a file path or a
+// Notion database, last received a successful export, so the next export
+// can send only what changed since
+type State struct {
+	path         string
+	Destinations map[string]time.Time `json:"destinations"`
+}
+
+// StatePath returns the state file location for the database at dbPath
+func StatePath(dbPath string) string {
+	return filepath.Join(filepath.Dir(dbPath), StateFile)
+}
+
+// LoadState reads the state file at path. A missing file is an empty state.
+func LoadState(path string) (*State, error) {
+	state := &State{path: path, Destinations: map[string]time.Time{}}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return state, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read export state: %w", err)
+	}
+	if err := json.Unmarshal(data, state); err != nil {
+		return nil, fmt.Errorf("parse export state %s: %w", path, err)
+	}
+	if state.Destinations == nil {
+		state.Destinations = map[string]time.Time{}
+	}
+	return state, nil
+}
+
+// LastExport returns when destination was last exported to, and false if never
+func (s *State) LastExport(destination string) (time.Time, bool) {
+	t, ok := s.Destinations[destination]
+	return t, ok
+}
+
+// Advance records an export to destination that started at t, and saves
+// the state. Starting times are recorded, so ideas changed while the
+// export ran are sent again next time rather than missed.
+func (s *State) Advance(destination string, t time.Time) error {
+	s.Destinations[destination] = t.UTC()
+
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode export state: %w", err)
+	}
+
+	// Write a temporary file and rename it over the old one, so an
+	// interrupted save never leaves a truncated state behind
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), StateFile+".*")
+	if err != nil {
+		return fmt.Errorf("save export state: %w", err)
+	}
+	if _, err := tmp.Write(append(data, '\n')); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+		return fmt.Errorf("save export state: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		_ = os.Remove(tmp.Name())
+		return fmt.Errorf("save export state: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), s.path); err != nil {
+		_ = os.Remove(tmp.Name())
+		return fmt.Errorf("save export state: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+package export
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestState_AdvanceAndReload(t *testing.T) {
+	dir := t.TempDir()
+	path := StatePath(filepath.Join(dir, "ideas.db"))
+	assert.Equal(t, filepath.Join(dir, StateFile), path)
+
+	state, err := LoadState(path)
+	require.NoError(t, err, "a missing file is an empty state")
+	_, ok := state.LastExport("/tmp/ideas.csv")
+	assert.False(t, ok)
+
+	at := time.Date(2025, 3, 4, 10, 30, 15, 500, time.FixedZone("EST", -5*3600))
+	require.NoError(t, state.Advance("/tmp/ideas.csv", at))
+	require.NoError(t, state.Advance("notion:db1", at.Add(time.Hour)))
+
+	reloaded, err := LoadState(path)
+	require.NoError(t, err)
+	last, ok := reloaded.LastExport("/tmp/ideas.csv")
+	require.True(t, ok)
+	assert.True(t, at.Equal(last))
+	assert.Equal(t, time.UTC, last.Location())
+	last, ok = reloaded.LastExport("notion:db1")
+	require.True(t, ok)
+	assert.True(t, at.Add(time.Hour).Equal(last))
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Len(t, entries, 1, "no temporary files are left behind")
+}
+
+func TestLoadState_Corrupt(t *testing.T) {
+	path := filepath.Join(t.TempDir(), StateFile)
+	require.NoError(t, os.WriteFile(path, []byte("{not json"), 0600))
+
+	_, err := LoadState(path)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "parse export state")
+}
@@ -0,0 +1,304 @@
+// Package export writes ideas to file formats for sharing outside the tool.
+package export
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ryacub/telos-idea-matrix/internal/analytics"
+	"github.com/ryacub/telos-idea-matrix/internal/models"
+	"github.com/xuri/excelize/v2"
+)
+
+// Cell styles, indexes into cellStyles
+const (
+	styleDefault = iota
+	styleHeader
+	styleWrap
+	styleScore
+	styleDate
+	styleTitle
+)
+
+// cellStyles defines the style* constants: default, bold header on a blue
+// fill, wrapped text, one-decimal score, date-time, and a large bold title
+var cellStyles = []*excelize.Style{
+	styleDefault: nil,
+	styleHeader: {
+		Font: &excelize.Font{Bold: true, Color: "FFFFFF"},
+		Fill: excelize.Fill{Type: "pattern", Pattern: 1, Color: []string{"2F5597"}},
+	},
+	styleWrap:  {Alignment: &excelize.Alignment{Vertical: "top", WrapText: true}},
+	styleScore: {CustomNumFmt: stringPtr("0.0")},
+	styleDate:  {CustomNumFmt: stringPtr("yyyy-mm-dd hh:mm")},
+	styleTitle: {Font: &excelize.Font{Bold: true, Size: 14}},
+}
+
+// Sheet names, in workbook order
+const (
+	ideasSheetName   = "Ideas"
+	summarySheetName = "Summary"
+)
+
+// scoreBucketOrder lists the analytics score buckets from lowest to highest
+var scoreBucketOrder = []string{"0-2", "2-4", "4-6", "6-8", "8-10"}
+
+// ideaColumns follows the CSV export's column order, without the raw analysis JSON
+var ideaColumns = []column{
+	{"ID", 38},
+	{"Content", 60},
+	{"Raw Score", 11},
+	{"Final Score", 11},
+	{"Patterns", 30},
+	{"Recommendation", 32},
+	{"Created At", 18},
+	{"Status", 11},
+	{"Notes", 40},
+}
+
+type column struct {
+	title string
+	width float64
+}
+
+// cell is a single spreadsheet value, a string or a float64
+type cell struct {
+	value any
+	style int
+}
+
+func textCell(s string, style int) cell {
+	return cell{value: s, style: style}
+}
+
+func numberCell(n float64, style int) cell {
+	return cell{value: n, style: style}
+}
+
+// sheet is the content of one worksheet. With header set, the first row is
+// frozen and given an autofilter.
+type sheet struct {
+	name   string
+	rows   [][]cell
+	widths []float64
+	header bool
+}
+
+// ExportXLSX writes ideas to an Excel workbook with an "Ideas" sheet of one row
+// per idea and a "Summary" sheet with the score distribution and pattern counts.
+func ExportXLSX(ideas []*models.Idea, filename string) error {
+	wb := excelize.NewFile()
+	// Close only cleans up temporary files; the workbook is written by then
+	defer func() { _ = wb.Close() }()
+
+	styles, err := addStyles(wb)
+	if err != nil {
+		return err
+	}
+
+	for i, s := range []sheet{ideasSheet(ideas), summarySheet(ideas)} {
+		if i == 0 {
+			err = wb.SetSheetName(wb.GetSheetName(0), s.name)
+		} else {
+			_, err = wb.NewSheet(s.name)
+		}
+		if err != nil {
+			return fmt.Errorf("add %s sheet: %w", s.name, err)
+		}
+		if err := writeSheet(wb, s, styles); err != nil {
+			return fmt.Errorf("write %s sheet: %w", s.name, err)
+		}
+	}
+
+	file, err := os.Create(filename)
+	if err != nil {
+		return fmt.Errorf("create file: %w", err)
+	}
+	if _, err := wb.WriteTo(file); err != nil {
+		_ = file.Close()
+		return fmt.Errorf("write workbook: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("close file: %w", err)
+	}
+	return nil
+}
+
+// addStyles registers cellStyles with wb, returning their style IDs by index
+func addStyles(wb *excelize.File) ([]int, error) {
+	ids := make([]int, len(cellStyles))
+	for i, style := range cellStyles {
+		if style == nil {
+			continue
+		}
+		id, err := wb.NewStyle(style)
+		if err != nil {
+			return nil, fmt.Errorf("add cell style: %w", err)
+		}
+		ids[i] = id
+	}
+	return ids, nil
+}
+
+// writeSheet fills the worksheet s.name with s's rows, widths and header
+func writeSheet(wb *excelize.File, s sheet, styles []int) error {
+	for i, w := range s.widths {
+		col, err := excelize.ColumnNumberToName(i + 1)
+		if err != nil {
+			return err
+		}
+		if err := wb.SetColWidth(s.name, col, col, w); err != nil {
+			return err
+		}
+	}
+
+	for r, row := range s.rows {
+		for c, value := range row {
+			ref, err := excelize.CoordinatesToCellName(c+1, r+1)
+			if err != nil {
+				return err
+			}
+			if err := wb.SetCellValue(s.name, ref, value.value); err != nil {
+				return err
+			}
+			if value.style == styleDefault {
+				continue
+			}
+			if err := wb.SetCellStyle(s.name, ref, ref, styles[value.style]); err != nil {
+				return err
+			}
+		}
+	}
+
+	if !s.header || len(s.rows) == 0 {
+		return nil
+	}
+	if err := wb.SetPanes(s.name, &excelize.Panes{
+		Freeze: true, YSplit: 1, TopLeftCell: "A2", ActivePane: "bottomLeft",
+	}); err != nil {
+		return err
+	}
+	last, err := excelize.CoordinatesToCellName(len(s.rows[0]), len(s.rows))
+	if err != nil {
+		return err
+	}
+	return wb.AutoFilter(s.name, "A1:"+last, nil)
+}
+
+// ideasSheet builds the worksheet with one row per idea
+func ideasSheet(ideas []*models.Idea) sheet {
+	rows := make([][]cell, 0, len(ideas)+1)
+
+	header := make([]cell, len(ideaColumns))
+	widths := make([]float64, len(ideaColumns))
+	for i, c := range ideaColumns {
+		header[i] = textCell(c.title, styleHeader)
+		widths[i] = c.width
+	}
+	rows = append(rows, header)
+
+	for _, idea := range ideas {
+		rows = append(rows, []cell{
+			textCell(idea.ID, styleDefault),
+			textCell(idea.Content, styleWrap),
+			numberCell(idea.RawScore, styleScore),
+			numberCell(idea.FinalScore, styleScore),
+			textCell(strings.Join(idea.Patterns, ", "), styleWrap),
+			textCell(idea.Recommendation, styleDefault),
+			numberCell(excelSerialDate(idea.CreatedAt), styleDate),
+			textCell(idea.Status, styleDefault),
+			textCell(idea.Notes, styleWrap),
+		})
+	}
+
+	return sheet{name: ideasSheetName, rows: rows, widths: widths, header: true}
+}
+
+// summarySheet builds the worksheet with score distribution and pattern counts
+func summarySheet(ideas []*models.Idea) sheet {
+	distribution := analytics.NewService(nil).CalculateScoreDistribution(ideas)
+
+	total := 0.0
+	for _, idea := range ideas {
+		total += idea.FinalScore
+	}
+	average := 0.0
+	if len(ideas) > 0 {
+		average = total / float64(len(ideas))
+	}
+
+	rows := [][]cell{
+		{textCell("Idea Summary", styleTitle)},
+		{textCell("Total ideas", styleDefault), numberCell(float64(len(ideas)), styleDefault)},
+		{textCell("Average score", styleDefault), numberCell(average, styleScore)},
+		{},
+		{textCell("Score Range", styleHeader), textCell("Ideas", styleHeader)},
+	}
+	for _, bucket := range scoreBucketOrder {
+		rows = append(rows, []cell{
+			textCell(bucket, styleDefault),
+			numberCell(float64(distribution.Buckets[bucket]), styleDefault),
+		})
+	}
+
+	rows = append(rows, []cell{}, []cell{textCell("Pattern", styleHeader), textCell("Ideas", styleHeader)})
+	for _, pc := range countPatterns(ideas) {
+		rows = append(rows, []cell{
+			textCell(pc.pattern, styleDefault),
+			numberCell(float64(pc.count), styleDefault),
+		})
+	}
+
+	return sheet{name: summarySheetName, rows: rows, widths: []float64{32, 12}}
+}
+
+type patternCount struct {
+	pattern string
+	count   int
+}
+
+// countPatterns counts how many ideas carry each pattern, most common first.
+// Case and whitespace variants of a pattern are counted together.
+func countPatterns(ideas []*models.Idea) []patternCount {
+	counts := make(map[string]int)
+	names := make(map[string]string)
+	for _, idea := range ideas {
+		for _, p := range analytics.CanonicalizePatternsForDisplay(idea.Patterns) {
+			key := strings.ToLower(p)
+			if _, ok := names[key]; !ok {
+				names[key] = p
+			}
+			counts[key]++
+		}
+	}
+
+	result := make([]patternCount, 0, len(counts))
+	for key, count := range counts {
+		result = append(result, patternCount{pattern: names[key], count: count})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].count != result[j].count {
+			return result[i].count > result[j].count
+		}
+		return result[i].pattern < result[j].pattern
+	})
+	return result
+}
+
+// excelSerialDate converts a time to an Excel date serial number (days since 1899-12-30)
+func excelSerialDate(t time.Time) float64 {
+	if t.IsZero() {
+		return 0
+	}
+	epoch := time.Date(1899, 12, 30, 0, 0, 0, 0, time.UTC)
+	local := t.Local()
+	wall := time.Date(local.Year(), local.Month(), local.Day(), local.Hour(), local.Minute(), local.Second(), 0, time.UTC)
+	return wall.Sub(epoch).Hours() / 24
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
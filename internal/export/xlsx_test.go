@@ -0,0 +1,74 @@
+package export
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ryacub/telos-idea-matrix/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xuri/excelize/v2"
+)
+
+func TestExportXLSX_WritesIdeasAndSummary(t *testing.T) {
+	first := models.NewIdea(`Automate <invoices> & "receipts"`)
+	first.RawScore = 8.5
+	first.FinalScore = 8.25
+	first.Patterns = []string{"context-switching", "Perfectionism"}
+	first.Recommendation = "GOOD FIT - Worth pursuing"
+
+	second := models.NewIdea("Start a podcast")
+	second.FinalScore = 3.5
+	second.Patterns = []string{"perfectionism"}
+	second.Notes = "Recorded two episodes"
+
+	path := filepath.Join(t.TempDir(), "ideas.xlsx")
+	require.NoError(t, ExportXLSX([]*models.Idea{first, second}, path))
+
+	wb, err := excelize.OpenFile(path, excelize.Options{RawCellValue: true})
+	require.NoError(t, err)
+	defer wb.Close()
+	assert.Equal(t, []string{"Ideas", "Summary"}, wb.GetSheetList())
+
+	ideas, err := wb.GetRows("Ideas")
+	require.NoError(t, err)
+	require.Len(t, ideas, 3)
+	assert.Equal(t, "Final Score", ideas[0][3])
+	assert.Equal(t, first.ID, ideas[1][0])
+	assert.Equal(t, `Automate <invoices> & "receipts"`, ideas[1][1])
+	assert.Equal(t, "8.25", ideas[1][3])
+	assert.Equal(t, "Recorded two episodes", ideas[2][8])
+
+	panes, err := wb.GetPanes("Ideas")
+	require.NoError(t, err)
+	assert.True(t, panes.Freeze, "the header row should stay in view")
+	assert.Equal(t, 1, panes.YSplit)
+	require.Len(t, wb.GetDefinedName(), 1)
+	assert.Equal(t, "_xlnm._FilterDatabase", wb.GetDefinedName()[0].Name)
+	assert.Equal(t, "'Ideas'!$A$1:$I$3", wb.GetDefinedName()[0].RefersTo)
+
+	summary, err := wb.GetRows("Summary")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"8-10", "1"}, summary[9])
+	assert.Equal(t, []string{"2-4", "1"}, summary[6])
+	// Pattern variants are counted together, most common first
+	assert.Equal(t, []string{"Perfectionism", "2"}, summary[12])
+	assert.Equal(t, []string{"context-switching", "1"}, summary[13])
+}
+
+func TestExportXLSX_AnyExtension(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ideas.out")
+	require.NoError(t, ExportXLSX([]*models.Idea{models.NewIdea("Start a podcast")}, path))
+
+	wb, err := excelize.OpenFile(path)
+	require.NoError(t, err)
+	defer wb.Close()
+	assert.Equal(t, []string{"Ideas", "Summary"}, wb.GetSheetList())
+}
+
+func TestExcelSerialDate(t *testing.T) {
+	local := time.Date(2024, 1, 1, 12, 0, 0, 0, time.Local)
+	assert.Equal(t, 45292.5, excelSerialDate(local))
+	assert.Equal(t, 0.0, excelSerialDate(time.Time{}))
+}
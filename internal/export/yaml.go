@@ -0,0 +1,173 @@
+package export
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/ryacub/telos-idea-matrix/internal/models"
+	"gopkg.in/yaml.v3"
+)
+
+// yamlIdea is the YAML form of an idea. Keys match the JSON export's.
+type yamlIdea struct {
+	ID              string     `yaml:"id"`
+	Content         string     `yaml:"content"`
+	RawScore        float64    `yaml:"raw_score,omitempty"`
+	FinalScore      float64    `yaml:"final_score,omitempty"`
+	Patterns        []string   `yaml:"patterns,omitempty"`
+	Tags            []string   `yaml:"tags,omitempty"`
+	Recommendation  string     `yaml:"recommendation,omitempty"`
+	AnalysisDetails string     `yaml:"analysis_details,omitempty"`
+	CreatedAt       time.Time  `yaml:"created_at"`
+	ReviewedAt      *time.Time `yaml:"reviewed_at,omitempty"`
+	UpdatedAt       time.Time  `yaml:"updated_at"` // Informational; the repository sets it on import
+	Status          string     `yaml:"status"`
+	Trigger         string     `yaml:"trigger,omitempty"`
+	ArchiveReason   string     `yaml:"archive_reason,omitempty"`
+	Profile         string     `yaml:"profile,omitempty"`
+	TelosVersion    string     `yaml:"telos_version,omitempty"`
+	Notes           string     `yaml:"notes,omitempty"`
+}
+
+func toYAMLIdea(idea *models.Idea) yamlIdea {
+	return yamlIdea{
+		ID:              idea.ID,
+		Content:         idea.Content,
+		RawScore:        idea.RawScore,
+		FinalScore:      idea.FinalScore,
+		Patterns:        idea.Patterns,
+		Tags:            idea.Tags,
+		Recommendation:  idea.Recommendation,
+		AnalysisDetails: idea.AnalysisDetails,
+		CreatedAt:       idea.CreatedAt,
+		ReviewedAt:      idea.ReviewedAt,
+		UpdatedAt:       idea.UpdatedAt,
+		Status:          idea.Status,
+		Trigger:         idea.Trigger,
+		ArchiveReason:   idea.ArchiveReason,
+		Profile:         idea.Profile,
+		TelosVersion:    idea.TelosVersion,
+		Notes:           idea.Notes,
+	}
+}
+
+// toIdea converts back to an idea. Hand-written entries may leave out the
+// id, created_at, status and profile, which get the defaults of a new idea.
+func (y yamlIdea) toIdea() *models.Idea {
+	idea := models.NewIdea(y.Content)
+	if y.ID != "" {
+		idea.ID = y.ID
+	}
+	if !y.CreatedAt.IsZero() {
+		idea.CreatedAt = y.CreatedAt.UTC()
+	}
+	if y.Status != "" {
+		idea.Status = y.Status
+	}
+	if y.Profile != "" {
+		idea.Profile = y.Profile
+	}
+	idea.RawScore = y.RawScore
+	idea.FinalScore = y.FinalScore
+	idea.Patterns = y.Patterns
+	idea.Tags = y.Tags
+	idea.Recommendation = y.Recommendation
+	idea.AnalysisDetails = y.AnalysisDetails
+	idea.ReviewedAt = y.ReviewedAt
+	idea.Trigger = y.Trigger
+	idea.ArchiveReason = y.ArchiveReason
+	idea.TelosVersion = y.TelosVersion
+	idea.Notes = y.Notes
+	return idea
+}
+
+// ExportYAML writes ideas to a file as a YAML sequence
+func ExportYAML(ideas []*models.Idea, filename string) error {
+	values := make([]yamlIdea, len(ideas))
+	for i, idea := range ideas {
+		values[i] = toYAMLIdea(idea)
+	}
+
+	file, err := os.Create(filename)
+	if err != nil {
+		return fmt.Errorf("create file: %w", err)
+	}
+	defer func() {
+		if err := file.Close(); err != nil {
+			log.Warn().Err(err).Msg("failed to close file")
+		}
+	}()
+
+	encoder := yaml.NewEncoder(file)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(values); err != nil {
+		return fmt.Errorf("encode yaml: %w", err)
+	}
+	if err := encoder.Close(); err != nil {
+		return fmt.Errorf("encode yaml: %w", err)
+	}
+
+	return nil
+}
+
+// ImportYAML reads ideas from a YAML file. The file may hold several
+// documents separated by "---", each either a sequence of ideas, as
+// ExportYAML writes, or a single idea.
+func ImportYAML(filename string) ([]*models.Idea, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, fmt.Errorf("open file: %w", err)
+	}
+	defer func() {
+		if err := file.Close(); err != nil {
+			log.Warn().Err(err).Msg("failed to close file")
+		}
+	}()
+
+	var ideas []*models.Idea
+	decoder := yaml.NewDecoder(file)
+	for doc := 1; ; doc++ {
+		var node yaml.Node
+		if err := decoder.Decode(&node); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, fmt.Errorf("document %d: decode yaml: %w", doc, err)
+		}
+		if len(node.Content) == 0 {
+			continue
+		}
+
+		var values []yamlIdea
+		switch root := node.Content[0]; root.Kind {
+		case yaml.SequenceNode:
+			if err := root.Decode(&values); err != nil {
+				return nil, fmt.Errorf("document %d: %w", doc, err)
+			}
+		case yaml.MappingNode:
+			var value yamlIdea
+			if err := root.Decode(&value); err != nil {
+				return nil, fmt.Errorf("document %d: %w", doc, err)
+			}
+			values = append(values, value)
+		case yaml.ScalarNode:
+			// An empty document, such as a trailing "---", has a null root
+			if root.Tag == "!!null" {
+				continue
+			}
+			return nil, fmt.Errorf("document %d: expected an idea or a list of ideas", doc)
+		default:
+			return nil, fmt.Errorf("document %d: expected an idea or a list of ideas", doc)
+		}
+
+		for _, value := range values {
+			ideas = append(ideas, value.toIdea())
+		}
+	}
+
+	return ideas, nil
+}
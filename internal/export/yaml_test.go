@@ -0,0 +1,130 @@
+package export
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ryacub/telos-idea-matrix/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportYAML_RoundTrip(t *testing.T) {
+	reviewed := time.Date(2025, 3, 2, 9, 30, 0, 0, time.UTC)
+	first := models.NewIdea("Automate invoices\nand receipts: a CLI")
+	first.RawScore = 7.5
+	first.FinalScore = 8.25
+	first.Patterns = []string{"perfectionism", "context switching"}
+	first.Tags = []string{"work"}
+	first.Recommendation = "🔥 PRIORITIZE NOW"
+	first.AnalysisDetails = `{"mission_alignment": 3.5}`
+	first.ReviewedAt = &reviewed
+	first.Trigger = "Tax season"
+	first.TelosVersion = "abc123"
+	first.Notes = "Started prototyping 2025-06"
+	second := models.NewIdea("Start a podcast")
+	second.Archive("No time")
+
+	path := filepath.Join(t.TempDir(), "ideas.yaml")
+	require.NoError(t, ExportYAML([]*models.Idea{first, second}, path))
+
+	ideas, err := ImportYAML(path)
+	require.NoError(t, err)
+	require.Len(t, ideas, 2)
+
+	for i, want := range []*models.Idea{first, second} {
+		got := ideas[i]
+		assert.Equal(t, want.ID, got.ID)
+		assert.Equal(t, want.Content, got.Content)
+		assert.Equal(t, want.RawScore, got.RawScore)
+		assert.Equal(t, want.FinalScore, got.FinalScore)
+		assert.Equal(t, want.Patterns, got.Patterns)
+		assert.Equal(t, want.Tags, got.Tags)
+		assert.Equal(t, want.Recommendation, got.Recommendation)
+		assert.Equal(t, want.AnalysisDetails, got.AnalysisDetails)
+		assert.True(t, want.CreatedAt.Equal(got.CreatedAt), "created_at %v, want %v", got.CreatedAt, want.CreatedAt)
+		assert.Equal(t, want.Status, got.Status)
+		assert.Equal(t, want.Trigger, got.Trigger)
+		assert.Equal(t, want.ArchiveReason, got.ArchiveReason)
+		assert.Equal(t, want.Profile, got.Profile)
+		assert.Equal(t, want.TelosVersion, got.TelosVersion)
+		assert.Equal(t, want.Notes, got.Notes)
+	}
+	require.NotNil(t, ideas[0].ReviewedAt)
+	assert.True(t, reviewed.Equal(*ideas[0].ReviewedAt))
+	assert.Nil(t, ideas[1].ReviewedAt)
+}
+
+func TestExportYAML_UsesJSONFieldNames(t *testing.T) {
+	idea := models.NewIdea("Start a podcast")
+	idea.FinalScore = 6.5
+
+	path := filepath.Join(t.TempDir(), "ideas.yml")
+	require.NoError(t, ExportYAML([]*models.Idea{idea}, path))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "- id: "+idea.ID)
+	assert.Contains(t, string(data), "final_score: 6.5")
+	assert.Contains(t, string(data), "created_at: ")
+}
+
+func TestImportYAML_MultipleDocuments(t *testing.T) {
+	content := `- id: first
+  content: Automate invoices
+  final_score: 8
+  created_at: 2025-03-01T10:00:00Z
+  status: active
+- id: second
+  content: Start a podcast
+---
+content: Write a newsletter
+tags: [writing]
+---
+`
+	path := filepath.Join(t.TempDir(), "ideas.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+	ideas, err := ImportYAML(path)
+	require.NoError(t, err)
+	require.Len(t, ideas, 3)
+
+	assert.Equal(t, "first", ideas[0].ID)
+	assert.Equal(t, 8.0, ideas[0].FinalScore)
+	assert.Equal(t, time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC), ideas[0].CreatedAt)
+	assert.Equal(t, "second", ideas[1].ID)
+
+	// A hand-written idea gets the defaults of a new one
+	assert.Equal(t, "Write a newsletter", ideas[2].Content)
+	assert.Equal(t, []string{"writing"}, ideas[2].Tags)
+	assert.NotEmpty(t, ideas[2].ID)
+	assert.Equal(t, "active", ideas[2].Status)
+	assert.Equal(t, models.DefaultProfile, ideas[2].Profile)
+	assert.False(t, ideas[2].CreatedAt.IsZero())
+}
+
+func TestImportYAML_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{"scalar document", "just some text\n"},
+		{"malformed", "- id: first\n  content: [unclosed\n"},
+		{"wrong field type", "- content: Start a podcast\n  final_score: high\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "ideas.yaml")
+			require.NoError(t, os.WriteFile(path, []byte(tt.content), 0o600))
+
+			_, err := ImportYAML(path)
+			assert.Error(t, err)
+		})
+	}
+
+	_, err := ImportYAML(filepath.Join(t.TempDir(), "missing.yaml"))
+	assert.Error(t, err)
+}
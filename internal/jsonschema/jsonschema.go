@@ -0,0 +1,228 @@
+// Package jsonschema generates JSON Schema documents for Go types by
+// reflecting over their JSON-tagged fields.
+package jsonschema
+
+import (
+	"reflect"
+	"strings"
+	"time"
+)
+
+// Draft is the JSON Schema dialect generated documents declare
+const Draft = "https://json-schema.org/draft/2020-12/schema"
+
+// Schema is a JSON Schema document or subschema. Only the keywords the
+// generator produces are modeled.
+type Schema struct {
+	Schema               string             `json:"$schema,omitempty"`
+	Ref                  string             `json:"$ref,omitempty"`
+	Title                string             `json:"title,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Type                 any                `json:"type,omitempty"` // A type name, or a list of them when null is allowed
+	Format               string             `json:"format,omitempty"`
+	Enum                 []any              `json:"enum,omitempty"`
+	Minimum              *float64           `json:"minimum,omitempty"`
+	Maximum              *float64           `json:"maximum,omitempty"`
+	MinLength            *int               `json:"minLength,omitempty"`
+	MaxLength            *int               `json:"maxLength,omitempty"`
+	MinItems             *int               `json:"minItems,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	AnyOf                []*Schema          `json:"anyOf,omitempty"`
+	Defs                 map[string]*Schema `json:"$defs,omitempty"`
+}
+
+// Constraint refines a generated schema with rules the Go type can't
+// express, such as allowed values or lengths
+type Constraint func(*Schema)
+
+// Constraints refine the schemas of struct types, keyed by the type and then
+// by JSON field name. The empty field name refines the struct's own schema.
+type Constraints map[reflect.Type]map[string]Constraint
+
+// Generate returns a schema for the type of v, which must be a struct or a
+// pointer to one. Fields that are always marshaled are required, and nested
+// structs are described once under $defs.
+func Generate(v any, constraints Constraints) *Schema {
+	t := reflect.TypeOf(v)
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	g := &generator{constraints: constraints, defs: make(map[string]*Schema)}
+	root := g.structSchema(t)
+	root.Schema = Draft
+	root.Title = t.Name()
+	if len(g.defs) > 0 {
+		root.Defs = g.defs
+	}
+	return root
+}
+
+type generator struct {
+	constraints Constraints
+	defs        map[string]*Schema
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// typeSchema describes a value of type t
+func (g *generator) typeSchema(t reflect.Type) *Schema {
+	if t == timeType {
+		return &Schema{Type: "string", Format: "date-time"}
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		return g.typeSchema(t.Elem())
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		return &Schema{Type: "array", Items: g.typeSchema(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: g.typeSchema(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return g.structSchema(t)
+		}
+		// Reserve the name before describing the struct so a type that
+		// refers to itself ends in a reference instead of recursing
+		if _, ok := g.defs[t.Name()]; !ok {
+			g.defs[t.Name()] = &Schema{}
+			g.defs[t.Name()] = g.structSchema(t)
+		}
+		return &Schema{Ref: "#/$defs/" + t.Name()}
+	default:
+		// Interfaces and anything else accept any value
+		return &Schema{}
+	}
+}
+
+// structSchema describes the JSON object a struct marshals to
+func (g *generator) structSchema(t reflect.Type) *Schema {
+	s := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+	g.addFields(s, t)
+	if c := g.constraints[t][""]; c != nil {
+		c(s)
+	}
+	return s
+}
+
+// addFields adds the properties of t's fields to s, promoting the fields of
+// untagged embedded structs the way encoding/json does
+func (g *generator) addFields(s *Schema, t reflect.Type) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, options, _ := strings.Cut(tag, ",")
+
+		if field.Anonymous && name == "" {
+			embedded := field.Type
+			if embedded.Kind() == reflect.Pointer {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				g.addFields(s, embedded)
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		omitEmpty := strings.Contains(","+options+",", ",omitempty,")
+		prop := g.typeSchema(field.Type)
+		if !omitEmpty {
+			s.Required = append(s.Required, name)
+			if nilable(field.Type) {
+				prop = allowNull(prop)
+			}
+		}
+		if c := g.constraints[t][name]; c != nil {
+			c(prop)
+		}
+		s.Properties[name] = prop
+	}
+}
+
+// nilable reports whether a value of type t marshals to null when nil
+func nilable(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Pointer, reflect.Slice, reflect.Map, reflect.Interface:
+		return true
+	}
+	return false
+}
+
+// allowNull widens s to also accept null
+func allowNull(s *Schema) *Schema {
+	if name, ok := s.Type.(string); ok {
+		s.Type = []string{name, "null"}
+		return s
+	}
+	if s.Ref != "" {
+		return &Schema{AnyOf: []*Schema{s, {Type: "null"}}}
+	}
+	return s
+}
+
+// Enum restricts a value to the given values
+func Enum[T any](values ...T) Constraint {
+	return func(s *Schema) {
+		s.Enum = make([]any, len(values))
+		for i, v := range values {
+			s.Enum[i] = v
+		}
+	}
+}
+
+// Length restricts a string to between min and max characters; a negative
+// bound is left open
+func Length(min, max int) Constraint {
+	return func(s *Schema) {
+		if min >= 0 {
+			s.MinLength = &min
+		}
+		if max >= 0 {
+			s.MaxLength = &max
+		}
+	}
+}
+
+// Range restricts a number to between min and max, inclusive
+func Range(min, max float64) Constraint {
+	return func(s *Schema) {
+		s.Minimum = &min
+		s.Maximum = &max
+	}
+}
+
+// MinItems requires an array of at least n items, which rules out null
+func MinItems(n int) Constraint {
+	return func(s *Schema) {
+		s.Type = "array"
+		s.MinItems = &n
+	}
+}
+
+// Describe sets the description of a value
+func Describe(description string) Constraint {
+	return func(s *Schema) {
+		s.Description = description
+	}
+}
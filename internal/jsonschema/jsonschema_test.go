@@ -0,0 +1,119 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/ryacub/telos-idea-matrix/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestForModel_Idea(t *testing.T) {
+	s, err := ForModel("idea")
+	require.NoError(t, err)
+
+	assert.Equal(t, Draft, s.Schema)
+	assert.Equal(t, "Idea", s.Title)
+	assert.Equal(t, "object", s.Type)
+	assert.ElementsMatch(t, []string{"id", "content", "created_at", "updated_at", "status"}, s.Required,
+		"fields without omitempty are always present")
+
+	assert.Equal(t, "string", s.Properties["id"].Type)
+	assert.Equal(t, "number", s.Properties["final_score"].Type)
+	assert.Equal(t, "integer", s.Properties["version"].Type)
+	assert.Equal(t, &Schema{Type: "array", Items: &Schema{Type: "string"}}, s.Properties["patterns"])
+	assert.Equal(t, &Schema{Type: "string", Format: "date-time"}, s.Properties["created_at"])
+	assert.Equal(t, &Schema{Type: "string", Format: "date-time"}, s.Properties["reviewed_at"])
+
+	assert.Equal(t, []any{"active", "archived", "deleted"}, s.Properties["status"].Enum)
+	assert.Nil(t, s.Properties["recommendation"].Enum, "LLMs and custom providers word recommendations their own way")
+	assert.Contains(t, s.Properties["recommendation"].Description, "prioritize, pursue, consider, avoid")
+	assert.Equal(t, 200, *s.Properties["trigger"].MaxLength)
+	assert.Equal(t, models.MaxNotesLength, *s.Properties["notes"].MaxLength)
+	assert.Equal(t, 3, *s.Properties["title"].MinLength)
+	require.Len(t, s.AnyOf, 2, "a title or content is required")
+
+	assert.Equal(t, "#/$defs/Analysis", s.Properties["analysis"].Ref)
+	require.Contains(t, s.Defs, "DetectedPattern")
+	confidence := s.Defs["DetectedPattern"].Properties["confidence"]
+	assert.Equal(t, 0.0, *confidence.Minimum)
+	assert.Equal(t, 1.0, *confidence.Maximum)
+}
+
+func TestForModel_IdeaDescribesEveryMarshaledField(t *testing.T) {
+	reviewed := time.Now()
+	idea := models.NewIdea("Automate invoices")
+	idea.RawScore, idea.FinalScore = 7, 7.5
+	idea.Patterns, idea.Tags = []string{"perfectionism"}, []string{"finance"}
+	idea.Recommendation = models.RecommendationGood.String()
+	idea.AnalysisDetails, idea.Trigger, idea.ArchiveReason = "details", "tax season", "done"
+	idea.ReviewedAt = &reviewed
+	idea.TelosVersion, idea.Version, idea.Title = "abc", 2, "Invoices"
+	idea.Notes = "started prototyping"
+	idea.Analysis = &models.Analysis{}
+
+	data, err := json.Marshal(idea)
+	require.NoError(t, err)
+	var fields map[string]any
+	require.NoError(t, json.Unmarshal(data, &fields))
+
+	s, err := ForModel("idea")
+	require.NoError(t, err)
+	for name := range fields {
+		assert.Contains(t, s.Properties, name)
+	}
+	assert.Len(t, s.Properties, len(fields))
+}
+
+func TestForModel_Telos(t *testing.T) {
+	s, err := ForModel("TELOS")
+	require.NoError(t, err)
+
+	goals := s.Properties["goals"]
+	assert.Equal(t, "array", goals.Type, "goals may not be null")
+	assert.Equal(t, 1, *goals.MinItems)
+	assert.Equal(t, "#/$defs/Goal", goals.Items.Ref)
+	assert.Equal(t, []string{"array", "null"}, s.Properties["problems"].Type, "nil slices marshal to null")
+
+	goal := s.Defs["Goal"]
+	assert.ElementsMatch(t, []string{"id", "description", "priority"}, goal.Required)
+	assert.Equal(t, 1, *goal.Properties["id"].MinLength)
+	assert.Equal(t, &Schema{Type: "string", Format: "date-time"}, goal.Properties["deadline"])
+}
+
+func TestForModel_Analysis(t *testing.T) {
+	s, err := ForModel("analysis")
+	require.NoError(t, err)
+
+	assert.Equal(t, "#/$defs/MissionScores", s.Properties["mission"].Ref)
+	assert.Equal(t, &Schema{Type: "object", AdditionalProperties: &Schema{Type: "string"}}, s.Properties["explanations"])
+	assert.Equal(t, []any{"low", "medium", "high", "critical"}, s.Defs["DetectedPattern"].Properties["severity"].Enum)
+}
+
+func TestForModel_Unknown(t *testing.T) {
+	_, err := ForModel("user")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "analysis, idea, telos")
+}
+
+type node struct {
+	Name     string  `json:"name"`
+	Parent   *node   `json:"parent"`
+	Children []node  `json:"children,omitempty"`
+	Ignored  string  `json:"-"`
+	Weight   float32 // No tag: named after the field
+}
+
+func TestGenerate_SelfReferenceAndTags(t *testing.T) {
+	s := Generate(&node{}, nil)
+
+	assert.Equal(t, "node", s.Title)
+	assert.ElementsMatch(t, []string{"name", "parent", "Weight"}, s.Required)
+	assert.Equal(t, []*Schema{{Ref: "#/$defs/node"}, {Type: "null"}}, s.Properties["parent"].AnyOf)
+	assert.Equal(t, "#/$defs/node", s.Properties["children"].Items.Ref)
+	assert.NotContains(t, s.Properties, "Ignored")
+	assert.Equal(t, "number", s.Properties["Weight"].Type)
+	assert.Contains(t, s.Defs["node"].Properties, "children")
+}
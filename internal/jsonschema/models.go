@@ -0,0 +1,98 @@
+package jsonschema
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/ryacub/telos-idea-matrix/internal/models"
+)
+
+// model is a documented model a schema can be generated for
+type model struct {
+	value       any
+	description string
+}
+
+var modelsByName = map[string]model{
+	"idea": {
+		value:       models.Idea{},
+		description: "An idea as written by 'tm bulk export --format json' and NDJSON exports.",
+	},
+	"analysis": {
+		value:       models.Analysis{},
+		description: "The scoring breakdown of an idea, as returned in the analysis of API responses.",
+	},
+	"telos": {
+		value:       models.Telos{},
+		description: "A parsed telos file: the goals, strategies and stack ideas are scored against.",
+	},
+}
+
+// ModelNames lists the models ForModel accepts, in alphabetical order
+var ModelNames = []string{"analysis", "idea", "telos"}
+
+// nonEmpty requires a string with at least one character
+var nonEmpty = Length(1, -1)
+
+// modelConstraints carry the rules of the models' Validate methods, and the
+// known values of fields that hold one of a fixed set
+var modelConstraints = Constraints{
+	reflect.TypeOf(models.Idea{}): {
+		// Validate requires a title or content
+		"": func(s *Schema) {
+			content := &Schema{}
+			nonEmpty(content)
+			s.AnyOf = []*Schema{
+				{Required: []string{"title"}},
+				{Properties: map[string]*Schema{"content": content}},
+			}
+		},
+		"title":          Length(3, 200),
+		"trigger":        Length(-1, 200),
+		"archive_reason": Length(-1, 200),
+		"notes":          Length(-1, models.MaxNotesLength),
+		"status":         Enum(string(models.StatusActive), string(models.StatusArchived), string(models.StatusDeleted)),
+		// Free text: the built-in labels, LLM wording such as "GOOD ALIGNMENT"
+		// and custom providers' own all occur
+		"recommendation": Describe(recommendationDescription()),
+	},
+	reflect.TypeOf(models.DetectedPattern{}): {
+		"name":        nonEmpty,
+		"description": nonEmpty,
+		"confidence":  Range(0, 1),
+		"severity":    Enum("low", "medium", "high", "critical"),
+	},
+	reflect.TypeOf(models.Telos{}): {
+		"goals": MinItems(1),
+	},
+	reflect.TypeOf(models.Goal{}):      {"id": nonEmpty, "description": nonEmpty},
+	reflect.TypeOf(models.Strategy{}):  {"id": nonEmpty, "description": nonEmpty},
+	reflect.TypeOf(models.Problem{}):   {"id": nonEmpty, "description": nonEmpty},
+	reflect.TypeOf(models.Mission{}):   {"id": nonEmpty, "description": nonEmpty},
+	reflect.TypeOf(models.Challenge{}): {"id": nonEmpty, "description": nonEmpty},
+	reflect.TypeOf(models.Pattern{}):   {"name": nonEmpty, "description": nonEmpty},
+}
+
+// recommendationDescription explains how a recommendation's free text maps
+// to the categories filters compare
+func recommendationDescription() string {
+	categories := make([]string, len(models.RecommendationCategories))
+	for i, c := range models.RecommendationCategories {
+		categories[i] = string(c)
+	}
+	return fmt.Sprintf("Free text, e.g. %q; filters match its category: %s.",
+		models.RecommendationGood.String(), strings.Join(categories, ", "))
+}
+
+// ForModel returns the schema of the named model: "idea", "analysis" or "telos"
+func ForModel(name string) (*Schema, error) {
+	m, ok := modelsByName[strings.ToLower(name)]
+	if !ok {
+		return nil, fmt.Errorf("unknown model %q (must be one of: %s)", name, strings.Join(ModelNames, ", "))
+	}
+
+	s := Generate(m.value, modelConstraints)
+	s.Description = m.description
+	return s, nil
+}
@@ -1,6 +1,7 @@
 package llm
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/ryacub/telos-idea-matrix/internal/models"
@@ -35,12 +36,19 @@ func ConvertResultToAnalysis(result *AnalysisResult) *models.Analysis {
 			PublicAccountability: result.Scores.StrategicFit * 0.16,
 			RevenueTesting:       result.Scores.StrategicFit * 0.12,
 		},
+		Explanations: result.Explanations,
 	}
 }
 
 // AnalyzeWithProviderOverride runs LLM analysis with an optional provider override
 // Note: model parameter is reserved for future use when providers support model selection
 func (m *Manager) AnalyzeWithProviderOverride(ideaText, provider, model string, telos *models.Telos) (*models.Analysis, error) {
+	return m.AnalyzeWithProviderOverrideContext(context.Background(), ideaText, provider, model, telos)
+}
+
+// AnalyzeWithProviderOverrideContext is AnalyzeWithProviderOverride bound to
+// ctx. Once ctx is done the analysis is abandoned and ctx's error returned.
+func (m *Manager) AnalyzeWithProviderOverrideContext(ctx context.Context, ideaText, provider, model string, telos *models.Telos) (*models.Analysis, error) {
 	// Set provider if specified
 	if provider != "" {
 		if err := m.SetPrimaryProvider(provider); err != nil {
@@ -52,7 +60,42 @@ func (m *Manager) AnalyzeWithProviderOverride(ideaText, provider, model string,
 	_ = model
 
 	// Run LLM analysis
-	result, err := m.AnalyzeWithTelos(ideaText, telos)
+	result, err := m.AnalyzeContext(ctx, AnalysisRequest{IdeaContent: ideaText, Telos: telos})
+	if err != nil {
+		return nil, err
+	}
+
+	return ConvertResultToAnalysis(result), nil
+}
+
+// AnalyzeWithNamedProvider runs LLM analysis with one provider, without changing
+// the primary provider or falling back to others. Unlike AnalyzeWithProviderOverride
+// it is safe for concurrent callers that request different providers.
+// An unregistered name returns an error wrapping ErrUnknownProvider.
+func (m *Manager) AnalyzeWithNamedProvider(ideaText, provider string, telos *models.Telos) (*models.Analysis, error) {
+	return m.AnalyzeWithNamedProviderContext(context.Background(), ideaText, provider, telos)
+}
+
+// AnalyzeWithNamedProviderContext is AnalyzeWithNamedProvider bound to ctx
+func (m *Manager) AnalyzeWithNamedProviderContext(ctx context.Context, ideaText, provider string, telos *models.Telos) (*models.Analysis, error) {
+	m.mu.RLock()
+	var selected Provider
+	for _, p := range m.providers {
+		if p.Name() == provider {
+			selected = p
+			break
+		}
+	}
+	m.mu.RUnlock()
+
+	if selected == nil {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownProvider, provider)
+	}
+	if !selected.IsAvailable() {
+		return nil, fmt.Errorf("provider not available: %s", provider)
+	}
+
+	result, err := m.analyzeWithProvider(ctx, selected, AnalysisRequest{IdeaContent: ideaText, Telos: telos})
 	if err != nil {
 		return nil, err
 	}
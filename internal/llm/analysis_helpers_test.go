@@ -1,6 +1,7 @@
 package llm
 
 import (
+	"errors"
 	"math"
 	"testing"
 
@@ -242,3 +243,28 @@ func TestConvertResultToAnalysis_ProportionalDistribution(t *testing.T) {
 		t.Errorf("RevenueTesting = %v, want %v", analysis.Strategic.RevenueTesting, expectedRevenueTesting)
 	}
 }
+
+func TestAnalyzeWithNamedProvider(t *testing.T) {
+	manager := NewManager(DefaultManagerConfig())
+	telos := &models.Telos{
+		Goals: []models.Goal{{ID: "goal-1", Description: "Build AI-powered productivity tools", Priority: 1}},
+	}
+	primary := manager.GetPrimaryProviderName()
+
+	analysis, err := manager.AnalyzeWithNamedProvider("Create an AI assistant", "rule_based", telos)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if analysis.FinalScore < 0 || analysis.FinalScore > 10 {
+		t.Errorf("FinalScore should be between 0-10, got %v", analysis.FinalScore)
+	}
+
+	_, err = manager.AnalyzeWithNamedProvider("Some idea", "nonexistent_provider", telos)
+	if !errors.Is(err, ErrUnknownProvider) {
+		t.Errorf("Expected ErrUnknownProvider, got %v", err)
+	}
+
+	if got := manager.GetPrimaryProviderName(); got != primary {
+		t.Errorf("Primary provider changed from %q to %q", primary, got)
+	}
+}
@@ -0,0 +1,188 @@
+package llm
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/ryacub/telos-idea-matrix/internal/llm/processing"
+)
+
+// analysisSchema is the JSON object the analysis prompt asks for. Fields are
+// pointers so strict parsing can tell a missing score from a zero one.
+type analysisSchema struct {
+	Scores struct {
+		MissionAlignment *float64 `json:"mission_alignment"`
+		AntiChallenge    *float64 `json:"anti_challenge"`
+		StrategicFit     *float64 `json:"strategic_fit"`
+	} `json:"scores"`
+	FinalScore     *float64          `json:"final_score"`
+	Recommendation string            `json:"recommendation"`
+	Explanations   map[string]string `json:"explanations"`
+}
+
+// validRecommendations are the recommendations the prompt allows
+var validRecommendations = map[string]bool{
+	"PRIORITIZE NOW": true,
+	"GOOD ALIGNMENT": true,
+	"CONSIDER LATER": true,
+	"AVOID FOR NOW":  true,
+}
+
+var (
+	trailingCommaRe = regexp.MustCompile(`,\s*([}\]])`)
+	scoreFieldRes   = map[string]*regexp.Regexp{
+		"mission_alignment": regexp.MustCompile(`"mission_alignment"\s*:\s*"?(\d+(?:\.\d+)?)`),
+		"anti_challenge":    regexp.MustCompile(`"anti_challenge"\s*:\s*"?(\d+(?:\.\d+)?)`),
+		"strategic_fit":     regexp.MustCompile(`"strategic_fit"\s*:\s*"?(\d+(?:\.\d+)?)`),
+		"final_score":       regexp.MustCompile(`"final_score"\s*:\s*"?(\d+(?:\.\d+)?)`),
+	}
+	recommendationRe = regexp.MustCompile(`"recommendation"\s*:\s*"([^"]*)"`)
+)
+
+// ParseAnalysisJSON parses an analysis returned in the structured JSON format
+// of PromptTemplate. Providers share it so that every model's output is
+// scored the same way.
+//
+// The response is first decoded strictly: exactly one JSON object with every
+// score, a final score, and one of the allowed recommendations. If that fails
+// it is parsed leniently, which tolerates surrounding prose, code fences,
+// trailing commas, quoted numbers, a missing final score (the category sum is
+// used) and a missing or misspelled recommendation (derived from the score).
+// Scores must be within their category ranges either way.
+// The returned result has no Provider or Duration set.
+func ParseAnalysisJSON(raw string) (AnalysisResult, error) {
+	schema, err := decodeAnalysisStrict(raw)
+	if err != nil {
+		schema, err = decodeAnalysisLenient(raw)
+	}
+	if err != nil {
+		return AnalysisResult{}, fmt.Errorf("%w: %w", ErrInvalidResponse, err)
+	}
+
+	resp := LLMResponse{
+		FinalScore:     *schema.FinalScore,
+		Recommendation: schema.Recommendation,
+		Explanations:   schema.Explanations,
+	}
+	resp.Scores.MissionAlignment = *schema.Scores.MissionAlignment
+	resp.Scores.AntiChallenge = *schema.Scores.AntiChallenge
+	resp.Scores.StrategicFit = *schema.Scores.StrategicFit
+	if err := resp.Validate(); err != nil {
+		return AnalysisResult{}, fmt.Errorf("%w: %w", ErrInvalidResponse, err)
+	}
+
+	if resp.Explanations == nil {
+		resp.Explanations = make(map[string]string)
+	}
+	return AnalysisResult{
+		Scores: ScoreBreakdown{
+			MissionAlignment: resp.Scores.MissionAlignment,
+			AntiChallenge:    resp.Scores.AntiChallenge,
+			StrategicFit:     resp.Scores.StrategicFit,
+		},
+		FinalScore:     resp.FinalScore,
+		Recommendation: resp.Recommendation,
+		Explanations:   resp.Explanations,
+	}, nil
+}
+
+// parseProcessed adapts ParseAnalysisJSON for a response processor, so
+// providers that fall back to rule-based scoring parse the same way
+func parseProcessed(raw string) (*processing.ProcessedResult, error) {
+	result, err := ParseAnalysisJSON(raw)
+	if err != nil {
+		return nil, err
+	}
+	return &processing.ProcessedResult{
+		Scores: processing.ScoreBreakdown{
+			MissionAlignment: result.Scores.MissionAlignment,
+			AntiChallenge:    result.Scores.AntiChallenge,
+			StrategicFit:     result.Scores.StrategicFit,
+		},
+		FinalScore:     result.FinalScore,
+		Recommendation: result.Recommendation,
+		Explanations:   result.Explanations,
+	}, nil
+}
+
+// decodeAnalysisStrict accepts only a response that is exactly the schema
+func decodeAnalysisStrict(raw string) (*analysisSchema, error) {
+	dec := json.NewDecoder(strings.NewReader(raw))
+	dec.DisallowUnknownFields()
+
+	var schema analysisSchema
+	if err := dec.Decode(&schema); err != nil {
+		return nil, err
+	}
+	if dec.More() {
+		return nil, errors.New("unexpected content after JSON object")
+	}
+	if schema.Scores.MissionAlignment == nil || schema.Scores.AntiChallenge == nil ||
+		schema.Scores.StrategicFit == nil || schema.FinalScore == nil {
+		return nil, errors.New("missing score")
+	}
+	if !validRecommendations[schema.Recommendation] {
+		return nil, fmt.Errorf("invalid recommendation: %q", schema.Recommendation)
+	}
+	return &schema, nil
+}
+
+// decodeAnalysisLenient recovers an analysis from a response that doesn't
+// follow the schema exactly
+func decodeAnalysisLenient(raw string) (*analysisSchema, error) {
+	jsonStr := extractJSON(raw)
+	if jsonStr == "" {
+		return nil, errors.New("no JSON found in response")
+	}
+	jsonStr = trailingCommaRe.ReplaceAllString(jsonStr, "$1")
+
+	var schema analysisSchema
+	if err := json.Unmarshal([]byte(jsonStr), &schema); err != nil {
+		// Fall back to picking the fields out of the malformed JSON
+		schema = analysisSchema{}
+		schema.Scores.MissionAlignment = matchScore(jsonStr, "mission_alignment")
+		schema.Scores.AntiChallenge = matchScore(jsonStr, "anti_challenge")
+		schema.Scores.StrategicFit = matchScore(jsonStr, "strategic_fit")
+		schema.FinalScore = matchScore(jsonStr, "final_score")
+		if m := recommendationRe.FindStringSubmatch(jsonStr); m != nil {
+			schema.Recommendation = m[1]
+		}
+	}
+
+	if schema.Scores.MissionAlignment == nil || schema.Scores.AntiChallenge == nil || schema.Scores.StrategicFit == nil {
+		return nil, errors.New("missing category scores")
+	}
+	if schema.FinalScore == nil {
+		sum := *schema.Scores.MissionAlignment + *schema.Scores.AntiChallenge + *schema.Scores.StrategicFit
+		schema.FinalScore = &sum
+	}
+
+	schema.Recommendation = normalizeRecommendation(schema.Recommendation)
+	if !validRecommendations[schema.Recommendation] {
+		schema.Recommendation = processing.DetermineRecommendation(*schema.FinalScore)
+	}
+	return &schema, nil
+}
+
+// matchScore finds a numeric field in malformed JSON, or returns nil
+func matchScore(s, field string) *float64 {
+	m := scoreFieldRes[field].FindStringSubmatch(s)
+	if m == nil {
+		return nil
+	}
+	value, err := strconv.ParseFloat(m[1], 64)
+	if err != nil {
+		return nil
+	}
+	return &value
+}
+
+// normalizeRecommendation maps variants such as "good_alignment" to "GOOD ALIGNMENT"
+func normalizeRecommendation(rec string) string {
+	rec = strings.NewReplacer("_", " ", "-", " ").Replace(strings.ToUpper(rec))
+	return strings.Join(strings.Fields(rec), " ")
+}
@@ -0,0 +1,170 @@
+package llm
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ryacub/telos-idea-matrix/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
+)
+
+func TestParseAnalysisJSON_Valid(t *testing.T) {
+	tests := []struct {
+		name string
+		raw  string
+		want AnalysisResult
+	}{
+		{
+			name: "strict schema",
+			raw: `{
+				"scores": {"mission_alignment": 3.0, "anti_challenge": 2.5, "strategic_fit": 1.8},
+				"final_score": 7.3,
+				"recommendation": "GOOD ALIGNMENT",
+				"explanations": {"mission_alignment": "Uses AI skills"}
+			}`,
+			want: AnalysisResult{
+				Scores:         ScoreBreakdown{MissionAlignment: 3.0, AntiChallenge: 2.5, StrategicFit: 1.8},
+				FinalScore:     7.3,
+				Recommendation: "GOOD ALIGNMENT",
+				Explanations:   map[string]string{"mission_alignment": "Uses AI skills"},
+			},
+		},
+		{
+			name: "zero scores are not missing",
+			raw:  `{"scores": {"mission_alignment": 0, "anti_challenge": 0, "strategic_fit": 0}, "final_score": 0, "recommendation": "AVOID FOR NOW"}`,
+			want: AnalysisResult{
+				Recommendation: "AVOID FOR NOW",
+				Explanations:   map[string]string{},
+			},
+		},
+		{
+			name: "prose and code fence around JSON",
+			raw: "Here is my analysis:\n```json\n" +
+				`{"scores": {"mission_alignment": 3.5, "anti_challenge": 3.0, "strategic_fit": 2.0}, "final_score": 8.5, "recommendation": "PRIORITIZE NOW"}` +
+				"\n```\nLet me know if you need more.",
+			want: AnalysisResult{
+				Scores:         ScoreBreakdown{MissionAlignment: 3.5, AntiChallenge: 3.0, StrategicFit: 2.0},
+				FinalScore:     8.5,
+				Recommendation: "PRIORITIZE NOW",
+				Explanations:   map[string]string{},
+			},
+		},
+		{
+			name: "trailing commas and extra fields",
+			raw:  `{"scores": {"mission_alignment": 2.0, "anti_challenge": 2.0, "strategic_fit": 1.0,}, "final_score": 5.0, "recommendation": "CONSIDER LATER", "confidence": 0.9,}`,
+			want: AnalysisResult{
+				Scores:         ScoreBreakdown{MissionAlignment: 2.0, AntiChallenge: 2.0, StrategicFit: 1.0},
+				FinalScore:     5.0,
+				Recommendation: "CONSIDER LATER",
+				Explanations:   map[string]string{},
+			},
+		},
+		{
+			name: "quoted numbers and missing final score",
+			raw:  `{"scores": {"mission_alignment": "3.0", "anti_challenge": "2.0", "strategic_fit": "2.0"}, "recommendation": "GOOD ALIGNMENT"}`,
+			want: AnalysisResult{
+				Scores:         ScoreBreakdown{MissionAlignment: 3.0, AntiChallenge: 2.0, StrategicFit: 2.0},
+				FinalScore:     7.0,
+				Recommendation: "GOOD ALIGNMENT",
+				Explanations:   map[string]string{},
+			},
+		},
+		{
+			name: "recommendation variant is normalized",
+			raw:  `{"scores": {"mission_alignment": 3.0, "anti_challenge": 2.5, "strategic_fit": 1.8}, "final_score": 7.3, "recommendation": "good_alignment"}`,
+			want: AnalysisResult{
+				Scores:         ScoreBreakdown{MissionAlignment: 3.0, AntiChallenge: 2.5, StrategicFit: 1.8},
+				FinalScore:     7.3,
+				Recommendation: "GOOD ALIGNMENT",
+				Explanations:   map[string]string{},
+			},
+		},
+		{
+			name: "unknown recommendation is derived from the score",
+			raw:  `{"scores": {"mission_alignment": 1.0, "anti_challenge": 1.0, "strategic_fit": 1.0}, "final_score": 3.0, "recommendation": "maybe"}`,
+			want: AnalysisResult{
+				Scores:         ScoreBreakdown{MissionAlignment: 1.0, AntiChallenge: 1.0, StrategicFit: 1.0},
+				FinalScore:     3.0,
+				Recommendation: "AVOID FOR NOW",
+				Explanations:   map[string]string{},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseAnalysisJSON(tt.raw)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParseAnalysisJSON_Malformed(t *testing.T) {
+	tests := []struct {
+		name string
+		raw  string
+	}{
+		{name: "empty", raw: ""},
+		{name: "no JSON", raw: "This idea looks promising, I'd give it a 7."},
+		{name: "missing category score", raw: `{"scores": {"mission_alignment": 3.0, "anti_challenge": 2.5}, "final_score": 5.5, "recommendation": "CONSIDER LATER"}`},
+		{name: "score out of range", raw: `{"scores": {"mission_alignment": 9.0, "anti_challenge": 2.5, "strategic_fit": 1.8}, "final_score": 9.5, "recommendation": "PRIORITIZE NOW"}`},
+		{name: "negative score", raw: `{"scores": {"mission_alignment": -1.0, "anti_challenge": 2.5, "strategic_fit": 1.8}, "final_score": 3.3, "recommendation": "AVOID FOR NOW"}`},
+		{name: "truncated", raw: `{"scores": {"mission_alignment": 3.0, "anti_chall`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseAnalysisJSON(tt.raw)
+			require.Error(t, err)
+			assert.True(t, errors.Is(err, ErrInvalidResponse), "expected ErrInvalidResponse, got %v", err)
+		})
+	}
+}
+
+func TestProviders_RequestJSONOutput(t *testing.T) {
+	telos := &models.Telos{Goals: []models.Goal{{ID: "g1", Description: "Build AI products"}}}
+
+	t.Run("ollama sets format json", func(t *testing.T) {
+		var body map[string]interface{}
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"response": analysisJSON, "done": true})
+		}))
+		defer server.Close()
+
+		_, err := NewOllamaProvider(server.URL, "").Analyze(AnalysisRequest{IdeaContent: "Build an AI tool", Telos: telos})
+		require.NoError(t, err)
+		assert.Equal(t, "json", body["format"])
+	})
+
+	t.Run("openai sets response_format json_object", func(t *testing.T) {
+		var body map[string]interface{}
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"choices": []map[string]interface{}{
+					{"message": map[string]string{"role": "assistant", "content": analysisJSON}},
+				},
+			})
+		}))
+		defer server.Close()
+
+		provider := &OpenAIProvider{openAICompatibleProvider{
+			apiKey:      "test-key",
+			model:       "gpt-4",
+			baseURL:     server.URL,
+			httpClient:  &http.Client{},
+			maxRetries:  1,
+			rateLimiter: rate.NewLimiter(rate.Inf, 1),
+		}}
+		_, err := provider.Analyze(AnalysisRequest{IdeaContent: "Build an AI tool", Telos: telos})
+		require.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{"type": "json_object"}, body["response_format"])
+	})
+}
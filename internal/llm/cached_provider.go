@@ -1,6 +1,8 @@
 package llm
 
 import (
+	"context"
+
 	"github.com/ryacub/telos-idea-matrix/internal/llm/cache"
 )
 
@@ -33,6 +35,12 @@ func (cp *CachedProvider) IsAvailable() bool {
 // If a cache hit occurs, returns the cached result with FromCache=true.
 // Otherwise, calls the provider, stores the result in cache, and returns it.
 func (cp *CachedProvider) Analyze(req AnalysisRequest) (*AnalysisResult, error) {
+	return cp.AnalyzeContext(context.Background(), req)
+}
+
+// AnalyzeContext is Analyze bound to ctx, which is passed to the underlying
+// provider on a cache miss.
+func (cp *CachedProvider) AnalyzeContext(ctx context.Context, req AnalysisRequest) (*AnalysisResult, error) {
 	// Try to get from cache first
 	if cachedValue, found := cp.cache.Get(req.IdeaContent); found {
 		// Type assert to AnalysisResult
@@ -45,7 +53,7 @@ func (cp *CachedProvider) Analyze(req AnalysisRequest) (*AnalysisResult, error)
 	}
 
 	// Cache miss - call underlying provider
-	result, err := cp.provider.Analyze(req)
+	result, err := cp.provider.AnalyzeContext(ctx, req)
 	if err != nil {
 		return nil, err
 	}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -20,25 +21,32 @@ import (
 // CLAUDE PROVIDER
 // ============================================================================
 
+// defaultClaudeModel is used when neither the config nor CLAUDE_MODEL names a model
+const defaultClaudeModel = "claude-3-5-sonnet-20241022"
+
 // ClaudeProvider implements the Provider interface using Anthropic Claude API.
 type ClaudeProvider struct {
-	apiKey     string
-	model      string
-	baseURL    string
-	httpClient *http.Client
-	maxRetries int
-	processor  *processing.SimpleProcessor
+	apiKey       string
+	model        string
+	systemPrompt string // Leads the system message; empty uses DefaultSystemPrompt
+	baseURL      string
+	httpClient   *http.Client
+	maxRetries   int
+	processor    *processing.SimpleProcessor
 }
 
 // NewClaudeProvider creates a new Claude provider with the given configuration.
 // If apiKey is empty, it will try to read from ANTHROPIC_API_KEY environment variable.
-// If model is empty, defaults to "claude-3-5-sonnet-20241022".
+// If model is empty, it reads CLAUDE_MODEL and then defaults to "claude-3-5-sonnet-20241022".
 func NewClaudeProvider(apiKey string, model string) *ClaudeProvider {
 	if apiKey == "" {
 		apiKey = os.Getenv("ANTHROPIC_API_KEY")
 	}
 	if model == "" {
-		model = "claude-3-5-sonnet-20241022"
+		model = os.Getenv("CLAUDE_MODEL")
+	}
+	if model == "" {
+		model = defaultClaudeModel
 	}
 
 	// Create processor with rule-based fallback function
@@ -75,7 +83,7 @@ func NewClaudeProvider(apiKey string, model string) *ClaudeProvider {
 		}, nil
 	}
 
-	processor := processing.NewSimpleProcessor(fallbackFunc)
+	processor := processing.NewSimpleProcessor(fallbackFunc).WithParser(parseProcessed)
 
 	return &ClaudeProvider{
 		apiKey:  apiKey,
@@ -83,13 +91,13 @@ func NewClaudeProvider(apiKey string, model string) *ClaudeProvider {
 		baseURL: "https://api.anthropic.com/v1/messages",
 		httpClient: &http.Client{
 			Timeout: 30 * time.Second,
-			Transport: &http.Transport{
+			Transport: tracingTransport{base: &http.Transport{
 				MaxIdleConns:        100,              // Max idle connections across all hosts
 				MaxIdleConnsPerHost: 10,               // Max idle connections per host
 				MaxConnsPerHost:     10,               // Max total connections per host
 				IdleConnTimeout:     90 * time.Second, // Keep idle connections for 90s
 				DisableKeepAlives:   false,            // Enable connection reuse
-			},
+			}},
 		},
 		maxRetries: 3,
 		processor:  processor,
@@ -101,31 +109,21 @@ func (cp *ClaudeProvider) Name() string {
 	return "claude"
 }
 
-// IsAvailable checks if Claude API is accessible.
-// It verifies that an API key is configured and can make a test request.
+// IsAvailable checks if the provider is available (has API key).
+// It doesn't call the API: the manager checks availability before every
+// request and on each health check, and each call would be billed.
 func (cp *ClaudeProvider) IsAvailable() bool {
-	if cp.apiKey == "" {
-		return false
-	}
-
-	// Test with minimal request
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
-
-	req := &claudeRequest{
-		Model: cp.model,
-		Messages: []claudeMessage{
-			{Role: "user", Content: "test"},
-		},
-		MaxTokens: 10,
-	}
-
-	_, err := cp.sendRequest(ctx, req)
-	return err == nil
+	return cp.apiKey != ""
 }
 
 // Analyze performs idea analysis using Claude API.
 func (cp *ClaudeProvider) Analyze(req AnalysisRequest) (*AnalysisResult, error) {
+	return cp.AnalyzeContext(context.Background(), req)
+}
+
+// AnalyzeContext performs idea analysis using Claude API, abandoning the
+// request and any pending retries if ctx is cancelled.
+func (cp *ClaudeProvider) AnalyzeContext(ctx context.Context, req AnalysisRequest) (*AnalysisResult, error) {
 	start := time.Now()
 
 	if !cp.IsAvailable() {
@@ -136,7 +134,7 @@ func (cp *ClaudeProvider) Analyze(req AnalysisRequest) (*AnalysisResult, error)
 	}
 
 	// Build prompt
-	prompt, err := BuildAnalysisPrompt(req.IdeaContent, req.Telos)
+	prompt, err := analysisPrompt(req)
 	if err != nil {
 		duration := time.Since(start)
 		metrics.RecordLLMRequest(cp.Name(), false, duration)
@@ -144,8 +142,12 @@ func (cp *ClaudeProvider) Analyze(req AnalysisRequest) (*AnalysisResult, error)
 		return nil, fmt.Errorf("build prompt: %w", err)
 	}
 
-	// Extract system prompt and user prompt
-	systemPrompt, userPrompt := cp.extractPrompts(prompt)
+	// The telos and idea join the configured system prompt; the task is the user message
+	telosContext, userPrompt := cp.extractPrompts(prompt)
+	systemPrompt := systemPromptOr(cp.systemPrompt)
+	if telosContext != "" {
+		systemPrompt += "\n\n" + telosContext
+	}
 
 	// Create Claude request
 	claudeReq := &claudeRequest{
@@ -162,24 +164,24 @@ func (cp *ClaudeProvider) Analyze(req AnalysisRequest) (*AnalysisResult, error)
 	}
 
 	// Send request with retries and exponential backoff
-	ctx := context.Background()
 	var resp *claudeResponse
 	var lastErr error
 
 	for attempt := 0; attempt < cp.maxRetries; attempt++ {
-		// Add timeout for each attempt
-		attemptCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
-		resp, lastErr = cp.sendRequest(attemptCtx, claudeReq)
-		cancel()
+		// Each attempt is bounded by the HTTP client timeout
+		resp, lastErr = cp.sendRequest(ctx, claudeReq)
 
-		if lastErr == nil {
+		if lastErr == nil || !isRetryableClaudeError(lastErr) || ctx.Err() != nil {
 			break
 		}
 
 		// Exponential backoff before retry
 		if attempt < cp.maxRetries-1 {
 			backoff := time.Duration(1<<uint(attempt)) * time.Second
-			time.Sleep(backoff)
+			if err := sleepContext(ctx, backoff); err != nil {
+				lastErr = err
+				break
+			}
 		}
 	}
 
@@ -188,20 +190,21 @@ func (cp *ClaudeProvider) Analyze(req AnalysisRequest) (*AnalysisResult, error)
 	if lastErr != nil {
 		metrics.RecordLLMRequest(cp.Name(), false, duration)
 		metrics.RecordLLMError(cp.Name(), classifyError(lastErr))
-		return nil, fmt.Errorf("claude request failed after %d retries: %w", cp.maxRetries, lastErr)
+		return nil, fmt.Errorf("claude request failed: %w", lastErr)
 	}
 
 	// Extract text from response
-	if len(resp.Content) == 0 {
+	responseText := resp.text()
+	if responseText == "" {
 		metrics.RecordLLMRequest(cp.Name(), false, duration)
 		metrics.RecordLLMError(cp.Name(), "invalid_response")
-		return nil, fmt.Errorf("no response content from Claude")
+		return nil, fmt.Errorf("%w: no text content from Claude", ErrInvalidResponse)
 	}
 
-	responseText := resp.Content[0].Text
-
 	// Process LLM response with fallback support
+	_, parseSpan := startSpan(ctx, "llm.parse", attrProvider.String(cp.Name()))
 	processed, err := cp.processor.Process(responseText, req.IdeaContent, req.Telos)
+	endSpan(parseSpan, err)
 	if err != nil {
 		metrics.RecordLLMRequest(cp.Name(), false, duration)
 		metrics.RecordLLMError(cp.Name(), "invalid_response")
@@ -272,6 +275,18 @@ type claudeResponse struct {
 	} `json:"error,omitempty"`
 }
 
+// text joins the text blocks of the response. Claude may split its answer
+// across several blocks, so using only the first can truncate the JSON.
+func (r *claudeResponse) text() string {
+	var b strings.Builder
+	for _, block := range r.Content {
+		if block.Type == "text" {
+			b.WriteString(block.Text)
+		}
+	}
+	return strings.TrimSpace(b.String())
+}
+
 // ============================================================================
 // HELPER METHODS
 // ============================================================================
@@ -315,7 +330,10 @@ func (cp *ClaudeProvider) sendRequest(ctx context.Context, req *claudeRequest) (
 	// Send request
 	httpResp, err := cp.httpClient.Do(httpReq)
 	if err != nil {
-		return nil, fmt.Errorf("HTTP request failed: %w", err)
+		if errors.Is(err, context.DeadlineExceeded) {
+			return nil, fmt.Errorf("%w: %w", ErrTimeout, err)
+		}
+		return nil, fmt.Errorf("%w: %w", ErrNetwork, err)
 	}
 	defer func() { _ = httpResp.Body.Close() }()
 
@@ -327,40 +345,65 @@ func (cp *ClaudeProvider) sendRequest(ctx context.Context, req *claudeRequest) (
 
 	// Check status code
 	if httpResp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("claude API error (status %d): %s", httpResp.StatusCode, string(respBody))
+		return nil, fmt.Errorf("%w: claude API error (status %d): %s",
+			claudeStatusError(httpResp.StatusCode), httpResp.StatusCode, string(respBody))
 	}
 
 	// Unmarshal response
 	var resp claudeResponse
 	if err := json.Unmarshal(respBody, &resp); err != nil {
-		return nil, fmt.Errorf("unmarshal response: %w", err)
+		return nil, fmt.Errorf("%w: unmarshal response: %w", ErrInvalidResponse, err)
 	}
 
 	// Check for API error
 	if resp.Error != nil {
-		return nil, fmt.Errorf("claude API error: %s (type: %s)", resp.Error.Message, resp.Error.Type)
+		return nil, fmt.Errorf("%w: claude API error: %s (type: %s)", ErrProvider, resp.Error.Message, resp.Error.Type)
 	}
 
 	return &resp, nil
 }
 
-// SetModel updates the Claude model being used.
-func (cp *ClaudeProvider) SetModel(model string) {
-	validModels := []string{
-		"claude-3-5-sonnet-20241022",
-		"claude-3-opus-20240229",
-		"claude-3-sonnet-20240229",
-		"claude-3-haiku-20240307",
+// errClaudeRequest marks a request the API rejected as malformed; resending
+// it unchanged can't succeed
+var errClaudeRequest = errors.New("invalid request")
+
+// claudeStatusError maps an HTTP status from the Messages API to an error type
+func claudeStatusError(status int) error {
+	switch {
+	case status == http.StatusUnauthorized || status == http.StatusForbidden:
+		return ErrAuth
+	case status == http.StatusTooManyRequests:
+		return ErrRateLimit
+	case status >= 500: // Includes 529, which the API returns when overloaded
+		return ErrProvider
+	default:
+		return errClaudeRequest
 	}
+}
 
-	for _, valid := range validModels {
-		if model == valid {
-			cp.model = model
-			return
-		}
+// isRetryableClaudeError reports whether a failed request is worth repeating.
+// Auth and request errors fail fast so the manager can fall back promptly.
+func isRetryableClaudeError(err error) bool {
+	return errors.Is(err, ErrRateLimit) ||
+		errors.Is(err, ErrProvider) ||
+		errors.Is(err, ErrNetwork) ||
+		errors.Is(err, ErrTimeout)
+}
+
+// SetModel updates the Claude model being used. Any claude-* model ID is
+// accepted so new releases work without a code change; other values are ignored.
+func (cp *ClaudeProvider) SetModel(model string) {
+	if strings.HasPrefix(model, "claude-") {
+		cp.model = model
 	}
 }
 
+// SetSystemPrompt sets the system prompt sent with every request; empty
+// restores DefaultSystemPrompt.
+func (cp *ClaudeProvider) SetSystemPrompt(prompt string) {
+	cp.systemPrompt = prompt
+}
+
 // GetModel returns the current Claude model.
 func (cp *ClaudeProvider) GetModel() string {
 	return cp.model
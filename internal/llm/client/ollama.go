@@ -22,6 +22,7 @@ type GenerateRequest struct {
 	Model  string `json:"model"`
 	Prompt string `json:"prompt"`
 	Stream bool   `json:"stream"`
+	Format string `json:"format,omitempty"` // "json" constrains the output to valid JSON
 }
 
 // GenerateResponse represents a response from Ollama's generate endpoint.
@@ -160,3 +161,50 @@ func (c *OllamaClient) HealthCheck(ctx context.Context) error {
 
 	return nil
 }
+
+// EmbedRequest represents a request to Ollama's embed endpoint.
+type EmbedRequest struct {
+	Model string `json:"model"`
+	Input string `json:"input"`
+}
+
+// EmbedResponse represents a response from Ollama's embed endpoint.
+type EmbedResponse struct {
+	Model      string      `json:"model"`
+	Embeddings [][]float32 `json:"embeddings"`
+}
+
+// Embed returns the embedding of req.Input computed by req.Model.
+func (c *OllamaClient) Embed(ctx context.Context, req EmbedRequest) ([]float32, error) {
+	payload, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("marshal request: %w", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, "POST",
+		c.baseURL+"/api/embed", bytes.NewReader(payload))
+	if err != nil {
+		return nil, fmt.Errorf("create request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("execute request: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("ollama error: status %d", resp.StatusCode)
+	}
+
+	var result EmbedResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("decode response: %w", err)
+	}
+	if len(result.Embeddings) == 0 || len(result.Embeddings[0]) == 0 {
+		return nil, fmt.Errorf("ollama returned no embedding")
+	}
+
+	return result.Embeddings[0], nil
+}
@@ -6,6 +6,8 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+
+	"github.com/ryacub/telos-idea-matrix/internal/config"
 )
 
 // Config stores LLM configuration preferences
@@ -19,12 +21,7 @@ const configVersion = "1.0"
 
 // GetConfigPath returns the path to the configuration file
 func GetConfigPath() (string, error) {
-	homeDir, err := os.UserHomeDir()
-	if err != nil {
-		return "", fmt.Errorf("failed to get home directory: %w", err)
-	}
-
-	configDir := filepath.Join(homeDir, ".telos")
+	configDir := config.ResolvePaths().ConfigDir
 
 	// Create directory if it doesn't exist
 	if err := os.MkdirAll(configDir, 0755); err != nil {
@@ -199,7 +196,7 @@ func ValidateConfig(config *Config) error {
 
 	// Validate default provider if set
 	if config.DefaultProvider != "" {
-		validProviders := []string{"ollama", "claude", "openai", "custom", "rule_based"}
+		validProviders := []string{"ollama", "claude", "openai", "groq", "custom", "rule_based"}
 		valid := false
 		for _, p := range validProviders {
 			if config.DefaultProvider == p {
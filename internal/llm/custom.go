@@ -2,6 +2,7 @@ package llm
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -11,6 +12,7 @@ import (
 	"text/template"
 	"time"
 
+	"github.com/ryacub/telos-idea-matrix/internal/llm/processing"
 	"github.com/ryacub/telos-idea-matrix/internal/metrics"
 )
 
@@ -31,6 +33,8 @@ import (
 //	CUSTOM_LLM_ENDPOINT="http://localhost:8080/v1/analyze"
 //	CUSTOM_LLM_HEADERS="Authorization:Bearer token123,Content-Type:application/json"
 //	CUSTOM_LLM_PROMPT_TEMPLATE='{"prompt": "{{.IdeaContent}}", "context": "{{.Telos}}"}'
+//
+// Templates can include the configured system prompt as {{.SystemPrompt}}.
 type CustomProvider struct {
 	name           string
 	endpoint       string
@@ -38,6 +42,7 @@ type CustomProvider struct {
 	httpClient     *http.Client
 	promptTemplate string
 	responseParser string
+	systemPrompt   string // Sent as "system", or {{.SystemPrompt}} in a template; empty uses DefaultSystemPrompt
 }
 
 // NewCustomProvider creates a custom HTTP provider from environment variable configuration.
@@ -51,13 +56,13 @@ func NewCustomProvider() *CustomProvider {
 		headers:  parseHeaders(os.Getenv("CUSTOM_LLM_HEADERS")),
 		httpClient: &http.Client{
 			Timeout: time.Duration(timeoutSeconds) * time.Second,
-			Transport: &http.Transport{
+			Transport: tracingTransport{base: &http.Transport{
 				MaxIdleConns:        100,              // Max idle connections across all hosts
 				MaxIdleConnsPerHost: 10,               // Max idle connections per host
 				MaxConnsPerHost:     10,               // Max total connections per host
 				IdleConnTimeout:     90 * time.Second, // Keep idle connections for 90s
 				DisableKeepAlives:   false,            // Enable connection reuse
-			},
+			}},
 		},
 		promptTemplate: os.Getenv("CUSTOM_LLM_PROMPT_TEMPLATE"),
 		responseParser: os.Getenv("CUSTOM_LLM_RESPONSE_PARSER"),
@@ -69,6 +74,12 @@ func (p *CustomProvider) Name() string {
 	return p.name
 }
 
+// SetSystemPrompt sets the system prompt sent with every request; empty
+// restores DefaultSystemPrompt.
+func (p *CustomProvider) SetSystemPrompt(prompt string) {
+	p.systemPrompt = prompt
+}
+
 // IsAvailable checks if the custom provider is configured.
 // Returns true only if CUSTOM_LLM_ENDPOINT is set.
 func (p *CustomProvider) IsAvailable() bool {
@@ -79,6 +90,11 @@ func (p *CustomProvider) IsAvailable() bool {
 // The request body is built using the template (if configured) and the response
 // is parsed according to the configured parser.
 func (p *CustomProvider) Analyze(req AnalysisRequest) (*AnalysisResult, error) {
+	return p.AnalyzeContext(context.Background(), req)
+}
+
+// AnalyzeContext is Analyze bound to ctx; cancelling ctx aborts the HTTP call.
+func (p *CustomProvider) AnalyzeContext(ctx context.Context, req AnalysisRequest) (*AnalysisResult, error) {
 	start := time.Now()
 
 	if !p.IsAvailable() {
@@ -98,7 +114,7 @@ func (p *CustomProvider) Analyze(req AnalysisRequest) (*AnalysisResult, error) {
 	}
 
 	// Create HTTP request
-	httpReq, err := http.NewRequest("POST", p.endpoint, bytes.NewBuffer(requestBody))
+	httpReq, err := http.NewRequestWithContext(ctx, "POST", p.endpoint, bytes.NewBuffer(requestBody))
 	if err != nil {
 		duration := time.Since(start)
 		metrics.RecordLLMRequest(p.Name(), false, duration)
@@ -153,7 +169,9 @@ func (p *CustomProvider) Analyze(req AnalysisRequest) (*AnalysisResult, error) {
 	}
 
 	// Parse response
+	_, parseSpan := startSpan(ctx, "llm.parse", attrProvider.String(p.Name()))
 	result, err := p.parseResponse(respBody)
+	endSpan(parseSpan, err)
 	if err != nil {
 		metrics.RecordLLMRequest(p.Name(), false, duration)
 		metrics.RecordLLMError(p.Name(), "invalid_response")
@@ -177,7 +195,8 @@ func (p *CustomProvider) buildRequestBody(req AnalysisRequest) ([]byte, error) {
 	if p.promptTemplate == "" {
 		// Default template: simple JSON with idea content and telos
 		defaultReq := map[string]interface{}{
-			"idea": req.IdeaContent,
+			"system": systemPromptOr(p.systemPrompt),
+			"idea":   req.IdeaContent,
 		}
 
 		// Add telos information if available
@@ -200,10 +219,12 @@ func (p *CustomProvider) buildRequestBody(req AnalysisRequest) ([]byte, error) {
 	// Create template data with helper functions for JSON formatting
 	templateData := struct {
 		AnalysisRequest
-		TelosJSON string
+		TelosJSON    string
+		SystemPrompt string
 	}{
 		AnalysisRequest: req,
 		TelosJSON:       "",
+		SystemPrompt:    systemPromptOr(p.systemPrompt),
 	}
 
 	// Add JSON-encoded telos if available
@@ -277,7 +298,7 @@ func (p *CustomProvider) parseJSONResponse(data map[string]interface{}) (*Analys
 
 	// Default recommendation if not provided
 	if result.Recommendation == "" {
-		result.Recommendation = generateRecommendation(result.FinalScore)
+		result.Recommendation = processing.DetermineRecommendation(result.FinalScore)
 	}
 
 	return result, nil
@@ -375,20 +396,6 @@ func extractString(data map[string]interface{}, keys ...string) string {
 	return ""
 }
 
-// generateRecommendation generates a recommendation based on final score.
-func generateRecommendation(score float64) string {
-	switch {
-	case score >= 8.0:
-		return "strongly_pursue"
-	case score >= 6.0:
-		return "pursue"
-	case score >= 4.0:
-		return "review"
-	default:
-		return "deprioritize"
-	}
-}
-
 // getEnv gets an environment variable with a default value.
 func getEnv(key, defaultValue string) string {
 	if value := os.Getenv(key); value != "" {
@@ -2,7 +2,6 @@ package llm
 
 import (
 	"encoding/json"
-	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"os"
@@ -530,32 +529,6 @@ func TestParseHeaders(t *testing.T) {
 	}
 }
 
-// TestGenerateRecommendation tests recommendation generation based on score
-func TestGenerateRecommendation(t *testing.T) {
-	tests := []struct {
-		score    float64
-		expected string
-	}{
-		{score: 9.0, expected: "strongly_pursue"},
-		{score: 8.0, expected: "strongly_pursue"},
-		{score: 7.5, expected: "pursue"},
-		{score: 6.0, expected: "pursue"},
-		{score: 5.5, expected: "review"},
-		{score: 4.0, expected: "review"},
-		{score: 3.0, expected: "deprioritize"},
-		{score: 0.0, expected: "deprioritize"},
-	}
-
-	for _, tt := range tests {
-		t.Run(fmt.Sprintf("score_%.1f", tt.score), func(t *testing.T) {
-			result := generateRecommendation(tt.score)
-			if result != tt.expected {
-				t.Errorf("For score %.1f, expected '%s', got '%s'", tt.score, tt.expected, result)
-			}
-		})
-	}
-}
-
 // TestCustomProvider_CalculatedFinalScore tests final score calculation when not provided
 func TestCustomProvider_CalculatedFinalScore(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
@@ -610,8 +583,8 @@ func TestCustomProvider_DefaultRecommendation(t *testing.T) {
 		t.Fatalf("Expected no error, got %v", err)
 	}
 
-	// Score is 8.5, should generate "strongly_pursue"
-	if result.Recommendation != "strongly_pursue" {
-		t.Errorf("Expected generated recommendation 'strongly_pursue', got '%s'", result.Recommendation)
+	// Score is 8.5, the same cutoff every other provider prioritizes at
+	if result.Recommendation != "PRIORITIZE NOW" {
+		t.Errorf("Expected generated recommendation 'PRIORITIZE NOW', got '%s'", result.Recommendation)
 	}
 }
@@ -0,0 +1,184 @@
+package llm
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/ryacub/telos-idea-matrix/internal/llm/client"
+)
+
+// Default embedding models
+const (
+	DefaultOllamaEmbedModel = "nomic-embed-text"
+	DefaultOpenAIEmbedModel = "text-embedding-3-small"
+)
+
+// ErrNoEmbedder is returned by NewEmbedder when no embedding provider is
+// configured. Similarity search is unavailable, but nothing else is affected.
+var ErrNoEmbedder = errors.New("no embedding provider configured")
+
+// Embedder turns text into a vector; texts with similar meaning get vectors
+// with a high cosine similarity.
+type Embedder interface {
+	// Model identifies the provider and model. Vectors from different models
+	// cannot be compared.
+	Model() string
+
+	// Embed returns the embedding of text
+	Embed(ctx context.Context, text string) ([]float32, error)
+}
+
+// NewEmbedder returns the embedder for provider ("ollama" or "openai") using
+// model, or the provider's default model when model is empty. An empty
+// provider returns ErrNoEmbedder.
+func NewEmbedder(provider, model string) (Embedder, error) {
+	switch provider {
+	case "":
+		return nil, ErrNoEmbedder
+	case "ollama":
+		return NewOllamaEmbedder("", model), nil
+	case "openai":
+		e := NewOpenAIEmbedder(model)
+		if e.apiKey == "" {
+			return nil, fmt.Errorf("OpenAI embeddings need OPENAI_API_KEY")
+		}
+		return e, nil
+	default:
+		return nil, fmt.Errorf("unknown embedding provider %q (must be ollama or openai)", provider)
+	}
+}
+
+// OllamaEmbedder computes embeddings with a local Ollama model
+type OllamaEmbedder struct {
+	client *client.OllamaClient
+	model  string
+}
+
+// NewOllamaEmbedder creates an Ollama embedder. An empty baseURL uses the
+// local default, and an empty model uses nomic-embed-text.
+func NewOllamaEmbedder(baseURL, model string) *OllamaEmbedder {
+	if model == "" {
+		model = DefaultOllamaEmbedModel
+	}
+	return &OllamaEmbedder{
+		client: client.NewOllamaClient(baseURL, 30*time.Second),
+		model:  model,
+	}
+}
+
+// Model returns "ollama/<model>"
+func (e *OllamaEmbedder) Model() string {
+	return "ollama/" + e.model
+}
+
+// Embed returns the embedding of text
+func (e *OllamaEmbedder) Embed(ctx context.Context, text string) ([]float32, error) {
+	vector, err := e.client.Embed(ctx, client.EmbedRequest{Model: e.model, Input: text})
+	if err != nil {
+		return nil, fmt.Errorf("ollama embedding failed: %w", err)
+	}
+	return vector, nil
+}
+
+// OpenAIEmbedder computes embeddings with the OpenAI embeddings API
+type OpenAIEmbedder struct {
+	apiKey     string
+	model      string
+	baseURL    string
+	httpClient *http.Client
+}
+
+// NewOpenAIEmbedder creates an OpenAI embedder authenticated with
+// OPENAI_API_KEY. An empty model uses text-embedding-3-small.
+func NewOpenAIEmbedder(model string) *OpenAIEmbedder {
+	if model == "" {
+		model = DefaultOpenAIEmbedModel
+	}
+	return &OpenAIEmbedder{
+		apiKey:     os.Getenv("OPENAI_API_KEY"),
+		model:      model,
+		baseURL:    "https://api.openai.com/v1/embeddings",
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// Model returns "openai/<model>"
+func (e *OpenAIEmbedder) Model() string {
+	return "openai/" + e.model
+}
+
+type openAIEmbeddingRequest struct {
+	Model string `json:"model"`
+	Input string `json:"input"`
+}
+
+type openAIEmbeddingResponse struct {
+	Data []struct {
+		Embedding []float32 `json:"embedding"`
+	} `json:"data"`
+}
+
+// Embed returns the embedding of text
+func (e *OpenAIEmbedder) Embed(ctx context.Context, text string) ([]float32, error) {
+	body, err := json.Marshal(openAIEmbeddingRequest{Model: e.model, Input: text})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, "POST", e.baseURL, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("Authorization", "Bearer "+e.apiKey)
+
+	httpResp, err := e.httpClient.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("HTTP request failed: %w", err)
+	}
+	defer func() { _ = httpResp.Body.Close() }()
+
+	respBody, err := io.ReadAll(httpResp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	if httpResp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("OpenAI API error (status %d): %s", httpResp.StatusCode, string(respBody))
+	}
+
+	var resp openAIEmbeddingResponse
+	if err := json.Unmarshal(respBody, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+	if len(resp.Data) == 0 || len(resp.Data[0].Embedding) == 0 {
+		return nil, fmt.Errorf("no embedding from OpenAI")
+	}
+	return resp.Data[0].Embedding, nil
+}
+
+// CosineSimilarity returns the cosine of the angle between a and b, from -1
+// to 1. Vectors of different lengths, or with no magnitude, return 0.
+func CosineSimilarity(a, b []float32) float64 {
+	if len(a) != len(b) || len(a) == 0 {
+		return 0
+	}
+
+	var dot, normA, normB float64
+	for i := range a {
+		dot += float64(a[i]) * float64(b[i])
+		normA += float64(a[i]) * float64(a[i])
+		normB += float64(b[i]) * float64(b[i])
+	}
+	if normA == 0 || normB == 0 {
+		return 0
+	}
+	return dot / (math.Sqrt(normA) * math.Sqrt(normB))
+}
@@ -0,0 +1,92 @@
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ryacub/telos-idea-matrix/internal/llm/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewEmbedder(t *testing.T) {
+	_, err := NewEmbedder("", "")
+	assert.ErrorIs(t, err, ErrNoEmbedder)
+
+	_, err = NewEmbedder("claude", "")
+	assert.Error(t, err)
+
+	embedder, err := NewEmbedder("ollama", "")
+	require.NoError(t, err)
+	assert.Equal(t, "ollama/nomic-embed-text", embedder.Model())
+
+	t.Setenv("OPENAI_API_KEY", "")
+	_, err = NewEmbedder("openai", "")
+	assert.Error(t, err, "OpenAI embeddings need an API key")
+
+	t.Setenv("OPENAI_API_KEY", "sk-test")
+	embedder, err = NewEmbedder("openai", "text-embedding-3-large")
+	require.NoError(t, err)
+	assert.Equal(t, "openai/text-embedding-3-large", embedder.Model())
+}
+
+func TestOllamaEmbedder_Embed(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/embed", r.URL.Path)
+		var req client.EmbedRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, "nomic-embed-text", req.Model)
+		assert.Equal(t, "a podcast about woodworking", req.Input)
+		_, _ = w.Write([]byte(`{"model":"nomic-embed-text","embeddings":[[0.1,0.2,0.3]]}`))
+	}))
+	defer server.Close()
+
+	vector, err := NewOllamaEmbedder(server.URL, "").Embed(context.Background(), "a podcast about woodworking")
+	require.NoError(t, err)
+	assert.Equal(t, []float32{0.1, 0.2, 0.3}, vector)
+}
+
+func TestOpenAIEmbedder_Embed(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer sk-test", r.Header.Get("Authorization"))
+		var req openAIEmbeddingRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, DefaultOpenAIEmbedModel, req.Model)
+		_, _ = w.Write([]byte(`{"data":[{"embedding":[0.5,-0.5]}]}`))
+	}))
+	defer server.Close()
+
+	t.Setenv("OPENAI_API_KEY", "sk-test")
+	embedder := NewOpenAIEmbedder("")
+	embedder.baseURL = server.URL
+
+	vector, err := embedder.Embed(context.Background(), "an idea")
+	require.NoError(t, err)
+	assert.Equal(t, []float32{0.5, -0.5}, vector)
+}
+
+func TestOpenAIEmbedder_EmbedReportsAPIError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(`{"error":{"message":"bad key"}}`))
+	}))
+	defer server.Close()
+
+	embedder := NewOpenAIEmbedder("")
+	embedder.baseURL = server.URL
+
+	_, err := embedder.Embed(context.Background(), "an idea")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "401")
+}
+
+func TestCosineSimilarity(t *testing.T) {
+	assert.InDelta(t, 1.0, CosineSimilarity([]float32{1, 2, 3}, []float32{2, 4, 6}), 1e-9)
+	assert.InDelta(t, 0.0, CosineSimilarity([]float32{1, 0}, []float32{0, 1}), 1e-9)
+	assert.InDelta(t, -1.0, CosineSimilarity([]float32{1, 1}, []float32{-1, -1}), 1e-9)
+	assert.Zero(t, CosineSimilarity([]float32{1, 2}, []float32{1, 2, 3}), "mismatched lengths")
+	assert.Zero(t, CosineSimilarity([]float32{0, 0}, []float32{1, 1}), "zero vector")
+}
@@ -0,0 +1,51 @@
+package llm
+
+import (
+	"context"
+	"os"
+)
+
+// defaultGroqModel is used when neither the config nor GROQ_MODEL names a model
+const defaultGroqModel = "llama-3.1-70b-versatile"
+
+// GroqProvider implements the Provider interface using Groq's
+// OpenAI-compatible chat completions API
+type GroqProvider struct {
+	openAICompatibleProvider
+}
+
+// NewGroqProvider creates a new Groq provider with the given configuration.
+// If apiKey is empty, it will try to read from GROQ_API_KEY environment variable.
+// If model is empty, it reads GROQ_MODEL and then defaults to "llama-3.1-70b-versatile".
+func NewGroqProvider(apiKey string, model string) *GroqProvider {
+	if apiKey == "" {
+		apiKey = os.Getenv("GROQ_API_KEY")
+	}
+	if model == "" {
+		model = os.Getenv("GROQ_MODEL")
+	}
+	if model == "" {
+		model = defaultGroqModel
+	}
+
+	return &GroqProvider{newOpenAICompatibleProvider(
+		"Groq", "GROQ_API_KEY", apiKey, model,
+		"https://api.groq.com/openai/v1/chat/completions",
+	)}
+}
+
+// Name returns the provider name
+func (p *GroqProvider) Name() string {
+	return "groq"
+}
+
+// Analyze performs idea analysis using a model hosted on Groq
+func (p *GroqProvider) Analyze(req AnalysisRequest) (*AnalysisResult, error) {
+	return p.AnalyzeContext(context.Background(), req)
+}
+
+// AnalyzeContext performs idea analysis using a model hosted on Groq,
+// abandoning the request and any pending retries if ctx is cancelled
+func (p *GroqProvider) AnalyzeContext(ctx context.Context, req AnalysisRequest) (*AnalysisResult, error) {
+	return p.analyze(ctx, p.Name(), req)
+}
@@ -0,0 +1,101 @@
+package llm
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ryacub/telos-idea-matrix/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
+)
+
+func TestNewGroqProvider_Defaults(t *testing.T) {
+	t.Setenv("GROQ_API_KEY", "")
+	t.Setenv("GROQ_MODEL", "")
+
+	provider := NewGroqProvider("", "")
+	assert.Equal(t, "groq", provider.Name())
+	assert.Equal(t, defaultGroqModel, provider.GetModel())
+	assert.False(t, provider.IsAvailable(), "Provider should not be available without API key")
+
+	t.Setenv("GROQ_API_KEY", "gsk_env")
+	t.Setenv("GROQ_MODEL", "llama-3.1-8b-instant")
+	provider = NewGroqProvider("", "")
+	assert.True(t, provider.IsAvailable())
+	assert.Equal(t, "llama-3.1-8b-instant", provider.GetModel())
+
+	provider = NewGroqProvider("gsk_config", "mixtral-8x7b-32768")
+	assert.Equal(t, "gsk_config", provider.GetAPIKey())
+	assert.Equal(t, "mixtral-8x7b-32768", provider.GetModel())
+}
+
+func TestGroqProvider_Analyze_MockServer(t *testing.T) {
+	var body map[string]interface{}
+	var auth string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth = r.Header.Get("Authorization")
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{
+			"choices": [{
+				"message": {
+					"role": "assistant",
+					"content": "{\"scores\": {\"mission_alignment\": 3.0, \"anti_challenge\": 2.5, \"strategic_fit\": 1.5}, \"final_score\": 7.0, \"recommendation\": \"GOOD ALIGNMENT\", \"explanations\": {}}"
+				}
+			}],
+			"usage": {"prompt_tokens": 120, "completion_tokens": 40}
+		}`))
+	}))
+	defer server.Close()
+
+	provider := NewGroqProvider("gsk_test", "")
+	provider.baseURL = server.URL
+	provider.maxRetries = 1
+	provider.rateLimiter = rate.NewLimiter(rate.Inf, 1)
+
+	result, err := provider.Analyze(AnalysisRequest{
+		IdeaContent: "Build a fast inference demo",
+		Telos:       &models.Telos{Goals: []models.Goal{{ID: "g1", Description: "Ship demos"}}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 7.0, result.FinalScore)
+	assert.Equal(t, "groq", result.Provider)
+	assert.Equal(t, "Bearer gsk_test", auth)
+	assert.Equal(t, defaultGroqModel, body["model"])
+	assert.Equal(t, map[string]interface{}{"type": "json_object"}, body["response_format"])
+}
+
+func TestGroqProvider_APIError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(`{"error": {"message": "Invalid API Key"}}`))
+	}))
+	defer server.Close()
+
+	provider := NewGroqProvider("gsk_bad", "")
+	provider.baseURL = server.URL
+	provider.maxRetries = 1
+	provider.rateLimiter = rate.NewLimiter(rate.Inf, 1)
+
+	_, err := provider.Analyze(AnalysisRequest{IdeaContent: "test", Telos: &models.Telos{}})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Groq API error (status 401)")
+}
+
+func TestManager_RegistersGroqWithAPIKey(t *testing.T) {
+	t.Setenv("TELOS_HOME", t.TempDir())
+	t.Setenv("GROQ_API_KEY", "")
+	config := DefaultManagerConfig()
+	config.ProviderConfig.OllamaBaseURL = ""
+	manager := NewManager(config)
+	assert.NotContains(t, manager.ProviderNames(), "groq")
+
+	config = DefaultManagerConfig()
+	config.ProviderConfig.OllamaBaseURL = ""
+	config.ProviderConfig.GroqAPIKey = "gsk_test"
+	manager = NewManager(config)
+	assert.Contains(t, manager.ProviderNames(), "groq")
+}
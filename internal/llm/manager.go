@@ -1,14 +1,19 @@
 package llm
 
 import (
+	"context"
 	"fmt"
+	"strings"
 	"sync"
-	"sync/atomic"
+	"text/template"
 	"time"
 
+	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
 	"github.com/ryacub/telos-idea-matrix/internal/metrics"
 	"github.com/ryacub/telos-idea-matrix/internal/models"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/time/rate"
 )
 
 // Manager handles multiple LLM providers with fallback, health checks, and statistics
@@ -19,6 +24,9 @@ type Manager struct {
 	mu              sync.RWMutex
 	healthCache     map[string]healthStatus
 	stats           map[string]*providerStats
+	limiters        map[string]*rate.Limiter
+	promptTemplates map[string]*template.Template // Per-provider analysis prompts, from LoadPromptTemplates
+	tracer          trace.Tracer                  // Nil records no spans
 	config          *ManagerConfig
 }
 
@@ -28,14 +36,58 @@ type healthStatus struct {
 	lastCheck time.Time
 }
 
-// providerStats tracks statistics for a provider
+// providerStats tracks statistics for a provider. The counters are guarded
+// by one mutex rather than separate atomics so a snapshot is consistent:
+// TotalRequests always equals SuccessCount plus FailureCount, and the average
+// latency is computed from a matching count.
 type providerStats struct {
+	mu            sync.Mutex
 	totalRequests int64
 	successCount  int64
 	failureCount  int64
-	totalLatency  int64 // in nanoseconds
+	totalLatency  time.Duration // of successful requests
 	lastUsed      time.Time
-	mu            sync.RWMutex
+}
+
+// record counts one finished request
+func (s *providerStats) record(duration time.Duration, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.totalRequests++
+	if err != nil {
+		s.failureCount++
+	} else {
+		s.successCount++
+		s.totalLatency += duration
+	}
+	s.lastUsed = time.Now()
+}
+
+// snapshot returns the counters for a provider; Available is left for the caller
+func (s *providerStats) snapshot(name string) ProviderStats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stats := ProviderStats{
+		Name:          name,
+		TotalRequests: s.totalRequests,
+		SuccessCount:  s.successCount,
+		FailureCount:  s.failureCount,
+		LastUsed:      s.lastUsed,
+	}
+	if s.successCount > 0 {
+		stats.AverageLatency = s.totalLatency / time.Duration(s.successCount)
+	}
+	return stats
+}
+
+func (s *providerStats) reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.totalRequests, s.successCount, s.failureCount = 0, 0, 0
+	s.totalLatency = 0
+	s.lastUsed = time.Time{}
 }
 
 // ManagerConfig contains configuration for the provider manager
@@ -45,6 +97,20 @@ type ManagerConfig struct {
 	HealthCheckInterval time.Duration
 	Priority            []string
 	ProviderConfig      ProviderConfig
+
+	// HealthCheckTimeout bounds each provider's probe; a provider that doesn't
+	// answer in time is marked unavailable for that round
+	HealthCheckTimeout time.Duration
+
+	// HealthCheckConcurrency caps how many providers are probed at once (0 = all)
+	HealthCheckConcurrency int
+
+	// OnRateLimited is called when a request waits for a provider's rate limit
+	OnRateLimited RateLimitedFunc
+
+	// Tracer records a span for each analysis, with child spans for the
+	// provider's HTTP calls and response parsing; nil records nothing
+	Tracer trace.Tracer
 }
 
 // DefaultManagerConfig returns the default manager configuration
@@ -53,8 +119,11 @@ func DefaultManagerConfig() *ManagerConfig {
 		DefaultProvider:     "",
 		FallbackEnabled:     true,
 		HealthCheckInterval: 30 * time.Second,
-		Priority:            []string{"ollama", "claude", "openai", "custom", "rule_based"},
+		Priority:            []string{"ollama", "claude", "openai", "groq", "custom", "rule_based"},
 		ProviderConfig:      DefaultProviderConfig(),
+
+		HealthCheckTimeout:     5 * time.Second,
+		HealthCheckConcurrency: 4,
 	}
 }
 
@@ -69,6 +138,8 @@ func NewManager(config *ManagerConfig) *Manager {
 		fallbackEnabled: config.FallbackEnabled,
 		healthCache:     make(map[string]healthStatus),
 		stats:           make(map[string]*providerStats),
+		limiters:        make(map[string]*rate.Limiter),
+		tracer:          config.Tracer,
 		config:          config,
 	}
 
@@ -93,6 +164,7 @@ func (m *Manager) registerAvailableProviders() {
 			m.config.ProviderConfig.OllamaBaseURL,
 			m.config.ProviderConfig.OllamaModel,
 		)
+		ollama.SetSystemPrompt(m.config.ProviderConfig.SystemPrompts["ollama"])
 		m.RegisterProvider(ollama)
 	}
 
@@ -100,18 +172,34 @@ func (m *Manager) registerAvailableProviders() {
 	claudeAPIKey := m.config.ProviderConfig.ClaudeAPIKey
 	claudeModel := m.config.ProviderConfig.ClaudeModel
 	claude := NewClaudeProvider(claudeAPIKey, claudeModel)
+	if timeout := m.config.ProviderConfig.ClaudeTimeout; timeout > 0 {
+		claude.httpClient.Timeout = time.Duration(timeout) * time.Second
+	}
+	claude.SetSystemPrompt(m.config.ProviderConfig.SystemPrompts["claude"])
 	if claude.IsAvailable() {
 		m.RegisterProvider(claude)
 	}
 
 	// Register OpenAI if API key is available (reads from env var)
 	openai := NewOpenAIProvider()
+	openai.SetSystemPrompt(m.config.ProviderConfig.SystemPrompts["openai"])
 	if openai.IsAvailable() {
 		m.RegisterProvider(openai)
 	}
 
+	// Register Groq if API key is available (from config or env var)
+	groq := NewGroqProvider(m.config.ProviderConfig.GroqAPIKey, m.config.ProviderConfig.GroqModel)
+	if timeout := m.config.ProviderConfig.GroqTimeout; timeout > 0 {
+		groq.httpClient.Timeout = time.Duration(timeout) * time.Second
+	}
+	groq.SetSystemPrompt(m.config.ProviderConfig.SystemPrompts["groq"])
+	if groq.IsAvailable() {
+		m.RegisterProvider(groq)
+	}
+
 	// Register Custom provider if endpoint is configured (reads from env var)
 	custom := NewCustomProvider()
+	custom.SetSystemPrompt(m.config.ProviderConfig.SystemPrompts["custom"])
 	if custom.IsAvailable() {
 		m.RegisterProvider(custom)
 	}
@@ -132,6 +220,9 @@ func (m *Manager) RegisterProvider(p Provider) {
 
 	m.providers = append(m.providers, p)
 	m.stats[p.Name()] = &providerStats{}
+	if rpm := m.requestsPerMinuteFor(p.Name()); rpm > 0 {
+		m.limiters[p.Name()] = newRequestsPerMinuteLimiter(rpm)
+	}
 	m.healthCache[p.Name()] = healthStatus{
 		available: p.IsAvailable(),
 		lastCheck: time.Now(),
@@ -167,6 +258,103 @@ func (m *Manager) selectPrimaryProvider() {
 
 // Analyze performs analysis using the primary provider with fallback support
 func (m *Manager) Analyze(req AnalysisRequest) (*AnalysisResult, error) {
+	return m.AnalyzeContext(context.Background(), req)
+}
+
+// AnalyzeContext is Analyze bound to ctx. Cancelling ctx abandons the
+// in-flight provider request and skips the fallback chain, returning ctx's
+// error.
+//
+// Each call is recorded as an "llm.analyze" span, a child of any span in ctx,
+// with the provider used, the number of providers tried and the outcome.
+func (m *Manager) AnalyzeContext(ctx context.Context, req AnalysisRequest) (*AnalysisResult, error) {
+	tracer := m.tracer
+	if tracer == nil {
+		tracer = noopTracer
+	}
+	ctx, span := tracer.Start(ctx, "llm.analyze")
+
+	result, provider, attempts, err := m.analyzeWithFallback(ctx, req)
+
+	outcome := outcomeSuccess
+	switch {
+	case err != nil && ctx.Err() != nil:
+		outcome = outcomeCancelled
+	case err != nil:
+		outcome = outcomeFailed
+	case attempts > 1:
+		outcome = outcomeFallback
+	}
+	span.SetAttributes(
+		attrProvider.String(provider),
+		attrAttempts.Int(attempts),
+		attrOutcome.String(outcome),
+	)
+	endSpan(span, err)
+
+	return result, err
+}
+
+// AnalyzeAll runs req through every registered provider at once, to compare
+// how they score the same idea. Results are keyed by provider name, with
+// Duration set to each provider's latency; providers that are unavailable or
+// fail are left out. See AnalyzeAllContext for their errors.
+func (m *Manager) AnalyzeAll(req AnalysisRequest) map[string]AnalysisResult {
+	results, _ := m.AnalyzeAllContext(context.Background(), req)
+	return results
+}
+
+// AnalyzeAllContext is AnalyzeAll bound to ctx. It also returns why each
+// provider left out of the results failed, ErrUnavailable for those that
+// were not available.
+func (m *Manager) AnalyzeAllContext(ctx context.Context, req AnalysisRequest) (map[string]AnalysisResult, map[string]error) {
+	providers := m.GetProviders()
+
+	results := make(map[string]AnalysisResult, len(providers))
+	errs := make(map[string]error)
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+
+	for _, p := range providers {
+		wg.Add(1)
+		go func(p Provider) {
+			defer wg.Done()
+
+			if !p.IsAvailable() {
+				mu.Lock()
+				errs[p.Name()] = ErrUnavailable
+				mu.Unlock()
+				return
+			}
+
+			start := time.Now()
+			result, err := m.analyzeWithProvider(ctx, p, req)
+			elapsed := time.Since(start)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				errs[p.Name()] = err
+				return
+			}
+			// Copy the result; providers may hand out results they also cache
+			timed := *result
+			timed.Provider = p.Name()
+			timed.Duration = elapsed
+			results[p.Name()] = timed
+		}(p)
+	}
+
+	wg.Wait()
+	return results, errs
+}
+
+// analyzeWithFallback tries the primary provider, then the others in order
+// if fallback is enabled. It returns the provider that answered, or the last
+// one tried, and how many providers were tried. The result records every
+// provider considered, and why each that didn't answer failed, in its
+// FallbackChain; when all fail the error lists them instead.
+func (m *Manager) analyzeWithFallback(ctx context.Context, req AnalysisRequest) (*AnalysisResult, string, int, error) {
 	m.mu.RLock()
 	primary := m.primary
 	fallbackEnabled := m.fallbackEnabled
@@ -174,14 +362,21 @@ func (m *Manager) Analyze(req AnalysisRequest) (*AnalysisResult, error) {
 	var primaryProviderName string
 	var result *AnalysisResult
 	var err error
+	var chain []string
+	attempts := 0
 
 	if primary != nil {
 		primaryProviderName = primary.Name()
 		m.mu.RUnlock() // Unlock before potentially slow I/O
-		result, err = m.analyzeWithProvider(primary, req)
+		attempts++
+		result, err = m.analyzeWithProvider(ctx, primary, req)
 		if err == nil {
-			return result, nil
+			return withFallbackChain(result, append(chain, primaryProviderName+": ok")), primaryProviderName, attempts, nil
+		}
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, primaryProviderName, attempts, ctxErr
 		}
+		chain = append(chain, fmt.Sprintf("%s: %v", primaryProviderName, err))
 		// Log primary failure but continue to fallback
 		fmt.Printf("[Manager] Primary provider %s failed: %v\n", primaryProviderName, err)
 	} else {
@@ -190,7 +385,7 @@ func (m *Manager) Analyze(req AnalysisRequest) (*AnalysisResult, error) {
 
 	// If fallback disabled, return error
 	if !fallbackEnabled {
-		return nil, fmt.Errorf("primary provider failed and fallback disabled")
+		return nil, primaryProviderName, attempts, fmt.Errorf("primary provider failed and fallback disabled")
 	}
 
 	// Fallback chain
@@ -198,72 +393,95 @@ func (m *Manager) Analyze(req AnalysisRequest) (*AnalysisResult, error) {
 	providers := m.providers
 	m.mu.RUnlock()
 
+	lastProvider := primaryProviderName
 	var lastErr error
 	for _, provider := range providers {
+		if err := ctx.Err(); err != nil {
+			return nil, lastProvider, attempts, err
+		}
+
 		// Skip primary (already tried)
 		if primary != nil && provider.Name() == primary.Name() {
 			continue
 		}
 
 		if !provider.IsAvailable() {
+			chain = append(chain, provider.Name()+": unavailable")
 			continue
 		}
 
 		// Record fallback event
 		metrics.RecordLLMFallback(primaryProviderName, provider.Name())
 
-		result, err := m.analyzeWithProvider(provider, req)
+		attempts++
+		lastProvider = provider.Name()
+		result, err := m.analyzeWithProvider(ctx, provider, req)
 		if err == nil {
 			fmt.Printf("[Manager] Fallback succeeded with provider: %s\n", provider.Name())
-			return result, nil
+			return withFallbackChain(result, append(chain, lastProvider+": ok")), lastProvider, attempts, nil
 		}
 
+		chain = append(chain, fmt.Sprintf("%s: %v", provider.Name(), err))
 		lastErr = err
 	}
 
-	return nil, fmt.Errorf("all providers failed, last error: %w", lastErr)
+	return nil, lastProvider, attempts, fmt.Errorf("all providers failed (%s), last error: %w", strings.Join(chain, "; "), lastErr)
+}
+
+// withFallbackChain returns a copy of result with its fallback chain set.
+// Providers may hand out results they also cache, so result isn't modified.
+func withFallbackChain(result *AnalysisResult, chain []string) *AnalysisResult {
+	annotated := *result
+	annotated.FallbackChain = chain
+	return &annotated
 }
 
 // analyzeWithProvider performs analysis with a specific provider and tracks statistics
-func (m *Manager) analyzeWithProvider(provider Provider, req AnalysisRequest) (*AnalysisResult, error) {
-	start := time.Now()
+func (m *Manager) analyzeWithProvider(ctx context.Context, provider Provider, req AnalysisRequest) (*AnalysisResult, error) {
+	// Render the provider's own prompt; a broken template fails this provider
+	req, err := m.withPromptTemplate(provider.Name(), req)
+	if err != nil {
+		return nil, err
+	}
 
-	// Update stats - increment total requests
-	m.updateStats(provider.Name(), func(stats *providerStats) {
-		atomic.AddInt64(&stats.totalRequests, 1)
-		stats.mu.Lock()
-		stats.lastUsed = time.Now()
-		stats.mu.Unlock()
-	})
+	// Respect the provider's request quota before calling it
+	if err := m.waitForRateLimit(ctx, provider.Name()); err != nil {
+		return nil, err
+	}
 
-	// Perform analysis
-	result, err := provider.Analyze(req)
+	start := time.Now()
+	result, err := provider.AnalyzeContext(ctx, req)
 	duration := time.Since(start)
+	m.RecordRequest(provider.Name(), duration, err)
+
+	// Only logged when the caller attached a logger to ctx, such as an API
+	// request's, so the lines carry its request ID
+	logger := zerolog.Ctx(ctx)
 
-	// Update stats based on result
 	if err != nil {
-		m.updateStats(provider.Name(), func(stats *providerStats) {
-			atomic.AddInt64(&stats.failureCount, 1)
-		})
+		logger.Warn().Err(err).Str("provider", provider.Name()).Dur("duration_ms", duration).Msg("llm analysis failed")
 		return nil, err
 	}
 
-	m.updateStats(provider.Name(), func(stats *providerStats) {
-		atomic.AddInt64(&stats.successCount, 1)
-		atomic.AddInt64(&stats.totalLatency, int64(duration))
-	})
+	logger.Info().
+		Str("provider", provider.Name()).
+		Float64("final_score", result.FinalScore).
+		Dur("duration_ms", duration).
+		Msg("llm analysis completed")
 
 	return result, nil
 }
 
-// updateStats safely updates provider statistics
-func (m *Manager) updateStats(providerName string, updateFunc func(*providerStats)) {
+// RecordRequest counts a finished request to a provider in its statistics,
+// as failed if err is set. It is safe to call from multiple goroutines;
+// requests to a provider that isn't registered are ignored.
+func (m *Manager) RecordRequest(providerName string, duration time.Duration, err error) {
 	m.mu.RLock()
 	stats, exists := m.stats[providerName]
 	m.mu.RUnlock()
 
-	if exists && stats != nil {
-		updateFunc(stats)
+	if exists {
+		stats.record(duration, err)
 	}
 }
 
@@ -319,28 +537,79 @@ func (m *Manager) IsFallbackEnabled() bool {
 	return m.fallbackEnabled
 }
 
-// HealthCheck performs health checks on all providers
+// HealthCheck performs health checks on all providers.
+// Providers are probed concurrently, each with its own timeout, and the health
+// cache is updated as each probe finishes so a slow provider doesn't hold back
+// the others' status.
 func (m *Manager) HealthCheck() map[string]bool {
+	// Snapshot under the lock: LoadConfig may swap the config and reorder
+	// providers while probes are running
 	m.mu.RLock()
-	providers := m.providers
+	providers := append([]Provider(nil), m.providers...)
+	timeout := m.config.HealthCheckTimeout
+	concurrency := m.config.HealthCheckConcurrency
 	m.mu.RUnlock()
 
-	status := make(map[string]bool)
+	if timeout <= 0 {
+		timeout = 5 * time.Second
+	}
+	if concurrency <= 0 || concurrency > len(providers) {
+		concurrency = len(providers)
+	}
+
+	status := make(map[string]bool, len(providers))
+	var statusMu sync.Mutex
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, concurrency)
+
 	for _, p := range providers {
-		available := p.IsAvailable()
-		status[p.Name()] = available
-
-		// Update health cache
-		m.mu.Lock()
-		m.healthCache[p.Name()] = healthStatus{
-			available: available,
-			lastCheck: time.Now(),
-		}
-		m.mu.Unlock()
+		wg.Add(1)
+		go func(p Provider) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			available := probeProvider(p, timeout)
+
+			statusMu.Lock()
+			status[p.Name()] = available
+			statusMu.Unlock()
+
+			// Update health cache
+			m.mu.Lock()
+			m.healthCache[p.Name()] = healthStatus{
+				available: available,
+				lastCheck: time.Now(),
+			}
+			m.mu.Unlock()
+		}(p)
 	}
+
+	wg.Wait()
 	return status
 }
 
+// probeProvider reports whether a provider is available, treating a probe
+// that outlasts timeout as unavailable. Provider.IsAvailable takes no context,
+// so a hung probe is abandoned rather than cancelled; its result is discarded.
+func probeProvider(p Provider, timeout time.Duration) bool {
+	result := make(chan bool, 1)
+	go func() {
+		result <- p.IsAvailable()
+	}()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case available := <-result:
+		return available
+	case <-timer.C:
+		log.Warn().Str("provider", p.Name()).Dur("timeout", timeout).Msg("provider health check timed out")
+		return false
+	}
+}
+
 // GetHealthStatus returns the health status for a specific provider
 func (m *Manager) GetHealthStatus(providerName string) (bool, time.Time, error) {
 	m.mu.RLock()
@@ -356,7 +625,9 @@ func (m *Manager) GetHealthStatus(providerName string) (bool, time.Time, error)
 
 // StartPeriodicHealthCheck runs health checks in background
 func (m *Manager) StartPeriodicHealthCheck(stopCh <-chan struct{}) {
+	m.mu.RLock()
 	interval := m.config.HealthCheckInterval
+	m.mu.RUnlock()
 	if interval == 0 {
 		interval = 30 * time.Second
 	}
@@ -385,40 +656,28 @@ type ProviderStats struct {
 	LastUsed       time.Time
 }
 
-// GetStats returns statistics for all providers
+// GetStats returns statistics for all providers. It is safe to call while
+// analyses are running.
 func (m *Manager) GetStats() []ProviderStats {
+	// Snapshot under the lock, then ask for availability outside it, since a
+	// provider may check over the network
+	type entry struct {
+		provider Provider
+		stats    ProviderStats
+	}
 	m.mu.RLock()
-	defer m.mu.RUnlock()
-
-	stats := make([]ProviderStats, 0, len(m.providers))
+	entries := make([]entry, 0, len(m.providers))
 	for _, p := range m.providers {
-		providerStats, exists := m.stats[p.Name()]
-		if !exists {
-			continue
-		}
-
-		providerStats.mu.RLock()
-		totalRequests := atomic.LoadInt64(&providerStats.totalRequests)
-		successCount := atomic.LoadInt64(&providerStats.successCount)
-		failureCount := atomic.LoadInt64(&providerStats.failureCount)
-		totalLatency := atomic.LoadInt64(&providerStats.totalLatency)
-		lastUsed := providerStats.lastUsed
-		providerStats.mu.RUnlock()
-
-		var avgLatency time.Duration
-		if successCount > 0 {
-			avgLatency = time.Duration(totalLatency / successCount)
+		if providerStats, exists := m.stats[p.Name()]; exists {
+			entries = append(entries, entry{provider: p, stats: providerStats.snapshot(p.Name())})
 		}
+	}
+	m.mu.RUnlock()
 
-		stats = append(stats, ProviderStats{
-			Name:           p.Name(),
-			Available:      p.IsAvailable(),
-			TotalRequests:  totalRequests,
-			SuccessCount:   successCount,
-			FailureCount:   failureCount,
-			AverageLatency: avgLatency,
-			LastUsed:       lastUsed,
-		})
+	stats := make([]ProviderStats, len(entries))
+	for i, e := range entries {
+		stats[i] = e.stats
+		stats[i].Available = e.provider.IsAvailable()
 	}
 	return stats
 }
@@ -426,13 +685,7 @@ func (m *Manager) GetStats() []ProviderStats {
 // GetProviderStats returns statistics for a specific provider
 func (m *Manager) GetProviderStats(providerName string) (*ProviderStats, error) {
 	m.mu.RLock()
-	defer m.mu.RUnlock()
-
 	providerStats, exists := m.stats[providerName]
-	if !exists {
-		return nil, fmt.Errorf("provider not found: %s", providerName)
-	}
-
 	var provider Provider
 	for _, p := range m.providers {
 		if p.Name() == providerName {
@@ -440,33 +693,15 @@ func (m *Manager) GetProviderStats(providerName string) (*ProviderStats, error)
 			break
 		}
 	}
+	m.mu.RUnlock()
 
-	if provider == nil {
+	if !exists || provider == nil {
 		return nil, fmt.Errorf("provider not found: %s", providerName)
 	}
 
-	providerStats.mu.RLock()
-	totalRequests := atomic.LoadInt64(&providerStats.totalRequests)
-	successCount := atomic.LoadInt64(&providerStats.successCount)
-	failureCount := atomic.LoadInt64(&providerStats.failureCount)
-	totalLatency := atomic.LoadInt64(&providerStats.totalLatency)
-	lastUsed := providerStats.lastUsed
-	providerStats.mu.RUnlock()
-
-	var avgLatency time.Duration
-	if successCount > 0 {
-		avgLatency = time.Duration(totalLatency / successCount)
-	}
-
-	return &ProviderStats{
-		Name:           providerName,
-		Available:      provider.IsAvailable(),
-		TotalRequests:  totalRequests,
-		SuccessCount:   successCount,
-		FailureCount:   failureCount,
-		AverageLatency: avgLatency,
-		LastUsed:       lastUsed,
-	}, nil
+	stats := providerStats.snapshot(providerName)
+	stats.Available = provider.IsAvailable()
+	return &stats, nil
 }
 
 // LoadConfig applies a new configuration to the manager
@@ -550,19 +785,25 @@ func (m *Manager) GetProviders() []Provider {
 	return providers
 }
 
+// ProviderNames returns the names of all registered providers in priority order
+func (m *Manager) ProviderNames() []string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	names := make([]string, len(m.providers))
+	for i, p := range m.providers {
+		names[i] = p.Name()
+	}
+	return names
+}
+
 // ResetStats resets statistics for all providers
 func (m *Manager) ResetStats() {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
 	for _, stats := range m.stats {
-		atomic.StoreInt64(&stats.totalRequests, 0)
-		atomic.StoreInt64(&stats.successCount, 0)
-		atomic.StoreInt64(&stats.failureCount, 0)
-		atomic.StoreInt64(&stats.totalLatency, 0)
-		stats.mu.Lock()
-		stats.lastUsed = time.Time{}
-		stats.mu.Unlock()
+		stats.reset()
 	}
 }
 
@@ -603,9 +844,14 @@ func (m *Manager) GetAllProviders() map[string]Provider {
 // AnalyzeWithTelos is a helper that performs analysis with idea content and telos
 // This is a convenience method for CLI integration
 func (m *Manager) AnalyzeWithTelos(ideaContent string, telos *models.Telos) (*AnalysisResult, error) {
+	return m.AnalyzeWithTelosContext(context.Background(), ideaContent, telos)
+}
+
+// AnalyzeWithTelosContext is AnalyzeWithTelos bound to ctx
+func (m *Manager) AnalyzeWithTelosContext(ctx context.Context, ideaContent string, telos *models.Telos) (*AnalysisResult, error) {
 	req := AnalysisRequest{
 		IdeaContent: ideaContent,
 		Telos:       telos,
 	}
-	return m.Analyze(req)
+	return m.AnalyzeContext(ctx, req)
 }
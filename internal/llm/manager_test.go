@@ -1,11 +1,17 @@
 package llm
 
 import (
+	"bytes"
+	"context"
 	"errors"
+	"reflect"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/rs/zerolog"
 	"github.com/ryacub/telos-idea-matrix/internal/models"
 )
 
@@ -51,6 +57,16 @@ func (m *mockProviderForManager) Analyze(req AnalysisRequest) (*AnalysisResult,
 	return m.result, nil
 }
 
+func (m *mockProviderForManager) AnalyzeContext(ctx context.Context, req AnalysisRequest) (*AnalysisResult, error) {
+	if err := ctx.Err(); err != nil {
+		m.mu.Lock()
+		m.callCount++
+		m.mu.Unlock()
+		return nil, err
+	}
+	return m.Analyze(req)
+}
+
 func (m *mockProviderForManager) GetCallCount() int {
 	m.mu.Lock()
 	defer m.mu.Unlock()
@@ -188,6 +204,100 @@ func TestManager_FallbackChain(t *testing.T) {
 	}
 }
 
+func TestManager_RecordsFallbackChain(t *testing.T) {
+	shared := &AnalysisResult{FinalScore: 6.0, Provider: "fallback"}
+	manager, _ := newTracedManager(
+		&mockProviderForManager{name: "primary", available: true, err: errors.New("connection refused")},
+		&mockProviderForManager{name: "offline", available: false},
+		&mockProviderForManager{name: "fallback", available: true, result: shared},
+	)
+
+	result, err := manager.Analyze(AnalysisRequest{IdeaContent: "Test idea"})
+	if err != nil {
+		t.Fatalf("Expected successful analysis with fallback, got error: %v", err)
+	}
+
+	want := []string{"primary: connection refused", "offline: unavailable", "fallback: ok"}
+	if !reflect.DeepEqual(result.FallbackChain, want) {
+		t.Errorf("FallbackChain = %q, want %q", result.FallbackChain, want)
+	}
+	if shared.FallbackChain != nil {
+		t.Error("Expected the provider's own result to be left alone")
+	}
+
+	// Without a fallback the chain is just the primary
+	manager, _ = newTracedManager(&mockProviderForManager{name: "primary", available: true})
+	result, err = manager.Analyze(AnalysisRequest{IdeaContent: "Test idea"})
+	if err != nil {
+		t.Fatalf("Analyze() error = %v", err)
+	}
+	if !reflect.DeepEqual(result.FallbackChain, []string{"primary: ok"}) {
+		t.Errorf("FallbackChain = %q, want only the primary", result.FallbackChain)
+	}
+}
+
+func TestManager_AllProvidersFailedListsChain(t *testing.T) {
+	manager, _ := newTracedManager(
+		&mockProviderForManager{name: "primary", available: true, err: errors.New("connection refused")},
+		&mockProviderForManager{name: "fallback", available: true, err: errors.New("invalid API key")},
+	)
+
+	_, err := manager.Analyze(AnalysisRequest{IdeaContent: "Test idea"})
+	if err == nil {
+		t.Fatal("Expected an error when every provider fails")
+	}
+	for _, want := range []string{"primary: connection refused", "fallback: invalid API key"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Expected error to mention %q, got %v", want, err)
+		}
+	}
+}
+
+func TestManager_AnalyzeAll(t *testing.T) {
+	shared := &AnalysisResult{FinalScore: 6.0, Recommendation: "Maybe"}
+	failing := errors.New("invalid API key")
+	manager, _ := newTracedManager(
+		&mockProviderForManager{name: "primary", available: true},
+		&mockProviderForManager{name: "offline", available: false},
+		&mockProviderForManager{name: "broken", available: true, err: failing},
+		&mockProviderForManager{name: "cached", available: true, result: shared},
+	)
+
+	results, errs := manager.AnalyzeAllContext(context.Background(), AnalysisRequest{IdeaContent: "Test idea"})
+
+	if len(results) != 2 {
+		t.Fatalf("Expected results from 2 providers, got %d", len(results))
+	}
+	if got := results["primary"].FinalScore; got != 7.5 {
+		t.Errorf("primary score = %.1f, want 7.5", got)
+	}
+	cached := results["cached"]
+	if cached.FinalScore != 6.0 || cached.Provider != "cached" {
+		t.Errorf("cached result = %+v, want score 6.0 from cached", cached)
+	}
+	if cached.Duration <= 0 {
+		t.Error("Expected the latency to be recorded")
+	}
+	if shared.Provider != "" || shared.Duration != 0 {
+		t.Error("Expected the provider's own result to be left alone")
+	}
+
+	if !errors.Is(errs["offline"], ErrUnavailable) {
+		t.Errorf("offline error = %v, want ErrUnavailable", errs["offline"])
+	}
+	if !errors.Is(errs["broken"], failing) {
+		t.Errorf("broken error = %v, want %v", errs["broken"], failing)
+	}
+	if len(errs) != 2 {
+		t.Errorf("Expected errors for 2 providers, got %v", errs)
+	}
+
+	// AnalyzeAll keeps only the results
+	if got := manager.AnalyzeAll(AnalysisRequest{IdeaContent: "Test idea"}); len(got) != 2 {
+		t.Errorf("AnalyzeAll() returned %d results, want 2", len(got))
+	}
+}
+
 func TestManager_FallbackDisabled(t *testing.T) {
 	config := &ManagerConfig{
 		FallbackEnabled: false,
@@ -222,6 +332,73 @@ func TestManager_FallbackDisabled(t *testing.T) {
 	}
 }
 
+func TestManager_AnalyzeContext_CanceledSkipsFallback(t *testing.T) {
+	config := &ManagerConfig{
+		FallbackEnabled: true,
+		Priority:        []string{"primary", "fallback"},
+		ProviderConfig:  DefaultProviderConfig(),
+	}
+	manager := NewManager(config)
+
+	primaryProvider := &mockProviderForManager{name: "primary", available: true}
+	fallbackProvider := &mockProviderForManager{name: "fallback", available: true}
+	manager.RegisterProvider(primaryProvider)
+	manager.RegisterProvider(fallbackProvider)
+	if err := manager.SetPrimaryProvider("primary"); err != nil {
+		t.Fatalf("Failed to set primary provider: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := manager.AnalyzeContext(ctx, AnalysisRequest{
+		IdeaContent: "Test idea",
+		Telos:       createTestTelos(),
+	})
+
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+	if primaryProvider.GetCallCount() != 1 {
+		t.Errorf("Expected primary to be called once, got %d", primaryProvider.GetCallCount())
+	}
+	if fallbackProvider.GetCallCount() != 0 {
+		t.Errorf("Expected no fallback after cancellation, got %d calls", fallbackProvider.GetCallCount())
+	}
+}
+
+func TestManager_AnalyzeContext_LogsWithContextLogger(t *testing.T) {
+	config := &ManagerConfig{
+		FallbackEnabled: true,
+		Priority:        []string{"primary"},
+		ProviderConfig:  DefaultProviderConfig(),
+	}
+	manager := NewManager(config)
+	manager.RegisterProvider(&mockProviderForManager{name: "primary", available: true})
+	if err := manager.SetPrimaryProvider("primary"); err != nil {
+		t.Fatalf("Failed to set primary provider: %v", err)
+	}
+
+	var buf bytes.Buffer
+	logger := zerolog.New(&buf).With().Str("request_id", "req-42").Logger()
+	ctx := logger.WithContext(context.Background())
+
+	if _, err := manager.AnalyzeContext(ctx, AnalysisRequest{
+		IdeaContent: "Test idea",
+		Telos:       createTestTelos(),
+	}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	out := buf.String()
+	if !strings.Contains(out, `"message":"llm analysis completed"`) {
+		t.Fatalf("Expected an analysis log line, got %q", out)
+	}
+	if !strings.Contains(out, `"request_id":"req-42"`) || !strings.Contains(out, `"provider":"primary"`) {
+		t.Errorf("Expected request_id and provider in analysis log, got %q", out)
+	}
+}
+
 func TestManager_SetPrimaryProvider(t *testing.T) {
 	config := DefaultManagerConfig()
 	manager := NewManager(config)
@@ -316,6 +493,96 @@ func TestManager_HealthCheck(t *testing.T) {
 	}
 }
 
+// blockingProvider's health probe hangs until release is closed once blocking is set
+type blockingProvider struct {
+	mockProviderForManager
+	blocking atomic.Bool
+	release  chan struct{}
+}
+
+func (b *blockingProvider) IsAvailable() bool {
+	if b.blocking.Load() {
+		<-b.release
+	}
+	return true
+}
+
+func TestManager_HealthCheck_SlowProviderDoesNotDelayOthers(t *testing.T) {
+	config := DefaultManagerConfig()
+	config.HealthCheckTimeout = 500 * time.Millisecond
+	manager := NewManager(config)
+
+	slow := &blockingProvider{
+		mockProviderForManager: mockProviderForManager{name: "slow"},
+		release:                make(chan struct{}),
+	}
+	defer close(slow.release)
+	manager.RegisterProvider(slow)
+	fast := &mockProviderForManager{name: "fast", available: false}
+	manager.RegisterProvider(fast)
+
+	// Registration probes once; only the periodic checks should see the slow probe hang
+	slow.blocking.Store(true)
+	fast.available = true
+
+	start := time.Now()
+	done := make(chan map[string]bool, 1)
+	go func() {
+		done <- manager.HealthCheck()
+	}()
+
+	// The fast provider's status should land while the slow probe is still hanging
+	deadline := time.Now().Add(200 * time.Millisecond)
+	for {
+		if available, lastCheck, err := manager.GetHealthStatus("fast"); err == nil && lastCheck.After(start) {
+			if !available {
+				t.Error("Expected 'fast' provider to be available")
+			}
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("Expected 'fast' provider status to update before the slow probe finished")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	select {
+	case status := <-done:
+		if status["slow"] {
+			t.Error("Expected timed-out provider to be reported unavailable")
+		}
+		if !status["fast"] {
+			t.Error("Expected 'fast' provider to be available")
+		}
+		if elapsed := time.Since(start); elapsed > 2*time.Second {
+			t.Errorf("HealthCheck took %s, expected it to stop waiting after the timeout", elapsed)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("HealthCheck did not return after the probe timeout")
+	}
+
+	if available, _, err := manager.GetHealthStatus("slow"); err != nil || available {
+		t.Errorf("Expected 'slow' provider cached as unavailable, got available=%v err=%v", available, err)
+	}
+}
+
+func TestManager_HealthCheck_ConcurrencyLimit(t *testing.T) {
+	config := DefaultManagerConfig()
+	config.HealthCheckConcurrency = 1
+	manager := NewManager(config)
+
+	for _, name := range []string{"a", "b", "c"} {
+		manager.RegisterProvider(&mockProviderForManager{name: name, available: true})
+	}
+
+	status := manager.HealthCheck()
+	for _, name := range []string{"a", "b", "c"} {
+		if !status[name] {
+			t.Errorf("Expected provider %q to be available", name)
+		}
+	}
+}
+
 func TestManager_GetHealthStatus(t *testing.T) {
 	config := DefaultManagerConfig()
 	manager := NewManager(config)
@@ -511,6 +778,75 @@ func TestManager_GetStats(t *testing.T) {
 	}
 }
 
+// Run with -race: stats are written by every analysis and read by GetStats
+func TestManager_StatsConcurrentAnalyze(t *testing.T) {
+	ok := &mockProviderForManager{name: "ok", available: true}
+	failing := &mockProviderForManager{name: "failing", available: true, err: errors.New("test error")}
+	manager, _ := newTracedManager(ok, failing)
+	manager.EnableFallback(false)
+
+	const calls = 200
+	telos := createTestTelos()
+	stop := make(chan struct{})
+	var readers, analyses sync.WaitGroup
+
+	// Read stats the whole time the analyses run
+	readers.Add(1)
+	go func() {
+		defer readers.Done()
+		for {
+			select {
+			case <-stop:
+				return
+			default:
+				for _, stats := range manager.GetStats() {
+					if stats.TotalRequests != stats.SuccessCount+stats.FailureCount {
+						t.Errorf("inconsistent stats for %s: %d total, %d succeeded, %d failed",
+							stats.Name, stats.TotalRequests, stats.SuccessCount, stats.FailureCount)
+						return
+					}
+				}
+			}
+		}
+	}()
+
+	for i := 0; i < calls; i++ {
+		analyses.Add(1)
+		go func(i int) {
+			defer analyses.Done()
+			if i%2 == 0 {
+				_, _ = manager.Analyze(AnalysisRequest{IdeaContent: "Test idea", Telos: telos})
+			} else {
+				manager.RecordRequest("failing", time.Millisecond, errors.New("test error"))
+			}
+			if i%50 == 0 {
+				_, _ = manager.GetProviderStats("ok")
+			}
+		}(i)
+	}
+	analyses.Wait()
+	close(stop)
+	readers.Wait()
+
+	okStats, err := manager.GetProviderStats("ok")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if okStats.TotalRequests != calls/2 || okStats.SuccessCount != calls/2 {
+		t.Errorf("expected %d successful requests to ok, got %d of %d", calls/2, okStats.SuccessCount, okStats.TotalRequests)
+	}
+	failingStats, err := manager.GetProviderStats("failing")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if failingStats.TotalRequests != calls/2 || failingStats.FailureCount != calls/2 {
+		t.Errorf("expected %d failed requests to failing, got %d of %d", calls/2, failingStats.FailureCount, failingStats.TotalRequests)
+	}
+	if got := okStats.TotalRequests + failingStats.TotalRequests; got != calls {
+		t.Errorf("expected %d requests in total, got %d", calls, got)
+	}
+}
+
 func TestManager_ResetStats(t *testing.T) {
 	config := DefaultManagerConfig()
 	manager := NewManager(config)
@@ -749,6 +1085,50 @@ func TestManager_ConcurrentAccess(t *testing.T) {
 	}
 }
 
+// TestManager_HealthCheck_ConcurrentAccess is meant to run under -race: health
+// checks, status reads and config reloads all touch the provider list and cache
+func TestManager_HealthCheck_ConcurrentAccess(t *testing.T) {
+	config := DefaultManagerConfig()
+	config.HealthCheckTimeout = time.Second
+	manager := NewManager(config)
+
+	names := []string{"a", "b", "c"}
+	for _, name := range names {
+		manager.RegisterProvider(&mockProviderForManager{name: name, available: true})
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(3)
+		go func() {
+			defer wg.Done()
+			status := manager.HealthCheck()
+			for _, name := range names {
+				if !status[name] {
+					t.Errorf("Expected provider %q to be available", name)
+				}
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for _, name := range names {
+				if _, _, err := manager.GetHealthStatus(name); err != nil {
+					t.Errorf("GetHealthStatus(%q): %v", name, err)
+				}
+			}
+		}()
+		go func(i int) {
+			defer wg.Done()
+			reloaded := DefaultManagerConfig()
+			reloaded.Priority = []string{names[i%len(names)]}
+			if err := manager.LoadConfig(reloaded); err != nil {
+				t.Errorf("LoadConfig: %v", err)
+			}
+		}(i)
+	}
+	wg.Wait()
+}
+
 func TestCreateManagerWithTelos(t *testing.T) {
 	telos := createTestTelos()
 
@@ -0,0 +1,273 @@
+package llm
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/ryacub/telos-idea-matrix/internal/metrics"
+	"golang.org/x/time/rate"
+)
+
+// openAICompatibleProvider analyzes ideas through an OpenAI-style chat
+// completions API. OpenAIProvider and GroqProvider embed it and differ only
+// in endpoint, credentials and model.
+type openAICompatibleProvider struct {
+	service      string // Shown in errors, e.g. "OpenAI"
+	apiKeyEnv    string // Environment variable named when the key is missing
+	apiKey       string
+	model        string
+	systemPrompt string // Sent as the system message; empty uses DefaultSystemPrompt
+	baseURL      string
+	httpClient   *http.Client
+	maxRetries   int
+	rateLimiter  *rate.Limiter
+}
+
+// newOpenAICompatibleProvider returns a provider for the chat completions
+// endpoint at baseURL, with pooled connections and a 3 req/sec client-side limit
+func newOpenAICompatibleProvider(service, apiKeyEnv, apiKey, model, baseURL string) openAICompatibleProvider {
+	return openAICompatibleProvider{
+		service:   service,
+		apiKeyEnv: apiKeyEnv,
+		apiKey:    apiKey,
+		model:     model,
+		baseURL:   baseURL,
+		httpClient: &http.Client{
+			Timeout: 30 * time.Second,
+			Transport: tracingTransport{base: &http.Transport{
+				MaxIdleConns:        100,              // Max idle connections across all hosts
+				MaxIdleConnsPerHost: 10,               // Max idle connections per host
+				MaxConnsPerHost:     10,               // Max total connections per host
+				IdleConnTimeout:     90 * time.Second, // Keep idle connections for 90s
+				DisableKeepAlives:   false,            // Enable connection reuse
+			}},
+		},
+		maxRetries:  3,
+		rateLimiter: rate.NewLimiter(rate.Limit(3), 5), // 3 req/sec, burst of 5
+	}
+}
+
+// SetSystemPrompt sets the system message sent with every request; empty
+// restores DefaultSystemPrompt.
+func (p *openAICompatibleProvider) SetSystemPrompt(prompt string) {
+	p.systemPrompt = prompt
+}
+
+// IsAvailable checks if the provider is available (has API key)
+func (p *openAICompatibleProvider) IsAvailable() bool {
+	return p.apiKey != ""
+}
+
+// GetModel returns the current model
+func (p *openAICompatibleProvider) GetModel() string {
+	return p.model
+}
+
+// GetAPIKey returns the API key (for config display)
+func (p *openAICompatibleProvider) GetAPIKey() string {
+	return p.apiKey
+}
+
+// analyze performs idea analysis, recording metrics under name and
+// abandoning the request and any pending retries if ctx is cancelled
+func (p *openAICompatibleProvider) analyze(ctx context.Context, name string, req AnalysisRequest) (*AnalysisResult, error) {
+	start := time.Now()
+
+	if !p.IsAvailable() {
+		duration := time.Since(start)
+		metrics.RecordLLMRequest(name, false, duration)
+		metrics.RecordLLMError(name, "auth_error")
+		return nil, fmt.Errorf("%s provider not available (check %s)", p.service, p.apiKeyEnv)
+	}
+
+	// Build the analysis prompt
+	prompt, err := analysisPrompt(req)
+	if err != nil {
+		duration := time.Since(start)
+		metrics.RecordLLMRequest(name, false, duration)
+		metrics.RecordLLMError(name, "invalid_request")
+		return nil, fmt.Errorf("build prompt: %w", err)
+	}
+
+	// Create chat completions request
+	openAIReq := &openAIRequest{
+		Model: p.model,
+		Messages: []openAIMessage{
+			{
+				Role:    "system",
+				Content: systemPromptOr(p.systemPrompt),
+			},
+			{
+				Role:    "user",
+				Content: prompt,
+			},
+		},
+		MaxTokens:      1000,
+		Temperature:    0.7,
+		ResponseFormat: &openAIResponseFormat{Type: "json_object"},
+	}
+
+	// Send request with retries
+	var resp *openAIResponse
+	var lastErr error
+
+	for attempt := 0; attempt < p.maxRetries; attempt++ {
+		resp, lastErr = p.sendRequest(ctx, openAIReq)
+		if lastErr == nil || ctx.Err() != nil {
+			break
+		}
+
+		// Exponential backoff
+		if attempt < p.maxRetries-1 {
+			if err := sleepContext(ctx, time.Duration(1<<uint(attempt))*time.Second); err != nil {
+				lastErr = err
+				break
+			}
+		}
+	}
+
+	duration := time.Since(start)
+
+	if lastErr != nil {
+		metrics.RecordLLMRequest(name, false, duration)
+		metrics.RecordLLMError(name, classifyError(lastErr))
+		return nil, fmt.Errorf("%s request failed after %d retries: %w", p.service, p.maxRetries, lastErr)
+	}
+
+	// Parse response
+	if len(resp.Choices) == 0 {
+		metrics.RecordLLMRequest(name, false, duration)
+		metrics.RecordLLMError(name, "invalid_response")
+		return nil, fmt.Errorf("no response from %s", p.service)
+	}
+
+	// Extract structured result from the model's response
+	_, parseSpan := startSpan(ctx, "llm.parse", attrProvider.String(name))
+	parsed, err := ParseAnalysisJSON(resp.Choices[0].Message.Content)
+	endSpan(parseSpan, err)
+	if err != nil {
+		metrics.RecordLLMRequest(name, false, duration)
+		metrics.RecordLLMError(name, "invalid_response")
+		return nil, fmt.Errorf("failed to parse %s response: %w", p.service, err)
+	}
+
+	// Record successful request
+	metrics.RecordLLMRequest(name, true, duration)
+
+	// Record token usage
+	metrics.RecordLLMTokens(name, resp.Usage.PromptTokens, resp.Usage.CompletionTokens)
+
+	// Convert to AnalysisResult
+	result := &parsed
+	result.Provider = name
+	result.Duration = time.Since(start)
+
+	return result, nil
+}
+
+// sendRequest sends an HTTP request to the chat completions API with rate limiting
+func (p *openAICompatibleProvider) sendRequest(ctx context.Context, req *openAIRequest) (*openAIResponse, error) {
+	// Wait for rate limiter
+	if err := p.rateLimiter.Wait(ctx); err != nil {
+		return nil, fmt.Errorf("rate limiter error: %w", err)
+	}
+
+	// Marshal request
+	body, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	// Create HTTP request
+	httpReq, err := http.NewRequestWithContext(ctx, "POST", p.baseURL, bytes.NewBuffer(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("Authorization", fmt.Sprintf("Bearer %s", p.apiKey))
+
+	// Send request
+	httpResp, err := p.httpClient.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("HTTP request failed: %w", err)
+	}
+	defer func() { _ = httpResp.Body.Close() }()
+
+	// Read response
+	respBody, err := io.ReadAll(httpResp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	// Check status code
+	if httpResp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s API error (status %d): %s", p.service, httpResp.StatusCode, string(respBody))
+	}
+
+	// Unmarshal response
+	var resp openAIResponse
+	if err := json.Unmarshal(respBody, &resp); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	// Check for API error
+	if resp.Error != nil {
+		return nil, fmt.Errorf("%s API error: %s (type: %s)", p.service, resp.Error.Message, resp.Error.Type)
+	}
+
+	return &resp, nil
+}
+
+// ============================================================================
+// REQUEST/RESPONSE STRUCTURES
+// ============================================================================
+
+// openAIRequest represents a chat completions request
+type openAIRequest struct {
+	Model          string                `json:"model"`
+	Messages       []openAIMessage       `json:"messages"`
+	MaxTokens      int                   `json:"max_tokens,omitempty"`
+	Temperature    float64               `json:"temperature,omitempty"`
+	ResponseFormat *openAIResponseFormat `json:"response_format,omitempty"`
+}
+
+// openAIResponseFormat selects the output mode; "json_object" guarantees the
+// reply is a single valid JSON object
+type openAIResponseFormat struct {
+	Type string `json:"type"`
+}
+
+// openAIMessage represents a message in the conversation
+type openAIMessage struct {
+	Role    string `json:"role"` // "system", "user", or "assistant"
+	Content string `json:"content"`
+}
+
+// openAIResponse represents a chat completions response
+type openAIResponse struct {
+	ID      string `json:"id"`
+	Object  string `json:"object"`
+	Created int64  `json:"created"`
+	Model   string `json:"model"`
+	Choices []struct {
+		Index        int           `json:"index"`
+		Message      openAIMessage `json:"message"`
+		FinishReason string        `json:"finish_reason"`
+	} `json:"choices"`
+	Usage struct {
+		PromptTokens     int `json:"prompt_tokens"`
+		CompletionTokens int `json:"completion_tokens"`
+		TotalTokens      int `json:"total_tokens"`
+	} `json:"usage"`
+	Error *struct {
+		Message string `json:"message"`
+		Type    string `json:"type"`
+		Code    string `json:"code"`
+	} `json:"error,omitempty"`
+}
@@ -1,6 +1,7 @@
 package llm
 
 import (
+	"context"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -19,7 +20,7 @@ func TestOpenAIProvider_Name(t *testing.T) {
 }
 
 func TestOpenAIProvider_IsAvailable_NoAPIKey(t *testing.T) {
-	provider := &OpenAIProvider{apiKey: ""}
+	provider := &OpenAIProvider{openAICompatibleProvider{apiKey: ""}}
 	assert.False(t, provider.IsAvailable(), "Provider should not be available without API key")
 }
 
@@ -45,14 +46,14 @@ func TestOpenAIProvider_Analyze_MockServer(t *testing.T) {
 	}))
 	defer server.Close()
 
-	provider := &OpenAIProvider{
+	provider := &OpenAIProvider{openAICompatibleProvider{
 		apiKey:      "test-key",
 		model:       "gpt-4",
 		baseURL:     server.URL,
 		httpClient:  &http.Client{},
 		maxRetries:  1,
 		rateLimiter: rate.NewLimiter(rate.Inf, 1), // No rate limiting for tests
-	}
+	}}
 
 	// Create a minimal telos for testing
 	telos := &models.Telos{
@@ -103,14 +104,14 @@ func TestOpenAIProvider_RetryLogic(t *testing.T) {
 	}))
 	defer server.Close()
 
-	provider := &OpenAIProvider{
+	provider := &OpenAIProvider{openAICompatibleProvider{
 		apiKey:      "test-key",
 		model:       "gpt-4",
 		baseURL:     server.URL,
 		httpClient:  &http.Client{},
 		maxRetries:  3,
 		rateLimiter: rate.NewLimiter(rate.Inf, 1), // No rate limiting for tests
-	}
+	}}
 
 	telos := &models.Telos{
 		Goals: []models.Goal{
@@ -142,14 +143,14 @@ func TestOpenAIProvider_APIError(t *testing.T) {
 	}))
 	defer server.Close()
 
-	provider := &OpenAIProvider{
+	provider := &OpenAIProvider{openAICompatibleProvider{
 		apiKey:      "invalid-key",
 		model:       "gpt-4",
 		baseURL:     server.URL,
 		httpClient:  &http.Client{},
 		maxRetries:  1,
 		rateLimiter: rate.NewLimiter(rate.Inf, 1),
-	}
+	}}
 
 	telos := &models.Telos{
 		Goals: []models.Goal{
@@ -219,18 +220,18 @@ func TestOpenAIProvider_RateLimit(t *testing.T) {
 	defer server.Close()
 
 	// Create provider with strict rate limit (1 req/sec)
-	provider := &OpenAIProvider{
+	provider := &OpenAIProvider{openAICompatibleProvider{
 		apiKey:      "test-key",
 		model:       "gpt-4",
 		baseURL:     server.URL,
 		httpClient:  &http.Client{},
 		maxRetries:  1,
 		rateLimiter: rate.NewLimiter(rate.Limit(1), 1), // 1 req/sec
-	}
+	}}
 
 	// Make 3 requests
 	for i := 0; i < 3; i++ {
-		_, _ = provider.sendRequest(&openAIRequest{
+		_, _ = provider.sendRequest(context.Background(), &openAIRequest{
 			Model:    "gpt-4",
 			Messages: []openAIMessage{{Role: "user", Content: "test"}},
 		})
@@ -268,14 +269,14 @@ func TestOpenAIProvider_MalformedResponse(t *testing.T) {
 	}))
 	defer server.Close()
 
-	provider := &OpenAIProvider{
+	provider := &OpenAIProvider{openAICompatibleProvider{
 		apiKey:      "test-key",
 		model:       "gpt-4",
 		baseURL:     server.URL,
 		httpClient:  &http.Client{},
 		maxRetries:  1,
 		rateLimiter: rate.NewLimiter(rate.Inf, 1),
-	}
+	}}
 
 	telos := &models.Telos{
 		Goals: []models.Goal{
@@ -306,14 +307,14 @@ func TestOpenAIProvider_EmptyResponse(t *testing.T) {
 	}))
 	defer server.Close()
 
-	provider := &OpenAIProvider{
+	provider := &OpenAIProvider{openAICompatibleProvider{
 		apiKey:      "test-key",
 		model:       "gpt-4",
 		baseURL:     server.URL,
 		httpClient:  &http.Client{},
 		maxRetries:  1,
 		rateLimiter: rate.NewLimiter(rate.Inf, 1),
-	}
+	}}
 
 	telos := &models.Telos{
 		Goals: []models.Goal{
@@ -3,8 +3,11 @@ package processing
 
 import (
 	"encoding/json"
+	"fmt"
 	"regexp"
 	"strconv"
+
+	"github.com/ryacub/telos-idea-matrix/internal/models"
 )
 
 // ScoreBreakdown contains the three main scoring categories
@@ -27,9 +30,13 @@ type ProcessedResult struct {
 // FallbackFunc is called when processing fails
 type FallbackFunc func(ideaContent string, telos interface{}) (*ProcessedResult, error)
 
+// ParseFunc parses a raw LLM response, returning an error if it can't be used
+type ParseFunc func(rawResponse string) (*ProcessedResult, error)
+
 // SimpleProcessor handles LLM response processing
 type SimpleProcessor struct {
 	fallback FallbackFunc
+	parse    ParseFunc
 }
 
 // NewSimpleProcessor creates a new processor
@@ -39,8 +46,19 @@ func NewSimpleProcessor(fallbackFn FallbackFunc) *SimpleProcessor {
 	}
 }
 
+// WithParser replaces the built-in JSON and regex parsing with parse.
+// The fallback is still used when parse fails or the result is out of range.
+func (sp *SimpleProcessor) WithParser(parse ParseFunc) *SimpleProcessor {
+	sp.parse = parse
+	return sp
+}
+
 // Process parses an LLM response and returns the result
 func (sp *SimpleProcessor) Process(rawResponse string, ideaContent string, telos interface{}) (*ProcessedResult, error) {
+	if sp.parse != nil {
+		return sp.processWithParser(rawResponse, ideaContent, telos)
+	}
+
 	// Try to parse JSON
 	var jsonResp struct {
 		Scores struct {
@@ -98,6 +116,29 @@ func (sp *SimpleProcessor) Process(rawResponse string, ideaContent string, telos
 	return result, nil
 }
 
+// processWithParser parses with the configured ParseFunc, falling back on failure
+func (sp *SimpleProcessor) processWithParser(rawResponse string, ideaContent string, telos interface{}) (*ProcessedResult, error) {
+	result, err := sp.parse(rawResponse)
+	if err == nil && sp.validate(result) {
+		if result.Explanations == nil {
+			result.Explanations = make(map[string]string)
+		}
+		return result, nil
+	}
+	if err == nil {
+		err = fmt.Errorf("scores out of range")
+	}
+
+	if sp.fallback != nil {
+		result, fallbackErr := sp.fallback(ideaContent, telos)
+		if fallbackErr == nil {
+			result.UsedFallback = true
+		}
+		return result, fallbackErr
+	}
+	return nil, err
+}
+
 // extractWithRegex tries to extract scores using regex
 func (sp *SimpleProcessor) extractWithRegex(response string) *ProcessedResult {
 	missionRe := regexp.MustCompile(`"mission_alignment":\s*(\d+\.?\d*)`)
@@ -150,14 +191,8 @@ func (sp *SimpleProcessor) validate(result *ProcessedResult) bool {
 	return true
 }
 
-// DetermineRecommendation maps score to recommendation
+// DetermineRecommendation maps score to recommendation under the current
+// thresholds, without the emoji (e.g. "GOOD ALIGNMENT")
 func DetermineRecommendation(score float64) string {
-	if score >= 8.5 {
-		return "PRIORITIZE NOW"
-	} else if score >= 7.0 {
-		return "GOOD ALIGNMENT"
-	} else if score >= 5.0 {
-		return "CONSIDER LATER"
-	}
-	return "AVOID FOR NOW"
+	return models.CurrentRecommendationThresholds().Recommend(score).Label()
 }
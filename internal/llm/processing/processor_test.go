@@ -202,6 +202,64 @@ func TestSimpleProcessor_Process_NoFallback(t *testing.T) {
 	}
 }
 
+func TestSimpleProcessor_WithParser(t *testing.T) {
+	fallback := &ProcessedResult{FinalScore: 4.5, Recommendation: "CONSIDER LATER"}
+	fallbackFunc := func(string, interface{}) (*ProcessedResult, error) {
+		result := *fallback
+		return &result, nil
+	}
+
+	parsed := &ProcessedResult{
+		Scores:         ScoreBreakdown{MissionAlignment: 3.0, AntiChallenge: 2.5, StrategicFit: 1.8},
+		FinalScore:     7.3,
+		Recommendation: "GOOD ALIGNMENT",
+	}
+	tests := []struct {
+		name         string
+		parse        ParseFunc
+		wantScore    float64
+		wantFallback bool
+	}{
+		{
+			name:      "parsed result is used",
+			parse:     func(string) (*ProcessedResult, error) { return parsed, nil },
+			wantScore: 7.3,
+		},
+		{
+			name:         "parse error falls back",
+			parse:        func(string) (*ProcessedResult, error) { return nil, errors.New("malformed") },
+			wantScore:    4.5,
+			wantFallback: true,
+		},
+		{
+			name:         "out of range result falls back",
+			parse:        func(string) (*ProcessedResult, error) { return &ProcessedResult{FinalScore: 12}, nil },
+			wantScore:    4.5,
+			wantFallback: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			processor := NewSimpleProcessor(fallbackFunc).WithParser(tt.parse)
+
+			result, err := processor.Process("raw", "test idea", nil)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if result.FinalScore != tt.wantScore {
+				t.Errorf("expected final score %v, got %v", tt.wantScore, result.FinalScore)
+			}
+			if result.UsedFallback != tt.wantFallback {
+				t.Errorf("expected UsedFallback %v, got %v", tt.wantFallback, result.UsedFallback)
+			}
+			if result.Explanations == nil && !tt.wantFallback {
+				t.Error("expected explanations to be initialized")
+			}
+		})
+	}
+}
+
 func TestSimpleProcessor_Validate(t *testing.T) {
 	processor := NewSimpleProcessor(nil)
 
@@ -0,0 +1,151 @@
+package llm
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/ryacub/telos-idea-matrix/internal/models"
+)
+
+// PromptTemplateExt is the extension of prompt template files. A file named
+// <provider>.tmpl, e.g. openai.tmpl, replaces PromptTemplate for that provider.
+const PromptTemplateExt = ".tmpl"
+
+// sampleTelos is the telos prompt templates are test-rendered with when loaded
+var sampleTelos = &models.Telos{
+	Goals:      []models.Goal{{ID: "G1", Description: "Ship a profitable side project", Priority: 1}},
+	Strategies: []models.Strategy{{ID: "S1", Description: "Build in public"}},
+	Stack:      models.Stack{Primary: []string{"Go"}},
+}
+
+// LoadPromptTemplates parses every <provider>.tmpl file in dir, keyed by
+// provider name. Each template is rendered once with a sample idea so that
+// references to missing fields fail here rather than on the first analysis.
+// Invalid files are reported together in the error and left out of the
+// result. A missing dir yields no templates.
+func LoadPromptTemplates(dir string) (map[string]*template.Template, error) {
+	templates := make(map[string]*template.Template)
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return templates, nil
+	}
+	if err != nil {
+		return templates, fmt.Errorf("read prompt templates: %w", err)
+	}
+
+	var errs []error
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || filepath.Ext(name) != PromptTemplateExt {
+			continue
+		}
+		provider := strings.TrimSuffix(name, PromptTemplateExt)
+		tmpl, err := parsePromptTemplate(filepath.Join(dir, name))
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		templates[provider] = tmpl
+	}
+	return templates, errors.Join(errs...)
+}
+
+// parsePromptTemplate parses and test-renders one prompt template file
+func parsePromptTemplate(path string) (*template.Template, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read prompt template %s: %w", path, err)
+	}
+	tmpl, err := template.New(filepath.Base(path)).Parse(string(content))
+	if err != nil {
+		return nil, fmt.Errorf("parse prompt template %s: %w", path, err)
+	}
+	prompt, err := executePromptTemplate(tmpl, "Build a habit tracker for developers", sampleTelos)
+	if err != nil {
+		return nil, fmt.Errorf("prompt template %s: %w", path, err)
+	}
+	if strings.TrimSpace(prompt) == "" {
+		return nil, fmt.Errorf("prompt template %s renders an empty prompt", path)
+	}
+	return tmpl, nil
+}
+
+// executePromptTemplate renders an analysis prompt for ideaContent and telos
+func executePromptTemplate(tmpl *template.Template, ideaContent string, telos *models.Telos) (string, error) {
+	if ideaContent == "" {
+		return "", fmt.Errorf("idea content is required")
+	}
+	if telos == nil {
+		return "", fmt.Errorf("telos is required")
+	}
+
+	data := PromptData{
+		TelosContent: formatTelos(telos),
+		IdeaContent:  ideaContent,
+		Telos:        telos,
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("execute template: %w", err)
+	}
+	return buf.String(), nil
+}
+
+// LoadPromptTemplates replaces the manager's prompt templates with those in
+// dir (see the package-level LoadPromptTemplates). Valid templates are used
+// even when others fail to load; the error lists the failures.
+func (m *Manager) LoadPromptTemplates(dir string) error {
+	templates, err := LoadPromptTemplates(dir)
+	m.mu.Lock()
+	m.promptTemplates = templates
+	m.mu.Unlock()
+	return err
+}
+
+// PromptTemplateProviders returns the providers with a prompt template loaded
+func (m *Manager) PromptTemplateProviders() []string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	names := make([]string, 0, len(m.promptTemplates))
+	for name := range m.promptTemplates {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// baseProviderName strips what a provider adds to its configured name: the
+// "_cached" suffix of a CachedProvider and the model of an OpenAI provider,
+// e.g. "openai_gpt-4o_cached" is "openai"
+func baseProviderName(providerName string) string {
+	name := strings.TrimSuffix(providerName, "_cached")
+	if strings.HasPrefix(name, "openai_") {
+		return "openai"
+	}
+	return name
+}
+
+// withPromptTemplate renders the provider's prompt template, if one is
+// loaded, into req.Prompt. Templates are keyed by base provider name.
+func (m *Manager) withPromptTemplate(providerName string, req AnalysisRequest) (AnalysisRequest, error) {
+	m.mu.RLock()
+	tmpl := m.promptTemplates[baseProviderName(providerName)]
+	m.mu.RUnlock()
+	if tmpl == nil || req.Prompt != "" {
+		return req, nil
+	}
+
+	prompt, err := executePromptTemplate(tmpl, req.IdeaContent, req.Telos)
+	if err != nil {
+		return req, fmt.Errorf("%s prompt template %s: %w", providerName, tmpl.Name(), err)
+	}
+	req.Prompt = prompt
+	return req, nil
+}
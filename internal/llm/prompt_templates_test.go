@@ -0,0 +1,198 @@
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/ryacub/telos-idea-matrix/internal/models"
+	"golang.org/x/time/rate"
+)
+
+// promptRecorder records the prompt of each analysis it is asked to run
+type promptRecorder struct {
+	name    string
+	mu      sync.Mutex
+	prompts []string
+}
+
+func (p *promptRecorder) Name() string      { return p.name }
+func (p *promptRecorder) IsAvailable() bool { return true }
+
+func (p *promptRecorder) Analyze(req AnalysisRequest) (*AnalysisResult, error) {
+	return p.AnalyzeContext(context.Background(), req)
+}
+
+func (p *promptRecorder) AnalyzeContext(_ context.Context, req AnalysisRequest) (*AnalysisResult, error) {
+	prompt, err := analysisPrompt(req)
+	if err != nil {
+		return nil, err
+	}
+	p.mu.Lock()
+	p.prompts = append(p.prompts, prompt)
+	p.mu.Unlock()
+	return &AnalysisResult{FinalScore: 5, Provider: p.name}, nil
+}
+
+func writePromptTemplate(t *testing.T, dir, name, content string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0600); err != nil {
+		t.Fatalf("Failed to write template: %v", err)
+	}
+}
+
+func TestLoadPromptTemplates(t *testing.T) {
+	dir := t.TempDir()
+	writePromptTemplate(t, dir, "openai.tmpl", "Rate {{.IdeaContent}} against:\n{{.TelosContent}}")
+	writePromptTemplate(t, dir, "ollama.tmpl", "First goal: {{(index .Telos.Goals 0).Description}}. Idea: {{.IdeaContent}}")
+	writePromptTemplate(t, dir, "claude.tmpl", "Idea: {{.IdeaContent")          // Parse error
+	writePromptTemplate(t, dir, "groq.tmpl", "Idea: {{.Idea}}")                 // No such field
+	writePromptTemplate(t, dir, "custom.tmpl", "{{if false}}never{{end}}   \n") // Renders nothing
+	writePromptTemplate(t, dir, "notes.txt", "not a template")
+
+	templates, err := LoadPromptTemplates(dir)
+	if err == nil {
+		t.Fatal("Expected an error for the invalid templates")
+	}
+	for _, bad := range []string{"claude.tmpl", "groq.tmpl", "custom.tmpl"} {
+		if !strings.Contains(err.Error(), bad) {
+			t.Errorf("Expected error to name %s, got %v", bad, err)
+		}
+	}
+
+	if len(templates) != 2 || templates["openai"] == nil || templates["ollama"] == nil {
+		t.Errorf("Expected the openai and ollama templates to load, got %v", templates)
+	}
+}
+
+func TestLoadPromptTemplates_MissingDir(t *testing.T) {
+	templates, err := LoadPromptTemplates(filepath.Join(t.TempDir(), "prompts"))
+	if err != nil {
+		t.Fatalf("Expected no error for a missing directory, got %v", err)
+	}
+	if len(templates) != 0 {
+		t.Errorf("Expected no templates, got %d", len(templates))
+	}
+}
+
+func TestManager_PromptTemplates(t *testing.T) {
+	dir := t.TempDir()
+	writePromptTemplate(t, dir, "templated.tmpl", "Custom prompt for: {{.IdeaContent}}")
+
+	manager := NewManager(&ManagerConfig{Priority: []string{"templated", "plain"}, ProviderConfig: DefaultProviderConfig()})
+	templated := &promptRecorder{name: "templated"}
+	plain := &promptRecorder{name: "plain"}
+	manager.RegisterProvider(templated)
+	manager.RegisterProvider(plain)
+
+	if err := manager.LoadPromptTemplates(dir); err != nil {
+		t.Fatalf("Expected templates to load, got %v", err)
+	}
+	if got := manager.PromptTemplateProviders(); len(got) != 1 || got[0] != "templated" {
+		t.Errorf("Expected templated provider, got %v", got)
+	}
+
+	req := AnalysisRequest{IdeaContent: "A podcast about Go", Telos: createTestTelos()}
+	if _, err := manager.AnalyzeWithNamedProvider(req.IdeaContent, "templated", req.Telos); err != nil {
+		t.Fatalf("Expected templated analysis to succeed, got %v", err)
+	}
+	if _, err := manager.AnalyzeWithNamedProvider(req.IdeaContent, "plain", req.Telos); err != nil {
+		t.Fatalf("Expected plain analysis to succeed, got %v", err)
+	}
+
+	if len(templated.prompts) != 1 || templated.prompts[0] != "Custom prompt for: A podcast about Go" {
+		t.Errorf("Expected the templated prompt, got %q", templated.prompts)
+	}
+	builtIn, _ := BuildAnalysisPrompt(req.IdeaContent, req.Telos)
+	if len(plain.prompts) != 1 || plain.prompts[0] != builtIn {
+		t.Errorf("Expected the built-in prompt for a provider without a template")
+	}
+}
+
+func TestManager_PromptTemplates_OpenAI(t *testing.T) {
+	var mu sync.Mutex
+	var prompts []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req openAIRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("Failed to decode request: %v", err)
+		}
+		mu.Lock()
+		prompts = append(prompts, req.Messages[len(req.Messages)-1].Content)
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"choices": [{"message": {"role": "assistant", "content": "{\"scores\": {\"mission_alignment\": 2, \"anti_challenge\": 2, \"strategic_fit\": 2}, \"final_score\": 6, \"recommendation\": \"CONSIDER\"}"}}]}`))
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	writePromptTemplate(t, dir, "openai.tmpl", "OpenAI prompt for: {{.IdeaContent}}")
+
+	newOpenAI := func() *OpenAIProvider {
+		return &OpenAIProvider{openAICompatibleProvider{
+			service:     "OpenAI",
+			apiKey:      "test-key",
+			model:       "gpt-4o",
+			baseURL:     server.URL,
+			httpClient:  &http.Client{},
+			maxRetries:  1,
+			rateLimiter: rate.NewLimiter(rate.Inf, 1),
+		}}
+	}
+
+	tests := []struct {
+		name     string
+		provider Provider
+	}{
+		{"openai_gpt-4o", newOpenAI()},
+		{"openai_gpt-4o_cached", NewCachedProvider(newOpenAI())},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prompts = nil
+			manager := NewManager(&ManagerConfig{Priority: []string{"openai"}, ProviderConfig: DefaultProviderConfig()})
+			manager.RegisterProvider(tt.provider)
+			if err := manager.LoadPromptTemplates(dir); err != nil {
+				t.Fatalf("Expected templates to load, got %v", err)
+			}
+
+			if tt.provider.Name() != tt.name {
+				t.Fatalf("Expected provider name %q, got %q", tt.name, tt.provider.Name())
+			}
+			if _, err := manager.AnalyzeWithNamedProvider("A podcast about Go", tt.name, createTestTelos()); err != nil {
+				t.Fatalf("Expected analysis to succeed, got %v", err)
+			}
+			if len(prompts) != 1 || prompts[0] != "OpenAI prompt for: A podcast about Go" {
+				t.Errorf("Expected openai.tmpl to be used, got %q", prompts)
+			}
+		})
+	}
+}
+
+func TestManager_PromptTemplateExecutionError(t *testing.T) {
+	dir := t.TempDir()
+	// Valid for the sample telos, but fails for a telos without goals
+	writePromptTemplate(t, dir, "templated.tmpl", "Goal: {{(index .Telos.Goals 0).Description}}")
+
+	manager := NewManager(&ManagerConfig{Priority: []string{"templated"}, ProviderConfig: DefaultProviderConfig()})
+	templated := &promptRecorder{name: "templated"}
+	manager.RegisterProvider(templated)
+	if err := manager.LoadPromptTemplates(dir); err != nil {
+		t.Fatalf("Expected templates to load, got %v", err)
+	}
+
+	_, err := manager.AnalyzeWithNamedProvider("An idea", "templated", &models.Telos{})
+	if err == nil || !strings.Contains(err.Error(), "templated.tmpl") {
+		t.Fatalf("Expected a prompt template error, got %v", err)
+	}
+	if len(templated.prompts) != 0 {
+		t.Errorf("Expected the provider not to be called, got %d calls", len(templated.prompts))
+	}
+}
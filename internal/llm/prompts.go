@@ -10,10 +10,13 @@ import (
 	"github.com/ryacub/telos-idea-matrix/internal/models"
 )
 
-// PromptTemplate is the template for LLM analysis prompts.
-const PromptTemplate = `You are an expert at evaluating ideas against personal goals and values.
+// DefaultSystemPrompt sets the tone of the analysis for providers without a
+// configured system prompt
+const DefaultSystemPrompt = "You are an expert at evaluating ideas against personal goals and values."
 
-TELOS (Personal Goals & Values):
+// PromptTemplate is the template for LLM analysis prompts. The system prompt
+// is sent separately; see withSystemPrompt.
+const PromptTemplate = `TELOS (Personal Goals & Values):
 {{.TelosContent}}
 
 IDEA TO EVALUATE:
@@ -43,7 +46,7 @@ SCORING FRAMEWORK:
    - Revenue Testing (0-0.3): Is this scalable (SaaS vs consulting)?
 
 RESPONSE FORMAT:
-Respond with valid JSON in this exact format:
+Respond with a single JSON object in this exact format:
 {
   "scores": {
     "mission_alignment": 2.5,
@@ -60,49 +63,51 @@ Respond with valid JSON in this exact format:
 }
 
 IMPORTANT:
-- Provide ONLY the JSON response, no additional text
+- Provide ONLY the JSON object: no additional text and no markdown code fences
+- Include every field shown above, with numbers as numbers rather than strings
 - Ensure all scores are within their valid ranges
 - final_score should be the sum of the three category scores
 - recommendation should be one of: "PRIORITIZE NOW", "GOOD ALIGNMENT", "CONSIDER LATER", "AVOID FOR NOW"
 `
 
-// PromptData contains the data needed to build a prompt.
+// PromptData contains the data needed to build a prompt. Prompt template
+// files see the same fields.
 type PromptData struct {
-	TelosContent string
-	IdeaContent  string
+	TelosContent string        // The telos formatted as Markdown sections
+	IdeaContent  string        // The idea to evaluate
+	Telos        *models.Telos // The parsed telos, for templates that format it themselves
 }
 
-// BuildAnalysisPrompt builds a prompt for LLM analysis.
-// It takes the idea content and telos, and returns a formatted prompt.
-func BuildAnalysisPrompt(ideaContent string, telos *models.Telos) (string, error) {
-	if ideaContent == "" {
-		return "", fmt.Errorf("idea content is required")
-	}
-	if telos == nil {
-		return "", fmt.Errorf("telos is required")
-	}
+// defaultPromptTemplate is PromptTemplate, parsed once
+var defaultPromptTemplate = template.Must(template.New("prompt").Parse(PromptTemplate))
 
-	// Convert telos to human-readable format
-	telosContent := formatTelos(telos)
-
-	// Create template data
-	data := PromptData{
-		TelosContent: telosContent,
-		IdeaContent:  ideaContent,
+// systemPromptOr returns prompt, or DefaultSystemPrompt if none is configured
+func systemPromptOr(prompt string) string {
+	if strings.TrimSpace(prompt) == "" {
+		return DefaultSystemPrompt
 	}
+	return prompt
+}
 
-	// Parse and execute template
-	tmpl, err := template.New("prompt").Parse(PromptTemplate)
-	if err != nil {
-		return "", fmt.Errorf("parse template: %w", err)
-	}
+// withSystemPrompt prepends a system prompt to a prompt, for completion-style
+// providers that take a single block of text
+func withSystemPrompt(systemPrompt, prompt string) string {
+	return systemPromptOr(systemPrompt) + "\n\n" + prompt
+}
 
-	var buf bytes.Buffer
-	if err := tmpl.Execute(&buf, data); err != nil {
-		return "", fmt.Errorf("execute template: %w", err)
-	}
+// BuildAnalysisPrompt builds a prompt for LLM analysis.
+// It takes the idea content and telos, and returns a formatted prompt.
+func BuildAnalysisPrompt(ideaContent string, telos *models.Telos) (string, error) {
+	return executePromptTemplate(defaultPromptTemplate, ideaContent, telos)
+}
 
-	return buf.String(), nil
+// analysisPrompt returns the prompt to send for req: the one rendered from
+// the provider's prompt template, or else the built-in one
+func analysisPrompt(req AnalysisRequest) (string, error) {
+	if req.Prompt != "" {
+		return req.Prompt, nil
+	}
+	return BuildAnalysisPrompt(req.IdeaContent, req.Telos)
 }
 
 // formatTelos converts a Telos struct to a human-readable string.
@@ -202,12 +207,6 @@ func (r *LLMResponse) Validate() error {
 		return fmt.Errorf("final_score must be between 0-10, got %f", r.FinalScore)
 	}
 
-	validRecommendations := map[string]bool{
-		"PRIORITIZE NOW": true,
-		"GOOD ALIGNMENT": true,
-		"CONSIDER LATER": true,
-		"AVOID FOR NOW":  true,
-	}
 	if !validRecommendations[r.Recommendation] {
 		return fmt.Errorf("invalid recommendation: %s", r.Recommendation)
 	}
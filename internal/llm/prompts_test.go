@@ -0,0 +1,147 @@
+package llm
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ryacub/telos-idea-matrix/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
+)
+
+// analysisJSON is a valid analysis as a model would return it
+const analysisJSON = `{"scores": {"mission_alignment": 3.0, "anti_challenge": 2.5, "strategic_fit": 1.8}, "final_score": 7.3, "recommendation": "GOOD ALIGNMENT", "explanations": {}}`
+
+// systemPromptProvider is a provider whose tone can be configured
+type systemPromptProvider interface {
+	Provider
+	SetSystemPrompt(prompt string)
+}
+
+func TestProviders_SendSystemPrompt(t *testing.T) {
+	tests := []struct {
+		name     string
+		provider func(t *testing.T, url string) systemPromptProvider
+		response func() interface{}
+		// sent returns the part of the request that should start with the system prompt
+		sent func(body map[string]interface{}) string
+	}{
+		{
+			name: "ollama prepends it to the prompt",
+			provider: func(_ *testing.T, url string) systemPromptProvider {
+				return NewOllamaProvider(url, "")
+			},
+			response: func() interface{} {
+				return map[string]interface{}{"model": "llama2", "response": analysisJSON, "done": true}
+			},
+			sent: func(body map[string]interface{}) string {
+				return body["prompt"].(string)
+			},
+		},
+		{
+			name: "claude sends it as the system prompt",
+			provider: func(_ *testing.T, url string) systemPromptProvider {
+				p := NewClaudeProvider("test-key", "")
+				p.baseURL = url
+				return p
+			},
+			response: func() interface{} {
+				return map[string]interface{}{
+					"type":    "message",
+					"role":    "assistant",
+					"content": []map[string]string{{"type": "text", "text": analysisJSON}},
+				}
+			},
+			sent: func(body map[string]interface{}) string {
+				return body["system"].(string)
+			},
+		},
+		{
+			name: "openai sends it as the system message",
+			provider: func(_ *testing.T, url string) systemPromptProvider {
+				return &OpenAIProvider{openAICompatibleProvider{
+					apiKey:      "test-key",
+					model:       "gpt-4",
+					baseURL:     url,
+					httpClient:  &http.Client{},
+					maxRetries:  1,
+					rateLimiter: rate.NewLimiter(rate.Inf, 1),
+				}}
+			},
+			response: func() interface{} {
+				return map[string]interface{}{
+					"choices": []map[string]interface{}{
+						{"message": map[string]string{"role": "assistant", "content": analysisJSON}},
+					},
+				}
+			},
+			sent: func(body map[string]interface{}) string {
+				first := body["messages"].([]interface{})[0].(map[string]interface{})
+				if first["role"] != "system" {
+					return ""
+				}
+				return first["content"].(string)
+			},
+		},
+		{
+			name: "custom sends it as system",
+			provider: func(t *testing.T, url string) systemPromptProvider {
+				t.Setenv("CUSTOM_LLM_ENDPOINT", url)
+				return NewCustomProvider()
+			},
+			response: func() interface{} {
+				return map[string]interface{}{"final_score": 7.3, "recommendation": "review"}
+			},
+			sent: func(body map[string]interface{}) string {
+				return body["system"].(string)
+			},
+		},
+	}
+
+	telos := &models.Telos{
+		Goals: []models.Goal{{ID: "g1", Description: "Build AI products"}},
+	}
+
+	prompts := []struct {
+		name       string
+		configured string
+		want       string
+	}{
+		{name: "configured", configured: "Be terse. Answer with JSON only.", want: "Be terse. Answer with JSON only."},
+		{name: "default", configured: "", want: DefaultSystemPrompt},
+	}
+
+	for _, tt := range tests {
+		for _, pt := range prompts {
+			t.Run(tt.name+"/"+pt.name, func(t *testing.T) {
+				var body map[string]interface{}
+				server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					_ = json.NewDecoder(r.Body).Decode(&body)
+					w.Header().Set("Content-Type", "application/json")
+					_ = json.NewEncoder(w).Encode(tt.response())
+				}))
+				defer server.Close()
+
+				provider := tt.provider(t, server.URL)
+				provider.SetSystemPrompt(pt.configured)
+
+				_, err := provider.Analyze(AnalysisRequest{IdeaContent: "Build an AI tool", Telos: telos})
+				require.NoError(t, err)
+				require.NotNil(t, body, "provider sent no request")
+
+				sent := tt.sent(body)
+				assert.True(t, strings.HasPrefix(sent, pt.want), "expected %q to start with %q", sent, pt.want)
+			})
+		}
+	}
+}
+
+func TestBuildAnalysisPrompt_LeavesOutSystemPrompt(t *testing.T) {
+	prompt, err := BuildAnalysisPrompt("Build an AI tool", &models.Telos{})
+	require.NoError(t, err)
+	assert.NotContains(t, prompt, DefaultSystemPrompt)
+}
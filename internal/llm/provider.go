@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/http"
 	"strings"
 	"sync"
 	"time"
@@ -14,6 +15,7 @@ import (
 	"github.com/ryacub/telos-idea-matrix/internal/metrics"
 	"github.com/ryacub/telos-idea-matrix/internal/models"
 	"github.com/ryacub/telos-idea-matrix/internal/scoring"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 // Error types for better error classification
@@ -24,6 +26,8 @@ var (
 	ErrNetwork         = errors.New("network error")
 	ErrInvalidResponse = errors.New("invalid response")
 	ErrProvider        = errors.New("provider error")
+	ErrUnknownProvider = errors.New("unknown provider")
+	ErrUnavailable     = errors.New("provider unavailable")
 )
 
 // Global quality tracker for all LLM analyses
@@ -46,9 +50,10 @@ func GetQualityTracker() *quality.SimpleTracker {
 
 // OllamaProvider implements the Provider interface using Ollama.
 type OllamaProvider struct {
-	client    *client.OllamaClient
-	model     string
-	processor *processing.SimpleProcessor
+	client       *client.OllamaClient
+	model        string
+	systemPrompt string // Prepended to the prompt; empty uses DefaultSystemPrompt
+	processor    *processing.SimpleProcessor
 }
 
 // NewOllamaProvider creates a new Ollama provider with the given configuration.
@@ -92,7 +97,7 @@ func NewOllamaProvider(baseURL string, model string) *OllamaProvider {
 		}, nil
 	}
 
-	processor := processing.NewSimpleProcessor(fallbackFunc)
+	processor := processing.NewSimpleProcessor(fallbackFunc).WithParser(parseProcessed)
 
 	return &OllamaProvider{
 		client:    client.NewOllamaClient(baseURL, 30*time.Second),
@@ -106,6 +111,12 @@ func (op *OllamaProvider) Name() string {
 	return "ollama"
 }
 
+// SetSystemPrompt sets the prompt prepended to every request; empty restores
+// DefaultSystemPrompt.
+func (op *OllamaProvider) SetSystemPrompt(prompt string) {
+	op.systemPrompt = prompt
+}
+
 // IsAvailable checks if Ollama is running and accessible.
 func (op *OllamaProvider) IsAvailable() bool {
 	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
@@ -116,22 +127,32 @@ func (op *OllamaProvider) IsAvailable() bool {
 
 // Analyze performs idea analysis using Ollama.
 func (op *OllamaProvider) Analyze(req AnalysisRequest) (*AnalysisResult, error) {
+	return op.AnalyzeContext(context.Background(), req)
+}
+
+// AnalyzeContext performs idea analysis using Ollama, abandoning the request
+// if ctx is cancelled.
+func (op *OllamaProvider) AnalyzeContext(ctx context.Context, req AnalysisRequest) (*AnalysisResult, error) {
 	start := time.Now()
 
 	// Build prompt
-	prompt, err := BuildAnalysisPrompt(req.IdeaContent, req.Telos)
+	prompt, err := analysisPrompt(req)
 	if err != nil {
 		return nil, fmt.Errorf("build prompt: %w", err)
 	}
 
 	// Generate analysis using Ollama
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
-	resp, err := op.client.Generate(ctx, client.GenerateRequest{
+	// The Ollama client has its own transport, so its call is traced here
+	httpCtx, httpSpan := startSpan(ctx, "llm.http", attribute.String("http.request.method", http.MethodPost))
+	resp, err := op.client.Generate(httpCtx, client.GenerateRequest{
 		Model:  op.model,
-		Prompt: prompt,
+		Prompt: withSystemPrompt(op.systemPrompt, prompt),
+		Format: "json",
 	})
+	endSpan(httpSpan, err)
 
 	duration := time.Since(start)
 
@@ -145,7 +166,9 @@ func (op *OllamaProvider) Analyze(req AnalysisRequest) (*AnalysisResult, error)
 	}
 
 	// Process LLM response with fallback support
+	_, parseSpan := startSpan(ctx, "llm.parse", attrProvider.String(op.Name()))
 	processed, err := op.processor.Process(resp.Response, req.IdeaContent, req.Telos)
+	endSpan(parseSpan, err)
 	if err != nil {
 		// Record failure
 		metrics.RecordLLMRequest(op.Name(), false, duration)
@@ -217,6 +240,16 @@ func (rbp *RuleBasedProvider) IsAvailable() bool {
 
 // Analyze performs rule-based analysis using the scoring engine.
 func (rbp *RuleBasedProvider) Analyze(req AnalysisRequest) (*AnalysisResult, error) {
+	return rbp.AnalyzeContext(context.Background(), req)
+}
+
+// AnalyzeContext performs rule-based analysis. Scoring is local and quick, so
+// ctx is only checked before starting.
+func (rbp *RuleBasedProvider) AnalyzeContext(ctx context.Context, req AnalysisRequest) (*AnalysisResult, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
 	start := time.Now()
 
 	if req.Telos == nil {
@@ -322,16 +355,26 @@ func (fp *FallbackProvider) IsAvailable() bool {
 // Analyze tries each provider in order until one succeeds.
 // If all providers fail, returns the last error encountered.
 func (fp *FallbackProvider) Analyze(req AnalysisRequest) (*AnalysisResult, error) {
+	return fp.AnalyzeContext(context.Background(), req)
+}
+
+// AnalyzeContext is Analyze bound to ctx. Once ctx is cancelled no further
+// providers are tried.
+func (fp *FallbackProvider) AnalyzeContext(ctx context.Context, req AnalysisRequest) (*AnalysisResult, error) {
 	var lastErr error
 
 	for _, provider := range fp.providers {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
 		// Skip unavailable providers
 		if !provider.IsAvailable() {
 			continue
 		}
 
 		// Try to analyze with this provider
-		result, err := provider.Analyze(req)
+		result, err := provider.AnalyzeContext(ctx, req)
 		if err == nil {
 			// Success! Return the result
 			return result, nil
@@ -365,6 +408,19 @@ func CreateDefaultFallbackChain(config ProviderConfig, _ *models.Telos) *Fallbac
 	return NewFallbackProvider(providers...)
 }
 
+// sleepContext pauses for d, returning ctx's error early if it is cancelled
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
 // classifyError categorizes errors into standard types for metrics tracking
 // Uses error type checking first, then falls back to string matching for compatibility
 func classifyError(err error) string {
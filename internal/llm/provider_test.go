@@ -34,6 +34,10 @@ func (m *MockProvider) Analyze(req AnalysisRequest) (*AnalysisResult, error) {
 	return m.result, nil
 }
 
+func (m *MockProvider) AnalyzeContext(_ context.Context, req AnalysisRequest) (*AnalysisResult, error) {
+	return m.Analyze(req)
+}
+
 func TestProvider_OllamaProvider_Analyze(t *testing.T) {
 	// This test will fail until we implement OllamaProvider
 	provider := NewOllamaProvider("http://localhost:11434", "llama2")
@@ -0,0 +1,122 @@
+package llm
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ryacub/telos-idea-matrix/internal/logging"
+	"golang.org/x/time/rate"
+)
+
+// RateLimitedFunc is called when a request must wait for a provider's rate limit
+type RateLimitedFunc func(provider string, wait time.Duration)
+
+// newRequestsPerMinuteLimiter creates a token bucket refilling at rpm requests per minute.
+// The bucket holds one second's worth of requests (at least one), so short bursts are
+// allowed but sustained traffic is spread evenly across the minute.
+func newRequestsPerMinuteLimiter(rpm int) *rate.Limiter {
+	burst := rpm / 60
+	if burst < 1 {
+		burst = 1
+	}
+	return rate.NewLimiter(rate.Limit(float64(rpm)/60.0), burst)
+}
+
+// requestsPerMinuteFor returns the configured rate limit for a provider, or 0 if unlimited
+func (m *Manager) requestsPerMinuteFor(providerName string) int {
+	cfg := m.config.ProviderConfig
+	name := baseProviderName(providerName)
+
+	switch {
+	case name == "openai":
+		return rpmOrEnv(cfg.OpenAIRequestsPerMinute, "OPENAI_REQUESTS_PER_MINUTE")
+	case name == "claude":
+		return rpmOrEnv(cfg.ClaudeRequestsPerMinute, "CLAUDE_REQUESTS_PER_MINUTE")
+	case name == "groq":
+		return rpmOrEnv(cfg.GroqRequestsPerMinute, "GROQ_REQUESTS_PER_MINUTE")
+	case strings.HasPrefix(name, "custom"):
+		return rpmOrEnv(cfg.CustomRequestsPerMinute, "CUSTOM_LLM_REQUESTS_PER_MINUTE")
+	default:
+		// ollama and rule_based run locally and are never limited
+		return 0
+	}
+}
+
+// rpmOrEnv returns the configured value, falling back to an environment variable
+func rpmOrEnv(configured int, envKey string) int {
+	if configured > 0 {
+		return configured
+	}
+	if value, err := strconv.Atoi(os.Getenv(envKey)); err == nil && value > 0 {
+		return value
+	}
+	return 0
+}
+
+// SetRateLimit sets the requests-per-minute limit for a registered provider.
+// A value of 0 or less removes the limit.
+func (m *Manager) SetRateLimit(providerName string, requestsPerMinute int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if requestsPerMinute <= 0 {
+		delete(m.limiters, providerName)
+		return
+	}
+	if m.limiters == nil {
+		m.limiters = make(map[string]*rate.Limiter)
+	}
+	m.limiters[providerName] = newRequestsPerMinuteLimiter(requestsPerMinute)
+}
+
+// waitForRateLimit blocks until the provider's rate limit allows another request.
+// It gives up if the wait would exceed the configured rate limit timeout or
+// ctx is cancelled.
+func (m *Manager) waitForRateLimit(ctx context.Context, providerName string) error {
+	m.mu.RLock()
+	limiter, exists := m.limiters[providerName]
+	onRateLimited := m.config.OnRateLimited
+	timeout := time.Duration(m.config.ProviderConfig.RateLimitTimeout) * time.Second
+	m.mu.RUnlock()
+
+	if !exists {
+		return nil
+	}
+
+	reservation := limiter.Reserve()
+	delay := reservation.Delay()
+	if delay <= 0 {
+		return nil
+	}
+
+	if timeout <= 0 {
+		timeout = 60 * time.Second
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	if deadline, ok := ctx.Deadline(); ok && time.Now().Add(delay).After(deadline) {
+		reservation.Cancel()
+		return fmt.Errorf("%w: %s would wait %s (timeout %s)", ErrRateLimit, providerName, delay.Round(time.Millisecond), timeout)
+	}
+
+	logging.FromContext(ctx).Info().Str("provider", providerName).Dur("wait", delay).Msg("rate limited, waiting")
+	if onRateLimited != nil {
+		onRateLimited(providerName, delay)
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		reservation.Cancel()
+		return fmt.Errorf("%w: %s: %w", ErrRateLimit, providerName, ctx.Err())
+	}
+}
@@ -0,0 +1,187 @@
+package llm
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newRateLimitTestManager creates a manager whose primary provider is a mock
+func newRateLimitTestManager(t *testing.T, cfg ProviderConfig, onRateLimited RateLimitedFunc) (*Manager, *mockProviderForManager) {
+	t.Helper()
+
+	manager := NewManager(&ManagerConfig{
+		FallbackEnabled: false,
+		ProviderConfig:  cfg,
+		OnRateLimited:   onRateLimited,
+	})
+
+	mock := &mockProviderForManager{name: "mock", available: true}
+	manager.RegisterProvider(mock)
+	if err := manager.SetPrimaryProvider("mock"); err != nil {
+		t.Fatalf("failed to set primary provider: %v", err)
+	}
+
+	return manager, mock
+}
+
+func TestManager_RateLimit_ThrottlesBurst(t *testing.T) {
+	var waits int32
+	manager, mock := newRateLimitTestManager(t, ProviderConfig{RateLimitTimeout: 5}, func(provider string, wait time.Duration) {
+		if provider != "mock" {
+			t.Errorf("expected rate limit callback for mock, got %s", provider)
+		}
+		if wait <= 0 {
+			t.Errorf("expected positive wait, got %s", wait)
+		}
+		atomic.AddInt32(&waits, 1)
+	})
+
+	// 600 requests/minute = 10/second with a burst of 10
+	manager.SetRateLimit("mock", 600)
+
+	req := AnalysisRequest{IdeaContent: "test idea", Telos: createTestTelos()}
+	start := time.Now()
+	for i := 0; i < 13; i++ {
+		if _, err := manager.Analyze(req); err != nil {
+			t.Fatalf("request %d failed: %v", i, err)
+		}
+	}
+	elapsed := time.Since(start)
+
+	// The 3 requests beyond the burst must each wait ~100ms for a token
+	if elapsed < 250*time.Millisecond {
+		t.Errorf("expected burst to be throttled to ~300ms, took %s", elapsed)
+	}
+	if got := atomic.LoadInt32(&waits); got < 1 || got > 3 {
+		t.Errorf("expected 1-3 rate limit waits, got %d", got)
+	}
+	if mock.GetCallCount() != 13 {
+		t.Errorf("expected 13 provider calls, got %d", mock.GetCallCount())
+	}
+}
+
+func TestManager_RateLimit_UnlimitedByDefault(t *testing.T) {
+	var waits int32
+	manager, _ := newRateLimitTestManager(t, ProviderConfig{}, func(string, time.Duration) {
+		atomic.AddInt32(&waits, 1)
+	})
+
+	req := AnalysisRequest{IdeaContent: "test idea", Telos: createTestTelos()}
+	start := time.Now()
+	for i := 0; i < 50; i++ {
+		if _, err := manager.Analyze(req); err != nil {
+			t.Fatalf("request %d failed: %v", i, err)
+		}
+	}
+
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("unlimited provider should not be throttled, took %s", elapsed)
+	}
+	if got := atomic.LoadInt32(&waits); got != 0 {
+		t.Errorf("expected no rate limit waits, got %d", got)
+	}
+}
+
+func TestManager_RateLimit_GivesUpAfterTimeout(t *testing.T) {
+	manager, mock := newRateLimitTestManager(t, ProviderConfig{RateLimitTimeout: 1}, nil)
+
+	// 1 request/minute: the second request would wait ~60s, beyond the 1s timeout
+	manager.SetRateLimit("mock", 1)
+
+	req := AnalysisRequest{IdeaContent: "test idea", Telos: createTestTelos()}
+	if _, err := manager.Analyze(req); err != nil {
+		t.Fatalf("first request failed: %v", err)
+	}
+
+	start := time.Now()
+	_, err := manager.Analyze(req)
+	if err == nil {
+		t.Fatal("expected second request to be rate limited")
+	}
+	if time.Since(start) > 500*time.Millisecond {
+		t.Error("expected rate limit error without waiting for the full timeout")
+	}
+
+	// Fallback is disabled, so the manager wraps the provider error
+	if mock.GetCallCount() != 1 {
+		t.Errorf("expected provider to be called once, got %d", mock.GetCallCount())
+	}
+}
+
+func TestManager_WaitForRateLimit_ReturnsErrRateLimit(t *testing.T) {
+	manager, _ := newRateLimitTestManager(t, ProviderConfig{RateLimitTimeout: 1}, nil)
+	manager.SetRateLimit("mock", 1)
+
+	if err := manager.waitForRateLimit(context.Background(), "mock"); err != nil {
+		t.Fatalf("first wait failed: %v", err)
+	}
+	if err := manager.waitForRateLimit(context.Background(), "mock"); !errors.Is(err, ErrRateLimit) {
+		t.Errorf("expected ErrRateLimit, got %v", err)
+	}
+
+	// Removing the limit lets requests through again
+	manager.SetRateLimit("mock", 0)
+	if err := manager.waitForRateLimit(context.Background(), "mock"); err != nil {
+		t.Errorf("expected no limit after removal, got %v", err)
+	}
+}
+
+// TestManager_WaitForRateLimit_ConcurrentLoadConfig is meant for -race:
+// waiting reads the timeout while the config is being replaced
+func TestManager_WaitForRateLimit_ConcurrentLoadConfig(t *testing.T) {
+	manager, _ := newRateLimitTestManager(t, ProviderConfig{RateLimitTimeout: 1}, nil)
+	manager.SetRateLimit("mock", 1)
+	if err := manager.waitForRateLimit(context.Background(), "mock"); err != nil {
+		t.Fatalf("first wait failed: %v", err)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 50; i++ {
+			if err := manager.LoadConfig(&ManagerConfig{ProviderConfig: ProviderConfig{RateLimitTimeout: 1}}); err != nil {
+				t.Errorf("LoadConfig failed: %v", err)
+				return
+			}
+		}
+	}()
+	for i := 0; i < 50; i++ {
+		if err := manager.waitForRateLimit(context.Background(), "mock"); !errors.Is(err, ErrRateLimit) {
+			t.Errorf("expected ErrRateLimit, got %v", err)
+		}
+	}
+	<-done
+}
+
+func TestManager_RequestsPerMinuteFor(t *testing.T) {
+	t.Setenv("CLAUDE_REQUESTS_PER_MINUTE", "30")
+	t.Setenv("OPENAI_REQUESTS_PER_MINUTE", "")
+	t.Setenv("CUSTOM_LLM_REQUESTS_PER_MINUTE", "")
+	t.Setenv("GROQ_REQUESTS_PER_MINUTE", "")
+
+	manager := &Manager{config: &ManagerConfig{
+		ProviderConfig: ProviderConfig{OpenAIRequestsPerMinute: 100, GroqRequestsPerMinute: 30},
+	}}
+
+	tests := []struct {
+		provider string
+		want     int
+	}{
+		{"openai_gpt-4o", 100},
+		{"openai_gpt-4o_cached", 100},
+		{"claude", 30},
+		{"groq", 30},
+		{"custom", 0},
+		{"ollama", 0},
+		{"rule_based", 0},
+	}
+
+	for _, tt := range tests {
+		if got := manager.requestsPerMinuteFor(tt.provider); got != tt.want {
+			t.Errorf("requestsPerMinuteFor(%q) = %d, want %d", tt.provider, got, tt.want)
+		}
+	}
+}
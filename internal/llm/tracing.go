@@ -0,0 +1,89 @@
+package llm
+
+import (
+	"context"
+	"io"
+	"net/http"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+// TracerName is the instrumentation name of the spans this package records
+const TracerName = "github.com/ryacub/telos-idea-matrix/internal/llm"
+
+// Span attributes recorded on analysis spans
+const (
+	attrProvider = attribute.Key("llm.provider")
+	attrAttempts = attribute.Key("llm.attempts")
+	attrOutcome  = attribute.Key("llm.outcome")
+)
+
+// Outcomes of an analysis, recorded as llm.outcome
+const (
+	outcomeSuccess   = "success"   // The primary provider answered
+	outcomeFallback  = "fallback"  // A provider answered after another failed
+	outcomeFailed    = "failed"    // No provider answered
+	outcomeCancelled = "cancelled" // The caller's context ended first
+)
+
+// noopTracer records nothing; it's used when ManagerConfig.Tracer is nil
+var noopTracer = noop.NewTracerProvider().Tracer(TracerName)
+
+// startSpan starts a child of the span in ctx from the same tracer provider,
+// so provider spans are recorded exactly when the manager's analysis span is.
+// Without a span in ctx the returned span is a no-op.
+func startSpan(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	tracer := trace.SpanFromContext(ctx).TracerProvider().Tracer(TracerName)
+	return tracer.Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// endSpan records err on span, if there is one, and ends it
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// tracingTransport records an "llm.http" span for each request, lasting until
+// the response body is closed so reading the body is included
+type tracingTransport struct {
+	base http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper
+func (t tracingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx, span := startSpan(req.Context(), "llm.http",
+		attribute.String("http.request.method", req.Method),
+		attribute.String("server.address", req.URL.Host),
+	)
+
+	resp, err := t.base.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		endSpan(span, err)
+		return nil, err
+	}
+
+	span.SetAttributes(attribute.Int("http.response.status_code", resp.StatusCode))
+	if resp.StatusCode >= http.StatusBadRequest {
+		span.SetStatus(codes.Error, resp.Status)
+	}
+	resp.Body = &spanBody{ReadCloser: resp.Body, span: span}
+	return resp, nil
+}
+
+// spanBody ends its span when the response body is closed
+type spanBody struct {
+	io.ReadCloser
+	span trace.Span
+}
+
+func (b *spanBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.span.End()
+	return err
+}
@@ -0,0 +1,187 @@
+package llm
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// newTracedManager returns a manager with providers tried in the given order,
+// recording spans to the returned recorder
+func newTracedManager(providers ...Provider) (*Manager, *tracetest.SpanRecorder) {
+	recorder := tracetest.NewSpanRecorder()
+	tracer := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)).Tracer(TracerName)
+
+	manager := &Manager{
+		providers:       make([]Provider, 0),
+		fallbackEnabled: true,
+		healthCache:     make(map[string]healthStatus),
+		stats:           make(map[string]*providerStats),
+		tracer:          tracer,
+		config:          DefaultManagerConfig(),
+	}
+	for _, p := range providers {
+		manager.RegisterProvider(p)
+	}
+	if len(providers) > 0 {
+		_ = manager.SetPrimaryProvider(providers[0].Name())
+	}
+	return manager, recorder
+}
+
+// endedSpan returns the ended span named name, failing the test if there isn't exactly one
+func endedSpan(t *testing.T, recorder *tracetest.SpanRecorder, name string) sdktrace.ReadOnlySpan {
+	t.Helper()
+	var found []sdktrace.ReadOnlySpan
+	for _, span := range recorder.Ended() {
+		if span.Name() == name {
+			found = append(found, span)
+		}
+	}
+	if len(found) != 1 {
+		t.Fatalf("Expected one %q span, got %d", name, len(found))
+	}
+	return found[0]
+}
+
+// spanAttr returns the value of the attribute key on span
+func spanAttr(span sdktrace.ReadOnlySpan, key attribute.Key) attribute.Value {
+	for _, kv := range span.Attributes() {
+		if kv.Key == key {
+			return kv.Value
+		}
+	}
+	return attribute.Value{}
+}
+
+func TestManager_AnalyzeSpan(t *testing.T) {
+	tests := []struct {
+		name         string
+		primaryErr   error
+		wantProvider string
+		wantAttempts int64
+		wantOutcome  string
+		wantError    bool
+	}{
+		{"primary answers", nil, "primary", 1, outcomeSuccess, false},
+		{"fallback answers", errors.New("primary failed"), "fallback", 2, outcomeFallback, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			manager, recorder := newTracedManager(
+				&mockProviderForManager{name: "primary", available: true, err: tt.primaryErr},
+				&mockProviderForManager{name: "fallback", available: true},
+			)
+
+			if _, err := manager.AnalyzeContext(context.Background(), AnalysisRequest{IdeaContent: "Test idea"}); err != nil {
+				t.Fatalf("AnalyzeContext() error = %v", err)
+			}
+
+			span := endedSpan(t, recorder, "llm.analyze")
+			if got := spanAttr(span, attrProvider).AsString(); got != tt.wantProvider {
+				t.Errorf("llm.provider = %q, want %q", got, tt.wantProvider)
+			}
+			if got := spanAttr(span, attrAttempts).AsInt64(); got != tt.wantAttempts {
+				t.Errorf("llm.attempts = %d, want %d", got, tt.wantAttempts)
+			}
+			if got := spanAttr(span, attrOutcome).AsString(); got != tt.wantOutcome {
+				t.Errorf("llm.outcome = %q, want %q", got, tt.wantOutcome)
+			}
+			if span.Status().Code == codes.Error {
+				t.Errorf("Expected span status not to be an error, got %v", span.Status())
+			}
+		})
+	}
+}
+
+func TestManager_AnalyzeSpanRecordsFailure(t *testing.T) {
+	manager, recorder := newTracedManager(
+		&mockProviderForManager{name: "primary", available: true, err: errors.New("primary failed")},
+		&mockProviderForManager{name: "fallback", available: true, err: errors.New("fallback failed")},
+	)
+
+	if _, err := manager.AnalyzeContext(context.Background(), AnalysisRequest{IdeaContent: "Test idea"}); err == nil {
+		t.Fatal("Expected an error when every provider fails")
+	}
+
+	span := endedSpan(t, recorder, "llm.analyze")
+	if got := spanAttr(span, attrOutcome).AsString(); got != outcomeFailed {
+		t.Errorf("llm.outcome = %q, want %q", got, outcomeFailed)
+	}
+	if got := spanAttr(span, attrProvider).AsString(); got != "fallback" {
+		t.Errorf("llm.provider = %q, want the last provider tried", got)
+	}
+	if span.Status().Code != codes.Error {
+		t.Errorf("Expected error status, got %v", span.Status())
+	}
+
+	// Cancelled before any provider answers
+	manager, recorder = newTracedManager(&mockProviderForManager{name: "primary", available: true})
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, _ = manager.AnalyzeContext(ctx, AnalysisRequest{IdeaContent: "Test idea"})
+
+	span = endedSpan(t, recorder, "llm.analyze")
+	if got := spanAttr(span, attrOutcome).AsString(); got != outcomeCancelled {
+		t.Errorf("llm.outcome = %q, want %q", got, outcomeCancelled)
+	}
+}
+
+func TestManager_AnalyzeSpanHasHTTPAndParseChildren(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"mission_alignment": 3, "anti_challenge": 2, "strategic_fit": 2, "final_score": 7, "recommendation": "pursue"}`))
+	}))
+	defer server.Close()
+	t.Setenv("CUSTOM_LLM_ENDPOINT", server.URL)
+
+	manager, recorder := newTracedManager(NewCustomProvider())
+
+	// The caller's span, such as an API request's, becomes the parent
+	ctx, request := manager.tracer.Start(context.Background(), "request")
+	if _, err := manager.AnalyzeContext(ctx, AnalysisRequest{IdeaContent: "Test idea"}); err != nil {
+		t.Fatalf("AnalyzeContext() error = %v", err)
+	}
+	request.End()
+
+	analyze := endedSpan(t, recorder, "llm.analyze")
+	if analyze.Parent().SpanID() != request.SpanContext().SpanID() {
+		t.Error("Expected llm.analyze to be a child of the caller's span")
+	}
+
+	for _, name := range []string{"llm.http", "llm.parse"} {
+		span := endedSpan(t, recorder, name)
+		if span.Parent().SpanID() != analyze.SpanContext().SpanID() {
+			t.Errorf("Expected %s to be a child of llm.analyze", name)
+		}
+	}
+
+	httpSpan := endedSpan(t, recorder, "llm.http")
+	if got := spanAttr(httpSpan, "http.response.status_code").AsInt64(); got != http.StatusOK {
+		t.Errorf("http.response.status_code = %d, want 200", got)
+	}
+}
+
+func TestProviderSpansWithoutTracing(t *testing.T) {
+	// Without a span in ctx, provider spans are no-ops
+	_, span := startSpan(context.Background(), "llm.parse")
+	if span.IsRecording() {
+		t.Error("Expected a non-recording span without a parent")
+	}
+	endSpan(span, errors.New("ignored"))
+
+	// A manager without a tracer records nothing and still analyzes
+	manager := NewManager(&ManagerConfig{FallbackEnabled: true})
+	manager.RegisterProvider(&mockProviderForManager{name: "primary", available: true})
+	_ = manager.SetPrimaryProvider("primary")
+	if _, err := manager.AnalyzeContext(context.Background(), AnalysisRequest{IdeaContent: "Test idea"}); err != nil {
+		t.Fatalf("AnalyzeContext() error = %v", err)
+	}
+}
@@ -1,6 +1,7 @@
 package llm
 
 import (
+	"context"
 	"time"
 
 	"github.com/ryacub/telos-idea-matrix/internal/models"
@@ -10,6 +11,10 @@ import (
 type AnalysisRequest struct {
 	IdeaContent string        // The idea text to analyze
 	Telos       *models.Telos // The parsed telos configuration
+
+	// Prompt replaces the built-in analysis prompt when set. The Manager fills
+	// it in from the provider's prompt template file, if there is one.
+	Prompt string
 }
 
 // AnalysisResult represents the result of an LLM analysis.
@@ -22,6 +27,7 @@ type AnalysisResult struct {
 	Provider       string            // Which provider generated this result
 	Duration       time.Duration     // How long the analysis took
 	FromCache      bool              // Whether result came from cache
+	FallbackChain  []string          // Providers tried by the Manager, in order: "name: ok", "name: unavailable" or "name: <error>"
 }
 
 // ScoreBreakdown contains the three main scoring categories.
@@ -46,6 +52,10 @@ type Provider interface {
 	// Analyze performs the idea analysis and returns the result.
 	// Returns an error if the analysis fails.
 	Analyze(req AnalysisRequest) (*AnalysisResult, error)
+
+	// AnalyzeContext is Analyze bound to ctx: cancelling ctx abandons any
+	// in-flight request and returns ctx's error.
+	AnalyzeContext(ctx context.Context, req AnalysisRequest) (*AnalysisResult, error)
 }
 
 // ProviderConfig contains configuration for LLM providers.
@@ -57,7 +67,7 @@ type ProviderConfig struct {
 
 	// Claude API configuration
 	ClaudeAPIKey  string // Claude API key (or use ANTHROPIC_API_KEY env var)
-	ClaudeModel   string // Default: claude-3-5-sonnet-20241022
+	ClaudeModel   string // Or use CLAUDE_MODEL env var; default: claude-3-5-sonnet-20241022
 	ClaudeTimeout int    // Timeout in seconds, default: 30
 
 	// OpenAI API configuration
@@ -65,12 +75,30 @@ type ProviderConfig struct {
 	OpenAIModel   string // Default: gpt-5.1
 	OpenAITimeout int    // Timeout in seconds, default: 30
 
+	// Groq API configuration
+	GroqAPIKey  string // Groq API key (or use GROQ_API_KEY env var)
+	GroqModel   string // Or use GROQ_MODEL env var; default: llama-3.1-70b-versatile
+	GroqTimeout int    // Timeout in seconds, default: 30
+
 	// Custom provider configuration
 	CustomEndpoint       string // Custom LLM endpoint URL (or use CUSTOM_LLM_ENDPOINT env var)
 	CustomHeaders        string // Custom headers as comma-separated key:value pairs
 	CustomPromptTemplate string // Go template for request body
 	CustomTimeout        int    // Timeout in seconds, default: 30
 
+	// SystemPrompts sets each provider's tone, keyed by "ollama", "claude",
+	// "openai", "groq" or "custom"; a missing entry uses DefaultSystemPrompt.
+	// Chat-style providers send it as the system message, Ollama prepends it.
+	SystemPrompts map[string]string
+
+	// Rate limits in requests per minute; 0 means unlimited.
+	// Ollama and rule-based providers are never rate limited.
+	OpenAIRequestsPerMinute int // Or use OPENAI_REQUESTS_PER_MINUTE env var
+	ClaudeRequestsPerMinute int // Or use CLAUDE_REQUESTS_PER_MINUTE env var
+	GroqRequestsPerMinute   int // Or use GROQ_REQUESTS_PER_MINUTE env var
+	CustomRequestsPerMinute int // Or use CUSTOM_LLM_REQUESTS_PER_MINUTE env var
+	RateLimitTimeout        int // Max seconds to wait for a rate limit token, default: 60
+
 	// General configuration
 	EnableCache bool // Whether to cache results
 	CacheTTL    int  // Cache TTL in seconds
@@ -82,12 +110,14 @@ func DefaultProviderConfig() ProviderConfig {
 		OllamaBaseURL: "http://localhost:11434",
 		OllamaModel:   "llama2",
 		OllamaTimeout: 30,
-		ClaudeModel:   "claude-3-5-sonnet-20241022",
 		ClaudeTimeout: 30,
 		OpenAIModel:   "gpt-5.1",
 		OpenAITimeout: 30,
+		GroqTimeout:   30,
 		CustomTimeout: 30,
 		EnableCache:   true,
 		CacheTTL:      3600, // 1 hour
+
+		RateLimitTimeout: 60,
 	}
 }
@@ -2,9 +2,11 @@
 package logging
 
 import (
+	"fmt"
 	"io"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/rs/zerolog"
@@ -14,9 +16,9 @@ import (
 
 // Config holds the logging configuration
 type Config struct {
-	Level      string
+	Level      string // See Levels; anything else is info
 	Format     string // "json" or "console"
-	OutputPath string // file path or "stdout"
+	OutputPath string // file path, "stdout" or "stderr"
 	MaxSizeMB  int
 	MaxBackups int
 	MaxAgeDays int
@@ -30,9 +32,13 @@ func NewLogger(cfg Config) zerolog.Logger {
 
 	// Configure output
 	var output io.Writer
-	if cfg.OutputPath == "stdout" || cfg.OutputPath == "" {
+	toFile := false
+	switch cfg.OutputPath {
+	case "stdout", "":
 		output = os.Stdout
-	} else {
+	case "stderr":
+		output = os.Stderr
+	default:
 		// Ensure directory exists
 		dir := filepath.Dir(cfg.OutputPath)
 		if err := os.MkdirAll(dir, 0755); err != nil {
@@ -40,6 +46,7 @@ func NewLogger(cfg Config) zerolog.Logger {
 			output = os.Stdout
 		} else {
 			// File output with rotation
+			toFile = true
 			output = &lumberjack.Logger{
 				Filename:   cfg.OutputPath,
 				MaxSize:    cfg.MaxSizeMB,
@@ -52,7 +59,8 @@ func NewLogger(cfg Config) zerolog.Logger {
 
 	// Format
 	if cfg.Format == "console" {
-		output = zerolog.ConsoleWriter{Out: output, TimeFormat: time.RFC3339}
+		// Color codes would be escape noise in a log file
+		output = zerolog.ConsoleWriter{Out: output, TimeFormat: time.RFC3339, NoColor: toFile}
 	}
 
 	logger := zerolog.New(output).With().Timestamp().Caller().Logger().Level(level)
@@ -63,18 +71,29 @@ func NewLogger(cfg Config) zerolog.Logger {
 	return logger
 }
 
-// parseLogLevel converts a string log level to zerolog.Level
-func parseLogLevel(level string) zerolog.Level {
-	switch level {
+// Levels lists the accepted log levels, from most to least verbose
+var Levels = []string{"debug", "info", "warn", "error"}
+
+// ParseLevel converts a log level name to a zerolog.Level, ignoring case.
+// An empty name is info.
+func ParseLevel(level string) (zerolog.Level, error) {
+	switch strings.ToLower(strings.TrimSpace(level)) {
 	case "debug":
-		return zerolog.DebugLevel
-	case "info":
-		return zerolog.InfoLevel
+		return zerolog.DebugLevel, nil
+	case "info", "":
+		return zerolog.InfoLevel, nil
 	case "warn":
-		return zerolog.WarnLevel
+		return zerolog.WarnLevel, nil
 	case "error":
-		return zerolog.ErrorLevel
+		return zerolog.ErrorLevel, nil
 	default:
-		return zerolog.InfoLevel
+		return zerolog.InfoLevel, fmt.Errorf("invalid log level %q (must be one of: %s)", level, strings.Join(Levels, ", "))
 	}
 }
+
+// parseLogLevel converts a string log level to zerolog.Level, falling back
+// to info for unknown levels
+func parseLogLevel(level string) zerolog.Level {
+	parsed, _ := ParseLevel(level)
+	return parsed
+}
@@ -183,3 +183,45 @@ func TestParseLogLevel(t *testing.T) {
 		})
 	}
 }
+
+func TestParseLevel(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected zerolog.Level
+		wantErr  bool
+	}{
+		{"debug", zerolog.DebugLevel, false},
+		{"WARN", zerolog.WarnLevel, false},
+		{" error ", zerolog.ErrorLevel, false},
+		{"", zerolog.InfoLevel, false},
+		{"verbose", zerolog.InfoLevel, true},
+	}
+
+	for _, tt := range tests {
+		level, err := ParseLevel(tt.input)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseLevel(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+		}
+		if level != tt.expected {
+			t.Errorf("ParseLevel(%q) = %v, want %v", tt.input, level, tt.expected)
+		}
+	}
+}
+
+func TestLogger_ConsoleFileOutputHasNoColor(t *testing.T) {
+	logFile := filepath.Join(t.TempDir(), "console.log")
+
+	logger := NewLogger(Config{Level: "info", Format: "console", OutputPath: logFile})
+	logger.Info().Msg("plain text")
+
+	data, err := os.ReadFile(logFile)
+	if err != nil {
+		t.Fatalf("Failed to read log file: %v", err)
+	}
+	if !bytes.Contains(data, []byte("plain text")) {
+		t.Errorf("Expected the message in the log file, got %q", data)
+	}
+	if bytes.Contains(data, []byte("\x1b[")) {
+		t.Errorf("Expected no color codes in the log file, got %q", data)
+	}
+}
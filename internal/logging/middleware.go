@@ -1,22 +1,44 @@
 package logging
 
 import (
+	"context"
 	"net/http"
 	"time"
 
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
 )
 
-// Middleware provides HTTP request/response logging
+// RequestIDHeader carries a request's correlation ID. A client may send its
+// own; the server always returns the ID it used.
+const RequestIDHeader = "X-Request-ID"
+
+// maxRequestIDLength bounds client-supplied IDs so they can't bloat every log line
+const maxRequestIDLength = 128
+
+// Middleware provides HTTP request/response logging. Each request gets a
+// correlation ID, taken from the X-Request-ID header or generated, which is
+// returned in the response headers and added to the logger stored in the
+// request context, so every log line written through FromContext carries it.
 func Middleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		start := time.Now()
 
+		requestID := r.Header.Get(RequestIDHeader)
+		if !validRequestID(requestID) {
+			requestID = uuid.New().String()
+		}
+		w.Header().Set(RequestIDHeader, requestID)
+
+		logger := log.With().Str("request_id", requestID).Logger()
+		r = r.WithContext(logger.WithContext(r.Context()))
+
 		// Wrap response writer to capture status code
 		wrapped := &responseWriter{ResponseWriter: w, statusCode: http.StatusOK}
 
 		// Log request
-		log.Info().
+		logger.Info().
 			Str("method", r.Method).
 			Str("path", r.URL.Path).
 			Str("remote_addr", r.RemoteAddr).
@@ -27,7 +49,7 @@ func Middleware(next http.Handler) http.Handler {
 
 		// Log response
 		duration := time.Since(start)
-		log.Info().
+		logger.Info().
 			Str("method", r.Method).
 			Str("path", r.URL.Path).
 			Int("status", wrapped.statusCode).
@@ -36,6 +58,28 @@ func Middleware(next http.Handler) http.Handler {
 	})
 }
 
+// FromContext returns the request logger stored in ctx by Middleware, or the
+// global logger outside a request.
+func FromContext(ctx context.Context) *zerolog.Logger {
+	if logger := zerolog.Ctx(ctx); logger.GetLevel() != zerolog.Disabled {
+		return logger
+	}
+	return &log.Logger
+}
+
+// validRequestID accepts non-empty, reasonably short IDs of printable ASCII
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] < 0x21 || id[i] > 0x7e {
+			return false
+		}
+	}
+	return true
+}
+
 // responseWriter wraps http.ResponseWriter to capture the status code
 type responseWriter struct {
 	http.ResponseWriter
@@ -0,0 +1,135 @@
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+)
+
+// captureLogs points the global logger at a buffer for the rest of the test
+func captureLogs(t *testing.T) *bytes.Buffer {
+	t.Helper()
+	var buf bytes.Buffer
+	previous := log.Logger
+	log.Logger = zerolog.New(&buf)
+	t.Cleanup(func() { log.Logger = previous })
+	return &buf
+}
+
+// logEntries parses one JSON log entry per line
+func logEntries(t *testing.T, buf *bytes.Buffer) []map[string]interface{} {
+	t.Helper()
+	var entries []map[string]interface{}
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		var entry map[string]interface{}
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			t.Fatalf("Failed to parse log line %q: %v", line, err)
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+func TestMiddleware_UsesClientRequestID(t *testing.T) {
+	buf := captureLogs(t)
+
+	handler := Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		FromContext(r.Context()).Info().Msg("inside handler")
+		w.WriteHeader(http.StatusCreated)
+	}))
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/ideas", nil)
+	req.Header.Set(RequestIDHeader, "trace-abc-123")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if got := rec.Header().Get(RequestIDHeader); got != "trace-abc-123" {
+		t.Errorf("Expected response request ID 'trace-abc-123', got %q", got)
+	}
+
+	entries := logEntries(t, buf)
+	if len(entries) != 3 {
+		t.Fatalf("Expected 3 log entries, got %d: %s", len(entries), buf.String())
+	}
+	for _, entry := range entries {
+		if entry["request_id"] != "trace-abc-123" {
+			t.Errorf("Expected request_id 'trace-abc-123' in %v", entry)
+		}
+	}
+
+	completed := entries[2]
+	if completed["message"] != "request completed" {
+		t.Errorf("Expected last entry to be 'request completed', got %v", completed["message"])
+	}
+	if completed["method"] != "POST" || completed["path"] != "/api/v1/ideas" {
+		t.Errorf("Expected POST /api/v1/ideas, got %v %v", completed["method"], completed["path"])
+	}
+	if completed["status"] != float64(http.StatusCreated) {
+		t.Errorf("Expected status 201, got %v", completed["status"])
+	}
+	if _, ok := completed["duration_ms"]; !ok {
+		t.Error("Expected duration_ms in completion entry")
+	}
+}
+
+func TestMiddleware_GeneratesRequestID(t *testing.T) {
+	captureLogs(t)
+
+	tests := []struct {
+		name   string
+		header string
+	}{
+		{"missing header", ""},
+		{"header with spaces", "not a valid id"},
+		{"overlong header", strings.Repeat("a", maxRequestIDLength+1)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var seen string
+			handler := Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				seen = w.Header().Get(RequestIDHeader)
+			}))
+
+			req := httptest.NewRequest(http.MethodGet, "/health", nil)
+			if tt.header != "" {
+				req.Header.Set(RequestIDHeader, tt.header)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			got := rec.Header().Get(RequestIDHeader)
+			if _, err := uuid.Parse(got); err != nil {
+				t.Errorf("Expected a generated UUID request ID, got %q", got)
+			}
+			if seen != got {
+				t.Errorf("Expected handler to see request ID %q, got %q", got, seen)
+			}
+		})
+	}
+}
+
+func TestFromContext_FallsBackToGlobalLogger(t *testing.T) {
+	buf := captureLogs(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	FromContext(req.Context()).Info().Msg("outside a request")
+
+	entries := logEntries(t, buf)
+	if len(entries) != 1 {
+		t.Fatalf("Expected 1 log entry, got %d", len(entries))
+	}
+	if _, ok := entries[0]["request_id"]; ok {
+		t.Errorf("Expected no request_id outside a request, got %v", entries[0]["request_id"])
+	}
+}
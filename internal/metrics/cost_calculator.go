@@ -32,6 +32,10 @@ var providerPricing = map[string]TokenCost{
 		InputCostPerM:  30.0, // $30 per million input tokens (GPT-4)
 		OutputCostPerM: 60.0, // $60 per million output tokens
 	},
+	"groq": {
+		InputCostPerM:  0.59, // $0.59 per million input tokens (Llama 3.1 70B)
+		OutputCostPerM: 0.79, // $0.79 per million output tokens
+	},
 	"ollama": {
 		InputCostPerM:  0.0, // Free (local)
 		OutputCostPerM: 0.0,
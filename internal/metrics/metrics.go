@@ -23,6 +23,7 @@ func GetGlobalCollector() *Collector {
 // RecordIdeaCreated increments the counter for created ideas
 func RecordIdeaCreated() {
 	GetGlobalCollector().RecordCounter("ideas_created_total", 1)
+	promIdeasCreated.Inc()
 }
 
 // RecordIdeaUpdated increments the counter for updated ideas
@@ -111,6 +112,7 @@ func RecordLLMRequest(provider string, success bool, duration time.Duration) {
 
 	// Track request duration (latency)
 	collector.RecordHistogram("llm_request_duration_ms_"+provider, float64(duration.Milliseconds()))
+	observeAnalysisDuration(provider, duration)
 }
 
 // RecordLLMTokens tracks token usage for cost estimation
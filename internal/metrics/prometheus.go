@@ -0,0 +1,132 @@
+package metrics
+
+import (
+	"database/sql"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// promNamespace prefixes every metric exported to Prometheus
+const promNamespace = "telos"
+
+var (
+	promRegistry = prometheus.NewRegistry()
+
+	promIdeas = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: promNamespace,
+		Name:      "ideas",
+		Help:      "Ideas stored in the database, by status.",
+	}, []string{"status"})
+
+	promIdeasCreated = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: promNamespace,
+		Name:      "ideas_created_total",
+		Help:      "Ideas created through the API since the server started.",
+	})
+
+	promAnalysisDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: promNamespace,
+		Name:      "llm_analysis_duration_seconds",
+		Help:      "Time taken by LLM analysis requests, by provider.",
+		Buckets:   []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60},
+	}, []string{"provider"})
+
+	promProviderRequestsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(promNamespace, "llm", "provider_requests_total"),
+		"LLM provider requests made by the analysis manager, by provider and result (success or failure).",
+		[]string{"provider", "result"}, nil,
+	)
+
+	promProviderStats = &swappableCollector{}
+	promDBStats       = &swappableCollector{}
+)
+
+func init() {
+	promRegistry.MustRegister(promIdeas, promIdeasCreated, promAnalysisDuration, promProviderStats, promDBStats)
+}
+
+// PrometheusHandler serves the exported metrics in the Prometheus text
+// exposition format
+func PrometheusHandler() http.Handler {
+	return promhttp.HandlerFor(promRegistry, promhttp.HandlerOpts{})
+}
+
+// SetIdeaCounts sets the ideas gauge to the number of ideas in each status.
+// Statuses missing from counts keep their previous value.
+func SetIdeaCounts(counts map[string]int) {
+	for status, count := range counts {
+		promIdeas.WithLabelValues(status).Set(float64(count))
+	}
+}
+
+// ProviderRequestStats is the running request count of one LLM provider
+type ProviderRequestStats struct {
+	Provider  string
+	Successes int64
+	Failures  int64
+}
+
+// ExportProviderStats reports the counts returned by stats, which is called
+// on every scrape, as telos_llm_provider_requests_total. A later call
+// replaces the source.
+func ExportProviderStats(stats func() []ProviderRequestStats) {
+	promProviderStats.set(providerStatsCollector(stats))
+}
+
+// ExportDatabaseStats reports the connection pool statistics of db as the
+// go_sql_* metrics. A later call replaces the database.
+func ExportDatabaseStats(db *sql.DB) {
+	promDBStats.set(collectors.NewDBStatsCollector(db, "ideas"))
+}
+
+// observeAnalysisDuration records an LLM request in the analysis histogram
+func observeAnalysisDuration(provider string, duration time.Duration) {
+	promAnalysisDuration.WithLabelValues(provider).Observe(duration.Seconds())
+}
+
+// providerStatsCollector turns a stats source into constant counter metrics
+type providerStatsCollector func() []ProviderRequestStats
+
+func (c providerStatsCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- promProviderRequestsDesc
+}
+
+func (c providerStatsCollector) Collect(ch chan<- prometheus.Metric) {
+	for _, stat := range c() {
+		ch <- prometheus.MustNewConstMetric(promProviderRequestsDesc, prometheus.CounterValue,
+			float64(stat.Successes), stat.Provider, "success")
+		ch <- prometheus.MustNewConstMetric(promProviderRequestsDesc, prometheus.CounterValue,
+			float64(stat.Failures), stat.Provider, "failure")
+	}
+}
+
+// swappableCollector delegates to a collector set after registration. It
+// describes nothing, which makes it an unchecked collector, so the registry
+// accepts whatever the current delegate collects.
+type swappableCollector struct {
+	mu        sync.RWMutex
+	collector prometheus.Collector
+}
+
+func (s *swappableCollector) set(c prometheus.Collector) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.collector = c
+}
+
+func (s *swappableCollector) Describe(chan<- *prometheus.Desc) {}
+
+func (s *swappableCollector) Collect(ch chan<- prometheus.Metric) {
+	s.mu.RLock()
+	c := s.collector
+	s.mu.RUnlock()
+
+	if c != nil {
+		c.Collect(ch)
+	}
+}
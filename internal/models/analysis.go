@@ -3,6 +3,8 @@ package models
 
 import (
 	"errors"
+	"strings"
+	"sync"
 	"time"
 )
 
@@ -21,19 +23,10 @@ type Analysis struct {
 	AnalyzedAt       time.Time           `json:"analyzed_at"`
 }
 
-// GetRecommendation returns the recommendation based on the final score.
-// Matches Rust Recommendation enum and thresholds.
+// GetRecommendation returns the recommendation for the final score under the
+// current thresholds (see SetRecommendationThresholds).
 func (a *Analysis) GetRecommendation() string {
-	switch {
-	case a.FinalScore >= 8.5:
-		return "\U0001F525 PRIORITIZE NOW" // 🔥
-	case a.FinalScore >= 7.0:
-		return "\u2705 GOOD ALIGNMENT" // ✅
-	case a.FinalScore >= 5.0:
-		return "\u26A0\uFE0F CONSIDER LATER" // ⚠️
-	default:
-		return "\U0001F6AB AVOID FOR NOW" // 🚫
-	}
+	return CurrentRecommendationThresholds().Recommend(a.FinalScore).String()
 }
 
 // MissionScores represents the mission alignment scoring breakdown.
@@ -104,13 +97,13 @@ func (p *DetectedPattern) Validate() error {
 type Recommendation string
 
 const (
-	// RecommendationPriority indicates a high-priority idea (>= 8.5).
+	// RecommendationPriority indicates a high-priority idea (>= 8.5 by default).
 	RecommendationPriority Recommendation = "\U0001F525 PRIORITIZE NOW" // 🔥
-	// RecommendationGood indicates good alignment (>= 7.0).
+	// RecommendationGood indicates good alignment (>= 7.0 by default).
 	RecommendationGood Recommendation = "\u2705 GOOD ALIGNMENT" // ✅
-	// RecommendationConsider indicates worth considering later (>= 5.0).
+	// RecommendationConsider indicates worth considering later (>= 5.0 by default).
 	RecommendationConsider Recommendation = "\u26A0\uFE0F CONSIDER LATER" // ⚠️
-	// RecommendationAvoid indicates should avoid for now (< 5.0).
+	// RecommendationAvoid indicates should avoid for now (below Consider).
 	RecommendationAvoid Recommendation = "\U0001F6AB AVOID FOR NOW" // 🚫
 )
 
@@ -118,3 +111,151 @@ const (
 func (r Recommendation) String() string {
 	return string(r)
 }
+
+// Label returns the recommendation without its emoji, as LLMs are asked to
+// write it (e.g. "GOOD ALIGNMENT").
+func (r Recommendation) Label() string {
+	_, label, _ := strings.Cut(string(r), " ")
+	return label
+}
+
+// Category returns the canonical category of the recommendation.
+func (r Recommendation) Category() RecommendationCategory {
+	return ParseRecommendation(string(r))
+}
+
+// RecommendationCategory is the canonical kind of a recommendation, however
+// it was worded when stored. Ideas keep the display string they were scored
+// with; the category is what filters and checks compare.
+type RecommendationCategory string
+
+const (
+	// CategoryPrioritize covers RecommendationPriority.
+	CategoryPrioritize RecommendationCategory = "prioritize"
+	// CategoryPursue covers RecommendationGood.
+	CategoryPursue RecommendationCategory = "pursue"
+	// CategoryConsider covers RecommendationConsider.
+	CategoryConsider RecommendationCategory = "consider"
+	// CategoryAvoid covers RecommendationAvoid.
+	CategoryAvoid RecommendationCategory = "avoid"
+	// CategoryUnknown is a recommendation that matches no category.
+	CategoryUnknown RecommendationCategory = ""
+)
+
+// RecommendationCategories lists the known categories, best first.
+var RecommendationCategories = []RecommendationCategory{
+	CategoryPrioritize, CategoryPursue, CategoryConsider, CategoryAvoid,
+}
+
+// recommendationEmoji maps the emoji that lead the built-in recommendations
+// to their category.
+var recommendationEmoji = []struct {
+	prefix   string
+	category RecommendationCategory
+}{
+	{"\U0001F525", CategoryPrioritize}, // 🔥
+	{"\u2705", CategoryPursue},         // ✅
+	{"\u26A0", CategoryConsider},       // ⚠️, with or without the variation selector
+	{"\U0001F6AB", CategoryAvoid},      // 🚫
+}
+
+// recommendationWords maps the words recommendations are written with, by
+// this tool, LLMs and custom providers, to their category.
+var recommendationWords = map[string]RecommendationCategory{
+	"PRIORITIZE": CategoryPrioritize,
+	"PRIORITY":   CategoryPrioritize,
+	"GOOD":       CategoryPursue,
+	"PURSUE":     CategoryPursue,
+	"CONSIDER":   CategoryConsider,
+	"REVIEW":     CategoryConsider,
+	"DEFER":      CategoryConsider,
+	"AVOID":      CategoryAvoid,
+	"REJECT":     CategoryAvoid,
+}
+
+// ParseRecommendation returns the category of a stored recommendation, such
+// as "🔥 PRIORITIZE NOW", "GOOD ALIGNMENT", "pursue" or "consider_later".
+// A leading emoji decides; otherwise the first word with a known meaning
+// does, ignoring case. Anything else is CategoryUnknown.
+func ParseRecommendation(s string) RecommendationCategory {
+	s = strings.TrimSpace(s)
+	for _, e := range recommendationEmoji {
+		if strings.HasPrefix(s, e.prefix) {
+			return e.category
+		}
+	}
+
+	words := strings.FieldsFunc(strings.ToUpper(s), func(r rune) bool {
+		return r == ' ' || r == '_' || r == '-'
+	})
+	for _, word := range words {
+		if category, ok := recommendationWords[word]; ok {
+			return category
+		}
+	}
+	return CategoryUnknown
+}
+
+// RecommendationThresholds are the lowest final scores that earn each
+// recommendation; anything below Consider is RecommendationAvoid.
+type RecommendationThresholds struct {
+	Prioritize float64 `json:"prioritize"`
+	Good       float64 `json:"good"`
+	Consider   float64 `json:"consider"`
+}
+
+// DefaultRecommendationThresholds returns the standard cutoffs: 8.5, 7.0 and 5.0.
+func DefaultRecommendationThresholds() RecommendationThresholds {
+	return RecommendationThresholds{Prioritize: 8.5, Good: 7.0, Consider: 5.0}
+}
+
+// Recommend returns the recommendation for a final score. A score exactly on
+// a cutoff earns the higher recommendation.
+func (t RecommendationThresholds) Recommend(score float64) Recommendation {
+	switch {
+	case score >= t.Prioritize:
+		return RecommendationPriority
+	case score >= t.Good:
+		return RecommendationGood
+	case score >= t.Consider:
+		return RecommendationConsider
+	default:
+		return RecommendationAvoid
+	}
+}
+
+// Validate checks that the cutoffs lie within 0-10 and ascend from Consider to Prioritize.
+func (t RecommendationThresholds) Validate() error {
+	if t.Consider < 0 || t.Prioritize > 10 {
+		return errors.New("cutoffs must be between 0 and 10")
+	}
+	if t.Consider > t.Good || t.Good > t.Prioritize {
+		return errors.New("cutoffs must ascend: consider <= good <= prioritize")
+	}
+	return nil
+}
+
+var (
+	thresholdsMu      sync.RWMutex
+	currentThresholds = DefaultRecommendationThresholds()
+)
+
+// SetRecommendationThresholds replaces the thresholds GetRecommendation and
+// the LLM providers use. The CLI and web server set them from the config at
+// startup; invalid thresholds are rejected and the current ones kept.
+func SetRecommendationThresholds(t RecommendationThresholds) error {
+	if err := t.Validate(); err != nil {
+		return err
+	}
+	thresholdsMu.Lock()
+	defer thresholdsMu.Unlock()
+	currentThresholds = t
+	return nil
+}
+
+// CurrentRecommendationThresholds returns the thresholds in use.
+func CurrentRecommendationThresholds() RecommendationThresholds {
+	thresholdsMu.RLock()
+	defer thresholdsMu.RUnlock()
+	return currentThresholds
+}
@@ -0,0 +1,41 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Kinds of bulk job
+const (
+	BulkJobAnalyze   = "analyze"   // Recorded by `tm bulk analyze`
+	BulkJobReanalyze = "reanalyze" // Background re-analysis after the telos changes
+)
+
+// BulkJob is a bulk operation whose progress is recorded idea by idea, so an
+// interrupted run can be resumed without redoing finished work.
+type BulkJob struct {
+	ID          string     `json:"id" db:"id"`
+	Kind        string     `json:"kind" db:"kind"`
+	Params      string     `json:"params" db:"params"` // JSON-encoded options the job was started with
+	CreatedAt   time.Time  `json:"created_at" db:"created_at"`
+	CompletedAt *time.Time `json:"completed_at,omitempty" db:"completed_at"`
+	Total       int        `json:"total"`   // Ideas in the job
+	Done        int        `json:"done"`    // Ideas already processed, including skipped ones
+	Skipped     int        `json:"skipped"` // Ideas given up on after failing every attempt
+}
+
+// NewBulkJob creates a job of the given kind with a generated ID
+func NewBulkJob(kind, params string) *BulkJob {
+	return &BulkJob{
+		ID:        uuid.New().String(),
+		Kind:      kind,
+		Params:    params,
+		CreatedAt: time.Now().UTC(),
+	}
+}
+
+// IsComplete reports whether every idea in the job has been processed
+func (j *BulkJob) IsComplete() bool {
+	return j.CompletedAt != nil
+}
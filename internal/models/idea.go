@@ -1,8 +1,14 @@
 package models
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/google/uuid"
 )
@@ -19,22 +25,51 @@ type Idea struct {
 	Recommendation  string     `json:"recommendation,omitempty" db:"recommendation"`
 	AnalysisDetails string     `json:"analysis_details,omitempty" db:"analysis_details"`
 	CreatedAt       time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at" db:"updated_at"` // Set by the repository on every write, including the insert
 	ReviewedAt      *time.Time `json:"reviewed_at,omitempty" db:"reviewed_at"`
 	Status          string     `json:"status" db:"status"`
-	Title           string     `json:"title,omitempty"`    // For compatibility
-	Analysis        *Analysis  `json:"analysis,omitempty"` // Full analysis object (not stored in DB)
+	Trigger         string     `json:"trigger,omitempty" db:"trigger_context"`       // What prompted the idea ("why now")
+	ArchiveReason   string     `json:"archive_reason,omitempty" db:"archive_reason"` // Why the idea was archived
+	Profile         string     `json:"profile,omitempty" db:"profile"`               // Telos profile the idea was scored against
+	TelosVersion    string     `json:"telos_version,omitempty" db:"telos_version"`   // Version of the telos the idea was scored against
+	Notes           string     `json:"notes,omitempty" db:"notes"`                   // The user's own commentary, kept apart from the analysis
+	Version         int        `json:"version,omitempty" db:"version"`               // Incremented on every update; guards against concurrent edits
+	Title           string     `json:"title,omitempty"`                              // For compatibility
+	Analysis        *Analysis  `json:"analysis,omitempty"`                           // Full analysis object (not stored in DB)
 }
 
+// DefaultProfile is the telos profile used when no other profile is selected.
+const DefaultProfile = "default"
+
+// MaxNotesLength is the longest notes an idea can hold, in characters.
+const MaxNotesLength = 10000
+
+// LegacyTelosVersion marks ideas scored before telos versions were recorded.
+// Such ideas never match the current version, so they are treated as stale.
+const LegacyTelosVersion = "unknown/legacy"
+
 // NewIdea creates a new Idea with generated ID and current timestamp.
 func NewIdea(content string) *Idea {
+	now := time.Now().UTC()
 	return &Idea{
 		ID:        uuid.New().String(),
 		Content:   content,
 		Status:    "active",
-		CreatedAt: time.Now().UTC(),
+		Profile:   DefaultProfile,
+		CreatedAt: now,
+		UpdatedAt: now,
 	}
 }
 
+// ContentHash returns a stable hash of idea content used for duplicate detection.
+// Content is lowercased and whitespace is collapsed before hashing, so ideas that
+// differ only in case or spacing produce the same hash.
+func ContentHash(content string) string {
+	normalized := strings.Join(strings.Fields(strings.ToLower(content)), " ")
+	sum := sha256.Sum256([]byte(normalized))
+	return hex.EncodeToString(sum[:])
+}
+
 // Validate validates the idea.
 func (i *Idea) Validate() error {
 	// Validate title if present (used in some contexts)
@@ -52,6 +87,18 @@ func (i *Idea) Validate() error {
 		return errors.New("title or content is required")
 	}
 
+	if len(i.Trigger) > 200 {
+		return errors.New("trigger must be at most 200 characters")
+	}
+
+	if len(i.ArchiveReason) > 200 {
+		return errors.New("archive reason must be at most 200 characters")
+	}
+
+	if utf8.RuneCountInString(i.Notes) > MaxNotesLength {
+		return fmt.Errorf("notes must be at most %d characters", MaxNotesLength)
+	}
+
 	// Validate status
 	validStatuses := map[string]bool{
 		"active":   true,
@@ -66,6 +113,91 @@ func (i *Idea) Validate() error {
 	return nil
 }
 
+// Archive marks the idea as archived and records why.
+func (i *Idea) Archive(reason string) {
+	i.Status = string(StatusArchived)
+	i.ArchiveReason = strings.TrimSpace(reason)
+}
+
+// Trash soft-deletes the idea. It stays recoverable until the trash is emptied.
+func (i *Idea) Trash() {
+	i.Status = string(StatusDeleted)
+}
+
+// Restore makes an archived or deleted idea active again.
+func (i *Idea) Restore() {
+	i.Status = string(StatusActive)
+	i.ArchiveReason = ""
+}
+
+// AddNote appends note to the idea's notes on a line of its own, or sets it
+// when the idea has no notes yet.
+func (i *Idea) AddNote(note string) {
+	note = strings.TrimSpace(note)
+	if note == "" {
+		return
+	}
+	if i.Notes == "" {
+		i.Notes = note
+		return
+	}
+	i.Notes += "\n" + note
+}
+
+// ParsedAnalysis decodes AnalysisDetails as a serialized Analysis. It reports
+// false, rather than failing, for empty details, for the plain text older
+// versions saved and for JSON in any other shape, such as the explanations
+// bulk analyze saves for LLM results.
+func (i *Idea) ParsedAnalysis() (*Analysis, bool) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(i.AnalysisDetails), &fields); err != nil || fields["mission"] == nil {
+		return nil, false
+	}
+	var analysis Analysis
+	if err := json.Unmarshal([]byte(i.AnalysisDetails), &analysis); err != nil {
+		return nil, false
+	}
+	return &analysis, true
+}
+
+// AnalysisText returns plain-text analysis details, whether stored as the
+// bare text older versions saved or wrapped by WrapAnalysisText. It reports
+// false for empty details and for any other JSON object.
+func (i *Idea) AnalysisText() (string, bool) {
+	details := strings.TrimSpace(i.AnalysisDetails)
+	if details == "" {
+		return "", false
+	}
+	var wrapped map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(details), &wrapped); err != nil {
+		return details, true
+	}
+	if len(wrapped) != 1 || wrapped["text"] == nil {
+		return "", false
+	}
+	var text string
+	if err := json.Unmarshal(wrapped["text"], &text); err != nil {
+		return "", false
+	}
+	return strings.TrimSpace(text), true
+}
+
+// HasPlainTextAnalysis reports whether AnalysisDetails holds text rather
+// than a JSON object, as older versions saved it. WrapAnalysisText repairs
+// such details.
+func (i *Idea) HasPlainTextAnalysis() bool {
+	details := strings.TrimSpace(i.AnalysisDetails)
+	var fields map[string]json.RawMessage
+	return details != "" && json.Unmarshal([]byte(details), &fields) != nil
+}
+
+// WrapAnalysisText returns plain-text analysis details as the minimal JSON
+// object {"text": "..."}, so every stored analysis decodes as JSON.
+func WrapAnalysisText(text string) string {
+	wrapped, _ := json.Marshal(map[string]string{"text": strings.TrimSpace(text)})
+	return string(wrapped)
+}
+
 // IdeaStatus represents the status of an idea.
 type IdeaStatus string
 
@@ -0,0 +1,12 @@
+package models
+
+import "time"
+
+// IdeaMove records an idea being transferred from one telos profile to another
+type IdeaMove struct {
+	IdeaID      string    `json:"idea_id" db:"idea_id"`
+	FromProfile string    `json:"from_profile" db:"from_profile"`
+	ToProfile   string    `json:"to_profile" db:"to_profile"`
+	Reanalyzed  bool      `json:"reanalyzed" db:"reanalyzed"` // Whether the idea was re-scored against ToProfile
+	MovedAt     time.Time `json:"moved_at" db:"moved_at"`
+}
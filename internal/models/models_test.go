@@ -127,6 +127,14 @@ func TestIdea_NewIdea_GeneratesValidID(t *testing.T) {
 	assert.NotZero(t, idea.CreatedAt)
 }
 
+func TestContentHash_NormalizesCaseAndWhitespace(t *testing.T) {
+	base := models.ContentHash("Build a SaaS product")
+
+	assert.Len(t, base, 64)
+	assert.Equal(t, base, models.ContentHash("  build   a\tSAAS product\n"))
+	assert.NotEqual(t, base, models.ContentHash("Build a SaaS platform"))
+}
+
 func TestIdeaStatus_String_ReturnsCorrectValue(t *testing.T) {
 	testCases := []struct {
 		status   models.IdeaStatus
@@ -142,6 +150,89 @@ func TestIdeaStatus_String_ReturnsCorrectValue(t *testing.T) {
 	}
 }
 
+func TestIdea_AddNote(t *testing.T) {
+	idea := models.NewIdea("Weekly Go newsletter")
+
+	idea.AddNote("  started prototyping 2025-06\n")
+	assert.Equal(t, "started prototyping 2025-06", idea.Notes)
+
+	idea.AddNote("   ")
+	assert.Equal(t, "started prototyping 2025-06", idea.Notes, "blank notes are ignored")
+
+	idea.AddNote("demoed to two users")
+	assert.Equal(t, "started prototyping 2025-06\ndemoed to two users", idea.Notes)
+}
+
+func TestIdea_Validate_NotesTooLong_ReturnsError(t *testing.T) {
+	idea := models.NewIdea("Weekly Go newsletter")
+	idea.Notes = strings.Repeat("x", models.MaxNotesLength)
+	require.NoError(t, idea.Validate())
+
+	idea.Notes += "x"
+	err := idea.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "notes must be at most")
+
+	// The limit counts characters, not bytes
+	idea.Notes = strings.Repeat("é", models.MaxNotesLength)
+	assert.NoError(t, idea.Validate())
+}
+
+func TestIdea_ParsedAnalysis(t *testing.T) {
+	analysis := &models.Analysis{
+		FinalScore: 7.5,
+		Mission:    models.MissionScores{Total: 3.0},
+	}
+	details, err := json.Marshal(analysis)
+	require.NoError(t, err)
+
+	got, ok := (&models.Idea{AnalysisDetails: string(details)}).ParsedAnalysis()
+	require.True(t, ok)
+	assert.Equal(t, 7.5, got.FinalScore)
+	assert.Equal(t, 3.0, got.Mission.Total)
+
+	for _, details := range []string{
+		"",
+		"GOOD ALIGNMENT",
+		`{"mission": `,
+		`{"provider":"ollama","scores":{"mission_alignment":3.2}}`,
+		models.WrapAnalysisText("Worth doing"),
+	} {
+		_, ok := (&models.Idea{AnalysisDetails: details}).ParsedAnalysis()
+		assert.False(t, ok, "ParsedAnalysis(%q)", details)
+	}
+}
+
+func TestIdea_AnalysisText(t *testing.T) {
+	tests := []struct {
+		details string
+		text    string
+		ok      bool
+	}{
+		{details: "  GOOD ALIGNMENT\n", text: "GOOD ALIGNMENT", ok: true},
+		{details: `{"mission": `, text: `{"mission":`, ok: true},
+		{details: models.WrapAnalysisText("Worth doing"), text: "Worth doing", ok: true},
+		{details: "", ok: false},
+		{details: `{"text":"Worth doing","provider":"ollama"}`, ok: false},
+		{details: `{"mission":{"total":3}}`, ok: false},
+	}
+
+	for _, tt := range tests {
+		idea := &models.Idea{AnalysisDetails: tt.details}
+		text, ok := idea.AnalysisText()
+		assert.Equal(t, tt.ok, ok, "AnalysisText(%q)", tt.details)
+		assert.Equal(t, tt.text, text, "AnalysisText(%q)", tt.details)
+	}
+}
+
+func TestIdea_HasPlainTextAnalysis(t *testing.T) {
+	assert.True(t, (&models.Idea{AnalysisDetails: "GOOD ALIGNMENT"}).HasPlainTextAnalysis())
+	assert.True(t, (&models.Idea{AnalysisDetails: "42"}).HasPlainTextAnalysis(), "JSON that isn't an object is text")
+	assert.False(t, (&models.Idea{}).HasPlainTextAnalysis())
+	assert.False(t, (&models.Idea{AnalysisDetails: models.WrapAnalysisText("GOOD ALIGNMENT")}).HasPlainTextAnalysis())
+	assert.JSONEq(t, `{"text":"say \"hi\""}`, models.WrapAnalysisText(" say \"hi\"\n"))
+}
+
 // ============================================================================
 // TELOS TESTS
 // ============================================================================
@@ -214,6 +305,77 @@ func TestTelos_Validate_InvalidPattern_ReturnsError(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestValidateTelos_ValidTelos_ReturnsNoIssues(t *testing.T) {
+	telos := &models.Telos{
+		Missions:   []models.Mission{{ID: "M1", Description: "Help indie developers ship"}},
+		Goals:      []models.Goal{{ID: "G1", Description: "Launch a Go CLI", Priority: 1}},
+		Challenges: []models.Challenge{{ID: "C1", Description: "Limited evenings"}},
+		Strategies: []models.Strategy{{ID: "S1", Description: "Reuse the TypeScript dashboard"}},
+		Stack:      models.Stack{Primary: []string{"Go"}, Secondary: []string{"TypeScript"}},
+	}
+
+	issues := models.ValidateTelos(telos)
+	assert.Empty(t, issues)
+	assert.False(t, models.HasValidationErrors(issues))
+}
+
+func TestValidateTelos_ReportsEveryProblem(t *testing.T) {
+	telos := &models.Telos{
+		Goals: []models.Goal{
+			{ID: "G1", Description: "Launch a Go CLI"},
+			{ID: "G1", Description: "Write a book"},
+			{ID: "G2", Description: "  "},
+		},
+		Strategies:      []models.Strategy{{ID: "", Description: "Ship early"}},
+		FailurePatterns: []models.Pattern{{Name: "Perfectionism", Description: "Polishing forever"}},
+		Stack:           models.Stack{Primary: []string{"Go", "Rust"}, Secondary: []string{"go"}},
+	}
+
+	issues := models.ValidateTelos(telos)
+	require.True(t, models.HasValidationErrors(issues))
+
+	assert.Contains(t, issues, models.ValidationIssue{
+		Severity: models.SeverityError, Section: "Goals", ID: "G1", Message: "G1 is defined more than once",
+	})
+	assert.Contains(t, issues, models.ValidationIssue{
+		Severity: models.SeverityError, Section: "Goals", ID: "G2", Message: "G2 has an empty description",
+	})
+	assert.Contains(t, issues, models.ValidationIssue{
+		Severity: models.SeverityError, Section: "Strategies", Message: "item 1 has no ID",
+	})
+	assert.Contains(t, issues, models.ValidationIssue{
+		Severity: models.SeverityWarning, Section: "Missions", Message: "no missions defined",
+	})
+	assert.Contains(t, issues, models.ValidationIssue{
+		Severity: models.SeverityWarning, Section: "Challenges", Message: "no challenges defined",
+	})
+	assert.Contains(t, issues, models.ValidationIssue{
+		Severity: models.SeverityWarning, Section: "Stack", ID: "Rust",
+		Message: "Rust isn't mentioned by any goal, mission, problem, challenge or strategy",
+	})
+	assert.Contains(t, issues, models.ValidationIssue{
+		Severity: models.SeverityWarning, Section: "Stack", ID: "go", Message: "go is listed more than once",
+	})
+	assert.Len(t, issues, 7)
+}
+
+func TestValidateTelos_NoGoals_IsAnError(t *testing.T) {
+	issues := models.ValidateTelos(&models.Telos{})
+
+	assert.Contains(t, issues, models.ValidationIssue{
+		Severity: models.SeverityError, Section: "Goals", Message: "no goals defined",
+	})
+	assert.True(t, models.HasValidationErrors(issues))
+}
+
+func TestHasValidationErrors_WarningsOnly_ReturnsFalse(t *testing.T) {
+	issues := []models.ValidationIssue{
+		{Severity: models.SeverityWarning, Section: "Missions", Message: "no missions defined"},
+	}
+
+	assert.False(t, models.HasValidationErrors(issues))
+}
+
 func TestTelos_JSONSerialization_RoundTrip(t *testing.T) {
 	deadline := time.Date(2025, 12, 31, 0, 0, 0, 0, time.UTC)
 	original := &models.Telos{
@@ -582,3 +744,96 @@ func TestRecommendation_String_ReturnsCorrectValue(t *testing.T) {
 		assert.Equal(t, tc.expected, tc.rec.String())
 	}
 }
+
+// ============================================================================
+// RECOMMENDATION TESTS
+// ============================================================================
+
+func TestRecommendationThresholds_Recommend_DefaultMapping(t *testing.T) {
+	thresholds := models.DefaultRecommendationThresholds()
+
+	tests := []struct {
+		score float64
+		want  models.Recommendation
+	}{
+		{10.0, models.RecommendationPriority},
+		{8.5, models.RecommendationPriority},
+		{8.49, models.RecommendationGood},
+		{7.0, models.RecommendationGood},
+		{6.99, models.RecommendationConsider},
+		{5.0, models.RecommendationConsider},
+		{4.99, models.RecommendationAvoid},
+		{0.0, models.RecommendationAvoid},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, thresholds.Recommend(tt.score), "score %.2f", tt.score)
+	}
+}
+
+func TestRecommendation_Label_DropsEmoji(t *testing.T) {
+	assert.Equal(t, "PRIORITIZE NOW", models.RecommendationPriority.Label())
+	assert.Equal(t, "GOOD ALIGNMENT", models.RecommendationGood.Label())
+	assert.Equal(t, "CONSIDER LATER", models.RecommendationConsider.Label())
+	assert.Equal(t, "AVOID FOR NOW", models.RecommendationAvoid.Label())
+}
+
+func TestParseRecommendation(t *testing.T) {
+	tests := []struct {
+		input string
+		want  models.RecommendationCategory
+	}{
+		{models.RecommendationPriority.String(), models.CategoryPrioritize},
+		{models.RecommendationGood.String(), models.CategoryPursue},
+		{models.RecommendationConsider.String(), models.CategoryConsider},
+		{models.RecommendationAvoid.String(), models.CategoryAvoid},
+		{"\u26A0 CONSIDER LATER", models.CategoryConsider}, // Without the variation selector
+		{"GOOD ALIGNMENT", models.CategoryPursue},
+		{"good_alignment", models.CategoryPursue},
+		{"Avoid for now", models.CategoryAvoid},
+		{"pursue", models.CategoryPursue},
+		{"review", models.CategoryConsider},
+		{"defer", models.CategoryConsider},
+		{"reject", models.CategoryAvoid},
+		{"high-priority", models.CategoryPrioritize},
+		{"", models.CategoryUnknown},
+		{"maybe someday", models.CategoryUnknown},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, models.ParseRecommendation(tt.input), "input %q", tt.input)
+	}
+}
+
+func TestRecommendation_Category_CoversEveryRecommendation(t *testing.T) {
+	recommendations := []models.Recommendation{
+		models.RecommendationPriority, models.RecommendationGood,
+		models.RecommendationConsider, models.RecommendationAvoid,
+	}
+	for i, r := range recommendations {
+		assert.Equal(t, models.RecommendationCategories[i], r.Category(), "recommendation %q", r)
+	}
+}
+
+func TestRecommendationThresholds_Validate(t *testing.T) {
+	assert.NoError(t, models.DefaultRecommendationThresholds().Validate())
+	assert.NoError(t, models.RecommendationThresholds{Prioritize: 7, Good: 7, Consider: 7}.Validate())
+	assert.Error(t, models.RecommendationThresholds{Prioritize: 8, Good: 9, Consider: 5}.Validate())
+	assert.Error(t, models.RecommendationThresholds{Prioritize: 8, Good: 7, Consider: -1}.Validate())
+	assert.Error(t, models.RecommendationThresholds{Prioritize: 11, Good: 7, Consider: 5}.Validate())
+}
+
+func TestSetRecommendationThresholds_AppliesToGetRecommendation(t *testing.T) {
+	t.Cleanup(func() {
+		require.NoError(t, models.SetRecommendationThresholds(models.DefaultRecommendationThresholds()))
+	})
+	analysis := &models.Analysis{FinalScore: 7.0}
+	assert.Equal(t, models.RecommendationGood.String(), analysis.GetRecommendation())
+
+	require.NoError(t, models.SetRecommendationThresholds(models.RecommendationThresholds{Prioritize: 9, Good: 7.5, Consider: 6}))
+	assert.Equal(t, models.RecommendationConsider.String(), analysis.GetRecommendation())
+
+	// Invalid thresholds leave the current ones in place
+	require.Error(t, models.SetRecommendationThresholds(models.RecommendationThresholds{Prioritize: 5, Good: 7, Consider: 6}))
+	assert.Equal(t, 7.5, models.CurrentRecommendationThresholds().Good)
+}
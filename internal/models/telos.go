@@ -2,6 +2,8 @@ package models
 
 import (
 	"errors"
+	"fmt"
+	"strings"
 	"time"
 )
 
@@ -180,3 +182,143 @@ func (p *Pattern) Validate() error {
 	}
 	return nil
 }
+
+// ValidationSeverity is how serious a telos validation issue is
+type ValidationSeverity string
+
+// Validation severities. Errors make scoring unreliable; warnings are worth
+// fixing but the telos is still usable.
+const (
+	SeverityError   ValidationSeverity = "error"
+	SeverityWarning ValidationSeverity = "warning"
+)
+
+// ValidationIssue is a structural problem found by ValidateTelos
+type ValidationIssue struct {
+	Severity ValidationSeverity `json:"severity"`
+	Section  string             `json:"section"`      // telos.md section, e.g. "Goals"
+	ID       string             `json:"id,omitempty"` // item the issue is about, if any
+	Message  string             `json:"message"`
+}
+
+// telosItem is the part of a telos entry ValidateTelos checks
+type telosItem struct {
+	id          string
+	description string
+}
+
+// ValidateTelos reports every structural problem in t, unlike Validate, which
+// stops at the first. Missing goals, items without an ID or description and
+// duplicate IDs are errors. Missing missions, challenges or strategies, and
+// stack items no goal, mission, problem, challenge or strategy mentions, are
+// warnings.
+func ValidateTelos(t *Telos) []ValidationIssue {
+	var issues []ValidationIssue
+	addIssue := func(severity ValidationSeverity, section, id, format string, args ...any) {
+		issues = append(issues, ValidationIssue{
+			Severity: severity,
+			Section:  section,
+			ID:       id,
+			Message:  fmt.Sprintf(format, args...),
+		})
+	}
+
+	var problems, missions, goals, challenges, strategies, patterns []telosItem
+	for _, p := range t.Problems {
+		problems = append(problems, telosItem{p.ID, p.Description})
+	}
+	for _, m := range t.Missions {
+		missions = append(missions, telosItem{m.ID, m.Description})
+	}
+	for _, g := range t.Goals {
+		goals = append(goals, telosItem{g.ID, g.Description})
+	}
+	for _, c := range t.Challenges {
+		challenges = append(challenges, telosItem{c.ID, c.Description})
+	}
+	for _, s := range t.Strategies {
+		strategies = append(strategies, telosItem{s.ID, s.Description})
+	}
+	for _, p := range t.FailurePatterns {
+		patterns = append(patterns, telosItem{p.Name, p.Description})
+	}
+
+	sections := []struct {
+		name     string
+		items    []telosItem
+		required ValidationSeverity // severity when the section is empty; "" if optional
+	}{
+		{"Problems", problems, ""},
+		{"Missions", missions, SeverityWarning},
+		{"Goals", goals, SeverityError},
+		{"Challenges", challenges, SeverityWarning},
+		{"Strategies", strategies, SeverityWarning},
+		{"Failure Patterns", patterns, ""},
+	}
+
+	for _, section := range sections {
+		if len(section.items) == 0 {
+			if section.required != "" {
+				addIssue(section.required, section.name, "", "no %s defined", strings.ToLower(section.name))
+			}
+			continue
+		}
+
+		seen := make(map[string]bool, len(section.items))
+		for i, item := range section.items {
+			if item.id == "" {
+				addIssue(SeverityError, section.name, "", "item %d has no ID", i+1)
+			} else if seen[item.id] {
+				addIssue(SeverityError, section.name, item.id, "%s is defined more than once", item.id)
+			}
+			seen[item.id] = true
+
+			if strings.TrimSpace(item.description) == "" {
+				addIssue(SeverityError, section.name, item.id, "%s has an empty description", displayID(item.id, i))
+			}
+		}
+	}
+
+	// Stack items nothing refers to suggest a stale or mistyped stack
+	var text strings.Builder
+	for _, items := range [][]telosItem{problems, missions, goals, challenges, strategies} {
+		for _, item := range items {
+			text.WriteString(strings.ToLower(item.description))
+			text.WriteString("\n")
+		}
+	}
+	referenced := text.String()
+	listed := make(map[string]bool)
+	for _, tech := range append(append([]string(nil), t.Stack.Primary...), t.Stack.Secondary...) {
+		key := strings.ToLower(strings.TrimSpace(tech))
+		switch {
+		case key == "":
+			addIssue(SeverityWarning, "Stack", "", "empty stack item")
+		case listed[key]:
+			addIssue(SeverityWarning, "Stack", tech, "%s is listed more than once", tech)
+		case !strings.Contains(referenced, key):
+			addIssue(SeverityWarning, "Stack", tech, "%s isn't mentioned by any goal, mission, problem, challenge or strategy", tech)
+		}
+		listed[key] = true
+	}
+
+	return issues
+}
+
+// HasValidationErrors reports whether any issue is an error rather than a warning
+func HasValidationErrors(issues []ValidationIssue) bool {
+	for _, issue := range issues {
+		if issue.Severity == SeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+// displayID names an item by its ID, or by position when it has none
+func displayID(id string, index int) string {
+	if id != "" {
+		return id
+	}
+	return fmt.Sprintf("item %d", index+1)
+}
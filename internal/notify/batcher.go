@@ -0,0 +1,159 @@
+package notify
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/ryacub/telos-idea-matrix/internal/config"
+	"github.com/ryacub/telos-idea-matrix/internal/models"
+)
+
+// Options configures a Batcher
+type Options struct {
+	MinScore float64       // Ideas scoring below this are ignored
+	Window   time.Duration // How long to collect ideas after the first one; 0 sends immediately
+	MaxBatch int           // Send early once this many ideas are waiting; 0 means no limit
+}
+
+// Batcher coalesces qualifying ideas into digests. The first idea opens a
+// window; every idea that arrives before it closes goes into the same digest.
+// A nil *Batcher is valid and drops everything, so callers need not check
+// whether notifications are configured.
+type Batcher struct {
+	sender Sender
+	opts   Options
+
+	mu         sync.Mutex
+	pending    []IdeaSummary
+	timer      *time.Timer
+	generation int // Incremented on every flush so a stale timer can't flush a newer batch
+	closed     bool
+	lastSend   chan struct{} // Closed when the most recent digest has been sent
+
+	sends       sync.WaitGroup
+	sendCtx     context.Context // Cancelled to abandon sends still in flight at CloseContext's deadline
+	cancelSends context.CancelFunc
+}
+
+// NewBatcher creates a batcher that delivers digests through sender
+func NewBatcher(sender Sender, opts Options) *Batcher {
+	sendCtx, cancelSends := context.WithCancel(context.Background())
+	return &Batcher{sender: sender, opts: opts, sendCtx: sendCtx, cancelSends: cancelSends}
+}
+
+// FromConfig creates a webhook batcher, or returns nil when no webhook URL is configured
+func FromConfig(cfg config.NotifyConfig) *Batcher {
+	if cfg.WebhookURL == "" {
+		return nil
+	}
+	sender := NewWebhookSender(cfg.WebhookURL, WebhookOptions{
+		Secret:  cfg.Secret,
+		Timeout: cfg.Timeout,
+		Retries: cfg.Retries,
+	})
+	return NewBatcher(sender, Options{
+		MinScore: cfg.MinScore,
+		Window:   cfg.BatchWindow,
+		MaxBatch: cfg.MaxBatch,
+	})
+}
+
+// Notify queues idea for the next digest if its score qualifies
+func (b *Batcher) Notify(idea *models.Idea) {
+	if b == nil || idea == nil || idea.FinalScore < b.opts.MinScore {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		return
+	}
+
+	b.pending = append(b.pending, summarize(idea))
+
+	if b.opts.Window <= 0 || (b.opts.MaxBatch > 0 && len(b.pending) >= b.opts.MaxBatch) {
+		b.flushLocked()
+		return
+	}
+
+	if b.timer == nil {
+		generation := b.generation
+		b.timer = time.AfterFunc(b.opts.Window, func() {
+			b.mu.Lock()
+			defer b.mu.Unlock()
+			if b.generation == generation {
+				b.flushLocked()
+			}
+		})
+	}
+}
+
+// Close sends any pending digest and waits for in-flight sends to finish
+func (b *Batcher) Close() {
+	b.CloseContext(context.Background())
+}
+
+// CloseContext is Close bound to ctx: once ctx is done, sends still in
+// flight, retries included, are abandoned and their digests dropped
+func (b *Batcher) CloseContext(ctx context.Context) {
+	if b == nil {
+		return
+	}
+
+	b.mu.Lock()
+	if !b.closed {
+		b.closed = true
+		b.flushLocked()
+	}
+	b.mu.Unlock()
+
+	sent := make(chan struct{})
+	go func() {
+		b.sends.Wait()
+		close(sent)
+	}()
+
+	select {
+	case <-sent:
+	case <-ctx.Done():
+		b.cancelSends()
+		<-sent
+	}
+	b.cancelSends()
+}
+
+// flushLocked sends the pending ideas as one digest. Callers must hold b.mu.
+func (b *Batcher) flushLocked() {
+	if b.timer != nil {
+		b.timer.Stop()
+		b.timer = nil
+	}
+	b.generation++
+
+	if len(b.pending) == 0 {
+		return
+	}
+	digest := Digest{Ideas: b.pending}
+	b.pending = nil
+
+	// Send in the background without blocking callers, one digest at a time and in order
+	prev := b.lastSend
+	done := make(chan struct{})
+	b.lastSend = done
+
+	b.sends.Add(1)
+	go func() {
+		defer b.sends.Done()
+		defer close(done)
+		if prev != nil {
+			<-prev
+		}
+		if err := b.sender.Send(b.sendCtx, digest); err != nil {
+			log.Warn().Err(err).Int("ideas", len(digest.Ideas)).Msg("failed to send idea notification")
+		}
+	}()
+}
@@ -0,0 +1,214 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ryacub/telos-idea-matrix/internal/config"
+	"github.com/ryacub/telos-idea-matrix/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingSender records every digest it is asked to send
+type recordingSender struct {
+	mu      sync.Mutex
+	digests []Digest
+}
+
+func (s *recordingSender) Send(_ context.Context, digest Digest) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.digests = append(s.digests, digest)
+	return nil
+}
+
+func (s *recordingSender) sent() []Digest {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Digest(nil), s.digests...)
+}
+
+func scoredIdea(id string, score float64) *models.Idea {
+	return &models.Idea{ID: id, Content: "Idea " + id, FinalScore: score}
+}
+
+func digestIDs(d Digest) []string {
+	ids := make([]string, len(d.Ideas))
+	for i, idea := range d.Ideas {
+		ids[i] = idea.ID
+	}
+	return ids
+}
+
+func TestBatcher_CoalescesIdeasWithinWindow(t *testing.T) {
+	sender := &recordingSender{}
+	b := NewBatcher(sender, Options{MinScore: 7, Window: 50 * time.Millisecond, MaxBatch: 10})
+
+	b.Notify(scoredIdea("1", 8.0))
+	b.Notify(scoredIdea("2", 3.0)) // below the threshold
+	b.Notify(scoredIdea("3", 9.5))
+	b.Notify(scoredIdea("4", 7.0))
+
+	require.Eventually(t, func() bool { return len(sender.sent()) > 0 }, time.Second, 5*time.Millisecond)
+	time.Sleep(100 * time.Millisecond) // nothing else should arrive
+
+	digests := sender.sent()
+	require.Len(t, digests, 1)
+	assert.Equal(t, []string{"1", "3", "4"}, digestIDs(digests[0]))
+}
+
+func TestBatcher_FlushesAtMaxBatch(t *testing.T) {
+	sender := &recordingSender{}
+	b := NewBatcher(sender, Options{Window: time.Hour, MaxBatch: 2})
+
+	for _, id := range []string{"1", "2", "3"} {
+		b.Notify(scoredIdea(id, 8.0))
+	}
+	b.Close()
+
+	digests := sender.sent()
+	require.Len(t, digests, 2)
+	assert.Equal(t, []string{"1", "2"}, digestIDs(digests[0]))
+	assert.Equal(t, []string{"3"}, digestIDs(digests[1]))
+
+	// Ideas after Close are dropped
+	b.Notify(scoredIdea("4", 8.0))
+	assert.Len(t, sender.sent(), 2)
+}
+
+func TestBatcher_CloseContextAbandonsUnreachableWebhook(t *testing.T) {
+	// A server that is already gone refuses connections, a retryable error
+	server := httptest.NewServer(http.NotFoundHandler())
+	server.Close()
+
+	b := FromConfig(config.NotifyConfig{WebhookURL: server.URL, MinScore: 7, Retries: 3})
+	b.Notify(scoredIdea("1", 8.0))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	b.CloseContext(ctx)
+	assert.Less(t, time.Since(start), time.Second, "Close should not wait out the retry backoff")
+}
+
+func TestBatcher_NilIsNoop(t *testing.T) {
+	var b *Batcher
+	b.Notify(scoredIdea("1", 10))
+	b.Close()
+	assert.Nil(t, FromConfig(config.NotifyConfig{MinScore: 7}))
+}
+
+func TestWebhookSender_PostsDigest(t *testing.T) {
+	var body struct {
+		Text  string        `json:"text"`
+		Count int           `json:"count"`
+		Ideas []IdeaSummary `json:"ideas"`
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		assert.Empty(t, r.Header.Get(SignatureHeader), "unsigned without a secret")
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+	}))
+	defer server.Close()
+
+	digest := Digest{Ideas: []IdeaSummary{{ID: "1", Content: "Ship it", Score: 8.5}}}
+	require.NoError(t, NewWebhookSender(server.URL, WebhookOptions{}).Send(context.Background(), digest))
+
+	assert.Equal(t, 1, body.Count)
+	assert.Equal(t, "1 high-scoring idea captured:\n• 8.5  Ship it", body.Text)
+	assert.Equal(t, "Ship it", body.Ideas[0].Content)
+}
+
+func TestWebhookSender_SignsBody(t *testing.T) {
+	var signature string
+	var body []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		signature = r.Header.Get(SignatureHeader)
+		body, _ = io.ReadAll(r.Body)
+	}))
+	defer server.Close()
+
+	sender := NewWebhookSender(server.URL, WebhookOptions{Secret: "s3cret"})
+	require.NoError(t, sender.Send(context.Background(), Digest{Ideas: []IdeaSummary{{ID: "1"}}}))
+
+	assert.Equal(t, Sign([]byte("s3cret"), body), signature)
+	assert.Regexp(t, "^sha256=[0-9a-f]{64}$", signature)
+	assert.NotEqual(t, Sign([]byte("other"), body), signature)
+}
+
+func TestWebhookSender_RetriesTemporaryFailures(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch calls.Add(1) {
+		case 1:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		case 2:
+			w.WriteHeader(http.StatusTooManyRequests)
+		}
+	}))
+	defer server.Close()
+
+	sender := NewWebhookSender(server.URL, WebhookOptions{Retries: 2})
+	sender.backoff = time.Millisecond
+	require.NoError(t, sender.Send(context.Background(), Digest{}))
+	assert.Equal(t, int32(3), calls.Load())
+
+	calls.Store(0)
+	sender.retries = 1
+	err := sender.Send(context.Background(), Digest{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "status 429")
+	assert.Equal(t, int32(2), calls.Load(), "retries run out")
+}
+
+func TestWebhookSender_DoesNotRetryClientErrors(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	sender := NewWebhookSender(server.URL, WebhookOptions{Retries: 3})
+	sender.backoff = time.Millisecond
+	require.Error(t, sender.Send(context.Background(), Digest{}))
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func TestWebhookSender_TimesOut(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer server.Close()
+	defer close(release)
+
+	sender := NewWebhookSender(server.URL, WebhookOptions{Timeout: 20 * time.Millisecond})
+	start := time.Now()
+	require.Error(t, sender.Send(context.Background(), Digest{}))
+	assert.Less(t, time.Since(start), time.Second)
+}
+
+func TestSummarize_IncludesCategoryScores(t *testing.T) {
+	idea := scoredIdea("1", 8.2)
+	assert.Nil(t, summarize(idea).Scores, "no breakdown without an analysis")
+
+	idea.Analysis = &models.Analysis{
+		Mission:       models.MissionScores{Total: 3.5},
+		AntiChallenge: models.AntiChallengeScores{Total: 2.7},
+		Strategic:     models.StrategicScores{Total: 2},
+	}
+	assert.Equal(t, &ScoreBreakdown{Mission: 3.5, AntiChallenge: 2.7, Strategic: 2}, summarize(idea).Scores)
+
+	idea.Analysis = nil
+	idea.AnalysisDetails = `{"mission":{"total":1.5},"anti_challenge":{"total":1},"strategic":{"total":0.5}}`
+	assert.Equal(t, &ScoreBreakdown{Mission: 1.5, AntiChallenge: 1, Strategic: 0.5}, summarize(idea).Scores, "read from stored details")
+}
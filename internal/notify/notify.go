@@ -0,0 +1,198 @@
+// Package notify sends webhook notifications about high-scoring ideas.
+//
+// Notifications are coalesced by a Batcher so that a burst of qualifying
+// ideas, such as a bulk import, produces one digest instead of one message
+// per idea.
+package notify
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ryacub/telos-idea-matrix/internal/models"
+)
+
+// IdeaSummary is the part of an idea included in a notification
+type IdeaSummary struct {
+	ID             string    `json:"id"`
+	Content        string    `json:"content"`
+	Score          float64   `json:"score"`
+	Recommendation string    `json:"recommendation"`
+	CreatedAt      time.Time `json:"created_at"`
+
+	// Scores breaks the final score down by category; nil when the idea
+	// has no structured analysis
+	Scores *ScoreBreakdown `json:"scores,omitempty"`
+}
+
+// ScoreBreakdown is an idea's score in each scoring category
+type ScoreBreakdown struct {
+	Mission       float64 `json:"mission"`        // Out of 4.0
+	AntiChallenge float64 `json:"anti_challenge"` // Out of 3.5
+	Strategic     float64 `json:"strategic"`      // Out of 2.5
+}
+
+// Digest is a batch of ideas delivered as a single notification
+type Digest struct {
+	Ideas []IdeaSummary `json:"ideas"`
+}
+
+// Text renders the digest as a short human-readable message
+func (d Digest) Text() string {
+	var b strings.Builder
+	if len(d.Ideas) == 1 {
+		b.WriteString("1 high-scoring idea captured:")
+	} else {
+		fmt.Fprintf(&b, "%d high-scoring ideas captured:", len(d.Ideas))
+	}
+	for _, idea := range d.Ideas {
+		fmt.Fprintf(&b, "\n• %.1f  %s", idea.Score, idea.Content)
+	}
+	return b.String()
+}
+
+// Sender delivers a digest
+type Sender interface {
+	Send(ctx context.Context, digest Digest) error
+}
+
+// DefaultWebhookTimeout bounds each webhook request unless WebhookOptions
+// says otherwise
+const DefaultWebhookTimeout = 5 * time.Second
+
+// SignatureHeader carries the HMAC-SHA256 of the request body, as
+// "sha256=<hex>", when the webhook has a secret
+const SignatureHeader = "X-Signature"
+
+// WebhookOptions configures a WebhookSender
+type WebhookOptions struct {
+	Secret  string        // Signs each body in SignatureHeader; empty sends no signature
+	Timeout time.Duration // Bounds each attempt; 0 means DefaultWebhookTimeout
+	Retries int           // Further attempts after a network error, 429 or 5xx
+}
+
+// WebhookSender posts digests as JSON to a URL. The body carries both a
+// "text" field, which chat webhooks such as Slack display, and the ideas.
+type WebhookSender struct {
+	url     string
+	secret  []byte
+	retries int
+	backoff time.Duration // Wait before the first retry, doubling for each later one
+	client  *http.Client
+}
+
+// NewWebhookSender creates a sender for url
+func NewWebhookSender(url string, opts WebhookOptions) *WebhookSender {
+	timeout := opts.Timeout
+	if timeout <= 0 {
+		timeout = DefaultWebhookTimeout
+	}
+	return &WebhookSender{
+		url:     url,
+		secret:  []byte(opts.Secret),
+		retries: max(0, opts.Retries),
+		backoff: time.Second,
+		client:  &http.Client{Timeout: timeout},
+	}
+}
+
+// Send posts the digest to the webhook, retrying failures that may be
+// temporary
+func (s *WebhookSender) Send(ctx context.Context, digest Digest) error {
+	body, err := json.Marshal(struct {
+		Text  string        `json:"text"`
+		Count int           `json:"count"`
+		Ideas []IdeaSummary `json:"ideas"`
+	}{digest.Text(), len(digest.Ideas), digest.Ideas})
+	if err != nil {
+		return fmt.Errorf("failed to encode digest: %w", err)
+	}
+
+	backoff := s.backoff
+	for attempt := 0; ; attempt++ {
+		retryable, err := s.post(ctx, body)
+		if err == nil || !retryable || attempt >= s.retries {
+			return err
+		}
+
+		timer := time.NewTimer(backoff)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		}
+		backoff *= 2
+	}
+}
+
+// post makes one attempt at delivering body, reporting whether a failure
+// is worth retrying
+func (s *WebhookSender) post(ctx context.Context, body []byte) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return false, fmt.Errorf("failed to create webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if len(s.secret) > 0 {
+		req.Header.Set(SignatureHeader, Sign(s.secret, body))
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return ctx.Err() == nil, fmt.Errorf("webhook request failed: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		retryable := resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+		return retryable, fmt.Errorf("webhook returned status %d", resp.StatusCode)
+	}
+	return false, nil
+}
+
+// Sign returns the SignatureHeader value for body: "sha256=" and the hex
+// HMAC-SHA256 of body keyed with secret. Receivers verify a delivery by
+// computing the same over the raw body.
+func Sign(secret, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func summarize(idea *models.Idea) IdeaSummary {
+	return IdeaSummary{
+		ID:             idea.ID,
+		Content:        idea.Content,
+		Score:          idea.FinalScore,
+		Recommendation: idea.Recommendation,
+		CreatedAt:      idea.CreatedAt,
+		Scores:         scoreBreakdown(idea),
+	}
+}
+
+// scoreBreakdown returns the category scores of idea's analysis, or nil
+func scoreBreakdown(idea *models.Idea) *ScoreBreakdown {
+	analysis := idea.Analysis
+	if analysis == nil {
+		var ok bool
+		if analysis, ok = idea.ParsedAnalysis(); !ok {
+			return nil
+		}
+	}
+	return &ScoreBreakdown{
+		Mission:       analysis.Mission.Total,
+		AntiChallenge: analysis.AntiChallenge.Total,
+		Strategic:     analysis.Strategic.Total,
+	}
+}
@@ -8,6 +8,21 @@ import (
 	"github.com/ryacub/telos-idea-matrix/internal/models"
 )
 
+// Descriptions of the built-in positive patterns. They share a name with
+// the anti-pattern they counter, so the description tells them apart.
+const (
+	stayingFocusedDescription = "Staying focused on current tech stack"
+	accountabilityDescription = "External accountability component detected - building in public or with customers"
+)
+
+// IsPositive reports whether a pattern stored on an idea, as "Name: Description",
+// is one of the built-in positive patterns rather than an anti-pattern.
+func IsPositive(pattern string) bool {
+	_, description, _ := strings.Cut(pattern, ": ")
+	description = strings.TrimSpace(description)
+	return description == stayingFocusedDescription || description == accountabilityDescription
+}
+
 // Detector detects anti-patterns and positive patterns in ideas.
 type Detector struct {
 	telos *models.Telos
@@ -18,12 +33,35 @@ type Detector struct {
 	procrastinationRegex   *regexp.Regexp
 	accountabilityNegRegex *regexp.Regexp
 	accountabilityPosRegex *regexp.Regexp
+
+	// User-defined rules, and their lowercased names for collision checks
+	rules     []Rule
+	ruleNames map[string]bool
 }
 
 // NewDetector creates a new pattern detector with the given telos configuration.
 func NewDetector(telos *models.Telos) *Detector {
+	return NewDetectorWithRules(telos, nil)
+}
+
+// NewDetectorWithRules creates a pattern detector that also applies user-defined rules.
+// A rule whose name matches a built-in or telos pattern (case-insensitive) replaces it.
+// Rules are normally obtained from LoadRules; any other invalid rule is ignored.
+func NewDetectorWithRules(telos *models.Telos, rules []Rule) *Detector {
+	valid := make([]Rule, 0, len(rules))
+	ruleNames := make(map[string]bool, len(rules))
+	for _, rule := range rules {
+		if err := rule.compile(); err != nil {
+			continue
+		}
+		valid = append(valid, rule)
+		ruleNames[strings.ToLower(rule.Name)] = true
+	}
+
 	return &Detector{
-		telos: telos,
+		telos:     telos,
+		rules:     valid,
+		ruleNames: ruleNames,
 		// Context switching penalty keywords
 		contextSwitchingRegex: regexp.MustCompile(`(?i)(rust|javascript|typescript|react|flutter|swift|mobile\s+app|game\s+development)`),
 		// Perfectionism keywords
@@ -43,33 +81,41 @@ func (d *Detector) DetectPatterns(ideaText string) []models.DetectedPattern {
 		return []models.DetectedPattern{}
 	}
 
-	var patterns []models.DetectedPattern
+	var detected []models.DetectedPattern
 	ideaLower := strings.ToLower(ideaText)
 
-	// Detect context switching
-	if pattern := d.detectContextSwitching(ideaLower); pattern != nil {
-		patterns = append(patterns, *pattern)
+	// Built-in and telos detectors
+	builtIn := []*models.DetectedPattern{
+		d.detectContextSwitching(ideaLower),
+		d.detectPerfectionism(ideaLower),
+		d.detectProcrastination(ideaLower),
+		d.detectAccountabilityAvoidance(ideaLower),
 	}
-
-	// Detect perfectionism
-	if pattern := d.detectPerfectionism(ideaLower); pattern != nil {
-		patterns = append(patterns, *pattern)
+	for _, pattern := range builtIn {
+		if pattern != nil && !d.overridden(pattern.Name) {
+			detected = append(detected, *pattern)
+		}
 	}
 
-	// Detect procrastination
-	if pattern := d.detectProcrastination(ideaLower); pattern != nil {
-		patterns = append(patterns, *pattern)
+	for _, pattern := range d.detectTelosFailurePatterns(ideaLower) {
+		if !d.overridden(pattern.Name) {
+			detected = append(detected, pattern)
+		}
 	}
 
-	// Detect accountability avoidance
-	if pattern := d.detectAccountabilityAvoidance(ideaLower); pattern != nil {
-		patterns = append(patterns, *pattern)
+	// User-defined rules
+	for i := range d.rules {
+		if pattern := d.rules[i].match(ideaText, ideaLower); pattern != nil {
+			detected = append(detected, *pattern)
+		}
 	}
 
-	// Detect telos failure patterns
-	patterns = append(patterns, d.detectTelosFailurePatterns(ideaLower)...)
+	return detected
+}
 
-	return patterns
+// overridden reports whether a user-defined rule replaces the named pattern
+func (d *Detector) overridden(name string) bool {
+	return d.ruleNames[strings.ToLower(name)]
 }
 
 // detectContextSwitching detects stack switching anti-patterns.
@@ -100,7 +146,7 @@ func (d *Detector) detectContextSwitching(ideaLower string) *models.DetectedPatt
 		if matchCount >= 2 {
 			return &models.DetectedPattern{
 				Name:        "Context switching",
-				Description: "Staying focused on current tech stack",
+				Description: stayingFocusedDescription,
 				Confidence:  0.9,
 				Severity:    "low", // Low severity = positive pattern
 			}
@@ -151,7 +197,7 @@ func (d *Detector) detectAccountabilityAvoidance(ideaLower string) *models.Detec
 	if d.accountabilityPosRegex.MatchString(ideaLower) {
 		return &models.DetectedPattern{
 			Name:        "Accountability avoidance",
-			Description: "External accountability component detected - building in public or with customers",
+			Description: accountabilityDescription,
 			Confidence:  0.8,
 			Severity:    "low", // Low severity = positive pattern
 		}
@@ -373,3 +373,15 @@ func TestDetector_DetectPatterns_TelosFailurePatterns_Matched(t *testing.T) {
 	}
 	assert.True(t, found, "Should detect telos failure pattern")
 }
+
+func TestIsPositive(t *testing.T) {
+	detector := patterns.NewDetector(&models.Telos{Stack: models.Stack{Primary: []string{"Go", "Python"}}})
+
+	for _, p := range detector.DetectPatterns("Share a Go and Python tool on GitHub") {
+		stored := p.Name + ": " + p.Description
+		assert.Equal(t, p.Severity == "low", patterns.IsPositive(stored), stored)
+	}
+
+	assert.False(t, patterns.IsPositive("Perfectionism: Scope creep risk - over-engineering detected"))
+	assert.False(t, patterns.IsPositive("Context switching"))
+}
@@ -0,0 +1,135 @@
+package patterns
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/ryacub/telos-idea-matrix/internal/models"
+	"gopkg.in/yaml.v3"
+)
+
+// ruleConfidence is the confidence reported for a user-defined rule match
+const ruleConfidence = 0.8
+
+// validSeverities lists the severities a rule may declare
+var validSeverities = map[string]bool{
+	"low":      true,
+	"medium":   true,
+	"high":     true,
+	"critical": true,
+}
+
+// Rule is a user-defined pattern loaded from a patterns file.
+// A rule matches when any keyword appears in the idea or the regex matches it.
+// Both are case-insensitive.
+type Rule struct {
+	Name        string   `yaml:"name"`
+	Description string   `yaml:"description,omitempty"`
+	Keywords    []string `yaml:"keywords,omitempty"`
+	Regex       string   `yaml:"regex,omitempty"`
+	Severity    string   `yaml:"severity,omitempty"` // low, medium (default), high, or critical
+
+	compiled *regexp.Regexp
+}
+
+// rulesFile is the on-disk layout of a patterns file
+type rulesFile struct {
+	Patterns []Rule `yaml:"patterns"`
+}
+
+// LoadRules reads and validates pattern rules from a YAML file.
+//
+// Example:
+//
+//	patterns:
+//	  - name: Shiny object
+//	    description: Chasing a new tool instead of shipping
+//	    keywords: [rewrite, new framework]
+//	    regex: '\bv2\b'
+//	    severity: high
+func LoadRules(path string) ([]Rule, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read patterns file: %w", err)
+	}
+
+	var file rulesFile
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse patterns file: %w", err)
+	}
+
+	seen := make(map[string]bool, len(file.Patterns))
+	for i := range file.Patterns {
+		rule := &file.Patterns[i]
+		if err := rule.compile(); err != nil {
+			return nil, fmt.Errorf("patterns[%d]: %w", i, err)
+		}
+
+		key := strings.ToLower(rule.Name)
+		if seen[key] {
+			return nil, fmt.Errorf("patterns[%d]: duplicate pattern name %q", i, rule.Name)
+		}
+		seen[key] = true
+	}
+
+	return file.Patterns, nil
+}
+
+// compile validates the rule, applies defaults, and compiles its regex
+func (r *Rule) compile() error {
+	r.Name = strings.TrimSpace(r.Name)
+	if r.Name == "" {
+		return errors.New("name is required")
+	}
+	if len(r.Keywords) == 0 && r.Regex == "" {
+		return fmt.Errorf("pattern %q needs keywords or a regex", r.Name)
+	}
+
+	if r.Severity == "" {
+		r.Severity = "medium"
+	}
+	r.Severity = strings.ToLower(r.Severity)
+	if !validSeverities[r.Severity] {
+		return fmt.Errorf("pattern %q: invalid severity %q (must be low, medium, high, or critical)", r.Name, r.Severity)
+	}
+
+	if r.Regex != "" {
+		// Validate the expression as written so errors quote the user's regex
+		if _, err := regexp.Compile(r.Regex); err != nil {
+			return fmt.Errorf("pattern %q: invalid regex: %w", r.Name, err)
+		}
+		r.compiled = regexp.MustCompile("(?i)" + r.Regex)
+	}
+
+	return nil
+}
+
+// match returns the detected pattern if the rule matches the idea, or nil
+func (r *Rule) match(ideaText, ideaLower string) *models.DetectedPattern {
+	matched := r.compiled != nil && r.compiled.MatchString(ideaText)
+	for _, keyword := range r.Keywords {
+		if matched {
+			break
+		}
+		keyword = strings.ToLower(strings.TrimSpace(keyword))
+		matched = keyword != "" && strings.Contains(ideaLower, keyword)
+	}
+	if !matched {
+		return nil
+	}
+
+	description := r.Description
+	if description == "" {
+		description = "Matched custom pattern rule"
+	}
+
+	return &models.DetectedPattern{
+		Name:        r.Name,
+		Description: description,
+		Confidence:  ruleConfidence,
+		Severity:    r.Severity,
+	}
+}
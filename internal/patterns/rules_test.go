@@ -0,0 +1,129 @@
+package patterns_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ryacub/telos-idea-matrix/internal/models"
+	"github.com/ryacub/telos-idea-matrix/internal/patterns"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writePatternsFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "patterns.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	return path
+}
+
+func findPattern(detected []models.DetectedPattern, name string) *models.DetectedPattern {
+	for i := range detected {
+		if detected[i].Name == name {
+			return &detected[i]
+		}
+	}
+	return nil
+}
+
+func TestLoadRules_ValidFile(t *testing.T) {
+	path := writePatternsFile(t, `
+patterns:
+  - name: Shiny object
+    description: Chasing a new tool instead of shipping
+    keywords: [rewrite, new framework]
+    severity: high
+  - name: Version creep
+    regex: '\bv[2-9]\b'
+`)
+
+	rules, err := patterns.LoadRules(path)
+	require.NoError(t, err)
+	require.Len(t, rules, 2)
+	assert.Equal(t, "Shiny object", rules[0].Name)
+	assert.Equal(t, []string{"rewrite", "new framework"}, rules[0].Keywords)
+	assert.Equal(t, "high", rules[0].Severity)
+	assert.Equal(t, "medium", rules[1].Severity, "severity should default to medium")
+}
+
+func TestLoadRules_Invalid(t *testing.T) {
+	testCases := []struct {
+		name    string
+		content string
+		errMsg  string
+	}{
+		{"malformed regex", "patterns:\n  - name: Broken\n    regex: '(unclosed'\n", "invalid regex"},
+		{"missing name", "patterns:\n  - keywords: [x]\n", "name is required"},
+		{"no matchers", "patterns:\n  - name: Empty\n", "needs keywords or a regex"},
+		{"bad severity", "patterns:\n  - name: Loud\n    keywords: [x]\n    severity: extreme\n", "invalid severity"},
+		{"duplicate name", "patterns:\n  - name: Dup\n    keywords: [a]\n  - name: dup\n    keywords: [b]\n", "duplicate pattern name"},
+		{"malformed yaml", "patterns: [", "failed to parse"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := patterns.LoadRules(writePatternsFile(t, tc.content))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tc.errMsg)
+		})
+	}
+}
+
+func TestLoadRules_MissingFile(t *testing.T) {
+	_, err := patterns.LoadRules(filepath.Join(t.TempDir(), "missing.yaml"))
+	require.Error(t, err)
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestDetector_UserRules_MergeWithBuiltIns(t *testing.T) {
+	rules, err := patterns.LoadRules(writePatternsFile(t, `
+patterns:
+  - name: Shiny object
+    keywords: [rewrite]
+  - name: Version creep
+    regex: '\bV[2-9]\b'
+`))
+	require.NoError(t, err)
+	detector := patterns.NewDetectorWithRules(loadTestTelos(t), rules)
+
+	detected := detector.DetectPatterns("Rewrite the comprehensive dashboard as V2")
+
+	assert.NotNil(t, findPattern(detected, "Shiny object"), "keyword rule should match case-insensitively")
+	assert.NotNil(t, findPattern(detected, "Version creep"), "regex rule should match case-insensitively")
+	assert.NotNil(t, findPattern(detected, "Perfectionism"), "built-in patterns should still be detected")
+}
+
+func TestDetector_UserRules_OverrideBuiltInOnNameCollision(t *testing.T) {
+	rules, err := patterns.LoadRules(writePatternsFile(t, `
+patterns:
+  - name: perfectionism
+    description: My own definition
+    keywords: [gold plating]
+    severity: critical
+`))
+	require.NoError(t, err)
+	detector := patterns.NewDetectorWithRules(loadTestTelos(t), rules)
+
+	// The built-in keyword no longer triggers the pattern
+	assert.Nil(t, findPattern(detector.DetectPatterns("Build a comprehensive platform"), "Perfectionism"))
+
+	// The user's definition does, with its own settings
+	detected := detector.DetectPatterns("Spend a week gold plating the UI")
+	pattern := findPattern(detected, "perfectionism")
+	require.NotNil(t, pattern)
+	assert.Equal(t, "My own definition", pattern.Description)
+	assert.Equal(t, "critical", pattern.Severity)
+	assert.Nil(t, findPattern(detected, "Perfectionism"))
+}
+
+func TestNewDetectorWithRules_IgnoresInvalidRules(t *testing.T) {
+	detector := patterns.NewDetectorWithRules(nil, []patterns.Rule{
+		{Name: "Broken", Regex: "(unclosed"},
+		{Name: "Valid", Keywords: []string{"podcast"}},
+	})
+
+	detected := detector.DetectPatterns("Start a podcast (unclosed")
+	assert.Nil(t, findPattern(detected, "Broken"))
+	assert.NotNil(t, findPattern(detected, "Valid"))
+}
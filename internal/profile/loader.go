@@ -24,15 +24,19 @@ const (
 
 // DefaultPath returns the default profile path (~/.brain-salad/profile.yaml)
 func DefaultPath() (string, error) {
-	home, err := os.UserHomeDir()
+	dir, err := DefaultDir()
 	if err != nil {
-		return "", fmt.Errorf("failed to get home directory: %w", err)
+		return "", err
 	}
-	return filepath.Join(home, DefaultProfileDir, DefaultProfileFile), nil
+	return filepath.Join(dir, DefaultProfileFile), nil
 }
 
-// DefaultDir returns the default profile directory (~/.brain-salad)
+// DefaultDir returns the default profile directory: $TELOS_HOME when set,
+// so it holds the whole tree, otherwise ~/.brain-salad
 func DefaultDir() (string, error) {
+	if dir := os.Getenv("TELOS_HOME"); dir != "" {
+		return dir, nil
+	}
 	home, err := os.UserHomeDir()
 	if err != nil {
 		return "", fmt.Errorf("failed to get home directory: %w", err)
@@ -154,9 +158,70 @@ func Validate(p *Profile) error {
 		return fmt.Errorf("invalid money_matters value: %s", p.Preferences.MoneyMatters)
 	}
 
+	// Validate score bounds
+	for i, b := range p.ScoreBounds {
+		if err := validateScoreBound(b); err != nil {
+			return fmt.Errorf("score_bounds[%d]: %w", i, err)
+		}
+	}
+
+	return nil
+}
+
+// validateScoreBound checks that a single score bound is well-formed.
+func validateScoreBound(b ScoreBound) error {
+	if (b.Tag == "") == (b.Pattern == "") {
+		return errors.New("exactly one of tag or pattern must be set")
+	}
+	if b.Floor == nil && b.Ceiling == nil {
+		return errors.New("floor or ceiling must be set")
+	}
+	if b.Floor != nil && (*b.Floor < 0 || *b.Floor > 10) {
+		return fmt.Errorf("floor must be between 0 and 10, got %.2f", *b.Floor)
+	}
+	if b.Ceiling != nil && (*b.Ceiling < 0 || *b.Ceiling > 10) {
+		return fmt.Errorf("ceiling must be between 0 and 10, got %.2f", *b.Ceiling)
+	}
+	if b.Floor != nil && b.Ceiling != nil && *b.Floor > *b.Ceiling {
+		return fmt.Errorf("floor %.2f cannot exceed ceiling %.2f", *b.Floor, *b.Ceiling)
+	}
 	return nil
 }
 
+// LoadScoreBounds reads score floors and ceilings from a YAML file, for
+// telos.md scoring, which has no profile to hold them. A missing file has
+// no bounds.
+//
+// Example:
+//
+//	score_bounds:
+//	  - tag: urgent
+//	    floor: 7
+//	  - pattern: perfectionism
+//	    ceiling: 5
+func LoadScoreBounds(path string) ([]ScoreBound, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read score bounds: %w", err)
+	}
+
+	var file struct {
+		ScoreBounds []ScoreBound `yaml:"score_bounds"`
+	}
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse score bounds: %w", err)
+	}
+	for i, b := range file.ScoreBounds {
+		if err := validateScoreBound(b); err != nil {
+			return nil, fmt.Errorf("score_bounds[%d]: %w", i, err)
+		}
+	}
+	return file.ScoreBounds, nil
+}
+
 // DefaultProfile creates a profile with sensible default weights.
 // This represents a balanced starting point before wizard customization.
 func DefaultProfile() *Profile {
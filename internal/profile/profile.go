@@ -22,6 +22,10 @@ type Profile struct {
 	// Preferences captured from discovery wizard
 	Preferences Preferences `yaml:"preferences" json:"preferences"`
 
+	// ScoreBounds are floors and ceilings applied to the final score
+	// of ideas carrying a matching tag or pattern
+	ScoreBounds []ScoreBound `yaml:"score_bounds,omitempty" json:"score_bounds,omitempty"`
+
 	// CreatedAt tracks when the profile was created
 	CreatedAt time.Time `yaml:"created_at" json:"created_at"`
 
@@ -44,6 +48,22 @@ type Preferences struct {
 	PushesThrough bool `yaml:"pushes_through" json:"pushes_through"`
 }
 
+// ScoreBound clamps the final score of ideas matching a tag or pattern.
+// Exactly one of Tag or Pattern must be set, and at least one of Floor or Ceiling.
+type ScoreBound struct {
+	// Tag matches ideas carrying this tag (case-insensitive)
+	Tag string `yaml:"tag,omitempty" json:"tag,omitempty"`
+
+	// Pattern matches ideas with this detected pattern name (case-insensitive)
+	Pattern string `yaml:"pattern,omitempty" json:"pattern,omitempty"`
+
+	// Floor is the minimum final score for matching ideas
+	Floor *float64 `yaml:"floor,omitempty" json:"floor,omitempty"`
+
+	// Ceiling is the maximum final score for matching ideas
+	Ceiling *float64 `yaml:"ceiling,omitempty" json:"ceiling,omitempty"`
+}
+
 // Dimension names as constants for consistency
 const (
 	DimensionCompletionLikelihood = "completion_likelihood"
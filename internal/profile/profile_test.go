@@ -114,6 +114,60 @@ func TestValidate_InvalidMoneyMatters_ReturnsError(t *testing.T) {
 	assert.Contains(t, err.Error(), "money_matters")
 }
 
+func TestValidate_ScoreBounds(t *testing.T) {
+	floor, ceiling, tooHigh := 7.0, 4.0, 11.0
+
+	tests := []struct {
+		name    string
+		bound   ScoreBound
+		wantErr string
+	}{
+		{"valid floor", ScoreBound{Tag: "urgent", Floor: &floor}, ""},
+		{"valid ceiling", ScoreBound{Pattern: "perfectionism", Ceiling: &ceiling}, ""},
+		{"no selector", ScoreBound{Floor: &floor}, "exactly one"},
+		{"both selectors", ScoreBound{Tag: "a", Pattern: "b", Floor: &floor}, "exactly one"},
+		{"no limits", ScoreBound{Tag: "urgent"}, "floor or ceiling"},
+		{"out of range", ScoreBound{Tag: "urgent", Floor: &tooHigh}, "between 0 and 10"},
+		{"floor above ceiling", ScoreBound{Tag: "urgent", Floor: &floor, Ceiling: &ceiling}, "cannot exceed"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := DefaultProfile()
+			p.ScoreBounds = []ScoreBound{tt.bound}
+
+			err := Validate(p)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestLoadScoreBounds(t *testing.T) {
+	dir := t.TempDir()
+
+	bounds, err := LoadScoreBounds(filepath.Join(dir, "missing.yaml"))
+	require.NoError(t, err)
+	assert.Empty(t, bounds, "a missing file has no bounds")
+
+	path := filepath.Join(dir, "score-bounds.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("score_bounds:\n  - tag: urgent\n    floor: 7\n"), 0600))
+	bounds, err = LoadScoreBounds(path)
+	require.NoError(t, err)
+	require.Len(t, bounds, 1)
+	assert.Equal(t, "urgent", bounds[0].Tag)
+	assert.Equal(t, 7.0, *bounds[0].Floor)
+
+	require.NoError(t, os.WriteFile(path, []byte("score_bounds:\n  - tag: urgent\n"), 0600))
+	_, err = LoadScoreBounds(path)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "score_bounds[0]")
+}
+
 func TestNormalizePriorities_SumsTo1(t *testing.T) {
 	p := &Profile{
 		Priorities: map[string]float64{
@@ -0,0 +1,273 @@
+// Package reanalyze re-scores ideas in the background after the telos changes,
+// paced and budgeted so a paid LLM provider's limits are respected.
+package reanalyze
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/ryacub/telos-idea-matrix/internal/database"
+	"github.com/ryacub/telos-idea-matrix/internal/llm"
+	"github.com/ryacub/telos-idea-matrix/internal/metrics"
+	"github.com/ryacub/telos-idea-matrix/internal/models"
+	"github.com/ryacub/telos-idea-matrix/internal/patterns"
+	"github.com/ryacub/telos-idea-matrix/internal/profile"
+	"github.com/ryacub/telos-idea-matrix/internal/scoring"
+	"golang.org/x/time/rate"
+)
+
+// Token counts assumed for one analysis when estimating spend. The analysis
+// prompt carries the whole telos, so input dominates.
+const (
+	estimatedInputTokens  = 2000
+	estimatedOutputTokens = 500
+)
+
+// DefaultMaxAttempts is how many times an idea may fail to re-analyze
+// before it is skipped, unless Options says otherwise
+const DefaultMaxAttempts = 3
+
+// ErrBudgetExhausted is returned by Run when the day's budget has been spent.
+// The job's remaining ideas are left pending for a later run.
+var ErrBudgetExhausted = errors.New("re-analysis budget exhausted")
+
+// AnalyzeFunc re-analyzes and saves one idea, returning the name of the
+// provider that scored it so its cost can be estimated
+type AnalyzeFunc func(idea *models.Idea) (provider string, err error)
+
+// Options configures a Runner
+type Options struct {
+	MaxPerMinute int     // Re-analyses started per minute; must be positive
+	BudgetUSD    float64 // Estimated spend allowed per UTC day; 0 means no limit
+	MaxAttempts  int     // Failed analyses of an idea before it is skipped; 0 means DefaultMaxAttempts
+}
+
+// Runner works through re-analysis jobs. Progress is recorded as bulk jobs,
+// so a paused or interrupted run resumes where it stopped. Spend is tracked
+// in memory and resets at midnight UTC.
+type Runner struct {
+	repo    *database.Repository
+	opts    Options
+	limiter *rate.Limiter
+	now     func() time.Time
+
+	mu       sync.Mutex
+	spentDay string // UTC date that spentUSD applies to
+	spentUSD float64
+}
+
+// NewRunner creates a runner that paces re-analysis according to opts
+func NewRunner(repo *database.Repository, opts Options) *Runner {
+	perMinute := opts.MaxPerMinute
+	if perMinute < 1 {
+		perMinute = 1
+	}
+	if opts.MaxAttempts < 1 {
+		opts.MaxAttempts = DefaultMaxAttempts
+	}
+	return &Runner{
+		repo:    repo,
+		opts:    opts,
+		limiter: rate.NewLimiter(rate.Every(time.Minute/time.Duration(perMinute)), 1),
+		now:     time.Now,
+	}
+}
+
+// Enqueue records a re-analysis job over ideas, returning nil if there are none
+func (r *Runner) Enqueue(ideas []*models.Idea, telosVersion string) (*models.BulkJob, error) {
+	if len(ideas) == 0 {
+		return nil, nil
+	}
+
+	params, err := json.Marshal(map[string]string{"telos_version": telosVersion})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode job settings: %w", err)
+	}
+
+	ids := make([]string, len(ideas))
+	for i, idea := range ideas {
+		ids[i] = idea.ID
+	}
+
+	job := models.NewBulkJob(models.BulkJobReanalyze, string(params))
+	if err := r.repo.CreateBulkJob(job, ids); err != nil {
+		return nil, err
+	}
+	return job, nil
+}
+
+// RunPending runs every incomplete re-analysis job, oldest first
+func (r *Runner) RunPending(ctx context.Context, analyze AnalyzeFunc) error {
+	ids, err := r.repo.IncompleteBulkJobIDs(models.BulkJobReanalyze)
+	if err != nil {
+		return err
+	}
+	for _, id := range ids {
+		if err := r.Run(ctx, id, analyze); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// HasPending reports whether a re-analysis job is still incomplete
+func (r *Runner) HasPending() (bool, error) {
+	ids, err := r.repo.IncompleteBulkJobIDs(models.BulkJobReanalyze)
+	return len(ids) > 0, err
+}
+
+// Run re-analyzes the ideas job jobID hasn't finished, starting no more than
+// MaxPerMinute a minute. It stops with ErrBudgetExhausted once the day's
+// budget is spent, leaving the rest pending. An idea that fails is recorded
+// and left for the next run, and skipped once it has failed MaxAttempts
+// times, so it can't hold up the others; Run then returns an error naming
+// how many ideas are left to retry. The job is marked complete only when
+// every idea is done or skipped.
+func (r *Runner) Run(ctx context.Context, jobID string, analyze AnalyzeFunc) error {
+	pending, err := r.repo.PendingBulkJobItems(jobID)
+	if err != nil {
+		return err
+	}
+
+	retries := 0
+	for _, id := range pending {
+		if r.budgetExhausted() {
+			return ErrBudgetExhausted
+		}
+		if err := r.limiter.Wait(ctx); err != nil {
+			return err
+		}
+
+		idea, err := r.repo.GetByID(id)
+		if err != nil && !database.IsNotFound(err) {
+			return fmt.Errorf("failed to load idea %s: %w", id, err)
+		}
+		// A deleted idea has nothing left to analyze
+		if err == nil {
+			provider, err := analyze(idea)
+			if err != nil {
+				skipped, recordErr := r.repo.RecordBulkJobItemFailure(jobID, id, err, r.opts.MaxAttempts)
+				if recordErr != nil {
+					return fmt.Errorf("failed to record progress: %w", recordErr)
+				}
+				if skipped {
+					log.Warn().Err(err).Str("idea_id", id).Int("attempts", r.opts.MaxAttempts).
+						Msg("Skipping idea that keeps failing to re-analyze")
+				} else {
+					log.Warn().Err(err).Str("idea_id", id).Msg("Failed to re-analyze idea; will retry")
+					retries++
+				}
+				continue
+			}
+			r.spend(analysisCost(provider))
+		}
+
+		if err := r.repo.MarkBulkJobItemDone(jobID, id); err != nil {
+			return fmt.Errorf("failed to record progress: %w", err)
+		}
+	}
+
+	if retries > 0 {
+		return fmt.Errorf("%d ideas failed to re-analyze and will be retried", retries)
+	}
+	return r.repo.CompleteBulkJob(jobID)
+}
+
+// SpentUSD returns the estimated spend so far today
+func (r *Runner) SpentUSD() float64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.resetIfNewDayLocked()
+	return r.spentUSD
+}
+
+func (r *Runner) budgetExhausted() bool {
+	if r.opts.BudgetUSD <= 0 {
+		return false
+	}
+	return r.SpentUSD() >= r.opts.BudgetUSD
+}
+
+func (r *Runner) spend(usd float64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.resetIfNewDayLocked()
+	r.spentUSD += usd
+}
+
+func (r *Runner) resetIfNewDayLocked() {
+	if day := r.now().UTC().Format("2006-01-02"); day != r.spentDay {
+		r.spentDay = day
+		r.spentUSD = 0
+	}
+}
+
+// analysisCost estimates the cost of one analysis by provider
+func analysisCost(provider string) float64 {
+	return metrics.CalculateCost(provider, estimatedInputTokens, estimatedOutputTokens).TotalCost
+}
+
+// LLMAnalyzer returns an AnalyzeFunc that re-scores ideas with manager
+// against telos, enforcing bounds, and stamps them with telosVersion
+func LLMAnalyzer(repo *database.Repository, manager *llm.Manager, telos *models.Telos, telosVersion string, bounds []profile.ScoreBound) AnalyzeFunc {
+	detector := patterns.NewDetector(telos)
+
+	return func(idea *models.Idea) (string, error) {
+		result, err := manager.AnalyzeWithTelos(idea.Content, telos)
+		if err != nil {
+			return "", err
+		}
+
+		detected := detector.DetectPatterns(idea.Content)
+		patternStrings := make([]string, len(detected))
+		for i, p := range detected {
+			patternStrings[i] = fmt.Sprintf("%s: %s", p.Name, p.Description)
+		}
+
+		analysis := llm.ConvertResultToAnalysis(result)
+		scoring.ApplyAnalysisBounds(analysis, idea.Tags, patternStrings, bounds)
+		analysisJSON, err := json.Marshal(analysis)
+		if err != nil {
+			return "", fmt.Errorf("failed to encode analysis: %w", err)
+		}
+
+		idea.FinalScore = analysis.FinalScore
+		idea.Recommendation = analysis.GetRecommendation()
+		idea.AnalysisDetails = string(analysisJSON)
+		idea.Patterns = patternStrings
+		idea.TelosVersion = telosVersion
+
+		if err := repo.Update(idea); err != nil {
+			return "", fmt.Errorf("failed to save: %w", err)
+		}
+
+		log.Debug().Str("idea_id", idea.ID).Float64("score", idea.FinalScore).Msg("Re-analyzed idea")
+		return result.Provider, nil
+	}
+}
+
+// StaleIdeas returns the active ideas of profile that were scored against a
+// telos version other than version, oldest first
+func StaleIdeas(repo *database.Repository, profile, version string) ([]*models.Idea, error) {
+	ideas, err := repo.List(database.ListOptions{
+		Status:  "active",
+		Profile: profile,
+		OrderBy: database.OrderBy(database.SortByCreatedAt, database.Ascending),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list ideas: %w", err)
+	}
+
+	var stale []*models.Idea
+	for _, idea := range ideas {
+		if idea.TelosVersion != version {
+			stale = append(stale, idea)
+		}
+	}
+	return stale, nil
+}
@@ -0,0 +1,200 @@
+package reanalyze
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ryacub/telos-idea-matrix/internal/database"
+	"github.com/ryacub/telos-idea-matrix/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestRepo(t *testing.T) *database.Repository {
+	t.Helper()
+	repo, err := database.NewRepository(filepath.Join(t.TempDir(), "test.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = repo.Close() })
+	return repo
+}
+
+func createIdeas(t *testing.T, repo *database.Repository, n int, telosVersion string) []*models.Idea {
+	t.Helper()
+	ideas := make([]*models.Idea, n)
+	for i := range ideas {
+		ideas[i] = models.NewIdea(fmt.Sprintf("Idea number %d", i))
+		ideas[i].TelosVersion = telosVersion
+		require.NoError(t, repo.Create(ideas[i]))
+	}
+	return ideas
+}
+
+// recordingAnalyzer stamps ideas with version and records when each was analyzed
+type recordingAnalyzer struct {
+	repo     *database.Repository
+	provider string
+	version  string
+
+	mu    sync.Mutex
+	times []time.Time
+}
+
+func (a *recordingAnalyzer) analyze(idea *models.Idea) (string, error) {
+	a.mu.Lock()
+	a.times = append(a.times, time.Now())
+	a.mu.Unlock()
+
+	idea.TelosVersion = a.version
+	return a.provider, a.repo.Update(idea)
+}
+
+func (a *recordingAnalyzer) count() int {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return len(a.times)
+}
+
+func TestRunner_RespectsPerMinutePace(t *testing.T) {
+	repo := newTestRepo(t)
+	ideas := createIdeas(t, repo, 10, "old")
+
+	// 1200 a minute is one every 50ms
+	runner := NewRunner(repo, Options{MaxPerMinute: 1200})
+	job, err := runner.Enqueue(ideas, "new")
+	require.NoError(t, err)
+
+	analyzer := &recordingAnalyzer{repo: repo, provider: "ollama", version: "new"}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 175*time.Millisecond)
+	defer cancel()
+	err = runner.Run(ctx, job.ID, analyzer.analyze)
+	require.Error(t, err)
+
+	// Analyses start at 0, 50, 100 and 150ms; never more than the pace allows
+	assert.LessOrEqual(t, analyzer.count(), 4)
+	assert.GreaterOrEqual(t, analyzer.count(), 2)
+	for i := 1; i < len(analyzer.times); i++ {
+		assert.GreaterOrEqual(t, analyzer.times[i].Sub(analyzer.times[i-1]), 40*time.Millisecond,
+			"analyses %d and %d were not paced", i-1, i)
+	}
+
+	// The rest of the job is still pending and resumes where it stopped
+	pending, err := repo.PendingBulkJobItems(job.ID)
+	require.NoError(t, err)
+	assert.Len(t, pending, 10-analyzer.count())
+
+	require.NoError(t, runner.RunPending(context.Background(), analyzer.analyze))
+	assert.Equal(t, 10, analyzer.count())
+
+	got, err := repo.GetBulkJob(job.ID)
+	require.NoError(t, err)
+	assert.True(t, got.IsComplete())
+}
+
+func TestRunner_PausesWhenBudgetSpent(t *testing.T) {
+	repo := newTestRepo(t)
+	ideas := createIdeas(t, repo, 6, "old")
+
+	day := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	runner := NewRunner(repo, Options{MaxPerMinute: 60000, BudgetUSD: 0.05})
+	runner.now = func() time.Time { return day }
+
+	job, err := runner.Enqueue(ideas, "new")
+	require.NoError(t, err)
+
+	// Each Claude analysis is estimated at $0.0135, so the fourth crosses $0.05
+	analyzer := &recordingAnalyzer{repo: repo, provider: "claude", version: "new"}
+	err = runner.Run(context.Background(), job.ID, analyzer.analyze)
+	assert.True(t, errors.Is(err, ErrBudgetExhausted), "expected ErrBudgetExhausted, got %v", err)
+	assert.Equal(t, 4, analyzer.count())
+
+	// Still paused until the budget resets the next day
+	assert.ErrorIs(t, runner.RunPending(context.Background(), analyzer.analyze), ErrBudgetExhausted)
+	assert.Equal(t, 4, analyzer.count())
+
+	day = day.Add(24 * time.Hour)
+	require.NoError(t, runner.RunPending(context.Background(), analyzer.analyze))
+	assert.Equal(t, 6, analyzer.count())
+}
+
+func TestRunner_FreeProviderIgnoresBudget(t *testing.T) {
+	repo := newTestRepo(t)
+	ideas := createIdeas(t, repo, 3, "old")
+
+	runner := NewRunner(repo, Options{MaxPerMinute: 60000, BudgetUSD: 0.01})
+	job, err := runner.Enqueue(ideas, "new")
+	require.NoError(t, err)
+
+	analyzer := &recordingAnalyzer{repo: repo, provider: "ollama", version: "new"}
+	require.NoError(t, runner.Run(context.Background(), job.ID, analyzer.analyze))
+	assert.Equal(t, 3, analyzer.count())
+	assert.Zero(t, runner.SpentUSD())
+}
+
+func TestRunner_SkipsIdeaThatKeepsFailing(t *testing.T) {
+	repo := newTestRepo(t)
+	ideas := createIdeas(t, repo, 3, "old")
+
+	runner := NewRunner(repo, Options{MaxPerMinute: 60000, MaxAttempts: 2})
+	job, err := runner.Enqueue(ideas, "new")
+	require.NoError(t, err)
+
+	// The first idea always fails; the ones after it must still be analyzed
+	analyzer := &recordingAnalyzer{repo: repo, provider: "ollama", version: "new"}
+	analyze := func(idea *models.Idea) (string, error) {
+		if idea.ID == ideas[0].ID {
+			return "", errors.New("provider returned malformed JSON")
+		}
+		return analyzer.analyze(idea)
+	}
+
+	err = runner.Run(context.Background(), job.ID, analyze)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "1 ideas failed")
+	assert.Equal(t, 2, analyzer.count())
+
+	pending, err := repo.PendingBulkJobItems(job.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []string{ideas[0].ID}, pending, "the failing idea is retried on the next run")
+
+	// The second failure uses up its attempts, so the job completes without it
+	require.NoError(t, runner.RunPending(context.Background(), analyze))
+	got, err := repo.GetBulkJob(job.ID)
+	require.NoError(t, err)
+	assert.True(t, got.IsComplete())
+	assert.Equal(t, 3, got.Done)
+	assert.Equal(t, 1, got.Skipped)
+}
+
+func TestStaleIdeas(t *testing.T) {
+	repo := newTestRepo(t)
+
+	current := createIdeas(t, repo, 1, "v2")[0]
+	stale := createIdeas(t, repo, 1, "v1")[0]
+	unversioned := createIdeas(t, repo, 1, "")[0]
+
+	otherProfile := models.NewIdea("Scored against another profile")
+	otherProfile.Profile = "personal"
+	otherProfile.TelosVersion = "v1"
+	require.NoError(t, repo.Create(otherProfile))
+
+	archived := models.NewIdea("Archived idea")
+	archived.TelosVersion = "v1"
+	archived.Status = "archived"
+	require.NoError(t, repo.Create(archived))
+
+	ideas, err := StaleIdeas(repo, models.DefaultProfile, "v2")
+	require.NoError(t, err)
+
+	ids := make([]string, len(ideas))
+	for i, idea := range ideas {
+		ids[i] = idea.ID
+	}
+	assert.ElementsMatch(t, []string{stale.ID, unversioned.ID}, ids)
+	assert.NotContains(t, ids, current.ID)
+}
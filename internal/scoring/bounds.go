@@ -0,0 +1,125 @@
+package scoring
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ryacub/telos-idea-matrix/internal/models"
+	"github.com/ryacub/telos-idea-matrix/internal/profile"
+)
+
+// AppliedBound records a floor or ceiling that changed an idea's final score.
+type AppliedBound struct {
+	// Kind is "floor" or "ceiling"
+	Kind string `json:"kind"`
+
+	// Match describes the rule that fired, e.g. "tag:urgent" or "pattern:Perfectionism"
+	Match string `json:"match"`
+
+	// Limit is the bound value that was enforced
+	Limit float64 `json:"limit"`
+
+	// Before is the score prior to applying the bound
+	Before float64 `json:"before"`
+
+	// After is the score once the bound was applied
+	After float64 `json:"after"`
+}
+
+// ApplyScoreBounds clamps score using every bound whose tag or pattern matches.
+//
+// Bounds are not additive: when several rules match, the highest floor and the
+// lowest ceiling win. If the winning floor exceeds the winning ceiling, the
+// ceiling takes precedence so conflicting rules can never inflate a score.
+// Patterns match by name, so stored values like "Perfectionism: description"
+// match a bound on "perfectionism".
+func ApplyScoreBounds(score float64, tags, patterns []string, bounds []profile.ScoreBound) (float64, []AppliedBound) {
+	var floor, ceiling *float64
+	var floorMatch, ceilingMatch string
+
+	for _, b := range bounds {
+		match, ok := matchBound(b, tags, patterns)
+		if !ok {
+			continue
+		}
+		if b.Floor != nil && (floor == nil || *b.Floor > *floor) {
+			floor = b.Floor
+			floorMatch = match
+		}
+		if b.Ceiling != nil && (ceiling == nil || *b.Ceiling < *ceiling) {
+			ceiling = b.Ceiling
+			ceilingMatch = match
+		}
+	}
+
+	var applied []AppliedBound
+
+	if floor != nil && score < *floor && (ceiling == nil || *floor <= *ceiling) {
+		applied = append(applied, AppliedBound{
+			Kind:   "floor",
+			Match:  floorMatch,
+			Limit:  *floor,
+			Before: score,
+			After:  *floor,
+		})
+		score = *floor
+	}
+
+	if ceiling != nil && score > *ceiling {
+		applied = append(applied, AppliedBound{
+			Kind:   "ceiling",
+			Match:  ceilingMatch,
+			Limit:  *ceiling,
+			Before: score,
+			After:  *ceiling,
+		})
+		score = *ceiling
+	}
+
+	return score, applied
+}
+
+// matchBound reports whether a bound applies and returns a label for the match.
+func matchBound(b profile.ScoreBound, tags, patterns []string) (string, bool) {
+	if b.Tag != "" {
+		for _, tag := range tags {
+			if strings.EqualFold(tag, b.Tag) {
+				return "tag:" + b.Tag, true
+			}
+		}
+		return "", false
+	}
+
+	for _, p := range patterns {
+		name := p
+		if idx := strings.Index(p, ":"); idx >= 0 {
+			name = p[:idx]
+		}
+		if strings.EqualFold(strings.TrimSpace(name), b.Pattern) {
+			return "pattern:" + b.Pattern, true
+		}
+	}
+	return "", false
+}
+
+// String describes the bound for insights and scoring details, e.g.
+// "Score floor 7.0 applied (tag:urgent): 5.2 → 7.0"
+func (b AppliedBound) String() string {
+	return fmt.Sprintf("Score %s %.1f applied (%s): %.1f → %.1f", b.Kind, b.Limit, b.Match, b.Before, b.After)
+}
+
+// ApplyAnalysisBounds enforces bounds on a telos analysis of an idea with the
+// given tags and patterns. Any bound that changes the score is added to the
+// analysis's scoring details and returned.
+func ApplyAnalysisBounds(analysis *models.Analysis, tags, patterns []string, bounds []profile.ScoreBound) []AppliedBound {
+	if analysis == nil || len(bounds) == 0 {
+		return nil
+	}
+
+	score, applied := ApplyScoreBounds(analysis.FinalScore, tags, patterns, bounds)
+	analysis.FinalScore = score
+	for _, b := range applied {
+		analysis.ScoringDetails = append(analysis.ScoringDetails, b.String())
+	}
+	return applied
+}
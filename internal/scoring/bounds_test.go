@@ -0,0 +1,116 @@
+package scoring
+
+import (
+	"testing"
+
+	"github.com/ryacub/telos-idea-matrix/internal/profile"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func floatPtr(v float64) *float64 {
+	return &v
+}
+
+func TestApplyScoreBounds_FloorRaisesLowScore(t *testing.T) {
+	bounds := []profile.ScoreBound{{Tag: "critical", Floor: floatPtr(7.0)}}
+
+	score, applied := ApplyScoreBounds(3.2, []string{"Critical"}, nil, bounds)
+
+	assert.Equal(t, 7.0, score)
+	require.Len(t, applied, 1)
+	assert.Equal(t, "floor", applied[0].Kind)
+	assert.Equal(t, "tag:critical", applied[0].Match)
+	assert.Equal(t, 3.2, applied[0].Before)
+}
+
+func TestApplyScoreBounds_CeilingCapsHighScore(t *testing.T) {
+	bounds := []profile.ScoreBound{{Pattern: "Shiny Object", Ceiling: floatPtr(4.0)}}
+
+	score, applied := ApplyScoreBounds(9.1, nil, []string{"shiny object: chasing new tech"}, bounds)
+
+	assert.Equal(t, 4.0, score)
+	require.Len(t, applied, 1)
+	assert.Equal(t, "ceiling", applied[0].Kind)
+}
+
+func TestApplyScoreBounds_ScoreWithinBounds_Unchanged(t *testing.T) {
+	bounds := []profile.ScoreBound{{Tag: "critical", Floor: floatPtr(5.0), Ceiling: floatPtr(9.0)}}
+
+	score, applied := ApplyScoreBounds(6.5, []string{"critical"}, nil, bounds)
+
+	assert.Equal(t, 6.5, score)
+	assert.Empty(t, applied)
+}
+
+func TestApplyScoreBounds_NoMatch_Unchanged(t *testing.T) {
+	bounds := []profile.ScoreBound{{Tag: "critical", Floor: floatPtr(8.0)}}
+
+	score, applied := ApplyScoreBounds(2.0, []string{"someday"}, nil, bounds)
+
+	assert.Equal(t, 2.0, score)
+	assert.Empty(t, applied)
+}
+
+func TestApplyScoreBounds_StrictestRuleWins(t *testing.T) {
+	bounds := []profile.ScoreBound{
+		{Tag: "work", Floor: floatPtr(5.0)},
+		{Tag: "urgent", Floor: floatPtr(7.5)},
+		{Tag: "spam", Ceiling: floatPtr(6.0)},
+		{Tag: "noise", Ceiling: floatPtr(3.0)},
+	}
+
+	score, _ := ApplyScoreBounds(1.0, []string{"work", "urgent"}, nil, bounds)
+	assert.Equal(t, 7.5, score)
+
+	score, _ = ApplyScoreBounds(9.0, []string{"spam", "noise"}, nil, bounds)
+	assert.Equal(t, 3.0, score)
+}
+
+func TestApplyScoreBounds_ConflictingFloorAndCeiling_CeilingWins(t *testing.T) {
+	bounds := []profile.ScoreBound{
+		{Tag: "critical", Floor: floatPtr(8.0)},
+		{Tag: "spam", Ceiling: floatPtr(4.0)},
+	}
+
+	score, applied := ApplyScoreBounds(2.0, []string{"critical", "spam"}, nil, bounds)
+	assert.Equal(t, 2.0, score, "floor above ceiling must not raise the score")
+	assert.Empty(t, applied)
+
+	score, applied = ApplyScoreBounds(9.0, []string{"critical", "spam"}, nil, bounds)
+	assert.Equal(t, 4.0, score)
+	require.Len(t, applied, 1)
+	assert.Equal(t, "ceiling", applied[0].Kind)
+}
+
+func TestUniversalEngine_ApplyBounds_FlooredTagNeverBelowFloor(t *testing.T) {
+	p := testProfile()
+	p.ScoreBounds = []profile.ScoreBound{{Tag: "critical", Floor: floatPtr(8.0)}}
+	engine := NewUniversalEngine(p)
+
+	analysis, err := engine.Score("A comprehensive enterprise wholesale platform, eventually")
+	require.NoError(t, err)
+	require.Less(t, analysis.FinalScore, 8.0)
+
+	engine.ApplyBounds(analysis, []string{"critical"}, nil)
+
+	assert.GreaterOrEqual(t, analysis.FinalScore, 8.0)
+	assert.Equal(t, analysis.GetRecommendation(), analysis.Recommendation)
+	require.Len(t, analysis.Bounds, 1)
+}
+
+func TestUniversalEngine_ApplyBounds_CappedTagNeverAboveCeiling(t *testing.T) {
+	p := testProfile()
+	p.ScoreBounds = []profile.ScoreBound{{Tag: "distraction", Ceiling: floatPtr(2.0)}}
+	engine := NewUniversalEngine(p)
+
+	analysis, err := engine.Score("Sell simple pottery at the farmer's market this weekend")
+	require.NoError(t, err)
+	require.Greater(t, analysis.FinalScore, 2.0)
+
+	engine.ApplyBounds(analysis, []string{"distraction"}, nil)
+
+	assert.LessOrEqual(t, analysis.FinalScore, 2.0)
+	require.Len(t, analysis.Bounds, 1)
+	assert.Equal(t, "ceiling", analysis.Bounds[0].Kind)
+}
@@ -1,6 +1,12 @@
 package scoring
 
-import "time"
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/ryacub/telos-idea-matrix/internal/models"
+)
 
 // UniversalScores represents the scoring breakdown using universal dimensions.
 // These dimensions are domain-agnostic and work for any type of project.
@@ -47,6 +53,9 @@ type UniversalAnalysis struct {
 	// Insights are dimension-specific observations
 	Insights map[string]string `json:"insights,omitempty"`
 
+	// Bounds lists floors and ceilings that adjusted FinalScore
+	Bounds []AppliedBound `json:"bounds,omitempty"`
+
 	// AnalyzedAt records when the analysis was performed
 	AnalyzedAt time.Time `json:"analyzed_at"`
 
@@ -56,18 +65,7 @@ type UniversalAnalysis struct {
 
 // GetRecommendation returns a human-readable recommendation based on score.
 func (a *UniversalAnalysis) GetRecommendation() string {
-	switch {
-	case a.FinalScore >= 8.5:
-		return "GREAT FIT - Start this now"
-	case a.FinalScore >= 7.0:
-		return "GOOD FIT - Worth pursuing"
-	case a.FinalScore >= 5.0:
-		return "MAYBE - Consider carefully"
-	case a.FinalScore >= 3.0:
-		return "POOR FIT - Likely to struggle"
-	default:
-		return "AVOID - Not aligned with your goals"
-	}
+	return DefaultThresholds().Recommendation(a.FinalScore)
 }
 
 // DimensionScore holds information about a single scored dimension.
@@ -152,3 +150,52 @@ var ScoreThresholds = struct {
 	PoorFit:  3.0,
 	Avoid:    0.0,
 }
+
+// Thresholds are the minimum final scores for each recommendation.
+type Thresholds struct {
+	GreatFit float64 `yaml:"great_fit" json:"great_fit"`
+	GoodFit  float64 `yaml:"good_fit" json:"good_fit"`
+	Maybe    float64 `yaml:"maybe" json:"maybe"`
+	PoorFit  float64 `yaml:"poor_fit" json:"poor_fit"`
+}
+
+// DefaultThresholds returns the recommendation boundaries in use. Great, good
+// and maybe follow the configured recommendation cutoffs (see
+// models.CurrentRecommendationThresholds), which match ScoreThresholds by
+// default; poor fit stays at ScoreThresholds.PoorFit unless maybe is lower.
+func DefaultThresholds() Thresholds {
+	current := models.CurrentRecommendationThresholds()
+	return Thresholds{
+		GreatFit: current.Prioritize,
+		GoodFit:  current.Good,
+		Maybe:    current.Consider,
+		PoorFit:  math.Min(ScoreThresholds.PoorFit, current.Consider),
+	}
+}
+
+// Recommendation returns the verdict for a final score under these thresholds.
+func (t Thresholds) Recommendation(score float64) string {
+	switch {
+	case score >= t.GreatFit:
+		return "GREAT FIT - Start this now"
+	case score >= t.GoodFit:
+		return "GOOD FIT - Worth pursuing"
+	case score >= t.Maybe:
+		return "MAYBE - Consider carefully"
+	case score >= t.PoorFit:
+		return "POOR FIT - Likely to struggle"
+	default:
+		return "AVOID - Not aligned with your goals"
+	}
+}
+
+// Validate checks that thresholds lie within 0-10 and ascend from PoorFit to GreatFit.
+func (t Thresholds) Validate() error {
+	if t.PoorFit < 0 || t.GreatFit > 10 {
+		return fmt.Errorf("thresholds must be between 0 and 10")
+	}
+	if t.PoorFit > t.Maybe || t.Maybe > t.GoodFit || t.GoodFit > t.GreatFit {
+		return fmt.Errorf("thresholds must ascend: poor_fit <= maybe <= good_fit <= great_fit")
+	}
+	return nil
+}
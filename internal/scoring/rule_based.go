@@ -3,11 +3,24 @@ package scoring
 import (
 	"strings"
 	"unicode"
+
+	"github.com/ryacub/telos-idea-matrix/internal/models"
 )
 
+// StrategyKeywordWeight is the weight StrategyKeywordWeights gives to words
+// taken from telos strategies, double that of a default positive keyword
+const StrategyKeywordWeight = 2.0
+
+// maxKeywordPoints caps the points positive keywords can earn together
+const maxKeywordPoints = 3.0
+
 // RuleBasedScorer provides fast, heuristic-based scoring
 type RuleBasedScorer struct {
 	weights map[string]float64
+
+	// keywords maps a lowercase keyword to the points it adds when found in
+	// an idea; negative weights penalize vague language
+	keywords map[string]float64
 }
 
 // NewRuleBasedScorer creates a new rule-based scorer
@@ -19,11 +32,80 @@ func NewRuleBasedScorer() *RuleBasedScorer {
 			"telos_alignment": 3.0, // Max 3 points for telos keywords
 			"complexity":      2.0, // Max 2 points for idea complexity
 		},
+		keywords: DefaultKeywordWeights(),
+	}
+}
+
+// DefaultKeywordWeights returns the keyword weights NewRuleBasedScorer starts
+// with: 1 point for each positive keyword and -0.5 for each vague one
+func DefaultKeywordWeights() map[string]float64 {
+	weights := make(map[string]float64)
+	for _, keyword := range []string{
+		"innovation", "improve", "solve", "build", "create",
+		"impact", "sustainable", "efficient", "scale", "growth",
+		"productivity", "automate", "optimize", "enhance", "transform",
+	} {
+		weights[keyword] = 1.0
+	}
+	for _, keyword := range []string{
+		"maybe", "might", "possibly", "unclear", "vague",
+		"eventually", "someday", "unsure",
+	} {
+		weights[keyword] = -0.5
+	}
+	return weights
+}
+
+// WithKeywordWeights returns a copy of the scorer with weights merged over its
+// keyword weights, so "revenue": 2 makes revenue count double a default
+// keyword. Keywords match case-insensitively; a weight of 0 drops a keyword.
+func (s *RuleBasedScorer) WithKeywordWeights(weights map[string]float64) *RuleBasedScorer {
+	keywords := make(map[string]float64, len(s.keywords)+len(weights))
+	for keyword, weight := range s.keywords {
+		keywords[keyword] = weight
+	}
+	for keyword, weight := range weights {
+		keyword = strings.ToLower(strings.TrimSpace(keyword))
+		if keyword == "" {
+			continue
+		}
+		if weight == 0 {
+			delete(keywords, keyword)
+			continue
+		}
+		keywords[keyword] = weight
+	}
+
+	return &RuleBasedScorer{weights: s.weights, keywords: keywords}
+}
+
+// StrategyKeywordWeights seeds high-weight keywords from telos strategies.
+// Every significant word (longer than four letters and not a common word) in
+// a strategy description gets StrategyKeywordWeight, so a strategy such as
+// "Validate revenue before building" makes ideas mentioning "revenue" or
+// "validate" score higher. Pass the result to WithKeywordWeights.
+func StrategyKeywordWeights(strategies []models.Strategy) map[string]float64 {
+	weights := make(map[string]float64)
+	for _, strategy := range strategies {
+		for _, word := range extractImportantWords(strategy.Description) {
+			weights[word] = StrategyKeywordWeight
+		}
 	}
+	return weights
 }
 
 // Score calculates a rule-based score (0-10)
 func (s *RuleBasedScorer) Score(content, telos string) float64 {
+	if telos == "" {
+		// If no telos, redistribute weight to other factors
+		return s.scoreWithAlignment(content, 1.5) // Neutral score
+	}
+	return s.scoreWithAlignment(content, s.scoreTelosAlignment(content, telos)*s.weights["telos_alignment"]/3.0)
+}
+
+// scoreWithAlignment totals the content-based scores and the given telos
+// alignment points (0-3)
+func (s *RuleBasedScorer) scoreWithAlignment(content string, alignment float64) float64 {
 	var totalScore float64
 
 	// 1. Keyword matching (0-3 points)
@@ -35,13 +117,7 @@ func (s *RuleBasedScorer) Score(content, telos string) float64 {
 	totalScore += lengthScore * s.weights["length"] / 2.0
 
 	// 3. Telos alignment (0-3 points)
-	if telos != "" {
-		telosScore := s.scoreTelosAlignment(content, telos)
-		totalScore += telosScore * s.weights["telos_alignment"] / 3.0
-	} else {
-		// If no telos, redistribute weight to other factors
-		totalScore += 1.5 // Neutral score
-	}
+	totalScore += alignment
 
 	// 4. Complexity/detail (0-2 points)
 	complexityScore := s.scoreComplexity(content)
@@ -58,41 +134,28 @@ func (s *RuleBasedScorer) Score(content, telos string) float64 {
 	return totalScore
 }
 
-// scoreKeywords scores based on positive and negative keywords
+// scoreKeywords sums the weights of the keywords found in content. Positive
+// weights together earn at most 3 points before negative weights are applied.
 func (s *RuleBasedScorer) scoreKeywords(content string) float64 {
 	contentLower := strings.ToLower(content)
 
-	positiveKeywords := []string{
-		"innovation", "improve", "solve", "build", "create",
-		"impact", "sustainable", "efficient", "scale", "growth",
-		"productivity", "automate", "optimize", "enhance", "transform",
-	}
-
-	negativeKeywords := []string{
-		"maybe", "might", "possibly", "unclear", "vague",
-		"eventually", "someday", "unsure",
-	}
-
-	score := 0.0
-
-	// Add points for positive keywords (max 3 points)
-	matches := 0
-	for _, keyword := range positiveKeywords {
-		if strings.Contains(contentLower, keyword) {
-			matches++
-			if matches >= 3 {
-				break
-			}
+	var positive, negative float64
+	for keyword, weight := range s.keywords {
+		if !strings.Contains(contentLower, keyword) {
+			continue
+		}
+		if weight > 0 {
+			positive += weight
+		} else {
+			negative += weight
 		}
 	}
-	score += float64(matches)
 
-	// Subtract points for negative keywords
-	for _, keyword := range negativeKeywords {
-		if strings.Contains(contentLower, keyword) {
-			score -= 0.5
-		}
+	score := positive
+	if score > maxKeywordPoints {
+		score = maxKeywordPoints
 	}
+	score += negative
 
 	if score < 0 {
 		score = 0
@@ -3,6 +3,8 @@ package scoring
 import (
 	"strings"
 	"testing"
+
+	"github.com/ryacub/telos-idea-matrix/internal/models"
 )
 
 func TestRuleBasedScorer_Score(t *testing.T) {
@@ -72,6 +74,103 @@ func TestScoreKeywords(t *testing.T) {
 	}
 }
 
+// TestRuleBasedScorer_DefaultScoresPinned pins the scores of the default
+// keyword weights so weighting changes can't silently shift existing ideas.
+func TestRuleBasedScorer_DefaultScoresPinned(t *testing.T) {
+	scorer := NewRuleBasedScorer()
+
+	tests := []struct {
+		content  string
+		telos    string
+		keywords float64
+		score    float64
+	}{
+		{
+			content:  "Build an innovative mobile app to improve productivity through automation and smart scheduling",
+			telos:    "Focus on productivity and innovation",
+			keywords: 3.0,
+			score:    6.5,
+		},
+		{content: "Maybe do something", keywords: 0.0, score: 3.0},
+		{
+			content:  "Create a website for local businesses",
+			telos:    "Support local communities",
+			keywords: 1.0,
+			score:    4.5,
+		},
+		{
+			content:  "Build a revenue dashboard app. It might help someday. Automate invoices to improve growth and scale the business quickly for small teams.",
+			telos:    "Grow revenue through automation",
+			keywords: 2.0,
+			score:    7.0,
+		},
+		{content: "Innovation, growth, scale, impact and efficient automation for everyone", keywords: 3.0, score: 7.0},
+	}
+
+	for _, tt := range tests {
+		if got := scorer.scoreKeywords(tt.content); got != tt.keywords {
+			t.Errorf("scoreKeywords(%q) = %.2f, want %.2f", tt.content, got, tt.keywords)
+		}
+		if got := scorer.Score(tt.content, tt.telos); got != tt.score {
+			t.Errorf("Score(%q) = %.2f, want %.2f", tt.content, got, tt.score)
+		}
+	}
+}
+
+func TestRuleBasedScorer_WithKeywordWeights(t *testing.T) {
+	base := NewRuleBasedScorer()
+	weighted := base.WithKeywordWeights(map[string]float64{
+		"Revenue": 2.0,
+		"app":     0.5,
+		"maybe":   0,
+	})
+
+	tests := []struct {
+		content string
+		want    float64
+	}{
+		{"a revenue tracker", 2.0},
+		{"a habit app", 0.5},
+		{"revenue app to build", 3.0}, // positive weights cap at 3
+		{"maybe a revenue tracker", 2.0},
+		{"a revenue tracker someday", 1.5},
+	}
+
+	for _, tt := range tests {
+		if got := weighted.scoreKeywords(tt.content); got != tt.want {
+			t.Errorf("scoreKeywords(%q) = %.2f, want %.2f", tt.content, got, tt.want)
+		}
+	}
+
+	if weighted.scoreKeywords("a revenue tracker") <= weighted.scoreKeywords("a habit app") {
+		t.Error("revenue should count more than app")
+	}
+	if got := base.scoreKeywords("a revenue tracker"); got != 0 {
+		t.Errorf("WithKeywordWeights modified the original scorer: got %.2f", got)
+	}
+}
+
+func TestStrategyKeywordWeights(t *testing.T) {
+	weights := StrategyKeywordWeights([]models.Strategy{
+		{ID: "S1", Description: "Validate revenue before building"},
+		{ID: "S2", Description: "Ship in public"},
+	})
+
+	for _, word := range []string{"validate", "revenue", "before", "building"} {
+		if weights[word] != StrategyKeywordWeight {
+			t.Errorf("weights[%q] = %.2f, want %.2f", word, weights[word], StrategyKeywordWeight)
+		}
+	}
+	if _, ok := weights["ship"]; ok {
+		t.Error("short words should not become keywords")
+	}
+
+	scorer := NewRuleBasedScorer().WithKeywordWeights(weights)
+	if got := scorer.scoreKeywords("Charge for revenue from day one"); got != StrategyKeywordWeight {
+		t.Errorf("scoreKeywords() = %.2f, want %.2f", got, StrategyKeywordWeight)
+	}
+}
+
 func TestScoreLength(t *testing.T) {
 	scorer := NewRuleBasedScorer()
 
@@ -0,0 +1,165 @@
+package scoring
+
+import (
+	"context"
+	"math"
+	"strings"
+	"sync"
+
+	"github.com/ryacub/telos-idea-matrix/internal/models"
+)
+
+// SemanticWeight is the share of the telos alignment points that comes from
+// embedding similarity when an embedder is available; the rest still comes
+// from keyword matches
+const SemanticWeight = 0.6
+
+// Cosine similarities at or below semanticFloor earn no semantic alignment
+// points and those at or above semanticCeiling earn all of them. Embedding
+// models rarely score unrelated text near 0 or paraphrases near 1, so the
+// range between is stretched over the full 0-3 points.
+const (
+	semanticFloor   = 0.2
+	semanticCeiling = 0.8
+)
+
+// Embedder turns text into a vector whose cosine similarity to another
+// text's vector reflects how close they are in meaning. The embedders in
+// package llm satisfy it.
+type Embedder interface {
+	Embed(ctx context.Context, text string) ([]float32, error)
+}
+
+// SemanticScorer is a RuleBasedScorer whose telos alignment also measures
+// meaning rather than only shared keywords. It embeds the idea and each
+// mission, goal and strategy, and blends the similarity with the keyword
+// alignment by SemanticWeight. Without an embedder, or when embedding
+// fails, it scores exactly like the rule-based scorer it wraps.
+type SemanticScorer struct {
+	base     *RuleBasedScorer
+	embedder Embedder
+
+	mu      sync.Mutex
+	vectors map[string][]float32 // Telos element embeddings by text
+}
+
+// NewSemanticScorer wraps base, or a default RuleBasedScorer when base is
+// nil. A nil embedder disables semantic alignment.
+func NewSemanticScorer(base *RuleBasedScorer, embedder Embedder) *SemanticScorer {
+	if base == nil {
+		base = NewRuleBasedScorer()
+	}
+	return &SemanticScorer{
+		base:     base,
+		embedder: embedder,
+		vectors:  make(map[string][]float32),
+	}
+}
+
+// Score calculates the blended score (0-10) of content against telos
+func (s *SemanticScorer) Score(ctx context.Context, content string, telos *models.Telos) float64 {
+	elements := telosElements(telos)
+	if len(elements) == 0 {
+		return s.base.Score(content, "")
+	}
+
+	alignment := s.base.scoreTelosAlignment(content, strings.Join(elements, "\n"))
+	if semantic, ok := s.semanticAlignment(ctx, content, elements); ok {
+		alignment = SemanticWeight*semantic + (1-SemanticWeight)*alignment
+	}
+	return s.base.scoreWithAlignment(content, alignment*s.base.weights["telos_alignment"]/3.0)
+}
+
+// semanticAlignment scores (0-3) how close content is in meaning to the
+// telos elements, from the mean of the best and the average similarity, so
+// an idea must serve one element strongly or several reasonably well. It
+// reports false when no embedding could be computed.
+func (s *SemanticScorer) semanticAlignment(ctx context.Context, content string, elements []string) (float64, bool) {
+	if s.embedder == nil {
+		return 0, false
+	}
+	idea, err := s.embedder.Embed(ctx, content)
+	if err != nil {
+		return 0, false
+	}
+
+	var best, sum float64
+	for i, element := range elements {
+		vector, err := s.elementVector(ctx, element)
+		if err != nil {
+			return 0, false
+		}
+		similarity := cosineSimilarity(idea, vector)
+		if i == 0 || similarity > best {
+			best = similarity
+		}
+		sum += similarity
+	}
+	similarity := (best + sum/float64(len(elements))) / 2
+
+	fraction := (similarity - semanticFloor) / (semanticCeiling - semanticFloor)
+	return 3 * math.Max(0, math.Min(1, fraction)), true
+}
+
+// elementVector embeds a telos element, reusing earlier embeddings of the
+// same text since telos changes rarely
+func (s *SemanticScorer) elementVector(ctx context.Context, text string) ([]float32, error) {
+	s.mu.Lock()
+	vector, ok := s.vectors[text]
+	s.mu.Unlock()
+	if ok {
+		return vector, nil
+	}
+
+	vector, err := s.embedder.Embed(ctx, text)
+	if err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	s.vectors[text] = vector
+	s.mu.Unlock()
+	return vector, nil
+}
+
+// telosElements lists the non-empty mission, goal and strategy descriptions
+func telosElements(telos *models.Telos) []string {
+	if telos == nil {
+		return nil
+	}
+	var elements []string
+	add := func(description string) {
+		if description = strings.TrimSpace(description); description != "" {
+			elements = append(elements, description)
+		}
+	}
+	for _, mission := range telos.Missions {
+		add(mission.Description)
+	}
+	for _, goal := range telos.Goals {
+		add(goal.Description)
+	}
+	for _, strategy := range telos.Strategies {
+		add(strategy.Description)
+	}
+	return elements
+}
+
+// cosineSimilarity returns the cosine of the angle between a and b, or 0 for
+// vectors of different lengths or with no magnitude. It matches
+// llm.CosineSimilarity, which can't be used here since llm imports scoring.
+func cosineSimilarity(a, b []float32) float64 {
+	if len(a) != len(b) || len(a) == 0 {
+		return 0
+	}
+
+	var dot, normA, normB float64
+	for i := range a {
+		dot += float64(a[i]) * float64(b[i])
+		normA += float64(a[i]) * float64(a[i])
+		normB += float64(b[i]) * float64(b[i])
+	}
+	if normA == 0 || normB == 0 {
+		return 0
+	}
+	return dot / (math.Sqrt(normA) * math.Sqrt(normB))
+}
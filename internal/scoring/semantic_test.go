@@ -0,0 +1,134 @@
+package scoring
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/ryacub/telos-idea-matrix/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// stubEmbedder returns fixed vectors by text and counts calls per text
+type stubEmbedder struct {
+	vectors map[string][]float32
+	calls   map[string]int
+	err     error
+}
+
+func (e *stubEmbedder) Embed(_ context.Context, text string) ([]float32, error) {
+	if e.calls == nil {
+		e.calls = make(map[string]int)
+	}
+	e.calls[text]++
+	if e.err != nil {
+		return nil, e.err
+	}
+	if vector, ok := e.vectors[text]; ok {
+		return vector, nil
+	}
+	return []float32{0, 0, 1}, nil
+}
+
+const (
+	semanticGoal     = "Reach financial independence"
+	semanticStrategy = "Ship small products every month"
+	// Shares no keywords with the telos, so keyword alignment is 0
+	alignedIdea    = "Sell a paid newsletter for indie makers"
+	misalignedIdea = "Plant tomatoes along the garden fence"
+)
+
+func semanticTelos() *models.Telos {
+	return &models.Telos{
+		Goals:      []models.Goal{{ID: "G1", Description: semanticGoal}},
+		Strategies: []models.Strategy{{ID: "S1", Description: semanticStrategy}},
+	}
+}
+
+func semanticStub() *stubEmbedder {
+	return &stubEmbedder{vectors: map[string][]float32{
+		semanticGoal:     {1, 0, 0},
+		semanticStrategy: {1, 0, 0},
+		alignedIdea:      {2, 0, 0},
+		misalignedIdea:   {0, 1, 0},
+	}}
+}
+
+func keywordOnlyScore(content string) float64 {
+	return NewRuleBasedScorer().Score(content, semanticGoal+"\n"+semanticStrategy)
+}
+
+func TestSemanticScorer_BlendsSimilarityIntoAlignment(t *testing.T) {
+	scorer := NewSemanticScorer(nil, semanticStub())
+	ctx := context.Background()
+
+	// Identical direction to every element earns the full 3 semantic points
+	aligned := scorer.Score(ctx, alignedIdea, semanticTelos())
+	assert.InDelta(t, keywordOnlyScore(alignedIdea)+SemanticWeight*3, aligned, 0.001)
+
+	// Orthogonal to every element earns none
+	misaligned := scorer.Score(ctx, misalignedIdea, semanticTelos())
+	assert.InDelta(t, keywordOnlyScore(misalignedIdea), misaligned, 0.001)
+}
+
+func TestSemanticScorer_PartialSimilarity(t *testing.T) {
+	stub := semanticStub()
+	// cos 60° = 0.5 to the goal, orthogonal to the strategy
+	stub.vectors[semanticStrategy] = []float32{0, 0, 1}
+	stub.vectors[alignedIdea] = []float32{0.5, 0.866, 0}
+	scorer := NewSemanticScorer(nil, stub)
+
+	// best 0.5, mean 0.25: similarity 0.375 is 29% of the 0.2-0.8 range
+	got := scorer.Score(context.Background(), alignedIdea, semanticTelos())
+	assert.InDelta(t, keywordOnlyScore(alignedIdea)+SemanticWeight*3*(0.375-0.2)/0.6, got, 0.001)
+}
+
+func TestSemanticScorer_FallsBackToKeywords(t *testing.T) {
+	tests := []struct {
+		name     string
+		embedder Embedder
+	}{
+		{"no embedder", nil},
+		{"embedder fails", &stubEmbedder{err: errors.New("connection refused")}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scorer := NewSemanticScorer(nil, tt.embedder)
+			content := "Build a tool to automate financial reports every month"
+
+			got := scorer.Score(context.Background(), content, semanticTelos())
+			assert.InDelta(t, keywordOnlyScore(content), got, 0.001)
+		})
+	}
+}
+
+func TestSemanticScorer_NoTelos(t *testing.T) {
+	scorer := NewSemanticScorer(nil, semanticStub())
+	content := "Build a habit tracker"
+
+	assert.InDelta(t, NewRuleBasedScorer().Score(content, ""), scorer.Score(context.Background(), content, nil), 0.001)
+	assert.InDelta(t, NewRuleBasedScorer().Score(content, ""), scorer.Score(context.Background(), content, &models.Telos{}), 0.001)
+}
+
+func TestSemanticScorer_CachesTelosEmbeddings(t *testing.T) {
+	stub := semanticStub()
+	scorer := NewSemanticScorer(nil, stub)
+
+	scorer.Score(context.Background(), alignedIdea, semanticTelos())
+	scorer.Score(context.Background(), misalignedIdea, semanticTelos())
+
+	assert.Equal(t, 1, stub.calls[semanticGoal])
+	assert.Equal(t, 1, stub.calls[semanticStrategy])
+	assert.Equal(t, 1, stub.calls[alignedIdea])
+}
+
+func TestSemanticScorer_UsesBaseKeywordWeights(t *testing.T) {
+	base := NewRuleBasedScorer().WithKeywordWeights(map[string]float64{"tomatoes": 3})
+	scorer := NewSemanticScorer(base, nil)
+
+	got := scorer.Score(context.Background(), misalignedIdea, semanticTelos())
+	assert.InDelta(t, base.Score(misalignedIdea, strings.Join([]string{semanticGoal, semanticStrategy}, "\n")), got, 0.001)
+	assert.Greater(t, got, keywordOnlyScore(misalignedIdea))
+}
@@ -0,0 +1,188 @@
+package scoring
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"sort"
+
+	"github.com/ryacub/telos-idea-matrix/internal/models"
+	"github.com/ryacub/telos-idea-matrix/internal/profile"
+	"gopkg.in/yaml.v3"
+)
+
+// Settings are the tunable parts of universal scoring.
+type Settings struct {
+	Weights    Weights    `yaml:"weights" json:"weights"`
+	Thresholds Thresholds `yaml:"thresholds" json:"thresholds"`
+}
+
+// SimulationItem is an idea's recomputed dimension scores plus what score bounds match on.
+type SimulationItem struct {
+	ID       string
+	Content  string
+	Scores   UniversalScores
+	Tags     []string
+	Patterns []string
+}
+
+// SimulatedIdea is one idea's score and recommendation under current and proposed settings.
+type SimulatedIdea struct {
+	ID                   string  `json:"id"`
+	Content              string  `json:"content"`
+	ScoreBefore          float64 `json:"score_before"`
+	ScoreAfter           float64 `json:"score_after"`
+	RecommendationBefore string  `json:"recommendation_before"`
+	RecommendationAfter  string  `json:"recommendation_after"`
+}
+
+// Delta returns how far the score moves under the proposed settings
+func (s SimulatedIdea) Delta() float64 {
+	return s.ScoreAfter - s.ScoreBefore
+}
+
+// SimulationResult summarizes how proposed settings would change scoring.
+type SimulationResult struct {
+	Total              int             `json:"total"`
+	Flips              int             `json:"flips"` // Ideas whose recommendation changes
+	AverageBefore      float64         `json:"average_before"`
+	AverageAfter       float64         `json:"average_after"`
+	DistributionBefore map[string]int  `json:"distribution_before"` // Recommendation -> count
+	DistributionAfter  map[string]int  `json:"distribution_after"`
+	Ideas              []SimulatedIdea `json:"ideas"` // Ordered by largest absolute score change
+}
+
+// SimulateScores scores each item under the current and proposed settings.
+// Score bounds are applied to both sides so only the settings differ.
+// Nothing is persisted.
+func SimulateScores(items []SimulationItem, bounds []profile.ScoreBound, current, proposed Settings) SimulationResult {
+	result := SimulationResult{
+		Total:              len(items),
+		DistributionBefore: make(map[string]int),
+		DistributionAfter:  make(map[string]int),
+		Ideas:              make([]SimulatedIdea, 0, len(items)),
+	}
+
+	var sumBefore, sumAfter float64
+	for _, item := range items {
+		before, _ := ApplyScoreBounds(WeightedScore(item.Scores, current.Weights), item.Tags, item.Patterns, bounds)
+		after, _ := ApplyScoreBounds(WeightedScore(item.Scores, proposed.Weights), item.Tags, item.Patterns, bounds)
+
+		simulated := SimulatedIdea{
+			ID:                   item.ID,
+			Content:              item.Content,
+			ScoreBefore:          before,
+			ScoreAfter:           after,
+			RecommendationBefore: current.Thresholds.Recommendation(before),
+			RecommendationAfter:  proposed.Thresholds.Recommendation(after),
+		}
+		if simulated.RecommendationBefore != simulated.RecommendationAfter {
+			result.Flips++
+		}
+
+		result.DistributionBefore[simulated.RecommendationBefore]++
+		result.DistributionAfter[simulated.RecommendationAfter]++
+		sumBefore += before
+		sumAfter += after
+		result.Ideas = append(result.Ideas, simulated)
+	}
+
+	if len(items) > 0 {
+		result.AverageBefore = sumBefore / float64(len(items))
+		result.AverageAfter = sumAfter / float64(len(items))
+	}
+
+	sort.SliceStable(result.Ideas, func(i, j int) bool {
+		return math.Abs(result.Ideas[i].Delta()) > math.Abs(result.Ideas[j].Delta())
+	})
+
+	return result
+}
+
+// Simulate recomputes rule-based scores for stored ideas under the engine's
+// current settings and the proposed ones, without persisting anything.
+func (e *UniversalEngine) Simulate(ideas []*models.Idea, proposed Settings) (SimulationResult, error) {
+	items := make([]SimulationItem, 0, len(ideas))
+	for _, idea := range ideas {
+		analysis, err := e.Score(idea.Content)
+		if err != nil {
+			return SimulationResult{}, fmt.Errorf("failed to score idea %s: %w", idea.ID, err)
+		}
+		items = append(items, SimulationItem{
+			ID:       idea.ID,
+			Content:  idea.Content,
+			Scores:   analysis.Universal,
+			Tags:     idea.Tags,
+			Patterns: idea.Patterns,
+		})
+	}
+
+	return SimulateScores(items, e.profile.ScoreBounds, e.CurrentSettings(), proposed), nil
+}
+
+// CurrentSettings returns the weights and thresholds the engine scores with.
+func (e *UniversalEngine) CurrentSettings() Settings {
+	weights := make(Weights, len(e.profile.Priorities))
+	for dim, weight := range e.profile.Priorities {
+		weights[dim] = weight
+	}
+	return Settings{Weights: weights, Thresholds: DefaultThresholds()}
+}
+
+// LoadSettings reads proposed settings from a YAML file, starting from base.
+// Weights and thresholds left out of the file keep their base values, e.g.:
+//
+//	weights:
+//	  skill_fit: 0.25
+//	  sustainability: 0.05
+//	thresholds:
+//	  good_fit: 6.5
+func LoadSettings(path string, base Settings) (Settings, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Settings{}, fmt.Errorf("failed to read settings file: %w", err)
+	}
+
+	// Decode over a copy of base so omitted fields keep their values
+	proposed := Settings{Weights: make(Weights, len(base.Weights)), Thresholds: base.Thresholds}
+	for dim, weight := range base.Weights {
+		proposed.Weights[dim] = weight
+	}
+	if err := yaml.Unmarshal(data, &proposed); err != nil {
+		return Settings{}, fmt.Errorf("failed to parse settings file: %w", err)
+	}
+
+	if err := validateWeights(proposed.Weights); err != nil {
+		return Settings{}, err
+	}
+	if err := proposed.Thresholds.Validate(); err != nil {
+		return Settings{}, err
+	}
+
+	return proposed, nil
+}
+
+// validateWeights checks weights cover known dimensions, lie within 0-1, and sum to 1.0
+func validateWeights(w Weights) error {
+	known := make(map[string]bool)
+	for _, dim := range profile.AllDimensions() {
+		known[dim] = true
+	}
+
+	sum := 0.0
+	for dim, weight := range w {
+		if !known[dim] {
+			return fmt.Errorf("unknown weight %q", dim)
+		}
+		if weight < 0 || weight > 1 {
+			return fmt.Errorf("weight for %s must be between 0 and 1, got %.2f", dim, weight)
+		}
+		sum += weight
+	}
+
+	// Same tolerance as profile validation
+	if math.Abs(sum-1.0) > 0.01 {
+		return fmt.Errorf("weights must sum to 1.0, got %.2f", sum)
+	}
+	return nil
+}
@@ -0,0 +1,139 @@
+package scoring
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ryacub/telos-idea-matrix/internal/profile"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// simulationItems scores only skill fit and time to done, so weight changes are easy to follow
+func simulationItems() []SimulationItem {
+	return []SimulationItem{
+		{ID: "skill-heavy", Scores: UniversalScores{SkillFit: 2.0, TimeToDone: 0.0}},  // 5.0 → 8.0
+		{ID: "time-heavy", Scores: UniversalScores{SkillFit: 0.0, TimeToDone: 2.0}},   // 5.0 → 2.0
+		{ID: "balanced", Scores: UniversalScores{SkillFit: 1.6, TimeToDone: 1.6}},     // 8.0 → 8.0
+		{ID: "mostly-skill", Scores: UniversalScores{SkillFit: 1.8, TimeToDone: 1.2}}, // 7.5 → 8.4
+	}
+}
+
+func twoDimensionWeights(skill, time float64) Weights {
+	return Weights{profile.DimensionSkillFit: skill, profile.DimensionTimeToDone: time}
+}
+
+func TestSimulateScores_CountsRecommendationFlips(t *testing.T) {
+	current := Settings{Weights: twoDimensionWeights(0.5, 0.5), Thresholds: DefaultThresholds()}
+	proposed := Settings{Weights: twoDimensionWeights(0.8, 0.2), Thresholds: DefaultThresholds()}
+
+	result := SimulateScores(simulationItems(), nil, current, proposed)
+
+	assert.Equal(t, 4, result.Total)
+	assert.Equal(t, 2, result.Flips, "skill-heavy rises to GOOD FIT and time-heavy drops to AVOID")
+	assert.InDelta(t, 6.375, result.AverageBefore, 0.001)
+	assert.InDelta(t, 6.6, result.AverageAfter, 0.001)
+
+	maybe := DefaultThresholds().Recommendation(5.0)
+	good := DefaultThresholds().Recommendation(7.0)
+	avoid := DefaultThresholds().Recommendation(0)
+	assert.Equal(t, map[string]int{maybe: 2, good: 2}, result.DistributionBefore)
+	assert.Equal(t, map[string]int{good: 3, avoid: 1}, result.DistributionAfter)
+
+	ids := make([]string, len(result.Ideas))
+	for i, idea := range result.Ideas {
+		ids[i] = idea.ID
+	}
+	assert.Equal(t, []string{"skill-heavy", "time-heavy", "mostly-skill", "balanced"}, ids,
+		"ideas should be ordered by largest absolute score change")
+}
+
+func TestSimulateScores_ThresholdChangeOnly(t *testing.T) {
+	current := Settings{Weights: twoDimensionWeights(0.5, 0.5), Thresholds: DefaultThresholds()}
+	stricter := DefaultThresholds()
+	stricter.GoodFit = 8.2
+	proposed := Settings{Weights: twoDimensionWeights(0.5, 0.5), Thresholds: stricter}
+
+	result := SimulateScores(simulationItems(), nil, current, proposed)
+
+	assert.Equal(t, 2, result.Flips, "balanced and mostly-skill fall below the raised GOOD FIT bar")
+	assert.Equal(t, result.AverageBefore, result.AverageAfter)
+}
+
+func TestSimulateScores_AppliesScoreBoundsToBothSides(t *testing.T) {
+	current := Settings{Weights: twoDimensionWeights(0.5, 0.5), Thresholds: DefaultThresholds()}
+	proposed := Settings{Weights: twoDimensionWeights(0.8, 0.2), Thresholds: DefaultThresholds()}
+	ceiling := 4.0
+	bounds := []profile.ScoreBound{{Tag: "someday", Ceiling: &ceiling}}
+
+	items := simulationItems()[:1]
+	items[0].Tags = []string{"someday"}
+
+	result := SimulateScores(items, bounds, current, proposed)
+
+	assert.Equal(t, 0, result.Flips)
+	assert.Equal(t, 4.0, result.Ideas[0].ScoreBefore)
+	assert.Equal(t, 4.0, result.Ideas[0].ScoreAfter)
+}
+
+func TestLoadSettings(t *testing.T) {
+	base := Settings{Weights: Weights(profile.DefaultProfile().Priorities), Thresholds: DefaultThresholds()}
+
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{
+			name:    "thresholds only",
+			content: "thresholds:\n  good_fit: 6.5\n",
+		},
+		{
+			name:    "weights that no longer sum to one",
+			content: "weights:\n  skill_fit: 0.9\n",
+			wantErr: "must sum to 1.0",
+		},
+		{
+			name:    "unknown dimension",
+			content: "weights:\n  vibes: 0.1\n",
+			wantErr: `unknown weight "vibes"`,
+		},
+		{
+			name:    "thresholds out of order",
+			content: "thresholds:\n  maybe: 7.5\n",
+			wantErr: "must ascend",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "settings.yaml")
+			require.NoError(t, os.WriteFile(path, []byte(tt.content), 0600))
+
+			settings, err := LoadSettings(path, base)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, 6.5, settings.Thresholds.GoodFit)
+			assert.Equal(t, base.Thresholds.GreatFit, settings.Thresholds.GreatFit, "omitted thresholds keep their base value")
+			assert.Equal(t, base.Weights, settings.Weights, "omitted weights keep their base value")
+		})
+	}
+}
+
+func TestLoadSettings_DoesNotModifyBase(t *testing.T) {
+	base := Settings{Weights: Weights(profile.DefaultProfile().Priorities), Thresholds: DefaultThresholds()}
+	original := base.Weights[profile.DimensionSkillFit]
+
+	path := filepath.Join(t.TempDir(), "settings.yaml")
+	content := "weights:\n  skill_fit: 0.25\n  sustainability: 0.05\n"
+	require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+
+	_, err := LoadSettings(path, base)
+	require.NoError(t, err)
+	assert.Equal(t, original, base.Weights[profile.DimensionSkillFit])
+}
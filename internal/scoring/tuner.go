@@ -0,0 +1,115 @@
+package scoring
+
+import (
+	"math"
+
+	"github.com/ryacub/telos-idea-matrix/internal/profile"
+)
+
+// Weight tuning bounds. Every dimension keeps some influence, and no single
+// dimension is allowed to dominate the score.
+const (
+	TuneStep          = 0.05 // Weight moved between dimensions per step
+	TuneMinWeight     = 0.05 // Lowest weight a dimension can be tuned down to
+	TuneMaxWeight     = 0.40 // Highest weight a dimension can be tuned up to
+	tuneMaxIterations = 100
+)
+
+// Weights maps scoring dimensions to priority weights (summing to 1.0),
+// in the same form as profile.Profile.Priorities.
+type Weights map[string]float64
+
+// LabeledIdea is an idea's dimension scores paired with the user's verdict on it.
+type LabeledIdea struct {
+	Scores UniversalScores
+	Good   bool
+}
+
+// WeightedScore calculates the 0-10 total for dimension scores under the given weights.
+func WeightedScore(scores UniversalScores, w Weights) float64 {
+	total := 0.0
+
+	total += scores.CompletionLikelihood * w[profile.DimensionCompletionLikelihood] * ScaleMajor
+	total += scores.SkillFit * w[profile.DimensionSkillFit] * ScaleMajor
+	total += scores.TimeToDone * w[profile.DimensionTimeToDone] * ScaleMajor
+	total += scores.RewardAlignment * w[profile.DimensionRewardAlignment] * ScaleMajor
+	total += scores.Sustainability * w[profile.DimensionSustainability] * ScaleMinor
+	total += scores.AvoidanceFit * w[profile.DimensionAvoidanceFit] * ScaleMinor
+
+	return math.Max(0, math.Min(10, total))
+}
+
+// Separation measures how well weights distinguish good ideas from bad ones:
+// the mean score of good ideas minus the mean score of bad ideas.
+// Returns 0 unless there is at least one idea of each label.
+func Separation(labeled []LabeledIdea, w Weights) float64 {
+	var goodSum, badSum float64
+	var goodCount, badCount int
+
+	for _, idea := range labeled {
+		score := WeightedScore(idea.Scores, w)
+		if idea.Good {
+			goodSum += score
+			goodCount++
+		} else {
+			badSum += score
+			badCount++
+		}
+	}
+
+	if goodCount == 0 || badCount == 0 {
+		return 0
+	}
+	return goodSum/float64(goodCount) - badSum/float64(badCount)
+}
+
+// SuggestWeights searches for weights that better separate good ideas from bad ones.
+// Starting from base, it repeatedly moves TuneStep of weight from one dimension to
+// another, taking the move that most improves Separation, until no move helps.
+// Weights stay within TuneMinWeight-TuneMaxWeight and keep summing to the base total.
+// The base is returned unchanged when labeled lacks a good or a bad idea.
+func SuggestWeights(labeled []LabeledIdea, base Weights) Weights {
+	suggested := make(Weights, len(base))
+	for dim, weight := range base {
+		suggested[dim] = weight
+	}
+
+	best := Separation(labeled, suggested)
+	dims := profile.AllDimensions()
+
+	for i := 0; i < tuneMaxIterations; i++ {
+		bestFrom, bestTo := "", ""
+
+		for _, from := range dims {
+			if suggested[from]-TuneStep < TuneMinWeight-1e-9 {
+				continue
+			}
+			for _, to := range dims {
+				if to == from || suggested[to]+TuneStep > TuneMaxWeight+1e-9 {
+					continue
+				}
+
+				suggested[from] -= TuneStep
+				suggested[to] += TuneStep
+				if sep := Separation(labeled, suggested); sep > best+1e-9 {
+					best, bestFrom, bestTo = sep, from, to
+				}
+				suggested[from] += TuneStep
+				suggested[to] -= TuneStep
+			}
+		}
+
+		if bestFrom == "" {
+			break
+		}
+		suggested[bestFrom] -= TuneStep
+		suggested[bestTo] += TuneStep
+	}
+
+	// Round away floating point drift from repeated steps
+	for dim, weight := range suggested {
+		suggested[dim] = math.Round(weight*1000) / 1000
+	}
+
+	return suggested
+}
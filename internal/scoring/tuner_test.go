@@ -0,0 +1,65 @@
+package scoring
+
+import (
+	"testing"
+
+	"github.com/ryacub/telos-idea-matrix/internal/profile"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSuggestWeights_ImprovesSeparation(t *testing.T) {
+	// Good ideas fit the user's skills; bad ones only look quick to build.
+	// The other dimensions are identical, so they carry no signal.
+	labeled := []LabeledIdea{
+		{Good: true, Scores: UniversalScores{CompletionLikelihood: 1.0, SkillFit: 1.9, TimeToDone: 0.6, RewardAlignment: 1.0, Sustainability: 0.5, AvoidanceFit: 0.5}},
+		{Good: true, Scores: UniversalScores{CompletionLikelihood: 1.0, SkillFit: 1.8, TimeToDone: 0.4, RewardAlignment: 1.0, Sustainability: 0.5, AvoidanceFit: 0.5}},
+		{Good: true, Scores: UniversalScores{CompletionLikelihood: 1.0, SkillFit: 2.0, TimeToDone: 0.5, RewardAlignment: 1.0, Sustainability: 0.5, AvoidanceFit: 0.5}},
+		{Good: false, Scores: UniversalScores{CompletionLikelihood: 1.0, SkillFit: 0.3, TimeToDone: 1.9, RewardAlignment: 1.0, Sustainability: 0.5, AvoidanceFit: 0.5}},
+		{Good: false, Scores: UniversalScores{CompletionLikelihood: 1.0, SkillFit: 0.5, TimeToDone: 2.0, RewardAlignment: 1.0, Sustainability: 0.5, AvoidanceFit: 0.5}},
+		{Good: false, Scores: UniversalScores{CompletionLikelihood: 1.0, SkillFit: 0.4, TimeToDone: 1.8, RewardAlignment: 1.0, Sustainability: 0.5, AvoidanceFit: 0.5}},
+	}
+	base := Weights(profile.DefaultProfile().Priorities)
+
+	suggested := SuggestWeights(labeled, base)
+
+	baseSeparation := Separation(labeled, base)
+	suggestedSeparation := Separation(labeled, suggested)
+	assert.Greater(t, suggestedSeparation, baseSeparation)
+	assert.Greater(t, suggestedSeparation, 0.0, "good ideas should outscore bad ones")
+
+	assert.Greater(t, suggested[profile.DimensionSkillFit], base[profile.DimensionSkillFit])
+	assert.Less(t, suggested[profile.DimensionTimeToDone], base[profile.DimensionTimeToDone])
+
+	sum := 0.0
+	for _, dim := range profile.AllDimensions() {
+		weight := suggested[dim]
+		assert.GreaterOrEqual(t, weight, TuneMinWeight-1e-9, dim)
+		assert.LessOrEqual(t, weight, TuneMaxWeight+1e-9, dim)
+		sum += weight
+	}
+	assert.InDelta(t, 1.0, sum, 0.001)
+
+	// The base map must not be modified
+	assert.Equal(t, 0.15, base[profile.DimensionSkillFit])
+}
+
+func TestSuggestWeights_NeedsBothLabels(t *testing.T) {
+	base := Weights(profile.DefaultProfile().Priorities)
+	onlyGood := []LabeledIdea{
+		{Good: true, Scores: UniversalScores{SkillFit: 2.0}},
+		{Good: true, Scores: UniversalScores{TimeToDone: 2.0}},
+	}
+
+	assert.Equal(t, base, SuggestWeights(onlyGood, base))
+	assert.Equal(t, base, SuggestWeights(nil, base))
+}
+
+func TestWeightedScore_MatchesEngine(t *testing.T) {
+	p := profile.DefaultProfile()
+	engine := NewUniversalEngine(p)
+
+	analysis, err := engine.Score("Build a CLI tool in Go that I can ship this weekend")
+	assert.NoError(t, err)
+
+	assert.InDelta(t, analysis.FinalScore, WeightedScore(analysis.Universal, p.Priorities), 0.001)
+}
@@ -118,6 +118,24 @@ func (e *UniversalEngine) Score(ideaText string) (*UniversalAnalysis, error) {
 	return analysis, nil
 }
 
+// ApplyBounds enforces the profile's score floors and ceilings on an analysis
+// for an idea with the given tags and patterns. Any bound that changes the
+// score is recorded in analysis.Bounds and the recommendation is refreshed.
+func (e *UniversalEngine) ApplyBounds(analysis *UniversalAnalysis, tags, patterns []string) {
+	if analysis == nil || len(e.profile.ScoreBounds) == 0 {
+		return
+	}
+
+	score, applied := ApplyScoreBounds(analysis.FinalScore, tags, patterns, e.profile.ScoreBounds)
+	if len(applied) == 0 {
+		return
+	}
+
+	analysis.FinalScore = score
+	analysis.Bounds = applied
+	analysis.Recommendation = analysis.GetRecommendation()
+}
+
 // scoreCompletion evaluates "Will I actually finish this?"
 func (e *UniversalEngine) scoreCompletion(ideaLower string) float64 {
 	maxScore := 2.0
@@ -296,30 +314,9 @@ const (
 	ScaleMinor = 10.0 // For dimensions with max score 1.0 (sustainability, avoidance)
 )
 
-// applyWeights calculates the weighted total score.
+// applyWeights calculates the weighted total score using the profile's priorities.
 func (e *UniversalEngine) applyWeights(scores *UniversalScores) float64 {
-	total := 0.0
-
-	// Apply user's priority weights with appropriate scale factors
-	// Major dimensions (max raw score 2.0)
-	total += scores.CompletionLikelihood * e.profile.GetPriority(profile.DimensionCompletionLikelihood) * ScaleMajor
-	total += scores.SkillFit * e.profile.GetPriority(profile.DimensionSkillFit) * ScaleMajor
-	total += scores.TimeToDone * e.profile.GetPriority(profile.DimensionTimeToDone) * ScaleMajor
-	total += scores.RewardAlignment * e.profile.GetPriority(profile.DimensionRewardAlignment) * ScaleMajor
-
-	// Minor dimensions (max raw score 1.0)
-	total += scores.Sustainability * e.profile.GetPriority(profile.DimensionSustainability) * ScaleMinor
-	total += scores.AvoidanceFit * e.profile.GetPriority(profile.DimensionAvoidanceFit) * ScaleMinor
-
-	// Clamp to 0-10 range
-	if total > 10.0 {
-		total = 10.0
-	}
-	if total < 0 {
-		total = 0
-	}
-
-	return total
+	return WeightedScore(*scores, e.profile.Priorities)
 }
 
 // generateInsights creates human-readable observations about the scores.
@@ -0,0 +1,225 @@
+package tasks
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// cronMacros are the shorthand specs accepted in place of five fields
+var cronMacros = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+var (
+	monthNames = map[string]int{
+		"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+		"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+	}
+	weekdayNames = map[string]int{
+		"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+	}
+)
+
+// maxSearchYears bounds the search for a spec that can never match, such as
+// February 30th
+const maxSearchYears = 5
+
+// CronSchedule is a parsed standard five-field cron expression:
+// minute, hour, day of month, month and day of week.
+//
+// Times are matched against the wall clock of the location Next is given. A
+// time skipped when clocks spring forward runs once, at the moment of the
+// jump; a time repeated when clocks fall back runs once, at its first
+// occurrence.
+type CronSchedule struct {
+	minute, hour, dom, month, dow uint64
+
+	// Like cron, when both day fields are restricted a day matching either
+	// one matches
+	domRestricted, dowRestricted bool
+}
+
+// ParseCron parses a standard cron expression such as "0 3 * * *" (daily at
+// 3am). Fields accept *, numbers, ranges (1-5), steps (*/15, 0-30/10) and
+// comma-separated lists. Month and day-of-week fields also accept names (JAN,
+// MON), and 7 is Sunday as well as 0. The macros @yearly, @monthly, @weekly,
+// @daily and @hourly are accepted too.
+func ParseCron(spec string) (*CronSchedule, error) {
+	expr := strings.TrimSpace(spec)
+	if macro, ok := cronMacros[strings.ToLower(expr)]; ok {
+		expr = macro
+	}
+
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("invalid cron spec %q: expected 5 fields, got %d", spec, len(fields))
+	}
+
+	s := &CronSchedule{}
+	var err error
+	if s.minute, err = parseCronField(fields[0], 0, 59, nil); err != nil {
+		return nil, fmt.Errorf("invalid cron spec %q: minute: %w", spec, err)
+	}
+	if s.hour, err = parseCronField(fields[1], 0, 23, nil); err != nil {
+		return nil, fmt.Errorf("invalid cron spec %q: hour: %w", spec, err)
+	}
+	if s.dom, err = parseCronField(fields[2], 1, 31, nil); err != nil {
+		return nil, fmt.Errorf("invalid cron spec %q: day of month: %w", spec, err)
+	}
+	if s.month, err = parseCronField(fields[3], 1, 12, monthNames); err != nil {
+		return nil, fmt.Errorf("invalid cron spec %q: month: %w", spec, err)
+	}
+	if s.dow, err = parseCronField(fields[4], 0, 7, weekdayNames); err != nil {
+		return nil, fmt.Errorf("invalid cron spec %q: day of week: %w", spec, err)
+	}
+
+	// 7 is an alias for Sunday
+	if s.dow&(1<<7) != 0 {
+		s.dow = s.dow&^(1<<7) | 1
+	}
+	s.domRestricted = !strings.HasPrefix(fields[2], "*")
+	s.dowRestricted = !strings.HasPrefix(fields[4], "*")
+	return s, nil
+}
+
+// parseCronField returns a bit set of the values a field matches
+func parseCronField(field string, min, max int, names map[string]int) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(field, ",") {
+		rangePart, step := part, 1
+		if i := strings.Index(part, "/"); i >= 0 {
+			n, err := strconv.Atoi(part[i+1:])
+			if err != nil || n < 1 {
+				return 0, fmt.Errorf("invalid step in %q", part)
+			}
+			rangePart, step = part[:i], n
+		}
+
+		lo, hi := min, max
+		switch {
+		case rangePart == "*":
+		case strings.Contains(rangePart, "-"):
+			bounds := strings.SplitN(rangePart, "-", 2)
+			var err error
+			if lo, err = parseCronValue(bounds[0], min, max, names); err != nil {
+				return 0, err
+			}
+			if hi, err = parseCronValue(bounds[1], min, max, names); err != nil {
+				return 0, err
+			}
+			if lo > hi {
+				return 0, fmt.Errorf("invalid range %q", rangePart)
+			}
+		default:
+			v, err := parseCronValue(rangePart, min, max, names)
+			if err != nil {
+				return 0, err
+			}
+			lo = v
+			// A single value with a step runs from the value to the end
+			if step == 1 {
+				hi = v
+			}
+		}
+
+		for v := lo; v <= hi; v += step {
+			bits |= 1 << uint(v)
+		}
+	}
+	return bits, nil
+}
+
+func parseCronValue(s string, min, max int, names map[string]int) (int, error) {
+	if v, ok := names[strings.ToLower(s)]; ok {
+		return v, nil
+	}
+	v, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value %q", s)
+	}
+	if v < min || v > max {
+		return 0, fmt.Errorf("value %d out of range %d-%d", v, min, max)
+	}
+	return v, nil
+}
+
+// Next returns the first time after t that the schedule matches, in t's
+// location, or the zero time if it never matches
+func (s *CronSchedule) Next(t time.Time) time.Time {
+	loc := t.Location()
+
+	// Walk the calendar in UTC, which has no clock changes, and map each
+	// matching wall-clock time back to loc
+	wall := time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), 0, 0, time.UTC).Add(time.Minute)
+	limit := wall.Year() + maxSearchYears
+
+	for {
+		wall = s.nextWall(wall, limit)
+		if wall.IsZero() {
+			return time.Time{}
+		}
+
+		next := time.Date(wall.Year(), wall.Month(), wall.Day(), wall.Hour(), wall.Minute(), 0, 0, loc)
+		if next.Hour() != wall.Hour() || next.Minute() != wall.Minute() {
+			// The wall-clock time was skipped by a spring-forward; run at the
+			// moment the clocks jumped
+			_, next = next.ZoneBounds()
+		}
+		if next.After(t) {
+			return next
+		}
+		wall = wall.Add(time.Minute)
+	}
+}
+
+// nextWall returns the first UTC time at or after wall matching the
+// schedule, or the zero time once the search passes limitYear
+func (s *CronSchedule) nextWall(wall time.Time, limitYear int) time.Time {
+wrap:
+	if wall.Year() > limitYear {
+		return time.Time{}
+	}
+
+	for s.month&(1<<uint(wall.Month())) == 0 {
+		wall = time.Date(wall.Year(), wall.Month()+1, 1, 0, 0, 0, 0, time.UTC)
+		if wall.Month() == time.January {
+			goto wrap
+		}
+	}
+	for !s.dayMatches(wall) {
+		wall = time.Date(wall.Year(), wall.Month(), wall.Day()+1, 0, 0, 0, 0, time.UTC)
+		if wall.Day() == 1 {
+			goto wrap
+		}
+	}
+	for s.hour&(1<<uint(wall.Hour())) == 0 {
+		wall = wall.Truncate(time.Hour).Add(time.Hour)
+		if wall.Hour() == 0 {
+			goto wrap
+		}
+	}
+	for s.minute&(1<<uint(wall.Minute())) == 0 {
+		wall = wall.Add(time.Minute)
+		if wall.Minute() == 0 {
+			goto wrap
+		}
+	}
+	return wall
+}
+
+func (s *CronSchedule) dayMatches(t time.Time) bool {
+	dom := s.dom&(1<<uint(t.Day())) != 0
+	dow := s.dow&(1<<uint(t.Weekday())) != 0
+	if s.domRestricted && s.dowRestricted {
+		return dom || dow
+	}
+	return dom && dow
+}
@@ -0,0 +1,117 @@
+package tasks
+
+import (
+	"testing"
+	"time"
+	_ "time/tzdata" // DST tests need America/New_York wherever they run
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newYork(t *testing.T) *time.Location {
+	t.Helper()
+	loc, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+	return loc
+}
+
+func TestParseCron_RejectsInvalidSpecs(t *testing.T) {
+	specs := []string{
+		"",
+		"* * * *",
+		"* * * * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * 32 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"5-1 * * * *",
+		"*/0 * * * *",
+		"a * * * *",
+		"* * * FOO *",
+		"@every 5m",
+	}
+	for _, spec := range specs {
+		t.Run(spec, func(t *testing.T) {
+			_, err := ParseCron(spec)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestCronSchedule_Next(t *testing.T) {
+	from := time.Date(2025, 6, 10, 14, 7, 30, 0, time.UTC) // a Tuesday
+
+	tests := []struct {
+		spec string
+		want time.Time
+	}{
+		{"* * * * *", time.Date(2025, 6, 10, 14, 8, 0, 0, time.UTC)},
+		{"*/15 * * * *", time.Date(2025, 6, 10, 14, 15, 0, 0, time.UTC)},
+		{"0 3 * * *", time.Date(2025, 6, 11, 3, 0, 0, 0, time.UTC)},
+		{"30 9 * * MON-FRI", time.Date(2025, 6, 11, 9, 30, 0, 0, time.UTC)},
+		{"0 0 * * 7", time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)},
+		{"0 0 1 JAN *", time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{"@monthly", time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 12 29 2 *", time.Date(2028, 2, 29, 12, 0, 0, 0, time.UTC)},
+		// Both day fields restricted: the 13th or any Friday
+		{"0 0 13 * FRI", time.Date(2025, 6, 13, 0, 0, 0, 0, time.UTC)},
+		{"0 0 11 * FRI", time.Date(2025, 6, 11, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			s, err := ParseCron(tt.spec)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, s.Next(from))
+		})
+	}
+}
+
+func TestCronSchedule_NextNeverMatches(t *testing.T) {
+	s, err := ParseCron("0 0 30 2 *")
+	require.NoError(t, err)
+	assert.True(t, s.Next(time.Now()).IsZero())
+}
+
+func TestCronSchedule_NextKeepsWallClockAcrossDST(t *testing.T) {
+	loc := newYork(t)
+	s, err := ParseCron("0 3 * * *")
+	require.NoError(t, err)
+
+	// Clocks spring forward on 2025-03-09 and fall back on 2025-11-02
+	next := s.Next(time.Date(2025, 3, 8, 3, 0, 0, 0, loc))
+	assert.Equal(t, time.Date(2025, 3, 9, 3, 0, 0, 0, loc), next)
+	assert.Equal(t, 23*time.Hour, next.Sub(time.Date(2025, 3, 8, 3, 0, 0, 0, loc)))
+
+	next = s.Next(time.Date(2025, 11, 1, 3, 0, 0, 0, loc))
+	assert.Equal(t, time.Date(2025, 11, 2, 3, 0, 0, 0, loc), next)
+	assert.Equal(t, 25*time.Hour, next.Sub(time.Date(2025, 11, 1, 3, 0, 0, 0, loc)))
+}
+
+func TestCronSchedule_NextRunsSkippedTimeAtSpringForward(t *testing.T) {
+	loc := newYork(t)
+	s, err := ParseCron("30 2 * * *")
+	require.NoError(t, err)
+
+	// 2:30 doesn't exist on 2025-03-09; it runs when clocks jump to 3:00
+	next := s.Next(time.Date(2025, 3, 8, 12, 0, 0, 0, loc))
+	assert.Equal(t, time.Date(2025, 3, 9, 7, 0, 0, 0, time.UTC), next.UTC())
+
+	next = s.Next(next)
+	assert.Equal(t, time.Date(2025, 3, 10, 2, 30, 0, 0, loc), next)
+}
+
+func TestCronSchedule_NextRunsRepeatedTimeOnceAtFallBack(t *testing.T) {
+	loc := newYork(t)
+	s, err := ParseCron("30 * * * *")
+	require.NoError(t, err)
+
+	// 1:30 happens twice on 2025-11-02: at 05:30 and 06:30 UTC
+	first := s.Next(time.Date(2025, 11, 2, 0, 45, 0, 0, loc))
+	assert.Equal(t, time.Date(2025, 11, 2, 5, 30, 0, 0, time.UTC), first.UTC())
+
+	second := s.Next(first)
+	assert.Equal(t, time.Date(2025, 11, 2, 7, 30, 0, 0, time.UTC), second.UTC(), "2:30 EST, skipping the repeated 1:30")
+}
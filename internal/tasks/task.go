@@ -0,0 +1,228 @@
+// Package tasks runs background jobs on a fixed interval or a cron schedule
+// and stops them together on shutdown.
+package tasks
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Task is a named job run repeatedly on a schedule
+type Task struct {
+	name     string
+	schedule string
+	next     func(time.Time) time.Time
+	fn       func(context.Context) error
+
+	retries     int
+	backoff     time.Duration
+	maxFailures int // 0 means a failing task never turns unhealthy
+}
+
+// TaskOption configures how a task handles failed runs
+type TaskOption func(*Task)
+
+// WithRetry retries a failed run up to attempts more times before waiting for
+// the next scheduled run. The first retry waits backoff, and each later one
+// waits twice as long as the one before.
+func WithRetry(attempts int, backoff time.Duration) TaskOption {
+	return func(t *Task) {
+		t.retries = attempts
+		t.backoff = backoff
+	}
+}
+
+// WithMaxConsecutiveFailures marks the task unhealthy once more than n runs
+// in a row have failed, after their retries. A successful run makes it healthy
+// again.
+func WithMaxConsecutiveFailures(n int) TaskOption {
+	return func(t *Task) {
+		t.maxFailures = n
+	}
+}
+
+// NewScheduledTask returns a task that runs fn every interval, measured from
+// the end of the previous run
+func NewScheduledTask(name string, interval time.Duration, fn func(context.Context) error, opts ...TaskOption) *Task {
+	t := &Task{
+		name:     name,
+		schedule: "every " + interval.String(),
+		next:     func(t time.Time) time.Time { return t.Add(interval) },
+		fn:       fn,
+	}
+	for _, opt := range opts {
+		opt(t)
+	}
+	return t
+}
+
+// NewCronTask returns a task that runs fn at the times matched by a standard
+// cron spec, in the local time zone. See ParseCron for the accepted syntax.
+func NewCronTask(name, spec string, fn func(context.Context) error, opts ...TaskOption) (*Task, error) {
+	schedule, err := ParseCron(spec)
+	if err != nil {
+		return nil, err
+	}
+	t := &Task{
+		name:     name,
+		schedule: spec,
+		next:     schedule.Next,
+		fn:       fn,
+	}
+	for _, opt := range opts {
+		opt(t)
+	}
+	return t, nil
+}
+
+// Name returns the task's name
+func (t *Task) Name() string {
+	return t.name
+}
+
+// Next returns when the task next runs after now, or the zero time if never
+func (t *Task) Next(now time.Time) time.Time {
+	return t.next(now)
+}
+
+// run calls the task's function, retrying failures as configured. It returns
+// the last error, or nil once an attempt succeeds.
+func (t *Task) run(ctx context.Context) error {
+	backoff := t.backoff
+	for attempt := 0; ; attempt++ {
+		err := t.fn(ctx)
+		if err == nil || attempt >= t.retries || ctx.Err() != nil {
+			return err
+		}
+
+		log.Debug().Err(err).Str("task", t.name).Int("attempt", attempt+1).Dur("backoff", backoff).
+			Msg("Background task failed; retrying")
+
+		timer := time.NewTimer(backoff)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		}
+		backoff *= 2
+	}
+}
+
+// TaskStatus reports how a spawned task's runs have gone
+type TaskStatus struct {
+	Healthy             bool
+	ConsecutiveFailures int       // Runs in a row that failed after their retries
+	LastRun             time.Time // Zero until the first run finishes
+	LastError           string    // Error of the last run; empty if it succeeded
+	NextRun             time.Time
+}
+
+// TaskManager runs spawned tasks until Shutdown
+type TaskManager struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	mu     sync.Mutex
+	status map[string]TaskStatus
+}
+
+// NewTaskManager returns a manager with no tasks running
+func NewTaskManager() *TaskManager {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &TaskManager{ctx: ctx, cancel: cancel, status: make(map[string]TaskStatus)}
+}
+
+// Spawn starts running task in the background. A run that fails, after any
+// retries, is logged and the task keeps its schedule.
+func (m *TaskManager) Spawn(task *Task) {
+	m.update(task.name, func(s *TaskStatus) { s.Healthy = true })
+
+	m.wg.Add(1)
+	go func() {
+		defer m.wg.Done()
+
+		log.Info().Str("task", task.name).Str("schedule", task.schedule).Msg("Started background task")
+
+		for {
+			next := task.Next(time.Now())
+			if next.IsZero() {
+				log.Warn().Str("task", task.name).Msg("Background task has no future runs; stopping")
+				return
+			}
+			m.update(task.name, func(s *TaskStatus) { s.NextRun = next })
+
+			timer := time.NewTimer(time.Until(next))
+			select {
+			case <-timer.C:
+			case <-m.ctx.Done():
+				timer.Stop()
+				log.Info().Str("task", task.name).Msg("Stopping background task")
+				return
+			}
+
+			err := task.run(m.ctx)
+			if errors.Is(err, context.Canceled) && m.ctx.Err() != nil {
+				continue
+			}
+			m.record(task, err)
+		}
+	}()
+}
+
+// record updates a task's status after a run
+func (m *TaskManager) record(task *Task, err error) {
+	m.update(task.name, func(s *TaskStatus) {
+		s.LastRun = time.Now()
+		if err == nil {
+			if !s.Healthy {
+				log.Info().Str("task", task.name).Msg("Background task recovered")
+			}
+			s.Healthy = true
+			s.ConsecutiveFailures = 0
+			s.LastError = ""
+			return
+		}
+
+		s.ConsecutiveFailures++
+		s.LastError = err.Error()
+		log.Warn().Err(err).Str("task", task.name).Int("consecutive_failures", s.ConsecutiveFailures).
+			Msg("Background task failed")
+
+		if s.Healthy && task.maxFailures > 0 && s.ConsecutiveFailures > task.maxFailures {
+			s.Healthy = false
+			log.Error().Str("task", task.name).Int("consecutive_failures", s.ConsecutiveFailures).
+				Msg("Background task is unhealthy")
+		}
+	})
+}
+
+func (m *TaskManager) update(name string, fn func(*TaskStatus)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	s := m.status[name]
+	fn(&s)
+	m.status[name] = s
+}
+
+// Status returns the status of every spawned task, keyed by name
+func (m *TaskManager) Status() map[string]TaskStatus {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	status := make(map[string]TaskStatus, len(m.status))
+	for name, s := range m.status {
+		status[name] = s
+	}
+	return status
+}
+
+// Shutdown stops every task and waits for runs in progress to return
+func (m *TaskManager) Shutdown() {
+	m.cancel()
+	m.wg.Wait()
+}
@@ -0,0 +1,122 @@
+package tasks
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewCronTask_RejectsInvalidSpec(t *testing.T) {
+	_, err := NewCronTask("cleanup", "0 25 * * *", func(context.Context) error { return nil })
+	assert.Error(t, err)
+}
+
+func TestTaskManager_RunsUntilShutdown(t *testing.T) {
+	var runs atomic.Int32
+	manager := NewTaskManager()
+	manager.Spawn(NewScheduledTask("tick", 10*time.Millisecond, func(context.Context) error {
+		runs.Add(1)
+		return nil
+	}))
+
+	require.Eventually(t, func() bool { return runs.Load() >= 3 }, time.Second, 5*time.Millisecond)
+	manager.Shutdown()
+
+	stopped := runs.Load()
+	time.Sleep(30 * time.Millisecond)
+	assert.Equal(t, stopped, runs.Load(), "no runs after Shutdown")
+}
+
+func TestTaskManager_ShutdownCancelsRunInProgress(t *testing.T) {
+	started := make(chan struct{})
+	manager := NewTaskManager()
+	manager.Spawn(NewScheduledTask("slow", time.Millisecond, func(ctx context.Context) error {
+		close(started)
+		<-ctx.Done()
+		return ctx.Err()
+	}))
+
+	<-started
+	done := make(chan struct{})
+	go func() {
+		manager.Shutdown()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Shutdown did not return")
+	}
+}
+
+func TestTask_RetriesWithinRun(t *testing.T) {
+	calls := 0
+	task := NewScheduledTask("flaky", time.Minute, func(context.Context) error {
+		calls++
+		if calls < 3 {
+			return errors.New("not yet")
+		}
+		return nil
+	}, WithRetry(2, time.Millisecond))
+
+	assert.NoError(t, task.run(context.Background()))
+	assert.Equal(t, 3, calls)
+}
+
+func TestTask_GivesUpAfterRetries(t *testing.T) {
+	calls := 0
+	task := NewScheduledTask("broken", time.Minute, func(context.Context) error {
+		calls++
+		return errors.New("down")
+	}, WithRetry(2, time.Millisecond))
+
+	assert.EqualError(t, task.run(context.Background()), "down")
+	assert.Equal(t, 3, calls, "one try and two retries")
+}
+
+func TestTaskManager_StatusTracksConsecutiveFailures(t *testing.T) {
+	var failing atomic.Bool
+	failing.Store(true)
+	task := NewScheduledTask("health", 5*time.Millisecond, func(context.Context) error {
+		if failing.Load() {
+			return errors.New("database unreachable")
+		}
+		return nil
+	}, WithMaxConsecutiveFailures(2))
+
+	manager := NewTaskManager()
+	defer manager.Shutdown()
+	manager.Spawn(task)
+
+	assert.True(t, manager.Status()["health"].Healthy, "healthy until it has run")
+
+	require.Eventually(t, func() bool { return !manager.Status()["health"].Healthy }, time.Second, time.Millisecond)
+	status := manager.Status()["health"]
+	assert.Greater(t, status.ConsecutiveFailures, 2)
+	assert.Equal(t, "database unreachable", status.LastError)
+
+	failing.Store(false)
+	require.Eventually(t, func() bool { return manager.Status()["health"].Healthy }, time.Second, time.Millisecond)
+	status = manager.Status()["health"]
+	assert.Zero(t, status.ConsecutiveFailures)
+	assert.Empty(t, status.LastError)
+}
+
+func TestTaskManager_FailuresWithoutMaxStayHealthy(t *testing.T) {
+	task := NewScheduledTask("noisy", time.Millisecond, func(context.Context) error {
+		return errors.New("boom")
+	})
+
+	manager := NewTaskManager()
+	defer manager.Shutdown()
+	manager.Spawn(task)
+
+	require.Eventually(t, func() bool { return manager.Status()["noisy"].ConsecutiveFailures >= 5 }, time.Second, time.Millisecond)
+	assert.True(t, manager.Status()["noisy"].Healthy)
+}
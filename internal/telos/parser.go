@@ -51,6 +51,22 @@ func ParseTelosFile(path string) (*models.Telos, error) {
 
 // ParseFile parses a telos.md file and returns a Telos struct.
 func (p *Parser) ParseFile(path string) (*models.Telos, error) {
+	telos, err := p.ParseFileUnvalidated(path)
+	if err != nil {
+		return nil, err
+	}
+
+	// Validate the parsed telos
+	if err := telos.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid telos: %w", err)
+	}
+
+	return telos, nil
+}
+
+// ParseFileUnvalidated parses a telos.md file without validating it, so a
+// broken file can still be inspected with models.ValidateTelos.
+func (p *Parser) ParseFileUnvalidated(path string) (*models.Telos, error) {
 	file, err := os.Open(path)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open file: %w", err)
@@ -113,11 +129,6 @@ func (p *Parser) ParseFile(path string) (*models.Telos, error) {
 		return nil, fmt.Errorf("error reading file: %w", err)
 	}
 
-	// Validate the parsed telos
-	if err := telos.Validate(); err != nil {
-		return nil, fmt.Errorf("invalid telos: %w", err)
-	}
-
 	return telos, nil
 }
 
@@ -232,7 +243,7 @@ func (p *Parser) parsePattern(line string) *models.Pattern {
 	return &models.Pattern{
 		Name:        strings.TrimSpace(matches[1]),
 		Description: strings.TrimSpace(matches[2]),
-		Keywords:    extractKeywords(matches[2]),
+		Keywords:    ExtractKeywords(matches[2]),
 	}
 }
 
@@ -249,9 +260,9 @@ func parseTechList(text string) []string {
 	return result
 }
 
-// extractKeywords extracts meaningful keywords from a description.
+// ExtractKeywords extracts meaningful keywords from a description.
 // Filters out common stopwords and short words.
-func extractKeywords(text string) []string {
+func ExtractKeywords(text string) []string {
 	// Common stopwords to filter out
 	stopWords := map[string]bool{
 		"the": true, "a": true, "an": true, "and": true,
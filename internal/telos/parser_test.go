@@ -149,6 +149,15 @@ func TestParseFile_EmptyFile_ReturnsError(t *testing.T) {
 	assert.Contains(t, err.Error(), "at least one goal is required")
 }
 
+func TestParseFileUnvalidated_EmptyFile_ReturnsEmptyTelos(t *testing.T) {
+	parser := telos.NewParser()
+
+	result, err := parser.ParseFileUnvalidated("testdata/empty.md")
+
+	require.NoError(t, err)
+	assert.Empty(t, result.Goals)
+}
+
 func TestParseFile_SetsLoadedAt(t *testing.T) {
 	parser := telos.NewParser()
 
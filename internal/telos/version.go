@@ -0,0 +1,22 @@
+package telos
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+)
+
+// versionLength is how many hex characters of the content hash form a version
+const versionLength = 12
+
+// Version identifies the contents of a telos file. Ideas record the version
+// they were scored against, so any edit to the file makes them stale.
+func Version(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read telos file: %w", err)
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])[:versionLength], nil
+}
@@ -0,0 +1,32 @@
+package telos_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ryacub/telos-idea-matrix/internal/telos"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVersion_ChangesWithContent(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "telos.md")
+	require.NoError(t, os.WriteFile(path, []byte("## Goals\n- G1: Ship v1\n"), 0644))
+
+	first, err := telos.Version(path)
+	require.NoError(t, err)
+	assert.Len(t, first, 12)
+
+	again, err := telos.Version(path)
+	require.NoError(t, err)
+	assert.Equal(t, first, again, "unchanged file should keep its version")
+
+	require.NoError(t, os.WriteFile(path, []byte("## Goals\n- G1: Ship v2\n"), 0644))
+	changed, err := telos.Version(path)
+	require.NoError(t, err)
+	assert.NotEqual(t, first, changed)
+
+	_, err = telos.Version(filepath.Join(t.TempDir(), "missing.md"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,97 @@
+// Package tracing sets up OpenTelemetry tracing and traces HTTP requests.
+//
+// Tracing is off unless Setup is called: until then the global tracer
+// provider is a no-op, so spans cost next to nothing.
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TracerName is the instrumentation name of the HTTP request spans
+const TracerName = "github.com/ryacub/telos-idea-matrix/internal/tracing"
+
+// Setup installs a global tracer provider that exports spans over OTLP/HTTP
+// and returns it with a function that flushes pending spans and stops it.
+//
+// The exporter reads the standard OTEL_EXPORTER_OTLP_* variables, so
+// OTEL_EXPORTER_OTLP_ENDPOINT points it at a collector (default
+// https://localhost:4318; an http:// endpoint disables TLS). Incoming W3C traceparent headers are honored, so
+// server spans join the caller's trace.
+func Setup(ctx context.Context, serviceName string) (trace.TracerProvider, func(context.Context) error, error) {
+	exporter, err := otlptracehttp.New(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create OTLP exporter: %w", err)
+	}
+
+	// Later options win, so OTEL_SERVICE_NAME overrides serviceName
+	res, err := resource.New(ctx,
+		resource.WithAttributes(semconv.ServiceName(serviceName)),
+		resource.WithTelemetrySDK(),
+		resource.WithFromEnv(),
+	)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to describe tracing resource: %w", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+	)
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	return provider, provider.Shutdown, nil
+}
+
+// Middleware records a server span for each request with the global tracer
+// provider, and stores it in the request context so work done for the
+// request, such as LLM analysis, is recorded as its children. Spans are
+// named by route pattern, e.g. "POST /api/v1/ideas", once routing is done.
+func Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := otel.GetTextMapPropagator().Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+		ctx, span := otel.Tracer(TracerName).Start(ctx, r.Method,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				attribute.String("http.request.method", r.Method),
+				attribute.String("url.path", r.URL.Path),
+			),
+		)
+		defer span.End()
+
+		wrapped := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+		next.ServeHTTP(wrapped, r.WithContext(ctx))
+
+		status := wrapped.Status()
+		if status == 0 {
+			status = http.StatusOK
+		}
+		span.SetAttributes(attribute.Int("http.response.status_code", status))
+		if status >= http.StatusInternalServerError {
+			span.SetStatus(codes.Error, http.StatusText(status))
+		}
+
+		if rctx := chi.RouteContext(r.Context()); rctx != nil && rctx.RoutePattern() != "" {
+			span.SetAttributes(attribute.String("http.route", rctx.RoutePattern()))
+			span.SetName(r.Method + " " + rctx.RoutePattern())
+		}
+	})
+}
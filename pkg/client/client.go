@@ -15,6 +15,7 @@ import (
 	"net/http"
 	"net/url"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/ryacub/telos-idea-matrix/internal/models"
@@ -85,8 +86,8 @@ type IdeaResponse struct {
 
 // ListIdeasResponse represents a paginated list of ideas
 type ListIdeasResponse struct {
-	Ideas  []IdeaResponse `json:"ideas"`
-	Total  int            `json:"total"`
+	Items  []IdeaResponse `json:"items"`
+	Total  int            `json:"total"` // Ideas matching the filters, across all pages
 	Limit  int            `json:"limit"`
 	Offset int            `json:"offset"`
 }
@@ -173,11 +174,14 @@ func (c *Client) ListIdeas(ctx context.Context, opts *ListOptions) (*ListIdeasRe
 		if opts.Status != "" {
 			query.Set("status", opts.Status)
 		}
-		if opts.SortBy != "" {
-			query.Set("sort", opts.SortBy)
+		if opts.MinScore != nil {
+			query.Set("min_score", strconv.FormatFloat(*opts.MinScore, 'f', -1, 64))
 		}
-		if opts.Order != "" {
-			query.Set("order", opts.Order)
+		if opts.MaxScore != nil {
+			query.Set("max_score", strconv.FormatFloat(*opts.MaxScore, 'f', -1, 64))
+		}
+		if orderBy := opts.orderBy(); orderBy != "" {
+			query.Set("order_by", orderBy)
 		}
 	}
 
@@ -227,11 +231,28 @@ func (c *Client) GetAnalyticsStats(ctx context.Context) (*StatsResponse, error)
 
 // ListOptions contains options for listing ideas
 type ListOptions struct {
-	Limit  int
-	Offset int
-	Status string // Filter by status: "active", "completed", "archived"
-	SortBy string // Sort by field: "score", "created_at", "updated_at"
-	Order  string // Sort order: "asc", "desc"
+	Limit    int
+	Offset   int
+	Status   string   // Filter by status: "active", "completed", "archived"
+	MinScore *float64 // Filter by minimum final score
+	MaxScore *float64 // Filter by maximum final score
+	SortBy   string   // Sort by field: "score", "raw_score", "created_at"
+	Order    string   // Sort order: "asc", "desc"
+}
+
+// orderBy returns the order_by query value for the options, or "" for the server default
+func (o *ListOptions) orderBy() string {
+	field := o.SortBy
+	switch field {
+	case "":
+		return ""
+	case "score":
+		field = "final_score"
+	}
+	if strings.EqualFold(o.Order, "desc") {
+		return "-" + field
+	}
+	return field
 }
 
 // ============================================================================
@@ -163,11 +163,12 @@ func TestClientListIdeas(t *testing.T) {
 		assert.Equal(t, "10", query.Get("limit"))
 		// Offset 0 is not included in query params (it's the default)
 		assert.Equal(t, "active", query.Get("status"))
-		assert.Equal(t, "score", query.Get("sort"))
-		assert.Equal(t, "desc", query.Get("order"))
+		assert.Equal(t, "-final_score", query.Get("order_by"))
+		assert.Equal(t, "7.5", query.Get("min_score"))
+		assert.Empty(t, query.Get("max_score"))
 
 		response := ListIdeasResponse{
-			Ideas: []IdeaResponse{
+			Items: []IdeaResponse{
 				{ID: "1", Content: "idea 1", RawScore: 9.0, Status: "active"},
 				{ID: "2", Content: "idea 2", RawScore: 8.5, Status: "active"},
 			},
@@ -181,19 +182,21 @@ func TestClientListIdeas(t *testing.T) {
 	defer server.Close()
 
 	client := NewClient(server.URL)
+	minScore := 7.5
 	opts := &ListOptions{
-		Limit:  10,
-		Offset: 0,
-		Status: "active",
-		SortBy: "score",
-		Order:  "desc",
+		Limit:    10,
+		Offset:   0,
+		Status:   "active",
+		MinScore: &minScore,
+		SortBy:   "score",
+		Order:    "desc",
 	}
 	response, err := client.ListIdeas(context.Background(), opts)
 
 	require.NoError(t, err)
 	assert.Equal(t, 2, response.Total)
-	assert.Len(t, response.Ideas, 2)
-	assert.Equal(t, "idea 1", response.Ideas[0].Content)
+	assert.Len(t, response.Items, 2)
+	assert.Equal(t, "idea 1", response.Items[0].Content)
 }
 
 func TestClientListIdeasNoOptions(t *testing.T) {
@@ -202,7 +205,7 @@ func TestClientListIdeasNoOptions(t *testing.T) {
 		assert.Empty(t, r.URL.RawQuery)
 
 		response := ListIdeasResponse{
-			Ideas:  []IdeaResponse{},
+			Items:  []IdeaResponse{},
 			Total:  0,
 			Limit:  100,
 			Offset: 0,
@@ -40,7 +40,7 @@ func TestEndToEndWorkflow(t *testing.T) {
 - Over-engineering
 - Technical debt accumulation
 `
-	ts, _ := setupTestServer(t, &testServerConfig{telosContent: telosContent})
+	ts, repo := setupTestServer(t, &testServerConfig{telosContent: telosContent})
 
 	// Test 1: Create an idea via API
 	t.Run("CreateIdea", func(t *testing.T) {
@@ -100,8 +100,8 @@ func TestEndToEndWorkflow(t *testing.T) {
 		err = json.NewDecoder(resp.Body).Decode(&listResp)
 		require.NoError(t, err)
 
-		assert.Greater(t, len(listResp.Ideas), 0)
-		assert.Equal(t, len(listResp.Ideas), listResp.Total)
+		assert.Greater(t, len(listResp.Items), 0)
+		assert.Equal(t, len(listResp.Items), listResp.Total)
 	})
 
 	// Test 4: Get specific idea
@@ -207,12 +207,25 @@ func TestEndToEndWorkflow(t *testing.T) {
 
 		assert.Equal(t, http.StatusNoContent, deleteResp.StatusCode)
 
+		// Verify it's in the trash
+		trashed, err := repo.GetByID(idea.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "deleted", trashed.Status)
+
+		// Delete it for good
+		req, err = http.NewRequest(http.MethodDelete, ts.URL+"/api/v1/ideas/"+idea.ID+"?permanent=true", nil)
+		require.NoError(t, err)
+		permanentResp, err := client.Do(req)
+		require.NoError(t, err)
+		defer permanentResp.Body.Close()
+		assert.Equal(t, http.StatusNoContent, permanentResp.StatusCode)
+
 		// Verify it's gone
-		getResp, err := http.Get(ts.URL + "/api/v1/ideas/" + idea.ID)
+		goneResp, err := http.Get(ts.URL + "/api/v1/ideas/" + idea.ID)
 		require.NoError(t, err)
-		defer getResp.Body.Close()
+		defer goneResp.Body.Close()
 
-		assert.Equal(t, http.StatusNotFound, getResp.StatusCode)
+		assert.Equal(t, http.StatusNotFound, goneResp.StatusCode)
 	})
 
 	// Test 7: Analytics stats
@@ -286,7 +299,7 @@ func TestConcurrentAccess(t *testing.T) {
 		err = json.NewDecoder(resp.Body).Decode(&listResp)
 		require.NoError(t, err)
 
-		assert.Equal(t, numGoroutines, len(listResp.Ideas))
+		assert.Equal(t, numGoroutines, len(listResp.Items))
 	})
 
 	// Test concurrent reads and writes
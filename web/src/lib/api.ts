@@ -62,13 +62,21 @@ export const api = {
 	ideas: {
 		list: async (params?: {
 			status?: string;
+			min_score?: number;
+			max_score?: number;
 			limit?: number;
 			offset?: number;
+			order_by?: string;
 		}): Promise<ListIdeasResponse> => {
 			const searchParams = new URLSearchParams();
 			if (params?.status) searchParams.append('status', params.status);
+			if (params?.min_score !== undefined)
+				searchParams.append('min_score', params.min_score.toString());
+			if (params?.max_score !== undefined)
+				searchParams.append('max_score', params.max_score.toString());
 			if (params?.limit) searchParams.append('limit', params.limit.toString());
 			if (params?.offset) searchParams.append('offset', params.offset.toString());
+			if (params?.order_by) searchParams.append('order_by', params.order_by);
 
 			const query = searchParams.toString();
 			return fetchAPI(`/api/v1/ideas${query ? `?${query}` : ''}`);
@@ -12,7 +12,8 @@ describe('IdeaCard', () => {
 		patterns: ['context-switching'],
 		recommendation: 'This is a strong idea',
 		created_at: '2025-01-01T00:00:00Z',
-		status: 'active'
+		status: 'active',
+		version: 1
 	};
 
 	it('renders idea content correctly', () => {
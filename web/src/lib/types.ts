@@ -31,10 +31,11 @@ export interface Idea {
 	created_at: string;
 	reviewed_at?: string;
 	status: 'active' | 'archived' | 'completed';
+	version: number;
 }
 
 export interface ListIdeasResponse {
-	ideas: Idea[];
+	items: Idea[];
 	total: number;
 	limit: number;
 	offset: number;
@@ -46,6 +47,10 @@ export interface AnalyticsStats {
 	average_score: number;
 	high_score: number;
 	low_score: number;
+	median_score: number;
+	score_buckets: Record<string, number>;
+	top_patterns: { pattern: string; count: number }[];
+	refreshed_at: string | null;
 }
 
 export interface CreateIdeaRequest {
@@ -55,6 +60,8 @@ export interface CreateIdeaRequest {
 export interface UpdateIdeaRequest {
 	content?: string;
 	status?: string;
+	// Version last read; the update fails with 409 if the idea changed since
+	version?: number;
 }
 
 export interface AnalyzeRequest {